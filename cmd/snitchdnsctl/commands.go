@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// dispatch runs a command and returns what to print, nil for nothing
+func dispatch(ctx context.Context, c snitchdns.ClientInterface, args []string, stderr io.Writer) (any, error) {
+	command, args := args[0], args[1:]
+	switch command {
+	case "status":
+		return c.GetServerInfo(ctx)
+	case "quota":
+		return c.GetQuota(ctx)
+	case "zones":
+		return zonesCommand(ctx, c, args, stderr)
+	case "records":
+		return recordsCommand(ctx, c, args, stderr)
+	case "query":
+		return queryCommand(ctx, c, args, stderr)
+	}
+	return nil, usageError(fmt.Sprintf("unknown command %q", command))
+}
+
+// zonesCommand runs the zones subcommands
+func zonesCommand(ctx context.Context, c snitchdns.ClientInterface, args []string, stderr io.Writer) (any, error) {
+	if len(args) == 0 {
+		return nil, usageError("zones needs a subcommand: list, get, create or delete")
+	}
+
+	subcommand, args := args[0], args[1:]
+	switch subcommand {
+	case "list":
+		return c.ListZonesWithContext(ctx)
+	case "get":
+		if len(args) != 1 {
+			return nil, usageError("zones get needs a zone ID or domain")
+		}
+		if _, err := strconv.Atoi(args[0]); err != nil {
+			return snitchdns.FindZoneByDomain(ctx, c, args[0])
+		}
+		return c.GetZoneWithContext(ctx, args[0])
+	case "create":
+		flags := newFlagSet("zones create <domain>", stderr)
+		inactive := flags.Bool("inactive", false, "create the zone disabled")
+		catchAll := flags.Bool("catch-all", false, "answer queries for any subdomain")
+		forwarding := flags.Bool("forwarding", false, "forward unmatched queries upstream")
+		regex := flags.Bool("regex", false, "treat the domain as a regular expression")
+		tags := flags.String("tags", "", "comma separated tags")
+		userID := flags.Int("user-id", 0, "create the zone for another user, with an admin key")
+		if err := parseFlags(flags, args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() != 1 {
+			return nil, usageError("zones create needs a domain")
+		}
+		var zoneTags snitchdns.ZoneTags
+		if *tags != "" {
+			zoneTags = snitchdns.NewZoneTags(strings.Split(*tags, ","))
+		}
+		return c.CreateZoneWithContext(ctx, snitchdns.CreateZoneRequest{
+			Domain:     flags.Arg(0),
+			Active:     !*inactive,
+			CatchAll:   *catchAll,
+			Forwarding: *forwarding,
+			Regex:      *regex,
+			Tags:       zoneTags,
+			UserID:     *userID,
+		})
+	case "delete":
+		if len(args) != 1 {
+			return nil, usageError("zones delete needs a zone ID")
+		}
+		return nil, c.DeleteZoneWithContext(ctx, args[0])
+	}
+	return nil, usageError(fmt.Sprintf("unknown zones subcommand %q", subcommand))
+}
+
+// recordsCommand runs the records subcommands
+func recordsCommand(ctx context.Context, c snitchdns.ClientInterface, args []string, stderr io.Writer) (any, error) {
+	if len(args) == 0 {
+		return nil, usageError("records needs a subcommand: list, get, create or delete")
+	}
+
+	subcommand, args := args[0], args[1:]
+	switch subcommand {
+	case "list":
+		if len(args) != 1 {
+			return nil, usageError("records list needs a zone ID")
+		}
+		return c.ListRecordsWithContext(ctx, args[0])
+	case "get":
+		if len(args) != 2 {
+			return nil, usageError("records get needs a zone ID and a record ID")
+		}
+		return c.GetRecordWithContext(ctx, args[0], args[1])
+	case "create":
+		flags := newFlagSet("records create <zone>", stderr)
+		recordType := flags.String("type", "A", "record type")
+		class := flags.String("class", "IN", "DNS class")
+		ttl := flags.Int("ttl", 300, "TTL in seconds")
+		data := flags.String("data", "", `record data as a JSON object, such as {"address": "10.0.0.1"}`)
+		inactive := flags.Bool("inactive", false, "create the record disabled")
+		if err := parseFlags(flags, args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() != 1 || *data == "" {
+			return nil, usageError("records create needs a zone ID and -data")
+		}
+		var recordData map[string]any
+		if err := json.Unmarshal([]byte(*data), &recordData); err != nil {
+			return nil, usageError(fmt.Sprintf("-data must be a JSON object: %s", err))
+		}
+		return c.CreateRecordWithContext(ctx, flags.Arg(0), snitchdns.CreateRecordRequest{
+			Active: !*inactive,
+			Class:  *class,
+			Type:   strings.ToUpper(*recordType),
+			TTL:    *ttl,
+			Data:   recordData,
+		})
+	case "delete":
+		if len(args) != 2 {
+			return nil, usageError("records delete needs a zone ID and a record ID")
+		}
+		return nil, c.DeleteRecordWithContext(ctx, args[0], args[1])
+	}
+	return nil, usageError(fmt.Sprintf("unknown records subcommand %q", subcommand))
+}
+
+// queryCommand searches the query log
+func queryCommand(ctx context.Context, c snitchdns.ClientInterface, args []string, stderr io.Writer) (any, error) {
+	flags := newFlagSet("query", stderr)
+	var params snitchdns.SearchParams
+	flags.StringVar(&params.Domain, "domain", "", "queried name")
+	flags.StringVar(&params.SourceIP, "source-ip", "", "address of the resolver that sent the query")
+	flags.StringVar(&params.Type, "type", "", "query type, such as A")
+	flags.IntVar(&params.Page, "page", 1, "page of results")
+	flags.IntVar(&params.PerPage, "per-page", 50, "results per page")
+	from := flags.String("from", "", "earliest time logged, in RFC 3339 format")
+	to := flags.String("to", "", "latest time logged, in RFC 3339 format")
+	if err := parseFlags(flags, args); err != nil {
+		return nil, err
+	}
+
+	for _, bound := range []struct {
+		name  string
+		value string
+		time  *time.Time
+	}{{"from", *from, &params.From}, {"to", *to, &params.To}} {
+		if bound.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return nil, usageError(fmt.Sprintf("-%s must be an RFC 3339 time: %s", bound.name, err))
+		}
+		*bound.time = parsed
+	}
+	return c.SearchLogs(ctx, params)
+}
+
+// errInvalidFlags is returned for flags a subcommand rejected, after its
+// usage was printed
+var errInvalidFlags = errors.New("invalid flags")
+
+// parseFlags parses the flags of a subcommand, returning flag.ErrHelp when
+// its usage was asked for
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	err := flags.Parse(args)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return errInvalidFlags
+}
+
+// newFlagSet returns the flag set of a subcommand, reporting errors to
+// stderr
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: snitchdnsctl %s\n\nFlags:\n", name)
+		flags.PrintDefaults()
+	}
+	return flags
+}
@@ -0,0 +1,142 @@
+// Command snitchdnsctl calls the SnitchDNS API with the client the provider
+// uses, printing the responses as JSON. Operators use it to reproduce the
+// provider's API calls outside Terraform, telling a provider bug from a
+// server one.
+//
+// Usage:
+//
+//	snitchdnsctl [flags] <command> [arguments]
+//
+// The API URL and key are read from SNITCHDNS_API_URL and SNITCHDNS_API_KEY,
+// or from the -api-url and -api-key flags. Run snitchdnsctl -h for the
+// commands.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// version is set at build time
+var version = "dev"
+
+// usage lists the commands
+const usage = `Usage: snitchdnsctl [flags] <command> [arguments]
+
+Commands:
+  status                            show the server version
+  quota                             show the zone quota of the API key's user
+  zones list                        list all zones
+  zones get <zone>                  show a zone by ID or domain
+  zones create [flags] <domain>     create a zone
+  zones delete <zone>               delete a zone
+  records list <zone>               list the records of a zone
+  records get <zone> <record>       show a record
+  records create [flags] <zone>     create a record
+  records delete <zone> <record>    delete a record
+  query [flags]                     search the query log
+
+Run a command with -h for its flags.
+
+Flags:
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run executes the command line args and returns the exit code: 0 on
+// success, 1 when the API call failed and 2 for usage errors
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("snitchdnsctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	apiURL := flags.String("api-url", os.Getenv("SNITCHDNS_API_URL"), "SnitchDNS API URL, including the API path such as /api/v1")
+	apiKey := flags.String("api-key", os.Getenv("SNITCHDNS_API_KEY"), "SnitchDNS API key")
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout of a single request")
+	maxRetries := flags.Int("max-retries", 3, "number of times a failed request is retried")
+	debug := flags.Bool("debug", false, "log every request attempt, with secrets redacted, to stderr")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+	if *apiURL == "" || *apiKey == "" {
+		fmt.Fprintln(stderr, "snitchdnsctl: set -api-url and -api-key, or SNITCHDNS_API_URL and SNITCHDNS_API_KEY")
+		return 2
+	}
+
+	client := snitchdns.NewBackend(snitchdns.NewClient(*apiURL, *apiKey,
+		snitchdns.WithUserAgent("snitchdnsctl/"+version),
+		snitchdns.WithTimeout(*timeout),
+		snitchdns.WithRetry(*maxRetries, time.Second, 30*time.Second),
+		snitchdns.WithDebugLogging(*debug),
+		snitchdns.WithLogger(debugLogger(stderr)),
+	))
+
+	result, err := dispatch(ctx, client, flags.Args(), stderr)
+	var usageErr usageError
+	switch {
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "snitchdnsctl: %s\n\n", usageErr)
+		flags.Usage()
+		return 2
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errInvalidFlags):
+		return 2
+	case err != nil:
+		fmt.Fprintf(stderr, "snitchdnsctl: %s\n", err)
+		return 1
+	}
+
+	if result == nil {
+		return 0
+	}
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		fmt.Fprintf(stderr, "snitchdnsctl: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// usageError is a command line that names no command, or one without its
+// arguments
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// debugLogger writes the client's debug log entries to w, one JSON object
+// per line
+func debugLogger(w io.Writer) snitchdns.Logger {
+	encoder := json.NewEncoder(w)
+	return snitchdns.LoggerFunc(func(_ context.Context, msg string, fields map[string]any) {
+		entry := map[string]any{"msg": msg}
+		for key, value := range fields {
+			entry[key] = value
+		}
+		_ = encoder.Encode(entry)
+	})
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/fakeserver"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// runCommand runs snitchdnsctl against a server and returns its exit code
+// and output
+func runCommand(t *testing.T, s *fakeserver.Server, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-api-url", s.Endpoint(), "-api-key", fakeserver.APIKey, "-max-retries", "0"}, args...)
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// TestRun_ZonesAndRecords tests the zones and records commands against the
+// fake server
+func TestRun_ZonesAndRecords(t *testing.T) {
+	s := fakeserver.New()
+	defer s.Close()
+
+	code, out, errOut := runCommand(t, s, "zones", "create", "-tags", "ci,debug", "example.com")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, errOut)
+	}
+	var zone snitchdns.Zone
+	if err := json.Unmarshal([]byte(out), &zone); err != nil {
+		t.Fatalf("Expected a zone as JSON, got %q: %v", out, err)
+	}
+	if zone.Domain != "example.com" || !zone.Active || zone.Tags.String() != "ci,debug" {
+		t.Errorf("Unexpected zone: %+v", zone)
+	}
+	zoneID := strconv.Itoa(zone.ID)
+
+	code, out, _ = runCommand(t, s, "zones", "list")
+	var zones []snitchdns.Zone
+	if code != 0 || json.Unmarshal([]byte(out), &zones) != nil || len(zones) != 1 {
+		t.Errorf("Expected one zone, got exit code %d and %q", code, out)
+	}
+
+	code, out, _ = runCommand(t, s, "zones", "get", "Example.com.")
+	if code != 0 || !strings.Contains(out, `"id": `+zoneID) {
+		t.Errorf("Expected the zone found by domain, got exit code %d and %q", code, out)
+	}
+
+	code, out, errOut = runCommand(t, s, "records", "create", "-type", "a", "-data", `{"address": "10.0.0.1"}`, zoneID)
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, errOut)
+	}
+	var record snitchdns.Record
+	if err := json.Unmarshal([]byte(out), &record); err != nil || record.Type != "A" {
+		t.Errorf("Expected an A record, got %q: %v", out, err)
+	}
+
+	code, out, _ = runCommand(t, s, "records", "get", zoneID, strconv.Itoa(record.ID))
+	if code != 0 || !strings.Contains(out, "10.0.0.1") {
+		t.Errorf("Expected the record, got exit code %d and %q", code, out)
+	}
+
+	code, out, _ = runCommand(t, s, "records", "delete", zoneID, strconv.Itoa(record.ID))
+	if code != 0 || out != "" {
+		t.Errorf("Expected no output, got exit code %d and %q", code, out)
+	}
+	if code, _, _ = runCommand(t, s, "zones", "delete", zoneID); code != 0 {
+		t.Errorf("Expected exit code 0, got %d", code)
+	}
+
+	code, _, errOut = runCommand(t, s, "zones", "get", zoneID)
+	if code != 1 || !strings.Contains(errOut, "snitchdnsctl:") {
+		t.Errorf("Expected exit code 1 with the error, got %d and %q", code, errOut)
+	}
+}
+
+// TestRun_Usage tests the exit codes of command lines that cannot be run
+func TestRun_Usage(t *testing.T) {
+	s := fakeserver.New()
+	defer s.Close()
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"status", []string{"status"}, 0},
+		{"help", []string{"zones", "create", "-h"}, 0},
+		{"unknown command", []string{"dump"}, 2},
+		{"missing subcommand", []string{"zones"}, 2},
+		{"missing argument", []string{"records", "get", "1"}, 2},
+		{"unknown flag", []string{"zones", "create", "-nope", "example.com"}, 2},
+		{"malformed data", []string{"records", "create", "-data", "[1]", "1"}, 2},
+		{"malformed time", []string{"query", "-from", "yesterday"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, _, errOut := runCommand(t, s, tt.args...); code != tt.code {
+				t.Errorf("Expected exit code %d, got %d: %s", tt.code, code, errOut)
+			}
+		})
+	}
+
+	var stderr bytes.Buffer
+	if code := run(context.Background(), []string{"-api-url", "", "status"}, &bytes.Buffer{}, &stderr); code != 2 {
+		t.Errorf("Expected exit code 2 without an API URL, got %d", code)
+	}
+}
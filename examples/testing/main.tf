@@ -0,0 +1,44 @@
+terraform {
+  required_providers {
+    snitchdns = {
+      source  = "EinDev/snitchdns"
+      version = "~> 1.0"
+    }
+  }
+}
+
+variable "domain" {
+  type        = string
+  description = "Domain of the canary zone"
+}
+
+variable "canaries" {
+  type        = map(string)
+  description = "Canary host names mapped to the address they resolve to"
+}
+
+resource "snitchdns_zone" "canary" {
+  domain = var.domain
+  active = true
+  regex  = false
+  tags   = ["canary"]
+}
+
+resource "snitchdns_record" "canary" {
+  for_each = var.canaries
+
+  zone_id = snitchdns_zone.canary.id
+  type    = "A"
+
+  data = {
+    address = each.value
+  }
+}
+
+output "zone_id" {
+  value = snitchdns_zone.canary.id
+}
+
+output "record_ids" {
+  value = { for name, record in snitchdns_record.canary : name => record.id }
+}
@@ -0,0 +1,366 @@
+# Mock data for the SnitchDNS provider, for use with terraform test:
+#
+#   mock_provider "snitchdns" {
+#     source = "./mocks"
+#   }
+#
+# Terraform generates random values for computed attributes of mocked
+# resources. The defaults below replace them with the values a SnitchDNS
+# server would return for a freshly created object, so plans and assertions
+# are deterministic. IDs are numeric strings like the server's. Attributes
+# whose value depends on other objects, such as the matches name of a
+# wildcard record, get a fixed placeholder; override them per test where
+# an assertion depends on them.
+
+mock_resource "snitchdns_zone" {
+  defaults = {
+    id            = "1"
+    user_id       = 1
+    catch_all     = false
+    forwarding    = false
+    master        = false
+    created_at    = "2024-01-01T00:00:00Z"
+    updated_at    = "2024-01-01T00:00:00Z"
+    record_count  = 0
+    total_hits    = 0
+    last_activity = ""
+  }
+}
+
+mock_resource "snitchdns_record" {
+  defaults = {
+    id                = "1"
+    active            = true
+    ttl               = 300
+    data              = {}
+    is_conditional    = false
+    conditional_count = 0
+    conditional_limit = 0
+    conditional_reset = false
+    conditional_data  = {}
+    on_destroy        = "delete"
+  }
+}
+
+mock_resource "snitchdns_wildcard_record" {
+  defaults = {
+    id      = "1"
+    cls     = "IN"
+    ttl     = 300
+    matches = "*.example.com"
+  }
+}
+
+mock_resource "snitchdns_ptr_record" {
+  defaults = {
+    id           = "1"
+    ttl          = 300
+    zone_id      = "1"
+    name         = "10.2.0.192.in-addr.arpa"
+    zone_managed = true
+  }
+}
+
+mock_resource "snitchdns_canary_zone" {
+  defaults = {
+    id        = "1"
+    sinkhole  = "0.0.0.0"
+    ttl       = 300
+    active    = true
+    record_id = "1"
+  }
+}
+
+mock_resource "snitchdns_subzone" {
+  defaults = {
+    id         = "2"
+    domain     = "token.canary.example.com"
+    active     = true
+    forwarding = false
+    catch_all  = false
+    tags       = []
+  }
+}
+
+mock_resource "snitchdns_zone_group" {
+  defaults = {
+    id        = "engagement"
+    exclusive = false
+    domains   = {}
+  }
+}
+
+mock_resource "snitchdns_zone_capture" {
+  defaults = {
+    mode               = "catch_all"
+    answers_unmatched  = true
+    forwards_unmatched = false
+  }
+}
+
+mock_resource "snitchdns_records_csv" {
+  defaults = {
+    id           = "1"
+    record_count = 0
+  }
+}
+
+mock_resource "snitchdns_record_set" {
+  defaults = {
+    id = "1"
+  }
+}
+
+mock_resource "snitchdns_zone_batch" {
+  defaults = {
+    id         = "1"
+    active     = true
+    catch_all  = false
+    forwarding = false
+    regex      = false
+    zone_ids   = {}
+  }
+}
+
+mock_resource "snitchdns_zone_file" {
+  defaults = {
+    id           = "1"
+    origin       = "example.com"
+    record_count = 0
+    skipped      = []
+  }
+}
+
+mock_resource "snitchdns_notification_recipients" {
+  defaults = {
+    id = "1"
+  }
+}
+
+mock_resource "snitchdns_notification" {
+  defaults = {
+    id      = "1:email"
+    enabled = true
+  }
+}
+
+mock_resource "snitchdns_unmatched_query_logging" {
+  defaults = {
+    id = "unmatched_query_logging"
+  }
+}
+
+mock_resource "snitchdns_log_forwarding" {
+  defaults = {
+    id           = "log_forwarding"
+    matched_only = false
+  }
+}
+
+mock_resource "snitchdns_global_restrictions" {
+  defaults = {
+    id = "global_restrictions"
+  }
+}
+
+mock_resource "snitchdns_forwarding_settings" {
+  defaults = {
+    id      = "forwarding_settings"
+    servers = []
+  }
+}
+
+mock_resource "snitchdns_dns_settings" {
+  defaults = {
+    id            = "dns_settings"
+    bind_ip       = "0.0.0.0"
+    bind_port     = 53
+    intercept_all = false
+  }
+}
+
+mock_resource "snitchdns_interception_settings" {
+  defaults = {
+    id = "interception_settings"
+  }
+}
+
+mock_resource "snitchdns_zone_restriction" {
+  defaults = {
+    id      = "1"
+    enabled = true
+  }
+}
+
+mock_resource "snitchdns_api_key" {
+  defaults = {
+    id         = "1"
+    enabled    = true
+    user_id    = 1
+    key        = "mock-api-key"
+    created_at = "2024-01-01T00:00:00Z"
+  }
+}
+
+mock_resource "snitchdns_alias" {
+  defaults = {
+    id = "1"
+  }
+}
+
+mock_data "snitchdns_zone_lookup" {
+  defaults = {
+    zones   = {}
+    ids     = {}
+    missing = []
+  }
+}
+
+mock_data "snitchdns_zone_match" {
+  defaults = {
+    found       = false
+    match       = ""
+    zone_id     = ""
+    zone_domain = ""
+    record_id   = ""
+    record_data = {}
+    forwarded   = false
+  }
+}
+
+mock_data "snitchdns_zone" {
+  defaults = {
+    id         = "1"
+    user_id    = 1
+    active     = true
+    catch_all  = false
+    forwarding = false
+    regex      = false
+    master     = false
+    tags       = []
+  }
+}
+
+mock_data "snitchdns_zones" {
+  defaults = {
+    total = 0
+    zones = []
+    ids   = []
+  }
+}
+
+mock_data "snitchdns_record" {
+  defaults = {
+    id                = "1"
+    type              = "A"
+    active            = true
+    cls               = "IN"
+    ttl               = 300
+    data              = {}
+    is_conditional    = false
+    conditional_count = 0
+    conditional_limit = 0
+    conditional_reset = false
+    conditional_data  = {}
+  }
+}
+
+mock_data "snitchdns_records" {
+  defaults = {
+    records = []
+    ids     = []
+  }
+}
+
+mock_data "snitchdns_zone_transfer" {
+  defaults = {
+    records       = []
+    skipped_types = []
+  }
+}
+
+mock_data "snitchdns_zone_export" {
+  defaults = {
+    origin       = "example.com."
+    content      = "$ORIGIN example.com.\n"
+    record_count = 0
+  }
+}
+
+mock_data "snitchdns_dns_lookup" {
+  defaults = {
+    rcode         = "NOERROR"
+    authoritative = true
+    answers       = []
+  }
+}
+
+mock_data "snitchdns_import_config" {
+  defaults = {
+    import_blocks = ""
+    config        = ""
+    zone_count    = 0
+    record_count  = 0
+  }
+}
+
+mock_data "snitchdns_wait_for_hit" {
+  defaults = {
+    hit_count = 0
+    hits      = []
+  }
+}
+
+mock_data "snitchdns_query_log" {
+  defaults = {
+    count         = 0
+    queries       = []
+    export_sha256 = ""
+  }
+}
+
+mock_data "snitchdns_zone_stats" {
+  defaults = {
+    record_count    = 0
+    total_hits      = 0
+    last_activity   = ""
+    since           = "2024-01-01T00:00:00Z"
+    query_count     = 0
+    queries_by_type = {}
+    queries_by_day  = {}
+  }
+}
+
+mock_data "snitchdns_notification_providers" {
+  defaults = {
+    names     = []
+    providers = []
+  }
+}
+
+mock_data "snitchdns_server_capabilities" {
+  defaults = {
+    version                = "1.4.0"
+    supports_conditional   = true
+    supports_regex_zones   = true
+    supports_zone_tags     = true
+    supports_notifications = true
+    record_types           = ["A", "AAAA", "CNAME", "MX", "NS", "PTR", "SRV", "TXT"]
+    forwarding_enabled     = false
+    intercept_all          = false
+  }
+}
+
+mock_data "snitchdns_search" {
+  defaults = {
+    count   = 0
+    results = []
+    summary = {
+      matched    = 0
+      forwarded  = 0
+      blocked    = 0
+      domains    = {}
+      source_ips = {}
+    }
+  }
+}
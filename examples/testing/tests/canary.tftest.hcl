@@ -0,0 +1,49 @@
+# Unit tests for the canary module. No SnitchDNS server is needed: the
+# provider is mocked with the defaults shipped in ../mocks.
+
+mock_provider "snitchdns" {
+  source = "./mocks"
+}
+
+variables {
+  domain = "canary.example.com"
+  canaries = {
+    web  = "192.0.2.10"
+    mail = "192.0.2.20"
+  }
+}
+
+run "creates_one_record_per_canary" {
+  command = apply
+
+  assert {
+    condition     = length(snitchdns_record.canary) == 2
+    error_message = "Expected a record for every canary"
+  }
+
+  assert {
+    condition     = alltrue([for record in snitchdns_record.canary : record.zone_id == snitchdns_zone.canary.id])
+    error_message = "Expected every record to belong to the canary zone"
+  }
+
+  assert {
+    condition     = output.zone_id == "1"
+    error_message = "Expected the mocked zone ID"
+  }
+}
+
+run "zone_id_can_be_overridden" {
+  command = apply
+
+  override_resource {
+    target = snitchdns_zone.canary
+    values = {
+      id = "42"
+    }
+  }
+
+  assert {
+    condition     = snitchdns_record.canary["web"].zone_id == "42"
+    error_message = "Expected records to use the overridden zone ID"
+  }
+}
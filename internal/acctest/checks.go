@@ -0,0 +1,123 @@
+package acctest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// checkTimeout bounds the API calls and DNS queries of a check
+const checkTimeout = 10 * time.Second
+
+// Client returns a client for the API of the container, for checks that
+// are not covered here
+func Client(container *testcontainer.SnitchDNSContainer) *snitchdns.Client {
+	return snitchdns.NewClient(container.GetAPIEndpoint(), container.APIKey)
+}
+
+// CheckZoneExists checks that the zone of a snitchdns_zone, or another
+// resource whose ID is a zone ID, exists with the domain in the state
+func CheckZoneExists(container *testcontainer.SnitchDNSContainer, address string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := resourceState(s, address)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+		defer cancel()
+
+		zone, err := Client(container).GetZoneWithContext(ctx, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("failed to read zone %s of %s: %w", rs.Primary.ID, address, err)
+		}
+		if domain, ok := rs.Primary.Attributes["domain"]; ok && !strings.EqualFold(strings.TrimSuffix(domain, "."), strings.TrimSuffix(zone.Domain, ".")) {
+			return fmt.Errorf("expected zone %s of %s to have domain %s, got %s", rs.Primary.ID, address, domain, zone.Domain)
+		}
+		return nil
+	}
+}
+
+// CheckZonesExist checks that zones with the given domains exist
+func CheckZonesExist(container *testcontainer.SnitchDNSContainer, domains ...string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+		defer cancel()
+
+		zones, err := Client(container).ListZonesWithContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list zones: %w", err)
+		}
+		for _, domain := range domains {
+			if _, ok := snitchdns.MatchZoneDomain(zones, domain); !ok {
+				return fmt.Errorf("expected zone %s to exist", domain)
+			}
+		}
+		return nil
+	}
+}
+
+// CheckZonesDestroyed checks that the zones of all snitchdns_zone resources
+// in the state are gone, for resource.TestCase.CheckDestroy
+func CheckZonesDestroyed(container *testcontainer.SnitchDNSContainer) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+		defer cancel()
+
+		client := Client(container)
+		for address, rs := range s.RootModule().Resources {
+			if rs.Type != "snitchdns_zone" {
+				continue
+			}
+
+			_, err := client.GetZoneWithContext(ctx, rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("zone %s of %s still exists", rs.Primary.ID, address)
+			}
+			if !snitchdns.IsNotFound(err) {
+				return fmt.Errorf("failed to read zone %s of %s: %w", rs.Primary.ID, address, err)
+			}
+		}
+		return nil
+	}
+}
+
+// CheckRecordResolves checks that the DNS server of the container answers a
+// query with a record whose data field has the given value, e.g. an A query
+// with the address field 192.0.2.1
+func CheckRecordResolves(container *testcontainer.SnitchDNSContainer, name, qtype, field, value string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+		defer cancel()
+
+		resp, err := container.Query(ctx, name, qtype)
+		if err != nil {
+			return err
+		}
+		for _, answer := range resp.Answers {
+			if answer.Data[field] == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("no %s answer for %s with %s %s, got %s %+v", qtype, name, field, value, resp.RCode, resp.Answers)
+	}
+}
+
+// resourceState returns the state of the resource at address in the root
+// module
+func resourceState(s *terraform.State, address string) (*terraform.ResourceState, error) {
+	rs, ok := s.RootModule().Resources[address]
+	if !ok {
+		return nil, fmt.Errorf("resource %s not found in state", address)
+	}
+	if rs.Primary == nil || rs.Primary.ID == "" {
+		return nil, fmt.Errorf("resource %s has no ID", address)
+	}
+	return rs, nil
+}
@@ -0,0 +1,243 @@
+// Package acctest builds the Terraform configurations of the provider's
+// acceptance tests and checks their results against a SnitchDNS container.
+//
+// Configurations are composed from typed blocks instead of HCL strings, so
+// tests only name what they are about and defaults stay the same across the
+// suite:
+//
+//	zone := acctest.ZoneConfig{Domain: domain, Tags: []string{"canary"}}
+//	record := acctest.RecordConfig{ZoneID: zone.Ref("id"), Type: "A", Data: map[string]string{"address": "192.0.2.1"}}
+//	config := acctest.Compose(acctest.Provider(container), zone, record)
+package acctest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+)
+
+// DefaultName is the name of resources whose block does not set one
+const DefaultName = "test"
+
+// Block is a block of a Terraform configuration
+type Block interface {
+	// Render returns the block as HCL
+	Render() string
+}
+
+// Expr is a raw HCL expression, such as a reference to another resource.
+// Other values are rendered as literals.
+type Expr string
+
+// Compose renders blocks into one configuration
+func Compose(blocks ...Block) string {
+	rendered := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		rendered = append(rendered, block.Render())
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// ProviderConfig is the provider block
+type ProviderConfig struct {
+	APIURL string
+	APIKey string
+
+	// Attributes are further provider attributes, such as debug_http
+	Attributes map[string]any
+}
+
+// Provider returns the provider block for the API of the container
+func Provider(container *testcontainer.SnitchDNSContainer) ProviderConfig {
+	return ProviderConfig{APIURL: container.GetAPIEndpoint(), APIKey: container.APIKey}
+}
+
+// Render returns the block as HCL
+func (p ProviderConfig) Render() string {
+	return renderBlock(`provider "snitchdns"`, []attribute{
+		{"api_url", p.APIURL},
+		{"api_key", p.APIKey},
+	}, p.Attributes)
+}
+
+// ZoneConfig is a snitchdns_zone resource. Zones are active and neither
+// catch-all, forwarding nor regex unless set.
+type ZoneConfig struct {
+	// Name is the resource name, DefaultName when empty
+	Name string
+
+	Domain     string
+	Inactive   bool
+	CatchAll   bool
+	Forwarding bool
+	Regex      bool
+	Tags       []string
+
+	// Attributes are further resource attributes, such as adopt_existing
+	Attributes map[string]any
+}
+
+// Render returns the block as HCL
+func (z ZoneConfig) Render() string {
+	attributes := []attribute{
+		{"domain", z.Domain},
+		{"active", !z.Inactive},
+		{"catch_all", z.CatchAll},
+		{"forwarding", z.Forwarding},
+		{"regex", z.Regex},
+	}
+	if z.Tags != nil {
+		attributes = append(attributes, attribute{"tags", z.Tags})
+	}
+	return renderBlock(fmt.Sprintf("resource %q %q", "snitchdns_zone", resourceName(z.Name)), attributes, z.Attributes)
+}
+
+// Address returns the address of the resource, e.g. snitchdns_zone.test
+func (z ZoneConfig) Address() string {
+	return "snitchdns_zone." + resourceName(z.Name)
+}
+
+// Ref returns a reference to an attribute of the resource
+func (z ZoneConfig) Ref(attr string) Expr {
+	return Expr(z.Address() + "." + attr)
+}
+
+// RecordConfig is a snitchdns_record resource. Records are active IN
+// records unless set.
+type RecordConfig struct {
+	// Name is the resource name, DefaultName when empty
+	Name string
+
+	// ZoneID references the zone, usually ZoneConfig.Ref("id")
+	ZoneID   Expr
+	Type     string
+	Class    string
+	TTL      int
+	Inactive bool
+	Data     map[string]string
+
+	// Attributes are further resource attributes, such as on_destroy
+	Attributes map[string]any
+}
+
+// Render returns the block as HCL
+func (r RecordConfig) Render() string {
+	class := r.Class
+	if class == "" {
+		class = "IN"
+	}
+	attributes := []attribute{
+		{"zone_id", r.ZoneID},
+		{"type", r.Type},
+		{"cls", class},
+	}
+	if r.TTL != 0 {
+		attributes = append(attributes, attribute{"ttl", r.TTL})
+	}
+	attributes = append(attributes, attribute{"active", !r.Inactive}, attribute{"data", r.Data})
+	return renderBlock(fmt.Sprintf("resource %q %q", "snitchdns_record", resourceName(r.Name)), attributes, r.Attributes)
+}
+
+// Address returns the address of the resource, e.g. snitchdns_record.test
+func (r RecordConfig) Address() string {
+	return "snitchdns_record." + resourceName(r.Name)
+}
+
+// Ref returns a reference to an attribute of the resource
+func (r RecordConfig) Ref(attr string) Expr {
+	return Expr(r.Address() + "." + attr)
+}
+
+// Raw is a block given as HCL, for blocks without a builder
+type Raw string
+
+// Render returns the block as HCL
+func (r Raw) Render() string {
+	return strings.TrimSpace(string(r)) + "\n"
+}
+
+// attribute is an attribute of a block in the order it is rendered
+type attribute struct {
+	name  string
+	value any
+}
+
+// renderBlock renders a block with its attributes, followed by the extra
+// attributes sorted by name
+func renderBlock(header string, attributes []attribute, extra map[string]any) string {
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		attributes = append(attributes, attribute{name, extra[name]})
+	}
+
+	var b strings.Builder
+	b.WriteString(header + " {\n")
+	for _, attr := range attributes {
+		fmt.Fprintf(&b, "  %s = %s\n", attr.name, Value(attr.value))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// resourceName returns name, or DefaultName when it is empty
+func resourceName(name string) string {
+	if name == "" {
+		return DefaultName
+	}
+	return name
+}
+
+// Value renders a Go value as an HCL literal: strings, booleans, integers,
+// string slices and string maps, and Expr as is. Strings are escaped so
+// they are never interpolated.
+func Value(v any) string {
+	switch v := v.(type) {
+	case Expr:
+		return string(v)
+	case string:
+		return quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case []string:
+		values := make([]string, len(v))
+		for i, value := range v {
+			values[i] = quote(value)
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	case map[string]string:
+		if len(v) == 0 {
+			return "{}"
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		entries := make([]string, len(keys))
+		for i, key := range keys {
+			entries[i] = quote(key) + " = " + quote(v[key])
+		}
+		return "{ " + strings.Join(entries, ", ") + " }"
+	default:
+		panic(fmt.Sprintf("acctest: cannot render %T as HCL", v))
+	}
+}
+
+// quote renders a string literal. Template sequences are escaped, as Go
+// quoting leaves them alone.
+func quote(s string) string {
+	quoted := strconv.Quote(s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
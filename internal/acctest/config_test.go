@@ -0,0 +1,75 @@
+package acctest
+
+import (
+	"testing"
+)
+
+// TestCompose tests rendering a configuration of a zone and a record
+func TestCompose(t *testing.T) {
+	zone := ZoneConfig{Domain: "canary.example.com", Tags: []string{"canary", "web"}}
+	record := RecordConfig{
+		Name:       "www",
+		ZoneID:     zone.Ref("id"),
+		Type:       "A",
+		TTL:        60,
+		Data:       map[string]string{"address": "192.0.2.1"},
+		Attributes: map[string]any{"on_destroy": "disable"},
+	}
+
+	got := Compose(ProviderConfig{APIURL: "http://localhost:8080", APIKey: "key"}, zone, record)
+	want := `provider "snitchdns" {
+  api_url = "http://localhost:8080"
+  api_key = "key"
+}
+
+resource "snitchdns_zone" "test" {
+  domain = "canary.example.com"
+  active = true
+  catch_all = false
+  forwarding = false
+  regex = false
+  tags = ["canary", "web"]
+}
+
+resource "snitchdns_record" "www" {
+  zone_id = snitchdns_zone.test.id
+  type = "A"
+  cls = "IN"
+  ttl = 60
+  active = true
+  data = { "address" = "192.0.2.1" }
+  on_destroy = "disable"
+}
+`
+	if got != want {
+		t.Errorf("Unexpected configuration:\n%s\nwant:\n%s", got, want)
+	}
+	if record.Address() != "snitchdns_record.www" {
+		t.Errorf("Unexpected address %s", record.Address())
+	}
+}
+
+// TestValue tests rendering values as HCL literals
+func TestValue(t *testing.T) {
+	tests := map[string]struct {
+		value any
+		want  string
+	}{
+		"string":        {"example.com", `"example.com"`},
+		"interpolation": {"${var.x} %{if}", `"$${var.x} %%{if}"`},
+		"escapes":       {"a\"b\n", `"a\"b\n"`},
+		"bool":          {false, "false"},
+		"int64":         {int64(300), "300"},
+		"expression":    {Expr("var.domain"), "var.domain"},
+		"empty list":    {[]string{}, "[]"},
+		"empty map":     {map[string]string{}, "{}"},
+		"map":           {map[string]string{"b": "2", "a": "1"}, `{ "a" = "1", "b" = "2" }`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Value(tt.value); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
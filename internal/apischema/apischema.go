@@ -0,0 +1,319 @@
+// Package apischema holds a machine-readable description of the SnitchDNS
+// API, embedded in the provider, and validates request bodies against it.
+//
+// The description uses the provider's canonical record data field names;
+// callers talking to older servers translate legacy names back before
+// validating record data.
+package apischema
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed schema.json
+var schemaJSON []byte
+
+// Schema describes the request bodies of the SnitchDNS API
+type Schema struct {
+	Endpoints []Endpoint `json:"endpoints"`
+
+	// RecordTypes maps a record type to the fields of its data
+	RecordTypes map[string]map[string]Field `json:"record_types"`
+}
+
+// Endpoint describes the body of requests to a single API route
+type Endpoint struct {
+	Method string `json:"method"`
+	// Path is the route with placeholders such as {zone} for path segments
+	Path string `json:"path"`
+	// Since is the first server version providing the route, empty when
+	// every supported version does
+	Since  string           `json:"since,omitempty"`
+	Fields map[string]Field `json:"fields"`
+	// AdditionalFields allows fields not listed in Fields
+	AdditionalFields bool `json:"additional_fields,omitempty"`
+	// Record marks routes whose body carries record data
+	Record bool `json:"record,omitempty"`
+}
+
+// Field describes a single body or record data field
+type Field struct {
+	Required bool   `json:"required,omitempty"`
+	Format   string `json:"format"`
+	// Since is the first server version accepting the field
+	Since string   `json:"since,omitempty"`
+	Enum  []string `json:"enum,omitempty"`
+}
+
+var (
+	loadOnce sync.Once
+	loaded   *Schema
+)
+
+// Load returns the embedded API schema. The schema is parsed once; a schema
+// that fails to parse is a build defect, caught by the package tests.
+func Load() *Schema {
+	loadOnce.Do(func() {
+		var schema Schema
+		if err := json.Unmarshal(schemaJSON, &schema); err != nil {
+			panic(fmt.Sprintf("apischema: invalid embedded schema: %s", err))
+		}
+		loaded = &schema
+	})
+	return loaded
+}
+
+// Endpoint returns the description of a route, matching path segments
+// against the placeholders of each endpoint. A query string is ignored.
+func (s *Schema) Endpoint(method, path string) (*Endpoint, bool) {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i := range s.Endpoints {
+		endpoint := &s.Endpoints[i]
+		if endpoint.Method == method && matchPath(endpoint.Path, segments) {
+			return endpoint, true
+		}
+	}
+	return nil, false
+}
+
+// ValidateBody checks that the body has every required field, no unknown
+// fields and values of the documented format. Fields introduced after the
+// given server version are rejected when set to a non-zero value, since the
+// server would silently ignore them. An empty version skips that check.
+func (e *Endpoint) ValidateBody(body map[string]interface{}, version string) error {
+	if e.Since != "" && versionBefore(version, e.Since) {
+		return fmt.Errorf("%s %s requires SnitchDNS %s or later, the server runs %s", e.Method, e.Path, e.Since, version)
+	}
+
+	return validateFields(e.Fields, body, version, e.AdditionalFields)
+}
+
+// ValidateRecordData checks record data against the fields of its type.
+// Types the schema does not describe are not checked.
+func (s *Schema) ValidateRecordData(recordType string, data map[string]interface{}) error {
+	fields, ok := s.RecordTypes[strings.ToUpper(recordType)]
+	if !ok {
+		return nil
+	}
+
+	if err := validateFields(fields, data, "", false); err != nil {
+		return fmt.Errorf("%s record data: %w", strings.ToUpper(recordType), err)
+	}
+	return nil
+}
+
+// validateFields checks values against field descriptions. Errors are
+// reported in field name order so they are stable.
+func validateFields(fields map[string]Field, values map[string]interface{}, version string, additional bool) error {
+	names := make([]string, 0, len(fields)+len(values))
+	for name := range fields {
+		names = append(names, name)
+	}
+	for name := range values {
+		if _, ok := fields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field, known := fields[name]
+		value, set := values[name]
+
+		switch {
+		case !known && !additional:
+			return fmt.Errorf("unknown field %q, expected one of: %s", name, strings.Join(fieldNames(fields), ", "))
+		case !known:
+			continue
+		case !set && field.Required:
+			return fmt.Errorf("missing required field %q", name)
+		case !set:
+			continue
+		}
+
+		if field.Since != "" && versionBefore(version, field.Since) && !isZero(value) {
+			return fmt.Errorf("field %q requires SnitchDNS %s or later, the server runs %s", name, field.Since, version)
+		}
+		if err := checkFormat(field, value); err != nil {
+			return fmt.Errorf("field %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// checkFormat checks a value against the format and enum of its field.
+// Numbers may be sent as JSON numbers or as strings of digits, since record
+// data values are strings in configuration.
+func checkFormat(field Field, value interface{}) error {
+	if len(field.Enum) > 0 {
+		s := fmt.Sprintf("%v", value)
+		found := false
+		for _, allowed := range field.Enum {
+			if s == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%q is not one of: %s", s, strings.Join(field.Enum, ", "))
+		}
+	}
+
+	switch field.Format {
+	case "bool":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected a boolean, got %v", value)
+		}
+	case "object":
+		if _, ok := value.(map[string]interface{}); !ok && value != nil {
+			return fmt.Errorf("expected an object, got %v", value)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("expected a string, got %v", value)
+		}
+	case "uint8":
+		return checkUint(value, math.MaxUint8)
+	case "uint16":
+		return checkUint(value, math.MaxUint16)
+	case "uint32":
+		return checkUint(value, math.MaxUint32)
+	case "ipv4":
+		if ip := net.ParseIP(fmt.Sprintf("%v", value)); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("%v is not an IPv4 address", value)
+		}
+	case "ipv6":
+		if ip := net.ParseIP(fmt.Sprintf("%v", value)); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("%v is not an IPv6 address", value)
+		}
+	case "hostname":
+		s, ok := value.(string)
+		if !ok || s == "" || strings.ContainsAny(s, " \t\r\n") {
+			return fmt.Errorf("%v is not a host name", value)
+		}
+	}
+	return nil
+}
+
+// checkUint checks that a value is a whole number between 0 and max
+func checkUint(value interface{}, max uint64) error {
+	var n float64
+	switch v := value.(type) {
+	case float64:
+		n = v
+	case string:
+		parsed, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", v)
+		}
+		n = float64(parsed)
+	default:
+		return fmt.Errorf("expected a number, got %v", value)
+	}
+
+	if n < 0 || n != math.Trunc(n) || n > float64(max) {
+		return fmt.Errorf("%v is not a whole number between 0 and %d", value, max)
+	}
+	return nil
+}
+
+// isZero reports whether a value is the zero value of its JSON type
+func isZero(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// matchPath reports whether path segments match a route template
+func matchPath(template string, segments []string) bool {
+	parts := strings.Split(strings.Trim(template, "/"), "/")
+	if len(parts) != len(segments) {
+		return false
+	}
+	for i, part := range parts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldNames returns the sorted names of the described fields
+func fieldNames(fields map[string]Field) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// versionBefore reports whether version is older than since. Versions that
+// cannot be parsed, including an unknown server version, are never older.
+func versionBefore(version, since string) bool {
+	v, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+	s, ok := parseVersion(since)
+	if !ok {
+		return false
+	}
+	for i := range v {
+		if v[i] != s[i] {
+			return v[i] < s[i]
+		}
+	}
+	return false
+}
+
+// parseVersion parses a major.minor.patch version, ignoring a "v" prefix and
+// any pre-release suffix
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+ "); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if s == "" || len(parts) > 3 {
+		return v, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
@@ -0,0 +1,107 @@
+package apischema
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestLoad tests that the embedded schema parses and describes the record routes
+func TestLoad(t *testing.T) {
+	schema := Load()
+
+	if _, ok := schema.Endpoint("POST", "/zones/12/records?x=1"); !ok {
+		t.Error("Expected the record create route to be described")
+	}
+	if endpoint, ok := schema.Endpoint("POST", "/zones/example.com/records/5"); !ok || !endpoint.Record {
+		t.Error("Expected the record update route to be described as a record route")
+	}
+	if _, ok := schema.Endpoint("GET", "/zones/12/records"); ok {
+		t.Error("Expected routes without a body not to be described")
+	}
+}
+
+// TestValidateBody tests required, unknown, typed and version-gated fields
+func TestValidateBody(t *testing.T) {
+	endpoint, _ := Load().Endpoint("POST", "/zones")
+
+	valid := map[string]interface{}{
+		"domain": "example.com", "active": true, "catch_all": false, "forwarding": false,
+		"regex": false, "master": false, "tags": "canary",
+	}
+
+	tests := map[string]struct {
+		modify  func(map[string]interface{})
+		version string
+		want    string
+	}{
+		"valid":            {func(map[string]interface{}) {}, "", ""},
+		"missing required": {func(b map[string]interface{}) { delete(b, "regex") }, "", `missing required field "regex"`},
+		"unknown field":    {func(b map[string]interface{}) { b["catchall"] = true }, "", `unknown field "catchall"`},
+		"wrong type":       {func(b map[string]interface{}) { b["active"] = "yes" }, "", `field "active": expected a boolean`},
+		"too old":          {func(map[string]interface{}) {}, "1.2.4", `field "tags" requires SnitchDNS 1.3.0`},
+		"old but unset":    {func(b map[string]interface{}) { b["tags"] = "" }, "1.2.4", ""},
+		"recent":           {func(map[string]interface{}) {}, "1.4.0", ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			body := make(map[string]interface{}, len(valid))
+			for k, v := range valid {
+				body[k] = v
+			}
+			tt.modify(body)
+
+			err := endpoint.ValidateBody(body, tt.version)
+			if tt.want == "" && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestValidateBody_EndpointSince tests that routes newer than the server are rejected
+func TestValidateBody_EndpointSince(t *testing.T) {
+	endpoint, _ := Load().Endpoint("POST", "/zones/1/notifications/email")
+
+	if err := endpoint.ValidateBody(map[string]interface{}{"enabled": true}, "1.2.0"); err == nil {
+		t.Error("Expected notifications to be rejected on SnitchDNS 1.2.0")
+	}
+	if err := endpoint.ValidateBody(map[string]interface{}{"enabled": true}, ""); err != nil {
+		t.Errorf("Expected an unknown server version to be accepted, got %v", err)
+	}
+}
+
+// TestValidateRecordData tests record data formats per type
+func TestValidateRecordData(t *testing.T) {
+	schema := Load()
+
+	tests := []struct {
+		recordType string
+		data       map[string]interface{}
+		want       string
+	}{
+		{"A", map[string]interface{}{"address": "10.0.0.1"}, ""},
+		{"A", map[string]interface{}{"address": "2001:db8::1"}, "not an IPv4 address"},
+		{"AAAA", map[string]interface{}{"address": "2001:db8::1"}, ""},
+		{"MX", map[string]interface{}{"hostname": "mail.example.com.", "priority": "10"}, ""},
+		{"MX", map[string]interface{}{"hostname": "mail.example.com."}, `missing required field "priority"`},
+		{"MX", map[string]interface{}{"hostname": "mail.example.com.", "priority": "70000"}, "between 0 and 65535"},
+		{"mx", map[string]interface{}{"hostname": "mail.example.com.", "priority": 10.0, "weight": "1"}, `unknown field "weight"`},
+		{"CAA", map[string]interface{}{"flags": "0", "tag": "issue", "value": "letsencrypt.org"}, ""},
+		{"CAA", map[string]interface{}{"flags": "0", "tag": "issuer", "value": "letsencrypt.org"}, "is not one of"},
+		{"HINFO", map[string]interface{}{"anything": "goes"}, ""},
+	}
+
+	for _, tt := range tests {
+		err := schema.ValidateRecordData(tt.recordType, tt.data)
+		if tt.want == "" && err != nil {
+			t.Errorf("%s %v: unexpected error: %v", tt.recordType, tt.data, err)
+		}
+		if tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)) {
+			t.Errorf("%s %v: expected error containing %q, got %v", tt.recordType, tt.data, tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Package axfr implements DNS zone transfers (AXFR) from external
+// authoritative servers, returning records in the SnitchDNS data format.
+package axfr
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/internal/dnslookup"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// DefaultTimeout bounds a complete transfer when the context has no deadline
+const DefaultTimeout = 30 * time.Second
+
+// Record is a transferred resource record. Data uses the same field names as
+// the data attribute of SnitchDNS records, e.g. "address" for A records.
+type Record struct {
+	// Name is the fully qualified owner name with a trailing dot
+	Name  string
+	Type  string
+	Class string
+	TTL   uint32
+	Data  map[string]string
+}
+
+// Result is the outcome of a zone transfer
+type Result struct {
+	Records []Record
+	// Skipped lists the types of records that could not be mapped to the
+	// SnitchDNS data format, one entry per skipped record
+	Skipped []string
+}
+
+// Transfer performs an AXFR of zone from server over TCP. server is a host
+// name or address with an optional port, defaulting to 53. The closing SOA
+// record of the transfer is not included in the result.
+func Transfer(ctx context.Context, server, zone string) (*Result, error) {
+	zone = strings.TrimSuffix(zone, ".") + "."
+	name, err := dnsmessage.NewName(zone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zone name %q: %w", zone, err)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", dnslookup.ServerAddress(server))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", server, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	id := uint16(time.Now().UnixNano())
+	if err := writeQuery(conn, id, name); err != nil {
+		return nil, fmt.Errorf("failed to send transfer request: %w", err)
+	}
+
+	result := &Result{}
+	soaSeen := 0
+	for soaSeen < 2 {
+		msg, err := readMessage(conn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read transfer response: %w", err)
+		}
+
+		done, err := parseMessage(msg, id, result, &soaSeen)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// writeQuery sends a length-prefixed AXFR query
+func writeQuery(w io.Writer, id uint16, zone dnsmessage.Name) error {
+	builder := dnsmessage.NewBuilder(make([]byte, 2, 514), dnsmessage.Header{ID: id})
+	if err := builder.StartQuestions(); err != nil {
+		return err
+	}
+	if err := builder.Question(dnsmessage.Question{
+		Name:  zone,
+		Type:  dnsmessage.TypeAXFR,
+		Class: dnsmessage.ClassINET,
+	}); err != nil {
+		return err
+	}
+
+	msg, err := builder.Finish()
+	if err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint16(msg, uint16(len(msg)-2))
+
+	_, err = w.Write(msg)
+	return err
+}
+
+// readMessage reads a single length-prefixed DNS message
+func readMessage(r io.Reader) ([]byte, error) {
+	var length uint16
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+
+	msg := make([]byte, length)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// parseMessage appends the answers of a transfer message to result. It
+// reports done once the closing SOA record has been read.
+func parseMessage(msg []byte, id uint16, result *Result, soaSeen *int) (bool, error) {
+	var p dnsmessage.Parser
+	header, err := p.Start(msg)
+	if err != nil {
+		return false, fmt.Errorf("malformed transfer response: %w", err)
+	}
+	if header.ID != id {
+		return false, errors.New("transfer response does not match the request")
+	}
+	if header.RCode != dnsmessage.RCodeSuccess {
+		return false, fmt.Errorf("transfer refused by server: %s", strings.TrimPrefix(header.RCode.String(), "RCode"))
+	}
+	if err := p.SkipAllQuestions(); err != nil {
+		return false, fmt.Errorf("malformed transfer response: %w", err)
+	}
+
+	for {
+		rh, err := p.AnswerHeader()
+		if errors.Is(err, dnsmessage.ErrSectionDone) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("malformed transfer response: %w", err)
+		}
+
+		if rh.Type == dnsmessage.TypeSOA {
+			*soaSeen++
+			if *soaSeen == 2 {
+				return true, nil
+			}
+		}
+
+		data, err := dnslookup.ParseData(&p, rh.Type)
+		if err != nil {
+			return false, fmt.Errorf("malformed %s record for %s: %w", dnslookup.TypeName(rh.Type), rh.Name, err)
+		}
+		if data == nil {
+			result.Skipped = append(result.Skipped, dnslookup.TypeName(rh.Type))
+			continue
+		}
+
+		result.Records = append(result.Records, Record{
+			Name:  rh.Name.String(),
+			Type:  dnslookup.TypeName(rh.Type),
+			Class: dnslookup.ClassName(rh.Class),
+			TTL:   rh.TTL,
+			Data:  data,
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package axfr
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/dnslookup"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// serveTransfer starts a TCP server answering one AXFR with the given messages,
+// each a list of resources
+func serveTransfer(t *testing.T, rcode dnsmessage.RCode, messages [][]dnsmessage.Resource) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		query, err := readMessage(conn)
+		if err != nil {
+			return
+		}
+		var p dnsmessage.Parser
+		header, err := p.Start(query)
+		if err != nil {
+			return
+		}
+		question, err := p.Question()
+		if err != nil || question.Type != dnsmessage.TypeAXFR {
+			return
+		}
+
+		for _, resources := range messages {
+			builder := dnsmessage.NewBuilder(make([]byte, 2, 512), dnsmessage.Header{ID: header.ID, Response: true, RCode: rcode})
+			_ = builder.StartQuestions()
+			_ = builder.Question(question)
+			_ = builder.StartAnswers()
+			for _, r := range resources {
+				switch body := r.Body.(type) {
+				case *dnsmessage.SOAResource:
+					_ = builder.SOAResource(r.Header, *body)
+				case *dnsmessage.AResource:
+					_ = builder.AResource(r.Header, *body)
+				case *dnsmessage.MXResource:
+					_ = builder.MXResource(r.Header, *body)
+				case *dnsmessage.TXTResource:
+					_ = builder.TXTResource(r.Header, *body)
+				case *dnsmessage.UnknownResource:
+					_ = builder.UnknownResource(r.Header, *body)
+				}
+			}
+			msg, _ := builder.Finish()
+			binary.BigEndian.PutUint16(msg, uint16(len(msg)-2))
+			if _, err := conn.Write(msg); err != nil {
+				return
+			}
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// header builds a resource header
+func header(name string, t dnsmessage.Type) dnsmessage.ResourceHeader {
+	return dnsmessage.ResourceHeader{
+		Name:  dnsmessage.MustNewName(name),
+		Type:  t,
+		Class: dnsmessage.ClassINET,
+		TTL:   300,
+	}
+}
+
+// TestTransfer tests a transfer spanning several messages, including CAA and unsupported records
+func TestTransfer(t *testing.T) {
+	soa := dnsmessage.Resource{
+		Header: header("example.com.", dnsmessage.TypeSOA),
+		Body: &dnsmessage.SOAResource{
+			NS: dnsmessage.MustNewName("ns1.example.com."), MBox: dnsmessage.MustNewName("admin.example.com."),
+			Serial: 2024010101, Refresh: 3600, Retry: 600, Expire: 86400, MinTTL: 300,
+		},
+	}
+
+	addr := serveTransfer(t, dnsmessage.RCodeSuccess, [][]dnsmessage.Resource{
+		{
+			soa,
+			{Header: header("www.example.com.", dnsmessage.TypeA), Body: &dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}}},
+			{Header: header("example.com.", dnsmessage.TypeMX), Body: &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mail.example.com.")}},
+		},
+		{
+			{Header: header("example.com.", dnsmessage.TypeTXT), Body: &dnsmessage.TXTResource{TXT: []string{"v=spf1 ", "-all"}}},
+			{Header: header("example.com.", dnslookup.TypeCAA), Body: &dnsmessage.UnknownResource{Type: dnslookup.TypeCAA, Data: []byte("\x00\x05issueletsencrypt.org")}},
+			{Header: header("example.com.", dnsmessage.Type(99)), Body: &dnsmessage.UnknownResource{Type: dnsmessage.Type(99), Data: []byte("x")}},
+			soa,
+		},
+	})
+
+	result, err := Transfer(context.Background(), addr, "example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Records) != 5 {
+		t.Fatalf("Expected 5 records (closing SOA excluded), got %d: %+v", len(result.Records), result.Records)
+	}
+
+	checks := []struct {
+		index int
+		typ   string
+		key   string
+		value string
+	}{
+		{0, "SOA", "serial", "2024010101"},
+		{1, "A", "address", "10.0.0.1"},
+		{2, "MX", "hostname", "mail.example.com."},
+		{3, "TXT", "data", "v=spf1 -all"},
+		{4, "CAA", "value", "letsencrypt.org"},
+	}
+	for _, c := range checks {
+		record := result.Records[c.index]
+		if record.Type != c.typ || record.Data[c.key] != c.value {
+			t.Errorf("Record %d: expected %s with %s=%q, got %s %v", c.index, c.typ, c.key, c.value, record.Type, record.Data)
+		}
+	}
+
+	if result.Records[1].Name != "www.example.com." || result.Records[1].Class != "IN" || result.Records[1].TTL != 300 {
+		t.Errorf("Unexpected record header: %+v", result.Records[1])
+	}
+	if len(result.Skipped) != 1 {
+		t.Errorf("Expected 1 skipped record, got %v", result.Skipped)
+	}
+}
+
+// TestTransferRefused tests that a refused transfer is reported as an error
+func TestTransferRefused(t *testing.T) {
+	addr := serveTransfer(t, dnsmessage.RCodeRefused, [][]dnsmessage.Resource{{}})
+
+	_, err := Transfer(context.Background(), addr, "example.com.")
+	if err == nil {
+		t.Fatal("Expected an error for a refused transfer")
+	}
+	if got := err.Error(); got != "transfer refused by server: Refused" {
+		t.Errorf("Unexpected error: %s", got)
+	}
+}
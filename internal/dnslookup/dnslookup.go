@@ -0,0 +1,405 @@
+// Package dnslookup sends single DNS queries and decodes the answers into
+// the SnitchDNS data format.
+package dnslookup
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// DefaultTimeout bounds a lookup when the context has no deadline
+const DefaultTimeout = 5 * time.Second
+
+// Protocols a lookup can be sent over
+const (
+	ProtocolUDP = "udp"
+	ProtocolTCP = "tcp"
+)
+
+// TypeCAA is the CAA record type, which dnsmessage does not define
+const TypeCAA dnsmessage.Type = 257
+
+// types maps the record types that can be decoded to their mnemonics
+var types = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CAA":   TypeCAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"NS":    dnsmessage.TypeNS,
+	"PTR":   dnsmessage.TypePTR,
+	"SOA":   dnsmessage.TypeSOA,
+	"SRV":   dnsmessage.TypeSRV,
+	"TXT":   dnsmessage.TypeTXT,
+}
+
+// Record is a resource record of a response. Data uses the same field names
+// as the data attribute of SnitchDNS records, e.g. "address" for A records.
+type Record struct {
+	// Name is the fully qualified owner name with a trailing dot
+	Name  string
+	Type  string
+	Class string
+	TTL   uint32
+	Data  map[string]string
+}
+
+// Response is the outcome of a lookup
+type Response struct {
+	// RCode is the response code mnemonic, e.g. "NOERROR" or "NXDOMAIN"
+	RCode         string
+	Authoritative bool
+	Answers       []Record
+	// Skipped lists the types of answers that could not be mapped to the
+	// SnitchDNS data format, one entry per skipped record
+	Skipped []string
+}
+
+// Query describes a lookup
+type Query struct {
+	// Server is a host name or address with an optional port, defaulting
+	// to 53
+	Server string
+	Name   string
+	// Type is a record type mnemonic such as "A" or "MX"
+	Type string
+	// Protocol is ProtocolUDP or ProtocolTCP. UDP lookups are repeated
+	// over TCP when the response is truncated.
+	Protocol string
+}
+
+// SupportedTypes returns the record types Lookup accepts
+func SupportedTypes() []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Lookup sends a recursive query to the server and returns its response.
+// Responses with an error code such as NXDOMAIN are returned, not failed;
+// only a missing or malformed response is an error.
+func Lookup(ctx context.Context, q Query) (*Response, error) {
+	qtype, ok := types[strings.ToUpper(q.Type)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type %q", q.Type)
+	}
+	name, err := dnsmessage.NewName(strings.TrimSuffix(q.Name, ".") + ".")
+	if err != nil {
+		return nil, fmt.Errorf("invalid name %q: %w", q.Name, err)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+
+	protocol := q.Protocol
+	if protocol == "" {
+		protocol = ProtocolUDP
+	}
+
+	msg, err := exchange(ctx, protocol, ServerAddress(q.Server), name, qtype)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, truncated, err := parseResponse(msg)
+	if err != nil {
+		return nil, err
+	}
+	if truncated && protocol == ProtocolUDP {
+		return Lookup(ctx, Query{Server: q.Server, Name: q.Name, Type: q.Type, Protocol: ProtocolTCP})
+	}
+	return resp, nil
+}
+
+// ServerAddress appends the default DNS port when server has none
+func ServerAddress(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(strings.Trim(server, "[]"), "53")
+}
+
+// exchange sends a query and returns the raw response with the query's ID
+func exchange(ctx context.Context, protocol, addr string, name dnsmessage.Name, qtype dnsmessage.Type) ([]byte, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, protocol, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	id := uint16(time.Now().UnixNano())
+	query, err := buildQuery(id, name, qtype)
+	if err != nil {
+		return nil, err
+	}
+
+	if protocol == ProtocolTCP {
+		prefixed := binary.BigEndian.AppendUint16(nil, uint16(len(query)))
+		if _, err := conn.Write(append(prefixed, query...)); err != nil {
+			return nil, fmt.Errorf("failed to query %s: %w", addr, err)
+		}
+		for {
+			var length uint16
+			if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+				return nil, fmt.Errorf("no DNS response from %s: %w", addr, err)
+			}
+			msg := make([]byte, length)
+			if _, err := io.ReadFull(conn, msg); err != nil {
+				return nil, fmt.Errorf("no DNS response from %s: %w", addr, err)
+			}
+			if matchesID(msg, id) {
+				return msg, nil
+			}
+		}
+	}
+
+	if _, err := conn.Write(query); err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", addr, err)
+	}
+	buf := make([]byte, 65535)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, fmt.Errorf("no DNS response from %s: %w", addr, err)
+		}
+		// Ignore stray datagrams and keep waiting until the deadline
+		if matchesID(buf[:n], id) {
+			return buf[:n], nil
+		}
+	}
+}
+
+// buildQuery builds a query with recursion desired, so the server may be a
+// recursive resolver as well as an authoritative server
+func buildQuery(id uint16, name dnsmessage.Name, qtype dnsmessage.Type) ([]byte, error) {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := builder.Question(dnsmessage.Question{
+		Name:  name,
+		Type:  qtype,
+		Class: dnsmessage.ClassINET,
+	}); err != nil {
+		return nil, err
+	}
+	return builder.Finish()
+}
+
+// matchesID reports whether msg is a response to the query with the ID
+func matchesID(msg []byte, id uint16) bool {
+	var p dnsmessage.Parser
+	header, err := p.Start(msg)
+	return err == nil && header.Response && header.ID == id
+}
+
+// parseResponse decodes the answer section of a response. It reports
+// whether the response is truncated.
+func parseResponse(msg []byte) (*Response, bool, error) {
+	var p dnsmessage.Parser
+	header, err := p.Start(msg)
+	if err != nil {
+		return nil, false, fmt.Errorf("malformed response: %w", err)
+	}
+	if header.Truncated {
+		return nil, true, nil
+	}
+	if err := p.SkipAllQuestions(); err != nil {
+		return nil, false, fmt.Errorf("malformed response: %w", err)
+	}
+
+	resp := &Response{
+		RCode:         RCodeName(header.RCode),
+		Authoritative: header.Authoritative,
+		Answers:       []Record{},
+		Skipped:       []string{},
+	}
+	for {
+		rh, err := p.AnswerHeader()
+		if errors.Is(err, dnsmessage.ErrSectionDone) {
+			return resp, false, nil
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("malformed response: %w", err)
+		}
+
+		data, err := ParseData(&p, rh.Type)
+		if err != nil {
+			return nil, false, fmt.Errorf("malformed %s record for %s: %w", TypeName(rh.Type), rh.Name, err)
+		}
+		if data == nil {
+			resp.Skipped = append(resp.Skipped, TypeName(rh.Type))
+			continue
+		}
+
+		resp.Answers = append(resp.Answers, Record{
+			Name:  rh.Name.String(),
+			Type:  TypeName(rh.Type),
+			Class: ClassName(rh.Class),
+			TTL:   rh.TTL,
+			Data:  data,
+		})
+	}
+}
+
+// ParseData decodes the body of a resource into the SnitchDNS data format.
+// It returns nil data for types SnitchDNS data cannot represent.
+func ParseData(p *dnsmessage.Parser, t dnsmessage.Type) (map[string]string, error) {
+	switch t {
+	case dnsmessage.TypeA:
+		r, err := p.AResource()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"address": net.IP(r.A[:]).String()}, nil
+	case dnsmessage.TypeAAAA:
+		r, err := p.AAAAResource()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"address": net.IP(r.AAAA[:]).String()}, nil
+	case dnsmessage.TypeCNAME:
+		r, err := p.CNAMEResource()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"name": r.CNAME.String()}, nil
+	case dnsmessage.TypeNS:
+		r, err := p.NSResource()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"name": r.NS.String()}, nil
+	case dnsmessage.TypePTR:
+		r, err := p.PTRResource()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"name": r.PTR.String()}, nil
+	case dnsmessage.TypeMX:
+		r, err := p.MXResource()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"priority": strconv.Itoa(int(r.Pref)),
+			"hostname": r.MX.String(),
+		}, nil
+	case dnsmessage.TypeTXT:
+		r, err := p.TXTResource()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"data": strings.Join(r.TXT, "")}, nil
+	case dnsmessage.TypeSRV:
+		r, err := p.SRVResource()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"priority": strconv.Itoa(int(r.Priority)),
+			"weight":   strconv.Itoa(int(r.Weight)),
+			"port":     strconv.Itoa(int(r.Port)),
+			"target":   r.Target.String(),
+		}, nil
+	case dnsmessage.TypeSOA:
+		r, err := p.SOAResource()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"mname":   r.NS.String(),
+			"rname":   r.MBox.String(),
+			"serial":  strconv.FormatUint(uint64(r.Serial), 10),
+			"refresh": strconv.FormatUint(uint64(r.Refresh), 10),
+			"retry":   strconv.FormatUint(uint64(r.Retry), 10),
+			"expire":  strconv.FormatUint(uint64(r.Expire), 10),
+			"minimum": strconv.FormatUint(uint64(r.MinTTL), 10),
+		}, nil
+	case TypeCAA:
+		r, err := p.UnknownResource()
+		if err != nil {
+			return nil, err
+		}
+		return parseCAA(r.Data)
+	default:
+		if _, err := p.UnknownResource(); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+}
+
+// parseCAA decodes CAA record data (RFC 8659): flags, tag length, tag, value
+func parseCAA(b []byte) (map[string]string, error) {
+	if len(b) < 2 || len(b) < 2+int(b[1]) {
+		return nil, errors.New("truncated CAA record")
+	}
+	tagEnd := 2 + int(b[1])
+	return map[string]string{
+		"flags": strconv.Itoa(int(b[0])),
+		"tag":   string(b[2:tagEnd]),
+		"value": string(b[tagEnd:]),
+	}, nil
+}
+
+// TypeName returns the record type mnemonic, e.g. "MX"
+func TypeName(t dnsmessage.Type) string {
+	if t == TypeCAA {
+		return "CAA"
+	}
+	return strings.TrimPrefix(t.String(), "Type")
+}
+
+// ClassName returns the class mnemonic used by SnitchDNS
+func ClassName(c dnsmessage.Class) string {
+	switch c {
+	case dnsmessage.ClassINET:
+		return "IN"
+	case dnsmessage.ClassCHAOS:
+		return "CH"
+	case dnsmessage.ClassHESIOD:
+		return "HS"
+	default:
+		return strings.TrimPrefix(c.String(), "Class")
+	}
+}
+
+// RCodeName returns the response code mnemonic, e.g. "NXDOMAIN"
+func RCodeName(rcode dnsmessage.RCode) string {
+	switch rcode {
+	case dnsmessage.RCodeSuccess:
+		return "NOERROR"
+	case dnsmessage.RCodeFormatError:
+		return "FORMERR"
+	case dnsmessage.RCodeServerFailure:
+		return "SERVFAIL"
+	case dnsmessage.RCodeNameError:
+		return "NXDOMAIN"
+	case dnsmessage.RCodeNotImplemented:
+		return "NOTIMP"
+	case dnsmessage.RCodeRefused:
+		return "REFUSED"
+	default:
+		return strconv.Itoa(int(rcode))
+	}
+}
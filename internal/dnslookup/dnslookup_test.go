@@ -0,0 +1,197 @@
+package dnslookup
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// answer builds a response to query with the given header flags and A answers
+func answer(t *testing.T, query []byte, header dnsmessage.Header, addresses ...[4]byte) []byte {
+	t.Helper()
+
+	var p dnsmessage.Parser
+	qh, err := p.Start(query)
+	if err != nil {
+		t.Errorf("Malformed query: %v", err)
+		return nil
+	}
+	question, err := p.Question()
+	if err != nil {
+		t.Errorf("Malformed question: %v", err)
+		return nil
+	}
+	if !qh.RecursionDesired {
+		t.Error("Expected recursion to be desired")
+	}
+
+	header.ID = qh.ID
+	header.Response = true
+	builder := dnsmessage.NewBuilder(nil, header)
+	_ = builder.StartQuestions()
+	_ = builder.Question(question)
+	_ = builder.StartAnswers()
+	for _, address := range addresses {
+		_ = builder.AResource(dnsmessage.ResourceHeader{
+			Name: question.Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 60,
+		}, dnsmessage.AResource{A: address})
+	}
+	_ = builder.UnknownResource(dnsmessage.ResourceHeader{
+		Name: question.Name, Type: dnsmessage.Type(99), Class: dnsmessage.ClassINET, TTL: 60,
+	}, dnsmessage.UnknownResource{Type: dnsmessage.Type(99), Data: []byte("x")})
+	msg, _ := builder.Finish()
+	return msg
+}
+
+// serveUDP answers one UDP query with the response built by respond
+func serveUDP(t *testing.T, respond func(query []byte) []byte) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		conn.WriteTo(respond(buf[:n]), addr)
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// serveTCP answers one TCP query on the given address with the response
+// built by respond
+func serveTCP(t *testing.T, addr string, respond func(query []byte) []byte) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var length uint16
+		if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+			return
+		}
+		query := make([]byte, length)
+		if _, err := io.ReadFull(conn, query); err != nil {
+			return
+		}
+		msg := respond(query)
+		conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(msg))), msg...))
+	}()
+}
+
+// TestLookup tests decoding the answers of a UDP response
+func TestLookup(t *testing.T) {
+	addr := serveUDP(t, func(query []byte) []byte {
+		return answer(t, query, dnsmessage.Header{Authoritative: true}, [4]byte{192, 0, 2, 1}, [4]byte{192, 0, 2, 2})
+	})
+
+	resp, err := Lookup(context.Background(), Query{Server: addr, Name: "www.example.com", Type: "a"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &Response{
+		RCode:         "NOERROR",
+		Authoritative: true,
+		Answers: []Record{
+			{Name: "www.example.com.", Type: "A", Class: "IN", TTL: 60, Data: map[string]string{"address": "192.0.2.1"}},
+			{Name: "www.example.com.", Type: "A", Class: "IN", TTL: 60, Data: map[string]string{"address": "192.0.2.2"}},
+		},
+		Skipped: []string{"99"},
+	}
+	if !reflect.DeepEqual(resp, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, resp)
+	}
+}
+
+// TestLookupTruncated tests that a truncated UDP response is repeated over TCP
+func TestLookupTruncated(t *testing.T) {
+	addr := serveUDP(t, func(query []byte) []byte {
+		return answer(t, query, dnsmessage.Header{Truncated: true})
+	})
+	serveTCP(t, addr, func(query []byte) []byte {
+		return answer(t, query, dnsmessage.Header{}, [4]byte{192, 0, 2, 3})
+	})
+
+	resp, err := Lookup(context.Background(), Query{Server: addr, Name: "www.example.com.", Type: "A"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(resp.Answers) != 1 || resp.Answers[0].Data["address"] != "192.0.2.3" {
+		t.Errorf("Expected the TCP answer, got %+v", resp)
+	}
+}
+
+// TestLookupNXDomain tests that error responses are returned, not failed
+func TestLookupNXDomain(t *testing.T) {
+	addr := serveUDP(t, func(query []byte) []byte {
+		return answer(t, query, dnsmessage.Header{RCode: dnsmessage.RCodeNameError})
+	})
+
+	resp, err := Lookup(context.Background(), Query{Server: addr, Name: "missing.example.com", Type: "A"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.RCode != "NXDOMAIN" || len(resp.Answers) != 0 {
+		t.Errorf("Expected an empty NXDOMAIN response, got %+v", resp)
+	}
+}
+
+// TestLookupErrors tests invalid queries and a silent server
+func TestLookupErrors(t *testing.T) {
+	if _, err := Lookup(context.Background(), Query{Server: "127.0.0.1", Name: "example.com", Type: "HINFO"}); err == nil {
+		t.Error("Expected an error for an unsupported type")
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if _, err := Lookup(ctx, Query{Server: conn.LocalAddr().String(), Name: "example.com", Type: "A"}); err == nil {
+		t.Error("Expected an error for a server that does not answer")
+	}
+}
+
+// TestServerAddress tests that the default DNS port is added when missing
+func TestServerAddress(t *testing.T) {
+	cases := map[string]string{
+		"ns1.example.com":    "ns1.example.com:53",
+		"ns1.example.com:54": "ns1.example.com:54",
+		"192.0.2.1":          "192.0.2.1:53",
+		"2001:db8::1":        "[2001:db8::1]:53",
+		"[2001:db8::1]:5353": "[2001:db8::1]:5353",
+	}
+	for server, want := range cases {
+		if got := ServerAddress(server); got != want {
+			t.Errorf("ServerAddress(%q) = %q, want %q", server, got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Package dnsprobe checks whether a DNS server answers queries.
+package dnsprobe
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// DefaultTimeout bounds a probe when the context has no deadline
+const DefaultTimeout = 3 * time.Second
+
+// probeName is queried by Probe. Any answer, including NXDOMAIN or REFUSED,
+// shows the daemon is up.
+const probeName = "snitchdns-probe.invalid."
+
+// Probe sends a single UDP query to server (host with optional port,
+// default 53) and returns an error if no DNS response arrives in time.
+func Probe(ctx context.Context, server string) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+
+	addr := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		addr = net.JoinHostPort(strings.Trim(server, "[]"), "53")
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	id := uint16(time.Now().UnixNano())
+	query, err := buildQuery(id)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Write(query); err != nil {
+		return fmt.Errorf("failed to query %s: %w", addr, err)
+	}
+
+	buf := make([]byte, 512)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return fmt.Errorf("no DNS response from %s: %w", addr, err)
+		}
+
+		var p dnsmessage.Parser
+		header, err := p.Start(buf[:n])
+		if err == nil && header.Response && header.ID == id {
+			return nil
+		}
+		// Ignore stray datagrams and keep waiting until the deadline
+	}
+}
+
+// buildQuery builds an A query for probeName
+func buildQuery(id uint16) ([]byte, error) {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id})
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := builder.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(probeName),
+		Type:  dnsmessage.TypeA,
+		Class: dnsmessage.ClassINET,
+	}); err != nil {
+		return nil, err
+	}
+
+	return builder.Finish()
+}
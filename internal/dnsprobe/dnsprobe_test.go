@@ -0,0 +1,55 @@
+package dnsprobe
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// TestProbe tests that any DNS response, even a refusal, counts as reachable
+func TestProbe(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	go func() {
+		buf := make([]byte, 512)
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		var p dnsmessage.Parser
+		header, err := p.Start(buf[:n])
+		if err != nil {
+			return
+		}
+		builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, RCode: dnsmessage.RCodeRefused})
+		resp, _ := builder.Finish()
+		conn.WriteTo(resp, addr)
+	}()
+
+	if err := Probe(context.Background(), conn.LocalAddr().String()); err != nil {
+		t.Errorf("Expected the server to be reachable, got %v", err)
+	}
+}
+
+// TestProbeNoAnswer tests that a silent server is reported as unreachable
+func TestProbeNoAnswer(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if err := Probe(ctx, conn.LocalAddr().String()); err == nil {
+		t.Error("Expected an error for a server that does not answer")
+	}
+}
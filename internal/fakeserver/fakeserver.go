@@ -0,0 +1,270 @@
+// Package fakeserver serves the part of the SnitchDNS HTTP API the provider
+// uses most, zones and records, from memory on an httptest.Server. It lets
+// the acceptance tests run without Docker, see testcontainer.FakeServerEnvVar.
+//
+// The state is kept by a snitchdnsmock.Client, so the server answers with
+// the same objects and SnitchDNS errors as the in-memory fake of the client,
+// and tests can inspect or seed it through API. Endpoints outside the zones,
+// records and status routes answer 404.
+package fakeserver
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+)
+
+const (
+	// APIPath is the path below the server's URL the API is served at
+	APIPath = "/api/v1"
+
+	// APIKey is the API key the server accepts
+	APIKey = "fakeserver-api-key"
+
+	// Version is the SnitchDNS version the server reports
+	Version = "1.4.0"
+)
+
+// Server is a running fake SnitchDNS server. Close it when done.
+type Server struct {
+	*httptest.Server
+
+	// API holds the zones and records the server serves
+	API *snitchdnsmock.Client
+}
+
+// New starts a fake SnitchDNS server without zones
+func New() *Server {
+	api := snitchdnsmock.New()
+	api.Version = Version
+
+	s := &Server{API: api}
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// Endpoint returns the URL of the API, the provider's api_url
+func (s *Server) Endpoint() string {
+	return s.URL + APIPath
+}
+
+// handler routes the API requests, rejecting those without the API key
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+APIPath+"/status", s.status)
+	mux.HandleFunc("GET "+APIPath+"/zones", s.listZones)
+	mux.HandleFunc("POST "+APIPath+"/zones", s.createZone)
+	mux.HandleFunc("GET "+APIPath+"/zones/{zone}", s.getZone)
+	mux.HandleFunc("POST "+APIPath+"/zones/{zone}", s.updateZone)
+	mux.HandleFunc("DELETE "+APIPath+"/zones/{zone}", s.deleteZone)
+	mux.HandleFunc("GET "+APIPath+"/zones/{zone}/records", s.listRecords)
+	mux.HandleFunc("POST "+APIPath+"/zones/{zone}/records", s.createRecord)
+	mux.HandleFunc("GET "+APIPath+"/zones/{zone}/records/{record}", s.getRecord)
+	mux.HandleFunc("POST "+APIPath+"/zones/{zone}/records/{record}", s.updateRecord)
+	mux.HandleFunc("DELETE "+APIPath+"/zones/{zone}/records/{record}", s.deleteRecord)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-SnitchDNS-Auth") != APIKey {
+			writeJSON(w, http.StatusUnauthorized, errorBody{Code: 0, Message: "Invalid API Key"})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// status answers the server status with the version
+func (s *Server) status(w http.ResponseWriter, r *http.Request) {
+	info, err := s.API.GetServerInfo(r.Context())
+	respond(w, info, err)
+}
+
+// zonePage is a page of the zone listing
+type zonePage struct {
+	Page    int              `json:"page"`
+	Pages   int              `json:"pages"`
+	PerPage int              `json:"per_page"`
+	Total   int              `json:"total"`
+	Results []snitchdns.Zone `json:"results"`
+}
+
+// listZones answers a page of the zones matching the search parameter
+func (s *Server) listZones(w http.ResponseWriter, r *http.Request) {
+	zones, err := s.API.SearchZonesWithContext(r.Context(), r.URL.Query().Get("search"))
+	if err != nil {
+		respond(w, nil, err)
+		return
+	}
+
+	page := queryInt(r, "page", 1)
+	perPage := queryInt(r, "per_page", 50)
+	result := zonePage{
+		Page:    page,
+		Pages:   (len(zones) + perPage - 1) / perPage,
+		PerPage: perPage,
+		Total:   len(zones),
+		Results: []snitchdns.Zone{},
+	}
+	if start := (page - 1) * perPage; start < len(zones) {
+		result.Results = zones[start:min(start+perPage, len(zones))]
+	}
+	respond(w, result, nil)
+}
+
+// createZone creates a zone
+func (s *Server) createZone(w http.ResponseWriter, r *http.Request) {
+	var req snitchdns.CreateZoneRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	zone, err := s.API.CreateZoneWithContext(r.Context(), req)
+	respond(w, zone, err)
+}
+
+// getZone answers a zone
+func (s *Server) getZone(w http.ResponseWriter, r *http.Request) {
+	zone, err := s.API.GetZoneWithContext(r.Context(), r.PathValue("zone"))
+	respond(w, zone, err)
+}
+
+// updateZone updates a zone. Conflicts are detected by the client, which
+// reads the zone first, so the expected timestamp is ignored like SnitchDNS
+// does.
+func (s *Server) updateZone(w http.ResponseWriter, r *http.Request) {
+	var req snitchdns.UpdateZoneRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	req.ExpectedUpdatedAt = ""
+	zone, err := s.API.UpdateZoneWithContext(r.Context(), r.PathValue("zone"), req)
+	respond(w, zone, err)
+}
+
+// deleteZone deletes a zone and its records
+func (s *Server) deleteZone(w http.ResponseWriter, r *http.Request) {
+	err := s.API.DeleteZoneWithContext(r.Context(), r.PathValue("zone"))
+	respond(w, map[string]bool{"success": true}, err)
+}
+
+// listRecords answers the records of a zone
+func (s *Server) listRecords(w http.ResponseWriter, r *http.Request) {
+	records, err := s.API.ListRecordsWithContext(r.Context(), r.PathValue("zone"))
+	if err != nil {
+		respond(w, nil, err)
+		return
+	}
+
+	result := make([]snitchdns.Record, 0, len(records))
+	for _, record := range records {
+		result = append(result, wireRecord(record))
+	}
+	respond(w, result, nil)
+}
+
+// createRecord creates a record in a zone
+func (s *Server) createRecord(w http.ResponseWriter, r *http.Request) {
+	var req snitchdns.CreateRecordRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	record, err := s.API.CreateRecordWithContext(r.Context(), r.PathValue("zone"), req)
+	respondRecord(w, record, err)
+}
+
+// getRecord answers a record
+func (s *Server) getRecord(w http.ResponseWriter, r *http.Request) {
+	record, err := s.API.GetRecordWithContext(r.Context(), r.PathValue("zone"), r.PathValue("record"))
+	respondRecord(w, record, err)
+}
+
+// updateRecord updates a record
+func (s *Server) updateRecord(w http.ResponseWriter, r *http.Request) {
+	var req snitchdns.UpdateRecordRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	record, err := s.API.UpdateRecordWithContext(r.Context(), r.PathValue("zone"), r.PathValue("record"), req)
+	respondRecord(w, record, err)
+}
+
+// deleteRecord deletes a record
+func (s *Server) deleteRecord(w http.ResponseWriter, r *http.Request) {
+	err := s.API.DeleteRecordWithContext(r.Context(), r.PathValue("zone"), r.PathValue("record"))
+	respond(w, map[string]bool{"success": true}, err)
+}
+
+// wireRecord returns the record as SnitchDNS sends it, with the data
+// fields as JSON-encoded strings
+func wireRecord(record snitchdns.Record) snitchdns.Record {
+	record.DataRaw = "{}"
+	if !record.Data.IsEmpty() {
+		record.DataRaw = string(record.Data)
+	}
+	if !record.ConditionalData.IsEmpty() {
+		record.ConditionalDataRaw = string(record.ConditionalData)
+	}
+	return record
+}
+
+// respondRecord writes a record, or the error
+func respondRecord(w http.ResponseWriter, record *snitchdns.Record, err error) {
+	if err != nil {
+		respond(w, nil, err)
+		return
+	}
+	respond(w, wireRecord(*record), nil)
+}
+
+// errorBody is a SnitchDNS error response
+type errorBody struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// respond writes v as JSON, or err as the SnitchDNS error response it
+// stands for. Errors of the in-memory API carry their status and body.
+func respond(w http.ResponseWriter, v any, err error) {
+	var apiErr *snitchdns.APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode != 0:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(apiErr.StatusCode)
+		_, _ = io.WriteString(w, apiErr.Body)
+	case err != nil:
+		writeJSON(w, http.StatusInternalServerError, errorBody{Message: err.Error()})
+	default:
+		writeJSON(w, http.StatusOK, v)
+	}
+}
+
+// decode decodes the JSON request body into v, answering 400 when it is
+// malformed
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{Code: snitchdns.ErrCodeMissingFields, Message: err.Error()})
+		return false
+	}
+	return true
+}
+
+// writeJSON writes v as a JSON response with the given status
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// queryInt returns a positive integer query parameter, or def when it is
+// missing or invalid
+func queryInt(r *http.Request, name string, def int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || value < 1 {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,114 @@
+package fakeserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// TestServer_Zones tests the zone routes through the API client
+func TestServer_Zones(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	defer s.Close()
+	c := snitchdns.NewClient(s.Endpoint(), APIKey)
+
+	info, err := c.GetServerInfo(ctx)
+	if err != nil || info.Version != Version {
+		t.Fatalf("Expected version %s, got %+v, %v", Version, info, err)
+	}
+
+	// More zones than fit on a page of the client's listing
+	for i := 0; i < 60; i++ {
+		if _, err := c.CreateZoneWithContext(ctx, snitchdns.CreateZoneRequest{Domain: fmt.Sprintf("zone%d.example.com", i), Active: true}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	zones, err := c.ListZonesWithContext(ctx)
+	if err != nil || len(zones) != 60 {
+		t.Fatalf("Expected 60 zones, got %d, %v", len(zones), err)
+	}
+	found, err := c.SearchZonesWithContext(ctx, "zone42.")
+	if err != nil || len(found) != 1 {
+		t.Errorf("Expected to find zone42, got %+v, %v", found, err)
+	}
+
+	// Duplicate domains fail with the SnitchDNS error code
+	_, err = c.CreateZoneWithContext(ctx, snitchdns.CreateZoneRequest{Domain: "zone1.example.com"})
+	var apiErr *snitchdns.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != snitchdns.ErrCodeZoneExists {
+		t.Errorf("Expected a zone exists error, got %v", err)
+	}
+
+	id := strconv.Itoa(zones[0].ID)
+	active := false
+	zone, err := c.UpdateZoneWithContext(ctx, id, snitchdns.UpdateZoneRequest{Active: &active, ExpectedUpdatedAt: zones[0].UpdatedAt})
+	if err != nil || zone.Active {
+		t.Errorf("Expected the zone to be deactivated, got %+v, %v", zone, err)
+	}
+
+	if err := c.DeleteZoneWithContext(ctx, id); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := c.GetZoneWithContext(ctx, id); !snitchdns.IsNotFound(err) {
+		t.Errorf("Expected not found after delete, got %v", err)
+	}
+}
+
+// TestServer_Records tests the record routes through the API client
+func TestServer_Records(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	defer s.Close()
+	c := snitchdns.NewClient(s.Endpoint(), APIKey)
+
+	zone, err := c.CreateZoneWithContext(ctx, snitchdns.CreateZoneRequest{Domain: "example.com", Active: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	zoneID := strconv.Itoa(zone.ID)
+
+	record, err := c.CreateRecordWithContext(ctx, zoneID, snitchdns.CreateRecordRequest{
+		Active: true, Class: "IN", Type: "SRV", TTL: 300,
+		Data: map[string]interface{}{"target": "sip.example.com", "port": 5060},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if record.Data.Get("target") != "sip.example.com" || record.Data.Get("port") != "5060" {
+		t.Errorf("Expected the data to round-trip, got %+v", record.Data)
+	}
+
+	ttl := 60
+	recordID := strconv.Itoa(record.ID)
+	if _, err := c.UpdateRecordWithContext(ctx, zoneID, recordID, snitchdns.UpdateRecordRequest{TTL: &ttl}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	records, err := c.ListRecordsWithContext(ctx, zoneID)
+	if err != nil || len(records) != 1 || records[0].TTL != 60 || records[0].Data.Get("target") != "sip.example.com" {
+		t.Errorf("Expected the updated record, got %+v, %v", records, err)
+	}
+
+	if err := c.DeleteRecordWithContext(ctx, zoneID, recordID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := c.GetRecordWithContext(ctx, zoneID, recordID); !snitchdns.IsNotFound(err) {
+		t.Errorf("Expected not found after delete, got %v", err)
+	}
+}
+
+// TestServer_Auth tests that requests without the API key are rejected
+func TestServer_Auth(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	c := snitchdns.NewClient(s.Endpoint(), "wrong")
+	if _, err := c.ListZonesWithContext(context.Background()); snitchdns.StatusCode(err) != http.StatusUnauthorized {
+		t.Errorf("Expected unauthorized, got %v", err)
+	}
+}
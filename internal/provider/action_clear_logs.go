@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/action/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &ClearLogsAction{}
+var _ action.ActionWithConfigure = &ClearLogsAction{}
+
+// NewClearLogsAction creates a new Clear Logs action.
+func NewClearLogsAction() action.Action {
+	return &ClearLogsAction{}
+}
+
+// ClearLogsAction defines the action implementation. It purges the query
+// log of a zone, for example between engagements that reuse the zone.
+type ClearLogsAction struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// ClearLogsActionModel describes the action data model.
+type ClearLogsActionModel struct {
+	ZoneID   types.String   `tfsdk:"zone_id"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the action type name.
+func (a *ClearLogsAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clear_logs"
+}
+
+// Schema defines the action schema.
+func (a *ClearLogsAction) Schema(ctx context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deletes the logged DNS queries of a zone. The zone and its records are kept. Requires Terraform 1.14 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone whose query log is cleared.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the action.
+func (a *ClearLogsAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = providerData.Client
+	a.offline = providerData.Offline
+}
+
+// Invoke implements the action logic
+func (a *ClearLogsAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if a.offline {
+		addOfflineError(&resp.Diagnostics, "clear query logs")
+		return
+	}
+
+	var data ClearLogsActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invokeTimeout, diags := data.Timeouts.Invoke(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, invokeTimeout)
+	defer cancel()
+
+	zoneID := data.ZoneID.ValueString()
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Clearing the query log of zone %s", zoneID),
+	})
+
+	err := a.client.ClearZoneLogs(ctx, zoneID)
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			resp.Diagnostics.AddAttributeError(path.Root("zone_id"), "Zone not found", fmt.Sprintf("Zone %s does not exist.", zoneID))
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error clearing query logs", "Could not clear the query log of zone "+zoneID, err)
+		return
+	}
+
+	tflog.Info(ctx, "Cleared zone query log", map[string]interface{}{
+		"zone_id": zoneID,
+	})
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Cleared the query log of zone %s", zoneID),
+	})
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccClearLogsAction tests that invoking the action empties the zone's
+// query log
+func TestAccClearLogsAction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClearLogsActionConfig(container, false),
+			},
+			{
+				PreConfig: func() {
+					testAccSendDNSQuery(t, container, "beacon.clear.example.com")
+				},
+				Config: testAccClearLogsActionConfig(container, true),
+				Check:  testAccCheckZoneLogsEmpty(container, "snitchdns_zone.test"),
+			},
+		},
+	})
+}
+
+// testAccCheckZoneLogsEmpty checks that no logged query was answered by the zone
+func testAccCheckZoneLogsEmpty(container *testcontainer.SnitchDNSContainer, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		zone, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		c := snitchdns.NewClient(container.GetAPIEndpoint(), container.APIKey)
+		page, err := c.SearchLogs(context.Background(), snitchdns.SearchParams{Domain: zone.Primary.Attributes["domain"]})
+		if err != nil {
+			return fmt.Errorf("failed to search logs: %w", err)
+		}
+		for _, log := range page.Results {
+			if fmt.Sprint(log.ZoneID) == zone.Primary.ID {
+				return fmt.Errorf("expected the query log of zone %s to be empty, found %s", zone.Primary.ID, log.Domain)
+			}
+		}
+		return nil
+	}
+}
+
+// testAccClearLogsActionConfig generates HCL configuration for clearing logs,
+// optionally with a resource whose creation triggers the action
+func testAccClearLogsActionConfig(container *testcontainer.SnitchDNSContainer, trigger bool) string {
+	config := fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain    = "clear.example.com"
+  active    = true
+  catch_all = true
+  regex     = false
+}
+
+action "snitchdns_clear_logs" "test" {
+  config {
+    zone_id = snitchdns_zone.test.id
+  }
+}
+`, container.GetAPIEndpoint(), container.APIKey)
+
+	if trigger {
+		config += `
+resource "terraform_data" "engagement" {
+  input = "next"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.snitchdns_clear_logs.test]
+    }
+  }
+}
+`
+	}
+	return config
+}
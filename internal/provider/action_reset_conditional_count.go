@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/action/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &ResetConditionalCountAction{}
+var _ action.ActionWithConfigure = &ResetConditionalCountAction{}
+
+// NewResetConditionalCountAction creates a new Reset Conditional Count action.
+func NewResetConditionalCountAction() action.Action {
+	return &ResetConditionalCountAction{}
+}
+
+// ResetConditionalCountAction defines the action implementation. It sets the
+// query count of a conditional record back to zero, so an exercise can start
+// over without recreating the record.
+type ResetConditionalCountAction struct {
+	client  snitchdns.ClientInterface
+	records *RecordCache
+	offline bool
+}
+
+// ResetConditionalCountActionModel describes the action data model.
+type ResetConditionalCountActionModel struct {
+	ZoneID   types.String   `tfsdk:"zone_id"`
+	RecordID types.String   `tfsdk:"record_id"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the action type name.
+func (a *ResetConditionalCountAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reset_conditional_count"
+}
+
+// Schema defines the action schema.
+func (a *ResetConditionalCountAction) Schema(ctx context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resets the query count of a conditional record to zero, so it answers with its regular data again until `conditional_limit` is reached. Requires Terraform 1.14 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone of the record.",
+			},
+			"record_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the conditional record whose count is reset.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the action.
+func (a *ResetConditionalCountAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = providerData.Client
+	a.records = providerData.Records
+	a.offline = providerData.Offline
+}
+
+// Invoke implements the action logic
+func (a *ResetConditionalCountAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if a.offline {
+		addOfflineError(&resp.Diagnostics, "reset conditional count")
+		return
+	}
+
+	var data ResetConditionalCountActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invokeTimeout, diags := data.Timeouts.Invoke(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, invokeTimeout)
+	defer cancel()
+
+	zoneID, recordID := data.ZoneID.ValueString(), data.RecordID.ValueString()
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Resetting the conditional count of record %s", recordID),
+	})
+
+	record, err := a.client.ResetConditionalCount(ctx, zoneID, recordID)
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			resp.Diagnostics.AddAttributeError(path.Root("record_id"), "Record not found",
+				fmt.Sprintf("Record %s does not exist in zone %s.", recordID, zoneID))
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error resetting conditional count", "Could not reset the conditional count of record "+recordID, err)
+		return
+	}
+	a.records.Invalidate(zoneID)
+
+	if !record.IsConditional {
+		resp.Diagnostics.AddAttributeWarning(path.Root("record_id"), "Record is not conditional",
+			fmt.Sprintf("Record %s has is_conditional = false, so its count has no effect on its answers.", recordID))
+	}
+
+	tflog.Info(ctx, "Reset conditional record count", map[string]interface{}{
+		"zone_id":   zoneID,
+		"record_id": recordID,
+	})
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Reset the conditional count of record %s", recordID),
+	})
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccResetConditionalCountAction tests that invoking the action sets the
+// count of a queried conditional record back to zero
+func TestAccResetConditionalCountAction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResetConditionalCountActionConfig(container, false),
+			},
+			{
+				PreConfig: func() {
+					testAccSendDNSQuery(t, container, "reset.example.com")
+					testAccSendDNSQuery(t, container, "reset.example.com")
+				},
+				Config: testAccResetConditionalCountActionConfig(container, true),
+				Check:  testAccCheckConditionalCount(container, "snitchdns_record.test", 0),
+			},
+		},
+	})
+}
+
+// testAccCheckConditionalCount checks the conditional count of a record on
+// the server
+func testAccCheckConditionalCount(container *testcontainer.SnitchDNSContainer, resourceName string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		c := snitchdns.NewClient(container.GetAPIEndpoint(), container.APIKey)
+		record, err := c.GetRecordWithContext(context.Background(), rs.Primary.Attributes["zone_id"], rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get record: %w", err)
+		}
+		if record.ConditionalCount != expected {
+			return fmt.Errorf("expected conditional count %d, got %d", expected, record.ConditionalCount)
+		}
+		return nil
+	}
+}
+
+// testAccResetConditionalCountActionConfig generates HCL configuration for
+// resetting a conditional record, optionally with a resource whose creation
+// triggers the action
+func testAccResetConditionalCountActionConfig(container *testcontainer.SnitchDNSContainer, trigger bool) string {
+	config := fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = "reset.example.com"
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_record" "test" {
+  zone_id = snitchdns_zone.test.id
+  active  = true
+  cls     = "IN"
+  type    = "A"
+  ttl     = 300
+  data    = { address = "10.0.0.1" }
+
+  is_conditional    = true
+  conditional_limit = 100
+  conditional_reset = false
+  conditional_data  = { address = "10.0.0.2" }
+}
+
+action "snitchdns_reset_conditional_count" "test" {
+  config {
+    zone_id   = snitchdns_record.test.zone_id
+    record_id = snitchdns_record.test.id
+  }
+}
+`, container.GetAPIEndpoint(), container.APIKey)
+
+	if trigger {
+		config += `
+resource "terraform_data" "exercise" {
+  input = "next"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.snitchdns_reset_conditional_count.test]
+    }
+  }
+}
+`
+	}
+	return config
+}
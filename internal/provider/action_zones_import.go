@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/action/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &ZonesImportAction{}
+var _ action.ActionWithConfigure = &ZonesImportAction{}
+var _ action.ActionWithValidateConfig = &ZonesImportAction{}
+
+// NewZonesImportAction creates a new Zones Import action.
+func NewZonesImportAction() action.Action {
+	return &ZonesImportAction{}
+}
+
+// ZonesImportAction defines the action implementation. It bulk-creates
+// zones from a CSV through the server's import endpoint, for migrations
+// from other DNS providers.
+type ZonesImportAction struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// ZonesImportActionModel describes the action data model.
+type ZonesImportActionModel struct {
+	Content      types.String   `tfsdk:"content"`
+	SkipExisting types.Bool     `tfsdk:"skip_existing"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the action type name.
+func (a *ZonesImportAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zones_import"
+}
+
+// Schema defines the action schema.
+func (a *ZonesImportAction) Schema(ctx context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates zones in bulk from a CSV in the SnitchDNS zone format, through the server's import endpoint. " +
+			"The zones are not managed by Terraform afterwards. Requires Terraform 1.14 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"content": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "CSV with a header row naming the columns: `domain`, which is required, and the optional " +
+					"`active`, `catch_all`, `forwarding`, `regex`, `master` and `tags`, the latter comma-separated. Other columns are ignored.",
+			},
+			"skip_existing": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Leave out rows whose domain already exists, so the action can run again after a partial migration. " +
+					"Defaults to `false`, in which case the server rejects the whole import when any domain exists.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the action.
+func (a *ZonesImportAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = providerData.Client
+	a.offline = providerData.Offline
+}
+
+// ValidateConfig parses a known CSV, so malformed content fails at plan time
+func (a *ZonesImportAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var content types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content"), &content)...)
+	if resp.Diagnostics.HasError() || content.IsNull() || content.IsUnknown() {
+		return
+	}
+
+	if _, err := snitchdns.ReadZonesCSV(strings.NewReader(content.ValueString())); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid Zone CSV", err.Error())
+	}
+}
+
+// Invoke implements the action logic
+func (a *ZonesImportAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if a.offline {
+		addOfflineError(&resp.Diagnostics, "import zones")
+		return
+	}
+
+	var data ZonesImportActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invokeTimeout, diags := data.Timeouts.Invoke(ctx, 10*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, invokeTimeout)
+	defer cancel()
+
+	zones, err := snitchdns.ReadZonesCSV(strings.NewReader(data.Content.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid Zone CSV", err.Error())
+		return
+	}
+
+	if data.SkipExisting.ValueBool() {
+		existing, err := a.client.ListZonesWithContext(ctx)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error listing zones", "Could not list the existing zones", err)
+			return
+		}
+
+		var missing []snitchdns.CreateZoneRequest
+		for _, zone := range zones {
+			if _, ok := snitchdns.MatchZoneDomain(existing, zone.Domain); !ok {
+				missing = append(missing, zone)
+			}
+		}
+		if skipped := len(zones) - len(missing); skipped > 0 {
+			resp.SendProgress(action.InvokeProgressEvent{
+				Message: fmt.Sprintf("Skipping %d of %d zones that already exist", skipped, len(zones)),
+			})
+		}
+		zones = missing
+	}
+
+	if len(zones) == 0 {
+		resp.SendProgress(action.InvokeProgressEvent{Message: "No zones to import"})
+		return
+	}
+
+	var csv bytes.Buffer
+	if err := snitchdns.WriteZonesCSV(&csv, zones); err != nil {
+		resp.Diagnostics.AddError("Error importing zones", fmt.Sprintf("Could not write the zone CSV: %s", err))
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Importing %d zones", len(zones)),
+	})
+
+	if err := a.client.ImportZonesCSV(ctx, &csv); err != nil {
+		addAPIError(&resp.Diagnostics, "Error importing zones", fmt.Sprintf("Could not import %d zones", len(zones)), err)
+		return
+	}
+
+	tflog.Info(ctx, "Imported zones", map[string]interface{}{
+		"count": len(zones),
+	})
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Imported %d zones", len(zones)),
+	})
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/acctest"
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccZonesImportAction tests that invoking the action creates the zones
+// of the CSV
+func TestAccZonesImportAction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZonesImportActionConfig(container),
+				Check:  acctest.CheckZonesExist(container, "imported-a.example.com", "imported-b.example.com"),
+			},
+		},
+	})
+}
+
+// testAccZonesImportActionConfig generates HCL configuration for a zone
+// import triggered by creating a resource
+func testAccZonesImportActionConfig(container *testcontainer.SnitchDNSContainer) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+action "snitchdns_zones_import" "test" {
+  config {
+    content       = <<-CSV
+      domain,active,catch_all,tags
+      imported-a.example.com,true,false,migrated
+      imported-b.example.com,true,true,migrated
+    CSV
+    skip_existing = true
+  }
+}
+
+resource "terraform_data" "migration" {
+  input = "once"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.snitchdns_zones_import.test]
+    }
+  }
+}
+`, container.GetAPIEndpoint(), container.APIKey)
+}
+
+// TestZonesImportAction_Mock tests that existing domains are skipped and the
+// rest imported
+func TestZonesImportAction_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	mock.AddZone(snitchdns.Zone{Domain: "example.org"})
+
+	a := NewZonesImportAction().(*ZonesImportAction)
+	var configureResp action.ConfigureResponse
+	a.Configure(ctx, action.ConfigureRequest{ProviderData: &ProviderData{Client: mock}}, &configureResp)
+
+	var schemaResp action.SchemaResponse
+	a.Schema(ctx, action.SchemaRequest{}, &schemaResp)
+
+	config := func(content string, skipExisting bool) tfsdk.Config {
+		raw := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"content":       tftypes.NewValue(tftypes.String, content),
+			"skip_existing": tftypes.NewValue(tftypes.Bool, skipExisting),
+			"timeouts":      tftypes.NewValue(schemaResp.Schema.Blocks["timeouts"].Type().TerraformType(ctx), nil),
+		})
+		return tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}
+	}
+
+	// Malformed content fails at plan time
+	var validateResp action.ValidateConfigResponse
+	a.ValidateConfig(ctx, action.ValidateConfigRequest{Config: config("active\ntrue\n", false)}, &validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Error("Expected an error for a CSV without a domain column")
+	}
+
+	csv := "domain,active\nexample.com,true\nexample.org,true\n"
+
+	var invokeResp action.InvokeResponse
+	invokeResp.SendProgress = func(action.InvokeProgressEvent) {}
+	a.Invoke(ctx, action.InvokeRequest{Config: config(csv, false)}, &invokeResp)
+	if !invokeResp.Diagnostics.HasError() || len(mock.Zones()) != 1 {
+		t.Fatalf("Expected the server to reject an existing domain, got %v and %+v", invokeResp.Diagnostics, mock.Zones())
+	}
+
+	invokeResp = action.InvokeResponse{SendProgress: func(action.InvokeProgressEvent) {}}
+	a.Invoke(ctx, action.InvokeRequest{Config: config(csv, true)}, &invokeResp)
+	if invokeResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected invoke error: %v", invokeResp.Diagnostics)
+	}
+	if zones := mock.Zones(); len(zones) != 2 || zones[1].Domain != "example.com" || !zones[1].Active {
+		t.Errorf("Expected example.com to be imported, got %+v", zones)
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiKeyCommandTimeout bounds a run of the api_key_command credential helper
+const apiKeyCommandTimeout = 30 * time.Second
+
+// maxCommandErrorOutput is the number of bytes of the credential helper's
+// standard error included in the error when it fails
+const maxCommandErrorOutput = 1024
+
+// apiKeyFromSource reads the API key from api_key_file or runs
+// api_key_command for it. It returns an empty key when neither is set.
+// Surrounding whitespace, such as a trailing newline, is removed.
+func apiKeyFromSource(ctx context.Context, data SnitchDNSProviderModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if file := data.APIKeyFile.ValueString(); file != "" {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			diags.AddAttributeError(path.Root("api_key_file"), "Unreadable API Key File",
+				fmt.Sprintf("Could not read %s: %s", file, err))
+			return "", diags
+		}
+
+		key := strings.TrimSpace(string(contents))
+		if key == "" {
+			diags.AddAttributeError(path.Root("api_key_file"), "Empty API Key File",
+				fmt.Sprintf("%s does not contain an API key.", file))
+		}
+		return key, diags
+	}
+
+	if data.APIKeyCommand.IsNull() || data.APIKeyCommand.IsUnknown() {
+		return "", diags
+	}
+
+	var argv []string
+	diags.Append(data.APIKeyCommand.ElementsAs(ctx, &argv, false)...)
+	if diags.HasError() || len(argv) == 0 {
+		return "", diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiKeyCommandTimeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Running API key command", map[string]any{
+		"command": argv[0],
+	})
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		detail := fmt.Sprintf("Running %s failed: %s", argv[0], err)
+		if output := strings.TrimSpace(stderr.String()); output != "" {
+			if len(output) > maxCommandErrorOutput {
+				output = output[:maxCommandErrorOutput] + "..."
+			}
+			detail += "\n\n" + output
+		}
+		diags.AddAttributeError(path.Root("api_key_command"), "API Key Command Failed", detail)
+		return "", diags
+	}
+
+	key := strings.TrimSpace(stdout.String())
+	if key == "" {
+		diags.AddAttributeError(path.Root("api_key_command"), "Empty API Key",
+			fmt.Sprintf("%s did not print an API key.", argv[0]))
+	}
+	return key, diags
+}
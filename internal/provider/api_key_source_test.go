@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestAPIKeyFromSource tests reading the API key from a file and from a
+// credential helper
+func TestAPIKeyFromSource(t *testing.T) {
+	ctx := context.Background()
+
+	file := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(file, []byte("file-key\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+
+	command := func(argv ...string) types.List {
+		values := make([]attr.Value, 0, len(argv))
+		for _, arg := range argv {
+			values = append(values, types.StringValue(arg))
+		}
+		return types.ListValueMust(types.StringType, values)
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		key, diags := apiKeyFromSource(ctx, SnitchDNSProviderModel{APIKeyCommand: types.ListNull(types.StringType)})
+		if diags.HasError() || key != "" {
+			t.Errorf("Expected no key, got %q, %v", key, diags)
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		key, diags := apiKeyFromSource(ctx, SnitchDNSProviderModel{APIKeyFile: types.StringValue(file)})
+		if diags.HasError() || key != "file-key" {
+			t.Errorf("Expected file-key, got %q, %v", key, diags)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, diags := apiKeyFromSource(ctx, SnitchDNSProviderModel{APIKeyFile: types.StringValue(file + ".missing")})
+		if !diags.HasError() {
+			t.Error("Expected an error for a missing file")
+		}
+	})
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("No shell to run credential helpers with")
+	}
+
+	t.Run("command", func(t *testing.T) {
+		key, diags := apiKeyFromSource(ctx, SnitchDNSProviderModel{APIKeyCommand: command("sh", "-c", "echo '  command-key  '")})
+		if diags.HasError() || key != "command-key" {
+			t.Errorf("Expected command-key, got %q, %v", key, diags)
+		}
+	})
+
+	t.Run("failing command", func(t *testing.T) {
+		_, diags := apiKeyFromSource(ctx, SnitchDNSProviderModel{APIKeyCommand: command("sh", "-c", "echo 'vault sealed' >&2; exit 2")})
+		if !diags.HasError() {
+			t.Fatal("Expected an error for a failing command")
+		}
+		if detail := diags[0].Detail(); !strings.Contains(detail, "vault sealed") {
+			t.Errorf("Expected the command's error output in the detail, got %q", detail)
+		}
+	})
+
+	t.Run("empty output", func(t *testing.T) {
+		_, diags := apiKeyFromSource(ctx, SnitchDNSProviderModel{APIKeyCommand: command("sh", "-c", "true")})
+		if !diags.HasError() {
+			t.Error("Expected an error for a command printing nothing")
+		}
+	})
+}
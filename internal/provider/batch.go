@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// defaultBatchParallelism is the number of API operations a bulk resource
+// runs at the same time unless configured otherwise
+const defaultBatchParallelism = snitchdns.DefaultParallelism
+
+// batchOperation is a single API call performed as part of a bulk apply
+type batchOperation struct {
+	// Description identifies the operation in diagnostics, e.g. "create A www"
+	Description string
+	Run         func(ctx context.Context) error
+}
+
+// batchResult is the outcome of a batchOperation
+type batchResult struct {
+	Description string
+	Err         error
+}
+
+// runBatch executes the operations with snitchdns.RunParallel, at most
+// parallelism running concurrently. Every operation is attempted even when
+// others fail, so the caller can persist what succeeded; results are returned
+// in the order of the operations. Operations not yet started when ctx is
+// cancelled fail with the context error.
+func runBatch(ctx context.Context, ops []batchOperation, parallelism int) []batchResult {
+	if parallelism < 1 {
+		parallelism = defaultBatchParallelism
+	}
+
+	errs := snitchdns.RunParallel(ctx, len(ops), parallelism, func(ctx context.Context, i int) error {
+		return ops[i].Run(ctx)
+	})
+
+	results := make([]batchResult, len(ops))
+	for i, op := range ops {
+		results[i] = batchResult{Description: op.Description, Err: errs[i]}
+	}
+	return results
+}
+
+// runPhases runs groups of operations one after the other, each with
+// runBatch, so that every operation of a phase has finished before the next
+// phase starts. Like within a phase, later phases run even when operations of
+// an earlier one failed. Results are returned in the order of the operations.
+func runPhases(ctx context.Context, parallelism int, phases ...[]batchOperation) []batchResult {
+	var results []batchResult
+	for _, ops := range phases {
+		results = append(results, runBatch(ctx, ops, parallelism)...)
+	}
+	return results
+}
+
+// Recovery advice of partialFailureDiagnostics, for a create and an update
+// that failed after some of their operations were applied
+const (
+	partialCreateRecovery = "The resource was saved to state with what was applied. Terraform marks it as tainted, " +
+		"so the next apply destroys and creates it again. To keep what was applied instead, fix the cause of the failures " +
+		"and run terraform untaint on the resource; the next apply then only makes the remaining changes."
+	partialUpdateRecovery = "The state was updated with what was applied, so the next plan shows the remaining changes. " +
+		"Fix the cause of the failures and apply again."
+)
+
+// batchDiagnostics summarizes failed operations. A single error diagnostic is
+// returned listing every failure along with how many operations succeeded, so
+// users can tell a partial apply from a total failure.
+func batchDiagnostics(summary string, results []batchResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, fmt.Sprintf("  - %s: %s", result.Description, result.Err))
+		}
+	}
+
+	if len(failed) == 0 {
+		return diags
+	}
+
+	diags.AddError(
+		summary,
+		fmt.Sprintf("%d of %d operations failed, %d succeeded:\n%s",
+			len(failed), len(results), len(results)-len(failed), strings.Join(failed, "\n")),
+	)
+	return diags
+}
+
+// partialFailureDiagnostics summarizes failed operations like
+// batchDiagnostics. When some operations were applied before others
+// failed, a warning lists them together with the recovery advice, as the
+// caller saves them to state instead of losing track of them.
+func partialFailureDiagnostics(summary, recovery string, results []batchResult) diag.Diagnostics {
+	diags := batchDiagnostics(summary, results)
+	if !diags.HasError() || !anyApplied(results) {
+		return diags
+	}
+
+	var applied []string
+	for _, result := range results {
+		if result.Err == nil {
+			applied = append(applied, "  - "+result.Description)
+		}
+	}
+	diags.AddWarning(
+		"Partially Applied",
+		fmt.Sprintf("%d of %d operations were applied before the failure and are recorded in state:\n%s\n\n%s",
+			len(applied), len(results), strings.Join(applied, "\n"), recovery),
+	)
+	return diags
+}
+
+// anyApplied reports whether at least one of the operations succeeded
+func anyApplied(results []batchResult) bool {
+	for _, result := range results {
+		if result.Err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRunBatch_BoundedParallelism tests that no more than the configured number of operations run at once
+func TestRunBatch_BoundedParallelism(t *testing.T) {
+	var running, peak atomic.Int32
+
+	ops := make([]batchOperation, 20)
+	for i := range ops {
+		ops[i] = batchOperation{
+			Description: fmt.Sprintf("op %d", i),
+			Run: func(context.Context) error {
+				current := running.Add(1)
+				for {
+					old := peak.Load()
+					if current <= old || peak.CompareAndSwap(old, current) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+				return nil
+			},
+		}
+	}
+
+	results := runBatch(context.Background(), ops, 4)
+
+	if len(results) != 20 {
+		t.Fatalf("Expected 20 results, got %d", len(results))
+	}
+	if peak.Load() > 4 {
+		t.Errorf("Expected at most 4 concurrent operations, got %d", peak.Load())
+	}
+	if diags := batchDiagnostics("Bulk apply failed", results); diags.HasError() {
+		t.Errorf("Expected no diagnostics, got %v", diags)
+	}
+}
+
+// TestRunBatch_PartialFailure tests that failures are reported alongside the successful operations
+func TestRunBatch_PartialFailure(t *testing.T) {
+	ops := []batchOperation{
+		{Description: "create A www", Run: func(context.Context) error { return nil }},
+		{Description: "create A api", Run: func(context.Context) error { return errors.New("status 500") }},
+		{Description: "delete TXT old", Run: func(context.Context) error { return nil }},
+	}
+
+	results := runBatch(context.Background(), ops, 2)
+
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Errorf("Expected successful operations to have no error, got %v and %v", results[0].Err, results[2].Err)
+	}
+	if results[1].Err == nil {
+		t.Fatal("Expected failing operation to report its error")
+	}
+
+	diags := batchDiagnostics("Bulk apply failed", results)
+	if !diags.HasError() {
+		t.Fatal("Expected an error diagnostic")
+	}
+
+	detail := diags[0].Detail()
+	if !strings.Contains(detail, "1 of 3 operations failed, 2 succeeded") || !strings.Contains(detail, "create A api: status 500") {
+		t.Errorf("Unexpected diagnostic detail: %s", detail)
+	}
+
+	// The applied operations are listed with the recovery advice
+	diags = partialFailureDiagnostics("Bulk apply failed", partialUpdateRecovery, results)
+	warnings := diags.Warnings()
+	if !diags.HasError() || len(warnings) != 1 {
+		t.Fatalf("Expected an error and a warning, got %v", diags)
+	}
+	if detail := warnings[0].Detail(); !strings.Contains(detail, "2 of 3 operations were applied") || !strings.Contains(detail, "  - delete TXT old") || !strings.Contains(detail, partialUpdateRecovery) {
+		t.Errorf("Unexpected warning detail: %s", detail)
+	}
+
+	// Nothing was applied when every operation failed
+	results[0].Err, results[2].Err = results[1].Err, results[1].Err
+	if diags := partialFailureDiagnostics("Bulk apply failed", partialUpdateRecovery, results); len(diags.Warnings()) != 0 {
+		t.Errorf("Expected no warning, got %v", diags)
+	}
+}
+
+// TestRunPhases tests that no operation of a phase starts before every
+// operation of the previous phase has finished, even after failures
+func TestRunPhases(t *testing.T) {
+	var finished atomic.Int32
+
+	phase := func(name string, n int, wantFinished int32, fail bool) []batchOperation {
+		ops := make([]batchOperation, n)
+		for i := range ops {
+			ops[i] = batchOperation{
+				Description: fmt.Sprintf("%s %d", name, i),
+				Run: func(context.Context) error {
+					if got := finished.Load(); got < wantFinished {
+						t.Errorf("%s %d started after %d operations finished, want %d", name, i, got, wantFinished)
+					}
+					time.Sleep(time.Duration(i) * time.Millisecond)
+					finished.Add(1)
+					if fail {
+						return errors.New("failed")
+					}
+					return nil
+				},
+			}
+		}
+		return ops
+	}
+
+	results := runPhases(context.Background(), 4,
+		phase("delete", 6, 0, true),
+		phase("update", 3, 6, false),
+		phase("create", 5, 9, false),
+	)
+
+	if len(results) != 14 {
+		t.Fatalf("Expected 14 results, got %d", len(results))
+	}
+	if results[0].Description != "delete 0" || results[13].Description != "create 4" {
+		t.Errorf("Expected results in the order of the operations, got %q first and %q last", results[0].Description, results[13].Description)
+	}
+	if results[5].Err == nil || results[6].Err != nil {
+		t.Errorf("Expected the deletes to fail and the updates to succeed, got %v and %v", results[5].Err, results[6].Err)
+	}
+}
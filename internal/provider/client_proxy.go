@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// proxySchemes are the proxy URL schemes the client's transport supports
+var proxySchemes = []string{"http", "https", "socks5", "socks5h"}
+
+// clientProxyURL parses the proxy_url attribute. It returns nil when the
+// attribute is unset, so the proxy environment variables apply.
+func clientProxyURL(data SnitchDNSProviderModel) (*url.URL, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	raw := data.ProxyURL.ValueString()
+	if raw == "" {
+		return nil, diags
+	}
+
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		diags.AddAttributeError(path.Root("proxy_url"), "Invalid Proxy URL",
+			fmt.Sprintf("The proxy URL could not be parsed: %s", err))
+		return nil, diags
+	}
+
+	supported := false
+	for _, scheme := range proxySchemes {
+		supported = supported || proxyURL.Scheme == scheme
+	}
+	if !supported || proxyURL.Host == "" {
+		diags.AddAttributeError(path.Root("proxy_url"), "Invalid Proxy URL",
+			fmt.Sprintf("%s is not a proxy URL: expected a scheme of http, https, socks5 or socks5h and a host, e.g. http://proxy.example.com:3128.", proxyURL.Redacted()))
+		return nil, diags
+	}
+
+	return proxyURL, diags
+}
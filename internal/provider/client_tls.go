@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// clientTLSConfig builds the TLS configuration for the TLS attributes. It
+// returns nil when none is set, so the client keeps the default transport.
+func clientTLSConfig(data SnitchDNSProviderModel) (*tls.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	caPEM := data.CACertPEM.ValueString()
+	if file := data.CACertFile.ValueString(); file != "" {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			diags.AddAttributeError(path.Root("ca_cert_file"), "Unreadable CA Certificate File",
+				fmt.Sprintf("Could not read %s: %s", file, err))
+			return nil, diags
+		}
+		caPEM = string(contents)
+	}
+
+	clientCertPEM := data.ClientCertPEM.ValueString()
+	clientKeyPEM := data.ClientKeyPEM.ValueString()
+	insecure := data.TLSInsecureSkipVerify.ValueBool()
+
+	if caPEM == "" && clientCertPEM == "" && !insecure {
+		return nil, diags
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caPEM != "" {
+		// Trust the custom CA in addition to the system roots, so a CA bundle
+		// for an internal proxy does not break public certificates
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			attribute := "ca_cert_pem"
+			if !data.CACertFile.IsNull() {
+				attribute = "ca_cert_file"
+			}
+			diags.AddAttributeError(path.Root(attribute), "Invalid CA Certificate",
+				"No PEM encoded certificate was found in the CA bundle.")
+			return nil, diags
+		}
+		config.RootCAs = pool
+	}
+
+	if clientCertPEM != "" {
+		certificate, err := tls.X509KeyPair([]byte(clientCertPEM), []byte(clientKeyPEM))
+		if err != nil {
+			diags.AddAttributeError(path.Root("client_cert_pem"), "Invalid Client Certificate",
+				fmt.Sprintf("The client certificate and key could not be loaded: %s", err))
+			return nil, diags
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	if insecure {
+		// Explicitly requested; the user is warned below
+		config.InsecureSkipVerify = true
+		diags.AddAttributeWarning(path.Root("tls_insecure_skip_verify"), "TLS Verification Disabled",
+			"The certificate of the SnitchDNS API is not verified, so the API key can be intercepted. "+
+				"Use ca_cert_pem or ca_cert_file to trust an internal CA instead.")
+	}
+
+	return config, diags
+}
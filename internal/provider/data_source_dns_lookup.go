@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/internal/dnslookup"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DNSLookupDataSource{}
+
+// dnsLookupAnswerAttrTypes are the attribute types of an answer
+var dnsLookupAnswerAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+	"type": types.StringType,
+	"cls":  types.StringType,
+	"ttl":  types.Int64Type,
+	"data": types.MapType{ElemType: types.StringType},
+}
+
+// NewDNSLookupDataSource creates a new DNS Lookup data source.
+func NewDNSLookupDataSource() datasource.DataSource {
+	return &DNSLookupDataSource{}
+}
+
+// DNSLookupDataSource defines the data source implementation. It sends a
+// DNS query rather than calling the API, so modules can check that a record
+// actually resolves.
+type DNSLookupDataSource struct {
+	server  string
+	offline bool
+}
+
+// DNSLookupDataSourceModel describes the data source data model.
+type DNSLookupDataSourceModel struct {
+	Name          types.String   `tfsdk:"name"`
+	Type          types.String   `tfsdk:"type"`
+	Server        types.String   `tfsdk:"server"`
+	Protocol      types.String   `tfsdk:"protocol"`
+	RCode         types.String   `tfsdk:"rcode"`
+	Authoritative types.Bool     `tfsdk:"authoritative"`
+	Answers       types.List     `tfsdk:"answers"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the data source type name.
+func (d *DNSLookupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_lookup"
+}
+
+// Schema defines the data source schema.
+func (d *DNSLookupDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	supported := dnslookup.SupportedTypes()
+	slices.Sort(supported)
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends a DNS query and returns the answers, so a configuration can check that a record actually resolves " +
+			"before infrastructure depending on it is created. Queries go to the SnitchDNS DNS daemon unless another server is given.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name to query, e.g. `www.example.com`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: fmt.Sprintf("Record type to query, one of %s. Defaults to `A`.",
+					"`"+strings.Join(supported, "`, `")+"`"),
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(supported...),
+				},
+			},
+			"server": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Server to query, as a host name or IP address with an optional port (default `53`), e.g. `192.0.2.1:5353`. " +
+					"Defaults to the provider's `dns_check_address`, or the host of `api_url`.",
+			},
+			"protocol": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Transport of the query, `udp` or `tcp`. UDP queries are repeated over TCP when the response is truncated. Defaults to `udp`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(dnslookup.ProtocolUDP, dnslookup.ProtocolTCP),
+				},
+			},
+			"rcode": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Response code, such as `NOERROR`, `NXDOMAIN` or `REFUSED`.",
+			},
+			"authoritative": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the server answered authoritatively.",
+			},
+			"answers": schema.ListNestedAttribute{
+				Computed: true,
+				MarkdownDescription: "Records of the answer section in response order, including any CNAME records leading to the answer. " +
+					"Empty when the name does not resolve.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Fully qualified owner name with a trailing dot.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Record type.",
+						},
+						"cls": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Record class.",
+						},
+						"ttl": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Time to live in seconds.",
+						},
+						"data": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "Record data in the format of the `snitchdns_record` `data` attribute.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+// Configure adds the provider's DNS server to the data source.
+func (d *DNSLookupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.server = providerData.DNSServer
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *DNSLookupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DNSLookupDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An explicit server is not the SnitchDNS server, so it may be queried
+	// in offline mode
+	server := data.Server.ValueString()
+	if server == "" {
+		if d.offline {
+			addOfflineError(&resp.Diagnostics, "query the SnitchDNS DNS daemon")
+			return
+		}
+		server = d.server
+	}
+	if server == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("server"),
+			"Missing DNS Server",
+			"No server to query: set server, or dns_check_address in the provider configuration.",
+		)
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, dnslookup.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	if data.Type.IsNull() {
+		data.Type = types.StringValue("A")
+	}
+	data.Type = types.StringValue(strings.ToUpper(data.Type.ValueString()))
+
+	query := dnslookup.Query{
+		Server:   server,
+		Name:     data.Name.ValueString(),
+		Type:     data.Type.ValueString(),
+		Protocol: data.Protocol.ValueString(),
+	}
+	tflog.Debug(ctx, "Sending DNS query", map[string]any{
+		"server": query.Server,
+		"name":   query.Name,
+		"type":   query.Type,
+	})
+
+	started := time.Now()
+	result, err := dnslookup.Lookup(ctx, query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error querying DNS server",
+			fmt.Sprintf("Could not look up %s %s at %s: %s", query.Name, query.Type, server, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Received DNS response", map[string]any{
+		"rcode":    result.RCode,
+		"answers":  len(result.Answers),
+		"duration": time.Since(started).String(),
+	})
+
+	answers, diags := dnsAnswersValue(ctx, result.Answers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.RCode = types.StringValue(result.RCode)
+	data.Authoritative = types.BoolValue(result.Authoritative)
+	data.Answers = answers
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dnsAnswersValue converts answers to the list value of the answers attribute
+func dnsAnswersValue(ctx context.Context, answers []dnslookup.Record) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]attr.Value, 0, len(answers))
+	for _, answer := range answers {
+		recordData, d := types.MapValueFrom(ctx, types.StringType, answer.Data)
+		diags.Append(d...)
+
+		value, d := types.ObjectValue(dnsLookupAnswerAttrTypes, map[string]attr.Value{
+			"name": types.StringValue(answer.Name),
+			"type": types.StringValue(answer.Type),
+			"cls":  types.StringValue(answer.Class),
+			"ttl":  types.Int64Value(int64(answer.TTL)),
+			"data": recordData,
+		})
+		diags.Append(d...)
+		values = append(values, value)
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: dnsLookupAnswerAttrTypes}, values)
+	diags.Append(d...)
+	return list, diags
+}
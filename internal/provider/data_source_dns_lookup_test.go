@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// TestAccDNSLookupDataSource tests resolving a record through the SnitchDNS daemon
+func TestAccDNSLookupDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	dnsAddress, err := container.DNSAddress(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get DNS address: %v", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSLookupDataSourceConfig(container, dnsAddress),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.snitchdns_dns_lookup.test", "rcode", "NOERROR"),
+					resource.TestCheckResourceAttr("data.snitchdns_dns_lookup.test", "answers.#", "1"),
+					resource.TestCheckResourceAttr("data.snitchdns_dns_lookup.test", "answers.0.type", "A"),
+					resource.TestCheckResourceAttr("data.snitchdns_dns_lookup.test", "answers.0.data.address", "192.0.2.10"),
+				),
+			},
+		},
+	})
+}
+
+// testAccDNSLookupDataSourceConfig generates HCL configuration querying a
+// freshly created record
+func testAccDNSLookupDataSourceConfig(container *testcontainer.SnitchDNSContainer, server string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = "lookup.example.com"
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_record" "test" {
+  zone_id = snitchdns_zone.test.id
+  type    = "A"
+  cls     = "IN"
+  ttl     = 300
+  active  = true
+
+  data = {
+    address = "192.0.2.10"
+  }
+}
+
+data "snitchdns_dns_lookup" "test" {
+  name   = snitchdns_zone.test.domain
+  server = %[3]q
+
+  depends_on = [snitchdns_record.test]
+}
+`, container.GetAPIEndpoint(), container.APIKey, server)
+}
+
+// TestDNSLookupDataSource tests the default server and answer conversion
+// against a local DNS server
+func TestDNSLookupDataSource(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	go func() {
+		buf := make([]byte, 512)
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		var p dnsmessage.Parser
+		header, err := p.Start(buf[:n])
+		if err != nil {
+			return
+		}
+		question, _ := p.Question()
+		builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true})
+		_ = builder.StartQuestions()
+		_ = builder.Question(question)
+		_ = builder.StartAnswers()
+		_ = builder.MXResource(dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeMX, Class: dnsmessage.ClassINET, TTL: 3600},
+			dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mail.example.com.")})
+		msg, _ := builder.Finish()
+		conn.WriteTo(msg, addr)
+	}()
+
+	d := NewDNSLookupDataSource()
+	var configureResp datasource.ConfigureResponse
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{
+		DNSServer: conn.LocalAddr().String(),
+	}}, &configureResp)
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	// Config has no setters, so build the value through a state
+	state := tfsdk.State{Schema: config.Schema, Raw: config.Raw}
+	state.SetAttribute(ctx, path.Root("name"), types.StringValue("example.com"))
+	state.SetAttribute(ctx, path.Root("type"), types.StringValue("mx"))
+	config.Raw = state.Raw
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", resp.Diagnostics)
+	}
+
+	var data DNSLookupDataSourceModel
+	resp.State.Get(ctx, &data)
+	if data.RCode.ValueString() != "NOERROR" || !data.Authoritative.ValueBool() || data.Type.ValueString() != "MX" {
+		t.Errorf("Unexpected response: %+v", data)
+	}
+
+	var answers []struct {
+		Name string            `tfsdk:"name"`
+		Type string            `tfsdk:"type"`
+		Cls  string            `tfsdk:"cls"`
+		TTL  int64             `tfsdk:"ttl"`
+		Data map[string]string `tfsdk:"data"`
+	}
+	data.Answers.ElementsAs(ctx, &answers, false)
+	if len(answers) != 1 || answers[0].Name != "example.com." || answers[0].Data["hostname"] != "mail.example.com." || answers[0].Data["priority"] != "10" {
+		t.Errorf("Unexpected answers: %+v", answers)
+	}
+}
+
+// TestDNSServerAddress tests the default DNS server derived from the provider configuration
+func TestDNSServerAddress(t *testing.T) {
+	tests := []struct {
+		checkAddress, apiURL, want string
+	}{
+		{"192.0.2.1:5353", "https://dns.example.com/api/v1", "192.0.2.1:5353"},
+		{"", "https://dns.example.com:8443/api/v1", "dns.example.com"},
+		{"", "http://[2001:db8::1]/api", "2001:db8::1"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		if got := dnsServerAddress(tt.checkAddress, tt.apiURL); got != tt.want {
+			t.Errorf("dnsServerAddress(%q, %q) = %q, want %q", tt.checkAddress, tt.apiURL, got, tt.want)
+		}
+	}
+}
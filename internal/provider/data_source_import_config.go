@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ImportConfigDataSource{}
+
+// NewImportConfigDataSource creates a new Import Config data source.
+func NewImportConfigDataSource() datasource.DataSource {
+	return &ImportConfigDataSource{}
+}
+
+// ImportConfigDataSource defines the data source implementation.
+type ImportConfigDataSource struct {
+	records    *RecordCache
+	zones      *ZoneResolver
+	recordData *recordDataMapper
+	offline    bool
+}
+
+// ImportConfigDataSourceModel describes the data source data model.
+type ImportConfigDataSourceModel struct {
+	Domains      types.List   `tfsdk:"domains"`
+	ImportBlocks types.String `tfsdk:"import_blocks"`
+	Config       types.String `tfsdk:"config"`
+	ZoneCount    types.Int64  `tfsdk:"zone_count"`
+	RecordCount  types.Int64  `tfsdk:"record_count"`
+}
+
+// Metadata sets the data source type name.
+func (d *ImportConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_import_config"
+}
+
+// Schema defines the data source schema.
+func (d *ImportConfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Enumerates the zones and records on the SnitchDNS server and generates `import` blocks plus skeleton resource configuration, " +
+			"so an existing deployment can be brought under Terraform management in one pass.",
+
+		Attributes: map[string]schema.Attribute{
+			"domains": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Only generate configuration for these zone domains. All zones visible to the API key are included when omitted.",
+			},
+			"import_blocks": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Terraform `import` blocks for every zone and record.",
+			},
+			"config": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Skeleton `snitchdns_zone` and `snitchdns_record` resource blocks matching `import_blocks`.",
+			},
+			"zone_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of zones included.",
+			},
+			"record_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of records included.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *ImportConfigDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.records = providerData.Records
+	d.zones = providerData.Zones
+	d.recordData = providerData.RecordData
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *ImportConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "read import configuration")
+		return
+	}
+
+	var data ImportConfigDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zones, err := d.zones.ListZones(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error listing zones", "Could not list zones", err)
+		return
+	}
+
+	if !data.Domains.IsNull() {
+		var domains []string
+		resp.Diagnostics.Append(data.Domains.ElementsAs(ctx, &domains, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		zones = filterZonesByDomain(zones, domains)
+	}
+
+	var targets []importZone
+	var recordCount int
+	for _, zone := range zones {
+		zoneID := strconv.Itoa(zone.ID)
+
+		records, err := d.records.ListRecords(ctx, zoneID)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error listing records",
+				fmt.Sprintf("Could not list records of zone ID %s", zoneID), err)
+			return
+		}
+
+		for i := range records {
+			records[i] = *d.recordData.FromServerRecord(&records[i])
+		}
+
+		targets = append(targets, importZone{Zone: zone, Records: records})
+		recordCount += len(records)
+	}
+
+	tflog.Debug(ctx, "Generating import configuration", map[string]any{
+		"zones":   len(targets),
+		"records": recordCount,
+	})
+
+	generated := generateImportConfig(targets)
+
+	data.ImportBlocks = types.StringValue(generated.ImportBlocks)
+	data.Config = types.StringValue(generated.Config)
+	data.ZoneCount = types.Int64Value(int64(len(targets)))
+	data.RecordCount = types.Int64Value(int64(recordCount))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterZonesByDomain returns the zones whose domain is in domains, compared
+// case-insensitively and ignoring a trailing dot
+func filterZonesByDomain(zones []snitchdns.Zone, domains []string) []snitchdns.Zone {
+	wanted := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		wanted[snitchdns.NormalizeZoneDomain(domain)] = true
+	}
+
+	var filtered []snitchdns.Zone
+	for _, zone := range zones {
+		if wanted[snitchdns.NormalizeZoneDomain(zone.Domain)] {
+			filtered = append(filtered, zone)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccImportConfigDataSource tests generating import configuration for existing zones and records
+func TestAccImportConfigDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImportConfigDataSourceConfig(container, "import.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.snitchdns_import_config.test", "zone_count", "1"),
+					resource.TestCheckResourceAttr("data.snitchdns_import_config.test", "record_count", "1"),
+					resource.TestMatchResourceAttr("data.snitchdns_import_config.test", "import_blocks",
+						regexp.MustCompile(`to = snitchdns_zone\.import_example_com`)),
+					resource.TestMatchResourceAttr("data.snitchdns_import_config.test", "config",
+						regexp.MustCompile(`"address" = "10\.0\.0\.1"`)),
+				),
+			},
+		},
+	})
+}
+
+// testAccImportConfigDataSourceConfig generates HCL configuration for import config testing
+func testAccImportConfigDataSourceConfig(container *testcontainer.SnitchDNSContainer, domain string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = %[3]q
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_record" "test" {
+  zone_id = snitchdns_zone.test.id
+  active  = true
+  cls     = "IN"
+  type    = "A"
+  ttl     = 300
+  data    = { address = "10.0.0.1" }
+}
+
+data "snitchdns_import_config" "test" {
+  domains = [snitchdns_zone.test.domain]
+
+  depends_on = [snitchdns_record.test]
+}
+`, container.GetAPIEndpoint(), container.APIKey, domain)
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NotificationProvidersDataSource{}
+
+// notificationProviderFields are the fields of the snitchdns_notification
+// attribute configuring each provider
+var notificationProviderFields = map[string][]string{
+	snitchdns.NotificationProviderEmail:   {"recipients"},
+	snitchdns.NotificationProviderWebhook: {"url"},
+	snitchdns.NotificationProviderSlack:   {"url"},
+	snitchdns.NotificationProviderTeams:   {"url"},
+}
+
+// notificationProviderAttrTypes are the attribute types of a notification
+// provider
+var notificationProviderAttrTypes = map[string]attr.Type{
+	"id":              types.Int64Type,
+	"name":            types.StringType,
+	"enabled":         types.BoolType,
+	"supported":       types.BoolType,
+	"required_fields": types.ListType{ElemType: types.StringType},
+}
+
+// NewNotificationProvidersDataSource creates a new Notification Providers
+// data source.
+func NewNotificationProvidersDataSource() datasource.DataSource {
+	return &NotificationProvidersDataSource{}
+}
+
+// NotificationProvidersDataSource defines the data source implementation.
+// It lists the notification providers of the server, so configurations can
+// check a provider is enabled before subscribing zones to it.
+type NotificationProvidersDataSource struct {
+	client     snitchdns.ClientInterface
+	offline    bool
+	strictMode bool
+}
+
+// NotificationProvidersDataSourceModel describes the data source data model.
+type NotificationProvidersDataSourceModel struct {
+	Enabled   types.Bool `tfsdk:"enabled"`
+	Names     types.List `tfsdk:"names"`
+	Providers types.List `tfsdk:"providers"`
+}
+
+// Metadata sets the data source type name.
+func (d *NotificationProvidersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_providers"
+}
+
+// Schema defines the data source schema.
+func (d *NotificationProvidersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the notification providers of the server and the fields `snitchdns_notification` needs to configure them. " +
+			"Use it to check that a provider is enabled before subscribing zones to it. " +
+			"Servers without the notifications API return no providers with a warning, unless the provider sets `strict_mode`.",
+
+		Attributes: map[string]schema.Attribute{
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return enabled (`true`) or disabled (`false`) providers.",
+			},
+			"names": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Names of the returned providers, sorted.",
+			},
+			"providers": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Returned providers, sorted by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Provider ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Provider name, such as `email`.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the provider is enabled on the server.",
+						},
+						"supported": schema.BoolAttribute{
+							Computed: true,
+							MarkdownDescription: "Whether `snitchdns_notification` can configure the provider, " +
+								"with the attribute of the same name.",
+						},
+						"required_fields": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							MarkdownDescription: "Fields required in the provider's `snitchdns_notification` attribute, " +
+								"such as `recipients` for `email`. Empty for unsupported providers.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *NotificationProvidersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.offline = providerData.Offline
+	d.strictMode = providerData.StrictMode
+}
+
+// Read implements the data source read logic
+func (d *NotificationProvidersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "list notification providers")
+		return
+	}
+
+	var data NotificationProvidersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var providers []snitchdns.NotificationProvider
+	if featureAvailable(ctx, d.client, snitchdns.FeatureNotifications, d.strictMode, &resp.Diagnostics, "list notification providers") {
+		var err error
+		providers, err = d.client.ListNotificationProviders(ctx)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error listing notification providers", "Could not list notification providers", err)
+			return
+		}
+	} else if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providers = slices.DeleteFunc(slices.Clone(providers), func(provider snitchdns.NotificationProvider) bool {
+		return !data.Enabled.IsNull() && provider.Enabled != data.Enabled.ValueBool()
+	})
+	sort.Slice(providers, func(i, j int) bool { return providers[i].Name < providers[j].Name })
+
+	names := make([]string, 0, len(providers))
+	values := make([]attr.Value, 0, len(providers))
+	for _, provider := range providers {
+		value, diags := notificationProviderValue(ctx, provider)
+		resp.Diagnostics.Append(diags...)
+		names = append(names, provider.Name)
+		values = append(values, value)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	providerList, diags := types.ListValue(types.ObjectType{AttrTypes: notificationProviderAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Names = nameList
+	data.Providers = providerList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// notificationProviderValue converts a provider to an element of the
+// providers list
+func notificationProviderValue(ctx context.Context, provider snitchdns.NotificationProvider) (attr.Value, diag.Diagnostics) {
+	fields, supported := notificationProviderFields[provider.Name]
+	if fields == nil {
+		fields = []string{}
+	}
+
+	fieldList, diags := types.ListValueFrom(ctx, types.StringType, fields)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	value, d := types.ObjectValue(notificationProviderAttrTypes, map[string]attr.Value{
+		"id":              types.Int64Value(int64(provider.ID)),
+		"name":            types.StringValue(provider.Name),
+		"enabled":         types.BoolValue(provider.Enabled),
+		"supported":       types.BoolValue(supported),
+		"required_fields": fieldList,
+	})
+	diags.Append(d...)
+	return value, diags
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccNotificationProvidersDataSource tests listing the providers shipped with SnitchDNS
+func TestAccNotificationProvidersDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+data "snitchdns_notification_providers" "test" {}
+`, container.GetAPIEndpoint(), container.APIKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.snitchdns_notification_providers.test", "names.*", "email"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.snitchdns_notification_providers.test", "providers.*", map[string]string{
+						"name":              "email",
+						"supported":         "true",
+						"required_fields.0": "recipients",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// TestNotificationProvidersDataSourceRead tests listing and filtering mock providers
+func TestNotificationProvidersDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	mock := snitchdnsmock.New()
+	mock.SetNotificationProviders(
+		snitchdns.NotificationProvider{ID: 1, Name: snitchdns.NotificationProviderEmail, Enabled: true},
+		snitchdns.NotificationProvider{ID: 2, Name: snitchdns.NotificationProviderSlack, Enabled: false},
+		snitchdns.NotificationProvider{ID: 3, Name: "webpush", Enabled: true},
+	)
+
+	d := NewNotificationProvidersDataSource()
+	var configureResp datasource.ConfigureResponse
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{
+		Client: mock,
+	}}, &configureResp)
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	type providerModel struct {
+		ID             int64    `tfsdk:"id"`
+		Name           string   `tfsdk:"name"`
+		Enabled        bool     `tfsdk:"enabled"`
+		Supported      bool     `tfsdk:"supported"`
+		RequiredFields []string `tfsdk:"required_fields"`
+	}
+
+	tests := []struct {
+		name      string
+		enabled   types.Bool
+		providers []providerModel
+	}{
+		{"all", types.BoolNull(), []providerModel{
+			{ID: 1, Name: "email", Enabled: true, Supported: true, RequiredFields: []string{"recipients"}},
+			{ID: 2, Name: "slack", Enabled: false, Supported: true, RequiredFields: []string{"url"}},
+			{ID: 3, Name: "webpush", Enabled: true, Supported: false, RequiredFields: []string{}},
+		}},
+		{"disabled", types.BoolValue(false), []providerModel{
+			{ID: 2, Name: "slack", Enabled: false, Supported: true, RequiredFields: []string{"url"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			// Config has no setters, so build the value through a state
+			state := tfsdk.State{Schema: config.Schema, Raw: config.Raw}
+			state.SetAttribute(ctx, path.Root("enabled"), tt.enabled)
+			config.Raw = state.Raw
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+			d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected read error: %v", resp.Diagnostics)
+			}
+
+			var data NotificationProvidersDataSourceModel
+			resp.State.Get(ctx, &data)
+
+			var providers []providerModel
+			data.Providers.ElementsAs(ctx, &providers, false)
+			if !reflect.DeepEqual(providers, tt.providers) {
+				t.Errorf("Expected providers %+v, got %+v", tt.providers, providers)
+			}
+
+			var names []string
+			data.Names.ElementsAs(ctx, &names, false)
+			if len(names) != len(tt.providers) {
+				t.Errorf("Expected %d names, got %v", len(tt.providers), names)
+			}
+		})
+	}
+}
+
+// TestNotificationProvidersDataSourceMissingFeature tests that servers
+// without the notifications API return no providers with a warning
+func TestNotificationProvidersDataSourceMissingFeature(t *testing.T) {
+	ctx := context.Background()
+
+	mock := snitchdnsmock.New()
+	mock.RemoveFeature(snitchdns.FeatureNotifications)
+
+	d := NewNotificationProvidersDataSource()
+	var configureResp datasource.ConfigureResponse
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{
+		Client: mock,
+	}}, &configureResp)
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	// Config has no setters, so build the value through a state
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	state.SetAttribute(ctx, path.Root("enabled"), types.BoolNull())
+	config := tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Expected a single warning, got %v", resp.Diagnostics)
+	}
+
+	var data NotificationProvidersDataSourceModel
+	resp.State.Get(ctx, &data)
+	if data.Names.IsNull() || len(data.Names.Elements()) != 0 || len(data.Providers.Elements()) != 0 {
+		t.Errorf("Expected no providers, got names %v and providers %v", data.Names, data.Providers)
+	}
+}
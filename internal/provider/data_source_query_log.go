@@ -0,0 +1,468 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// queryLogDefaultLimit is the number of entries returned when no limit
+	// is configured
+	queryLogDefaultLimit = 100
+	// queryLogMaxLimit bounds the entries a single read pages through
+	queryLogMaxLimit = 1000
+	// queryLogPageSize is the number of entries requested per page
+	queryLogPageSize = 100
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &QueryLogDataSource{}
+
+// queryLogAttrTypes are the attribute types of a logged query
+var queryLogAttrTypes = map[string]attr.Type{
+	"id":        types.Int64Type,
+	"domain":    types.StringType,
+	"source_ip": types.StringType,
+	"type":      types.StringType,
+	"matched":   types.BoolType,
+	"forwarded": types.BoolType,
+	"blocked":   types.BoolType,
+	"date":      types.StringType,
+	"zone_id":   types.StringType,
+	"record_id": types.StringType,
+}
+
+// NewQueryLogDataSource creates a new Query Log data source.
+func NewQueryLogDataSource() datasource.DataSource {
+	return &QueryLogDataSource{}
+}
+
+// QueryLogDataSource defines the data source implementation. It searches
+// the query log, which records every DNS query SnitchDNS received.
+type QueryLogDataSource struct {
+	client     snitchdns.ClientInterface
+	offline    bool
+	strictMode bool
+}
+
+// QueryLogDataSourceModel describes the data source data model.
+type QueryLogDataSourceModel struct {
+	Domain    types.String `tfsdk:"domain"`
+	Type      types.String `tfsdk:"type"`
+	SourceIP  types.String `tfsdk:"source_ip"`
+	Matched   types.Bool   `tfsdk:"matched"`
+	Forwarded types.Bool   `tfsdk:"forwarded"`
+	Since     types.String `tfsdk:"since"`
+	Until     types.String `tfsdk:"until"`
+	Limit     types.Int64  `tfsdk:"limit"`
+	Count     types.Int64  `tfsdk:"count"`
+	Queries   types.List   `tfsdk:"queries"`
+
+	ExportTo     types.String `tfsdk:"export_to"`
+	ExportFormat types.String `tfsdk:"export_format"`
+	ExportSHA256 types.String `tfsdk:"export_sha256"`
+}
+
+// queryLogSearch selects query log entries. The API filters too, but
+// servers that ignore a parameter are covered by filtering the results.
+type queryLogSearch struct {
+	Domain    string
+	Type      string
+	SourceIP  string
+	Matched   *bool
+	Forwarded *bool
+	Since     time.Time
+	Until     time.Time
+}
+
+// Metadata sets the data source type name.
+func (d *QueryLogDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_log"
+}
+
+// Schema defines the data source schema.
+func (d *QueryLogDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Searches the SnitchDNS query log, newest queries first. Use it to drive alerting modules and outputs from recent DNS queries. " +
+			"Servers without the query log API return no queries with a warning, unless the provider sets `strict_mode`.",
+
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries for this name, ignoring case and a trailing dot.",
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries of this type, such as `A` or `TXT`, ignoring case.",
+			},
+			"source_ip": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries from this address.",
+			},
+			"matched": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries a zone answered (`true`) or no zone answered (`false`).",
+			},
+			"forwarded": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries that were (`true`) or were not (`false`) forwarded to upstream resolvers.",
+			},
+			"since": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries logged at or after this RFC 3339 time.",
+			},
+			"until": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries logged at or before this RFC 3339 time.",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum number of queries to return, up to %d. Defaults to `%d`.", queryLogMaxLimit, queryLogDefaultLimit),
+				Validators: []validator.Int64{
+					int64validator.Between(1, queryLogMaxLimit),
+				},
+			},
+			"export_to": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Path of a file to write the server's export of every matching query to, for archival. " +
+					"`limit` does not apply to the export. The file is created with mode `0600`, replacing an existing file.",
+			},
+			"export_format": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Format of the export written to `export_to`, `csv` or `json`. Defaults to `csv`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(snitchdns.QueryLogFormatCSV), string(snitchdns.QueryLogFormatJSON)),
+					stringvalidator.AlsoRequires(path.MatchRoot("export_to")),
+				},
+			},
+			"export_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hex-encoded SHA-256 checksum of the file written to `export_to`, empty without an export.",
+			},
+			"count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of returned queries.",
+			},
+			"queries": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Matching queries, newest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Query log entry ID.",
+						},
+						"domain": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Queried name.",
+						},
+						"source_ip": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Address the query came from.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Query type.",
+						},
+						"matched": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether a zone answered the query.",
+						},
+						"forwarded": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the query was forwarded to upstream resolvers.",
+						},
+						"blocked": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the query was blocked by a restriction.",
+						},
+						"date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Time the query was logged, as reported by SnitchDNS.",
+						},
+						"zone_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the zone that answered the query, empty when no zone matched.",
+						},
+						"record_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the record that answered the query, empty when no record matched.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *QueryLogDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.offline = providerData.Offline
+	d.strictMode = providerData.StrictMode
+}
+
+// Read implements the data source read logic
+func (d *QueryLogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "search the query log")
+		return
+	}
+
+	var data QueryLogDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	search := data.search(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := queryLogDefaultLimit
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+	}
+
+	params := snitchdns.SearchParams{
+		Domain:    search.Domain,
+		Type:      search.Type,
+		SourceIP:  search.SourceIP,
+		Matched:   search.Matched,
+		Forwarded: search.Forwarded,
+		From:      search.Since,
+		To:        search.Until,
+		PerPage:   queryLogPageSize,
+	}
+
+	data.ExportSHA256 = types.StringValue("")
+	if !featureAvailable(ctx, d.client, snitchdns.FeatureQueryLogs, d.strictMode, &resp.Diagnostics, "search the query log") {
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Count = types.Int64Value(0)
+		data.Queries = types.ListValueMust(types.ObjectType{AttrTypes: queryLogAttrTypes}, []attr.Value{})
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	if !data.ExportTo.IsNull() {
+		checksum, err := d.export(ctx, params, data)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error exporting query logs",
+				fmt.Sprintf("Could not export the query log to %s", data.ExportTo.ValueString()), err)
+			return
+		}
+		data.ExportSHA256 = types.StringValue(checksum)
+	}
+
+	var queries []snitchdns.QueryLog
+	for params.Page = 1; len(queries) < limit; params.Page++ {
+		page, err := d.client.SearchLogs(ctx, params)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error searching query logs", "Could not search the query log", err)
+			return
+		}
+
+		queries = append(queries, search.match(page.Results)...)
+		tflog.Debug(ctx, "Searched query log page", map[string]any{
+			"page":    page.Page,
+			"pages":   page.Pages,
+			"matched": len(queries),
+		})
+
+		// Entries are newest first, so later pages are older than since
+		if page.Page >= page.Pages || len(page.Results) == 0 || search.olderThanSince(page.Results[len(page.Results)-1]) {
+			break
+		}
+	}
+	if len(queries) > limit {
+		queries = queries[:limit]
+	}
+
+	values := make([]attr.Value, 0, len(queries))
+	for _, query := range queries {
+		value, diags := queryLogValue(query)
+		resp.Diagnostics.Append(diags...)
+		values = append(values, value)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	queryList, diags := types.ListValue(types.ObjectType{AttrTypes: queryLogAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Count = types.Int64Value(int64(len(queries)))
+	data.Queries = queryList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// export writes the server's export of the entries matching params to the
+// configured file and returns its SHA-256 checksum
+func (d *QueryLogDataSource) export(ctx context.Context, params snitchdns.SearchParams, data QueryLogDataSourceModel) (string, error) {
+	format := snitchdns.QueryLogFormatCSV
+	if !data.ExportFormat.IsNull() {
+		format = snitchdns.QueryLogFormat(data.ExportFormat.ValueString())
+	}
+
+	body, err := d.client.ExportQueryLog(ctx, params, format)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(data.ExportTo.ValueString(), body, 0o600); err != nil {
+		return "", err
+	}
+
+	tflog.Debug(ctx, "Exported query log", map[string]any{
+		"path":   data.ExportTo.ValueString(),
+		"format": string(format),
+		"bytes":  len(body),
+	})
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// search builds the search from the configuration
+func (m *QueryLogDataSourceModel) search(diags *diag.Diagnostics) queryLogSearch {
+	search := queryLogSearch{
+		Domain:   snitchdns.NormalizeZoneDomain(m.Domain.ValueString()),
+		Type:     strings.ToUpper(m.Type.ValueString()),
+		SourceIP: m.SourceIP.ValueString(),
+	}
+	if !m.Matched.IsNull() {
+		matched := m.Matched.ValueBool()
+		search.Matched = &matched
+	}
+	if !m.Forwarded.IsNull() {
+		forwarded := m.Forwarded.ValueBool()
+		search.Forwarded = &forwarded
+	}
+
+	for _, bound := range []struct {
+		name  string
+		value types.String
+		time  *time.Time
+	}{
+		{"since", m.Since, &search.Since},
+		{"until", m.Until, &search.Until},
+	} {
+		if bound.value.IsNull() {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(bound.name), "Invalid time", fmt.Sprintf("%s must be an RFC 3339 time: %s", bound.name, err))
+			continue
+		}
+		*bound.time = t
+	}
+
+	if !search.Since.IsZero() && !search.Until.IsZero() && search.Until.Before(search.Since) {
+		diags.AddAttributeError(path.Root("until"), "Invalid time range", "until must not be before since.")
+	}
+
+	return search
+}
+
+// match returns the entries matching the search, keeping their order
+func (s queryLogSearch) match(logs []snitchdns.QueryLog) []snitchdns.QueryLog {
+	matched := []snitchdns.QueryLog{}
+	for _, log := range logs {
+		if s.Domain != "" && snitchdns.NormalizeZoneDomain(log.Domain) != s.Domain {
+			continue
+		}
+		if s.Type != "" && !strings.EqualFold(log.Type, s.Type) {
+			continue
+		}
+		if s.SourceIP != "" && log.SourceIP != s.SourceIP {
+			continue
+		}
+		if s.Matched != nil && log.Matched != *s.Matched {
+			continue
+		}
+		if s.Forwarded != nil && log.Forwarded != *s.Forwarded {
+			continue
+		}
+		if !s.Since.IsZero() || !s.Until.IsZero() {
+			date, ok := parseQueryLogDate(log.Date)
+			if !ok || (!s.Since.IsZero() && date.Before(s.Since)) || (!s.Until.IsZero() && date.After(s.Until)) {
+				continue
+			}
+		}
+		matched = append(matched, log)
+	}
+	return matched
+}
+
+// olderThanSince reports whether an entry was logged before the start of the
+// time range
+func (s queryLogSearch) olderThanSince(log snitchdns.QueryLog) bool {
+	if s.Since.IsZero() {
+		return false
+	}
+	date, ok := parseQueryLogDate(log.Date)
+	return ok && date.Before(s.Since)
+}
+
+// queryLogValue converts a query log entry to an element of the queries list
+func queryLogValue(log snitchdns.QueryLog) (attr.Value, diag.Diagnostics) {
+	return types.ObjectValue(queryLogAttrTypes, map[string]attr.Value{
+		"id":        types.Int64Value(int64(log.ID)),
+		"domain":    types.StringValue(log.Domain),
+		"source_ip": types.StringValue(log.SourceIP),
+		"type":      types.StringValue(log.Type),
+		"matched":   types.BoolValue(log.Matched),
+		"forwarded": types.BoolValue(log.Forwarded),
+		"blocked":   types.BoolValue(log.Blocked),
+		"date":      types.StringValue(log.Date),
+		"zone_id":   types.StringValue(optionalID(log.ZoneID)),
+		"record_id": types.StringValue(optionalID(log.RecordID)),
+	})
+}
+
+// optionalID formats an ID that is zero when unset
+func optionalID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
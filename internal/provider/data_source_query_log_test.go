@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccQueryLogDataSource tests that a logged query is returned
+func TestAccQueryLogDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWaitForHitZoneConfig(container),
+			},
+			{
+				PreConfig: func() {
+					testAccSendDNSQuery(t, container, "log.hit.example.com")
+				},
+				Config: testAccWaitForHitZoneConfig(container) + fmt.Sprintf(`
+data "snitchdns_query_log" "test" {
+  domain  = "log.hit.example.com"
+  matched = true
+  since   = %q
+  limit   = 10
+}
+`, time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.snitchdns_query_log.test", "count"),
+					resource.TestCheckResourceAttr("data.snitchdns_query_log.test", "queries.0.domain", "log.hit.example.com"),
+					resource.TestCheckResourceAttr("data.snitchdns_query_log.test", "queries.0.matched", "true"),
+				),
+			},
+		},
+	})
+}
+
+// TestQueryLogSearchMatch tests filtering query log entries
+func TestQueryLogSearchMatch(t *testing.T) {
+	logs := []snitchdns.QueryLog{
+		{ID: 4, Domain: "b.canary.example.com", SourceIP: "198.51.100.1", Type: "A", Matched: true, Date: "2024-05-01 12:00:00", ZoneID: 3},
+		{ID: 3, Domain: "Canary.example.com.", SourceIP: "198.51.100.2", Type: "TXT", Matched: true, Date: "2024-05-01 11:00:00", ZoneID: 3},
+		{ID: 2, Domain: "unknown.example.org", SourceIP: "198.51.100.1", Type: "A", Forwarded: true, Date: "2024-05-01 10:00:00"},
+		{ID: 1, Domain: "canary.example.com", SourceIP: "198.51.100.1", Type: "A", Matched: true, Date: "garbage", ZoneID: 3},
+	}
+
+	yes, no := true, false
+	at := func(hour int) time.Time { return time.Date(2024, 5, 1, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		search queryLogSearch
+		ids    []int
+	}{
+		{"all", queryLogSearch{}, []int{4, 3, 2, 1}},
+		{"domain", queryLogSearch{Domain: "canary.example.com"}, []int{3, 1}},
+		{"type", queryLogSearch{Type: "TXT"}, []int{3}},
+		{"source ip", queryLogSearch{SourceIP: "198.51.100.1"}, []int{4, 2, 1}},
+		{"unmatched", queryLogSearch{Matched: &no}, []int{2}},
+		{"forwarded", queryLogSearch{Forwarded: &yes}, []int{2}},
+		{"since", queryLogSearch{Since: at(11)}, []int{4, 3}},
+		{"until", queryLogSearch{Until: at(11)}, []int{3, 2}},
+		{"range", queryLogSearch{Since: at(11), Until: at(11)}, []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := []int{}
+			for _, log := range tt.search.match(logs) {
+				ids = append(ids, log.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.ids) {
+				t.Errorf("expected IDs %v, got %v", tt.ids, ids)
+			}
+		})
+	}
+}
+
+// TestQueryLogDataSourceExport tests writing the export of the matching
+// queries to a file
+func TestQueryLogDataSourceExport(t *testing.T) {
+	ctx := context.Background()
+
+	mock := snitchdnsmock.New()
+	mock.AddLogs(
+		snitchdns.QueryLog{ID: 1, Domain: "canary.example.com", Type: "A", Matched: true, Date: "2024-05-01 10:00:00"},
+		snitchdns.QueryLog{ID: 2, Domain: "other.example.com", Type: "A", Date: "2024-05-01 11:00:00"},
+	)
+
+	d := NewQueryLogDataSource()
+	var configureResp datasource.ConfigureResponse
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{
+		Client: mock,
+	}}, &configureResp)
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	exportPath := filepath.Join(t.TempDir(), "queries.json")
+
+	// Config has no setters, so build the value through a state
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	state.SetAttribute(ctx, path.Root("matched"), types.BoolValue(true))
+	state.SetAttribute(ctx, path.Root("export_to"), types.StringValue(exportPath))
+	state.SetAttribute(ctx, path.Root("export_format"), types.StringValue("json"))
+	config := tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", resp.Diagnostics)
+	}
+
+	contents, err := os.ReadFile(exportPath)
+	if err != nil {
+		t.Fatalf("Expected the export to be written: %v", err)
+	}
+	var exported []snitchdns.QueryLog
+	if err := json.Unmarshal(contents, &exported); err != nil || len(exported) != 1 || exported[0].ID != 1 {
+		t.Errorf("Expected the matched query in the export, got %s", contents)
+	}
+
+	var data QueryLogDataSourceModel
+	resp.State.Get(ctx, &data)
+	sum := sha256.Sum256(contents)
+	if data.ExportSHA256.ValueString() != hex.EncodeToString(sum[:]) {
+		t.Errorf("Expected the checksum of the export, got %s", data.ExportSHA256)
+	}
+	if data.Count.ValueInt64() != 1 {
+		t.Errorf("Expected 1 query, got %d", data.Count.ValueInt64())
+	}
+}
+
+// TestQueryLogDataSourceMissingFeature tests that servers without the query
+// log API return no queries with a warning, or fail in strict mode
+func TestQueryLogDataSourceMissingFeature(t *testing.T) {
+	ctx := context.Background()
+
+	for _, strict := range []bool{false, true} {
+		t.Run(fmt.Sprintf("strict=%t", strict), func(t *testing.T) {
+			mock := snitchdnsmock.New()
+			mock.AddLogs(snitchdns.QueryLog{ID: 1, Domain: "canary.example.com", Type: "A"})
+			mock.RemoveFeature(snitchdns.FeatureQueryLogs)
+
+			d := NewQueryLogDataSource()
+			var configureResp datasource.ConfigureResponse
+			d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{
+				Client:     mock,
+				StrictMode: strict,
+			}}, &configureResp)
+
+			var schemaResp datasource.SchemaResponse
+			d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			exportPath := filepath.Join(t.TempDir(), "queries.csv")
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			state.SetAttribute(ctx, path.Root("export_to"), types.StringValue(exportPath))
+			config := tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+			d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+
+			if strict {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("Expected an error in strict mode")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+				t.Fatalf("Expected a single warning, got %v", resp.Diagnostics)
+			}
+
+			var data QueryLogDataSourceModel
+			resp.State.Get(ctx, &data)
+			if data.Count.ValueInt64() != 0 || len(data.Queries.Elements()) != 0 {
+				t.Errorf("Expected no queries, got %d", data.Count.ValueInt64())
+			}
+			if data.ExportSHA256.ValueString() != "" {
+				t.Errorf("Expected no export checksum, got %s", data.ExportSHA256)
+			}
+			if _, err := os.Stat(exportPath); !os.IsNotExist(err) {
+				t.Errorf("Expected no export to be written, got %v", err)
+			}
+			if calls := mock.Calls("SearchLogs") + mock.Calls("ExportQueryLog"); calls != 0 {
+				t.Errorf("Expected no query log requests, got %d", calls)
+			}
+		})
+	}
+}
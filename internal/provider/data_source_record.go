@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RecordDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &RecordDataSource{}
+
+// NewRecordDataSource creates a new Record data source.
+func NewRecordDataSource() datasource.DataSource {
+	return &RecordDataSource{}
+}
+
+// RecordDataSource defines the data source implementation. It finds a single
+// record of a zone, for referencing records managed by other workspaces.
+// Records come from the cached zone listing shared with record refreshes.
+type RecordDataSource struct {
+	records    *RecordCache
+	recordData *recordDataMapper
+	offline    bool
+}
+
+// RecordDataSourceModel describes the data source data model.
+type RecordDataSourceModel struct {
+	ZoneID           types.String `tfsdk:"zone_id"`
+	ID               types.String `tfsdk:"id"`
+	Type             types.String `tfsdk:"type"`
+	MatchData        types.Map    `tfsdk:"match_data"`
+	Active           types.Bool   `tfsdk:"active"`
+	Class            types.String `tfsdk:"cls"`
+	TTL              types.Int64  `tfsdk:"ttl"`
+	Data             types.Map    `tfsdk:"data"`
+	IsConditional    types.Bool   `tfsdk:"is_conditional"`
+	ConditionalCount types.Int64  `tfsdk:"conditional_count"`
+	ConditionalLimit types.Int64  `tfsdk:"conditional_limit"`
+	ConditionalReset types.Bool   `tfsdk:"conditional_reset"`
+	ConditionalData  types.Map    `tfsdk:"conditional_data"`
+}
+
+// Metadata sets the data source type name.
+func (d *RecordDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record"
+}
+
+// Schema defines the data source schema.
+func (d *RecordDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Finds a single record of a zone by its ID, or by its type and data, for referencing records that are managed elsewhere. " +
+			"Reading fails unless exactly one record matches.",
+
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone the record belongs to.",
+			},
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "ID of the record. Either `id` or `type` must be set.",
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Type of the record, such as `A` or `TXT`, ignoring case. Either `id` or `type` must be set.",
+			},
+			"match_data": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				MarkdownDescription: "Data fields the record must have, in the format of the `snitchdns_record` `data` attribute, e.g. `{ address = \"10.0.0.1\" }`. " +
+					"Values are compared exactly; fields not listed match any value. Only used with `type`.",
+			},
+			"active": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the record is active.",
+			},
+			"cls": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Record class.",
+			},
+			"ttl": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Time to live in seconds.",
+			},
+			"data": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Record data in the format of the `snitchdns_record` `data` attribute.",
+			},
+			"is_conditional": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the record has conditional responses.",
+			},
+			"conditional_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of queries answered since the conditional count was last reset.",
+			},
+			"conditional_limit": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Query count at which the conditional data is served.",
+			},
+			"conditional_reset": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the count resets after the conditional data was served.",
+			},
+			"conditional_data": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Conditional record data, empty for records without conditional responses.",
+			},
+		},
+	}
+}
+
+// ConfigValidators requires the record to be selected either by ID or by
+// type and data.
+func (d *RecordDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("type"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("id"),
+			path.MatchRoot("match_data"),
+		),
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *RecordDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.records = providerData.Records
+	d.recordData = providerData.RecordData
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *RecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "look up record")
+		return
+	}
+
+	var data RecordDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+
+	var record *snitchdns.Record
+	if !data.ID.IsNull() {
+		found, ok, err := d.records.GetRecord(ctx, zoneID, data.ID.ValueString())
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error reading record",
+				fmt.Sprintf("Could not read record %s of zone %s", data.ID.ValueString(), zoneID), err)
+			return
+		}
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("id"),
+				"Record not found",
+				fmt.Sprintf("Zone %s has no record with ID %s.", zoneID, data.ID.ValueString()),
+			)
+			return
+		}
+		record = d.recordData.FromServerRecord(found)
+	} else {
+		match := map[string]string{}
+		resp.Diagnostics.Append(data.MatchData.ElementsAs(ctx, &match, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		records, err := d.records.ListRecords(ctx, zoneID)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error listing records",
+				fmt.Sprintf("Could not list records of zone %s", zoneID), err)
+			return
+		}
+
+		matched := d.matchRecords(filterRecords(records, recordFilter{Type: data.Type.ValueString()}), match)
+		if len(matched) != 1 {
+			ids := make([]string, len(matched))
+			for i := range matched {
+				ids[i] = strconv.Itoa(matched[i].ID)
+			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root("match_data"),
+				"Record not found",
+				fmt.Sprintf("Expected exactly one %s record matching match_data in zone %s, found %d%s. "+
+					"Add data fields to match_data or select the record by id.",
+					strings.ToUpper(data.Type.ValueString()), zoneID, len(matched), recordIDList(ids)),
+			)
+			return
+		}
+		record = &matched[0]
+	}
+
+	recordData, diags := types.MapValueFrom(ctx, types.StringType, record.Data.Strings())
+	resp.Diagnostics.Append(diags...)
+	conditionalData, diags := types.MapValueFrom(ctx, types.StringType, record.ConditionalData.Strings())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A configured type is kept as written, in whatever case
+	data.ID = types.StringValue(strconv.Itoa(record.ID))
+	if data.Type.IsNull() {
+		data.Type = types.StringValue(record.Type)
+	}
+	data.Active = types.BoolValue(record.Active)
+	data.Class = types.StringValue(record.Class)
+	data.TTL = types.Int64Value(int64(record.TTL))
+	data.Data = recordData
+	data.IsConditional = types.BoolValue(record.IsConditional)
+	data.ConditionalCount = types.Int64Value(int64(record.ConditionalCount))
+	data.ConditionalLimit = types.Int64Value(int64(record.ConditionalLimit))
+	data.ConditionalReset = types.BoolValue(record.ConditionalReset)
+	data.ConditionalData = conditionalData
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matchRecords returns the records whose data, in canonical field names,
+// has every field of match with the same value
+func (d *RecordDataSource) matchRecords(records []snitchdns.Record, match map[string]string) []snitchdns.Record {
+	matched := []snitchdns.Record{}
+	for i := range records {
+		record := d.recordData.FromServerRecord(&records[i])
+		values := record.Data.Strings()
+
+		ok := true
+		for key, want := range match {
+			if got, found := values[key]; !found || got != want {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, *record)
+		}
+	}
+	return matched
+}
+
+// recordIDList formats the IDs of ambiguous matches for a diagnostic, e.g.
+// " (IDs 3, 7)", or nothing when there are none
+func recordIDList(ids []string) string {
+	if len(ids) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (IDs %s)", strings.Join(ids, ", "))
+}
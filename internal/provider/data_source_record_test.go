@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestRecordDataSourceRead tests finding a record by ID and by type and data
+func TestRecordDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	mock := snitchdnsmock.New()
+	zone := mock.AddZone(snitchdns.Zone{Domain: "canary.example.com"})
+	first := mock.AddRecord(zone.ID, snitchdns.Record{Type: "A", Class: "IN", TTL: 300, Data: snitchdns.EncodeRecordData(map[string]interface{}{"address": "10.0.0.1"})})
+	second := mock.AddRecord(zone.ID, snitchdns.Record{Type: "A", Class: "IN", TTL: 60, Data: snitchdns.EncodeRecordData(map[string]interface{}{"address": "10.0.0.2"})})
+	mock.AddRecord(zone.ID, snitchdns.Record{Type: "TXT", Class: "IN", TTL: 300, Data: snitchdns.EncodeRecordData(map[string]interface{}{"data": "10.0.0.1"})})
+
+	d := NewRecordDataSource()
+	var configureResp datasource.ConfigureResponse
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{
+		Client:  mock,
+		Records: NewRecordCache(mock),
+	}}, &configureResp)
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	address := func(value string) types.Map {
+		return types.MapValueMust(types.StringType, map[string]attr.Value{"address": types.StringValue(value)})
+	}
+
+	tests := []struct {
+		name       string
+		attributes map[string]attr.Value
+		wantID     int
+		wantErr    bool
+	}{
+		{"by id", map[string]attr.Value{"id": types.StringValue(strconv.Itoa(second.ID))}, second.ID, false},
+		{"by type and data", map[string]attr.Value{"type": types.StringValue("a"), "match_data": address("10.0.0.1")}, first.ID, false},
+		{"ambiguous", map[string]attr.Value{"type": types.StringValue("A")}, 0, true},
+		{"no match", map[string]attr.Value{"type": types.StringValue("A"), "match_data": address("10.0.0.3")}, 0, true},
+		{"missing id", map[string]attr.Value{"id": types.StringValue("999")}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Config has no setters, so build the value through a state
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			state.SetAttribute(ctx, path.Root("zone_id"), types.StringValue(strconv.Itoa(zone.ID)))
+			for name, value := range tt.attributes {
+				state.SetAttribute(ctx, path.Root(name), value)
+			}
+			config := tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+			d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("Read() errors = %v, want error %v", resp.Diagnostics, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var data RecordDataSourceModel
+			resp.State.Get(ctx, &data)
+			if data.ID.ValueString() != strconv.Itoa(tt.wantID) {
+				t.Errorf("Expected record %d, got %s", tt.wantID, data.ID)
+			}
+			if data.TTL.IsNull() || data.Data.IsNull() || data.ConditionalData.IsNull() {
+				t.Errorf("Expected the record attributes to be set, got %+v", data)
+			}
+		})
+	}
+}
+
+// TestAccRecordDataSource tests referencing a record by type and data
+func TestAccRecordDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRecordDataSourceConfig(container),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.snitchdns_record.by_data", "id", "snitchdns_record.b", "id"),
+					resource.TestCheckResourceAttr("data.snitchdns_record.by_data", "ttl", "60"),
+					resource.TestCheckResourceAttr("data.snitchdns_record.by_id", "type", "A"),
+					resource.TestCheckResourceAttr("data.snitchdns_record.by_id", "data.address", "10.0.0.1"),
+				),
+			},
+		},
+	})
+}
+
+// testAccRecordDataSourceConfig generates HCL configuration for record lookup testing
+func testAccRecordDataSourceConfig(container *testcontainer.SnitchDNSContainer) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = "record.example.com"
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_record" "a" {
+  zone_id = snitchdns_zone.test.id
+  type    = "A"
+  cls     = "IN"
+  ttl     = 300
+  active  = true
+
+  data = {
+    address = "10.0.0.1"
+  }
+}
+
+resource "snitchdns_record" "b" {
+  zone_id = snitchdns_zone.test.id
+  type    = "A"
+  cls     = "IN"
+  ttl     = 60
+  active  = true
+
+  data = {
+    address = "10.0.0.2"
+  }
+}
+
+data "snitchdns_record" "by_data" {
+  zone_id    = snitchdns_zone.test.id
+  type       = "A"
+  match_data = { address = "10.0.0.2" }
+
+  depends_on = [snitchdns_record.a, snitchdns_record.b]
+}
+
+data "snitchdns_record" "by_id" {
+  zone_id = snitchdns_zone.test.id
+  id      = snitchdns_record.a.id
+}
+`, container.GetAPIEndpoint(), container.APIKey)
+}
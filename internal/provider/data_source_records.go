@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RecordsDataSource{}
+
+// NewRecordsDataSource creates a new Records data source.
+func NewRecordsDataSource() datasource.DataSource {
+	return &RecordsDataSource{}
+}
+
+// RecordsDataSource defines the data source implementation. Records are
+// filtered from the cached zone listing shared with record refreshes.
+type RecordsDataSource struct {
+	records    *RecordCache
+	recordData *recordDataMapper
+	offline    bool
+}
+
+// RecordsDataSourceModel describes the data source data model.
+type RecordsDataSourceModel struct {
+	ZoneID  types.String `tfsdk:"zone_id"`
+	Type    types.String `tfsdk:"type"`
+	Class   types.String `tfsdk:"cls"`
+	Active  types.Bool   `tfsdk:"active"`
+	Records types.List   `tfsdk:"records"`
+	IDs     types.List   `tfsdk:"ids"`
+}
+
+// recordFilter selects records from a zone listing. Empty fields match
+// every record.
+type recordFilter struct {
+	Type   string
+	Class  string
+	Active *bool
+}
+
+// recordListAttrTypes are the attribute types of a listed record
+var recordListAttrTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"active":         types.BoolType,
+	"cls":            types.StringType,
+	"type":           types.StringType,
+	"ttl":            types.Int64Type,
+	"data":           types.MapType{ElemType: types.StringType},
+	"is_conditional": types.BoolType,
+}
+
+// Metadata sets the data source type name.
+func (d *RecordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_records"
+}
+
+// Schema defines the data source schema.
+func (d *RecordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the records of a zone, optionally filtered by type, class or status.",
+
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone whose records are listed.",
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list records of this type, such as `A` or `TXT`, ignoring case.",
+			},
+			"cls": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list records of this class, such as `IN`, ignoring case.",
+			},
+			"active": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list active (`true`) or inactive (`false`) records.",
+			},
+			"records": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Matching records, ordered by ID.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Record ID.",
+						},
+						"active": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the record is active.",
+						},
+						"cls": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Record class.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Record type.",
+						},
+						"ttl": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Time to live in seconds.",
+						},
+						"data": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "Record data in the format of the `snitchdns_record` `data` attribute.",
+						},
+						"is_conditional": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the record has conditional responses.",
+						},
+					},
+				},
+			},
+			"ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "IDs of the matching records, in the order of `records`.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *RecordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.records = providerData.Records
+	d.recordData = providerData.RecordData
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *RecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "list records")
+		return
+	}
+
+	var data RecordsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := recordFilter{
+		Type:  data.Type.ValueString(),
+		Class: data.Class.ValueString(),
+	}
+	if !data.Active.IsNull() {
+		active := data.Active.ValueBool()
+		filter.Active = &active
+	}
+
+	records, err := d.records.ListRecords(ctx, data.ZoneID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error listing records",
+			fmt.Sprintf("Could not list records of zone %s", data.ZoneID.ValueString()), err)
+		return
+	}
+
+	matched := filterRecords(records, filter)
+
+	recordValues := make([]attr.Value, 0, len(matched))
+	ids := make([]string, 0, len(matched))
+	for i := range matched {
+		value, diags := recordListValue(ctx, d.recordData.FromServerRecord(&matched[i]))
+		resp.Diagnostics.Append(diags...)
+
+		recordValues = append(recordValues, value)
+		ids = append(ids, strconv.Itoa(matched[i].ID))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordList, diags := types.ListValue(types.ObjectType{AttrTypes: recordListAttrTypes}, recordValues)
+	resp.Diagnostics.Append(diags...)
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Records = recordList
+	data.IDs = idList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterRecords returns the records matching the filter, keeping the order
+// of the listing
+func filterRecords(records []snitchdns.Record, filter recordFilter) []snitchdns.Record {
+	matched := []snitchdns.Record{}
+	for _, record := range records {
+		if filter.Type != "" && !strings.EqualFold(record.Type, filter.Type) {
+			continue
+		}
+		if filter.Class != "" && !strings.EqualFold(record.Class, filter.Class) {
+			continue
+		}
+		if filter.Active != nil && record.Active != *filter.Active {
+			continue
+		}
+		matched = append(matched, record)
+	}
+	return matched
+}
+
+// recordListValue converts a record to an element of the records list
+func recordListValue(ctx context.Context, record *snitchdns.Record) (attr.Value, diag.Diagnostics) {
+	dataValue, diags := types.MapValueFrom(ctx, types.StringType, record.Data.Strings())
+	if diags.HasError() {
+		return types.ObjectNull(recordListAttrTypes), diags
+	}
+
+	value, d := types.ObjectValue(recordListAttrTypes, map[string]attr.Value{
+		"id":             types.StringValue(strconv.Itoa(record.ID)),
+		"active":         types.BoolValue(record.Active),
+		"cls":            types.StringValue(record.Class),
+		"type":           types.StringValue(record.Type),
+		"ttl":            types.Int64Value(int64(record.TTL)),
+		"data":           dataValue,
+		"is_conditional": types.BoolValue(record.IsConditional),
+	})
+	diags.Append(d...)
+	return value, diags
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccRecordsDataSource tests listing the records of a zone with filters
+func TestAccRecordsDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	container, namespace := testAccSharedContainer(t)
+	domain := testcontainer.NamespacedDomain(namespace, "records.example.com")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRecordsDataSourceConfig(container, domain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.snitchdns_records.all", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.snitchdns_records.a", "records.#", "1"),
+					resource.TestCheckResourceAttrPair("data.snitchdns_records.a", "ids.0", "snitchdns_record.a", "id"),
+					resource.TestCheckResourceAttr("data.snitchdns_records.a", "records.0.data.address", "10.0.0.1"),
+					resource.TestCheckResourceAttr("data.snitchdns_records.inactive", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.snitchdns_records.inactive", "records.0.type", "TXT"),
+				),
+			},
+		},
+	})
+}
+
+// testAccRecordsDataSourceConfig generates HCL configuration for record listing testing
+func testAccRecordsDataSourceConfig(container *testcontainer.SnitchDNSContainer, domain string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = %[3]q
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_record" "a" {
+  zone_id = snitchdns_zone.test.id
+  type    = "A"
+  cls     = "IN"
+  ttl     = 300
+  active  = true
+
+  data = {
+    address = "10.0.0.1"
+  }
+}
+
+resource "snitchdns_record" "txt" {
+  zone_id = snitchdns_zone.test.id
+  type    = "TXT"
+  cls     = "IN"
+  ttl     = 300
+  active  = false
+
+  data = {
+    data = "canary"
+  }
+}
+
+data "snitchdns_records" "all" {
+  zone_id = snitchdns_zone.test.id
+
+  depends_on = [snitchdns_record.a, snitchdns_record.txt]
+}
+
+data "snitchdns_records" "a" {
+  zone_id = snitchdns_zone.test.id
+  type    = "a"
+
+  depends_on = [snitchdns_record.a, snitchdns_record.txt]
+}
+
+data "snitchdns_records" "inactive" {
+  zone_id = snitchdns_zone.test.id
+  active  = false
+
+  depends_on = [snitchdns_record.a, snitchdns_record.txt]
+}
+`, container.GetAPIEndpoint(), container.APIKey, domain)
+}
+
+// TestFilterRecords tests selecting records by type, class and status
+func TestFilterRecords(t *testing.T) {
+	records := []snitchdns.Record{
+		{ID: 1, Type: "A", Class: "IN", Active: true},
+		{ID: 2, Type: "TXT", Class: "IN", Active: false},
+		{ID: 3, Type: "A", Class: "CH", Active: false},
+	}
+	inactive := false
+
+	tests := []struct {
+		name   string
+		filter recordFilter
+		want   []int
+	}{
+		{"no filter", recordFilter{}, []int{1, 2, 3}},
+		{"type", recordFilter{Type: "a"}, []int{1, 3}},
+		{"class", recordFilter{Class: "in"}, []int{1, 2}},
+		{"inactive", recordFilter{Active: &inactive}, []int{2, 3}},
+		{"combined", recordFilter{Type: "A", Active: &inactive}, []int{3}},
+		{"none", recordFilter{Type: "MX"}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int{}
+			for _, record := range filterRecords(records, tt.filter) {
+				got = append(got, record.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterRecords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,520 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SearchDataSource{}
+
+// searchResultAttrTypes are the attribute types of a search result
+var searchResultAttrTypes = map[string]attr.Type{
+	"id":        types.Int64Type,
+	"domain":    types.StringType,
+	"source_ip": types.StringType,
+	"type":      types.StringType,
+	"matched":   types.BoolType,
+	"forwarded": types.BoolType,
+	"blocked":   types.BoolType,
+	"time":      types.StringType,
+	"zone_id":   types.StringType,
+	"record_id": types.StringType,
+}
+
+// searchSummaryAttrTypes are the attribute types of the search summary
+var searchSummaryAttrTypes = map[string]attr.Type{
+	"matched":    types.Int64Type,
+	"forwarded":  types.Int64Type,
+	"blocked":    types.Int64Type,
+	"domains":    types.MapType{ElemType: types.Int64Type},
+	"source_ips": types.MapType{ElemType: types.Int64Type},
+}
+
+// NewSearchDataSource creates a new Search data source.
+func NewSearchDataSource() datasource.DataSource {
+	return &SearchDataSource{}
+}
+
+// SearchDataSource defines the data source implementation. Unlike
+// snitchdns_query_log it matches names against wildcard patterns and
+// addresses against networks, and summarizes the results for reports.
+type SearchDataSource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// SearchDataSourceModel describes the data source data model.
+type SearchDataSourceModel struct {
+	Domain   types.String `tfsdk:"domain"`
+	SourceIP types.String `tfsdk:"source_ip"`
+	Since    types.String `tfsdk:"since"`
+	Until    types.String `tfsdk:"until"`
+	Matched  types.Bool   `tfsdk:"matched"`
+	Blocked  types.Bool   `tfsdk:"blocked"`
+	Limit    types.Int64  `tfsdk:"limit"`
+	Count    types.Int64  `tfsdk:"count"`
+	Results  types.List   `tfsdk:"results"`
+	Summary  types.Object `tfsdk:"summary"`
+}
+
+// logSearch selects query log entries by pattern. The API only filters
+// names by substring and addresses exactly, so patterns and networks are
+// matched against the results.
+type logSearch struct {
+	// Domain matches the whole name, ignoring case and a trailing dot; nil
+	// matches every name
+	Domain *regexp.Regexp
+	// DomainHint is the longest literal part of the pattern, sent to the
+	// API to narrow the results
+	DomainHint string
+	// Network matches the source address; invalid matches every address
+	Network netip.Prefix
+	// Address is sent to the API when source_ip is a single address
+	Address string
+	Matched *bool
+	Blocked *bool
+	Since   time.Time
+	Until   time.Time
+}
+
+// Metadata sets the data source type name.
+func (d *SearchDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search"
+}
+
+// Schema defines the data source schema.
+func (d *SearchDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Searches the captured DNS queries by name pattern, source network, time range and outcome, and summarizes the results. Use it to build reports from Terraform outputs.",
+
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Only return queries for names matching this pattern, ignoring case and a trailing dot. " +
+					"`*` matches any number of characters and `?` a single character, e.g. `*.canary.example.com`.",
+			},
+			"source_ip": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries from this address, or from addresses in this network given in CIDR notation such as `198.51.100.0/24`.",
+			},
+			"since": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries logged at or after this RFC 3339 time.",
+			},
+			"until": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries logged at or before this RFC 3339 time.",
+			},
+			"matched": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries a zone answered (`true`) or no zone answered (`false`).",
+			},
+			"blocked": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries that were (`true`) or were not (`false`) blocked by a restriction.",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum number of queries to return, up to %d. Defaults to `%d`.", queryLogMaxLimit, queryLogDefaultLimit),
+				Validators: []validator.Int64{
+					int64validator.Between(1, queryLogMaxLimit),
+				},
+			},
+			"count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of returned queries.",
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Matching queries, newest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Query log entry ID.",
+						},
+						"domain": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Queried name.",
+						},
+						"source_ip": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Address the query came from.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Query type.",
+						},
+						"matched": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether a zone answered the query.",
+						},
+						"forwarded": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the query was forwarded to upstream resolvers.",
+						},
+						"blocked": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the query was blocked by a restriction.",
+						},
+						"time": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Time the query was logged as an RFC 3339 time in UTC, or as reported by SnitchDNS if its format is unknown.",
+						},
+						"zone_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the zone that answered the query, empty when no zone matched.",
+						},
+						"record_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the record that answered the query, empty when no record matched.",
+						},
+					},
+				},
+			},
+			"summary": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Totals over the returned queries.",
+				Attributes: map[string]schema.Attribute{
+					"matched": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Number of queries a zone answered.",
+					},
+					"forwarded": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Number of queries forwarded to upstream resolvers.",
+					},
+					"blocked": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Number of queries blocked by a restriction.",
+					},
+					"domains": schema.MapAttribute{
+						ElementType:         types.Int64Type,
+						Computed:            true,
+						MarkdownDescription: "Number of queries per queried name, in lower case without a trailing dot.",
+					},
+					"source_ips": schema.MapAttribute{
+						ElementType:         types.Int64Type,
+						Computed:            true,
+						MarkdownDescription: "Number of queries per source address.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *SearchDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *SearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "search the query log")
+		return
+	}
+
+	var data SearchDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	search := data.search(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := queryLogDefaultLimit
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+	}
+
+	params := snitchdns.SearchParams{
+		Domain:   search.DomainHint,
+		SourceIP: search.Address,
+		Matched:  search.Matched,
+		Blocked:  search.Blocked,
+		From:     search.Since,
+		To:       search.Until,
+		PerPage:  queryLogPageSize,
+	}
+
+	var results []snitchdns.QueryLog
+	for params.Page = 1; len(results) < limit; params.Page++ {
+		page, err := d.client.SearchLogs(ctx, params)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error searching query logs", "Could not search the query log", err)
+			return
+		}
+
+		results = append(results, search.match(page.Results)...)
+		tflog.Debug(ctx, "Searched query log page", map[string]any{
+			"page":    page.Page,
+			"pages":   page.Pages,
+			"matched": len(results),
+		})
+
+		// Entries are newest first, so later pages are older than since
+		if page.Page >= page.Pages || len(page.Results) == 0 || search.olderThanSince(page.Results[len(page.Results)-1]) {
+			break
+		}
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	values := make([]attr.Value, 0, len(results))
+	for _, result := range results {
+		value, diags := searchResultValue(result)
+		resp.Diagnostics.Append(diags...)
+		values = append(values, value)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resultList, diags := types.ListValue(types.ObjectType{AttrTypes: searchResultAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+
+	summary, diags := searchSummaryValue(ctx, results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Count = types.Int64Value(int64(len(results)))
+	data.Results = resultList
+	data.Summary = summary
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// search builds the search from the configuration
+func (m *SearchDataSourceModel) search(diags *diag.Diagnostics) logSearch {
+	var search logSearch
+
+	if pattern := snitchdns.NormalizeZoneDomain(m.Domain.ValueString()); pattern != "" {
+		search.Domain, search.DomainHint = domainPattern(pattern)
+	}
+
+	if source := m.SourceIP.ValueString(); source != "" {
+		if strings.Contains(source, "/") {
+			network, err := netip.ParsePrefix(source)
+			if err != nil {
+				diags.AddAttributeError(path.Root("source_ip"), "Invalid source address",
+					fmt.Sprintf("source_ip must be an IP address or a network in CIDR notation: %s", err))
+			} else {
+				search.Network = network.Masked()
+			}
+		} else {
+			address, err := netip.ParseAddr(source)
+			if err != nil {
+				diags.AddAttributeError(path.Root("source_ip"), "Invalid source address",
+					fmt.Sprintf("source_ip must be an IP address or a network in CIDR notation: %s", err))
+			} else {
+				search.Address = address.String()
+				search.Network = netip.PrefixFrom(address, address.BitLen())
+			}
+		}
+	}
+
+	if !m.Matched.IsNull() {
+		matched := m.Matched.ValueBool()
+		search.Matched = &matched
+	}
+	if !m.Blocked.IsNull() {
+		blocked := m.Blocked.ValueBool()
+		search.Blocked = &blocked
+	}
+
+	for _, bound := range []struct {
+		name  string
+		value types.String
+		time  *time.Time
+	}{
+		{"since", m.Since, &search.Since},
+		{"until", m.Until, &search.Until},
+	} {
+		if bound.value.IsNull() {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(bound.name), "Invalid time", fmt.Sprintf("%s must be an RFC 3339 time: %s", bound.name, err))
+			continue
+		}
+		*bound.time = t
+	}
+
+	if !search.Since.IsZero() && !search.Until.IsZero() && search.Until.Before(search.Since) {
+		diags.AddAttributeError(path.Root("until"), "Invalid time range", "until must not be before since.")
+	}
+
+	return search
+}
+
+// domainPattern compiles a normalized wildcard pattern into an anchored
+// regular expression, and returns the longest run of literal characters
+func domainPattern(pattern string) (*regexp.Regexp, string) {
+	var expr strings.Builder
+	var hint, literal string
+	flush := func() {
+		if len(literal) > len(hint) {
+			hint = literal
+		}
+		literal = ""
+	}
+
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			flush()
+			expr.WriteString(".*")
+		case '?':
+			flush()
+			expr.WriteString(".")
+		default:
+			literal += string(r)
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	flush()
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String()), hint
+}
+
+// match returns the entries matching the search, keeping their order
+func (s logSearch) match(logs []snitchdns.QueryLog) []snitchdns.QueryLog {
+	matched := []snitchdns.QueryLog{}
+	for _, log := range logs {
+		if s.Domain != nil && !s.Domain.MatchString(snitchdns.NormalizeZoneDomain(log.Domain)) {
+			continue
+		}
+		if s.Network.IsValid() {
+			address, err := netip.ParseAddr(log.SourceIP)
+			if err != nil || !s.Network.Contains(address.Unmap()) {
+				continue
+			}
+		}
+		if s.Matched != nil && log.Matched != *s.Matched {
+			continue
+		}
+		if s.Blocked != nil && log.Blocked != *s.Blocked {
+			continue
+		}
+		if !s.Since.IsZero() || !s.Until.IsZero() {
+			date, ok := parseQueryLogDate(log.Date)
+			if !ok || (!s.Since.IsZero() && date.Before(s.Since)) || (!s.Until.IsZero() && date.After(s.Until)) {
+				continue
+			}
+		}
+		matched = append(matched, log)
+	}
+	return matched
+}
+
+// olderThanSince reports whether an entry was logged before the start of the
+// time range
+func (s logSearch) olderThanSince(log snitchdns.QueryLog) bool {
+	if s.Since.IsZero() {
+		return false
+	}
+	date, ok := parseQueryLogDate(log.Date)
+	return ok && date.Before(s.Since)
+}
+
+// searchResultValue converts a query log entry to an element of the results
+// list
+func searchResultValue(log snitchdns.QueryLog) (attr.Value, diag.Diagnostics) {
+	logged := log.Date
+	if date, ok := parseQueryLogDate(log.Date); ok {
+		logged = date.UTC().Format(time.RFC3339)
+	}
+
+	return types.ObjectValue(searchResultAttrTypes, map[string]attr.Value{
+		"id":        types.Int64Value(int64(log.ID)),
+		"domain":    types.StringValue(log.Domain),
+		"source_ip": types.StringValue(log.SourceIP),
+		"type":      types.StringValue(log.Type),
+		"matched":   types.BoolValue(log.Matched),
+		"forwarded": types.BoolValue(log.Forwarded),
+		"blocked":   types.BoolValue(log.Blocked),
+		"time":      types.StringValue(logged),
+		"zone_id":   types.StringValue(optionalID(log.ZoneID)),
+		"record_id": types.StringValue(optionalID(log.RecordID)),
+	})
+}
+
+// searchSummaryValue totals the results into the summary object
+func searchSummaryValue(ctx context.Context, logs []snitchdns.QueryLog) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var matched, forwarded, blocked int64
+	domains := map[string]int64{}
+	sources := map[string]int64{}
+	for _, log := range logs {
+		if log.Matched {
+			matched++
+		}
+		if log.Forwarded {
+			forwarded++
+		}
+		if log.Blocked {
+			blocked++
+		}
+		domains[snitchdns.NormalizeZoneDomain(log.Domain)]++
+		sources[log.SourceIP]++
+	}
+
+	domainMap, d := types.MapValueFrom(ctx, types.Int64Type, domains)
+	diags.Append(d...)
+	sourceMap, d := types.MapValueFrom(ctx, types.Int64Type, sources)
+	diags.Append(d...)
+
+	summary, d := types.ObjectValue(searchSummaryAttrTypes, map[string]attr.Value{
+		"matched":    types.Int64Value(matched),
+		"forwarded":  types.Int64Value(forwarded),
+		"blocked":    types.Int64Value(blocked),
+		"domains":    domainMap,
+		"source_ips": sourceMap,
+	})
+	diags.Append(d...)
+
+	return summary, diags
+}
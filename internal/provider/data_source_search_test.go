@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccSearchDataSource tests that a logged query is found by pattern
+func TestAccSearchDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWaitForHitZoneConfig(container),
+			},
+			{
+				PreConfig: func() {
+					testAccSendDNSQuery(t, container, "search.hit.example.com")
+				},
+				Config: testAccWaitForHitZoneConfig(container) + fmt.Sprintf(`
+data "snitchdns_search" "test" {
+  domain  = "search.*.example.com"
+  matched = true
+  blocked = false
+  since   = %q
+}
+`, time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.snitchdns_search.test", "results.0.domain", "search.hit.example.com"),
+					resource.TestCheckResourceAttrSet("data.snitchdns_search.test", "results.0.time"),
+					resource.TestCheckResourceAttr("data.snitchdns_search.test", "summary.domains.search.hit.example.com", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestLogSearchMatch tests filtering query log entries by pattern and network
+func TestLogSearchMatch(t *testing.T) {
+	logs := []snitchdns.QueryLog{
+		{ID: 4, Domain: "b.canary.example.com", SourceIP: "198.51.100.1", Matched: true, Date: "2024-05-01 12:00:00"},
+		{ID: 3, Domain: "Canary.example.com.", SourceIP: "198.51.100.200", Matched: true, Blocked: true, Date: "2024-05-01 11:00:00"},
+		{ID: 2, Domain: "unknown.example.org", SourceIP: "203.0.113.7", Date: "2024-05-01 10:00:00"},
+		{ID: 1, Domain: "a.canary.example.com", SourceIP: "2001:db8::1", Matched: true, Date: "garbage"},
+	}
+
+	search := func(domain, sourceIP string) logSearch {
+		var diags diag.Diagnostics
+		model := SearchDataSourceModel{Domain: types.StringValue(domain), SourceIP: types.StringValue(sourceIP)}
+		s := model.search(&diags)
+		if diags.HasError() {
+			t.Fatalf("Unexpected error: %v", diags)
+		}
+		return s
+	}
+	yes := true
+	since := time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		search logSearch
+		ids    []int
+	}{
+		{"all", search("", ""), []int{4, 3, 2, 1}},
+		{"exact", search("canary.example.com", ""), []int{3}},
+		{"wildcard", search("*.canary.example.com.", ""), []int{4, 1}},
+		{"single character", search("?.CANARY.example.com", ""), []int{4, 1}},
+		{"address", search("", "198.51.100.1"), []int{4}},
+		{"network", search("", "198.51.100.0/24"), []int{4, 3}},
+		{"ipv6 network", search("", "2001:db8::/32"), []int{1}},
+		{"blocked", logSearch{Blocked: &yes}, []int{3}},
+		{"since", logSearch{Since: since}, []int{4, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := []int{}
+			for _, log := range tt.search.match(logs) {
+				ids = append(ids, log.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.ids) {
+				t.Errorf("expected IDs %v, got %v", tt.ids, ids)
+			}
+		})
+	}
+}
+
+// TestSearchDataSourceModelErrors tests that invalid filters are rejected
+func TestSearchDataSourceModelErrors(t *testing.T) {
+	tests := map[string]SearchDataSourceModel{
+		"source_ip": {SourceIP: types.StringValue("198.51.100.300")},
+		"network":   {SourceIP: types.StringValue("198.51.100.0/33")},
+		"since":     {Since: types.StringValue("yesterday")},
+		"range": {
+			Since: types.StringValue("2024-05-02T00:00:00Z"),
+			Until: types.StringValue("2024-05-01T00:00:00Z"),
+		},
+	}
+
+	for name, model := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			model.search(&diags)
+			if !diags.HasError() {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+// TestDomainPattern tests compiling wildcard patterns and their API hint
+func TestDomainPattern(t *testing.T) {
+	expr, hint := domainPattern("*.canary.example.*")
+	if hint != ".canary.example." {
+		t.Errorf("Expected the longest literal as hint, got %q", hint)
+	}
+	if !expr.MatchString("a.canary.example.com") || expr.MatchString("canary.example.com") || expr.MatchString("a.canaryxexample.com") {
+		t.Errorf("Unexpected matches of %s", expr)
+	}
+}
+
+// TestSearchDataSourceRead tests searching the mock query log
+func TestSearchDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	mock := snitchdnsmock.New()
+	mock.AddLogs(
+		snitchdns.QueryLog{Domain: "a.canary.example.com", SourceIP: "198.51.100.1", Type: "A", Matched: true, Date: "2024-05-01 10:00:00", ZoneID: 3},
+		snitchdns.QueryLog{Domain: "www.example.org", SourceIP: "198.51.100.2", Type: "A", Forwarded: true, Date: "2024-05-01 10:30:00"},
+		snitchdns.QueryLog{Domain: "b.canary.example.com", SourceIP: "198.51.100.1", Type: "TXT", Matched: true, Blocked: true, Date: "2024-05-01 11:00:00", ZoneID: 3},
+	)
+
+	d := NewSearchDataSource()
+	var configureResp datasource.ConfigureResponse
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{
+		Client: mock,
+	}}, &configureResp)
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	// Config has no setters, so build the value through a state
+	state := tfsdk.State{Schema: config.Schema, Raw: config.Raw}
+	state.SetAttribute(ctx, path.Root("domain"), types.StringValue("*.canary.example.com"))
+	state.SetAttribute(ctx, path.Root("source_ip"), types.StringValue("198.51.100.0/24"))
+	config.Raw = state.Raw
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", resp.Diagnostics)
+	}
+
+	var data SearchDataSourceModel
+	resp.State.Get(ctx, &data)
+	if data.Count.ValueInt64() != 2 {
+		t.Fatalf("Expected 2 results, got %d", data.Count.ValueInt64())
+	}
+
+	var results []struct {
+		ID        int64  `tfsdk:"id"`
+		Domain    string `tfsdk:"domain"`
+		SourceIP  string `tfsdk:"source_ip"`
+		Type      string `tfsdk:"type"`
+		Matched   bool   `tfsdk:"matched"`
+		Forwarded bool   `tfsdk:"forwarded"`
+		Blocked   bool   `tfsdk:"blocked"`
+		Time      string `tfsdk:"time"`
+		ZoneID    string `tfsdk:"zone_id"`
+		RecordID  string `tfsdk:"record_id"`
+	}
+	data.Results.ElementsAs(ctx, &results, false)
+	if results[0].Domain != "b.canary.example.com" || results[0].Time != "2024-05-01T11:00:00Z" || results[0].ZoneID != "3" {
+		t.Errorf("Unexpected first result: %+v", results[0])
+	}
+
+	var summary struct {
+		Matched   int64            `tfsdk:"matched"`
+		Forwarded int64            `tfsdk:"forwarded"`
+		Blocked   int64            `tfsdk:"blocked"`
+		Domains   map[string]int64 `tfsdk:"domains"`
+		SourceIPs map[string]int64 `tfsdk:"source_ips"`
+	}
+	data.Summary.As(ctx, &summary, basetypes.ObjectAsOptions{})
+	if summary.Matched != 2 || summary.Blocked != 1 || summary.Forwarded != 0 ||
+		summary.SourceIPs["198.51.100.1"] != 2 || summary.Domains["a.canary.example.com"] != 1 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerCapabilitiesDataSource{}
+
+// NewServerCapabilitiesDataSource creates a new Server Capabilities data
+// source.
+func NewServerCapabilitiesDataSource() datasource.DataSource {
+	return &ServerCapabilitiesDataSource{}
+}
+
+// ServerCapabilitiesDataSource defines the data source implementation. It
+// reports the features of the server, derived from its version and settings,
+// so modules can enable resources only where the server supports them.
+type ServerCapabilitiesDataSource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// ServerCapabilitiesDataSourceModel describes the data source data model.
+type ServerCapabilitiesDataSourceModel struct {
+	Version               types.String `tfsdk:"version"`
+	SupportsConditional   types.Bool   `tfsdk:"supports_conditional"`
+	SupportsRegexZones    types.Bool   `tfsdk:"supports_regex_zones"`
+	SupportsZoneTags      types.Bool   `tfsdk:"supports_zone_tags"`
+	SupportsNotifications types.Bool   `tfsdk:"supports_notifications"`
+	RecordTypes           types.List   `tfsdk:"record_types"`
+	ForwardingEnabled     types.Bool   `tfsdk:"forwarding_enabled"`
+	InterceptAll          types.Bool   `tfsdk:"intercept_all"`
+}
+
+// Metadata sets the data source type name.
+func (d *ServerCapabilitiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_capabilities"
+}
+
+// Schema defines the data source schema.
+func (d *ServerCapabilitiesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the features of the SnitchDNS server, derived from the version it reports and its settings. " +
+			"Use it to create resources only on servers supporting them, such as with `count = data.snitchdns_server_capabilities.this.supports_conditional ? 1 : 0`.",
+
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Version the server reports, null for servers that do not report one.",
+			},
+			"supports_conditional": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the server supports conditional records, added in SnitchDNS 1.2.0.",
+			},
+			"supports_regex_zones": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the server supports zones with `regex = true`. Every supported SnitchDNS release does.",
+			},
+			"supports_zone_tags": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the server supports zone tags, added in SnitchDNS 1.3.0.",
+			},
+			"supports_notifications": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the server supports the notifications API used by `snitchdns_notification`, added in SnitchDNS 1.3.0.",
+			},
+			"record_types": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Record types the server serves, sorted.",
+			},
+			"forwarding_enabled": schema.BoolAttribute{
+				Computed: true,
+				MarkdownDescription: "Whether the server forwards queries no zone answers to upstream servers. " +
+					"Null when the API key cannot read the server settings, which requires an admin key.",
+			},
+			"intercept_all": schema.BoolAttribute{
+				Computed: true,
+				MarkdownDescription: "Whether the server answers queries for every name. " +
+					"Null when the API key cannot read the server settings, which requires an admin key.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *ServerCapabilitiesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *ServerCapabilitiesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "read the server capabilities")
+		return
+	}
+
+	var data ServerCapabilitiesDataSourceModel
+
+	// Servers without the status endpoint do not report a version
+	data.Version = types.StringNull()
+	info, err := d.client.GetServerInfo(ctx)
+	switch {
+	case err == nil && info.Version != "":
+		data.Version = types.StringValue(info.Version)
+	case err != nil && !snitchdns.IsNotFound(err):
+		addAPIError(&resp.Diagnostics, "Error reading server capabilities", "Could not read the server version", err)
+		return
+	}
+
+	capabilities := serverCapabilities(data.Version.ValueString())
+	data.SupportsConditional = types.BoolValue(capabilities["supports_conditional"])
+	data.SupportsZoneTags = types.BoolValue(capabilities["supports_zone_tags"])
+	data.SupportsNotifications = types.BoolValue(capabilities["supports_notifications"])
+	// Regex zones predate the oldest supported release
+	data.SupportsRegexZones = types.BoolValue(true)
+
+	recordTypeList, diags := types.ListValueFrom(ctx, types.StringType, recordTypes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.RecordTypes = recordTypeList
+
+	data.ForwardingEnabled = types.BoolNull()
+	data.InterceptAll = types.BoolNull()
+	settings, err := d.client.GetSettings(ctx)
+	switch {
+	case err == nil:
+		data.ForwardingEnabled = types.BoolValue(settings.Bool(settingForwardDNSEnabled))
+		data.InterceptAll = types.BoolValue(settings.Bool(snitchdns.SettingInterceptAll))
+	case snitchdns.IsForbidden(err):
+		tflog.Debug(ctx, "API key cannot read the server settings, leaving them null", map[string]any{
+			"error": err.Error(),
+		})
+	default:
+		addAPIError(&resp.Diagnostics, "Error reading server capabilities", "Could not read the server settings", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
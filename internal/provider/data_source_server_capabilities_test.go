@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccServerCapabilitiesDataSource tests reading the capabilities of a
+// current server
+func TestAccServerCapabilitiesDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+data "snitchdns_server_capabilities" "test" {}
+`, container.GetAPIEndpoint(), container.APIKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.snitchdns_server_capabilities.test", "supports_conditional", "true"),
+					resource.TestCheckResourceAttr("data.snitchdns_server_capabilities.test", "supports_regex_zones", "true"),
+					resource.TestCheckTypeSetElemAttr("data.snitchdns_server_capabilities.test", "record_types.*", "CNAME"),
+					resource.TestCheckResourceAttrSet("data.snitchdns_server_capabilities.test", "forwarding_enabled"),
+				),
+			},
+		},
+	})
+}
+
+// TestServerCapabilitiesDataSourceRead tests deriving the capabilities from
+// the version and settings of mock servers
+func TestServerCapabilitiesDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	forbidden := &snitchdns.APIError{Method: "GET", Path: "/settings", StatusCode: http.StatusForbidden}
+
+	tests := []struct {
+		name          string
+		version       string
+		settingsErr   error
+		wantVersion   types.String
+		conditional   bool
+		tags          bool
+		forwarding    types.Bool
+		interceptAll  types.Bool
+		wantReadError bool
+	}{
+		{
+			name: "old server", version: "1.2.4",
+			wantVersion: types.StringValue("1.2.4"), conditional: true, tags: false,
+			forwarding: types.BoolValue(true), interceptAll: types.BoolValue(false),
+		},
+		{
+			name: "current server", version: "1.4.1",
+			wantVersion: types.StringValue("1.4.1"), conditional: true, tags: true,
+			forwarding: types.BoolValue(true), interceptAll: types.BoolValue(false),
+		},
+		{
+			name: "unreported version", version: "",
+			wantVersion: types.StringNull(), conditional: true, tags: true,
+			forwarding: types.BoolValue(true), interceptAll: types.BoolValue(false),
+		},
+		{
+			name: "no admin key", version: "1.4.1", settingsErr: forbidden,
+			wantVersion: types.StringValue("1.4.1"), conditional: true, tags: true,
+			forwarding: types.BoolNull(), interceptAll: types.BoolNull(),
+		},
+		{
+			name: "settings error", version: "1.4.1", settingsErr: fmt.Errorf("connection reset"),
+			wantReadError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := snitchdnsmock.New()
+			mock.Version = tt.version
+			if _, err := mock.UpdateSettings(ctx, snitchdns.Settings{settingForwardDNSEnabled: "true"}); err != nil {
+				t.Fatalf("Failed to seed settings: %v", err)
+			}
+			if tt.settingsErr != nil {
+				mock.Fail("GetSettings", tt.settingsErr)
+			}
+
+			d := NewServerCapabilitiesDataSource()
+			var configureResp datasource.ConfigureResponse
+			d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{
+				Client: mock,
+			}}, &configureResp)
+
+			var schemaResp datasource.SchemaResponse
+			d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+			d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+			if resp.Diagnostics.HasError() != tt.wantReadError {
+				t.Fatalf("Read() errors = %v, want error %t", resp.Diagnostics, tt.wantReadError)
+			}
+			if tt.wantReadError {
+				return
+			}
+
+			var data ServerCapabilitiesDataSourceModel
+			resp.State.Get(ctx, &data)
+
+			if !data.Version.Equal(tt.wantVersion) {
+				t.Errorf("Expected version %s, got %s", tt.wantVersion, data.Version)
+			}
+			if data.SupportsConditional.ValueBool() != tt.conditional || data.SupportsZoneTags.ValueBool() != tt.tags ||
+				data.SupportsNotifications.ValueBool() != tt.tags || !data.SupportsRegexZones.ValueBool() {
+				t.Errorf("Unexpected capabilities: %+v", data)
+			}
+			if !data.ForwardingEnabled.Equal(tt.forwarding) || !data.InterceptAll.Equal(tt.interceptAll) {
+				t.Errorf("Expected settings %s and %s, got %s and %s", tt.forwarding, tt.interceptAll, data.ForwardingEnabled, data.InterceptAll)
+			}
+			if len(data.RecordTypes.Elements()) != len(recordTypes) {
+				t.Errorf("Expected %d record types, got %s", len(recordTypes), data.RecordTypes)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// waitForHitDefaultInterval is the time between query log polls
+	waitForHitDefaultInterval = 10 * time.Second
+	// waitForHitPageSize is the number of newest log entries inspected per
+	// poll; a canary is expected to be among them right after it fired
+	waitForHitPageSize = 100
+)
+
+// queryLogDateLayouts are the formats SnitchDNS has used for query log dates.
+// Dates without a zone are UTC.
+var queryLogDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WaitForHitDataSource{}
+
+// waitForHitAttrTypes are the attribute types of an observed hit
+var waitForHitAttrTypes = map[string]attr.Type{
+	"id":        types.Int64Type,
+	"domain":    types.StringType,
+	"source_ip": types.StringType,
+	"type":      types.StringType,
+	"date":      types.StringType,
+	"record_id": types.StringType,
+}
+
+// NewWaitForHitDataSource creates a new Wait For Hit data source.
+func NewWaitForHitDataSource() datasource.DataSource {
+	return &WaitForHitDataSource{}
+}
+
+// WaitForHitDataSource defines the data source implementation. It polls the
+// query log until a query answered by the zone shows up, so a configuration
+// can verify a canary end to end.
+type WaitForHitDataSource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// WaitForHitDataSourceModel describes the data source data model.
+type WaitForHitDataSourceModel struct {
+	ZoneID       types.String   `tfsdk:"zone_id"`
+	RecordID     types.String   `tfsdk:"record_id"`
+	Domain       types.String   `tfsdk:"domain"`
+	Type         types.String   `tfsdk:"type"`
+	SourceIP     types.String   `tfsdk:"source_ip"`
+	Since        types.String   `tfsdk:"since"`
+	PollInterval types.String   `tfsdk:"poll_interval"`
+	HitCount     types.Int64    `tfsdk:"hit_count"`
+	Hits         types.List     `tfsdk:"hits"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// queryLogFilter selects the query log entries that count as a hit
+type queryLogFilter struct {
+	ZoneID   int
+	RecordID int
+	Domain   string
+	Type     string
+	SourceIP string
+	Since    time.Time
+}
+
+// Metadata sets the data source type name.
+func (d *WaitForHitDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_wait_for_hit"
+}
+
+// Schema defines the data source schema.
+func (d *WaitForHitDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Waits until the query log shows at least one DNS query answered by a zone or record, failing after the read timeout. " +
+			"Use it to verify a deployed canary end to end.",
+
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone that must answer the query.",
+			},
+			"record_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only count queries answered by this record.",
+			},
+			"domain": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only count queries for this name or names below it. Defaults to the zone's domain.",
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only count queries of this type, such as `A` or `TXT`.",
+			},
+			"source_ip": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only count queries from this address.",
+			},
+			"since": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only count queries logged at or after this RFC 3339 time, such as `timestamp()` captured when the canary was deployed.",
+			},
+			"poll_interval": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Time between query log polls as a duration such as `30s`. Defaults to `10s`.",
+			},
+			"hit_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of matching queries among the newest query log entries.",
+			},
+			"hits": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Matching queries, newest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Query log entry ID.",
+						},
+						"domain": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Queried name.",
+						},
+						"source_ip": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Address the query came from.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Query type.",
+						},
+						"date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Time the query was logged, as reported by SnitchDNS.",
+						},
+						"record_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the record that answered the query.",
+						},
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *WaitForHitDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *WaitForHitDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "wait for DNS hits")
+		return
+	}
+
+	var data WaitForHitDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	filter, interval := d.filter(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := snitchdns.SearchParams{
+		Domain:   filter.Domain,
+		Type:     filter.Type,
+		SourceIP: filter.SourceIP,
+		Page:     1,
+		PerPage:  waitForHitPageSize,
+	}
+
+	var hits []snitchdns.QueryLog
+	for {
+		page, err := d.client.SearchLogs(ctx, params)
+		if err != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			addAPIError(&resp.Diagnostics, "Error searching query logs", "Could not search the query log", err)
+			return
+		}
+		if err == nil {
+			hits = matchQueryLogs(page.Results, filter)
+			if len(hits) > 0 {
+				break
+			}
+			tflog.Debug(ctx, "No matching DNS hit yet", map[string]interface{}{
+				"zone_id": data.ZoneID.ValueString(),
+				"domain":  filter.Domain,
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError(
+				"No DNS hit observed",
+				fmt.Sprintf("No query for %s answered by zone %s was logged within %s.", filter.Domain, data.ZoneID.ValueString(), readTimeout),
+			)
+			return
+		case <-time.After(interval):
+		}
+	}
+
+	values := make([]attr.Value, 0, len(hits))
+	for _, hit := range hits {
+		value, diags := types.ObjectValue(waitForHitAttrTypes, map[string]attr.Value{
+			"id":        types.Int64Value(int64(hit.ID)),
+			"domain":    types.StringValue(hit.Domain),
+			"source_ip": types.StringValue(hit.SourceIP),
+			"type":      types.StringValue(hit.Type),
+			"date":      types.StringValue(hit.Date),
+			"record_id": types.StringValue(strconv.Itoa(hit.RecordID)),
+		})
+		resp.Diagnostics.Append(diags...)
+		values = append(values, value)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hitList, diags := types.ListValue(types.ObjectType{AttrTypes: waitForHitAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.HitCount = types.Int64Value(int64(len(hits)))
+	data.Hits = hitList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filter builds the hit filter and poll interval from the configuration,
+// looking up the zone's domain when no domain is given
+func (d *WaitForHitDataSource) filter(ctx context.Context, data *WaitForHitDataSourceModel, diags *diag.Diagnostics) (queryLogFilter, time.Duration) {
+	var filter queryLogFilter
+
+	zoneID, err := strconv.Atoi(data.ZoneID.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("zone_id"), "Invalid zone ID", fmt.Sprintf("Zone ID must be a number, got %q.", data.ZoneID.ValueString()))
+		return filter, 0
+	}
+	filter.ZoneID = zoneID
+
+	if !data.RecordID.IsNull() {
+		recordID, err := strconv.Atoi(data.RecordID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("record_id"), "Invalid record ID", fmt.Sprintf("Record ID must be a number, got %q.", data.RecordID.ValueString()))
+			return filter, 0
+		}
+		filter.RecordID = recordID
+	}
+
+	if !data.Since.IsNull() {
+		since, err := time.Parse(time.RFC3339, data.Since.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("since"), "Invalid time", fmt.Sprintf("since must be an RFC 3339 time: %s", err))
+			return filter, 0
+		}
+		filter.Since = since
+	}
+
+	interval := waitForHitDefaultInterval
+	if !data.PollInterval.IsNull() {
+		interval, err = time.ParseDuration(data.PollInterval.ValueString())
+		if err != nil || interval <= 0 {
+			diags.AddAttributeError(path.Root("poll_interval"), "Invalid poll interval", fmt.Sprintf("poll_interval must be a positive duration such as 30s, got %q.", data.PollInterval.ValueString()))
+			return filter, 0
+		}
+	}
+
+	filter.Domain = data.Domain.ValueString()
+	if filter.Domain == "" {
+		zone, err := d.client.GetZoneWithContext(ctx, data.ZoneID.ValueString())
+		if err != nil {
+			addAPIError(diags, "Error reading zone", "Could not read zone "+data.ZoneID.ValueString(), err)
+			return filter, 0
+		}
+		filter.Domain = zone.Domain
+	}
+	filter.Type = strings.ToUpper(data.Type.ValueString())
+	filter.SourceIP = data.SourceIP.ValueString()
+
+	return filter, interval
+}
+
+// matchQueryLogs returns the entries matching the filter, keeping their order
+func matchQueryLogs(logs []snitchdns.QueryLog, filter queryLogFilter) []snitchdns.QueryLog {
+	domain := snitchdns.NormalizeZoneDomain(filter.Domain)
+
+	matched := []snitchdns.QueryLog{}
+	for _, log := range logs {
+		if log.ZoneID != filter.ZoneID {
+			continue
+		}
+		if filter.RecordID != 0 && log.RecordID != filter.RecordID {
+			continue
+		}
+		if name := snitchdns.NormalizeZoneDomain(log.Domain); domain != "" && name != domain && !strings.HasSuffix(name, "."+domain) {
+			continue
+		}
+		if filter.Type != "" && !strings.EqualFold(log.Type, filter.Type) {
+			continue
+		}
+		if filter.SourceIP != "" && log.SourceIP != filter.SourceIP {
+			continue
+		}
+		if !filter.Since.IsZero() {
+			date, ok := parseQueryLogDate(log.Date)
+			if !ok || date.Before(filter.Since) {
+				continue
+			}
+		}
+		matched = append(matched, log)
+	}
+	return matched
+}
+
+// parseQueryLogDate parses a query log date in any known layout
+func parseQueryLogDate(date string) (time.Time, bool) {
+	for _, layout := range queryLogDateLayouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
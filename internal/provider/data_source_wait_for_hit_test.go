@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccWaitForHitDataSource tests that a query answered by a zone is
+// observed
+func TestAccWaitForHitDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWaitForHitZoneConfig(container),
+			},
+			{
+				PreConfig: func() {
+					testAccSendDNSQuery(t, container, "beacon.hit.example.com")
+				},
+				Config: testAccWaitForHitZoneConfig(container) + `
+data "snitchdns_wait_for_hit" "test" {
+  zone_id       = snitchdns_zone.test.id
+  poll_interval = "2s"
+
+  timeouts {
+    read = "1m"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.snitchdns_wait_for_hit.test", "hit_count"),
+					resource.TestCheckResourceAttr("data.snitchdns_wait_for_hit.test", "hits.0.domain", "beacon.hit.example.com"),
+				),
+			},
+		},
+	})
+}
+
+// testAccWaitForHitZoneConfig generates HCL configuration for a catch-all zone
+func testAccWaitForHitZoneConfig(container *testcontainer.SnitchDNSContainer) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain    = "hit.example.com"
+  active    = true
+  catch_all = true
+  regex     = false
+}
+`, container.GetAPIEndpoint(), container.APIKey)
+}
+
+// testAccSendDNSQuery sends an A query to the container's DNS server so it
+// shows up in the query log. The answer does not matter.
+func testAccSendDNSQuery(t *testing.T, container *testcontainer.SnitchDNSContainer, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := container.Query(ctx, name, "A"); err != nil {
+		t.Logf("DNS query for %s failed: %v", name, err)
+	}
+}
+
+// TestMatchQueryLogs tests selecting hits from query log entries
+func TestMatchQueryLogs(t *testing.T) {
+	logs := []snitchdns.QueryLog{
+		{ID: 6, Domain: "beacon.canary.example.com.", Type: "A", SourceIP: "192.0.2.1", ZoneID: 1, RecordID: 10, Date: "2026-03-01 12:00:00"},
+		{ID: 5, Domain: "CANARY.example.com", Type: "TXT", SourceIP: "192.0.2.2", ZoneID: 1, RecordID: 11, Date: "2026-03-01T11:00:00Z"},
+		{ID: 4, Domain: "notcanary.example.com", Type: "A", SourceIP: "192.0.2.1", ZoneID: 1, RecordID: 10, Date: "2026-03-01 10:00:00"},
+		{ID: 3, Domain: "beacon.canary.example.com", Type: "A", SourceIP: "192.0.2.1", ZoneID: 2, RecordID: 20, Date: "2026-03-01 09:00:00"},
+		{ID: 2, Domain: "old.canary.example.com", Type: "A", SourceIP: "192.0.2.1", ZoneID: 1, RecordID: 10, Date: "not a date"},
+	}
+	since := time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter queryLogFilter
+		want   []int
+	}{
+		{"zone and domain", queryLogFilter{ZoneID: 1, Domain: "canary.example.com"}, []int{6, 5, 2}},
+		{"record", queryLogFilter{ZoneID: 1, RecordID: 11, Domain: "canary.example.com"}, []int{5}},
+		{"type", queryLogFilter{ZoneID: 1, Domain: "canary.example.com", Type: "a"}, []int{6, 2}},
+		{"source", queryLogFilter{ZoneID: 1, Domain: "canary.example.com", SourceIP: "192.0.2.2"}, []int{5}},
+		{"since", queryLogFilter{ZoneID: 1, Domain: "canary.example.com", Since: since}, []int{6, 5}},
+		{"other zone", queryLogFilter{ZoneID: 3, Domain: "canary.example.com"}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int{}
+			for _, log := range matchQueryLogs(logs, tt.filter) {
+				got = append(got, log.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matchQueryLogs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
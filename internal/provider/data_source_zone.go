@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ZoneDataSource{}
+
+// NewZoneDataSource creates a new Zone data source.
+func NewZoneDataSource() datasource.DataSource {
+	return &ZoneDataSource{}
+}
+
+// ZoneDataSource defines the data source implementation. It resolves zones
+// created outside of Terraform by domain, using the shared zone listing.
+type ZoneDataSource struct {
+	zones   *ZoneResolver
+	offline bool
+}
+
+// ZoneDataSourceModel describes the data source data model.
+type ZoneDataSourceModel struct {
+	Domain     types.String `tfsdk:"domain"`
+	ID         types.String `tfsdk:"id"`
+	UserID     types.Int64  `tfsdk:"user_id"`
+	Active     types.Bool   `tfsdk:"active"`
+	CatchAll   types.Bool   `tfsdk:"catch_all"`
+	Forwarding types.Bool   `tfsdk:"forwarding"`
+	Regex      types.Bool   `tfsdk:"regex"`
+	Master     types.Bool   `tfsdk:"master"`
+	Tags       types.List   `tfsdk:"tags"`
+}
+
+// Metadata sets the data source type name.
+func (d *ZoneDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+// Schema defines the data source schema.
+func (d *ZoneDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a zone by its domain, for referencing zones that are not managed by Terraform.",
+
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Domain of the zone. Matched case-insensitively and without a trailing dot; regex zones are never matched.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Zone ID, for use as `zone_id`.",
+			},
+			"user_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the user who owns the zone.",
+			},
+			"active": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the zone is active.",
+			},
+			"catch_all": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the zone answers every name below it.",
+			},
+			"forwarding": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether unmatched queries are forwarded.",
+			},
+			"regex": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the domain is a regular expression. Always `false`.",
+			},
+			"master": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether this is the user's master zone.",
+			},
+			"tags": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Zone tags.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *ZoneDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.zones = providerData.Zones
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "look up zone")
+		return
+	}
+
+	var data ZoneDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zones, err := d.zones.ListZones(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error listing zones", "Could not list zones", err)
+		return
+	}
+
+	zone, ok := snitchdns.MatchZoneDomain(zones, data.Domain.ValueString())
+	if !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Zone not found",
+			fmt.Sprintf("No zone found for domain %s.", data.Domain.ValueString()),
+		)
+		return
+	}
+
+	tags, diags := types.ListValueFrom(ctx, types.StringType, zone.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(strconv.Itoa(zone.ID))
+	data.UserID = types.Int64Value(int64(zone.UserID))
+	data.Active = types.BoolValue(zone.Active)
+	data.CatchAll = types.BoolValue(zone.CatchAll)
+	data.Forwarding = types.BoolValue(zone.Forwarding)
+	data.Regex = types.BoolValue(zone.Regex)
+	data.Master = types.BoolValue(zone.Master)
+	data.Tags = tags
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ZoneExportDataSource{}
+
+// NewZoneExportDataSource creates a new Zone Export data source.
+func NewZoneExportDataSource() datasource.DataSource {
+	return &ZoneExportDataSource{}
+}
+
+// ZoneExportDataSource defines the data source implementation. It is the
+// read-only counterpart of snitchdns_zone_file and renders the zone's records
+// the same way.
+type ZoneExportDataSource struct {
+	client     snitchdns.ClientInterface
+	records    *RecordCache
+	recordData *recordDataMapper
+	offline    bool
+}
+
+// ZoneExportDataSourceModel describes the data source data model.
+type ZoneExportDataSourceModel struct {
+	ZoneID          types.String `tfsdk:"zone_id"`
+	IncludeInactive types.Bool   `tfsdk:"include_inactive"`
+	Origin          types.String `tfsdk:"origin"`
+	Content         types.String `tfsdk:"content"`
+	RecordCount     types.Int64  `tfsdk:"record_count"`
+}
+
+// Metadata sets the data source type name.
+func (d *ZoneExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_export"
+}
+
+// Schema defines the data source schema.
+func (d *ZoneExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders the records of a zone as RFC 1035 zone file text, such as a BIND zone file, " +
+			"for secondary name servers or archival.",
+
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone to export.",
+			},
+			"include_inactive": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Also export inactive records, which SnitchDNS does not answer with. Defaults to `false`.",
+			},
+			"origin": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Domain of the zone with a trailing dot, written as the `$ORIGIN` of the file.",
+			},
+			"content": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Zone file text, with records in the order of their IDs. " +
+					"Records of types a zone file cannot hold are written as comments.",
+			},
+			"record_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of records exported.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *ZoneExportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.records = providerData.Records
+	d.recordData = providerData.RecordData
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *ZoneExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "export zone")
+		return
+	}
+
+	var data ZoneExportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+
+	zone, err := d.client.GetZoneWithContext(ctx, zoneID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading zone", fmt.Sprintf("Could not read zone ID %s", zoneID), err)
+		return
+	}
+
+	records, err := d.records.ListRecords(ctx, zoneID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error listing records", fmt.Sprintf("Could not list records of zone %s", zoneID), err)
+		return
+	}
+
+	filter := recordFilter{}
+	if !data.IncludeInactive.ValueBool() {
+		active := true
+		filter.Active = &active
+	}
+
+	exported := filterRecords(records, filter)
+	for i := range exported {
+		exported[i] = *d.recordData.FromServerRecord(&exported[i])
+	}
+
+	origin := strings.TrimSuffix(zone.Domain, ".") + "."
+
+	data.Origin = types.StringValue(origin)
+	data.Content = types.StringValue(renderZoneFile(origin, exported))
+	data.RecordCount = types.Int64Value(int64(len(exported)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccZoneExportDataSource tests rendering the records of a zone as a zone file
+func TestAccZoneExportDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneExportDataSourceConfig(container),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.snitchdns_zone_export.test", "origin", "export.example.com."),
+					resource.TestCheckResourceAttr("data.snitchdns_zone_export.test", "record_count", "1"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone_export.test", "content",
+						"$ORIGIN export.example.com.\n@\t300\tIN\tA\t10.0.0.1\n"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone_export.all", "record_count", "2"),
+				),
+			},
+		},
+	})
+}
+
+// testAccZoneExportDataSourceConfig generates HCL configuration for zone export testing
+func testAccZoneExportDataSourceConfig(container *testcontainer.SnitchDNSContainer) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = "export.example.com"
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_record" "a" {
+  zone_id = snitchdns_zone.test.id
+  type    = "A"
+  cls     = "IN"
+  ttl     = 300
+  active  = true
+
+  data = {
+    address = "10.0.0.1"
+  }
+}
+
+resource "snitchdns_record" "txt" {
+  zone_id = snitchdns_zone.test.id
+  type    = "TXT"
+  cls     = "IN"
+  ttl     = 300
+  active  = false
+
+  data = {
+    data = "inactive"
+  }
+}
+
+data "snitchdns_zone_export" "test" {
+  zone_id = snitchdns_zone.test.id
+
+  depends_on = [snitchdns_record.a, snitchdns_record.txt]
+}
+
+data "snitchdns_zone_export" "all" {
+  zone_id          = snitchdns_zone.test.id
+  include_inactive = true
+
+  depends_on = [snitchdns_record.a, snitchdns_record.txt]
+}
+`, container.GetAPIEndpoint(), container.APIKey)
+}
+
+// TestZoneExportDataSource_Mock tests that inactive records are left out unless requested
+func TestZoneExportDataSource_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+
+	zone := mock.AddZone(snitchdns.Zone{Domain: "example.com", Active: true})
+	mock.AddRecord(zone.ID, snitchdns.Record{Active: true, Class: "IN", Type: "MX", TTL: 3600,
+		Data: snitchdns.EncodeRecordData(map[string]interface{}{"priority": float64(10), "hostname": "mail.example.com."})})
+	mock.AddRecord(zone.ID, snitchdns.Record{Active: false, Class: "IN", Type: "A", TTL: 300,
+		Data: snitchdns.EncodeRecordData(map[string]interface{}{"address": "192.0.2.1"})})
+
+	d := NewZoneExportDataSource()
+	var configureResp datasource.ConfigureResponse
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{
+		Client:  mock,
+		Records: NewRecordCache(mock),
+	}}, &configureResp)
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	tests := map[string]struct {
+		includeInactive bool
+		want            string
+	}{
+		"active": {false, "$ORIGIN example.com.\n@\t3600\tIN\tMX\t10 mail.example.com.\n"},
+		"all":    {true, "$ORIGIN example.com.\n@\t3600\tIN\tMX\t10 mail.example.com.\n@\t300\tIN\tA\t192.0.2.1\n"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			// Config has no setters, so build the value through a state
+			state := tfsdk.State{Schema: config.Schema, Raw: config.Raw}
+			state.SetAttribute(ctx, path.Root("zone_id"), types.StringValue(strconv.Itoa(zone.ID)))
+			state.SetAttribute(ctx, path.Root("include_inactive"), types.BoolValue(tt.includeInactive))
+			config.Raw = state.Raw
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+			d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected read error: %v", resp.Diagnostics)
+			}
+
+			var data ZoneExportDataSourceModel
+			resp.State.Get(ctx, &data)
+			if data.Origin.ValueString() != "example.com." || data.Content.ValueString() != tt.want {
+				t.Errorf("Unexpected export %q of origin %s, expected %q", data.Content.ValueString(), data.Origin.ValueString(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ZoneLookupDataSource{}
+
+// zoneLookupAttrTypes are the attribute types of a looked up zone
+var zoneLookupAttrTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"domain":     types.StringType,
+	"user_id":    types.Int64Type,
+	"active":     types.BoolType,
+	"catch_all":  types.BoolType,
+	"forwarding": types.BoolType,
+	"regex":      types.BoolType,
+	"master":     types.BoolType,
+	"tags":       types.ListType{ElemType: types.StringType},
+}
+
+// NewZoneLookupDataSource creates a new Zone Lookup data source.
+func NewZoneLookupDataSource() datasource.DataSource {
+	return &ZoneLookupDataSource{}
+}
+
+// ZoneLookupDataSource defines the data source implementation. All domains
+// are resolved from the shared zone listing, so looking up many domains costs
+// a single listing no matter how many there are.
+type ZoneLookupDataSource struct {
+	zones   *ZoneResolver
+	offline bool
+}
+
+// ZoneLookupDataSourceModel describes the data source data model.
+type ZoneLookupDataSourceModel struct {
+	Domains       types.Set  `tfsdk:"domains"`
+	IgnoreMissing types.Bool `tfsdk:"ignore_missing"`
+	Zones         types.Map  `tfsdk:"zones"`
+	IDs           types.Map  `tfsdk:"ids"`
+	Missing       types.Set  `tfsdk:"missing"`
+}
+
+// Metadata sets the data source type name.
+func (d *ZoneLookupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_lookup"
+}
+
+// Schema defines the data source schema.
+func (d *ZoneLookupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves many zone domains at once from a single zone listing, returning the attributes of each zone keyed by domain.",
+
+		Attributes: map[string]schema.Attribute{
+			"domains": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Zone domains to look up. Domains are matched case-insensitively and without a trailing dot; regex zones are never matched.",
+			},
+			"ignore_missing": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave domains without a zone out of `zones` and `ids` instead of failing. Defaults to `false`.",
+			},
+			"zones": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Zones keyed by the domain as given in `domains`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Zone ID.",
+						},
+						"domain": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Domain as stored by SnitchDNS.",
+						},
+						"user_id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the user who owns the zone.",
+						},
+						"active": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the zone is active.",
+						},
+						"catch_all": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the zone answers every name below it.",
+						},
+						"forwarding": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether unmatched queries are forwarded.",
+						},
+						"regex": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the domain is a regular expression. Always `false`.",
+						},
+						"master": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether this is the user's master zone.",
+						},
+						"tags": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "Zone tags.",
+						},
+					},
+				},
+			},
+			"ids": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Zone IDs keyed by the domain as given in `domains`, for use as `zone_id`.",
+			},
+			"missing": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Domains without a zone. Only non-empty when `ignore_missing` is set.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *ZoneLookupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.zones = providerData.Zones
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *ZoneLookupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "look up zones")
+		return
+	}
+
+	var data ZoneLookupDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var domains []string
+	resp.Diagnostics.Append(data.Domains.ElementsAs(ctx, &domains, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zones, err := d.zones.ListZones(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error listing zones", "Could not list zones", err)
+		return
+	}
+
+	found, missing := lookupZones(zones, domains)
+	if len(missing) > 0 && !data.IgnoreMissing.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domains"),
+			"Zones not found",
+			fmt.Sprintf("No zone found for: %s. Set ignore_missing = true to return the zones that exist.", strings.Join(missing, ", ")),
+		)
+		return
+	}
+
+	zoneValues := make(map[string]attr.Value, len(found))
+	ids := make(map[string]string, len(found))
+	for domain, zone := range found {
+		value, diags := zoneLookupValue(ctx, zone)
+		resp.Diagnostics.Append(diags...)
+
+		zoneValues[domain] = value
+		ids[domain] = strconv.Itoa(zone.ID)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneMap, diags := types.MapValue(types.ObjectType{AttrTypes: zoneLookupAttrTypes}, zoneValues)
+	resp.Diagnostics.Append(diags...)
+
+	idMap, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+
+	missingSet, diags := types.SetValueFrom(ctx, types.StringType, missing)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Zones = zoneMap
+	data.IDs = idMap
+	data.Missing = missingSet
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// zoneLookupValue converts a zone to an object of zoneLookupAttrTypes
+func zoneLookupValue(ctx context.Context, zone snitchdns.Zone) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tags, d := types.ListValueFrom(ctx, types.StringType, zone.Tags)
+	diags.Append(d...)
+
+	value, d := types.ObjectValue(zoneLookupAttrTypes, map[string]attr.Value{
+		"id":         types.StringValue(strconv.Itoa(zone.ID)),
+		"domain":     types.StringValue(zone.Domain),
+		"user_id":    types.Int64Value(int64(zone.UserID)),
+		"active":     types.BoolValue(zone.Active),
+		"catch_all":  types.BoolValue(zone.CatchAll),
+		"forwarding": types.BoolValue(zone.Forwarding),
+		"regex":      types.BoolValue(zone.Regex),
+		"master":     types.BoolValue(zone.Master),
+		"tags":       tags,
+	})
+	diags.Append(d...)
+
+	return value, diags
+}
+
+// lookupZones matches domains against the zone listing. Found zones are keyed
+// by the domain as given; domains without a non-regex zone are returned in
+// the order given.
+func lookupZones(zones []snitchdns.Zone, domains []string) (map[string]snitchdns.Zone, []string) {
+	byDomain := make(map[string]snitchdns.Zone, len(zones))
+	for _, zone := range zones {
+		if !zone.Regex {
+			byDomain[snitchdns.NormalizeZoneDomain(zone.Domain)] = zone
+		}
+	}
+
+	found := make(map[string]snitchdns.Zone, len(domains))
+	missing := []string{}
+	for _, domain := range domains {
+		zone, ok := byDomain[snitchdns.NormalizeZoneDomain(domain)]
+		if !ok {
+			missing = append(missing, domain)
+			continue
+		}
+		found[domain] = zone
+	}
+	return found, missing
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccZoneLookupDataSource tests resolving several domains at once
+func TestAccZoneLookupDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneLookupDataSourceConfig(container, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.snitchdns_zone_lookup.test", "ids.one.example.com", "snitchdns_zone.one", "id"),
+					resource.TestCheckResourceAttrPair("data.snitchdns_zone_lookup.test", "zones.two.example.com.id", "snitchdns_zone.two", "id"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone_lookup.test", "zones.two.example.com.catch_all", "true"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone_lookup.test", "missing.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.snitchdns_zone_lookup.test", "missing.*", "absent.example.com"),
+				),
+			},
+			{
+				Config:      testAccZoneLookupDataSourceConfig(container, false),
+				ExpectError: regexp.MustCompile(`No zone found for: absent\.example\.com`),
+			},
+		},
+	})
+}
+
+// testAccZoneLookupDataSourceConfig generates HCL configuration for zone lookup testing
+func testAccZoneLookupDataSourceConfig(container *testcontainer.SnitchDNSContainer, ignoreMissing bool) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "one" {
+  domain = "one.example.com"
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_zone" "two" {
+  domain    = "two.example.com"
+  active    = true
+  catch_all = true
+  regex     = false
+}
+
+data "snitchdns_zone_lookup" "test" {
+  domains        = ["one.example.com", "two.example.com", "absent.example.com"]
+  ignore_missing = %[3]t
+
+  depends_on = [snitchdns_zone.one, snitchdns_zone.two]
+}
+`, container.GetAPIEndpoint(), container.APIKey, ignoreMissing)
+}
+
+// TestLookupZones tests that domains are normalized and regex zones are skipped
+func TestLookupZones(t *testing.T) {
+	zones := []snitchdns.Zone{
+		{ID: 1, Domain: "example.com"},
+		{ID: 2, Domain: "Canary.Example.com."},
+		{ID: 3, Domain: "regex.example.com", Regex: true},
+	}
+
+	found, missing := lookupZones(zones, []string{"EXAMPLE.com.", "canary.example.com", "regex.example.com", "absent.example.com"})
+
+	if len(found) != 2 || found["EXAMPLE.com."].ID != 1 || found["canary.example.com"].ID != 2 {
+		t.Errorf("Unexpected zones: %+v", found)
+	}
+	if !reflect.DeepEqual(missing, []string{"regex.example.com", "absent.example.com"}) {
+		t.Errorf("Unexpected missing domains: %v", missing)
+	}
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ZoneMatchDataSource{}
+
+// NewZoneMatchDataSource creates a new Zone Match data source.
+func NewZoneMatchDataSource() datasource.DataSource {
+	return &ZoneMatchDataSource{}
+}
+
+// ZoneMatchDataSource defines the data source implementation. The zone is
+// matched against the shared zone listing and the record against the cached
+// record listing, without sending a DNS query.
+type ZoneMatchDataSource struct {
+	zones      *ZoneResolver
+	records    *RecordCache
+	recordData *recordDataMapper
+	offline    bool
+}
+
+// ZoneMatchDataSourceModel describes the data source data model.
+type ZoneMatchDataSourceModel struct {
+	Name       types.String `tfsdk:"name"`
+	Type       types.String `tfsdk:"type"`
+	Class      types.String `tfsdk:"cls"`
+	Found      types.Bool   `tfsdk:"found"`
+	Match      types.String `tfsdk:"match"`
+	ZoneID     types.String `tfsdk:"zone_id"`
+	ZoneDomain types.String `tfsdk:"zone_domain"`
+	RecordID   types.String `tfsdk:"record_id"`
+	RecordData types.Map    `tfsdk:"record_data"`
+	Forwarded  types.Bool   `tfsdk:"forwarded"`
+}
+
+// Metadata sets the data source type name.
+func (d *ZoneMatchDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_match"
+}
+
+// Schema defines the data source schema.
+func (d *ZoneMatchDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports which zone and record would answer a query for a name, following the matching rules of SnitchDNS, " +
+			"so modules can assert routing expectations where catch-all and regex zones overlap. The zones and records are read from the API; no DNS query is sent.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Query name, e.g. `www.example.com`. Matched case-insensitively and without a trailing dot.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Query type, such as `A` or `TXT`, ignoring case. Defaults to `A`.",
+			},
+			"cls": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Query class, ignoring case. Defaults to `IN`.",
+			},
+			"found": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether a zone answers the name.",
+			},
+			"match": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "How the zone matched: `exact` when its domain is the name, `regex` when its pattern matches the name, " +
+					"or `catch_all` when it is a catch-all zone of a parent domain. Null when no zone answers.",
+			},
+			"zone_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the zone answering the name. Null when no zone answers.",
+			},
+			"zone_domain": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Domain of the zone answering the name, as stored by SnitchDNS. Null when no zone answers.",
+			},
+			"record_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the record answering the query. Null when the zone has no active record of the type and class.",
+			},
+			"record_data": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Data of the record answering the query, in the format of the `snitchdns_record` `data` attribute. Null without a record.",
+			},
+			"forwarded": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the query is forwarded upstream, because the zone has no record for it and has forwarding enabled.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *ZoneMatchDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.zones = providerData.Zones
+	d.records = providerData.Records
+	d.recordData = providerData.RecordData
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *ZoneMatchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "match zones")
+		return
+	}
+
+	var data ZoneMatchDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordType, class := "A", "IN"
+	if !data.Type.IsNull() {
+		recordType = data.Type.ValueString()
+	}
+	if !data.Class.IsNull() {
+		class = data.Class.ValueString()
+	}
+
+	zones, err := d.zones.ListZones(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error listing zones", "Could not list zones", err)
+		return
+	}
+
+	data.Found = types.BoolValue(false)
+	data.Match = types.StringNull()
+	data.ZoneID = types.StringNull()
+	data.ZoneDomain = types.StringNull()
+	data.RecordID = types.StringNull()
+	data.RecordData = types.MapNull(types.StringType)
+	data.Forwarded = types.BoolValue(false)
+
+	zone, match, ok := snitchdns.MatchQueryZone(zones, data.Name.ValueString())
+	if !ok {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	zoneID := strconv.Itoa(zone.ID)
+	data.Found = types.BoolValue(true)
+	data.Match = types.StringValue(match)
+	data.ZoneID = types.StringValue(zoneID)
+	data.ZoneDomain = types.StringValue(zone.Domain)
+
+	records, err := d.records.ListRecords(ctx, zoneID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error listing records",
+			fmt.Sprintf("Could not list records of zone %s", zoneID), err)
+		return
+	}
+
+	record, ok := snitchdns.MatchQueryRecord(records, recordType, class)
+	if !ok {
+		data.Forwarded = types.BoolValue(zone.Forwarding)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	recordData, diags := types.MapValueFrom(ctx, types.StringType, d.recordData.FromServerRecord(record).Data.Strings())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.RecordID = types.StringValue(strconv.Itoa(record.ID))
+	data.RecordData = recordData
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
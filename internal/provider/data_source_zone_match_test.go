@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccZoneMatchDataSource tests finding the zone and record answering a
+// name where an exact and a catch-all zone overlap
+func TestAccZoneMatchDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneMatchDataSourceConfig(container),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.snitchdns_zone_match.exact", "match", "exact"),
+					resource.TestCheckResourceAttrPair("data.snitchdns_zone_match.exact", "zone_id", "snitchdns_zone.www", "id"),
+					resource.TestCheckResourceAttrPair("data.snitchdns_zone_match.exact", "record_id", "snitchdns_record.www", "id"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone_match.exact", "record_data.address", "192.0.2.10"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone_match.catch_all", "match", "catch_all"),
+					resource.TestCheckResourceAttrPair("data.snitchdns_zone_match.catch_all", "zone_id", "snitchdns_zone.parent", "id"),
+					resource.TestCheckNoResourceAttr("data.snitchdns_zone_match.catch_all", "record_id"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone_match.none", "found", "false"),
+				),
+			},
+		},
+	})
+}
+
+// testAccZoneMatchDataSourceConfig generates HCL configuration for zone match testing
+func testAccZoneMatchDataSourceConfig(container *testcontainer.SnitchDNSContainer) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "parent" {
+  domain    = "match.example.com"
+  active    = true
+  catch_all = true
+  regex     = false
+}
+
+resource "snitchdns_zone" "www" {
+  domain = "www.match.example.com"
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_record" "www" {
+  zone_id = snitchdns_zone.www.id
+  active  = true
+  cls     = "IN"
+  type    = "A"
+  ttl     = 300
+  data    = { address = "192.0.2.10" }
+}
+
+data "snitchdns_zone_match" "exact" {
+  name = "WWW.match.example.com."
+
+  depends_on = [snitchdns_record.www]
+}
+
+data "snitchdns_zone_match" "catch_all" {
+  name = "other.match.example.com"
+
+  depends_on = [snitchdns_zone.parent, snitchdns_zone.www]
+}
+
+data "snitchdns_zone_match" "none" {
+  name = "match.example.org"
+
+  depends_on = [snitchdns_zone.parent]
+}
+`, container.GetAPIEndpoint(), container.APIKey)
+}
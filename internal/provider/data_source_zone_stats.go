@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// zoneStatsDefaultDays is the number of days counted when no days are
+	// configured
+	zoneStatsDefaultDays = 30
+	// zoneStatsMaxDays bounds the days a single read counts
+	zoneStatsMaxDays = 366
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ZoneStatsDataSource{}
+
+// NewZoneStatsDataSource creates a new Zone Stats data source.
+func NewZoneStatsDataSource() datasource.DataSource {
+	return &ZoneStatsDataSource{}
+}
+
+// ZoneStatsDataSource defines the data source implementation. It reads the
+// activity summary of a zone and the queries it answered per type and day.
+type ZoneStatsDataSource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// ZoneStatsDataSourceModel describes the data source data model.
+type ZoneStatsDataSourceModel struct {
+	ZoneID        types.String `tfsdk:"zone_id"`
+	Days          types.Int64  `tfsdk:"days"`
+	RecordCount   types.Int64  `tfsdk:"record_count"`
+	TotalHits     types.Int64  `tfsdk:"total_hits"`
+	LastActivity  types.String `tfsdk:"last_activity"`
+	Since         types.String `tfsdk:"since"`
+	QueryCount    types.Int64  `tfsdk:"query_count"`
+	QueriesByType types.Map    `tfsdk:"queries_by_type"`
+	QueriesByDay  types.Map    `tfsdk:"queries_by_day"`
+}
+
+// Metadata sets the data source type name.
+func (d *ZoneStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_stats"
+}
+
+// Schema defines the data source schema.
+func (d *ZoneStatsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the query statistics of a zone: its total hits and the queries it answered per query type and per day. " +
+			"Use it for dashboards and for checks such as alerting when a canary zone was queried.",
+
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone.",
+			},
+			"days": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: fmt.Sprintf("Number of days counted in `query_count`, `queries_by_type` and `queries_by_day`, including today, up to %d. "+
+					"Days start at midnight UTC. Defaults to `%d`.", zoneStatsMaxDays, zoneStatsDefaultDays),
+				Validators: []validator.Int64{
+					int64validator.Between(1, zoneStatsMaxDays),
+				},
+			},
+			"record_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of records in the zone.",
+			},
+			"total_hits": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of queries the zone answered since it was created.",
+			},
+			"last_activity": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time of the latest query, as reported by SnitchDNS. Empty for a zone never queried.",
+			},
+			"since": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Start of the counted days, as an RFC 3339 time.",
+			},
+			"query_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of queries the zone answered in the counted days.",
+			},
+			"queries_by_type": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "Number of queries in the counted days per query type, such as `A`. Types that were not queried are missing.",
+			},
+			"queries_by_day": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "Number of queries per counted day, keyed by date such as `2024-05-01`. Every counted day is present, with `0` for days without queries.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *ZoneStatsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *ZoneStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "read zone statistics")
+		return
+	}
+
+	var data ZoneStatsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+	days := zoneStatsDefaultDays
+	if !data.Days.IsNull() {
+		days = int(data.Days.ValueInt64())
+	}
+
+	stats, err := d.client.GetZoneStats(ctx, zoneID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading zone statistics", fmt.Sprintf("Could not read stats of zone ID %s", zoneID), err)
+		return
+	}
+
+	since, dates := zoneStatsDays(time.Now(), days)
+	queries, err := d.client.GetZoneQueryStats(ctx, zoneID, snitchdns.ZoneQueryStatsParams{From: since})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading zone statistics", fmt.Sprintf("Could not read query counts of zone ID %s", zoneID), err)
+		return
+	}
+	tflog.Debug(ctx, "Read zone query statistics", map[string]any{
+		"zone_id": zoneID,
+		"since":   since.Format(time.RFC3339),
+		"total":   queries.Total,
+	})
+
+	byType := make(map[string]int64, len(queries.ByType))
+	for queryType, count := range queries.ByType {
+		byType[strings.ToUpper(queryType)] += int64(count)
+	}
+
+	// Servers leave out days without queries; days outside the range are
+	// dropped in case the server counts whole days in another time zone
+	byDay := make(map[string]int64, len(dates))
+	for _, date := range dates {
+		byDay[date] = int64(queries.ByDay[date])
+	}
+
+	byTypeMap, diags := types.MapValueFrom(ctx, types.Int64Type, byType)
+	resp.Diagnostics.Append(diags...)
+	byDayMap, diags := types.MapValueFrom(ctx, types.Int64Type, byDay)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.RecordCount = types.Int64Value(int64(stats.RecordCount))
+	data.TotalHits = types.Int64Value(int64(stats.TotalHits))
+	data.LastActivity = types.StringValue(stats.LastActivity)
+	data.Since = types.StringValue(since.Format(time.RFC3339))
+	data.QueryCount = types.Int64Value(int64(queries.Total))
+	data.QueriesByType = byTypeMap
+	data.QueriesByDay = byDayMap
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// zoneStatsDays returns the start of the counted days ending with the UTC
+// day of now, and their dates
+func zoneStatsDays(now time.Time, days int) (time.Time, []string) {
+	today := now.UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, 1-days)
+
+	dates := make([]string, 0, days)
+	for day := since; !day.After(today); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day.Format(time.DateOnly))
+	}
+	return since, dates
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccZoneStatsDataSource tests that a query shows up in the zone's statistics
+func TestAccZoneStatsDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	today := time.Now().UTC().Format(time.DateOnly)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWaitForHitZoneConfig(container),
+			},
+			{
+				PreConfig: func() {
+					testAccSendDNSQuery(t, container, "stats.hit.example.com")
+				},
+				Config: testAccWaitForHitZoneConfig(container) + `
+data "snitchdns_zone_stats" "test" {
+  zone_id = snitchdns_zone.test.id
+  days    = 7
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.snitchdns_zone_stats.test", "queries_by_day.%", "7"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone_stats.test", "queries_by_type.A", "1"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone_stats.test", "queries_by_day."+today, "1"),
+					resource.TestCheckResourceAttrSet("data.snitchdns_zone_stats.test", "total_hits"),
+				),
+			},
+		},
+	})
+}
+
+// TestZoneStatsDays tests the counted days end with the UTC day of now
+func TestZoneStatsDays(t *testing.T) {
+	now := time.Date(2024, 3, 1, 1, 30, 0, 0, time.FixedZone("CET", 2*60*60))
+
+	since, dates := zoneStatsDays(now, 3)
+	if !since.Equal(time.Date(2024, 2, 27, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected start %s", since)
+	}
+	if expected := []string{"2024-02-27", "2024-02-28", "2024-02-29"}; !reflect.DeepEqual(dates, expected) {
+		t.Errorf("Expected dates %v, got %v", expected, dates)
+	}
+}
+
+// TestZoneStatsDataSourceRead tests reading the statistics of a mock zone
+func TestZoneStatsDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	mock := snitchdnsmock.New()
+	zone, err := mock.CreateZone(snitchdns.CreateZoneRequest{Domain: "canary.example.com", Active: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	now := time.Now().UTC()
+	mock.AddLogs(
+		snitchdns.QueryLog{Domain: "canary.example.com", Type: "A", Matched: true, Date: now.Format("2006-01-02 15:04:05"), ZoneID: zone.ID},
+		snitchdns.QueryLog{Domain: "canary.example.com", Type: "txt", Matched: true, Date: now.AddDate(0, 0, -1).Format("2006-01-02 15:04:05"), ZoneID: zone.ID},
+		snitchdns.QueryLog{Domain: "canary.example.com", Type: "A", Matched: true, Date: now.AddDate(0, 0, -10).Format("2006-01-02 15:04:05"), ZoneID: zone.ID},
+		snitchdns.QueryLog{Domain: "other.example.org", Type: "A", Date: now.Format("2006-01-02 15:04:05")},
+	)
+
+	d := NewZoneStatsDataSource()
+	var configureResp datasource.ConfigureResponse
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{
+		Client: mock,
+	}}, &configureResp)
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	// Config has no setters, so build the value through a state
+	state := tfsdk.State{Schema: config.Schema, Raw: config.Raw}
+	state.SetAttribute(ctx, path.Root("zone_id"), types.StringValue(strconv.Itoa(zone.ID)))
+	state.SetAttribute(ctx, path.Root("days"), types.Int64Value(7))
+	config.Raw = state.Raw
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", resp.Diagnostics)
+	}
+
+	var data ZoneStatsDataSourceModel
+	resp.State.Get(ctx, &data)
+	if data.TotalHits.ValueInt64() != 3 || data.QueryCount.ValueInt64() != 2 {
+		t.Errorf("Expected 3 hits of which 2 in the counted days, got %+v", data)
+	}
+
+	var byType, byDay map[string]int64
+	data.QueriesByType.ElementsAs(ctx, &byType, false)
+	data.QueriesByDay.ElementsAs(ctx, &byDay, false)
+	if !reflect.DeepEqual(byType, map[string]int64{"A": 1, "TXT": 1}) {
+		t.Errorf("Unexpected counts per type: %v", byType)
+	}
+	if len(byDay) != 7 || byDay[now.Format(time.DateOnly)] != 1 || byDay[now.AddDate(0, 0, -6).Format(time.DateOnly)] != 0 {
+		t.Errorf("Unexpected counts per day: %v", byDay)
+	}
+}
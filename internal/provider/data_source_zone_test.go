@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccZoneDataSource tests looking up a zone by domain
+func TestAccZoneDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneDataSourceConfig(container, "Lookup.Example.com."),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.snitchdns_zone.test", "id", "snitchdns_zone.test", "id"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone.test", "catch_all", "true"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("data.snitchdns_zone.test", "tags.0", "external"),
+				),
+			},
+			{
+				Config:      testAccZoneDataSourceConfig(container, "absent.example.com"),
+				ExpectError: regexp.MustCompile(`No zone found for domain absent\.example\.com`),
+			},
+		},
+	})
+}
+
+// testAccZoneDataSourceConfig generates HCL configuration for zone data source testing
+func testAccZoneDataSourceConfig(container *testcontainer.SnitchDNSContainer, domain string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain    = "lookup.example.com"
+  active    = true
+  catch_all = true
+  regex     = false
+  tags      = ["external"]
+}
+
+data "snitchdns_zone" "test" {
+  domain = %[3]q
+
+  depends_on = [snitchdns_zone.test]
+}
+`, container.GetAPIEndpoint(), container.APIKey, domain)
+}
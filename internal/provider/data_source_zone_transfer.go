@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/internal/axfr"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ZoneTransferDataSource{}
+
+// NewZoneTransferDataSource creates a new Zone Transfer data source.
+func NewZoneTransferDataSource() datasource.DataSource {
+	return &ZoneTransferDataSource{}
+}
+
+// ZoneTransferDataSource defines the data source implementation. It talks to
+// an external DNS server rather than the SnitchDNS API, so it needs no
+// provider data.
+type ZoneTransferDataSource struct{}
+
+// ZoneTransferDataSourceModel describes the data source data model.
+type ZoneTransferDataSourceModel struct {
+	Server       types.String `tfsdk:"server"`
+	Zone         types.String `tfsdk:"zone"`
+	Types        types.List   `tfsdk:"types"`
+	Records      types.List   `tfsdk:"records"`
+	SkippedTypes types.List   `tfsdk:"skipped_types"`
+}
+
+// zoneTransferRecordAttrTypes are the attribute types of a transferred record
+var zoneTransferRecordAttrTypes = map[string]attr.Type{
+	"name":          types.StringType,
+	"relative_name": types.StringType,
+	"type":          types.StringType,
+	"cls":           types.StringType,
+	"ttl":           types.Int64Type,
+	"data":          types.MapType{ElemType: types.StringType},
+}
+
+// Metadata sets the data source type name.
+func (d *ZoneTransferDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_transfer"
+}
+
+// Schema defines the data source schema.
+func (d *ZoneTransferDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Performs a zone transfer (AXFR) from an external authoritative DNS server and returns the records in the data format of `snitchdns_record`, " +
+			"for migrating zones from BIND, PowerDNS or similar servers into SnitchDNS.",
+
+		Attributes: map[string]schema.Attribute{
+			"server": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Authoritative server to transfer from, as a host name or IP address with an optional port (default `53`), e.g. `ns1.example.com` or `192.0.2.1:5353`.",
+			},
+			"zone": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the zone to transfer, e.g. `example.com`.",
+			},
+			"types": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Only return records of these types, e.g. `[\"A\", \"CNAME\"]`. All supported types are returned when omitted.",
+			},
+			"records": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Transferred records in transfer order, excluding the closing SOA record.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Fully qualified owner name with a trailing dot.",
+						},
+						"relative_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Owner name relative to the zone; `@` for the zone apex.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Record type.",
+						},
+						"cls": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Record class.",
+						},
+						"ttl": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Time to live in seconds.",
+						},
+						"data": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "Record data in the format of the `snitchdns_record` `data` attribute.",
+						},
+					},
+				},
+			},
+			"skipped_types": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Types of transferred records that cannot be represented in SnitchDNS and were skipped, one entry per record.",
+			},
+		},
+	}
+}
+
+// Read implements the data source read logic
+func (d *ZoneTransferDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZoneTransferDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wanted := map[string]bool{}
+	if !data.Types.IsNull() {
+		var filter []string
+		resp.Diagnostics.Append(data.Types.ElementsAs(ctx, &filter, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, t := range filter {
+			wanted[strings.ToUpper(t)] = true
+		}
+	}
+
+	tflog.Debug(ctx, "Transferring zone", map[string]any{
+		"server": data.Server.ValueString(),
+		"zone":   data.Zone.ValueString(),
+	})
+
+	result, err := axfr.Transfer(ctx, data.Server.ValueString(), data.Zone.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error transferring zone",
+			fmt.Sprintf("Could not transfer zone %s from %s: %s. Make sure the server allows zone transfers to this host.",
+				data.Zone.ValueString(), data.Server.ValueString(), err),
+		)
+		return
+	}
+
+	zone := strings.TrimSuffix(data.Zone.ValueString(), ".") + "."
+	records := make([]attr.Value, 0, len(result.Records))
+	for _, record := range result.Records {
+		if len(wanted) > 0 && !wanted[record.Type] {
+			continue
+		}
+
+		recordData, diags := types.MapValueFrom(ctx, types.StringType, record.Data)
+		resp.Diagnostics.Append(diags...)
+
+		value, diags := types.ObjectValue(zoneTransferRecordAttrTypes, map[string]attr.Value{
+			"name":          types.StringValue(record.Name),
+			"relative_name": types.StringValue(relativeName(record.Name, zone)),
+			"type":          types.StringValue(record.Type),
+			"cls":           types.StringValue(record.Class),
+			"ttl":           types.Int64Value(int64(record.TTL)),
+			"data":          recordData,
+		})
+		resp.Diagnostics.Append(diags...)
+		records = append(records, value)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordList, diags := types.ListValue(types.ObjectType{AttrTypes: zoneTransferRecordAttrTypes}, records)
+	resp.Diagnostics.Append(diags...)
+
+	skipped, diags := types.ListValueFrom(ctx, types.StringType, result.Skipped)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Records = recordList
+	data.SkippedTypes = skipped
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// relativeName returns name relative to zone, or "@" for the apex. Names
+// outside the zone are returned unchanged.
+func relativeName(name, zone string) string {
+	if strings.EqualFold(name, zone) {
+		return "@"
+	}
+	suffix := "." + zone
+	if len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+		return name[:len(name)-len(suffix)]
+	}
+	return name
+}
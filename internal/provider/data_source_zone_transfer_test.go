@@ -0,0 +1,20 @@
+package provider
+
+import "testing"
+
+// TestRelativeName tests that owner names are made relative to the transferred zone
+func TestRelativeName(t *testing.T) {
+	cases := map[string]string{
+		"example.com.":           "@",
+		"www.example.com.":       "www",
+		"a.b.EXAMPLE.com.":       "a.b",
+		"other.org.":             "other.org.",
+		"notexample.com.":        "notexample.com.",
+		"_sip._tcp.example.com.": "_sip._tcp",
+	}
+	for name, want := range cases {
+		if got := relativeName(name, "example.com."); got != want {
+			t.Errorf("relativeName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
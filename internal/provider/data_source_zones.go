@@ -0,0 +1,394 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ZonesDataSource{}
+
+// NewZonesDataSource creates a new Zones data source.
+func NewZonesDataSource() datasource.DataSource {
+	return &ZonesDataSource{}
+}
+
+// ZonesDataSource defines the data source implementation. Zones are
+// filtered from the shared zone listing, which follows every page, or from a
+// listing narrowed by the server when only a domain search is needed.
+type ZonesDataSource struct {
+	zones   *ZoneResolver
+	offline bool
+}
+
+// ZonesDataSourceModel describes the data source data model.
+type ZonesDataSourceModel struct {
+	Tags           types.Set    `tfsdk:"tags"`
+	TagsAny        types.Set    `tfsdk:"tags_any"`
+	DomainContains types.String `tfsdk:"domain_contains"`
+	DomainRegex    types.String `tfsdk:"domain_regex"`
+	Active         types.Bool   `tfsdk:"active"`
+	CatchAll       types.Bool   `tfsdk:"catch_all"`
+	Master         types.Bool   `tfsdk:"master"`
+	Sort           types.String `tfsdk:"sort"`
+	Offset         types.Int64  `tfsdk:"offset"`
+	Limit          types.Int64  `tfsdk:"limit"`
+	Total          types.Int64  `tfsdk:"total"`
+	Zones          types.List   `tfsdk:"zones"`
+	IDs            types.List   `tfsdk:"ids"`
+}
+
+// zoneFilter selects zones from a listing. Nil fields do not filter.
+type zoneFilter struct {
+	Tags           []string
+	TagsAny        []string
+	DomainContains string
+	DomainRegex    *regexp.Regexp
+	Active         *bool
+	CatchAll       *bool
+	Master         *bool
+}
+
+// zoneSorts are the orders of the listed zones; a leading "-" reverses one
+var zoneSorts = []string{"id", "-id", "domain", "-domain"}
+
+// Metadata sets the data source type name.
+func (d *ZonesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zones"
+}
+
+// Schema defines the data source schema.
+func (d *ZonesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the zones the API key has access to, optionally filtered by tag, domain or flags, and paged with `offset` and `limit`.",
+
+		Attributes: map[string]schema.Attribute{
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Only list zones that have all of these tags.",
+			},
+			"tags_any": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Only list zones that have at least one of these tags.",
+			},
+			"domain_contains": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list zones whose domain contains this string, ignoring case. The search is passed to the server, so only matching zones are requested.",
+			},
+			"domain_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list zones whose domain matches this regular expression, in Go syntax. Matching is not anchored; use `^` and `$` to match the whole domain.",
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			},
+			"active": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list zones that are (`true`) or are not (`false`) active.",
+			},
+			"catch_all": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list zones that are (`true`) or are not (`false`) catch-all zones.",
+			},
+			"master": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list zones that are (`true`) or are not (`false`) master zones.",
+			},
+			"sort": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Order of the matching zones: `id` or `domain`, descending with a leading `-`. Defaults to `id`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(zoneSorts...),
+				},
+			},
+			"offset": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of matching zones to skip, in the order of `sort`. Defaults to `0`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of zones to return after `offset`. By default every matching zone is returned.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"total": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of matching zones before `offset` and `limit` are applied.",
+			},
+			"zones": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Matching zones, in the order of `sort`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Zone ID.",
+						},
+						"domain": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Domain as stored by SnitchDNS.",
+						},
+						"user_id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the user who owns the zone.",
+						},
+						"active": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the zone is active.",
+						},
+						"catch_all": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the zone answers every name below it.",
+						},
+						"forwarding": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether unmatched queries are forwarded.",
+						},
+						"regex": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the domain is a regular expression.",
+						},
+						"master": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether this is the user's master zone.",
+						},
+						"tags": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "Zone tags.",
+						},
+					},
+				},
+			},
+			"ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "IDs of the matching zones, in the order of `zones`.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *ZonesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.zones = providerData.Zones
+	d.offline = providerData.Offline
+}
+
+// Read implements the data source read logic
+func (d *ZonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.offline {
+		addOfflineError(&resp.Diagnostics, "list zones")
+		return
+	}
+
+	var data ZonesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := zoneFilter{
+		DomainContains: data.DomainContains.ValueString(),
+		Active:         data.Active.ValueBoolPointer(),
+		CatchAll:       data.CatchAll.ValueBoolPointer(),
+		Master:         data.Master.ValueBoolPointer(),
+	}
+	if !data.Tags.IsNull() {
+		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &filter.Tags, false)...)
+	}
+	if !data.TagsAny.IsNull() {
+		resp.Diagnostics.Append(data.TagsAny.ElementsAs(ctx, &filter.TagsAny, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.DomainRegex.IsNull() {
+		// The validator has already rejected invalid expressions
+		filter.DomainRegex = regexp.MustCompile(data.DomainRegex.ValueString())
+	}
+
+	zones, err := d.zones.SearchZones(ctx, filter.DomainContains)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error listing zones", "Could not list zones", err)
+		return
+	}
+
+	matched := filterZones(zones, filter)
+	sortZones(matched, data.Sort.ValueString())
+	data.Total = types.Int64Value(int64(len(matched)))
+	matched = pageZones(matched, int(data.Offset.ValueInt64()), int(data.Limit.ValueInt64()))
+
+	zoneValues := make([]attr.Value, 0, len(matched))
+	ids := make([]string, 0, len(matched))
+	for _, zone := range matched {
+		value, diags := zoneLookupValue(ctx, zone)
+		resp.Diagnostics.Append(diags...)
+
+		zoneValues = append(zoneValues, value)
+		ids = append(ids, strconv.Itoa(zone.ID))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneList, diags := types.ListValue(types.ObjectType{AttrTypes: zoneLookupAttrTypes}, zoneValues)
+	resp.Diagnostics.Append(diags...)
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Zones = zoneList
+	data.IDs = idList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterZones returns the zones matching the filter, ordered by ID
+func filterZones(zones []snitchdns.Zone, filter zoneFilter) []snitchdns.Zone {
+	contains := strings.ToLower(filter.DomainContains)
+
+	matched := []snitchdns.Zone{}
+	for _, zone := range zones {
+		if contains != "" && !strings.Contains(strings.ToLower(zone.Domain), contains) {
+			continue
+		}
+		if filter.DomainRegex != nil && !filter.DomainRegex.MatchString(zone.Domain) {
+			continue
+		}
+		if !hasAllTags(zone.Tags, filter.Tags) || !hasAnyTag(zone.Tags, filter.TagsAny) {
+			continue
+		}
+		if !matchesFlag(zone.Active, filter.Active) || !matchesFlag(zone.CatchAll, filter.CatchAll) || !matchesFlag(zone.Master, filter.Master) {
+			continue
+		}
+		matched = append(matched, zone)
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+	return matched
+}
+
+// sortZones orders zones in place by one of zoneSorts. An empty order keeps
+// the ID order of filterZones.
+func sortZones(zones []snitchdns.Zone, order string) {
+	descending := strings.HasPrefix(order, "-")
+	if strings.TrimPrefix(order, "-") == "domain" {
+		// Equal domains, e.g. of regex zones, stay in ID order
+		sort.SliceStable(zones, func(i, j int) bool {
+			return strings.ToLower(zones[i].Domain) < strings.ToLower(zones[j].Domain)
+		})
+	}
+	if descending {
+		for i, j := 0, len(zones)-1; i < j; i, j = i+1, j-1 {
+			zones[i], zones[j] = zones[j], zones[i]
+		}
+	}
+}
+
+// pageZones returns up to limit zones after skipping offset of them. A limit
+// of zero returns every remaining zone.
+func pageZones(zones []snitchdns.Zone, offset, limit int) []snitchdns.Zone {
+	if offset >= len(zones) {
+		return []snitchdns.Zone{}
+	}
+	zones = zones[offset:]
+	if limit > 0 && limit < len(zones) {
+		zones = zones[:limit]
+	}
+	return zones
+}
+
+// matchesFlag reports whether a zone flag has the wanted value, if any
+func matchesFlag(value bool, wanted *bool) bool {
+	return wanted == nil || value == *wanted
+}
+
+// hasAllTags reports whether every wanted tag is among the zone's tags
+func hasAllTags(tags, wanted []string) bool {
+	have := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		have[tag] = true
+	}
+	for _, tag := range wanted {
+		if !have[tag] {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAnyTag reports whether one of the wanted tags is among the zone's tags.
+// No wanted tags match every zone.
+func hasAnyTag(tags, wanted []string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	for _, tag := range wanted {
+		if slices.Contains(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// regexValidator validates that a string is a Go regular expression
+type regexValidator struct{}
+
+// Description describes the validation in plain text.
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a regular expression"
+}
+
+// MarkdownDescription describes the validation in Markdown.
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v regexValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid regular expression", err.Error())
+	}
+}
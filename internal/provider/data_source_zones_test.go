@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccZonesDataSource tests listing zones filtered by tag and domain
+func TestAccZonesDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZonesDataSourceConfig(container),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.snitchdns_zones.tagged", "zones.#", "1"),
+					resource.TestCheckResourceAttrPair("data.snitchdns_zones.tagged", "ids.0", "snitchdns_zone.prod", "id"),
+					resource.TestCheckResourceAttr("data.snitchdns_zones.tagged", "zones.0.domain", "prod.zones.example.com"),
+					resource.TestCheckResourceAttr("data.snitchdns_zones.matching", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.snitchdns_zones.page", "total", "2"),
+					resource.TestCheckResourceAttr("data.snitchdns_zones.page", "zones.#", "1"),
+					resource.TestCheckResourceAttr("data.snitchdns_zones.page", "zones.0.domain", "prod.zones.example.com"),
+				),
+			},
+		},
+	})
+}
+
+// testAccZonesDataSourceConfig generates HCL configuration for zone listing testing
+func testAccZonesDataSourceConfig(container *testcontainer.SnitchDNSContainer) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "prod" {
+  domain = "prod.zones.example.com"
+  active = true
+  regex  = false
+  tags   = ["prod", "canary"]
+}
+
+resource "snitchdns_zone" "staging" {
+  domain = "staging.zones.example.com"
+  active = true
+  regex  = false
+  tags   = ["canary"]
+}
+
+data "snitchdns_zones" "tagged" {
+  tags = ["prod", "canary"]
+
+  depends_on = [snitchdns_zone.prod, snitchdns_zone.staging]
+}
+
+data "snitchdns_zones" "matching" {
+  domain_contains = "ZONES.example"
+
+  depends_on = [snitchdns_zone.prod, snitchdns_zone.staging]
+}
+
+data "snitchdns_zones" "page" {
+  domain_regex = "^[a-z]+\\.zones\\.example\\.com$"
+  tags_any     = ["prod", "canary"]
+  active       = true
+  sort         = "-domain"
+  offset       = 1
+  limit        = 1
+
+  depends_on = [snitchdns_zone.prod, snitchdns_zone.staging]
+}
+`, container.GetAPIEndpoint(), container.APIKey)
+}
+
+// TestFilterZones tests selecting zones by tag, domain and flags
+func TestFilterZones(t *testing.T) {
+	zones := []snitchdns.Zone{
+		{ID: 3, Domain: "b.canary.example.com", Tags: []string{"prod"}, CatchAll: true},
+		{ID: 1, Domain: "a.canary.example.com", Tags: []string{"prod", "eu"}, Active: true},
+		{ID: 2, Domain: "other.example.org", Tags: []string{"eu"}, Active: true, Master: true},
+	}
+	yes, no := true, false
+
+	tests := []struct {
+		name   string
+		filter zoneFilter
+		want   []int
+	}{
+		{"no filter", zoneFilter{}, []int{1, 2, 3}},
+		{"tag", zoneFilter{Tags: []string{"eu"}}, []int{1, 2}},
+		{"all tags", zoneFilter{Tags: []string{"eu", "prod"}}, []int{1}},
+		{"domain", zoneFilter{DomainContains: "CANARY"}, []int{1, 3}},
+		{"both", zoneFilter{Tags: []string{"eu"}, DomainContains: ".org"}, []int{2}},
+		{"none", zoneFilter{Tags: []string{"missing"}}, []int{}},
+		{"any tag", zoneFilter{TagsAny: []string{"missing", "eu"}}, []int{1, 2}},
+		{"all and any tags", zoneFilter{Tags: []string{"prod"}, TagsAny: []string{"eu", "missing"}}, []int{1}},
+		{"regex", zoneFilter{DomainRegex: regexp.MustCompile(`^[ab]\.canary\.`)}, []int{1, 3}},
+		{"active", zoneFilter{Active: &yes}, []int{1, 2}},
+		{"inactive catch-all", zoneFilter{Active: &no, CatchAll: &yes}, []int{3}},
+		{"not master", zoneFilter{Master: &no, Tags: []string{"eu"}}, []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int{}
+			for _, zone := range filterZones(zones, tt.filter) {
+				got = append(got, zone.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterZones() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSortAndPageZones tests ordering filtered zones and selecting a page
+func TestSortAndPageZones(t *testing.T) {
+	zones := []snitchdns.Zone{
+		{ID: 1, Domain: "c.example.com"},
+		{ID: 2, Domain: "A.example.com"},
+		{ID: 3, Domain: "b.example.com"},
+	}
+
+	tests := []struct {
+		name          string
+		order         string
+		offset, limit int
+		want          []int
+	}{
+		{"default", "", 0, 0, []int{1, 2, 3}},
+		{"descending id", "-id", 0, 0, []int{3, 2, 1}},
+		{"domain", "domain", 0, 0, []int{2, 3, 1}},
+		{"descending domain", "-domain", 0, 0, []int{1, 3, 2}},
+		{"limit", "domain", 0, 2, []int{2, 3}},
+		{"offset", "id", 1, 0, []int{2, 3}},
+		{"page", "id", 1, 1, []int{2}},
+		{"past the end", "id", 5, 1, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := slices.Clone(zones)
+			sortZones(sorted, tt.order)
+
+			got := []int{}
+			for _, zone := range pageZones(sorted, tt.offset, tt.limit) {
+				got = append(got, zone.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
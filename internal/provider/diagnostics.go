@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// errorAttribute ties an API error a resource can cause to the attribute it
+// refers to and a remediation hint for that attribute
+type errorAttribute struct {
+	// code is the SnitchDNS error code
+	code int
+	// keyword narrows the error to messages mentioning it, if set
+	keyword string
+	attr    path.Path
+	hint    string
+}
+
+var (
+	// zoneErrorAttributes maps the errors of zone writes to the zone attributes
+	zoneErrorAttributes = []errorAttribute{
+		{code: snitchdns.ErrCodeZoneExists, attr: path.Root("domain"), hint: "A zone with this domain already exists in SnitchDNS. Import it with `terraform import` or choose a different domain."},
+		{code: snitchdns.ErrCodeEmptyDomain, attr: path.Root("domain"), hint: "The zone domain must not be empty."},
+		{code: snitchdns.ErrCodeTags, attr: path.Root("tags"), hint: "SnitchDNS could not save the zone tags. Tags must not contain commas."},
+	}
+
+	// ttlErrorAttribute maps a rejected TTL to the ttl attribute
+	ttlErrorAttribute = errorAttribute{code: snitchdns.ErrCodeInvalidValue, keyword: "ttl", attr: path.Root("ttl"),
+		hint: "The TTL is not accepted by SnitchDNS. Use a positive number of seconds."}
+
+	// recordErrorAttributes maps the errors of record writes to the record attributes
+	recordErrorAttributes = []errorAttribute{
+		{code: snitchdns.ErrCodeInvalidValue, keyword: "type", attr: path.Root("type"), hint: "SnitchDNS does not support this record type. Check the supported types in the record documentation."},
+		{code: snitchdns.ErrCodeInvalidValue, keyword: "class", attr: path.Root("cls"), hint: "SnitchDNS does not support this DNS class. Use one of IN, CH or HS."},
+		ttlErrorAttribute,
+		{code: snitchdns.ErrCodeInvalidValue, attr: path.Root("data"), hint: recordDataHint},
+		{code: snitchdns.ErrCodeInvalidData, attr: path.Root("data"), hint: recordDataHint},
+	}
+)
+
+const recordDataHint = "SnitchDNS rejected the record data. Check that `data` contains exactly the fields required by the record type, e.g. `address` for A records or `priority` and `hostname` for MX records."
+
+// addAPIError appends a diagnostic for a failed API call. Recurrent SnitchDNS
+// errors are mapped to a targeted diagnostic with a remediation hint. Callers
+// pass the attributes their request sets, so that errors about one of them
+// are attached to it; anything else is reported for the resource as a whole.
+// The detail starts with the operation description, e.g. "Could not create
+// zone", followed by the error and, for retried requests, the error of every
+// attempt.
+func addAPIError(diags *diag.Diagnostics, summary, operation string, err error, attrs ...errorAttribute) {
+	detail := fmt.Sprintf("%s: %s", operation, err)
+
+	var retryErr *snitchdns.RetryError
+	if errors.As(err, &retryErr) {
+		detail = fmt.Sprintf("%s\n\nAttempts:\n%s", detail, retryErr.History())
+	}
+
+	// The operation failed without reaching the server, and so will the
+	// ones after it until the server recovers
+	var circuitErr *snitchdns.CircuitOpenError
+	if errors.As(err, &circuitErr) {
+		diags.AddError("SnitchDNS Unavailable, Circuit Open", fmt.Sprintf("SnitchDNS failed %d requests in a row, so the provider stopped sending requests for circuit_breaker_cooldown "+
+			"instead of retrying every operation against a server that is down. Check that SnitchDNS is running and rerun Terraform.\n\n%s", circuitErr.Failures, detail))
+		return
+	}
+
+	var conflictErr *snitchdns.ConflictError
+	if errors.As(err, &conflictErr) {
+		diags.AddError("Conflicting Update", fmt.Sprintf("The object was changed outside of this Terraform run since it was last read, "+
+			"so the update was not made to keep from overwriting that change. Run terraform plan to review the current state, then apply again.\n\n%s", detail))
+		return
+	}
+
+	var validationErr *snitchdns.RequestValidationError
+	if errors.As(err, &validationErr) {
+		diags.AddError(summary, fmt.Sprintf("%s\n\nThe request was checked against the SnitchDNS API description embedded in the provider. "+
+			"If the server does accept it, set schema_validation = false in the provider configuration.", detail))
+		return
+	}
+
+	var apiErr *snitchdns.APIError
+	if !errors.As(err, &apiErr) {
+		diags.AddError(summary, detail)
+		return
+	}
+
+	attr, hint := apiErrorHint(apiErr, attrs)
+	if hint == "" {
+		diags.AddError(summary, detail)
+		return
+	}
+
+	detail = fmt.Sprintf("%s\n\n%s", hint, detail)
+	if attr.Equal(path.Empty()) {
+		diags.AddError(summary, detail)
+		return
+	}
+	diags.AddAttributeError(attr, summary, detail)
+}
+
+// apiErrorHint returns the attribute an API error refers to and a remediation
+// hint, or an empty hint when the error is not a known one. The first of the
+// caller's attributes matching the error wins; errors not tied to one are
+// reported without a path.
+func apiErrorHint(apiErr *snitchdns.APIError, attrs []errorAttribute) (path.Path, string) {
+	message := strings.ToLower(apiErr.Message + " " + apiErr.Details)
+
+	for _, attr := range attrs {
+		if apiErr.Code == attr.code && strings.Contains(message, attr.keyword) {
+			return attr.attr, attr.hint
+		}
+	}
+
+	switch {
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		return path.Empty(), "SnitchDNS rejected the API key. Check that api_key (or SNITCHDNS_API_KEY) is set to an enabled key."
+	case apiErr.StatusCode == http.StatusForbidden:
+		return path.Empty(), "The API key is not allowed to perform this operation. Administrative endpoints require a key belonging to an admin user."
+	case apiErr.Code == snitchdns.ErrCodeMissingFields:
+		return path.Empty(), "SnitchDNS reported missing required fields. This usually means the server version expects fields the provider does not send; please report it to the provider developers."
+	}
+
+	return path.Empty(), ""
+}
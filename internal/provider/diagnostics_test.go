@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// TestAddAPIError tests that known API errors are mapped to targeted diagnostics
+func TestAddAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		attrs    []errorAttribute
+		wantPath path.Path
+		wantHint string
+	}{
+		{
+			name:     "duplicate zone",
+			err:      &snitchdns.APIError{StatusCode: http.StatusBadRequest, Code: snitchdns.ErrCodeZoneExists, Message: "Domain already exists"},
+			attrs:    adoptableZoneErrorAttributes,
+			wantPath: path.Root("domain"),
+			wantHint: "adopt_existing",
+		},
+		{
+			name:     "duplicate zone without mapping",
+			err:      &snitchdns.APIError{StatusCode: http.StatusBadRequest, Code: snitchdns.ErrCodeZoneExists, Message: "Domain already exists"},
+			wantPath: path.Empty(),
+		},
+		{
+			name:     "unknown record type",
+			err:      &snitchdns.APIError{StatusCode: http.StatusBadRequest, Code: snitchdns.ErrCodeInvalidValue, Message: "Invalid type"},
+			attrs:    recordErrorAttributes,
+			wantPath: path.Root("type"),
+			wantHint: "does not support this record type",
+		},
+		{
+			name:     "invalid record data",
+			err:      &snitchdns.APIError{StatusCode: http.StatusBadRequest, Code: snitchdns.ErrCodeInvalidData, Message: "Invalid incoming data"},
+			attrs:    recordErrorAttributes,
+			wantPath: path.Root("data"),
+			wantHint: "rejected the record data",
+		},
+		{
+			name:     "record TTL",
+			err:      &snitchdns.APIError{StatusCode: http.StatusBadRequest, Code: snitchdns.ErrCodeInvalidValue, Message: "Invalid TTL"},
+			attrs:    []errorAttribute{ttlErrorAttribute},
+			wantPath: path.Root("ttl"),
+			wantHint: "positive number of seconds",
+		},
+		{
+			name:     "permission denied",
+			err:      &snitchdns.APIError{StatusCode: http.StatusForbidden},
+			wantPath: path.Empty(),
+			wantHint: "admin user",
+		},
+		{
+			name:     "unmapped error",
+			err:      &snitchdns.APIError{StatusCode: http.StatusInternalServerError, Body: "boom"},
+			wantPath: path.Empty(),
+		},
+		{
+			name: "retried request",
+			err: &snitchdns.RetryError{Retries: 1, Attempts: []snitchdns.Attempt{
+				{Err: &snitchdns.APIError{Err: errors.New("lookup snitch.example.com: i/o timeout")}},
+				{StatusCode: http.StatusServiceUnavailable, Err: &snitchdns.APIError{StatusCode: http.StatusServiceUnavailable}},
+			}},
+			wantPath: path.Empty(),
+			wantHint: "attempt 1: API request",
+		},
+		{
+			name: "circuit open",
+			err: &snitchdns.CircuitOpenError{Method: "GET", Path: "/zones/1", Failures: 5,
+				Err: &snitchdns.APIError{StatusCode: http.StatusBadGateway}},
+			wantPath: path.Empty(),
+			wantHint: "stopped sending requests",
+		},
+		{
+			name:     "non-API error",
+			err:      errors.New("connection refused"),
+			wantPath: path.Empty(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addAPIError(&diags, "Error creating zone", "Could not create zone", tt.err, tt.attrs...)
+
+			if len(diags) != 1 {
+				t.Fatalf("Expected 1 diagnostic, got %d", len(diags))
+			}
+
+			detail := diags[0].Detail()
+			if !strings.Contains(detail, "Could not create zone: "+tt.err.Error()) {
+				t.Errorf("Expected detail to contain the original error, got: %s", detail)
+			}
+			if tt.wantHint != "" && !strings.Contains(detail, tt.wantHint) {
+				t.Errorf("Expected detail to contain hint %q, got: %s", tt.wantHint, detail)
+			}
+
+			gotPath := path.Empty()
+			if withPath, ok := diags[0].(diag.DiagnosticWithPath); ok {
+				gotPath = withPath.Path()
+			}
+			if !gotPath.Equal(tt.wantPath) {
+				t.Errorf("Expected path %s, got %s", tt.wantPath, gotPath)
+			}
+		})
+	}
+}
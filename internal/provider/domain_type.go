@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the domain types satisfy the framework interfaces.
+var _ basetypes.StringTypable = DomainType{}
+var _ basetypes.StringValuableWithSemanticEquals = DomainValue{}
+
+// DomainType is the type of domain name attributes: a string whose values
+// ignore case and a trailing dot, as SnitchDNS normalizes both.
+type DomainType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable name of the type
+func (t DomainType) String() string {
+	return "DomainType"
+}
+
+// Equal reports whether the given type is a domain type
+func (t DomainType) Equal(o attr.Type) bool {
+	_, ok := o.(DomainType)
+	return ok
+}
+
+// ValueFromString wraps a string value as a domain
+func (t DomainType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return DomainValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform converts a Terraform value to a domain
+func (t DomainType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := value.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return DomainValue{StringValue: stringValue}, nil
+}
+
+// ValueType returns the value type of the type
+func (t DomainType) ValueType(_ context.Context) attr.Value {
+	return DomainValue{}
+}
+
+// DomainValue is a domain name attribute value.
+type DomainValue struct {
+	basetypes.StringValue
+}
+
+// NewDomainValue returns a known domain
+func NewDomainValue(domain string) DomainValue {
+	return DomainValue{StringValue: basetypes.NewStringValue(domain)}
+}
+
+// NewDomainNull returns a null domain
+func NewDomainNull() DomainValue {
+	return DomainValue{StringValue: basetypes.NewStringNull()}
+}
+
+// NewDomainUnknown returns an unknown domain
+func NewDomainUnknown() DomainValue {
+	return DomainValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+// Type returns the domain type
+func (v DomainValue) Type(_ context.Context) attr.Type {
+	return DomainType{}
+}
+
+// Equal reports whether the given value is the identical domain
+func (v DomainValue) Equal(o attr.Value) bool {
+	other, ok := o.(DomainValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether the domain read from the server (v)
+// names the same domain as the prior configuration or state, e.g.
+// example.com for Example.COM or example.com.
+func (v DomainValue) StringSemanticEquals(_ context.Context, priorValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	prior, ok := priorValuable.(DomainValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this issue to the provider developers.", v, priorValuable),
+		)
+		return false, diags
+	}
+
+	return snitchdns.NormalizeZoneDomain(prior.ValueString()) == snitchdns.NormalizeZoneDomain(v.ValueString()), diags
+}
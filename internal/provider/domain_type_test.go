@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+// TestDomainSemanticEquals tests that case and a trailing dot are ignored
+// but other changes are not
+func TestDomainSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		prior   string
+		current string
+		want    bool
+	}{
+		"identical":    {"example.com", "example.com", true},
+		"case":         {"Example.COM", "example.com", true},
+		"trailing dot": {"example.com.", "example.com", true},
+		"both":         {"Example.COM.", "example.com", true},
+		"other domain": {"example.com", "example.org", false},
+		"subdomain":    {"www.example.com", "example.com", false},
+		"two dots":     {"example.com", "example.com..", false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := NewDomainValue(tt.current).StringSemanticEquals(ctx, NewDomainValue(tt.prior))
+			if diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+			if equal != tt.want {
+				t.Errorf("Expected %q and %q to be equal: %v", tt.prior, tt.current, tt.want)
+			}
+		})
+	}
+}
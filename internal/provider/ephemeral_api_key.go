@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiKeyPrivateKey is the private data key holding the ID of the minted key
+const apiKeyPrivateKey = "api_key_id"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &APIKeyEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &APIKeyEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &APIKeyEphemeralResource{}
+
+// NewAPIKeyEphemeralResource creates a new API Key ephemeral resource.
+func NewAPIKeyEphemeralResource() ephemeral.EphemeralResource {
+	return &APIKeyEphemeralResource{}
+}
+
+// APIKeyEphemeralResource defines the ephemeral resource implementation. It
+// mints an API key for the provider's user when opened and revokes it when
+// closed, so the key never outlives the Terraform run or reaches the state.
+type APIKeyEphemeralResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// APIKeyEphemeralResourceModel describes the ephemeral resource data model.
+type APIKeyEphemeralResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Key  types.String `tfsdk:"key"`
+}
+
+// Metadata sets the ephemeral resource type name.
+func (r *APIKeyEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key"
+}
+
+// Schema defines the ephemeral resource schema.
+func (r *APIKeyEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a short-lived SnitchDNS API key for the user of the provider's API key, and revokes it when Terraform is done with it. " +
+			"Requires Terraform 1.10 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the key as shown in SnitchDNS. Defaults to `terraform-` followed by the creation time.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the key.",
+			},
+			"key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Secret of the key, sent in the `X-SnitchDNS-Auth` header.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the ephemeral resource.
+func (r *APIKeyEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// Open implements the ephemeral resource open logic
+func (r *APIKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create API key")
+		return
+	}
+
+	var data APIKeyEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.create(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privateData, _ := json.Marshal(data.ID.ValueString())
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, apiKeyPrivateKey, privateData)...)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Close implements the ephemeral resource close logic, revoking the key
+func (r *APIKeyEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateData, diags := req.Private.GetKey(ctx, apiKeyPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || privateData == nil {
+		return
+	}
+
+	var id string
+	if err := json.Unmarshal(privateData, &id); err != nil {
+		resp.Diagnostics.AddError("Invalid private data", fmt.Sprintf("Could not read the ID of the API key: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.revoke(ctx, id)...)
+}
+
+// create mints a key named after the model, or after the current time when
+// no name is configured, and sets the computed attributes
+func (r *APIKeyEphemeralResource) create(ctx context.Context, data *APIKeyEphemeralResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.Name.IsNull() || data.Name.IsUnknown() {
+		data.Name = types.StringValue("terraform-" + time.Now().UTC().Format("20060102T150405Z"))
+	}
+
+	key, err := r.client.CreateAPIKey(ctx, snitchdns.CreateAPIKeyRequest{Name: data.Name.ValueString()})
+	if err != nil {
+		addAPIError(&diags, "Error creating API key", fmt.Sprintf("Could not create API key %q", data.Name.ValueString()), err)
+		return diags
+	}
+
+	tflog.Debug(ctx, "Created ephemeral API key", map[string]any{
+		"api_key_id": key.ID,
+		"name":       key.Name,
+	})
+
+	data.ID = types.StringValue(strconv.Itoa(key.ID))
+	data.Name = types.StringValue(key.Name)
+	data.Key = types.StringValue(key.Key)
+	return diags
+}
+
+// revoke deletes the key. A key that is already gone is not an error.
+func (r *APIKeyEphemeralResource) revoke(ctx context.Context, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := r.client.DeleteAPIKey(ctx, id); err != nil && !snitchdns.IsNotFound(err) {
+		addAPIError(&diags, "Error revoking API key",
+			fmt.Sprintf("Could not revoke API key ID %s. Revoke it in the SnitchDNS web interface.", id), err)
+		return diags
+	}
+
+	tflog.Debug(ctx, "Revoked ephemeral API key", map[string]any{
+		"api_key_id": id,
+	})
+	return diags
+}
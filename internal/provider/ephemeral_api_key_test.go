@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccAPIKeyEphemeralResource tests that a key is minted for the run and
+// revoked afterwards
+func TestAccAPIKeyEphemeralResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"snitchdns": testAccProtoV6ProviderFactories(container)["snitchdns"],
+			"echo":      echoprovider.NewProviderServer(),
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIKeyEphemeralResourceConfig(container),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("name"), knownvalue.StringExact("terraform-acc")),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("key"), knownvalue.NotNull()),
+				},
+				Check: testAccCheckAPIKeyRevoked(container, "terraform-acc"),
+			},
+		},
+	})
+}
+
+// testAccCheckAPIKeyRevoked checks that no API key with the name is left
+func testAccCheckAPIKeyRevoked(container *testcontainer.SnitchDNSContainer, name string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		c := snitchdns.NewClient(container.GetAPIEndpoint(), container.APIKey)
+		keys, err := c.ListAPIKeys(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to list API keys: %w", err)
+		}
+		for _, key := range keys {
+			if key.Name == name {
+				return fmt.Errorf("expected API key %q to be revoked, found ID %d", name, key.ID)
+			}
+		}
+		return nil
+	}
+}
+
+// testAccAPIKeyEphemeralResourceConfig generates HCL configuration passing a
+// minted key to the echo provider, which stands in for a provisioner
+func testAccAPIKeyEphemeralResourceConfig(container *testcontainer.SnitchDNSContainer) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+ephemeral "snitchdns_api_key" "test" {
+  name = "terraform-acc"
+}
+
+provider "echo" {
+  data = ephemeral.snitchdns_api_key.test
+}
+
+resource "echo" "test" {}
+`, container.GetAPIEndpoint(), container.APIKey)
+}
+
+// TestAPIKeyEphemeralResource_Mock tests minting and revoking keys against the in-memory API
+func TestAPIKeyEphemeralResource_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := &APIKeyEphemeralResource{client: mock}
+
+	data := APIKeyEphemeralResourceModel{Name: types.StringNull()}
+	if diags := r.create(ctx, &data); diags.HasError() {
+		t.Fatalf("Unexpected create error: %v", diags)
+	}
+	if data.ID.IsNull() || data.Key.ValueString() == "" || len(data.Name.ValueString()) <= len("terraform-") {
+		t.Errorf("Expected a named key with a secret, got %+v", data)
+	}
+
+	keys, _ := mock.ListAPIKeys(ctx)
+	if len(keys) != 1 || keys[0].Name != data.Name.ValueString() {
+		t.Fatalf("Expected the key to be created, got %+v", keys)
+	}
+
+	if diags := r.revoke(ctx, data.ID.ValueString()); diags.HasError() {
+		t.Fatalf("Unexpected revoke error: %v", diags)
+	}
+	if keys, _ := mock.ListAPIKeys(ctx); len(keys) != 0 {
+		t.Errorf("Expected the key to be revoked, got %+v", keys)
+	}
+
+	// Revoking a key deleted elsewhere succeeds, other failures do not
+	if diags := r.revoke(ctx, data.ID.ValueString()); diags.HasError() {
+		t.Errorf("Expected revoking a missing key to succeed, got %v", diags)
+	}
+	mock.Fail("DeleteAPIKey", errors.New("connection refused"))
+	if diags := r.revoke(ctx, "1"); !diags.HasError() {
+		t.Error("Expected a failed revocation to be reported")
+	}
+}
@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	// canaryLabelDefaultLength is the number of generated characters when
+	// no length is given; 16 alphanumeric characters are about 82 bits
+	canaryLabelDefaultLength = 16
+	// canaryLabelMinLength keeps generated parts from being guessable
+	canaryLabelMinLength = 4
+	// dnsLabelMaxLength is the longest label DNS allows
+	dnsLabelMaxLength = 63
+)
+
+// canaryLabelCharsets are the named character sets of generated labels. All
+// of them are valid in DNS labels and survive case-insensitive matching.
+var canaryLabelCharsets = map[string]string{
+	"alnum":  "abcdefghijklmnopqrstuvwxyz0123456789",
+	"alpha":  "abcdefghijklmnopqrstuvwxyz",
+	"hex":    "0123456789abcdef",
+	"base32": "abcdefghijklmnopqrstuvwxyz234567",
+}
+
+// canaryLabelIDEncoding encodes embedded identifiers: lower-case base32
+// without padding, which only uses characters valid in DNS labels
+var canaryLabelIDEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &CanaryLabelFunction{}
+
+// NewCanaryLabelFunction creates a new canary_label function.
+func NewCanaryLabelFunction() function.Function {
+	return &CanaryLabelFunction{}
+}
+
+// CanaryLabelFunction generates DNS labels for canary token subdomains.
+type CanaryLabelFunction struct{}
+
+// canaryLabelOptions are the optional settings of canary_label
+type canaryLabelOptions struct {
+	Length  int
+	Charset string
+	ID      string
+}
+
+// Metadata sets the function name.
+func (f *CanaryLabelFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "canary_label"
+}
+
+// Definition defines the function signature.
+func (f *CanaryLabelFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Generates a DNS label for a canary token subdomain",
+		MarkdownDescription: "Generates a DNS label for a canary token subdomain from a seed. The same seed and options always produce the same label, " +
+			"while different seeds produce unrelated labels, so modules can mint unique, trackable names without storing random state.\n\n" +
+			"The optional second argument is an object with any of `length` (number of generated characters, default `16`), " +
+			"`charset` (`alnum` (default), `alpha`, `hex`, `base32`, or a string of the characters to use) and " +
+			"`id` (an identifier embedded in the label as lower-case, unpadded base32 before a `-`, so a hit can be traced back to it).",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "seed",
+				MarkdownDescription: "Secret or unique value the label is derived from, such as an engagement name combined with a host name.",
+			},
+		},
+		VariadicParameter: function.DynamicParameter{
+			Name:                "options",
+			MarkdownDescription: "Optional object with `length`, `charset` and `id`.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run generates the label.
+func (f *CanaryLabelFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seed string
+	var options []types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &seed, &options))
+	if resp.Error != nil {
+		return
+	}
+
+	if len(options) > 1 {
+		resp.Error = function.NewArgumentFuncError(1, "At most one options object may be given")
+		return
+	}
+
+	opts := canaryLabelOptions{Length: canaryLabelDefaultLength, Charset: "alnum"}
+	if len(options) == 1 {
+		if err := opts.parse(options[0]); err != nil {
+			resp.Error = function.NewArgumentFuncError(1, err.Error())
+			return
+		}
+	}
+
+	label, err := canaryLabel(seed, opts)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, label))
+}
+
+// parse reads the options object, leaving defaults for omitted attributes
+func (o *canaryLabelOptions) parse(value types.Dynamic) error {
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		return nil
+	}
+
+	var attributes map[string]attr.Value
+	switch v := value.UnderlyingValue().(type) {
+	case types.Object:
+		attributes = v.Attributes()
+	case types.Map:
+		attributes = v.Elements()
+	default:
+		return fmt.Errorf("options must be an object, got %s", value.UnderlyingValue().Type(context.Background()))
+	}
+
+	for name, attribute := range attributes {
+		if attribute.IsNull() {
+			continue
+		}
+
+		switch name {
+		case "length":
+			length, ok := canaryLabelNumber(attribute)
+			if !ok {
+				return fmt.Errorf("length must be a whole number")
+			}
+			o.Length = length
+		case "charset":
+			s, ok := attribute.(types.String)
+			if !ok {
+				return fmt.Errorf("charset must be a string")
+			}
+			o.Charset = s.ValueString()
+		case "id":
+			s, ok := attribute.(types.String)
+			if !ok {
+				return fmt.Errorf("id must be a string")
+			}
+			o.ID = s.ValueString()
+		default:
+			return fmt.Errorf("unknown option %q, expected length, charset or id", name)
+		}
+	}
+
+	return nil
+}
+
+// canaryLabelNumber converts a number attribute to an int
+func canaryLabelNumber(value attr.Value) (int, bool) {
+	var number *big.Float
+	switch v := value.(type) {
+	case types.Number:
+		number = v.ValueBigFloat()
+	case types.Int64:
+		return int(v.ValueInt64()), true
+	case basetypes.StringValue:
+		// Map options convert numbers to strings
+		parsed, ok := new(big.Float).SetString(v.ValueString())
+		if !ok {
+			return 0, false
+		}
+		number = parsed
+	default:
+		return 0, false
+	}
+
+	if !number.IsInt() {
+		return 0, false
+	}
+	n, _ := number.Int64()
+	return int(n), true
+}
+
+// canaryLabel derives a label from the seed. The generated part is an
+// HMAC-SHA256 stream keyed with the seed over the identifier, mapped onto the
+// character set without modulo bias.
+func canaryLabel(seed string, opts canaryLabelOptions) (string, error) {
+	if seed == "" {
+		return "", fmt.Errorf("seed must not be empty")
+	}
+
+	charset, err := canaryLabelCharset(opts.Charset)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.Length < canaryLabelMinLength || opts.Length > dnsLabelMaxLength {
+		return "", fmt.Errorf("length must be between %d and %d, got %d", canaryLabelMinLength, dnsLabelMaxLength, opts.Length)
+	}
+
+	prefix := ""
+	if opts.ID != "" {
+		prefix = strings.ToLower(canaryLabelIDEncoding.EncodeToString([]byte(opts.ID))) + "-"
+	}
+	if total := len(prefix) + opts.Length; total > dnsLabelMaxLength {
+		return "", fmt.Errorf("the label would be %d characters long, DNS allows at most %d; use a shorter id or length", total, dnsLabelMaxLength)
+	}
+
+	generated := make([]byte, 0, opts.Length)
+	limit := 256 - 256%len(charset)
+	for block := uint32(0); len(generated) < opts.Length; block++ {
+		mac := hmac.New(sha256.New, []byte(seed))
+		var counter [4]byte
+		binary.BigEndian.PutUint32(counter[:], block)
+		mac.Write(counter[:])
+		mac.Write([]byte(opts.ID))
+
+		for _, b := range mac.Sum(nil) {
+			// Bytes above the largest multiple of the charset size would
+			// favour the first characters, so they are skipped
+			if int(b) >= limit {
+				continue
+			}
+			generated = append(generated, charset[int(b)%len(charset)])
+			if len(generated) == opts.Length {
+				break
+			}
+		}
+	}
+
+	return prefix + string(generated), nil
+}
+
+// canaryLabelCharset resolves a named character set or validates a custom one
+func canaryLabelCharset(name string) (string, error) {
+	if charset, ok := canaryLabelCharsets[name]; ok {
+		return charset, nil
+	}
+
+	charset := strings.ToLower(name)
+	seen := make(map[rune]bool, len(charset))
+	for _, c := range charset {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+			return "", fmt.Errorf("charset must be alnum, alpha, hex, base32 or a string of letters and digits, got %q", name)
+		}
+		if seen[c] {
+			return "", fmt.Errorf("charset %q repeats %q", name, c)
+		}
+		seen[c] = true
+	}
+	if len(seen) < 2 {
+		return "", fmt.Errorf("charset must have at least two characters, got %q", name)
+	}
+
+	return charset, nil
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccCanaryLabelFunction tests calling canary_label from a configuration
+func TestAccCanaryLabelFunction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	expected, _ := canaryLabel("engagement-42", canaryLabelOptions{Length: 10, Charset: "hex", ID: "web01"})
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		// Functions need no provider configuration, so no server is started
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(nil),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "label" {
+  value = provider::snitchdns::canary_label("engagement-42", { length = 10, charset = "hex", id = "web01" })
+}
+
+output "default" {
+  value = provider::snitchdns::canary_label("engagement-42")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("label", expected),
+					resource.TestMatchOutput("default", regexp.MustCompile(`^[a-z0-9]{16}$`)),
+				),
+			},
+			{
+				Config: `
+output "label" {
+  value = provider::snitchdns::canary_label("engagement-42", { length = 80 })
+}
+`,
+				ExpectError: regexp.MustCompile(`length must be between 4 and 63`),
+			},
+		},
+	})
+}
+
+// TestCanaryLabel tests label generation
+func TestCanaryLabel(t *testing.T) {
+	defaults := canaryLabelOptions{Length: canaryLabelDefaultLength, Charset: "alnum"}
+
+	first, err := canaryLabel("seed", defaults)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !regexp.MustCompile(`^[a-z0-9]{16}$`).MatchString(first) {
+		t.Errorf("Expected 16 alphanumeric characters, got %q", first)
+	}
+
+	again, _ := canaryLabel("seed", defaults)
+	if again != first {
+		t.Errorf("Expected the same seed to produce the same label, got %q and %q", first, again)
+	}
+	other, _ := canaryLabel("other seed", defaults)
+	if other == first {
+		t.Errorf("Expected different seeds to produce different labels, both got %q", first)
+	}
+
+	long, _ := canaryLabel("seed", canaryLabelOptions{Length: 63, Charset: "hex"})
+	if !regexp.MustCompile(`^[0-9a-f]{63}$`).MatchString(long) {
+		t.Errorf("Expected 63 hex characters spanning several HMAC blocks, got %q", long)
+	}
+
+	custom, _ := canaryLabel("seed", canaryLabelOptions{Length: 8, Charset: "XY"})
+	if !regexp.MustCompile(`^[xy]{8}$`).MatchString(custom) {
+		t.Errorf("Expected a custom charset to be lower-cased and used, got %q", custom)
+	}
+}
+
+// TestCanaryLabel_EmbeddedID tests that an embedded identifier can be recovered
+func TestCanaryLabel_EmbeddedID(t *testing.T) {
+	label, err := canaryLabel("seed", canaryLabelOptions{Length: 8, Charset: "alnum", ID: "web01"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	encoded, generated, ok := strings.Cut(label, "-")
+	if !ok || len(generated) != 8 {
+		t.Fatalf("Expected an encoded id, a hyphen and 8 generated characters, got %q", label)
+	}
+	decoded, err := canaryLabelIDEncoding.DecodeString(strings.ToUpper(encoded))
+	if err != nil || string(decoded) != "web01" {
+		t.Errorf("Expected the id to decode to web01, got %q (%v)", decoded, err)
+	}
+
+	otherID, _ := canaryLabel("seed", canaryLabelOptions{Length: 8, Charset: "alnum", ID: "web02"})
+	if _, otherGenerated, _ := strings.Cut(otherID, "-"); otherGenerated == generated {
+		t.Errorf("Expected different ids to produce different generated parts, both got %q", generated)
+	}
+}
+
+// TestCanaryLabel_Errors tests rejected options
+func TestCanaryLabel_Errors(t *testing.T) {
+	tests := map[string]struct {
+		seed string
+		opts canaryLabelOptions
+		want string
+	}{
+		"empty seed":       {"", canaryLabelOptions{Length: 16, Charset: "alnum"}, "seed must not be empty"},
+		"too short":        {"seed", canaryLabelOptions{Length: 3, Charset: "alnum"}, "length must be between"},
+		"unknown charset":  {"seed", canaryLabelOptions{Length: 16, Charset: "a-z"}, "charset must be"},
+		"single character": {"seed", canaryLabelOptions{Length: 16, Charset: "aaa"}, "repeats"},
+		"id does not fit":  {"seed", canaryLabelOptions{Length: 40, Charset: "alnum", ID: strings.Repeat("x", 20)}, "DNS allows at most 63"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := canaryLabel(tt.seed, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestCanaryLabelFunction_Run tests option parsing through the function interface
+func TestCanaryLabelFunction_Run(t *testing.T) {
+	ctx := context.Background()
+
+	options := types.ObjectValueMust(
+		map[string]attr.Type{"length": types.NumberType, "charset": types.StringType},
+		map[string]attr.Value{"length": types.NumberValue(big.NewFloat(6)), "charset": types.StringValue("hex")},
+	)
+	variadic := types.TupleValueMust([]attr.Type{types.DynamicType}, []attr.Value{types.DynamicValue(options)})
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("seed"), variadic}),
+	}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+	NewCanaryLabelFunction().Run(ctx, req, &resp)
+	if resp.Error != nil {
+		t.Fatalf("Unexpected error: %v", resp.Error)
+	}
+
+	expected, _ := canaryLabel("seed", canaryLabelOptions{Length: 6, Charset: "hex"})
+	if got := resp.Result.Value().(types.String).ValueString(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	unknown := types.ObjectValueMust(
+		map[string]attr.Type{"size": types.NumberType},
+		map[string]attr.Value{"size": types.NumberValue(big.NewFloat(6))},
+	)
+	req.Arguments = function.NewArgumentsData([]attr.Value{
+		types.StringValue("seed"),
+		types.TupleValueMust([]attr.Type{types.DynamicType}, []attr.Value{types.DynamicValue(unknown)}),
+	})
+	resp = function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+	NewCanaryLabelFunction().Run(ctx, req, &resp)
+	if resp.Error == nil || !strings.Contains(resp.Error.Error(), `unknown option "size"`) {
+		t.Errorf("Expected an unknown option error, got %v", resp.Error)
+	}
+}
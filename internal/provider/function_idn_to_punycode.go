@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"golang.org/x/net/idna"
+)
+
+// idnProfile converts domain names like resolvers do (IDNA2008 with UTS #46
+// mapping, so upper-case letters are folded), but allows the underscores
+// and wildcards of service and canary names
+var idnProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.StrictDomainName(false),
+	idna.Transitional(false),
+	idna.VerifyDNSLength(true),
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &IDNToPunycodeFunction{}
+
+// NewIDNToPunycodeFunction creates a new idn_to_punycode function.
+func NewIDNToPunycodeFunction() function.Function {
+	return &IDNToPunycodeFunction{}
+}
+
+// IDNToPunycodeFunction converts internationalized domain names to the
+// ASCII form the API accepts.
+type IDNToPunycodeFunction struct{}
+
+// Metadata sets the function name.
+func (f *IDNToPunycodeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "idn_to_punycode"
+}
+
+// Definition defines the function signature.
+func (f *IDNToPunycodeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts an internationalized domain name to punycode",
+		MarkdownDescription: "Converts a domain name with Unicode labels to its ASCII form, encoding those labels as punycode (`xn--`). " +
+			"Names are mapped as by resolvers following IDNA2008 and UTS #46, so letters are lower-cased and compatible characters folded. " +
+			"ASCII names are returned lower-cased, so the result can be used for zone domains consistently.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "Domain name, such as `bücher.example`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run converts the domain.
+func (f *IDNToPunycodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &domain))
+	if resp.Error != nil {
+		return
+	}
+
+	ascii, err := convertIDN(domain, idnProfile.ToASCII)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, ascii))
+}
+
+// convertIDN applies an IDNA conversion to a domain name. A trailing dot
+// is kept, so fully qualified names stay fully qualified.
+func convertIDN(domain string, convert func(string) (string, error)) (string, error) {
+	name, fqdn := strings.CutSuffix(strings.TrimSpace(domain), ".")
+	if name == "" {
+		return "", fmt.Errorf("domain must not be empty")
+	}
+
+	converted, err := convert(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+	if fqdn {
+		converted += "."
+	}
+	return converted, nil
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccIDNFunctions tests calling idn_to_punycode and punycode_to_idn from
+// a configuration
+func TestAccIDNFunctions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		// Functions need no provider configuration, so no server is started
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(nil),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "ascii" {
+  value = provider::snitchdns::idn_to_punycode("Bücher.example")
+}
+
+output "unicode" {
+  value = provider::snitchdns::punycode_to_idn("xn--bcher-kva.example")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("ascii", "xn--bcher-kva.example"),
+					resource.TestCheckOutput("unicode", "bücher.example"),
+				),
+			},
+			{
+				Config: `
+output "unicode" {
+  value = provider::snitchdns::punycode_to_idn("xn--zz.example")
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid domain`),
+			},
+		},
+	})
+}
+
+// TestConvertIDN tests conversions in both directions
+func TestConvertIDN(t *testing.T) {
+	tests := []struct {
+		unicode string
+		ascii   string
+	}{
+		{"bücher.example", "xn--bcher-kva.example"},
+		{"bücher.example.", "xn--bcher-kva.example."},
+		{"*.canary.bücher.example", "*.canary.xn--bcher-kva.example"},
+		{"_dmarc.bücher.example", "_dmarc.xn--bcher-kva.example"},
+		{"faß.example", "xn--fa-hia.example"},
+		{"example.com", "example.com"},
+	}
+
+	for _, tt := range tests {
+		ascii, err := convertIDN(tt.unicode, idnProfile.ToASCII)
+		if err != nil || ascii != tt.ascii {
+			t.Errorf("ToASCII(%q) = %q, %v; expected %q", tt.unicode, ascii, err, tt.ascii)
+		}
+		unicode, err := convertIDN(tt.ascii, idnProfile.ToUnicode)
+		if err != nil || unicode != tt.unicode {
+			t.Errorf("ToUnicode(%q) = %q, %v; expected %q", tt.ascii, unicode, err, tt.unicode)
+		}
+	}
+
+	// Mapping folds case and compatible characters
+	if ascii, _ := convertIDN(" Bücher.EXAMPLE ", idnProfile.ToASCII); ascii != "xn--bcher-kva.example" {
+		t.Errorf("Expected the name to be lower-cased, got %q", ascii)
+	}
+}
+
+// TestConvertIDN_Errors tests rejected domains
+func TestConvertIDN_Errors(t *testing.T) {
+	tests := map[string]string{
+		"":                                   "must not be empty",
+		".":                                  "must not be empty",
+		"a..example":                         "invalid domain",
+		"-bücher.example":                    "invalid domain",
+		"xn--zz.example":                     "invalid domain",
+		strings.Repeat("ü", 64) + ".example": "invalid domain",
+	}
+
+	for input, want := range tests {
+		_, err := convertIDN(input, idnProfile.ToASCII)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("convertIDN(%q): expected an error containing %q, got %v", input, want, err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &PunycodeToIDNFunction{}
+
+// NewPunycodeToIDNFunction creates a new punycode_to_idn function.
+func NewPunycodeToIDNFunction() function.Function {
+	return &PunycodeToIDNFunction{}
+}
+
+// PunycodeToIDNFunction converts punycode domain names back to Unicode for
+// display.
+type PunycodeToIDNFunction struct{}
+
+// Metadata sets the function name.
+func (f *PunycodeToIDNFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "punycode_to_idn"
+}
+
+// Definition defines the function signature.
+func (f *PunycodeToIDNFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts a punycode domain name to Unicode",
+		MarkdownDescription: "Converts the punycode (`xn--`) labels of a domain name to Unicode, the reverse of `idn_to_punycode`. " +
+			"Invalid punycode is rejected.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "Domain name, such as `xn--bcher-kva.example`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run converts the domain.
+func (f *PunycodeToIDNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &domain))
+	if resp.Error != nil {
+		return
+	}
+
+	unicode, err := convertIDN(domain, idnProfile.ToUnicode)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, unicode))
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ReversePTRFunction{}
+
+// NewReversePTRFunction creates a new reverse_ptr function.
+func NewReversePTRFunction() function.Function {
+	return &ReversePTRFunction{}
+}
+
+// ReversePTRFunction converts IP addresses and networks to reverse DNS names.
+type ReversePTRFunction struct{}
+
+// Metadata sets the function name.
+func (f *ReversePTRFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "reverse_ptr"
+}
+
+// Definition defines the function signature.
+func (f *ReversePTRFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts an IP address or network to its reverse DNS name",
+		MarkdownDescription: "Converts an IPv4 or IPv6 address to its `in-addr.arpa` or `ip6.arpa` name, for PTR records. " +
+			"Given a network in CIDR notation, returns the name of its reverse zone instead; the prefix length must be a multiple of 8 " +
+			"for IPv4 and of 4 for IPv6, since RFC 2317 classless delegation is not supported.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ip",
+				MarkdownDescription: "IP address, such as `192.0.2.10` or `2001:db8::1`, or network, such as `192.0.2.0/24`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run converts the address.
+func (f *ReversePTRFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ip string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &ip))
+	if resp.Error != nil {
+		return
+	}
+
+	name, err := reversePTR(ip)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, name))
+}
+
+// reversePTR returns the reverse DNS name of an address, or of a network's
+// reverse zone, without a trailing dot. IPv4-mapped IPv6 addresses are
+// treated as IPv4.
+func reversePTR(value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	var prefix netip.Prefix
+	if strings.Contains(value, "/") {
+		parsed, err := netip.ParsePrefix(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid network %q: %w", value, err)
+		}
+		prefix = parsed.Masked()
+	} else {
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid IP address %q: %w", value, err)
+		}
+		if addr.Zone() != "" {
+			return "", fmt.Errorf("IP address %q must not have a zone", value)
+		}
+		addr = addr.Unmap()
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+
+	addr := prefix.Addr()
+	if addr.Is4() {
+		if prefix.Bits()%8 != 0 {
+			return "", fmt.Errorf("IPv4 prefix length must be a multiple of 8, got /%d", prefix.Bits())
+		}
+
+		octets := addr.As4()
+		labels := make([]string, 0, 5)
+		for i := prefix.Bits()/8 - 1; i >= 0; i-- {
+			labels = append(labels, fmt.Sprintf("%d", octets[i]))
+		}
+		return strings.Join(append(labels, "in-addr", "arpa"), "."), nil
+	}
+
+	if prefix.Bits()%4 != 0 {
+		return "", fmt.Errorf("IPv6 prefix length must be a multiple of 4, got /%d", prefix.Bits())
+	}
+
+	bytes := addr.As16()
+	labels := make([]string, 0, 34)
+	for i := prefix.Bits()/4 - 1; i >= 0; i-- {
+		nibble := bytes[i/2] >> 4
+		if i%2 == 1 {
+			nibble = bytes[i/2] & 0x0f
+		}
+		labels = append(labels, fmt.Sprintf("%x", nibble))
+	}
+	return strings.Join(append(labels, "ip6", "arpa"), "."), nil
+}
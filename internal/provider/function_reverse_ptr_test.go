@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccReversePTRFunction tests calling reverse_ptr from a configuration
+func TestAccReversePTRFunction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		// Functions need no provider configuration, so no server is started
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(nil),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "address" {
+  value = provider::snitchdns::reverse_ptr("192.0.2.10")
+}
+
+output "zone" {
+  value = provider::snitchdns::reverse_ptr("2001:db8::/32")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("address", "10.2.0.192.in-addr.arpa"),
+					resource.TestCheckOutput("zone", "8.b.d.0.1.0.0.2.ip6.arpa"),
+				),
+			},
+			{
+				Config: `
+output "zone" {
+  value = provider::snitchdns::reverse_ptr("192.0.2.0/25")
+}
+`,
+				ExpectError: regexp.MustCompile(`multiple of 8`),
+			},
+		},
+	})
+}
+
+// TestReversePTR tests reverse names of addresses and networks
+func TestReversePTR(t *testing.T) {
+	tests := map[string]string{
+		"192.0.2.10":         "10.2.0.192.in-addr.arpa",
+		" 10.1.2.3 ":         "3.2.1.10.in-addr.arpa",
+		"::ffff:192.0.2.1":   "1.2.0.192.in-addr.arpa",
+		"10.1.2.0/24":        "2.1.10.in-addr.arpa",
+		"10.1.2.3/16":        "1.10.in-addr.arpa",
+		"0.0.0.0/0":          "in-addr.arpa",
+		"2001:db8::/32":      "8.b.d.0.1.0.0.2.ip6.arpa",
+		"2001:db8:abcd::/36": "a.8.b.d.0.1.0.0.2.ip6.arpa",
+		"2001:db8::1":        "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+	}
+
+	for input, expected := range tests {
+		got, err := reversePTR(input)
+		if err != nil || got != expected {
+			t.Errorf("reversePTR(%q) = %q, %v; expected %q", input, got, err, expected)
+		}
+	}
+}
+
+// TestReversePTR_Errors tests rejected input
+func TestReversePTR_Errors(t *testing.T) {
+	tests := map[string]string{
+		"":              "invalid IP address",
+		"example.com":   "invalid IP address",
+		"192.0.2.0/33":  "invalid network",
+		"192.0.2.0/25":  "multiple of 8",
+		"2001:db8::/30": "multiple of 4",
+		"fe80::1%eth0":  "must not have a zone",
+	}
+
+	for input, want := range tests {
+		_, err := reversePTR(input)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("reversePTR(%q): expected an error containing %q, got %v", input, want, err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// invalidNameChars matches characters that are not allowed in Terraform
+// resource names
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// importZone is a zone and its records as enumerated for import generation
+type importZone struct {
+	Zone    snitchdns.Zone
+	Records []snitchdns.Record
+}
+
+// generatedImport is the output of generateImportConfig
+type generatedImport struct {
+	// ImportBlocks contains one import block per zone and record
+	ImportBlocks string
+	// Config contains skeleton resource blocks matching the import blocks
+	Config string
+}
+
+// generateImportConfig renders import blocks and skeleton resource
+// configuration for the given zones. Resource names are derived from the
+// domain and made unique, so the output can be written to a file and applied
+// as is; the skeleton only sets arguments, computed attributes are left out.
+func generateImportConfig(zones []importZone) generatedImport {
+	sorted := make([]importZone, len(zones))
+	copy(sorted, zones)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Zone.ID < sorted[j].Zone.ID
+	})
+
+	var imports, config strings.Builder
+	used := make(map[string]bool)
+
+	for _, z := range sorted {
+		zoneName := uniqueResourceName(used, resourceNameFromDomain(z.Zone.Domain), z.Zone.ID)
+		zoneAddr := "snitchdns_zone." + zoneName
+
+		writeImportBlock(&imports, zoneAddr, strconv.Itoa(z.Zone.ID))
+		writeZoneBlock(&config, zoneName, z.Zone)
+
+		records := make([]snitchdns.Record, len(z.Records))
+		copy(records, z.Records)
+		sort.Slice(records, func(i, j int) bool {
+			return records[i].ID < records[j].ID
+		})
+
+		for _, record := range records {
+			recordName := uniqueResourceName(used,
+				fmt.Sprintf("%s_%s_%d", zoneName, strings.ToLower(record.Type), record.ID), record.ID)
+
+			writeImportBlock(&imports, "snitchdns_record."+recordName, fmt.Sprintf("%d:%d", z.Zone.ID, record.ID))
+			writeRecordBlock(&config, recordName, zoneAddr, record)
+		}
+	}
+
+	return generatedImport{
+		ImportBlocks: imports.String(),
+		Config:       config.String(),
+	}
+}
+
+// resourceNameFromDomain converts a domain to a valid Terraform resource name,
+// e.g. "canary.example.com" to "canary_example_com"
+func resourceNameFromDomain(domain string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(domain), "_")
+	name = strings.Trim(name, "_")
+
+	if name == "" {
+		return "zone"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		return "zone_" + name
+	}
+	return name
+}
+
+// uniqueResourceName returns name, suffixed with id when it is already taken,
+// and marks the result as used
+func uniqueResourceName(used map[string]bool, name string, id int) string {
+	if used[name] {
+		name = fmt.Sprintf("%s_%d", name, id)
+	}
+	for used[name] {
+		name += "_"
+	}
+	used[name] = true
+	return name
+}
+
+// writeImportBlock renders a single import block
+func writeImportBlock(b *strings.Builder, to, id string) {
+	fmt.Fprintf(b, "import {\n  to = %s\n  id = %s\n}\n\n", to, hclString(id))
+}
+
+// writeZoneBlock renders the skeleton configuration of a zone
+func writeZoneBlock(b *strings.Builder, name string, zone snitchdns.Zone) {
+	fmt.Fprintf(b, "resource \"snitchdns_zone\" %q {\n", name)
+	fmt.Fprintf(b, "  domain     = %s\n", hclString(zone.Domain))
+	fmt.Fprintf(b, "  active     = %t\n", zone.Active)
+	fmt.Fprintf(b, "  catch_all  = %t\n", zone.CatchAll)
+	fmt.Fprintf(b, "  forwarding = %t\n", zone.Forwarding)
+	fmt.Fprintf(b, "  regex      = %t\n", zone.Regex)
+
+	if len(zone.Tags) > 0 {
+		tags := make([]string, len(zone.Tags))
+		for i, tag := range zone.Tags {
+			tags[i] = hclString(tag)
+		}
+		fmt.Fprintf(b, "  tags       = [%s]\n", strings.Join(tags, ", "))
+	}
+
+	b.WriteString("}\n\n")
+}
+
+// writeRecordBlock renders the skeleton configuration of a record
+func writeRecordBlock(b *strings.Builder, name, zoneAddr string, record snitchdns.Record) {
+	fmt.Fprintf(b, "resource \"snitchdns_record\" %q {\n", name)
+	fmt.Fprintf(b, "  zone_id = %s.id\n", zoneAddr)
+	fmt.Fprintf(b, "  active  = %t\n", record.Active)
+	fmt.Fprintf(b, "  cls     = %s\n", hclString(record.Class))
+	fmt.Fprintf(b, "  type    = %s\n", hclString(record.Type))
+	fmt.Fprintf(b, "  ttl     = %d\n", record.TTL)
+	writeHCLMap(b, "data", record.Data.Strings())
+
+	if record.IsConditional {
+		b.WriteString("\n  is_conditional    = true\n")
+		fmt.Fprintf(b, "  conditional_limit = %d\n", record.ConditionalLimit)
+		fmt.Fprintf(b, "  conditional_reset = %t\n", record.ConditionalReset)
+		writeHCLMap(b, "conditional_data", record.ConditionalData.Strings())
+	}
+
+	b.WriteString("}\n\n")
+}
+
+// writeHCLMap renders a map attribute with sorted, quoted keys
+func writeHCLMap(b *strings.Builder, attribute string, m map[string]string) {
+	if len(m) == 0 {
+		fmt.Fprintf(b, "  %s = {}\n", attribute)
+		return
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(b, "  %s = {\n", attribute)
+	for _, key := range keys {
+		fmt.Fprintf(b, "    %s = %s\n", hclString(key), hclString(m[key]))
+	}
+	b.WriteString("  }\n")
+}
+
+// hclString quotes a string for use in HCL, escaping template sequences so
+// values like "${x}" are kept literally
+func hclString(s string) string {
+	quoted := strconv.Quote(s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	quoted = strings.ReplaceAll(quoted, "%{", "%%{")
+	return quoted
+}
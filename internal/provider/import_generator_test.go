@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// TestGenerateImportConfig tests that import blocks and skeleton resources reference each other
+func TestGenerateImportConfig(t *testing.T) {
+	generated := generateImportConfig([]importZone{
+		{
+			Zone: snitchdns.Zone{ID: 7, Domain: "Canary.Example.com", Active: true, CatchAll: true, Tags: []string{"canary"}},
+			Records: []snitchdns.Record{
+				{ID: 12, Class: "IN", Type: "TXT", TTL: 60, Active: true, Data: snitchdns.EncodeRecordData(map[string]interface{}{"data": "${not-a-template}"})},
+				{ID: 3, Class: "IN", Type: "A", TTL: 300, Active: true, Data: snitchdns.EncodeRecordData(map[string]interface{}{"address": "10.0.0.1"})},
+			},
+		},
+	})
+
+	for _, want := range []string{
+		"to = snitchdns_zone.canary_example_com\n  id = \"7\"",
+		"to = snitchdns_record.canary_example_com_a_3\n  id = \"7:3\"",
+		"to = snitchdns_record.canary_example_com_txt_12\n  id = \"7:12\"",
+	} {
+		if !strings.Contains(generated.ImportBlocks, want) {
+			t.Errorf("Expected import blocks to contain %q, got:\n%s", want, generated.ImportBlocks)
+		}
+	}
+
+	for _, want := range []string{
+		`resource "snitchdns_zone" "canary_example_com"`,
+		`tags       = ["canary"]`,
+		`zone_id = snitchdns_zone.canary_example_com.id`,
+		`"address" = "10.0.0.1"`,
+		`"data" = "$${not-a-template}"`,
+	} {
+		if !strings.Contains(generated.Config, want) {
+			t.Errorf("Expected config to contain %q, got:\n%s", want, generated.Config)
+		}
+	}
+
+	// Records are emitted in ID order
+	if strings.Index(generated.Config, "_a_3") > strings.Index(generated.Config, "_txt_12") {
+		t.Error("Expected records to be ordered by ID")
+	}
+}
+
+// TestResourceNameFromDomain tests that domains become valid and unique resource names
+func TestResourceNameFromDomain(t *testing.T) {
+	cases := map[string]string{
+		"example.com":      "example_com",
+		"1.example.com.":   "zone_1_example_com",
+		"^.*\\.canary$":    "canary",
+		"xn--bcher-kva.ch": "xn_bcher_kva_ch",
+	}
+	for domain, want := range cases {
+		if got := resourceNameFromDomain(domain); got != want {
+			t.Errorf("resourceNameFromDomain(%q) = %q, want %q", domain, got, want)
+		}
+	}
+
+	used := make(map[string]bool)
+	first := uniqueResourceName(used, "example_com", 1)
+	second := uniqueResourceName(used, "example_com", 2)
+	if first != "example_com" || second != "example_com_2" {
+		t.Errorf("Expected example_com and example_com_2, got %s and %s", first, second)
+	}
+}
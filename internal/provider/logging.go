@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logAPIRequest is the client request hook used by the provider. It emits one
+// debug entry per API call; the framework has already attached the resource
+// type and RPC to ctx, so entries can be traced back to the resource.
+func logAPIRequest(ctx context.Context, info snitchdns.RequestInfo) {
+	fields := map[string]any{
+		"operation":   info.Method + " " + info.Path,
+		"request_id":  info.RequestID,
+		"status_code": info.StatusCode,
+		"attempts":    info.Attempts,
+		"retries":     info.Attempts - 1,
+		"duration_ms": info.Duration.Milliseconds(),
+	}
+	if info.Err != nil {
+		fields["error"] = info.Err.Error()
+	}
+
+	tflog.Debug(ctx, "SnitchDNS API request", fields)
+}
+
+// logAPIAttempt is the client attempt hook used by the provider. It emits one
+// debug entry per HTTP attempt, so retries of a call show up with the status
+// and duration of each try.
+func logAPIAttempt(ctx context.Context, info snitchdns.AttemptInfo) {
+	fields := map[string]any{
+		"operation":   info.Method + " " + info.Path,
+		"request_id":  info.RequestID,
+		"attempt":     info.Attempt,
+		"status_code": info.StatusCode,
+		"duration_ms": info.Duration.Milliseconds(),
+	}
+	if info.Err != nil {
+		fields["error"] = info.Err.Error()
+	}
+
+	tflog.Debug(ctx, "SnitchDNS API request attempt", fields)
+}
+
+// logHTTPDebug is the client logger used by the provider. The client only
+// calls it when debug_http is enabled, once per request attempt and once per
+// response, with credentials already redacted.
+func logHTTPDebug(ctx context.Context, msg string, fields map[string]any) {
+	tflog.Debug(ctx, msg, fields)
+}
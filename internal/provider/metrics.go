@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiMetrics collects the measurements of every client of the process.
+// Terraform runs the provider once per plan or apply, so at shutdown it
+// holds the measurements of that run, across all provider configurations.
+var apiMetrics = snitchdns.NewMetrics()
+
+// LogMetricsSummary writes a summary of the API requests made by the
+// provider to the debug log of ctx: an entry with the totals followed by an
+// entry per endpoint. It is called when the provider server stops and does
+// nothing when no request was made.
+func LogMetricsSummary(ctx context.Context) {
+	for _, entry := range metricsSummary(apiMetrics.Snapshot()) {
+		tflog.Debug(ctx, entry.Message, entry.Fields)
+	}
+}
+
+// metricsLogEntry is a debug log entry of the metrics summary
+type metricsLogEntry struct {
+	Message string
+	Fields  map[string]any
+}
+
+// metricsSummary returns the log entries summarizing the measurements, the
+// totals followed by the endpoints in name order, or nil when there are none
+func metricsSummary(snapshot snitchdns.MetricsSnapshot) []metricsLogEntry {
+	if len(snapshot.Endpoints) == 0 {
+		return nil
+	}
+
+	endpoints := make([]string, 0, len(snapshot.Endpoints))
+	for name := range snapshot.Endpoints {
+		endpoints = append(endpoints, name)
+	}
+	sort.Strings(endpoints)
+
+	var requests, retries int64
+	entries := []metricsLogEntry{{Message: "SnitchDNS API metrics"}}
+	for _, name := range endpoints {
+		e := snapshot.Endpoints[name]
+		requests += e.Latency.Count
+		retries += e.Retries
+
+		statuses := make(map[string]int64, len(e.Requests))
+		for status, count := range e.Requests {
+			label := strconv.Itoa(status)
+			if status == 0 {
+				label = "no response"
+			}
+			statuses[label] = count
+		}
+
+		entries = append(entries, metricsLogEntry{
+			Message: "SnitchDNS API endpoint metrics",
+			Fields: map[string]any{
+				"endpoint":        name,
+				"requests":        e.Latency.Count,
+				"statuses":        statuses,
+				"retries":         e.Retries,
+				"mean_latency_ms": e.Latency.Mean().Milliseconds(),
+			},
+		})
+	}
+
+	entries[0].Fields = map[string]any{
+		"requests": requests,
+		"retries":  retries,
+	}
+	return entries
+}
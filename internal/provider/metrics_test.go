@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// TestMetricsSummary tests the debug log summary of API requests
+func TestMetricsSummary(t *testing.T) {
+	metrics := snitchdns.NewMetrics()
+	if entries := metricsSummary(metrics.Snapshot()); entries != nil {
+		t.Errorf("Expected no summary without requests, got %v", entries)
+	}
+
+	metrics.ObserveRequest("GET /zones", 200, 10*time.Millisecond)
+	metrics.ObserveRequest("POST /zones/{id}/records", 503, 30*time.Millisecond)
+	metrics.ObserveRequest("POST /zones/{id}/records", 0, 50*time.Millisecond)
+	metrics.ObserveRequest("POST /zones/{id}/records", 200, 40*time.Millisecond)
+	metrics.ObserveRetry("POST /zones/{id}/records")
+	metrics.ObserveRetry("POST /zones/{id}/records")
+
+	want := []metricsLogEntry{
+		{"SnitchDNS API metrics", map[string]any{"requests": int64(4), "retries": int64(2)}},
+		{"SnitchDNS API endpoint metrics", map[string]any{
+			"endpoint":        "GET /zones",
+			"requests":        int64(1),
+			"statuses":        map[string]int64{"200": 1},
+			"retries":         int64(0),
+			"mean_latency_ms": int64(10),
+		}},
+		{"SnitchDNS API endpoint metrics", map[string]any{
+			"endpoint":        "POST /zones/{id}/records",
+			"requests":        int64(3),
+			"statuses":        map[string]int64{"no response": 1, "200": 1, "503": 1},
+			"retries":         int64(2),
+			"mean_latency_ms": int64(40),
+		}},
+	}
+	if entries := metricsSummary(metrics.Snapshot()); !reflect.DeepEqual(entries, want) {
+		t.Errorf("Unexpected summary:\n%v\nwant:\n%v", entries, want)
+	}
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// mockDataPath is the mock data shipped for terraform test mock providers
+const mockDataPath = "../../examples/testing/mocks/snitchdns.tfmock.hcl"
+
+// mockedAttribute is an attribute of a resource or data source schema as
+// far as mock data is concerned
+type mockedAttribute struct {
+	computed bool
+	typ      attr.Type
+	// def is the provider default, null when the attribute has none
+	def cty.Value
+}
+
+// TestMockData tests that the shipped mock data covers every resource and
+// data source, sets only computed attributes with values of the right type,
+// and uses the provider default for attributes that have one
+func TestMockData(t *testing.T) {
+	ctx := context.Background()
+
+	source, err := os.ReadFile(mockDataPath)
+	if err != nil {
+		t.Fatalf("Failed to read mock data: %v", err)
+	}
+	file, diags := hclsyntax.ParseConfig(source, mockDataPath, hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("Failed to parse mock data: %s", diags.Error())
+	}
+
+	mocked := map[string]map[string]cty.Value{}
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if len(block.Labels) != 1 || (block.Type != "mock_resource" && block.Type != "mock_data") {
+			t.Errorf("Unexpected block %s %v", block.Type, block.Labels)
+			continue
+		}
+		key := block.Type + "." + block.Labels[0]
+
+		expr, ok := block.Body.Attributes["defaults"]
+		if !ok {
+			t.Errorf("%s has no defaults", key)
+			continue
+		}
+		value, diags := expr.Expr.Value(nil)
+		if diags.HasErrors() {
+			t.Errorf("%s: invalid defaults: %s", key, diags.Error())
+			continue
+		}
+		mocked[key] = value.AsValueMap()
+	}
+
+	schemas := map[string]map[string]mockedAttribute{}
+	p := &SnitchDNSProvider{}
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "snitchdns"}, &metadata)
+		var schema resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schema)
+
+		attributes := map[string]mockedAttribute{}
+		for name, a := range schema.Schema.Attributes {
+			attributes[name] = mockedAttribute{
+				computed: a.IsComputed(),
+				typ:      a.GetType(),
+				def:      resourceDefault(ctx, a),
+			}
+		}
+		schemas["mock_resource."+metadata.TypeName] = attributes
+	}
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+		var metadata datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "snitchdns"}, &metadata)
+		var schema datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &schema)
+
+		attributes := map[string]mockedAttribute{}
+		for name, a := range schema.Schema.Attributes {
+			attributes[name] = mockedAttribute{computed: a.IsComputed(), typ: a.GetType(), def: cty.NullVal(cty.DynamicPseudoType)}
+		}
+		schemas["mock_data."+metadata.TypeName] = attributes
+	}
+
+	for key, attributes := range schemas {
+		values, ok := mocked[key]
+		if !ok {
+			t.Errorf("Mock data has no %s block", key)
+			continue
+		}
+
+		for name, a := range attributes {
+			value, set := values[name]
+			switch {
+			case !a.computed && set:
+				t.Errorf("%s sets %s, which is not computed", key, name)
+			case a.computed && !set:
+				t.Errorf("%s does not set computed attribute %s", key, name)
+			case !set:
+				continue
+			case !mockValueMatches(a.typ, value):
+				t.Errorf("%s sets %s to %#v, which does not match type %s", key, name, value, a.typ)
+			case !a.def.IsNull() && !a.def.RawEquals(value):
+				t.Errorf("%s sets %s to %#v, expected the provider default %#v", key, name, value, a.def)
+			}
+		}
+		for name := range values {
+			if _, ok := attributes[name]; !ok {
+				t.Errorf("%s sets unknown attribute %s", key, name)
+			}
+		}
+	}
+	for key := range mocked {
+		if _, ok := schemas[key]; !ok {
+			t.Errorf("Mock data has a block for unknown %s", key)
+		}
+	}
+}
+
+// resourceDefault returns the static default of a top-level attribute, or
+// null when it has none
+func resourceDefault(ctx context.Context, a resourceschema.Attribute) cty.Value {
+	switch a := a.(type) {
+	case resourceschema.StringAttribute:
+		if a.Default != nil {
+			var resp defaults.StringResponse
+			a.Default.DefaultString(ctx, defaults.StringRequest{}, &resp)
+			return cty.StringVal(resp.PlanValue.ValueString())
+		}
+	case resourceschema.Int64Attribute:
+		if a.Default != nil {
+			var resp defaults.Int64Response
+			a.Default.DefaultInt64(ctx, defaults.Int64Request{}, &resp)
+			return cty.NumberVal(new(big.Float).SetInt64(resp.PlanValue.ValueInt64()))
+		}
+	case resourceschema.BoolAttribute:
+		if a.Default != nil {
+			var resp defaults.BoolResponse
+			a.Default.DefaultBool(ctx, defaults.BoolRequest{}, &resp)
+			return cty.BoolVal(resp.PlanValue.ValueBool())
+		}
+	}
+	return cty.NullVal(cty.DynamicPseudoType)
+}
+
+// mockValueMatches reports whether a mock value fits an attribute type.
+// Collections are only checked for their kind, since HCL literals such as
+// {} and [] carry no element type.
+func mockValueMatches(typ attr.Type, value cty.Value) bool {
+	valueType := value.Type()
+
+	switch typ.(type) {
+	case basetypes.StringType, DomainType:
+		return valueType == cty.String
+	case basetypes.Int64Type, basetypes.Float64Type, basetypes.NumberType:
+		return valueType == cty.Number
+	case basetypes.BoolType:
+		return valueType == cty.Bool
+	case basetypes.ListType, basetypes.SetType:
+		return valueType.IsTupleType() || valueType.IsListType() || valueType.IsSetType()
+	}
+	// Maps, objects and custom types such as record data
+	return valueType.IsObjectType() || valueType.IsMapType()
+}
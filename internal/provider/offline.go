@@ -0,0 +1,17 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// addOfflineError reports that an operation needing the API was attempted
+// while the provider is in offline mode
+func addOfflineError(diags *diag.Diagnostics, operation string) {
+	diags.AddError(
+		"Provider is in offline mode",
+		fmt.Sprintf("Cannot %s: the provider is configured with offline = true (or SNITCHDNS_OFFLINE), "+
+			"which only allows planning against the existing state. Disable offline mode to apply changes.", operation),
+	)
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccProviderOfflineMode tests that offline mode plans against state and refuses to apply
+func TestAccProviderOfflineMode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOfflineConfig(container, false, true),
+			},
+			{
+				// Unchanged configuration plans cleanly without refreshing
+				Config:   testAccOfflineConfig(container, true, true),
+				PlanOnly: true,
+			},
+			{
+				Config:      testAccOfflineConfig(container, true, false),
+				ExpectError: regexp.MustCompile(`Provider is in offline mode`),
+			},
+		},
+	})
+}
+
+// TestDataSourcesOffline tests that every data source reading from SnitchDNS
+// fails an offline plan with the offline error instead of returning values
+// that do not reflect the server. Data sources that do not use the API work
+// offline.
+func TestDataSourcesOffline(t *testing.T) {
+	ctx := context.Background()
+
+	// Data sources that never contact the SnitchDNS API
+	worksOffline := map[string]bool{
+		"snitchdns_zone_transfer": true,
+	}
+
+	p := &SnitchDNSProvider{}
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+
+		var metadataResp datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "snitchdns"}, &metadataResp)
+		if worksOffline[metadataResp.TypeName] {
+			continue
+		}
+
+		t.Run(metadataResp.TypeName, func(t *testing.T) {
+			var configureResp datasource.ConfigureResponse
+			d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: &ProviderData{Offline: true}}, &configureResp)
+			if configureResp.Diagnostics.HasError() {
+				t.Fatalf("Configure failed: %v", configureResp.Diagnostics)
+			}
+
+			var schemaResp datasource.SchemaResponse
+			d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			// A configuration setting no attribute
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+			d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Provider is in offline mode" {
+				t.Errorf("Expected the offline error, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// testAccOfflineConfig generates HCL configuration for offline mode testing
+func testAccOfflineConfig(container *testcontainer.SnitchDNSContainer, offline bool, active bool) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+  offline = %[3]t
+}
+
+resource "snitchdns_zone" "test" {
+  domain = "offline.example.com"
+  active = %[4]t
+  regex  = false
+}
+`, container.GetAPIEndpoint(), container.APIKey, offline, active)
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// featureNames are the names of optional features in diagnostics
+var featureNames = map[snitchdns.Feature]string{
+	snitchdns.FeatureQueryLogs:     "query log",
+	snitchdns.FeatureNotifications: "notifications",
+}
+
+// featureAvailable reports whether the server serves an optional feature a
+// data source reads. Stripped-down SnitchDNS builds answer the endpoints of
+// missing features with 404 Not Found; the data source then returns empty
+// results with a warning, or fails when the provider sets strict_mode. A
+// failed probe is added as an error, so callers check diags when false is
+// returned.
+func featureAvailable(ctx context.Context, client snitchdns.ClientInterface, feature snitchdns.Feature, strict bool, diags *diag.Diagnostics, operation string) bool {
+	available, err := client.HasFeature(ctx, feature)
+	if err != nil {
+		addAPIError(diags, "Error detecting server features",
+			fmt.Sprintf("Could not detect whether the server serves the %s API", featureNames[feature]), err)
+		return false
+	}
+	if available {
+		return true
+	}
+
+	detail := fmt.Sprintf("Cannot %s: the SnitchDNS server does not serve the %s API, as with builds without the feature.",
+		operation, featureNames[feature])
+	if strict {
+		diags.AddError("SnitchDNS feature not available", detail+" Unset strict_mode on the provider to return empty results instead.")
+	} else {
+		diags.AddWarning("SnitchDNS feature not available", detail+" Returning empty results; set strict_mode = true on the provider to fail instead.")
+	}
+	return false
+}
@@ -3,21 +3,44 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
-	"snitchdns-tf/internal/client"
-	"snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/internal/dnsprobe"
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure SnitchDNSProvider satisfies various provider interfaces.
 var _ provider.Provider = &SnitchDNSProvider{}
+var _ provider.ProviderWithFunctions = &SnitchDNSProvider{}
+var _ provider.ProviderWithActions = &SnitchDNSProvider{}
+var _ provider.ProviderWithEphemeralResources = &SnitchDNSProvider{}
+var _ provider.ProviderWithValidateConfig = &SnitchDNSProvider{}
 
 // SnitchDNSProvider defines the provider implementation.
 type SnitchDNSProvider struct {
@@ -25,12 +48,134 @@ type SnitchDNSProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance testing.
 	version   string
 	container *testcontainer.SnitchDNSContainer
+
+	// probeMu guards the status probe, which runs once per provider
+	// instance; serverVersion is the version it found
+	probeMu       sync.Mutex
+	probed        bool
+	serverVersion string
 }
 
 // SnitchDNSProviderModel describes the provider data model.
 type SnitchDNSProviderModel struct {
-	APIUrl string `tfsdk:"api_url"`
-	APIKey string `tfsdk:"api_key"`
+	APIUrl               string       `tfsdk:"api_url"`
+	APIKey               string       `tfsdk:"api_key"`
+	APIKeyFile           types.String `tfsdk:"api_key_file"`
+	APIKeyCommand        types.List   `tfsdk:"api_key_command"`
+	AuthMethod           types.String `tfsdk:"auth_method"`
+	Username             types.String `tfsdk:"username"`
+	Password             types.String `tfsdk:"password"`
+	SkipUnchangedRefresh types.Bool   `tfsdk:"skip_unchanged_refresh"`
+	SkipReadAfterWrite   types.Bool   `tfsdk:"skip_read_after_write"`
+	APIPath              types.String `tfsdk:"api_path"`
+	APIPathRewrites      types.Map    `tfsdk:"api_path_rewrites"`
+	ExtraHeaders         types.Map    `tfsdk:"extra_headers"`
+	ExtraQueryParams     types.Map    `tfsdk:"extra_query_params"`
+	Offline              types.Bool   `tfsdk:"offline"`
+	StrictMode           types.Bool   `tfsdk:"strict_mode"`
+	DNSCheckAddress      types.String `tfsdk:"dns_check_address"`
+	DefaultUserID        types.Int64  `tfsdk:"default_user_id"`
+	AllowInPlaceRename   types.Bool   `tfsdk:"allow_in_place_rename"`
+	DefaultRecordTTL     types.Int64  `tfsdk:"default_record_ttl"`
+	SchemaValidation     types.Bool   `tfsdk:"schema_validation"`
+	MaxRetries           types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin         types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax         types.String `tfsdk:"retry_wait_max"`
+	RetryMaxElapsed      types.String `tfsdk:"retry_max_elapsed"`
+	RetryBackoff         types.String `tfsdk:"retry_backoff"`
+	RequestTimeout       types.String `tfsdk:"request_timeout"`
+
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+
+	CircuitBreakerThreshold types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown  types.String `tfsdk:"circuit_breaker_cooldown"`
+
+	ResponseCacheTTL types.String `tfsdk:"response_cache_ttl"`
+
+	RetryableStatusCodes    types.Set  `tfsdk:"retryable_status_codes"`
+	RetryOnConnectionErrors types.Bool `tfsdk:"retry_on_connection_errors"`
+	IdempotentRetries       types.Bool `tfsdk:"idempotent_retries"`
+
+	EnableTracing types.Bool `tfsdk:"enable_tracing"`
+	DebugHTTP     types.Bool `tfsdk:"debug_http"`
+
+	LogSensitiveKeys types.List `tfsdk:"log_sensitive_keys"`
+
+	CACertPEM             types.String `tfsdk:"ca_cert_pem"`
+	CACertFile            types.String `tfsdk:"ca_cert_file"`
+	ClientCertPEM         types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM          types.String `tfsdk:"client_key_pem"`
+	TLSInsecureSkipVerify types.Bool   `tfsdk:"tls_insecure_skip_verify"`
+	ProxyURL              types.String `tfsdk:"proxy_url"`
+}
+
+// Values of the auth_method attribute
+const (
+	authMethodAPIKey  = "api_key"
+	authMethodSession = "session"
+)
+
+// Client defaults applied when the corresponding provider attribute is unset
+const (
+	defaultMaxRetries     = 3
+	defaultRetryWaitMin   = 1 * time.Second
+	defaultRetryWaitMax   = 30 * time.Second
+	defaultRequestTimeout = 30 * time.Second
+
+	defaultCircuitBreakerThreshold = 5
+	defaultCircuitBreakerCooldown  = 30 * time.Second
+)
+
+// ProviderData is handed to every resource and data source by Configure.
+type ProviderData struct {
+	Client  snitchdns.ClientInterface
+	Records *RecordCache
+	Zones   *ZoneResolver
+	Users   *UserResolver
+
+	// Quota counts the zones plans create against the zone limit of the
+	// API key's user; nil in offline mode
+	Quota *ZoneQuota
+
+	// SkipUnchangedRefresh keeps the prior state during refresh when the
+	// server's updated_at timestamp matches the one in state
+	SkipUnchangedRefresh bool
+
+	// SkipReadAfterWrite keeps the write's response in state instead of
+	// reading zones and records back after create and update
+	SkipReadAfterWrite bool
+
+	// RecordData translates record data field names for the detected
+	// server version; nil when no translation is needed
+	RecordData *recordDataMapper
+
+	// Validator checks planned record data against the API schema; nil
+	// when schema validation is disabled
+	Validator *requestValidator
+
+	// Offline makes reads return the prior state without calling the API
+	// and rejects every change
+	Offline bool
+
+	// DNSServer is the address DNS queries are sent to by default:
+	// dns_check_address, or the host of api_url on port 53
+	DNSServer string
+
+	// DefaultUserID is the user zones are created for when they set neither
+	// owner nor user_id; 0 creates them for the API key's user
+	DefaultUserID int
+
+	// AllowInPlaceRename updates the domain of zones in place by default
+	// instead of replacing them
+	AllowInPlaceRename bool
+
+	// DefaultRecordTTL is the TTL of records omitting ttl; 0 stands for
+	// defaultRecordTTL
+	DefaultRecordTTL int64
+
+	// StrictMode fails data sources reading optional features the server
+	// does not serve, instead of returning empty results with a warning
+	StrictMode bool
 }
 
 // Metadata sets the provider type name and version.
@@ -49,7 +194,239 @@ func (p *SnitchDNSProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Optional:            true,
 			},
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "SnitchDNS API Key for authentication. Can also be set via SNITCHDNS_API_KEY environment variable.",
+				MarkdownDescription: "SnitchDNS API Key for authentication. Can also be set via SNITCHDNS_API_KEY environment variable. Conflicts with `api_key_file` and `api_key_command`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"api_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file holding the API key, read when the provider is configured. Surrounding whitespace is ignored. Conflicts with `api_key` and `api_key_command`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key"), path.MatchRoot("api_key_command")),
+				},
+			},
+			"api_key_command": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Credential helper printing the API key, as the program and its arguments, e.g. `[\"vault\", \"kv\", \"get\", \"-field=key\", \"secret/snitchdns\"]`. It runs without a shell when the provider is configured and must finish within 30 seconds; its output, without surrounding whitespace, is the key. Conflicts with `api_key` and `api_key_file`.",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(path.MatchRoot("api_key")),
+				},
+			},
+			"auth_method": schema.StringAttribute{
+				MarkdownDescription: "How the provider authenticates: `api_key`, or `session` to log in with `username` and `password` through the web login form, for servers with the API key feature disabled. Defaults to `api_key`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(authMethodAPIKey, authMethodSession),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username to log in with when `auth_method` is `session`. Can also be set via SNITCHDNS_USERNAME environment variable.",
+				Optional:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password to log in with when `auth_method` is `session`. Can also be set via SNITCHDNS_PASSWORD environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"skip_unchanged_refresh": schema.BoolAttribute{
+				MarkdownDescription: "Refresh zones with a conditional request, sending the `updated_at` timestamp in state as `If-Modified-Since`, and keep the state of zones that have not changed, including their activity and owner. Speeds up refresh of large states. Defaults to `false`.",
+				Optional:            true,
+			},
+			"skip_read_after_write": schema.BoolAttribute{
+				MarkdownDescription: "Keep the response of a create or update in state instead of reading the zone or record back from the server. The read back captures the server's normalization and timestamps, which the response does not always reflect; skipping it saves a request per write. Defaults to `false`.",
+				Optional:            true,
+			},
+			"api_path": schema.StringAttribute{
+				MarkdownDescription: "Path below `api_url` where the API is served, e.g. `/api/v1` or `/api` for forks that drop the version. When unset and `api_url` does not already end in `/api/v1` or `/api`, the path is detected by probing the server.",
+				Optional:            true,
+			},
+			"api_path_rewrites": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Route prefix rewrites for deployments whose routes differ from upstream SnitchDNS. Keys are upstream path prefixes such as `/records/types`, values the prefixes the server uses instead.",
+				Optional:            true,
+			},
+			"extra_headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Headers sent with every API request, e.g. a token required by an authenticating proxy in front of SnitchDNS. They cannot replace `X-SnitchDNS-Auth`. The values are redacted from errors and debug logs.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$"), "must be a valid HTTP header name"),
+						stringvalidator.NoneOfCaseInsensitive("X-SnitchDNS-Auth"),
+					),
+				},
+			},
+			"extra_query_params": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Query parameters added to every API request, e.g. a tenant selector of a proxy in front of SnitchDNS. Parameters the provider sets itself, such as the page of a listing, take precedence.",
+				Optional:            true,
+			},
+			"dns_check_address": schema.StringAttribute{
+				MarkdownDescription: "Address of the SnitchDNS DNS daemon (host with optional port, default `53`). When set, the provider sends a test query at configuration and warns if the daemon does not answer, since the API can be up while the daemon is down. Also the default server of `snitchdns_dns_lookup`.",
+				Optional:            true,
+			},
+			"default_user_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the user `snitchdns_zone` creates zones for when they set neither `owner` nor `user_id`. Requires an admin API key. Defaults to the user owning the API key.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"default_record_ttl": schema.Int64Attribute{
+				MarkdownDescription: "TTL in seconds of `snitchdns_record`, `snitchdns_wildcard_record` and `snitchdns_ptr_record` resources, and of `snitchdns_record_set` records, that omit `ttl`. Defaults to `300`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					recordTTLValidator(),
+				},
+			},
+			"allow_in_place_rename": schema.BoolAttribute{
+				MarkdownDescription: "Update the zone in place when the `domain` of a `snitchdns_zone` changes, instead of replacing it. Queries logged against the old domain stay with the renamed zone. Zones can override this with their own `allow_in_place_rename`. Defaults to `false`.",
+				Optional:            true,
+			},
+			"offline": schema.BoolAttribute{
+				MarkdownDescription: "Plan against the existing state without contacting the server: refreshes keep the state as-is, data sources reading from SnitchDNS fail the plan, and any apply fails. `api_url` and `api_key` are not required. Can also be set via SNITCHDNS_OFFLINE environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
+			"strict_mode": schema.BoolAttribute{
+				MarkdownDescription: "Fail data sources reading optional SnitchDNS features, the query log and notifications, when the server does not serve them. Stripped-down SnitchDNS builds answer their endpoints with 404 Not Found; by default, `snitchdns_query_log` and `snitchdns_notification_providers` then return empty results with a warning. Defaults to `false`.",
+				Optional:            true,
+			},
+			"schema_validation": schema.BoolAttribute{
+				MarkdownDescription: "Check request bodies against the SnitchDNS API description embedded in the provider before sending them, taking the detected server version into account. Set to `false` for servers that accept requests the description does not. Defaults to `true`.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a request is retried after a connection error, a rate limit or a server error. `0` disables retries. Can also be set via SNITCHDNS_MAX_RETRIES environment variable. Defaults to `3`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "Shortest wait before a retry, as a duration such as `500ms` or `2s`. With the default `retry_backoff`, the wait doubles with every retry, with some jitter, up to `retry_wait_max`. A `Retry-After` header of a rate limited or unavailable response takes precedence. Defaults to `1s`.",
+				Optional:            true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "Longest wait before a retry, as a duration such as `1m`. Must not be shorter than `retry_wait_min`. A request whose `Retry-After` header asks for a longer wait fails right away instead of waiting. Defaults to `30s`.",
+				Optional:            true,
+			},
+			"retry_backoff": schema.StringAttribute{
+				MarkdownDescription: "How the wait between `retry_wait_min` and `retry_wait_max` grows with retries: " +
+					"`exponential` doubles it with every retry, varied by 25%; `full_jitter` waits a random time up to the exponential wait; " +
+					"`constant` always waits `retry_wait_min`; `decorrelated_jitter` waits a random time up to three times the previous wait. " +
+					"Jitter keeps many clients failing together from retrying together. Defaults to `exponential`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(snitchdns.BackoffExponential, snitchdns.BackoffFullJitter, snitchdns.BackoffConstant, snitchdns.BackoffDecorrelatedJitter),
+				},
+			},
+			"retry_max_elapsed": schema.StringAttribute{
+				MarkdownDescription: "Longest time a request is retried for, including the waits between retries, as a duration such as `2m`. " +
+					"Retries that would start later are not made, and the request fails with the outcome of every attempt. " +
+					"Unbounded when unset, leaving `max_retries` and the resource timeouts.",
+				Optional: true,
+			},
+			"retryable_status_codes": schema.SetAttribute{
+				MarkdownDescription: "HTTP statuses of API responses that are retried, replacing the default of `429` and every `5xx` status. An empty set only retries connection errors.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueInt64sAre(int64validator.Between(400, 599)),
+				},
+			},
+			"retry_on_connection_errors": schema.BoolAttribute{
+				MarkdownDescription: "Retry requests that got no response, such as after a connection reset or an unexpected EOF. Certificate errors and unknown host names are never retried. Defaults to `true`.",
+				Optional:            true,
+			},
+			"idempotent_retries": schema.BoolAttribute{
+				MarkdownDescription: "Retry requests changing the server, such as creating a zone, on the same failures as reads. " +
+					"By default they are only retried after a `429` response or when no connection could be made, since a change that timed out may have been applied and could be applied twice. " +
+					"Zone creations are safe either way: a retry finding the domain taken adopts the zone created by an earlier attempt. Defaults to `false`.",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests in flight at the same time, shared by all resources and data sources of this provider configuration. " +
+					"Requests over the limit wait for a free slot. Unlimited when unset.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				MarkdownDescription: "Number of consecutive request attempts failing with a `5xx` status or without a response after which the provider stops sending requests for `circuit_breaker_cooldown`. " +
+					"Operations then fail at once with a diagnostic instead of each retrying against a server that is down. `0` disables the circuit breaker. Defaults to `5`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				MarkdownDescription: "How long requests are not sent once the circuit breaker opened, as a duration such as `1m`. " +
+					"Afterwards a single request probes the server; the circuit closes when it succeeds and opens again when it fails. Defaults to `30s`.",
+				Optional: true,
+			},
+			"response_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "Cache the responses of API reads, as a duration such as `10s`, to cut the time a plan spends refreshing many resources. " +
+					"Responses with an `ETag` or `Last-Modified` header are revalidated with a conditional request on every read; others are reused for this long without asking the server. " +
+					"Any change made by the provider empties the cache. Disabled when unset.",
+				Optional: true,
+			},
+			"enable_tracing": schema.BoolAttribute{
+				MarkdownDescription: "Record an OpenTelemetry span for every API call, with its method, path, response status and retry count. " +
+					"Spans are exported over OTLP/HTTP to the endpoint in the `OTEL_EXPORTER_OTLP_ENDPOINT` or `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT` environment variable; " +
+					"the other standard `OTEL_EXPORTER_OTLP_*` variables, such as the headers, are honored. Defaults to `false`.",
+				Optional: true,
+			},
+			"debug_http": schema.BoolAttribute{
+				MarkdownDescription: "Log every HTTP request attempt to the API at the `DEBUG` level, with its method, URL, headers, body, attempt number and the response. " +
+					"Credentials and values of sensitive fields are redacted. Logs are shown with `TF_LOG=DEBUG` or `TF_LOG_PROVIDER=DEBUG`. Defaults to `false`.",
+				Optional: true,
+			},
+			"log_sensitive_keys": schema.ListAttribute{
+				MarkdownDescription: "Names of JSON fields, headers and query parameters whose values are redacted from the provider's logs and error messages, " +
+					"in addition to the `X-SnitchDNS-Auth` header and the built-in list of fields such as `password`, `token` and `webhook_url`. Names ignore case.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout of a single HTTP request including reading the response, as a duration such as `2m`. Retries get a fresh timeout. Can also be set via SNITCHDNS_TIMEOUT environment variable. Defaults to `30s`.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates trusted for the API in addition to the system roots, for servers with a certificate from an internal CA. Conflicts with `ca_cert_file`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a PEM file with CA certificates trusted for the API in addition to the system roots. Can also be set via SNITCHDNS_CA_CERT_FILE environment variable. Conflicts with `ca_cert_pem`.",
+				Optional:            true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate presented to servers that require mutual TLS. Requires `client_key_pem`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_pem")),
+				},
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of `client_cert_pem`.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_pem")),
+				},
+			},
+			"tls_insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the API's TLS certificate. Only meant for testing; prefer `ca_cert_pem` or `ca_cert_file`. Can also be set via SNITCHDNS_TLS_SKIP_VERIFY environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy requests to the API go through, with the `http`, `https`, `socks5` or `socks5h` scheme and optional credentials, e.g. `http://proxy.example.com:3128`. Hosts in the `NO_PROXY` environment variable are reached directly. When unset, the `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables are used.",
 				Optional:            true,
 				Sensitive:           true,
 			},
@@ -78,6 +455,46 @@ func (p *SnitchDNSProvider) Configure(ctx context.Context, req provider.Configur
 		apiKey = os.Getenv("SNITCHDNS_API_KEY")
 	}
 
+	offline := data.Offline.ValueBool()
+	if data.Offline.IsNull() {
+		offline, _ = strconv.ParseBool(os.Getenv("SNITCHDNS_OFFLINE"))
+	}
+
+	if offline {
+		tflog.Info(ctx, "SnitchDNS provider is in offline mode, state will not be refreshed and changes cannot be applied")
+
+		// The client is never used, but keeps resources from handling a nil
+		// client should an offline check be missed
+		client := snitchdns.NewClient(apiURL, apiKey)
+		providerData := &ProviderData{
+			Client:  client,
+			Records: NewRecordCache(client),
+			Zones:   NewZoneResolver(client),
+			Users:   NewUserResolver(client),
+			Offline: true,
+
+			DNSServer: data.DNSCheckAddress.ValueString(),
+		}
+
+		resp.DataSourceData = providerData
+		resp.ResourceData = providerData
+		resp.ActionData = providerData
+		resp.EphemeralResourceData = providerData
+		return
+	}
+
+	// A key from the configuration takes precedence over the environment
+	if data.APIKey == "" {
+		key, diags := apiKeyFromSource(ctx, data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if key != "" {
+			apiKey = key
+		}
+	}
+
 	// Validate required configuration
 	if apiURL == "" {
 		resp.Diagnostics.AddAttributeError(
@@ -89,29 +506,431 @@ func (p *SnitchDNSProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
-	if apiKey == "" {
+	session := data.AuthMethod.ValueString() == authMethodSession
+	username, password := data.Username.ValueString(), data.Password.ValueString()
+	if username == "" {
+		username = os.Getenv("SNITCHDNS_USERNAME")
+	}
+	if password == "" {
+		password = os.Getenv("SNITCHDNS_PASSWORD")
+	}
+
+	if session && (username == "" || password == "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_method"),
+			"Missing Login Credentials",
+			"The provider cannot log in to SnitchDNS as the username or password is missing. "+
+				"Set the username and password values in the provider configuration or use the SNITCHDNS_USERNAME and SNITCHDNS_PASSWORD environment variables.",
+		)
+	}
+
+	if apiKey == "" && !session {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
 			"Missing API Key",
 			"The provider cannot create the SnitchDNS API client as there is a missing or empty value for the API key. "+
-				"Set the api_key value in the provider configuration or use the SNITCHDNS_API_KEY environment variable. "+
+				"Set the api_key, api_key_file or api_key_command value in the provider configuration or use the SNITCHDNS_API_KEY environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
+	// api_url itself was checked by ValidateConfig
+	if data.APIUrl == "" && apiURL != "" {
+		resp.Diagnostics.Append(validateAPIURL(apiURL, "SNITCHDNS_API_URL")...)
+	}
+	apiURL = normalizeAPIURL(apiURL)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Never let the API key or password reach the logs, even if echoed back
+	for _, secret := range []string{apiKey, password} {
+		if secret != "" {
+			ctx = tflog.MaskAllFieldValuesStrings(ctx, secret)
+			ctx = tflog.MaskMessageStrings(ctx, secret)
+		}
+	}
+
 	tflog.Debug(ctx, "Configuring SnitchDNS client", map[string]any{
 		"api_url": apiURL,
+		"backend": snitchdns.BackendName,
+	})
+
+	var rewrites map[string]string
+	if !data.APIPathRewrites.IsNull() {
+		resp.Diagnostics.Append(data.APIPathRewrites.ElementsAs(ctx, &rewrites, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var extraHeaders, extraQueryParams map[string]string
+	if !data.ExtraHeaders.IsNull() {
+		resp.Diagnostics.Append(data.ExtraHeaders.ElementsAs(ctx, &extraHeaders, false)...)
+	}
+	if !data.ExtraQueryParams.IsNull() {
+		resp.Diagnostics.Append(data.ExtraQueryParams.ElementsAs(ctx, &extraQueryParams, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var sensitiveKeys []string
+	if !data.LogSensitiveKeys.IsNull() {
+		resp.Diagnostics.Append(data.LogSensitiveKeys.ElementsAs(ctx, &sensitiveKeys, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveKeys...)
+	}
+	for _, value := range extraHeaders {
+		if value != "" {
+			ctx = tflog.MaskAllFieldValuesStrings(ctx, value)
+			ctx = tflog.MaskMessageStrings(ctx, value)
+		}
+	}
+
+	resp.Diagnostics.Append(applyEnvDefaults(&data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	transportOpts, diags := clientTransportOptions(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clientOpts := []snitchdns.Option{
+		snitchdns.WithUserAgent("terraform-provider-snitchdns/" + p.version),
+		snitchdns.WithRequestHook(logAPIRequest),
+		snitchdns.WithAttemptHook(logAPIAttempt),
+		snitchdns.WithSensitiveKeys(sensitiveKeys...),
+		snitchdns.WithMetrics(apiMetrics),
+		snitchdns.WithDebugLogging(data.DebugHTTP.ValueBool()),
+		snitchdns.WithLogger(snitchdns.LoggerFunc(logHTTPDebug)),
+		snitchdns.WithPathRewrites(rewrites),
+		snitchdns.WithExtraHeaders(extraHeaders),
+		snitchdns.WithExtraQueryParams(extraQueryParams),
+	}
+	clientOpts = append(clientOpts, transportOpts...)
+
+	tlsConfig, diags := clientTLSConfig(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if tlsConfig != nil {
+		clientOpts = append(clientOpts, snitchdns.WithTLSConfig(tlsConfig))
+	}
+
+	proxyURL, diags := clientProxyURL(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clientOpts = append(clientOpts, snitchdns.WithProxy(proxyURL))
+	if session {
+		clientOpts = append(clientOpts, snitchdns.WithSessionAuth(username, password))
+	}
+
+	if data.EnableTracing.ValueBool() {
+		resp.Diagnostics.Append(p.tracingOptions(ctx, &clientOpts)...)
+	}
+
+	validator := newRequestValidator()
+	schemaValidation := data.SchemaValidation.IsNull() || data.SchemaValidation.ValueBool()
+	if schemaValidation {
+		clientOpts = append(clientOpts, snitchdns.WithRequestValidator(validator.Validate))
+	}
+
+	apiURL = resolveAPIURL(ctx, apiURL, apiKey, data.APIPath, clientOpts)
+
+	// Create API client, using the backend selected at build time
+	client := snitchdns.NewBackend(snitchdns.NewClient(apiURL, apiKey, clientOpts...))
+
+	// Fail here rather than on the first API call deep into an apply
+	serverVersion, err := p.probeServer(ctx, client, resp)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Cannot Reach SnitchDNS", fmt.Sprintf("Cannot reach SnitchDNS at %s", apiURL), err)
+		return
+	}
+	if detail := unsupportedServerVersionError(serverVersion); detail != "" {
+		resp.Diagnostics.AddError("Unsupported SnitchDNS Server Version", detail)
+		return
+	}
+	recordData := newRecordDataMapper(serverVersion)
+
+	// The validator is complete before the client is handed out
+	validator.version = serverVersion
+	validator.recordData = recordData
+
+	if address := data.DNSCheckAddress.ValueString(); address != "" {
+		warnOnUnreachableDNSDaemon(ctx, address, resp)
+	}
+
+	providerData := &ProviderData{
+		Client:  client,
+		Records: NewRecordCache(client),
+		Zones:   NewZoneResolver(client),
+		Users:   NewUserResolver(client),
+		Quota:   NewZoneQuota(client),
+
+		SkipUnchangedRefresh: data.SkipUnchangedRefresh.ValueBool(),
+		SkipReadAfterWrite:   data.SkipReadAfterWrite.ValueBool(),
+		RecordData:           recordData,
+		DNSServer:            dnsServerAddress(data.DNSCheckAddress.ValueString(), apiURL),
+		DefaultUserID:        int(data.DefaultUserID.ValueInt64()),
+		AllowInPlaceRename:   data.AllowInPlaceRename.ValueBool(),
+		DefaultRecordTTL:     data.DefaultRecordTTL.ValueInt64(),
+		StrictMode:           data.StrictMode.ValueBool(),
+	}
+	if schemaValidation {
+		providerData.Validator = validator
+	}
+
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.ActionData = providerData
+	resp.EphemeralResourceData = providerData
+}
+
+// tracingOptions adds the client option recording spans when an OTLP
+// endpoint is configured, and warns otherwise. Tracing never fails the
+// configuration.
+func (p *SnitchDNSProvider) tracingOptions(ctx context.Context, opts *[]snitchdns.Option) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !otlpEndpointConfigured() {
+		diags.AddAttributeWarning(
+			path.Root("enable_tracing"),
+			"Tracing Not Exported",
+			"enable_tracing is set, but neither OTEL_EXPORTER_OTLP_ENDPOINT nor OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is. No spans are recorded.",
+		)
+		return diags
+	}
+
+	tp, err := tracerProvider(ctx, p.version)
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("enable_tracing"),
+			"Tracing Not Exported",
+			fmt.Sprintf("Could not create the OTLP exporter: %s. No spans are recorded.", err),
+		)
+		return diags
+	}
+
+	*opts = append(*opts, snitchdns.WithTracerProvider(tp))
+	return diags
+}
+
+// clientTransportOptions returns the client options for the retry, timeout,
+// concurrency and caching attributes, using the client defaults for unset attributes
+func clientTransportOptions(data SnitchDNSProviderModel) ([]snitchdns.Option, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	maxRetries := int64(defaultMaxRetries)
+	if !data.MaxRetries.IsNull() {
+		maxRetries = data.MaxRetries.ValueInt64()
+	}
+
+	waitMin := parseProviderDuration(data.RetryWaitMin, "retry_wait_min", defaultRetryWaitMin, &diags)
+	waitMax := parseProviderDuration(data.RetryWaitMax, "retry_wait_max", defaultRetryWaitMax, &diags)
+	maxElapsed := parseProviderDuration(data.RetryMaxElapsed, "retry_max_elapsed", 0, &diags)
+	timeout := parseProviderDuration(data.RequestTimeout, "request_timeout", defaultRequestTimeout, &diags)
+	cooldown := parseProviderDuration(data.CircuitBreakerCooldown, "circuit_breaker_cooldown", defaultCircuitBreakerCooldown, &diags)
+	cacheTTL := parseProviderDuration(data.ResponseCacheTTL, "response_cache_ttl", 0, &diags)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if waitMin > waitMax {
+		diags.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid Retry Wait",
+			fmt.Sprintf("retry_wait_min (%s) must not be longer than retry_wait_max (%s).", waitMin, waitMax),
+		)
+		return nil, diags
+	}
+
+	backoff := snitchdns.Backoff(snitchdns.ExponentialBackoff{})
+	if !data.RetryBackoff.IsNull() && !data.RetryBackoff.IsUnknown() {
+		var err error
+		if backoff, err = snitchdns.ParseBackoff(data.RetryBackoff.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root("retry_backoff"), "Invalid Retry Backoff", err.Error())
+			return nil, diags
+		}
+	}
+
+	var statusCodes []int
+	if !data.RetryableStatusCodes.IsNull() && !data.RetryableStatusCodes.IsUnknown() {
+		statusCodes = []int{}
+		for _, element := range data.RetryableStatusCodes.Elements() {
+			if code, ok := element.(types.Int64); ok && !code.IsNull() && !code.IsUnknown() {
+				statusCodes = append(statusCodes, int(code.ValueInt64()))
+			}
+		}
+	}
+
+	threshold := int64(defaultCircuitBreakerThreshold)
+	if !data.CircuitBreakerThreshold.IsNull() && !data.CircuitBreakerThreshold.IsUnknown() {
+		threshold = data.CircuitBreakerThreshold.ValueInt64()
+	}
+
+	retryConnectionErrors := true
+	if !data.RetryOnConnectionErrors.IsNull() && !data.RetryOnConnectionErrors.IsUnknown() {
+		retryConnectionErrors = data.RetryOnConnectionErrors.ValueBool()
+	}
+
+	return []snitchdns.Option{
+		snitchdns.WithRetry(int(maxRetries), waitMin, waitMax),
+		snitchdns.WithBackoff(backoff),
+		snitchdns.WithMaxElapsedTime(maxElapsed),
+		snitchdns.WithRetryPolicy(statusCodes, retryConnectionErrors),
+		snitchdns.WithIdempotentRetries(data.IdempotentRetries.ValueBool()),
+		snitchdns.WithTimeout(timeout),
+		snitchdns.WithMaxConcurrentRequests(int(data.MaxConcurrentRequests.ValueInt64())),
+		snitchdns.WithCircuitBreaker(int(threshold), cooldown),
+		snitchdns.WithCache(cacheTTL),
+	}, diags
+}
+
+// parseProviderDuration parses a positive duration attribute, returning the
+// default when the attribute is unset
+func parseProviderDuration(value types.String, attribute string, defaultValue time.Duration, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration <= 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Duration",
+			fmt.Sprintf("%s must be a positive duration such as 30s, got %q.", attribute, value.ValueString()),
+		)
+		return defaultValue
+	}
+	return duration
+}
+
+// resolveAPIURL returns the URL the API is served at: api_url joined with the
+// configured api_path, or with the detected path when api_url does not
+// already point at the API. Detection failures are logged and api_url is
+// used as given, so errors surface on the first real request.
+func resolveAPIURL(ctx context.Context, apiURL, apiKey string, apiPath types.String, opts []snitchdns.Option) string {
+	base := strings.TrimRight(apiURL, "/")
+
+	if !apiPath.IsNull() && !apiPath.IsUnknown() {
+		configured := strings.Trim(apiPath.ValueString(), "/")
+		if configured == "" {
+			return base
+		}
+		return base + "/" + configured
+	}
+
+	if hasAPIPath(base) {
+		return apiURL
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	detected, err := snitchdns.NewClient(base, apiKey, opts...).DetectAPIPath(probeCtx)
+	if err != nil {
+		tflog.Warn(ctx, "Could not detect SnitchDNS API path, using api_url as given", map[string]any{
+			"api_url": apiURL,
+			"error":   err.Error(),
+		})
+		return apiURL
+	}
+
+	tflog.Debug(ctx, "Detected SnitchDNS API path", map[string]any{
+		"api_path": detected,
+	})
+	return base + detected
+}
+
+// hasAPIPath reports whether the URL already ends in one of the known API
+// paths
+func hasAPIPath(apiURL string) bool {
+	for _, candidate := range snitchdns.APIPathCandidates {
+		if candidate != "" && strings.HasSuffix(apiURL, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// dnsServerAddress returns the address of the SnitchDNS DNS daemon: the
+// configured check address, or the host serving the API, which runs the
+// daemon in the usual single-host deployment
+func dnsServerAddress(checkAddress, apiURL string) string {
+	if checkAddress != "" {
+		return checkAddress
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// warnOnUnreachableDNSDaemon sends a test query to the DNS daemon and adds a
+// warning when it does not answer
+func warnOnUnreachableDNSDaemon(ctx context.Context, address string, resp *provider.ConfigureResponse) {
+	if err := dnsprobe.Probe(ctx, address); err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("dns_check_address"),
+			"SnitchDNS DNS Daemon Unreachable",
+			fmt.Sprintf("The SnitchDNS DNS daemon at %s did not answer a test query: %s. "+
+				"Zones and records can still be managed through the API, but no queries are answered or logged until the daemon is running.", address, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "SnitchDNS DNS daemon answered test query", map[string]any{
+		"address": address,
 	})
+}
+
+// probeServer reads the server status once per provider instance. The
+// single status request both checks that the API answers and accepts the
+// API key, failing otherwise, and reports the server version, for which a
+// warning is added when it is outside the tested range. Servers without the
+// status endpoint answer it with not found; they count as reachable and the
+// returned version is "", which is not warned about.
+func (p *SnitchDNSProvider) probeServer(ctx context.Context, c snitchdns.ClientInterface, resp *provider.ConfigureResponse) (string, error) {
+	p.probeMu.Lock()
+	defer p.probeMu.Unlock()
 
-	// Create API client
-	client := client.NewClient(apiURL, apiKey)
+	if p.probed {
+		return p.serverVersion, nil
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	info, err := c.GetServerInfo(probeCtx)
+	switch {
+	case snitchdns.IsNotFound(err):
+		tflog.Debug(ctx, "SnitchDNS API answered, but does not report its version")
+	case err != nil:
+		return "", err
+	default:
+		tflog.Debug(ctx, "SnitchDNS API answered status request", map[string]any{
+			"version": info.Version,
+		})
+		p.serverVersion = info.Version
+	}
+	p.probed = true
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	if unsupportedServerVersionError(p.serverVersion) == "" {
+		if detail := untestedServerVersionWarning(p.serverVersion); detail != "" {
+			resp.Diagnostics.AddWarning("Untested SnitchDNS Server Version", detail)
+		}
+	}
+	return p.serverVersion, nil
 }
 
 // Resources returns the list of resources supported by this provider.
@@ -119,12 +938,76 @@ func (p *SnitchDNSProvider) Resources(_ context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		NewZoneResource,
 		NewRecordResource,
+		NewZoneCaptureResource,
+		NewUnmatchedQueryLoggingResource,
+		NewNotificationRecipientsResource,
+		NewNotificationResource,
+		NewWildcardRecordResource,
+		NewPTRRecordResource,
+		NewCanaryZoneResource,
+		NewSubzoneResource,
+		NewZoneGroupResource,
+		NewRecordsCSVResource,
+		NewRecordSetResource,
+		NewZoneBatchResource,
+		NewZoneFileResource,
+		NewLogForwardingResource,
+		NewGlobalRestrictionsResource,
+		NewForwardingSettingsResource,
+		NewDNSSettingsResource,
+		NewInterceptionSettingsResource,
+		NewZoneRestrictionResource,
+		NewAPIKeyResource,
+		NewAliasResource,
 	}
 }
 
 // DataSources returns the list of data sources supported by this provider.
 func (p *SnitchDNSProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewImportConfigDataSource,
+		NewZoneTransferDataSource,
+		NewDNSLookupDataSource,
+		NewZoneExportDataSource,
+		NewZoneLookupDataSource,
+		NewZoneMatchDataSource,
+		NewZoneDataSource,
+		NewZonesDataSource,
+		NewRecordDataSource,
+		NewRecordsDataSource,
+		NewWaitForHitDataSource,
+		NewQueryLogDataSource,
+		NewSearchDataSource,
+		NewZoneStatsDataSource,
+		NewNotificationProvidersDataSource,
+		NewServerCapabilitiesDataSource,
+	}
+}
+
+// Functions returns the list of functions supported by this provider.
+func (p *SnitchDNSProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewCanaryLabelFunction,
+		NewReversePTRFunction,
+		NewIDNToPunycodeFunction,
+		NewPunycodeToIDNFunction,
+	}
+}
+
+// Actions returns the list of actions supported by this provider.
+func (p *SnitchDNSProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewClearLogsAction,
+		NewResetConditionalCountAction,
+		NewZonesImportAction,
+	}
+}
+
+// EphemeralResources returns the list of ephemeral resources supported by this provider.
+func (p *SnitchDNSProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAPIKeyEphemeralResource,
+	}
 }
 
 // New creates a new instance of the SnitchDNS provider.
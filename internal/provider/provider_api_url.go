@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ValidateConfig checks api_url before the provider is configured, so a
+// malformed URL is reported with how to fix it instead of as a 404 of the
+// first request. URLs from SNITCHDNS_API_URL are checked by Configure.
+func (p *SnitchDNSProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var apiURL types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("api_url"), &apiURL)...)
+	if resp.Diagnostics.HasError() || apiURL.IsNull() || apiURL.IsUnknown() || apiURL.ValueString() == "" {
+		return
+	}
+
+	resp.Diagnostics.Append(validateAPIURL(apiURL.ValueString(), "api_url")...)
+}
+
+// validateAPIURL checks an API URL taken from source, the attribute or the
+// environment variable it was set with. Trailing slashes are fine, since
+// normalizeAPIURL drops them.
+func validateAPIURL(apiURL, source string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	invalid := func(detail string, args ...any) diag.Diagnostics {
+		diags.AddAttributeError(path.Root("api_url"), "Invalid API URL", fmt.Sprintf(detail, args...))
+		return diags
+	}
+
+	if strings.TrimSpace(apiURL) != apiURL {
+		return invalid("%s %q has leading or trailing whitespace. Remove it.", source, apiURL)
+	}
+	if !strings.Contains(apiURL, "://") {
+		return invalid("%s %q has no scheme. Use the full URL of the server, such as %q.", source, apiURL, "https://"+apiURL)
+	}
+
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return invalid("%s %q is not a valid URL: %s", source, apiURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return invalid("%s %q uses the scheme %q. The SnitchDNS API is served over http or https.", source, apiURL, u.Scheme)
+	}
+	if u.Host == "" {
+		return invalid("%s %q has no host. Use the full URL of the server, such as %q.", source, apiURL, "https://snitch.example.com")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return invalid("%s %q has a query or fragment, which would be dropped from requests. "+
+			"Set query parameters sent with every request with extra_query_params instead.", source, apiURL)
+	}
+
+	if endpoint := apiURLEndpoint(u.Path); endpoint != "" {
+		base := strings.TrimSuffix(normalizeAPIURL(apiURL), endpoint)
+		diags.AddAttributeWarning(path.Root("api_url"), "API URL Names an Endpoint",
+			fmt.Sprintf("%s %q continues past the API path with %q, so requests would be sent to paths such as %s/zones, which SnitchDNS does not serve. "+
+				"Set it to the base URL of the API, %q.", source, apiURL, endpoint, normalizeAPIURL(apiURL), base))
+	}
+	return diags
+}
+
+// apiURLEndpoint returns the part of a URL path after the API path, such as
+// /zones for /api/v1/zones, or "" when the path ends at the API path or does
+// not contain one
+func apiURLEndpoint(urlPath string) string {
+	urlPath = strings.TrimRight(urlPath, "/") + "/"
+
+	// The longest match wins, so /api/v1 is not taken for /api
+	apiPath := ""
+	for _, candidate := range snitchdns.APIPathCandidates {
+		if candidate != "" && len(candidate) > len(apiPath) && strings.Contains(urlPath, candidate+"/") {
+			apiPath = candidate
+		}
+	}
+	if apiPath == "" {
+		return ""
+	}
+
+	rest := urlPath[strings.Index(urlPath, apiPath+"/")+len(apiPath):]
+	return strings.TrimSuffix(rest, "/")
+}
+
+// normalizeAPIURL drops the trailing slashes of an API URL, which would
+// double the slash before every request path
+func normalizeAPIURL(apiURL string) string {
+	return strings.TrimRight(apiURL, "/")
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+)
+
+// TestValidateAPIURL tests the diagnostics for misconfigured API URLs
+func TestValidateAPIURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		apiURL      string
+		wantError   bool
+		wantWarning bool
+	}{
+		{name: "base URL", apiURL: "https://snitch.example.com"},
+		{name: "trailing slash", apiURL: "https://snitch.example.com/api/v1//"},
+		{name: "API path", apiURL: "http://10.0.0.5:8000/snitch/api/v1"},
+		{name: "missing scheme", apiURL: "snitch.example.com/api/v1", wantError: true},
+		{name: "unsupported scheme", apiURL: "ftp://snitch.example.com", wantError: true},
+		{name: "missing host", apiURL: "https:///api/v1", wantError: true},
+		{name: "whitespace", apiURL: "https://snitch.example.com ", wantError: true},
+		{name: "query", apiURL: "https://snitch.example.com/api/v1?key=abc", wantError: true},
+		{name: "endpoint", apiURL: "https://snitch.example.com/api/v1/zones", wantWarning: true},
+		{name: "endpoint without version", apiURL: "https://snitch.example.com/api/zones/", wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateAPIURL(tt.apiURL, "api_url")
+			if diags.HasError() != tt.wantError || (diags.WarningsCount() > 0) != tt.wantWarning {
+				t.Errorf("validateAPIURL(%q) = %v, want error %t and warning %t", tt.apiURL, diags, tt.wantError, tt.wantWarning)
+			}
+		})
+	}
+}
+
+// TestAPIURLEndpoint tests finding the part of a path after the API path
+func TestAPIURLEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"":                    "",
+		"/":                   "",
+		"/api/v1":             "",
+		"/api/v1/":            "",
+		"/snitch/api":         "",
+		"/api/v1/zones":       "/zones",
+		"/api/zones/1":        "/zones/1",
+		"/apis/v1/zones":      "",
+		"/api/v1/records/new": "/records/new",
+	}
+
+	for urlPath, want := range tests {
+		if got := apiURLEndpoint(urlPath); got != want {
+			t.Errorf("apiURLEndpoint(%q) = %q, want %q", urlPath, got, want)
+		}
+	}
+}
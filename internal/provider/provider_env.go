@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Environment variables setting provider attributes that are not configured
+const (
+	envMaxRetries    = "SNITCHDNS_MAX_RETRIES"
+	envTimeout       = "SNITCHDNS_TIMEOUT"
+	envTLSSkipVerify = "SNITCHDNS_TLS_SKIP_VERIFY"
+	envCACertFile    = "SNITCHDNS_CA_CERT_FILE"
+)
+
+// applyEnvDefaults sets the retry and TLS attributes left unset in the
+// configuration from the environment, so CI systems can tune them without
+// editing HCL. A configured attribute always takes precedence over its
+// variable, and ca_cert_pem over SNITCHDNS_CA_CERT_FILE. Empty variables are
+// ignored; malformed ones are errors naming the variable.
+func applyEnvDefaults(data *SnitchDNSProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if value := os.Getenv(envMaxRetries); value != "" && data.MaxRetries.IsNull() {
+		retries, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || retries < 0 {
+			addEnvError(&diags, envMaxRetries, "a number of retries of 0 or more, such as 5", value)
+		} else {
+			data.MaxRetries = types.Int64Value(retries)
+		}
+	}
+
+	if value := os.Getenv(envTimeout); value != "" && data.RequestTimeout.IsNull() {
+		if timeout, err := time.ParseDuration(value); err != nil || timeout <= 0 {
+			addEnvError(&diags, envTimeout, "a positive duration such as 30s", value)
+		} else {
+			data.RequestTimeout = types.StringValue(value)
+		}
+	}
+
+	if value := os.Getenv(envTLSSkipVerify); value != "" && data.TLSInsecureSkipVerify.IsNull() {
+		if skip, err := strconv.ParseBool(value); err != nil {
+			addEnvError(&diags, envTLSSkipVerify, "true or false", value)
+		} else {
+			data.TLSInsecureSkipVerify = types.BoolValue(skip)
+		}
+	}
+
+	if value := os.Getenv(envCACertFile); value != "" && data.CACertFile.IsNull() && data.CACertPEM.IsNull() {
+		data.CACertFile = types.StringValue(value)
+	}
+
+	return diags
+}
+
+// addEnvError adds the error for a malformed environment variable
+func addEnvError(diags *diag.Diagnostics, name, expected, value string) {
+	diags.AddError("Invalid Environment Variable",
+		fmt.Sprintf("%s must be %s, got %q. Fix or unset the variable, or set the provider attribute, which takes precedence.", name, expected, value))
+}
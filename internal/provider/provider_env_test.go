@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestApplyEnvDefaults tests the precedence and validation of the
+// environment variables for retry and TLS attributes
+func TestApplyEnvDefaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		data    SnitchDNSProviderModel
+		want    SnitchDNSProviderModel
+		wantErr bool
+	}{
+		{
+			name: "unset",
+			want: SnitchDNSProviderModel{},
+		},
+		{
+			name: "from environment",
+			env: map[string]string{
+				envMaxRetries:    "5",
+				envTimeout:       "2m",
+				envTLSSkipVerify: "true",
+				envCACertFile:    "/etc/ssl/snitchdns.pem",
+			},
+			want: SnitchDNSProviderModel{
+				MaxRetries:            types.Int64Value(5),
+				RequestTimeout:        types.StringValue("2m"),
+				TLSInsecureSkipVerify: types.BoolValue(true),
+				CACertFile:            types.StringValue("/etc/ssl/snitchdns.pem"),
+			},
+		},
+		{
+			name: "configuration takes precedence",
+			env: map[string]string{
+				envMaxRetries:    "5",
+				envTimeout:       "2m",
+				envTLSSkipVerify: "true",
+				envCACertFile:    "/etc/ssl/snitchdns.pem",
+			},
+			data: SnitchDNSProviderModel{
+				MaxRetries:            types.Int64Value(0),
+				RequestTimeout:        types.StringValue("10s"),
+				TLSInsecureSkipVerify: types.BoolValue(false),
+				CACertPEM:             types.StringValue("-----BEGIN CERTIFICATE-----"),
+			},
+			want: SnitchDNSProviderModel{
+				MaxRetries:            types.Int64Value(0),
+				RequestTimeout:        types.StringValue("10s"),
+				TLSInsecureSkipVerify: types.BoolValue(false),
+				CACertPEM:             types.StringValue("-----BEGIN CERTIFICATE-----"),
+			},
+		},
+		{
+			name:    "negative retries",
+			env:     map[string]string{envMaxRetries: "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "malformed timeout",
+			env:     map[string]string{envTimeout: "30"},
+			wantErr: true,
+		},
+		{
+			name:    "malformed bool",
+			env:     map[string]string{envTLSSkipVerify: "yes"},
+			wantErr: true,
+		},
+		{
+			name: "malformed but configured",
+			env:  map[string]string{envMaxRetries: "many"},
+			data: SnitchDNSProviderModel{MaxRetries: types.Int64Value(2)},
+			want: SnitchDNSProviderModel{MaxRetries: types.Int64Value(2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{envMaxRetries, envTimeout, envTLSSkipVerify, envCACertFile} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			data := tt.data
+			diags := applyEnvDefaults(&data)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("applyEnvDefaults() errors = %v, want error %t", diags, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !data.MaxRetries.Equal(tt.want.MaxRetries) || !data.RequestTimeout.Equal(tt.want.RequestTimeout) ||
+				!data.TLSInsecureSkipVerify.Equal(tt.want.TLSInsecureSkipVerify) ||
+				!data.CACertFile.Equal(tt.want.CACertFile) || !data.CACertPEM.Equal(tt.want.CACertPEM) {
+				t.Errorf("Unexpected attributes: %v, %v, %v, %v, %v", data.MaxRetries, data.RequestTimeout,
+					data.TLSInsecureSkipVerify, data.CACertFile, data.CACertPEM)
+			}
+		})
+	}
+}
@@ -1,9 +1,20 @@
 package provider
 
 import (
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
-	"snitchdns-tf/internal/testcontainer"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -12,6 +23,265 @@ import (
 // reattach.
 func testAccProtoV6ProviderFactories(container *testcontainer.SnitchDNSContainer) map[string]func() (tfprotov6.ProviderServer, error) {
 	return map[string]func() (tfprotov6.ProviderServer, error){
-		"snitchdns": providerserver.NewProtocol6WithError(New("test", container)()),
+		"snitchdns": func() (tfprotov6.ProviderServer, error) {
+			return NewProtocol6Server("test", container)(), nil
+		},
+	}
+}
+
+// testAccSharedContainer returns the container shared by the parallel
+// acceptance tests, released when the test ends and logged when it failed,
+// and a namespace the test keeps its domains below, see
+// testcontainer.NamespacedDomain
+func testAccSharedContainer(t *testing.T) (*testcontainer.SnitchDNSContainer, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := testcontainer.Shared(ctx)
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("Failed to release container: %v", err)
+		}
+	})
+	testcontainer.WithFailureLogs(t, container)
+	return container, testcontainer.Namespace(t)
+}
+
+// testAccVersionMatrix runs an acceptance test against the shared container
+// of every SnitchDNS version of SNITCHDNS_TEST_VERSIONS, or once against
+// the shared container without it, see testcontainer.RunVersions. The test
+// gets a namespace to keep its domains below, as with testAccSharedContainer.
+func testAccVersionMatrix(t *testing.T, test func(t *testing.T, container *testcontainer.SnitchDNSContainer, namespace string)) {
+	t.Helper()
+
+	testcontainer.RunVersions(t, func(t *testing.T, container *testcontainer.SnitchDNSContainer) {
+		test(t, container, testcontainer.Namespace(t))
+	})
+}
+
+// testAccRequireFeature skips an acceptance test for containers of
+// SnitchDNS versions predating a versioned feature, by the capability
+// reporting it
+func testAccRequireFeature(t *testing.T, container *testcontainer.SnitchDNSContainer, capability string) {
+	t.Helper()
+
+	for _, feature := range versionedFeatures {
+		if feature.Capability == capability {
+			testcontainer.RequireVersion(t, container, feature.Name, feature.Since.String())
+			return
+		}
+	}
+	t.Fatalf("Unknown capability %s", capability)
+}
+
+// TestClientTransportOptions tests that the retry and timeout attributes are mapped to client options
+func TestClientTransportOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       SnitchDNSProviderModel
+		wantErr    bool
+		maxRetries int
+		waitMin    time.Duration
+		waitMax    time.Duration
+		timeout    time.Duration
+
+		statusCodes         []int
+		noConnectionRetries bool
+		idempotentRetries   bool
+		maxConcurrent       int
+		maxElapsed          time.Duration
+		breakerThreshold    int
+		breakerCooldown     time.Duration
+		cacheTTL            time.Duration
+		backoff             snitchdns.Backoff
+	}{
+		{
+			name:       "defaults",
+			data:       SnitchDNSProviderModel{},
+			maxRetries: 3, waitMin: time.Second, waitMax: 30 * time.Second, timeout: 30 * time.Second,
+			breakerThreshold: 5, breakerCooldown: 30 * time.Second,
+		},
+		{
+			name: "configured",
+			data: SnitchDNSProviderModel{
+				MaxRetries:     types.Int64Value(0),
+				RetryWaitMin:   types.StringValue("500ms"),
+				RetryWaitMax:   types.StringValue("1m"),
+				RequestTimeout: types.StringValue("2m"),
+			},
+			maxRetries: 0, waitMin: 500 * time.Millisecond, waitMax: time.Minute, timeout: 2 * time.Minute,
+			breakerThreshold: 5, breakerCooldown: 30 * time.Second,
+		},
+		{
+			name: "retry policy",
+			data: SnitchDNSProviderModel{
+				RetryableStatusCodes:    types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(502)}),
+				RetryOnConnectionErrors: types.BoolValue(false),
+			},
+			maxRetries: 3, waitMin: time.Second, waitMax: 30 * time.Second, timeout: 30 * time.Second,
+			breakerThreshold: 5, breakerCooldown: 30 * time.Second,
+			statusCodes: []int{502}, noConnectionRetries: true,
+		},
+		{
+			name:       "idempotent retries",
+			data:       SnitchDNSProviderModel{IdempotentRetries: types.BoolValue(true)},
+			maxRetries: 3, waitMin: time.Second, waitMax: 30 * time.Second, timeout: 30 * time.Second,
+			breakerThreshold: 5, breakerCooldown: 30 * time.Second,
+			idempotentRetries: true,
+		},
+		{
+			name:       "concurrency limit",
+			data:       SnitchDNSProviderModel{MaxConcurrentRequests: types.Int64Value(4)},
+			maxRetries: 3, waitMin: time.Second, waitMax: 30 * time.Second, timeout: 30 * time.Second,
+			breakerThreshold: 5, breakerCooldown: 30 * time.Second,
+			maxConcurrent: 4,
+		},
+		{
+			name:       "retry budget",
+			data:       SnitchDNSProviderModel{RetryMaxElapsed: types.StringValue("2m")},
+			maxRetries: 3, waitMin: time.Second, waitMax: 30 * time.Second, timeout: 30 * time.Second,
+			breakerThreshold: 5, breakerCooldown: 30 * time.Second,
+			maxElapsed: 2 * time.Minute,
+		},
+		{
+			name: "circuit breaker",
+			data: SnitchDNSProviderModel{
+				CircuitBreakerThreshold: types.Int64Value(10),
+				CircuitBreakerCooldown:  types.StringValue("1m"),
+			},
+			maxRetries: 3, waitMin: time.Second, waitMax: 30 * time.Second, timeout: 30 * time.Second,
+			breakerThreshold: 10, breakerCooldown: time.Minute,
+		},
+		{
+			name:       "circuit breaker disabled",
+			data:       SnitchDNSProviderModel{CircuitBreakerThreshold: types.Int64Value(0)},
+			maxRetries: 3, waitMin: time.Second, waitMax: 30 * time.Second, timeout: 30 * time.Second,
+		},
+		{
+			name:       "response cache",
+			data:       SnitchDNSProviderModel{ResponseCacheTTL: types.StringValue("10s")},
+			maxRetries: 3, waitMin: time.Second, waitMax: 30 * time.Second, timeout: 30 * time.Second,
+			breakerThreshold: 5, breakerCooldown: 30 * time.Second,
+			cacheTTL: 10 * time.Second,
+		},
+		{
+			name:       "backoff strategy",
+			data:       SnitchDNSProviderModel{RetryBackoff: types.StringValue("decorrelated_jitter")},
+			maxRetries: 3, waitMin: time.Second, waitMax: 30 * time.Second, timeout: 30 * time.Second,
+			breakerThreshold: 5, breakerCooldown: 30 * time.Second,
+			backoff: snitchdns.DecorrelatedJitterBackoff{},
+		},
+		{
+			name:    "unknown backoff strategy",
+			data:    SnitchDNSProviderModel{RetryBackoff: types.StringValue("linear")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid duration",
+			data:    SnitchDNSProviderModel{RequestTimeout: types.StringValue("soon")},
+			wantErr: true,
+		},
+		{
+			name:    "negative duration",
+			data:    SnitchDNSProviderModel{RetryWaitMax: types.StringValue("-1s")},
+			wantErr: true,
+		},
+		{
+			name:    "minimum above maximum",
+			data:    SnitchDNSProviderModel{RetryWaitMin: types.StringValue("1m")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, diags := clientTransportOptions(tt.data)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("clientTransportOptions() errors = %v, want error %v", diags, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			c := snitchdns.NewClient("http://localhost", "test-key", opts...)
+			if c.MaxRetries != tt.maxRetries || c.RetryWaitMin != tt.waitMin || c.RetryWaitMax != tt.waitMax {
+				t.Errorf("Unexpected retry settings: %d, %v, %v", c.MaxRetries, c.RetryWaitMin, c.RetryWaitMax)
+			}
+			if c.HTTPClient.Timeout != tt.timeout {
+				t.Errorf("Expected timeout %v, got %v", tt.timeout, c.HTTPClient.Timeout)
+			}
+			if !slices.Equal(c.RetryableStatusCodes, tt.statusCodes) || c.RetryOnConnectionErrors == tt.noConnectionRetries {
+				t.Errorf("Unexpected retry policy: %v, %t", c.RetryableStatusCodes, c.RetryOnConnectionErrors)
+			}
+			if c.IdempotentRetries != tt.idempotentRetries {
+				t.Errorf("Expected idempotent retries %t, got %t", tt.idempotentRetries, c.IdempotentRetries)
+			}
+			if c.MaxElapsedTime != tt.maxElapsed {
+				t.Errorf("Expected a retry budget of %v, got %v", tt.maxElapsed, c.MaxElapsedTime)
+			}
+			if c.MaxConcurrentRequests() != tt.maxConcurrent {
+				t.Errorf("Expected a limit of %d concurrent requests, got %d", tt.maxConcurrent, c.MaxConcurrentRequests())
+			}
+			if threshold, cooldown := c.CircuitBreaker(); threshold != tt.breakerThreshold || cooldown != tt.breakerCooldown {
+				t.Errorf("Expected a circuit breaker of %d failures and %v, got %d and %v", tt.breakerThreshold, tt.breakerCooldown, threshold, cooldown)
+			}
+			if c.CacheTTL() != tt.cacheTTL {
+				t.Errorf("Expected a response cache TTL of %v, got %v", tt.cacheTTL, c.CacheTTL())
+			}
+			wantBackoff := tt.backoff
+			if wantBackoff == nil {
+				wantBackoff = snitchdns.ExponentialBackoff{}
+			}
+			if c.Backoff != wantBackoff {
+				t.Errorf("Expected backoff %T, got %T", wantBackoff, c.Backoff)
+			}
+		})
+	}
+}
+
+// TestProbeServer tests that a single status request checks reachability
+// and detects the version, and that only a successful probe is kept
+func TestProbeServer(t *testing.T) {
+	tests := map[string]struct {
+		status      int
+		body        string
+		wantVersion string
+		wantErr     bool
+	}{
+		"reports version": {http.StatusOK, `{"version": "1.4.0"}`, "1.4.0", false},
+		"no endpoint":     {http.StatusNotFound, `{"message": "Not found"}`, "", false},
+		"rejected key":    {http.StatusUnauthorized, `{"message": "Invalid API key"}`, "", true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests := atomic.Int32{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			p := &SnitchDNSProvider{}
+			client := snitchdns.NewClient(server.URL, "test-key")
+			for range 2 {
+				version, err := p.probeServer(context.Background(), client, &provider.ConfigureResponse{})
+				if (err != nil) != tt.wantErr || version != tt.wantVersion {
+					t.Fatalf("probeServer() = %q, %v; want %q, error %t", version, err, tt.wantVersion, tt.wantErr)
+				}
+			}
+
+			want := int32(1)
+			if tt.wantErr {
+				want = 2
+			}
+			if requests.Load() != want {
+				t.Errorf("Expected %d status requests, got %d", want, requests.Load())
+			}
+		})
 	}
 }
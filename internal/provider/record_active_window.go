@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// recordActiveWindowMargin is how close to now a boundary of the active
+// window must be for active to be planned unknown, so a plan applied a few
+// minutes later does not set the state the window had when it was made
+const recordActiveWindowMargin = 5 * time.Minute
+
+// recordActiveWindowNow returns the time the active window is compared to,
+// replaced in tests
+var recordActiveWindowNow = time.Now
+
+// recordActiveWindow is the window a record is active in. A zero bound
+// leaves that side of the window open.
+type recordActiveWindow struct {
+	from, until time.Time
+}
+
+// newRecordActiveWindow parses active_from and active_until. ok is false
+// when neither is set, or one is unknown.
+func newRecordActiveWindow(from, until types.String) (window recordActiveWindow, ok bool, err error) {
+	if from.IsUnknown() || until.IsUnknown() || (from.IsNull() && until.IsNull()) {
+		return window, false, nil
+	}
+	if !from.IsNull() {
+		if window.from, err = time.Parse(time.RFC3339, from.ValueString()); err != nil {
+			return window, false, fmt.Errorf("active_from: %w", err)
+		}
+	}
+	if !until.IsNull() {
+		if window.until, err = time.Parse(time.RFC3339, until.ValueString()); err != nil {
+			return window, false, fmt.Errorf("active_until: %w", err)
+		}
+	}
+	return window, true, nil
+}
+
+// active reports whether the window covers now: from is inclusive, until
+// exclusive
+func (w recordActiveWindow) active(now time.Time) bool {
+	return (w.from.IsZero() || !now.Before(w.from)) && (w.until.IsZero() || now.Before(w.until))
+}
+
+// nearBoundary reports whether a bound of the window falls within margin
+// after now, so active may have flipped by the time a plan is applied
+func (w recordActiveWindow) nearBoundary(now time.Time, margin time.Duration) bool {
+	for _, bound := range []time.Time{w.from, w.until} {
+		if !bound.IsZero() && bound.After(now) && !bound.After(now.Add(margin)) {
+			return true
+		}
+	}
+	return false
+}
+
+// planActiveWindow plans active from the active window of a record
+// configuring one: whether it covers the current time, or unknown when a
+// bound is near enough to be passed before the apply, which then decides.
+// Once a bound passed, the next plan flips active and so updates the record.
+func planActiveWindow(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var from, until types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("active_from"), &from)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("active_until"), &until)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	window, ok, err := newRecordActiveWindow(from, until)
+	if err != nil || !ok {
+		// Invalid times are reported by the attribute validators
+		return
+	}
+
+	now := recordActiveWindowNow()
+	if window.nearBoundary(now, recordActiveWindowMargin) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("active"), types.BoolUnknown())...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("active"), window.active(now))...)
+}
+
+// applyActiveWindow decides active at apply time when it was planned
+// unknown, as the window's bound was near
+func applyActiveWindow(data *RecordResourceModel) {
+	if !data.Active.IsUnknown() {
+		return
+	}
+	window, ok, err := newRecordActiveWindow(data.ActiveFrom, data.ActiveUntil)
+	data.Active = types.BoolValue(err == nil && ok && window.active(recordActiveWindowNow()))
+}
+
+// recordActiveWindowValidator checks that active_from is before
+// active_until
+type recordActiveWindowValidator struct{}
+
+var _ resource.ConfigValidator = recordActiveWindowValidator{}
+
+// Description describes the validation in plain text formatting.
+func (v recordActiveWindowValidator) Description(_ context.Context) string {
+	return "active_from must be before active_until"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v recordActiveWindowValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation. Nothing is checked while a
+// bound is unknown, unset or invalid.
+func (v recordActiveWindowValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var from, until types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("active_from"), &from)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("active_until"), &until)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	window, ok, err := newRecordActiveWindow(from, until)
+	if err != nil || !ok || window.from.IsZero() || window.until.IsZero() {
+		return
+	}
+	if !window.from.Before(window.until) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("active_until"),
+			"Empty active window",
+			fmt.Sprintf("active_until (%s) must be after active_from (%s), or the record is never active.",
+				until.ValueString(), from.ValueString()),
+		)
+	}
+}
+
+// rfc3339Validator checks that a string is an RFC 3339 time
+type rfc3339Validator struct{}
+
+var _ validator.String = rfc3339Validator{}
+
+// Description describes the validation in plain text formatting.
+func (v rfc3339Validator) Description(_ context.Context) string {
+	return "value must be an RFC 3339 time, such as 2024-01-01T00:00:00Z"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Time",
+			fmt.Sprintf("%s: %s", v.Description(ctx), err))
+	}
+}
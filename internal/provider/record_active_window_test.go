@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestRecordResource_ModifyPlanActiveWindow tests that active is planned
+// from the active window, and unknown near a bound
+func TestRecordResource_ModifyPlanActiveWindow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) types.String {
+		return types.StringValue(now.Add(offset).Format(time.RFC3339))
+	}
+
+	tests := map[string]struct {
+		from, until types.String
+		expected    types.Bool
+	}{
+		"inside":            {at(-time.Hour), at(time.Hour), types.BoolValue(true)},
+		"before":            {at(time.Hour), at(2 * time.Hour), types.BoolValue(false)},
+		"after":             {at(-2 * time.Hour), at(-time.Hour), types.BoolValue(false)},
+		"from inclusive":    {at(0), types.StringNull(), types.BoolValue(true)},
+		"until exclusive":   {types.StringNull(), at(0), types.BoolValue(false)},
+		"open until":        {at(-time.Hour), types.StringNull(), types.BoolValue(true)},
+		"start near":        {at(2 * time.Minute), at(time.Hour), types.BoolUnknown()},
+		"end near":          {at(-time.Hour), at(5 * time.Minute), types.BoolUnknown()},
+		"end beyond margin": {at(-time.Hour), at(6 * time.Minute), types.BoolValue(true)},
+		"without window":    {types.StringNull(), types.StringNull(), types.BoolValue(true)},
+		"unknown bound":     {types.StringUnknown(), at(time.Hour), types.BoolValue(true)},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			recordActiveWindowNow = func() time.Time { return now }
+			t.Cleanup(func() { recordActiveWindowNow = time.Now })
+
+			r := newMockResource(t, NewRecordResource(), snitchdnsmock.New())
+			plan := mockPlan(t, r, map[string]attr.Value{
+				"zone_id":      types.StringValue("1"),
+				"type":         types.StringValue("A"),
+				"ttl":          types.Int64Value(300),
+				"active":       types.BoolValue(true),
+				"active_from":  tt.from,
+				"active_until": tt.until,
+			})
+			config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+				Config: config,
+				Plan:   plan,
+			}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+			}
+
+			var active types.Bool
+			resp.Plan.GetAttribute(ctx, path.Root("active"), &active)
+			if !active.Equal(tt.expected) {
+				t.Errorf("Expected active %s, got %s", tt.expected, active)
+			}
+		})
+	}
+}
+
+// TestRecordResource_MockActiveWindowApply tests that a record planned with
+// an unknown active is created in the state its window has at apply time
+func TestRecordResource_MockActiveWindowApply(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	recordActiveWindowNow = func() time.Time { return now }
+	t.Cleanup(func() { recordActiveWindowNow = time.Now })
+
+	mock := snitchdnsmock.New()
+	zone := mock.AddZone(snitchdns.Zone{Domain: "example.com", Active: true})
+	r := newMockResource(t, NewRecordResource(), mock)
+
+	data, diags := NewRecordDataValue(ctx, map[string]string{"address": "192.0.2.1"})
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"zone_id":      types.StringValue(strconv.Itoa(zone.ID)),
+		"active":       types.BoolUnknown(),
+		"active_from":  types.StringValue(now.Add(-time.Hour).Format(time.RFC3339)),
+		"active_until": types.StringValue(now.Add(time.Minute).Format(time.RFC3339)),
+		"cls":          types.StringValue("IN"),
+		"type":         types.StringValue("A"),
+		"ttl":          types.Int64Value(300),
+		"data":         data,
+	})
+
+	// The window ended between plan and apply
+	now = now.Add(2 * time.Minute)
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", resp.Diagnostics)
+	}
+
+	records := mock.Records(zone.ID)
+	if len(records) != 1 || records[0].Active {
+		t.Fatalf("Expected an inactive record, got %+v", records)
+	}
+	var active types.Bool
+	resp.State.GetAttribute(ctx, path.Root("active"), &active)
+	if !active.Equal(types.BoolValue(false)) {
+		t.Errorf("Expected active false in state, got %s", active)
+	}
+}
+
+// TestRecordActiveWindowValidator tests that an active window must not be
+// empty
+func TestRecordActiveWindowValidator(t *testing.T) {
+	ctx := context.Background()
+	r := newMockResource(t, NewRecordResource(), snitchdnsmock.New())
+
+	tests := map[string]struct {
+		from, until string
+		wantErr     bool
+	}{
+		"ordered": {"2024-06-01T08:00:00Z", "2024-06-01T18:00:00Z", false},
+		"equal":   {"2024-06-01T08:00:00Z", "2024-06-01T08:00:00Z", true},
+		"reverse": {"2024-06-01T18:00:00Z", "2024-06-01T10:00:00+02:00", true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := tfsdk.Config(mockPlan(t, r, map[string]attr.Value{
+				"active_from":  types.StringValue(tt.from),
+				"active_until": types.StringValue(tt.until),
+			}))
+			resp := fwresource.ValidateConfigResponse{}
+			recordActiveWindowValidator{}.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: config}, &resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("Expected error %t, got %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
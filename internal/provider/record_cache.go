@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RecordCache holds the records of each zone, listed once per provider
+// instance unless the listing fails. Terraform starts a fresh provider process for every
+// plan/apply, so the cache lives exactly as long as a single operation.
+type RecordCache struct {
+	client snitchdns.ClientInterface
+
+	mu    sync.Mutex
+	zones map[string]*zoneRecords
+}
+
+// zoneRecords is the cached listing of a single zone. Only a successful
+// listing is kept; after a failure, the next caller lists the zone again.
+type zoneRecords struct {
+	mu      sync.Mutex
+	loaded  bool
+	records map[string]snitchdns.Record
+}
+
+// NewRecordCache creates an empty record cache backed by the given client.
+func NewRecordCache(c snitchdns.ClientInterface) *RecordCache {
+	return &RecordCache{
+		client: c,
+		zones:  make(map[string]*zoneRecords),
+	}
+}
+
+// GetRecord returns a record from the cached zone listing. The zone is listed
+// on first access; concurrent callers for the same zone wait for that single
+// listing. The boolean result is false when the record does not exist.
+func (c *RecordCache) GetRecord(ctx context.Context, zoneID, recordID string) (*snitchdns.Record, bool, error) {
+	records, err := c.load(ctx, zoneID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	record, ok := records[recordID]
+	if !ok {
+		return nil, false, nil
+	}
+	return &record, true, nil
+}
+
+// ListRecords returns all records of a zone from the cached listing, ordered
+// by record ID. Data sources use it so that they share the listing with each
+// other and with resource refreshes.
+func (c *RecordCache) ListRecords(ctx context.Context, zoneID string) ([]snitchdns.Record, error) {
+	cached, err := c.load(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]snitchdns.Record, 0, len(cached))
+	for _, record := range cached {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ID < records[j].ID
+	})
+	return records, nil
+}
+
+// load returns the records of a zone by ID, listing the zone if necessary.
+// The listing is shared by every caller waiting for it, so it does not end
+// with the context of the caller that started it; a canceled caller would
+// otherwise fail the others.
+func (c *RecordCache) load(ctx context.Context, zoneID string) (map[string]snitchdns.Record, error) {
+	entry := c.zone(zoneID)
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.loaded {
+		return entry.records, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "Listing zone records for cache", map[string]any{
+		"zone_id": zoneID,
+	})
+
+	records, err := c.client.ListRecordsWithContext(context.WithoutCancel(ctx), zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.records = make(map[string]snitchdns.Record, len(records))
+	for _, record := range records {
+		entry.records[strconv.Itoa(record.ID)] = record
+	}
+	entry.loaded = true
+	return entry.records, nil
+}
+
+// Invalidate drops the cached listing of a zone so the next read lists it
+// again. It must be called after any write to the zone's records.
+func (c *RecordCache) Invalidate(zoneID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.zones, zoneID)
+}
+
+// zone returns the cache entry for a zone, creating it if necessary
+func (c *RecordCache) zone(zoneID string) *zoneRecords {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.zones[zoneID]
+	if !ok {
+		entry = &zoneRecords{}
+		c.zones[zoneID] = entry
+	}
+	return entry
+}
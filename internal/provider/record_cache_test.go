@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// TestRecordCache_SingleListingPerZone tests that concurrent reads share one listing call
+func TestRecordCache_SingleListingPerZone(t *testing.T) {
+	listings := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		listings.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{"id": 1, "zone_id": 7, "active": true, "cls": "IN", "type": "A", "ttl": 300, "data": "{\"address\": \"10.0.0.1\"}"},
+			{"id": 2, "zone_id": 7, "active": true, "cls": "IN", "type": "A", "ttl": 300, "data": "{\"address\": \"10.0.0.2\"}"}
+		]`))
+	}))
+	defer server.Close()
+
+	cache := NewRecordCache(snitchdns.NewClient(server.URL, "test-key"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, found, err := cache.GetRecord(context.Background(), "7", "2"); err != nil || !found {
+				t.Errorf("Expected record to be found, got found=%t err=%v", found, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if listings.Load() != 1 {
+		t.Errorf("Expected 1 listing call, got %d", listings.Load())
+	}
+
+	if _, found, err := cache.GetRecord(context.Background(), "7", "3"); err != nil || found {
+		t.Errorf("Expected missing record to be reported as not found, got found=%t err=%v", found, err)
+	}
+
+	cache.Invalidate("7")
+	if _, _, err := cache.GetRecord(context.Background(), "7", "1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if listings.Load() != 2 {
+		t.Errorf("Expected invalidation to trigger a second listing, got %d listings", listings.Load())
+	}
+}
+
+// TestRecordCache_FailureNotCached tests that a failed listing, or the
+// cancellation of the caller that started it, does not fail later reads
+func TestRecordCache_FailureNotCached(t *testing.T) {
+	var listings atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if listings.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "temporarily unavailable"}`))
+			return
+		}
+		w.Write([]byte(`[{"id": 1, "zone_id": 7, "active": true, "cls": "IN", "type": "A", "ttl": 300, "data": "{\"address\": \"10.0.0.1\"}"}]`))
+	}))
+	defer server.Close()
+
+	cache := NewRecordCache(snitchdns.NewClient(server.URL, "test-key", snitchdns.WithRetry(0, 0, 0)))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := cache.GetRecord(canceled, "7", "1"); err == nil {
+		t.Error("Expected an error for a canceled caller")
+	}
+	if _, _, err := cache.GetRecord(context.Background(), "7", "1"); err == nil {
+		t.Error("Expected the failed listing to be reported")
+	}
+	if _, found, err := cache.GetRecord(context.Background(), "7", "1"); err != nil || !found {
+		t.Errorf("Expected the zone to be listed again, got found=%t err=%v", found, err)
+	}
+	if listings.Load() != 2 {
+		t.Errorf("Expected 2 listings, got %d", listings.Load())
+	}
+}
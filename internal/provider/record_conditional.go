@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// conditionalRecordAttributes are the attributes that only take effect on
+// conditional records
+var conditionalRecordAttributes = []string{"conditional_limit", "conditional_reset", "conditional_data"}
+
+// conditionalRecordValidator checks that the conditional attributes of a
+// record are set together with is_conditional = true. SnitchDNS rejects
+// such records with a generic validation error, or silently ignores the
+// attributes; the fields of conditional_data are checked against the record
+// type at plan time, like those of data.
+type conditionalRecordValidator struct{}
+
+var _ resource.ConfigValidator = conditionalRecordValidator{}
+
+// Description describes the validation in plain text formatting.
+func (v conditionalRecordValidator) Description(_ context.Context) string {
+	return "conditional_limit, conditional_reset and conditional_data require is_conditional = true, " +
+		"and conditional records require conditional_limit and conditional_data"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v conditionalRecordValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation. Nothing is checked while
+// is_conditional is unknown.
+func (v conditionalRecordValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var isConditional types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("is_conditional"), &isConditional)...)
+	if resp.Diagnostics.HasError() || isConditional.IsUnknown() {
+		return
+	}
+
+	for _, name := range conditionalRecordAttributes {
+		var value attr.Value
+		diags := req.Config.GetAttribute(ctx, path.Root(name), &value)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		switch {
+		case !isConditional.ValueBool() && !value.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Conditional attribute on a regular record",
+				name+" only takes effect on conditional records. Set is_conditional = true, or remove "+name+".",
+			)
+		case isConditional.ValueBool() && value.IsNull() && name != "conditional_reset":
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Missing conditional attribute",
+				name+" must be set when is_conditional = true.",
+			)
+		}
+	}
+}
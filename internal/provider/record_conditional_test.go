@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestConditionalRecordValidator tests which combinations of conditional
+// attributes are accepted
+func TestConditionalRecordValidator(t *testing.T) {
+	ctx := context.Background()
+	r := NewRecordResource()
+
+	conditionalData := RecordDataValue{MapValue: types.MapValueMust(types.StringType, map[string]attr.Value{
+		"address": types.StringValue("10.0.0.2"),
+	})}
+
+	tests := []struct {
+		name       string
+		attributes map[string]attr.Value
+		errorPaths []path.Path
+	}{
+		{
+			name:       "regular record",
+			attributes: map[string]attr.Value{},
+		},
+		{
+			name: "conditional record",
+			attributes: map[string]attr.Value{
+				"is_conditional":    types.BoolValue(true),
+				"conditional_limit": types.Int64Value(10),
+				"conditional_data":  conditionalData,
+			},
+		},
+		{
+			name: "conditional attributes without is_conditional",
+			attributes: map[string]attr.Value{
+				"conditional_limit": types.Int64Value(10),
+				"conditional_reset": types.BoolValue(true),
+			},
+			errorPaths: []path.Path{path.Root("conditional_limit"), path.Root("conditional_reset")},
+		},
+		{
+			name: "conditional data on a disabled conditional record",
+			attributes: map[string]attr.Value{
+				"is_conditional":   types.BoolValue(false),
+				"conditional_data": conditionalData,
+			},
+			errorPaths: []path.Path{path.Root("conditional_data")},
+		},
+		{
+			name: "conditional record without limit and data",
+			attributes: map[string]attr.Value{
+				"is_conditional":    types.BoolValue(true),
+				"conditional_reset": types.BoolValue(true),
+			},
+			errorPaths: []path.Path{path.Root("conditional_limit"), path.Root("conditional_data")},
+		},
+		{
+			name: "unknown is_conditional",
+			attributes: map[string]attr.Value{
+				"is_conditional":    types.BoolUnknown(),
+				"conditional_limit": types.Int64Value(10),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := mockPlan(t, r, tt.attributes)
+			config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+
+			var resp fwresource.ValidateConfigResponse
+			conditionalRecordValidator{}.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: config}, &resp)
+
+			if resp.Diagnostics.ErrorsCount() != len(tt.errorPaths) {
+				t.Fatalf("Expected %d errors, got %v", len(tt.errorPaths), resp.Diagnostics)
+			}
+			for i, diagnostic := range resp.Diagnostics.Errors() {
+				withPath, ok := diagnostic.(interface{ Path() path.Path })
+				if !ok || !withPath.Path().Equal(tt.errorPaths[i]) {
+					t.Errorf("Expected error %d at %s, got %v", i, tt.errorPaths[i], diagnostic)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// recordFieldRename is a record data field that older SnitchDNS releases
+// name differently from the provider's canonical attribute name
+type recordFieldRename struct {
+	Type      string
+	Canonical string
+	Legacy    string
+	// Since is the first server version using the canonical name
+	Since serverVersion
+}
+
+// recordFieldRenames lists the known record data field renames. Canonical
+// names are the ones documented for the data attribute of snitchdns_record.
+var recordFieldRenames = []recordFieldRename{
+	{Type: "MX", Canonical: "priority", Legacy: "preference", Since: serverVersion{1, 3, 0}},
+	{Type: "SRV", Canonical: "target", Legacy: "hostname", Since: serverVersion{1, 3, 0}},
+	{Type: "TXT", Canonical: "data", Legacy: "text", Since: serverVersion{1, 3, 0}},
+	{Type: "SPF", Canonical: "data", Legacy: "text", Since: serverVersion{1, 3, 0}},
+}
+
+// recordDataMapper translates record data between the provider's canonical
+// field names and the field names of a specific server version. A nil mapper
+// translates nothing, which is correct for current servers and for servers
+// whose version could not be detected.
+type recordDataMapper struct {
+	// renames maps record type to canonical field name to server field name
+	renames map[string]map[string]string
+}
+
+// newRecordDataMapper returns the mapper for a server version, or nil when no
+// translation is needed or the version cannot be parsed
+func newRecordDataMapper(version string) *recordDataMapper {
+	v, ok := parseServerVersion(version)
+	if !ok {
+		return nil
+	}
+
+	renames := make(map[string]map[string]string)
+	for _, rename := range recordFieldRenames {
+		if !v.Less(rename.Since) {
+			continue
+		}
+		if renames[rename.Type] == nil {
+			renames[rename.Type] = make(map[string]string)
+		}
+		renames[rename.Type][rename.Canonical] = rename.Legacy
+	}
+
+	if len(renames) == 0 {
+		return nil
+	}
+	return &recordDataMapper{renames: renames}
+}
+
+// ToServer returns data with canonical field names replaced by the names the
+// server expects
+func (m *recordDataMapper) ToServer(recordType string, data map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return data
+	}
+	return renameFields(data, m.renames[strings.ToUpper(recordType)])
+}
+
+// FromServer returns data with server field names replaced by the canonical
+// names
+func (m *recordDataMapper) FromServer(recordType string, data map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return data
+	}
+
+	reverse := make(map[string]string)
+	for canonical, legacy := range m.renames[strings.ToUpper(recordType)] {
+		reverse[legacy] = canonical
+	}
+	return renameFields(data, reverse)
+}
+
+// FromServerRecord returns a copy of record with its data and conditional
+// data translated to canonical field names. The record's data is not
+// modified, as it may be shared with the record cache.
+func (m *recordDataMapper) FromServerRecord(record *snitchdns.Record) *snitchdns.Record {
+	if m == nil || record == nil || len(m.renames[strings.ToUpper(record.Type)]) == 0 {
+		return record
+	}
+
+	mapped := *record
+	mapped.Data = m.fromServerRaw(record.Type, record.Data)
+	mapped.ConditionalData = m.fromServerRaw(record.Type, record.ConditionalData)
+	return &mapped
+}
+
+// fromServerRaw returns raw data with server field names replaced by the
+// canonical names. The values are kept as encoded, so numbers are not
+// rounded; data that cannot be decoded is returned as is.
+func (m *recordDataMapper) fromServerRaw(recordType string, raw snitchdns.RawRecordData) snitchdns.RawRecordData {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		return raw
+	}
+
+	data := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		data[key] = value
+	}
+	return snitchdns.EncodeRecordData(m.FromServer(recordType, data))
+}
+
+// renameFields returns a copy of data with keys renamed; data is returned as
+// is when there is nothing to rename
+func renameFields(data map[string]interface{}, renames map[string]string) map[string]interface{} {
+	if len(renames) == 0 || data == nil {
+		return data
+	}
+
+	renamed := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		if to, ok := renames[key]; ok {
+			key = to
+		}
+		renamed[key] = value
+	}
+	return renamed
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// TestRecordDataMapper tests that legacy servers get legacy field names and current servers are left alone
+func TestRecordDataMapper(t *testing.T) {
+	for _, version := range []string{"1.3.0", "1.4.2", "", "unknown"} {
+		if m := newRecordDataMapper(version); m != nil {
+			t.Errorf("Expected no mapping for version %q", version)
+		}
+	}
+
+	m := newRecordDataMapper("1.2.5")
+	if m == nil {
+		t.Fatal("Expected a mapping for version 1.2.5")
+	}
+
+	toServer := m.ToServer("mx", map[string]interface{}{"priority": "10", "hostname": "mail.example.com."})
+	if toServer["preference"] != "10" || toServer["hostname"] != "mail.example.com." || len(toServer) != 2 {
+		t.Errorf("Unexpected server data: %v", toServer)
+	}
+
+	fromServer := m.FromServer("MX", toServer)
+	if fromServer["priority"] != "10" || len(fromServer) != 2 {
+		t.Errorf("Expected round-trip to canonical names, got %v", fromServer)
+	}
+
+	// Types without renames pass through unchanged
+	a := map[string]interface{}{"address": "10.0.0.1"}
+	if got := m.ToServer("A", a); got["address"] != "10.0.0.1" {
+		t.Errorf("Unexpected A data: %v", got)
+	}
+}
+
+// TestRecordDataMapperDoesNotModifyCachedRecords tests that translating a record leaves the original maps untouched
+func TestRecordDataMapperDoesNotModifyCachedRecords(t *testing.T) {
+	m := newRecordDataMapper("1.2.0")
+
+	cached := &snitchdns.Record{Type: "TXT", Data: snitchdns.EncodeRecordData(map[string]interface{}{"text": "hello"})}
+	mapped := m.FromServerRecord(cached)
+
+	if mapped.Data.Get("data") != "hello" {
+		t.Errorf("Expected canonical data field, got %s", mapped.Data)
+	}
+	if fields := cached.Data.Strings(); fields["text"] != "hello" || len(fields) != 1 {
+		t.Errorf("Expected cached record to keep server field names, got %s", cached.Data)
+	}
+
+	var nilMapper *recordDataMapper
+	if nilMapper.FromServerRecord(cached) != cached {
+		t.Error("Expected nil mapper to return the record as is")
+	}
+}
+
+// TestRecordDataMapperTypedData tests that typed data of legacy servers is
+// decoded with canonical field names
+func TestRecordDataMapperTypedData(t *testing.T) {
+	m := newRecordDataMapper("1.2.0")
+
+	record := m.FromServerRecord(&snitchdns.Record{
+		Type: "SRV",
+		Data: snitchdns.RawRecordData(`{"priority": 10, "weight": 60, "port": 5060, "hostname": "sip.example.com."}`),
+	})
+	typed, err := record.TypedData()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if srv, ok := typed.(*snitchdns.SRVRecordData); !ok || srv.Target != "sip.example.com." || srv.Port != 5060 {
+		t.Errorf("Expected the target from the legacy field, got %+v", typed)
+	}
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the record data types satisfy the framework interfaces.
+var _ basetypes.MapTypable = RecordDataType{}
+var _ basetypes.MapValuableWithSemanticEquals = RecordDataValue{}
+
+// recordDataDefaults are the values SnitchDNS fills in for optional data
+// fields that were not sent, e.g. an empty CAA tag or a zero SRV weight
+var recordDataDefaults = map[string]bool{
+	"":      true,
+	"0":     true,
+	"false": true,
+}
+
+// RecordDataType is the type of record data attributes: a map of strings
+// whose values ignore data fields the server adds with default values.
+type RecordDataType struct {
+	basetypes.MapType
+}
+
+// NewRecordDataType returns the record data type
+func NewRecordDataType() RecordDataType {
+	return RecordDataType{MapType: basetypes.MapType{ElemType: types.StringType}}
+}
+
+// String returns a human readable name of the type
+func (t RecordDataType) String() string {
+	return "RecordDataType"
+}
+
+// Equal reports whether the given type is a record data type
+func (t RecordDataType) Equal(o attr.Type) bool {
+	other, ok := o.(RecordDataType)
+	if !ok {
+		return false
+	}
+	return t.MapType.Equal(other.MapType)
+}
+
+// ValueFromMap wraps a map value as record data
+func (t RecordDataType) ValueFromMap(_ context.Context, in basetypes.MapValue) (basetypes.MapValuable, diag.Diagnostics) {
+	return RecordDataValue{MapValue: in}, nil
+}
+
+// ValueFromTerraform converts a Terraform value to record data
+func (t RecordDataType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.MapType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	mapValue, ok := value.(basetypes.MapValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return RecordDataValue{MapValue: mapValue}, nil
+}
+
+// ValueType returns the value type of the type
+func (t RecordDataType) ValueType(_ context.Context) attr.Value {
+	return RecordDataValue{}
+}
+
+// RecordDataValue is a record data attribute value.
+type RecordDataValue struct {
+	basetypes.MapValue
+}
+
+// NewRecordDataNull returns null record data
+func NewRecordDataNull() RecordDataValue {
+	return RecordDataValue{MapValue: types.MapNull(types.StringType)}
+}
+
+// NewRecordDataUnknown returns unknown record data
+func NewRecordDataUnknown() RecordDataValue {
+	return RecordDataValue{MapValue: types.MapUnknown(types.StringType)}
+}
+
+// NewRecordDataValue converts API record data to an attribute value
+func NewRecordDataValue(ctx context.Context, data map[string]string) (RecordDataValue, diag.Diagnostics) {
+	value, diags := types.MapValueFrom(ctx, types.StringType, data)
+	return RecordDataValue{MapValue: value}, diags
+}
+
+// Type returns the record data type
+func (v RecordDataValue) Type(_ context.Context) attr.Type {
+	return NewRecordDataType()
+}
+
+// Equal reports whether the given value is identical record data
+func (v RecordDataValue) Equal(o attr.Value) bool {
+	other, ok := o.(RecordDataValue)
+	if !ok {
+		return false
+	}
+	return v.MapValue.Equal(other.MapValue)
+}
+
+// MapSemanticEquals reports whether the data read from the server (v)
+// matches the prior configuration or state. Fields the server added with a
+// default value are ignored; every other difference is a genuine change.
+func (v RecordDataValue) MapSemanticEquals(ctx context.Context, priorValuable basetypes.MapValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	prior, ok := priorValuable.(RecordDataValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this issue to the provider developers.", v, priorValuable),
+		)
+		return false, diags
+	}
+
+	var current, previous map[string]string
+	diags.Append(v.ElementsAs(ctx, &current, false)...)
+	diags.Append(prior.ElementsAs(ctx, &previous, false)...)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	return recordDataEquivalent(previous, current), diags
+}
+
+// recordDataEquivalent reports whether the server's data matches the prior
+// data once the server-added defaults are ignored
+func recordDataEquivalent(prior, current map[string]string) bool {
+	for key, value := range prior {
+		if other, ok := current[key]; !ok || !recordDataValueEqual(value, other) {
+			return false
+		}
+	}
+	for key, value := range current {
+		if _, ok := prior[key]; !ok && !recordDataDefaults[value] {
+			return false
+		}
+	}
+	return true
+}
+
+// recordDataValueEqual reports whether two record data values are equal.
+// Values holding JSON objects or arrays are compared by their decoded
+// content, so key order and whitespace do not count as changes.
+func recordDataValueEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if !isJSONContainer(a) || !isJSONContainer(b) {
+		return false
+	}
+
+	var x, y interface{}
+	if json.Unmarshal([]byte(a), &x) != nil || json.Unmarshal([]byte(b), &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// isJSONContainer reports whether value looks like a JSON object or array
+func isJSONContainer(value string) bool {
+	trimmed := bytes.TrimSpace([]byte(value))
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
+
+// recordDataString converts a record data value decoded from the API's JSON
+// to the string stored in Terraform. Numbers are written in full rather than
+// in exponent form, and nested objects and arrays as compact JSON with
+// sorted keys, so the same data always converts to the same string.
+func recordDataString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(encoded)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
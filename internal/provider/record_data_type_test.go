@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+// TestRecordDataSemanticEquals tests that server-added defaults are ignored but real changes are not
+func TestRecordDataSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+	prior := map[string]string{"hostname": "sip.example.com", "port": "5060"}
+
+	tests := map[string]struct {
+		current map[string]string
+		want    bool
+	}{
+		"identical":          {map[string]string{"hostname": "sip.example.com", "port": "5060"}, true},
+		"added defaults":     {map[string]string{"hostname": "sip.example.com", "port": "5060", "weight": "0", "tag": ""}, true},
+		"added value":        {map[string]string{"hostname": "sip.example.com", "port": "5060", "weight": "10"}, false},
+		"changed value":      {map[string]string{"hostname": "sip.example.com", "port": "5061"}, false},
+		"removed configured": {map[string]string{"hostname": "sip.example.com"}, false},
+	}
+
+	priorValue, diags := NewRecordDataValue(ctx, prior)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			current, diags := NewRecordDataValue(ctx, tt.current)
+			if diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+
+			equal, diags := current.MapSemanticEquals(ctx, priorValue)
+			if diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+			if equal != tt.want {
+				t.Errorf("Expected semantic equality %t, got %t", tt.want, equal)
+			}
+		})
+	}
+
+	jsonPrior, _ := NewRecordDataValue(ctx, map[string]string{"data": `{"a": 1, "b": [1, 2]}`})
+	jsonCurrent, _ := NewRecordDataValue(ctx, map[string]string{"data": `{"b":[1,2],"a":1}`})
+	if equal, _ := jsonCurrent.MapSemanticEquals(ctx, jsonPrior); !equal {
+		t.Error("Expected reformatted JSON values to be semantically equal")
+	}
+}
+
+// TestRecordDataValueEqual tests that JSON values compare by content and other values exactly
+func TestRecordDataValueEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"sip.example.com", "sip.example.com", true},
+		{`{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`, true},
+		{`[ "x", "y" ]`, `["x","y"]`, true},
+		{`{"a": 1}`, `{"a": 2}`, false},
+		{`["x", "y"]`, `["y", "x"]`, false},
+		{"10", " 10", false},
+		{`{"a": 1`, `{"a": 1}`, false},
+	}
+
+	for _, tt := range tests {
+		if got := recordDataValueEqual(tt.a, tt.b); got != tt.want {
+			t.Errorf("recordDataValueEqual(%q, %q) = %t, expected %t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// TestRecordDataString tests that decoded JSON values convert to stable strings
+func TestRecordDataString(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{"mail.example.com", "mail.example.com"},
+		{float64(10), "10"},
+		{float64(2024010101), "2024010101"},
+		{1.5, "1.5"},
+		{true, "true"},
+		{nil, ""},
+		{map[string]interface{}{"b": float64(2), "a": "x"}, `{"a":"x","b":2}`},
+		{[]interface{}{"x", float64(1)}, `["x",1]`},
+	}
+
+	for _, tt := range tests {
+		if got := recordDataString(tt.value); got != tt.want {
+			t.Errorf("recordDataString(%#v) = %q, expected %q", tt.value, got, tt.want)
+		}
+	}
+}
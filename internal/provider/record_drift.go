@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// recordDrift returns the differences between a record in state and the
+// same record read from the server, one readable line per attribute or data
+// field, such as "TTL changed 300→600". Attributes unset in state, as after
+// an import, are not compared, and neither is conditional_count, which the
+// server changes on every conditional answer. Data fields are compared like
+// the data attribute's semantic equality, so differences Terraform ignores
+// are not reported.
+func recordDrift(ctx context.Context, prior, current RecordResourceModel) []string {
+	var changes []string
+	attributeDrift(&changes, "Active", prior.Active, current.Active)
+	attributeDrift(&changes, "Class", prior.Class, current.Class)
+	attributeDrift(&changes, "Type", prior.Type, current.Type)
+	attributeDrift(&changes, "TTL", prior.TTL, current.TTL)
+	attributeDrift(&changes, "Conditional", prior.IsConditional, current.IsConditional)
+	attributeDrift(&changes, "Conditional limit", prior.ConditionalLimit, current.ConditionalLimit)
+	attributeDrift(&changes, "Conditional reset", prior.ConditionalReset, current.ConditionalReset)
+	dataDrift(ctx, &changes, "Data", prior.Data, current.Data)
+	dataDrift(ctx, &changes, "Conditional data", prior.ConditionalData, current.ConditionalData)
+	return changes
+}
+
+// attributeDrift adds the change of an attribute set in state
+func attributeDrift(changes *[]string, label string, prior, current attr.Value) {
+	if prior.IsNull() || prior.IsUnknown() || prior.Equal(current) {
+		return
+	}
+	*changes = append(*changes, fmt.Sprintf("%s changed %s→%s", label, prior, current))
+}
+
+// dataDrift adds the changed, added and removed fields of record data set
+// in state. Fields the server added with a default value are ignored.
+func dataDrift(ctx context.Context, changes *[]string, label string, prior, current RecordDataValue) {
+	if prior.IsNull() || prior.IsUnknown() {
+		return
+	}
+
+	var previous, actual map[string]string
+	if prior.ElementsAs(ctx, &previous, false).HasError() {
+		return
+	}
+	if !current.IsNull() && !current.IsUnknown() && current.ElementsAs(ctx, &actual, false).HasError() {
+		return
+	}
+
+	keys := slices.AppendSeq(slices.Collect(maps.Keys(previous)), maps.Keys(actual))
+	slices.Sort(keys)
+	for _, key := range slices.Compact(keys) {
+		was, hadField := previous[key]
+		is, hasField := actual[key]
+		switch {
+		case hadField && !hasField:
+			*changes = append(*changes, fmt.Sprintf("%s field %q removed, was %q", label, key, was))
+		case !hadField && hasField && !recordDataDefaults[is]:
+			*changes = append(*changes, fmt.Sprintf("%s field %q added as %q", label, key, is))
+		case hadField && hasField && !recordDataValueEqual(was, is):
+			*changes = append(*changes, fmt.Sprintf("%s field %q changed %q→%q", label, key, was, is))
+		}
+	}
+}
+
+// addRecordDriftWarning warns about the changes made to a record outside
+// Terraform, which the plan only shows as new attribute values
+func addRecordDriftWarning(diags *diag.Diagnostics, prior RecordResourceModel, changes []string) {
+	if len(changes) == 0 {
+		return
+	}
+	diags.AddWarning("Record Changed Outside Terraform",
+		fmt.Sprintf("Record ID %s in zone %s was changed outside Terraform:\n  - %s",
+			prior.ID.ValueString(), prior.ZoneID.ValueString(), strings.Join(changes, "\n  - ")))
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRecordDrift(t *testing.T) {
+	ctx := context.Background()
+	dataValue := func(data map[string]string) RecordDataValue {
+		value, diags := NewRecordDataValue(ctx, data)
+		if diags.HasError() {
+			t.Fatalf("Unexpected error: %v", diags)
+		}
+		return value
+	}
+
+	prior := RecordResourceModel{
+		Active: types.BoolValue(true),
+		TTL:    types.Int64Value(300),
+		Data:   dataValue(map[string]string{"target": "sip.example.com.", "port": "5060", "priority": "10", "extra": `{"a":1,"b":2}`}),
+
+		ConditionalCount: types.Int64Value(1),
+		ConditionalData:  NewRecordDataNull(),
+	}
+	current := RecordResourceModel{
+		Active: types.BoolValue(false),
+		Class:  types.StringValue("IN"),
+		TTL:    types.Int64Value(300),
+		Data:   dataValue(map[string]string{"target": "sip2.example.com.", "port": "5060", "weight": "0", "extra": `{"b": 2, "a": 1}`, "new": "x"}),
+
+		ConditionalCount: types.Int64Value(5),
+		ConditionalData:  dataValue(map[string]string{"address": "192.0.2.1"}),
+	}
+
+	// Server defaults, reordered JSON, the conditional count and attributes
+	// unset in state are not drift
+	expected := []string{
+		"Active changed true→false",
+		`Data field "new" added as "x"`,
+		`Data field "priority" removed, was "10"`,
+		`Data field "target" changed "sip.example.com."→"sip2.example.com."`,
+	}
+	if got := recordDrift(ctx, prior, current); !slices.Equal(got, expected) {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	// An imported record has nothing in state to compare
+	if got := recordDrift(ctx, RecordResourceModel{Data: NewRecordDataNull(), ConditionalData: NewRecordDataNull()}, current); len(got) != 0 {
+		t.Errorf("Expected no drift after an import, got %q", got)
+	}
+}
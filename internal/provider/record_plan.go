@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"encoding/json"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// zoneRecord is a record of a zone without its ID, as written in the
+// configuration of the resources that own every record of a zone: a record
+// set, a CSV document or a zone file
+type zoneRecord struct {
+	Type             string
+	Class            string
+	TTL              int
+	Active           bool
+	Data             map[string]string
+	IsConditional    bool
+	ConditionalLimit int
+	ConditionalReset bool
+	ConditionalData  map[string]string
+}
+
+// key identifies the record for reconciliation. Records with the same key
+// answer the same question with the same data, so they are matched to each
+// other and only their remaining attributes are updated.
+func (r zoneRecord) key() string {
+	data, _ := json.Marshal(r.Data)
+	return r.Type + "|" + r.Class + "|" + string(data)
+}
+
+// equal reports whether two records with the same key need no update
+func (r zoneRecord) equal(other zoneRecord) bool {
+	return r.TTL == other.TTL &&
+		r.Active == other.Active &&
+		r.IsConditional == other.IsConditional &&
+		r.ConditionalLimit == other.ConditionalLimit &&
+		r.ConditionalReset == other.ConditionalReset &&
+		stringMapsEqual(r.ConditionalData, other.ConditionalData)
+}
+
+// zoneRecordFromClient converts an API record to a zoneRecord
+func zoneRecordFromClient(record *snitchdns.Record) zoneRecord {
+	return zoneRecord{
+		Type:             record.Type,
+		Class:            record.Class,
+		TTL:              record.TTL,
+		Active:           record.Active,
+		Data:             record.Data.Strings(),
+		IsConditional:    record.IsConditional,
+		ConditionalLimit: record.ConditionalLimit,
+		ConditionalReset: record.ConditionalReset,
+		ConditionalData:  record.ConditionalData.Strings(),
+	}
+}
+
+// recordUpdate is an existing record whose attributes must be changed
+type recordUpdate struct {
+	ID     int
+	Record zoneRecord
+}
+
+// recordPlan holds the changes that reconcile the records of a zone with
+// the desired ones
+type recordPlan struct {
+	Creates []zoneRecord
+	Updates []recordUpdate
+	Deletes []snitchdns.Record
+}
+
+// empty reports whether the zone already has the desired records
+func (p recordPlan) empty() bool {
+	return len(p.Creates) == 0 && len(p.Updates) == 0 && len(p.Deletes) == 0
+}
+
+// planRecords matches the desired records against the existing ones by
+// type, class and data. Matched records are updated when their other
+// attributes differ, unmatched desired records are created and unmatched
+// existing records are deleted. Existing records must already be mapped to
+// the field names used in configuration.
+func planRecords(desired []zoneRecord, existing []snitchdns.Record) recordPlan {
+	var plan recordPlan
+
+	available := make(map[string][]snitchdns.Record)
+	for _, record := range existing {
+		key := zoneRecordFromClient(&record).key()
+		available[key] = append(available[key], record)
+	}
+
+	for _, record := range desired {
+		key := record.key()
+		candidates := available[key]
+		if len(candidates) == 0 {
+			plan.Creates = append(plan.Creates, record)
+			continue
+		}
+
+		// Prefer a candidate that needs no update, so duplicates with
+		// different TTLs are not shuffled around on every apply
+		match := 0
+		for i := range candidates {
+			if zoneRecordFromClient(&candidates[i]).equal(record) {
+				match = i
+				break
+			}
+		}
+
+		current := candidates[match]
+		available[key] = append(candidates[:match:match], candidates[match+1:]...)
+
+		if !zoneRecordFromClient(&current).equal(record) {
+			plan.Updates = append(plan.Updates, recordUpdate{ID: current.ID, Record: record})
+		}
+	}
+
+	for _, record := range existing {
+		key := zoneRecordFromClient(&record).key()
+		for _, remaining := range available[key] {
+			if remaining.ID == record.ID {
+				plan.Deletes = append(plan.Deletes, record)
+				break
+			}
+		}
+	}
+
+	return plan
+}
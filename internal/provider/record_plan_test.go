@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// TestPlanRecords tests that records are matched by type, class and data
+func TestPlanRecords(t *testing.T) {
+	existing := []snitchdns.Record{
+		{ID: 1, Type: "A", Class: "IN", TTL: 300, Active: true, Data: snitchdns.EncodeRecordData(map[string]interface{}{"address": "10.0.0.1"})},
+		{ID: 2, Type: "A", Class: "IN", TTL: 300, Active: true, Data: snitchdns.EncodeRecordData(map[string]interface{}{"address": "10.0.0.2"})},
+		{ID: 3, Type: "TXT", Class: "IN", TTL: 300, Active: true, Data: snitchdns.EncodeRecordData(map[string]interface{}{"data": "old"})},
+	}
+
+	desired := []zoneRecord{
+		{Type: "A", Class: "IN", TTL: 300, Active: true, Data: map[string]string{"address": "10.0.0.1"}},
+		{Type: "A", Class: "IN", TTL: 60, Active: true, Data: map[string]string{"address": "10.0.0.2"}},
+		{Type: "TXT", Class: "IN", TTL: 300, Active: true, Data: map[string]string{"data": "new"}},
+	}
+
+	plan := planRecords(desired, existing)
+
+	if len(plan.Creates) != 1 || plan.Creates[0].Data["data"] != "new" {
+		t.Errorf("Expected the new TXT record to be created, got %+v", plan.Creates)
+	}
+	if len(plan.Updates) != 1 || plan.Updates[0].ID != 2 || plan.Updates[0].Record.TTL != 60 {
+		t.Errorf("Expected record 2 to be updated, got %+v", plan.Updates)
+	}
+	if len(plan.Deletes) != 1 || plan.Deletes[0].ID != 3 {
+		t.Errorf("Expected record 3 to be deleted, got %+v", plan.Deletes)
+	}
+}
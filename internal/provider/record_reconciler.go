@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// recordReconciler creates, updates and deletes records for the resources
+// that own every record of a zone. It lists records through the shared
+// cache and translates record data for the detected server version.
+type recordReconciler struct {
+	client     snitchdns.ClientInterface
+	records    *RecordCache
+	recordData *recordDataMapper
+}
+
+// listRecords returns the zone's records with their data mapped to the field
+// names used in configuration
+func (r *recordReconciler) listRecords(ctx context.Context, zoneID string) ([]snitchdns.Record, error) {
+	records, err := r.records.ListRecords(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range records {
+		records[i] = *r.recordData.FromServerRecord(&records[i])
+	}
+	return records, nil
+}
+
+// applyPlan makes the changes of a plan in three phases, deletes, then
+// updates, then creates, each running parallelism operations at a time. A
+// record replacing another is only created once the old one is gone, so it
+// never conflicts with it.
+func (r *recordReconciler) applyPlan(ctx context.Context, zoneID string, plan recordPlan, parallelism int) []batchResult {
+	deletes := make([]batchOperation, 0, len(plan.Deletes))
+	for _, record := range plan.Deletes {
+		deletes = append(deletes, r.deleteOperation(zoneID, record))
+	}
+	updates := make([]batchOperation, 0, len(plan.Updates))
+	for _, update := range plan.Updates {
+		updates = append(updates, r.updateOperation(zoneID, update))
+	}
+	creates := make([]batchOperation, 0, len(plan.Creates))
+	for _, record := range plan.Creates {
+		creates = append(creates, r.createOperation(zoneID, record))
+	}
+
+	return runPhases(ctx, parallelism, deletes, updates, creates)
+}
+
+// createOperation returns a batch operation creating a record
+func (r *recordReconciler) createOperation(zoneID string, record zoneRecord) batchOperation {
+	return batchOperation{
+		Description: fmt.Sprintf("create %s %s", record.Type, recordDataJSON(record.Data)),
+		Run: func(ctx context.Context) error {
+			_, err := r.client.CreateRecordWithContext(ctx, zoneID, snitchdns.CreateRecordRequest{
+				Active:           record.Active,
+				Class:            record.Class,
+				Type:             record.Type,
+				TTL:              record.TTL,
+				Data:             r.recordData.ToServer(record.Type, anyRecordData(record.Data)),
+				IsConditional:    record.IsConditional,
+				ConditionalLimit: record.ConditionalLimit,
+				ConditionalReset: record.ConditionalReset,
+				ConditionalData:  r.recordData.ToServer(record.Type, anyRecordData(record.ConditionalData)),
+			})
+			return err
+		},
+	}
+}
+
+// updateOperation returns a batch operation updating a matched record
+func (r *recordReconciler) updateOperation(zoneID string, update recordUpdate) batchOperation {
+	record := update.Record
+	recordID := strconv.Itoa(update.ID)
+
+	return batchOperation{
+		Description: fmt.Sprintf("update record %s", recordID),
+		Run: func(ctx context.Context) error {
+			_, err := r.client.UpdateRecordWithContext(ctx, zoneID, recordID, snitchdns.UpdateRecordRequest{
+				Active:           &record.Active,
+				TTL:              &record.TTL,
+				IsConditional:    &record.IsConditional,
+				ConditionalLimit: &record.ConditionalLimit,
+				ConditionalReset: &record.ConditionalReset,
+				ConditionalData:  r.recordData.ToServer(record.Type, anyRecordData(record.ConditionalData)),
+			})
+			return err
+		},
+	}
+}
+
+// deleteOperation returns a batch operation deleting a record, treating a
+// record that is already gone as deleted
+func (r *recordReconciler) deleteOperation(zoneID string, record snitchdns.Record) batchOperation {
+	recordID := strconv.Itoa(record.ID)
+
+	return batchOperation{
+		Description: fmt.Sprintf("delete record %s", recordID),
+		Run: func(ctx context.Context) error {
+			err := r.client.DeleteRecordWithContext(ctx, zoneID, recordID)
+			if snitchdns.IsNotFound(err) {
+				return nil
+			}
+			return err
+		},
+	}
+}
+
+// recordDataJSON renders record data for diagnostics
+func recordDataJSON(data map[string]string) string {
+	encoded, _ := json.Marshal(data)
+	return string(encoded)
+}
+
+// anyRecordData converts configuration data to the API representation
+func anyRecordData(data map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		result[key] = value
+	}
+	return result
+}
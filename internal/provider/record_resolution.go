@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/internal/dnslookup"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// recordResolutionDefaultTimeout bounds the wait for a record to resolve
+	recordResolutionDefaultTimeout = 2 * time.Minute
+	// recordResolutionDefaultInterval is the time between DNS queries
+	recordResolutionDefaultInterval = 5 * time.Second
+)
+
+// RecordWaitForResolutionModel describes the wait_for_resolution block.
+type RecordWaitForResolutionModel struct {
+	Server   types.String `tfsdk:"server"`
+	Timeout  types.String `tfsdk:"timeout"`
+	Interval types.String `tfsdk:"interval"`
+}
+
+// recordWaitForResolutionBlock returns the schema of the wait_for_resolution block
+func recordWaitForResolutionBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Wait after create and update until the DNS server answers with the record's data, failing the apply if it never does. " +
+			"Catches records that are stored by the API but not yet served by the DNS daemon. Inactive records and records of regex zones are not waited for.",
+		Attributes: map[string]schema.Attribute{
+			"server": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Server to query, as a host name or IP address with an optional port (default `53`). " +
+					"Defaults to the provider's `dns_check_address`, or the host of `api_url`.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long to wait for the record to resolve, as a duration such as `5m`. Defaults to `2m`.",
+			},
+			"interval": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Time between queries, as a duration such as `10s`. Defaults to `5s`.",
+			},
+		},
+	}
+}
+
+// recordResolution is a parsed wait_for_resolution block
+type recordResolution struct {
+	server   string
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// parseRecordResolution parses the block, falling back to the provider's
+// DNS server
+func parseRecordResolution(block *RecordWaitForResolutionModel, defaultServer string) (*recordResolution, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	wait := &recordResolution{
+		server:   block.Server.ValueString(),
+		timeout:  recordResolutionDefaultTimeout,
+		interval: recordResolutionDefaultInterval,
+	}
+	if wait.server == "" {
+		wait.server = defaultServer
+	}
+	if wait.server == "" {
+		diags.AddAttributeError(
+			path.Root("wait_for_resolution").AtName("server"),
+			"Missing DNS Server",
+			"No server to query: set server, or dns_check_address in the provider configuration.",
+		)
+	}
+
+	for _, d := range []struct {
+		name  string
+		value types.String
+		into  *time.Duration
+	}{
+		{"timeout", block.Timeout, &wait.timeout},
+		{"interval", block.Interval, &wait.interval},
+	} {
+		if d.value.IsNull() || d.value.IsUnknown() {
+			continue
+		}
+		duration, err := time.ParseDuration(d.value.ValueString())
+		if err != nil || duration <= 0 {
+			diags.AddAttributeError(
+				path.Root("wait_for_resolution").AtName(d.name),
+				"Invalid Duration",
+				fmt.Sprintf("%s must be a positive duration such as 30s, got %q.", d.name, d.value.ValueString()),
+			)
+			continue
+		}
+		*d.into = duration
+	}
+
+	return wait, diags
+}
+
+// waitForResolution queries the zone's domain until an answer matches the
+// record's data, or its conditional data for conditional records
+func (r *RecordResource) waitForResolution(ctx context.Context, data *RecordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.WaitForResolution == nil {
+		return diags
+	}
+
+	wait, d := parseRecordResolution(data.WaitForResolution, r.dnsServer)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	recordType := data.Type.ValueString()
+	if !slices.Contains(dnslookup.SupportedTypes(), recordType) {
+		diags.AddAttributeWarning(path.Root("wait_for_resolution"), "Record Resolution Not Checked",
+			fmt.Sprintf("%s records cannot be checked over DNS by the provider.", recordType))
+		return diags
+	}
+	if data.EnableTemplates.ValueBool() && (recordDataHasTemplate(data.Data) || recordDataHasTemplate(data.ConditionalData)) {
+		diags.AddAttributeWarning(path.Root("wait_for_resolution"), "Record Resolution Not Checked",
+			"The record's data holds placeholders, so its answers depend on the query.")
+		return diags
+	}
+	if !data.Active.ValueBool() {
+		tflog.Debug(ctx, "Record is inactive, not waiting for resolution", map[string]any{
+			"record_id": data.ID.ValueString(),
+		})
+		return diags
+	}
+
+	zone, err := r.client.GetZoneWithContext(ctx, data.ZoneID.ValueString())
+	if err != nil {
+		addAPIError(&diags, "Error reading zone",
+			fmt.Sprintf("Could not read zone ID %s to find the name of the record", data.ZoneID.ValueString()), err)
+		return diags
+	}
+	if zone.Regex || !zone.Active {
+		diags.AddAttributeWarning(path.Root("wait_for_resolution"), "Record Resolution Not Checked",
+			fmt.Sprintf("Zone %s is a regex zone or inactive, so the record has no name to query.", zone.Domain))
+		return diags
+	}
+
+	expected := []map[string]string{recordDataStrings(data.Data)}
+	if data.IsConditional.ValueBool() && !data.ConditionalData.IsNull() {
+		expected = append(expected, recordDataStrings(data.ConditionalData))
+	}
+
+	query := dnslookup.Query{Server: wait.server, Name: zone.Domain, Type: recordType}
+	tflog.Debug(ctx, "Waiting for record to resolve", map[string]any{
+		"server":   query.Server,
+		"name":     query.Name,
+		"type":     query.Type,
+		"timeout":  wait.timeout.String(),
+		"interval": wait.interval.String(),
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, wait.timeout)
+	defer cancel()
+
+	lastSeen := "no response"
+	for {
+		queryCtx, queryCancel := context.WithTimeout(ctx, min(dnslookup.DefaultTimeout, wait.timeout))
+		result, err := dnslookup.Lookup(queryCtx, query)
+		queryCancel()
+
+		switch {
+		case err != nil:
+			lastSeen = err.Error()
+		case matchingAnswer(result.Answers, recordType, expected):
+			tflog.Debug(ctx, "Record resolves", map[string]any{
+				"name": query.Name,
+				"type": query.Type,
+			})
+			return diags
+		default:
+			lastSeen = describeAnswers(result)
+		}
+
+		select {
+		case <-ctx.Done():
+			detail := fmt.Sprintf("%s %s did not resolve to the record's data at %s within %s. Last response: %s.",
+				query.Name, recordType, wait.server, wait.timeout, lastSeen)
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				detail = fmt.Sprintf("Waiting for %s %s to resolve was cancelled: %s.", query.Name, recordType, ctx.Err())
+			}
+			diags.AddAttributeError(path.Root("wait_for_resolution"), "Record Did Not Resolve", detail)
+			return diags
+		case <-time.After(wait.interval):
+		}
+	}
+}
+
+// recordDataStrings returns the elements of record data as strings
+func recordDataStrings(data RecordDataValue) map[string]string {
+	values := make(map[string]string, len(data.Elements()))
+	for key, value := range data.Elements() {
+		if s, ok := value.(types.String); ok {
+			values[key] = s.ValueString()
+		}
+	}
+	return values
+}
+
+// matchingAnswer reports whether an answer of the record type carries one of
+// the expected data maps. Values are compared without case and trailing dots,
+// since DNS returns names fully qualified; TXT data is compared exactly.
+func matchingAnswer(answers []dnslookup.Record, recordType string, expected []map[string]string) bool {
+	for _, answer := range answers {
+		if answer.Type != recordType {
+			continue
+		}
+		for _, want := range expected {
+			if answerDataMatches(recordType, answer.Data, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// answerDataMatches reports whether every expected field has the same value
+// in the answer
+func answerDataMatches(recordType string, got, want map[string]string) bool {
+	for key, value := range want {
+		answer, ok := got[key]
+		if !ok {
+			return false
+		}
+		if recordType == "TXT" {
+			if answer != value {
+				return false
+			}
+			continue
+		}
+		if !strings.EqualFold(strings.TrimSuffix(answer, "."), strings.TrimSuffix(value, ".")) {
+			return false
+		}
+	}
+	return true
+}
+
+// describeAnswers summarizes a response for error messages
+func describeAnswers(result *dnslookup.Response) string {
+	if len(result.Answers) == 0 {
+		return result.RCode + " without answers"
+	}
+	parts := make([]string, 0, len(result.Answers))
+	for _, answer := range result.Answers {
+		parts = append(parts, answer.Type+" "+recordDataJSON(answer.Data))
+	}
+	return result.RCode + " with " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/dnslookup"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// serveDNSAnswers answers A queries with 192.0.2.1 until the given number
+// of queries was answered, and with the address from then on
+func serveDNSAnswers(t *testing.T, staleQueries int32, address [4]byte) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	var queries atomic.Int32
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var p dnsmessage.Parser
+			header, err := p.Start(buf[:n])
+			if err != nil {
+				continue
+			}
+			question, _ := p.Question()
+
+			answer := address
+			if queries.Add(1) <= staleQueries {
+				answer = [4]byte{192, 0, 2, 1}
+			}
+			builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true})
+			_ = builder.StartQuestions()
+			_ = builder.Question(question)
+			_ = builder.StartAnswers()
+			_ = builder.AResource(dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 60},
+				dnsmessage.AResource{A: answer})
+			msg, _ := builder.Finish()
+			conn.WriteTo(msg, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// TestRecordWaitForResolution tests polling until the DNS server answers
+// with the record's data
+func TestRecordWaitForResolution(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	zone := mock.AddZone(snitchdns.Zone{Domain: "canary.example.com", Active: true})
+
+	address, _ := NewRecordDataValue(ctx, map[string]string{"address": "192.0.2.20"})
+	data := func(timeout string) *RecordResourceModel {
+		return &RecordResourceModel{
+			ID:     types.StringValue("1"),
+			ZoneID: types.StringValue(strconv.Itoa(zone.ID)),
+			Active: types.BoolValue(true),
+			Type:   types.StringValue("A"),
+			Data:   address,
+			WaitForResolution: &RecordWaitForResolutionModel{
+				Timeout:  types.StringValue(timeout),
+				Interval: types.StringValue("10ms"),
+			},
+		}
+	}
+
+	r := newMockResource(t, NewRecordResource(), mock).(*RecordResource)
+
+	// The record resolves after a few stale answers
+	r.dnsServer = serveDNSAnswers(t, 3, [4]byte{192, 0, 2, 20})
+	if diags := r.waitForResolution(ctx, data("5s")); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	// The record never resolves
+	r.dnsServer = serveDNSAnswers(t, 1000, [4]byte{192, 0, 2, 20})
+	diags := r.waitForResolution(ctx, data("100ms"))
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), `{"address":"192.0.2.1"}`) {
+		t.Errorf("Expected an error naming the last answer, got %v", diags)
+	}
+
+	// Inactive records are not waited for
+	inactive := data("100ms")
+	inactive.Active = types.BoolValue(false)
+	if diags := r.waitForResolution(ctx, inactive); diags.HasError() {
+		t.Errorf("Expected inactive records to be skipped, got %v", diags)
+	}
+
+	// Invalid durations are reported on the attribute
+	invalid := data("soon")
+	if diags := r.waitForResolution(ctx, invalid); !diags.HasError() || diags[0].Summary() != "Invalid Duration" {
+		t.Errorf("Expected an invalid duration error, got %v", diags)
+	}
+}
+
+// TestMatchingAnswer tests comparing DNS answers to record data
+func TestMatchingAnswer(t *testing.T) {
+	answers := []dnslookup.Record{
+		{Type: "CNAME", Data: map[string]string{"name": "target.example.net."}},
+		{Type: "MX", Data: map[string]string{"priority": "10", "hostname": "Mail.Example.com."}},
+		{Type: "TXT", Data: map[string]string{"data": "v=spf1 -all"}},
+	}
+
+	tests := []struct {
+		name       string
+		recordType string
+		expected   []map[string]string
+		want       bool
+	}{
+		{"names without trailing dot or case", "MX", []map[string]string{{"priority": "10", "hostname": "mail.example.com"}}, true},
+		{"other priority", "MX", []map[string]string{{"priority": "20", "hostname": "mail.example.com"}}, false},
+		{"conditional data", "MX", []map[string]string{{"priority": "20", "hostname": "x"}, {"priority": "10", "hostname": "mail.example.com"}}, true},
+		{"other type", "CNAME", []map[string]string{{"priority": "10", "hostname": "mail.example.com"}}, false},
+		{"txt exact", "TXT", []map[string]string{{"data": "v=spf1 -all"}}, true},
+		{"txt case", "TXT", []map[string]string{{"data": "V=SPF1 -ALL"}}, false},
+		{"missing field", "MX", []map[string]string{{"preference": "10"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchingAnswer(answers, tt.recordType, tt.expected); got != tt.want {
+				t.Errorf("matchingAnswer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// recordTemplateVariables are the placeholders SnitchDNS substitutes in
+// record data when answering a query: the IP address of the resolver that
+// sent it, and the name it asked for
+var recordTemplateVariables = []string{"source_ip", "query_name"}
+
+// recordTemplatePlaceholder matches a placeholder such as {{ source_ip }}
+var recordTemplatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]*)\s*\}\}`)
+
+// hasRecordTemplate reports whether a record data value holds a placeholder
+func hasRecordTemplate(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+// validateRecordTemplate checks the placeholders of a record data value:
+// every placeholder must name a variable SnitchDNS substitutes, and every
+// {{ must be closed
+func validateRecordTemplate(value string) error {
+	for _, match := range recordTemplatePlaceholder.FindAllStringSubmatch(value, -1) {
+		if !slices.Contains(recordTemplateVariables, strings.ToLower(match[1])) {
+			return fmt.Errorf("unknown placeholder %q, expected one of: {{%s}}",
+				match[0], strings.Join(recordTemplateVariables, "}}, {{"))
+		}
+	}
+
+	rest := recordTemplatePlaceholder.ReplaceAllString(value, "")
+	if strings.Contains(rest, "{{") || strings.Contains(rest, "}}") {
+		return fmt.Errorf("unbalanced braces in %q, placeholders are written as {{source_ip}}", value)
+	}
+	return nil
+}
+
+// canonicalRecordTemplate returns a record data value with its placeholders
+// written the way SnitchDNS stores them, such as {{source_ip}} for
+// {{ Source_IP }}
+func canonicalRecordTemplate(value string) string {
+	return recordTemplatePlaceholder.ReplaceAllStringFunc(value, func(placeholder string) string {
+		name := recordTemplatePlaceholder.FindStringSubmatch(placeholder)[1]
+		return "{{" + strings.ToLower(name) + "}}"
+	})
+}
+
+// recordTemplatesEquivalent reports whether record data read from the
+// server matches the prior data once placeholders are canonicalized
+func recordTemplatesEquivalent(ctx context.Context, prior, current RecordDataValue) bool {
+	if prior.IsNull() || prior.IsUnknown() || current.IsNull() || current.IsUnknown() {
+		return false
+	}
+
+	var previous, actual map[string]string
+	if prior.ElementsAs(ctx, &previous, false).HasError() || current.ElementsAs(ctx, &actual, false).HasError() {
+		return false
+	}
+	for key, value := range previous {
+		previous[key] = canonicalRecordTemplate(value)
+	}
+	for key, value := range actual {
+		actual[key] = canonicalRecordTemplate(value)
+	}
+	return recordDataEquivalent(previous, actual)
+}
+
+// recordDataHasTemplate reports whether any field of record data holds a
+// placeholder
+func recordDataHasTemplate(data RecordDataValue) bool {
+	for _, value := range recordDataStrings(data) {
+		if hasRecordTemplate(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordTemplateValidator checks the placeholders in the data and
+// conditional_data of records setting enable_templates = true
+type recordTemplateValidator struct{}
+
+var _ resource.ConfigValidator = recordTemplateValidator{}
+
+// Description describes the validation in plain text formatting.
+func (v recordTemplateValidator) Description(_ context.Context) string {
+	return "placeholders in data and conditional_data must name a supported variable when enable_templates = true"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v recordTemplateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation. Unknown values are not checked.
+func (v recordTemplateValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var enabled types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("enable_templates"), &enabled)...)
+	if resp.Diagnostics.HasError() || !enabled.ValueBool() {
+		return
+	}
+
+	for _, name := range []string{"data", "conditional_data"} {
+		var data RecordDataValue
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for key, element := range data.Elements() {
+			value, ok := element.(types.String)
+			if !ok || value.IsNull() || value.IsUnknown() {
+				continue
+			}
+			if err := validateRecordTemplate(value.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root(name).AtMapKey(key), "Invalid Record Template", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestValidateRecordTemplate tests the accepted placeholder syntax
+func TestValidateRecordTemplate(t *testing.T) {
+	tests := map[string]bool{
+		"{{source_ip}}":                     true,
+		"v=canary ip={{ source_ip }}":       true,
+		"{{Query_Name}} from {{source_ip}}": true,
+		"no placeholder":                    true,
+		"{{resolver}}":                      false,
+		"{{}}":                              false,
+		"{{source_ip":                       false,
+		"source_ip}}":                       false,
+		"{{source ip}}":                     false,
+	}
+	for value, valid := range tests {
+		t.Run(value, func(t *testing.T) {
+			if err := validateRecordTemplate(value); (err == nil) != valid {
+				t.Errorf("Expected valid %t, got %v", valid, err)
+			}
+		})
+	}
+
+	if got := canonicalRecordTemplate("ip={{ Source_IP }} name={{query_name}}"); got != "ip={{source_ip}} name={{query_name}}" {
+		t.Errorf("Unexpected canonical template %q", got)
+	}
+}
+
+// TestRecordResource_MockTemplates tests that a templated A record only
+// passes plan validation with enable_templates, and that the server
+// rewriting its placeholders is not drift
+func TestRecordResource_MockTemplates(t *testing.T) {
+	ctx := context.Background()
+
+	for _, enabled := range []bool{true, false} {
+		t.Run(strconv.FormatBool(enabled), func(t *testing.T) {
+			mock := snitchdnsmock.New()
+			zone := mock.AddZone(snitchdns.Zone{Domain: "example.com", Active: true})
+			r := newMockResource(t, NewRecordResource(), mock)
+			r.(*RecordResource).validator = newRequestValidator()
+
+			data, diags := NewRecordDataValue(ctx, map[string]string{"address": "{{ Source_IP }}"})
+			if diags.HasError() {
+				t.Fatalf("Unexpected error: %v", diags)
+			}
+			plan := mockPlan(t, r, map[string]attr.Value{
+				"zone_id":          types.StringValue(strconv.Itoa(zone.ID)),
+				"active":           types.BoolValue(true),
+				"cls":              types.StringValue("IN"),
+				"type":             types.StringValue("A"),
+				"ttl":              types.Int64Value(300),
+				"data":             data,
+				"enable_templates": types.BoolValue(enabled),
+			})
+
+			planResp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config(plan),
+				Plan:   plan,
+			}, &planResp)
+			if planResp.Diagnostics.HasError() == enabled {
+				t.Fatalf("Expected a plan error %t, got %v", !enabled, planResp.Diagnostics)
+			}
+			if !enabled {
+				return
+			}
+
+			createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+			if createResp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+			}
+
+			// The server stores the placeholder canonicalized
+			records := mock.Records(zone.ID)
+			if _, err := mock.UpdateRecordWithContext(ctx, strconv.Itoa(zone.ID), strconv.Itoa(records[0].ID), snitchdns.UpdateRecordRequest{
+				Data: map[string]interface{}{"address": "{{source_ip}}"},
+			}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			readResp := fwresource.ReadResponse{State: createResp.State}
+			newMockResource(t, NewRecordResource(), mock).Read(ctx, fwresource.ReadRequest{State: createResp.State}, &readResp)
+			if readResp.Diagnostics.HasError() || readResp.Diagnostics.WarningsCount() != 0 {
+				t.Fatalf("Expected no drift, got %v", readResp.Diagnostics)
+			}
+			var read RecordDataValue
+			readResp.State.GetAttribute(ctx, path.Root("data"), &read)
+			if got := recordDataStrings(read)["address"]; got != "{{ Source_IP }}" {
+				t.Errorf("Expected the configured template to be kept, got %q", got)
+			}
+		})
+	}
+}
+
+// TestRecordTemplateValidator tests that unknown placeholders fail
+// validation only with enable_templates
+func TestRecordTemplateValidator(t *testing.T) {
+	ctx := context.Background()
+	r := newMockResource(t, NewRecordResource(), snitchdnsmock.New())
+
+	data, diags := NewRecordDataValue(ctx, map[string]string{"data": "{{resolver}}"})
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+	for _, enabled := range []bool{true, false} {
+		config := tfsdk.Config(mockPlan(t, r, map[string]attr.Value{
+			"type":             types.StringValue("TXT"),
+			"data":             data,
+			"enable_templates": types.BoolValue(enabled),
+		}))
+		resp := fwresource.ValidateConfigResponse{}
+		recordTemplateValidator{}.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: config}, &resp)
+		if resp.Diagnostics.HasError() != enabled {
+			t.Errorf("Expected an error %t with enable_templates = %t, got %v", enabled, enabled, resp.Diagnostics)
+		}
+	}
+}
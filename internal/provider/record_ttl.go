@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// minRecordTTL and maxRecordTTL bound the TTLs SnitchDNS accepts, a
+	// positive signed 32-bit number of seconds as in RFC 2181
+	minRecordTTL = 1
+	maxRecordTTL = 2147483647
+
+	// defaultRecordTTL is the TTL of records omitting ttl when the provider
+	// sets no default_record_ttl
+	defaultRecordTTL = 300
+)
+
+// recordTTLValidator checks that a TTL is accepted by SnitchDNS
+func recordTTLValidator() validator.Int64 {
+	return int64validator.Between(minRecordTTL, maxRecordTTL)
+}
+
+// planDefaultTTL plans the provider's default TTL for a record whose
+// configuration omits ttl. A zero defaultTTL stands for defaultRecordTTL.
+func planDefaultTTL(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, defaultTTL int64) {
+	var configured types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+
+	if defaultTTL == 0 {
+		defaultTTL = defaultRecordTTL
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ttl"), defaultTTL)...)
+}
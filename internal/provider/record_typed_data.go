@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// recordTypedDataBlocks maps the typed data blocks of snitchdns_record to
+// the record type they hold the data of
+var recordTypedDataBlocks = map[string]string{
+	"srv":   "SRV",
+	"naptr": "NAPTR",
+	"caa":   "CAA",
+	"soa":   "SOA",
+}
+
+// RecordSRVModel describes the srv block.
+type RecordSRVModel struct {
+	Priority types.Int64  `tfsdk:"priority"`
+	Weight   types.Int64  `tfsdk:"weight"`
+	Port     types.Int64  `tfsdk:"port"`
+	Target   types.String `tfsdk:"target"`
+}
+
+// RecordNAPTRModel describes the naptr block.
+type RecordNAPTRModel struct {
+	Order       types.Int64  `tfsdk:"order"`
+	Preference  types.Int64  `tfsdk:"preference"`
+	Flags       types.String `tfsdk:"flags"`
+	Service     types.String `tfsdk:"service"`
+	Regexp      types.String `tfsdk:"regexp"`
+	Replacement types.String `tfsdk:"replacement"`
+}
+
+// RecordCAAModel describes the caa block.
+type RecordCAAModel struct {
+	Flags types.Int64  `tfsdk:"flags"`
+	Tag   types.String `tfsdk:"tag"`
+	Value types.String `tfsdk:"value"`
+}
+
+// RecordSOAModel describes the soa block.
+type RecordSOAModel struct {
+	MName   types.String `tfsdk:"mname"`
+	RName   types.String `tfsdk:"rname"`
+	Serial  types.Int64  `tfsdk:"serial"`
+	Refresh types.Int64  `tfsdk:"refresh"`
+	Retry   types.Int64  `tfsdk:"retry"`
+	Expire  types.Int64  `tfsdk:"expire"`
+	Minimum types.Int64  `tfsdk:"minimum"`
+}
+
+// recordTypedDataSchemaBlocks returns the schema of the typed data blocks
+func recordTypedDataSchemaBlocks() map[string]schema.Block {
+	uint8Attribute := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Required:            true,
+			MarkdownDescription: description,
+			Validators:          []validator.Int64{int64validator.Between(0, 255)},
+		}
+	}
+	uint16Attribute := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Required:            true,
+			MarkdownDescription: description,
+			Validators:          []validator.Int64{int64validator.Between(0, 65535)},
+		}
+	}
+	uint32Attribute := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Required:            true,
+			MarkdownDescription: description,
+			Validators:          []validator.Int64{int64validator.Between(0, 4294967295)},
+		}
+	}
+	stringAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: description,
+		}
+	}
+	optionalStringAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(""),
+			MarkdownDescription: description,
+		}
+	}
+
+	return map[string]schema.Block{
+		"srv": schema.SingleNestedBlock{
+			MarkdownDescription: "Data of an `SRV` record, in place of `data`. The numbers are sent to SnitchDNS as integers.",
+			Attributes: map[string]schema.Attribute{
+				"priority": uint16Attribute("Priority of the target host, lower values first."),
+				"weight":   uint16Attribute("Relative weight of targets with the same priority."),
+				"port":     uint16Attribute("Port of the service on the target host."),
+				"target":   stringAttribute("Host name of the target."),
+			},
+		},
+		"naptr": schema.SingleNestedBlock{
+			MarkdownDescription: "Data of a `NAPTR` record, in place of `data`. The numbers are sent to SnitchDNS as integers.",
+			Attributes: map[string]schema.Attribute{
+				"order":       uint16Attribute("Order in which the records must be processed, lower values first."),
+				"preference":  uint16Attribute("Preference of records with the same order, lower values first."),
+				"flags":       optionalStringAttribute("Flags controlling the rewriting, e.g. `U`. Defaults to `\"\"`."),
+				"service":     optionalStringAttribute("Service parameters, e.g. `E2U+sip`. Defaults to `\"\"`."),
+				"regexp":      optionalStringAttribute("Substitution expression applied to the original string. Defaults to `\"\"`."),
+				"replacement": stringAttribute("Next domain name to query, or `.` when `regexp` is used."),
+			},
+		},
+		"caa": schema.SingleNestedBlock{
+			MarkdownDescription: "Data of a `CAA` record, in place of `data`. The flags are sent to SnitchDNS as an integer.",
+			Attributes: map[string]schema.Attribute{
+				"flags": uint8Attribute("Flags of the property, usually `0` or `128` (critical)."),
+				"tag": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "Property tag: `issue`, `issuewild` or `iodef`.",
+					Validators:          []validator.String{stringvalidator.OneOf("issue", "issuewild", "iodef")},
+				},
+				"value": stringAttribute("Value of the property, e.g. the domain of a certificate authority."),
+			},
+		},
+		"soa": schema.SingleNestedBlock{
+			MarkdownDescription: "Data of an `SOA` record, in place of `data`. The serial and timers are sent to SnitchDNS as integers.",
+			Attributes: map[string]schema.Attribute{
+				"mname":   stringAttribute("Host name of the primary name server."),
+				"rname":   stringAttribute("Mailbox of the zone's administrator, as a host name."),
+				"serial":  uint32Attribute("Serial number of the zone."),
+				"refresh": uint32Attribute("Seconds after which secondaries refresh the zone."),
+				"retry":   uint32Attribute("Seconds after which secondaries retry a failed refresh."),
+				"expire":  uint32Attribute("Seconds after which secondaries stop answering for the zone."),
+				"minimum": uint32Attribute("TTL in seconds of negative answers."),
+			},
+		},
+	}
+}
+
+// recordTypedDataConfigValidators checks that a record sets either data or
+// one typed data block, and that the block matches the record type
+func recordTypedDataConfigValidators() []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("data"),
+			path.MatchRoot("srv"),
+			path.MatchRoot("naptr"),
+			path.MatchRoot("caa"),
+			path.MatchRoot("soa"),
+		),
+		recordTypedDataTypeValidator{},
+	}
+}
+
+// recordTypedDataTypeValidator checks that a typed data block is only set
+// on records of its type
+type recordTypedDataTypeValidator struct{}
+
+var _ resource.ConfigValidator = recordTypedDataTypeValidator{}
+
+// Description describes the validation in plain text formatting.
+func (v recordTypedDataTypeValidator) Description(_ context.Context) string {
+	return "srv, naptr, caa and soa require a record of their type"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v recordTypedDataTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation. Nothing is checked while type
+// is unknown.
+func (v recordTypedDataTypeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var recordType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	if resp.Diagnostics.HasError() || recordType.IsUnknown() || recordType.IsNull() {
+		return
+	}
+
+	for name, blockType := range recordTypedDataBlocks {
+		var block types.Object
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &block)...)
+		if block.IsNull() || strings.EqualFold(recordType.ValueString(), blockType) {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Typed data of another record type",
+			name+" holds the data of "+blockType+" records, but the record type is "+recordType.ValueString()+". Use data, or the block of the record type.",
+		)
+	}
+}
+
+// typedData returns the data of the model's typed data block, or nil when
+// no block is set. ok is false while a value of the block is unknown.
+func (m *RecordResourceModel) typedData() (data snitchdns.RecordData, ok bool) {
+	known := func(values ...interface{ IsUnknown() bool }) bool {
+		for _, value := range values {
+			if value.IsUnknown() {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case m.SRV != nil:
+		b := m.SRV
+		return snitchdns.SRVRecordData{
+			Priority: uint16(b.Priority.ValueInt64()),
+			Weight:   uint16(b.Weight.ValueInt64()),
+			Port:     uint16(b.Port.ValueInt64()),
+			Target:   b.Target.ValueString(),
+		}, known(b.Priority, b.Weight, b.Port, b.Target)
+	case m.NAPTR != nil:
+		b := m.NAPTR
+		return snitchdns.NAPTRRecordData{
+			Order:       uint16(b.Order.ValueInt64()),
+			Preference:  uint16(b.Preference.ValueInt64()),
+			Flags:       b.Flags.ValueString(),
+			Service:     b.Service.ValueString(),
+			Regexp:      b.Regexp.ValueString(),
+			Replacement: b.Replacement.ValueString(),
+		}, known(b.Order, b.Preference, b.Flags, b.Service, b.Regexp, b.Replacement)
+	case m.CAA != nil:
+		b := m.CAA
+		return snitchdns.CAARecordData{
+			Flags: uint8(b.Flags.ValueInt64()),
+			Tag:   b.Tag.ValueString(),
+			Value: b.Value.ValueString(),
+		}, known(b.Flags, b.Tag, b.Value)
+	case m.SOA != nil:
+		b := m.SOA
+		return snitchdns.SOARecordData{
+			MName:   b.MName.ValueString(),
+			RName:   b.RName.ValueString(),
+			Serial:  uint32(b.Serial.ValueInt64()),
+			Refresh: uint32(b.Refresh.ValueInt64()),
+			Retry:   uint32(b.Retry.ValueInt64()),
+			Expire:  uint32(b.Expire.ValueInt64()),
+			Minimum: uint32(b.Minimum.ValueInt64()),
+		}, known(b.MName, b.RName, b.Serial, b.Refresh, b.Retry, b.Expire, b.Minimum)
+	}
+	return nil, true
+}
+
+// setTypedData copies typed data read from the server into the model's
+// block of its type. Blocks the configuration does not set stay null.
+func (m *RecordResourceModel) setTypedData(data snitchdns.RecordData) {
+	switch d := data.(type) {
+	case *snitchdns.SRVRecordData:
+		if m.SRV != nil {
+			m.SRV = &RecordSRVModel{
+				Priority: types.Int64Value(int64(d.Priority)),
+				Weight:   types.Int64Value(int64(d.Weight)),
+				Port:     types.Int64Value(int64(d.Port)),
+				Target:   types.StringValue(d.Target),
+			}
+		}
+	case *snitchdns.NAPTRRecordData:
+		if m.NAPTR != nil {
+			m.NAPTR = &RecordNAPTRModel{
+				Order:       types.Int64Value(int64(d.Order)),
+				Preference:  types.Int64Value(int64(d.Preference)),
+				Flags:       types.StringValue(d.Flags),
+				Service:     types.StringValue(d.Service),
+				Regexp:      types.StringValue(d.Regexp),
+				Replacement: types.StringValue(d.Replacement),
+			}
+		}
+	case *snitchdns.CAARecordData:
+		if m.CAA != nil {
+			m.CAA = &RecordCAAModel{
+				Flags: types.Int64Value(int64(d.Flags)),
+				Tag:   types.StringValue(d.Tag),
+				Value: types.StringValue(d.Value),
+			}
+		}
+	case *snitchdns.SOARecordData:
+		if m.SOA != nil {
+			m.SOA = &RecordSOAModel{
+				MName:   types.StringValue(d.MName),
+				RName:   types.StringValue(d.RName),
+				Serial:  types.Int64Value(int64(d.Serial)),
+				Refresh: types.Int64Value(int64(d.Refresh)),
+				Retry:   types.Int64Value(int64(d.Retry)),
+				Expire:  types.Int64Value(int64(d.Expire)),
+				Minimum: types.Int64Value(int64(d.Minimum)),
+			}
+		}
+	}
+}
+
+// planTypedData plans data from the typed data block of a record that sets
+// one, so data is known at plan time and checked like configured data
+func planTypedData(ctx context.Context, resp *resource.ModifyPlanResponse) {
+	var plan RecordResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	typed, ok := plan.typedData()
+	switch {
+	case typed == nil:
+		return
+	case !ok:
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("data"), NewRecordDataUnknown())...)
+		return
+	}
+
+	data, diags := NewRecordDataValue(ctx, typedDataStrings(typed))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("data"), data)...)
+}
+
+// typedDataStrings converts typed data to the strings stored in data
+func typedDataStrings(typed snitchdns.RecordData) map[string]string {
+	fields := typed.Fields()
+	result := make(map[string]string, len(fields))
+	for key, value := range fields {
+		result[key] = recordDataString(value)
+	}
+	return result
+}
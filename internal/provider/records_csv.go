@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// recordsCSVColumns is the header written when rendering records as CSV. It
+// matches the record columns of the SnitchDNS export.
+var recordsCSVColumns = []string{
+	"type", "cls", "ttl", "active", "data",
+	"is_conditional", "conditional_limit", "conditional_reset", "conditional_data",
+}
+
+// recordsCSVIgnoredColumns are export columns that describe the zone or the
+// server-side state of a record rather than its desired configuration
+var recordsCSVIgnoredColumns = map[string]bool{
+	"id":                true,
+	"zone_id":           true,
+	"domain":            true,
+	"conditional_count": true,
+}
+
+// parseRecordsCSV parses records in the SnitchDNS CSV export format. Columns
+// are matched by header name, case-insensitively and ignoring an "r_" prefix;
+// only type and data are required. Zone columns and server-side counters in
+// an export are ignored so that an export can be used unchanged.
+func parseRecordsCSV(content string) ([]zoneRecord, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV content is empty, a header row is required")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), "r_")
+		if name == "class" {
+			name = "cls"
+		}
+		if recordsCSVIgnoredColumns[name] || strings.HasPrefix(name, "d_") {
+			continue
+		}
+		if !isRecordsCSVColumn(name) {
+			return nil, fmt.Errorf("unknown CSV column %q", header[i])
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"type", "data"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header is missing the %q column", required)
+		}
+	}
+
+	var records []zoneRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		record, err := parseRecordsCSVRow(columns, row)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// parseRecordsCSVRow converts a CSV row to a record, applying the defaults of
+// snitchdns_record for missing or empty columns
+func parseRecordsCSVRow(columns map[string]int, row []string) (zoneRecord, error) {
+	value := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	record := zoneRecord{
+		Type:   strings.ToUpper(value("type")),
+		Class:  "IN",
+		TTL:    300,
+		Active: true,
+	}
+	if record.Type == "" {
+		return record, fmt.Errorf("type must not be empty")
+	}
+	if cls := value("cls"); cls != "" {
+		record.Class = strings.ToUpper(cls)
+	}
+
+	var err error
+	if ttl := value("ttl"); ttl != "" {
+		if record.TTL, err = strconv.Atoi(ttl); err != nil || record.TTL < 1 {
+			return record, fmt.Errorf("invalid ttl %q", ttl)
+		}
+	}
+	if limit := value("conditional_limit"); limit != "" {
+		if record.ConditionalLimit, err = strconv.Atoi(limit); err != nil {
+			return record, fmt.Errorf("invalid conditional_limit %q", limit)
+		}
+	}
+
+	for name, target := range map[string]*bool{
+		"active":            &record.Active,
+		"is_conditional":    &record.IsConditional,
+		"conditional_reset": &record.ConditionalReset,
+	} {
+		if raw := value(name); raw != "" {
+			if *target, err = strconv.ParseBool(raw); err != nil {
+				return record, fmt.Errorf("invalid %s %q", name, raw)
+			}
+		}
+	}
+
+	if record.Data, err = parseRecordsCSVData(value("data")); err != nil {
+		return record, fmt.Errorf("invalid data: %w", err)
+	}
+	if len(record.Data) == 0 {
+		return record, fmt.Errorf("data must not be empty")
+	}
+	if record.ConditionalData, err = parseRecordsCSVData(value("conditional_data")); err != nil {
+		return record, fmt.Errorf("invalid conditional_data: %w", err)
+	}
+
+	return record, nil
+}
+
+// parseRecordsCSVData decodes a JSON object column
+func parseRecordsCSVData(raw string) (map[string]string, error) {
+	if raw == "" || raw == "{}" {
+		return map[string]string{}, nil
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, err
+	}
+	return stringifyRecordData(data), nil
+}
+
+// renderRecordsCSV renders records as CSV with a fixed header and the rows
+// sorted, so the same set of records always renders identically
+func renderRecordsCSV(records []zoneRecord) string {
+	rows := make([][]string, 0, len(records))
+	for _, record := range records {
+		data, _ := json.Marshal(record.Data)
+		conditionalData, _ := json.Marshal(record.ConditionalData)
+
+		rows = append(rows, []string{
+			record.Type,
+			record.Class,
+			strconv.Itoa(record.TTL),
+			strconv.FormatBool(record.Active),
+			string(data),
+			strconv.FormatBool(record.IsConditional),
+			strconv.Itoa(record.ConditionalLimit),
+			strconv.FormatBool(record.ConditionalReset),
+			string(conditionalData),
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return strings.Join(rows[i], ",") < strings.Join(rows[j], ",")
+	})
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write(recordsCSVColumns)
+	_ = writer.WriteAll(rows)
+	return buf.String()
+}
+
+// isRecordsCSVColumn reports whether name is a known record column
+func isRecordsCSVColumn(name string) bool {
+	for _, column := range recordsCSVColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+// stringifyRecordData converts API data values to the string values used in
+// configuration
+func stringifyRecordData(data map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(data))
+	for key, value := range data {
+		result[key] = recordDataString(value)
+	}
+	return result
+}
+
+// stringMapsEqual reports whether two string maps hold the same entries
+func stringMapsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
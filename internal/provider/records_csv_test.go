@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseRecordsCSV tests parsing an export with defaults and ignored columns
+func TestParseRecordsCSV(t *testing.T) {
+	content := `domain,r_type,r_ttl,r_data,r_conditional_count
+example.com,A,60,"{""address"": ""10.0.0.1""}",4
+example.com,mx,,"{""hostname"": ""mail.example.com"", ""priority"": 10}",0
+`
+
+	records, err := parseRecordsCSV(content)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	if records[0].Type != "A" || records[0].TTL != 60 || records[0].Data["address"] != "10.0.0.1" {
+		t.Errorf("Unexpected first record: %+v", records[0])
+	}
+	if records[1].Type != "MX" || records[1].Class != "IN" || records[1].TTL != 300 || !records[1].Active {
+		t.Errorf("Expected defaults on second record, got %+v", records[1])
+	}
+	if records[1].Data["priority"] != "10" {
+		t.Errorf("Expected numeric data to be stringified, got %q", records[1].Data["priority"])
+	}
+}
+
+// TestParseRecordsCSV_Errors tests that invalid documents are rejected with the failing line
+func TestParseRecordsCSV_Errors(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    string
+	}{
+		"empty":          {"", "header row is required"},
+		"missing data":   {"type,ttl\nA,60\n", `missing the "data" column`},
+		"unknown column": {"type,data,weight\n", `unknown CSV column "weight"`},
+		"invalid ttl":    {"type,ttl,data\nA,abc,\"{}\"\n", "line 2: invalid ttl"},
+		"invalid json":   {"type,data\nA,{address\n", "line 2: invalid data"},
+		"empty data":     {"type,data\nA,{}\n", "line 2: data must not be empty"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseRecordsCSV(tt.content)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestRenderRecordsCSV tests that rendering round-trips and ignores record order
+func TestRenderRecordsCSV(t *testing.T) {
+	records := []zoneRecord{
+		{Type: "TXT", Class: "IN", TTL: 300, Active: true, Data: map[string]string{"data": "a,b"}},
+		{Type: "A", Class: "IN", TTL: 60, Active: false, Data: map[string]string{"address": "10.0.0.1"}},
+	}
+
+	rendered := renderRecordsCSV(records)
+	if rendered != renderRecordsCSV([]zoneRecord{records[1], records[0]}) {
+		t.Error("Expected rendering to be independent of record order")
+	}
+
+	parsed, err := parseRecordsCSV(rendered)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing rendered CSV: %v", err)
+	}
+	if len(parsed) != 2 || parsed[0].Type != "A" || parsed[0].Active || parsed[1].Data["data"] != "a,b" {
+		t.Errorf("Unexpected round trip: %+v", parsed)
+	}
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/internal/apischema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// requestValidator checks outgoing request bodies against the embedded API
+// schema, so malformed requests fail with a precise error instead of the
+// server's often vague validation message. Its fields are set during provider
+// configuration, before the client is shared.
+type requestValidator struct {
+	schema *apischema.Schema
+
+	// version is the detected server version, empty when unknown
+	version string
+
+	// recordData translates the server's record data field names back to
+	// the canonical names the schema uses
+	recordData *recordDataMapper
+}
+
+// newRequestValidator returns a validator using the embedded API schema
+func newRequestValidator() *requestValidator {
+	return &requestValidator{schema: apischema.Load()}
+}
+
+// Validate implements snitchdns.RequestValidator. Routes the schema does not
+// describe are not checked, and neither is record data holding template
+// placeholders.
+func (v *requestValidator) Validate(method, path string, body []byte) error {
+	endpoint, ok := v.schema.Endpoint(method, path)
+	if !ok {
+		return nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return fmt.Errorf("request body is not a JSON object: %w", err)
+	}
+
+	if err := endpoint.ValidateBody(fields, v.version); err != nil {
+		return err
+	}
+
+	if !endpoint.Record {
+		return nil
+	}
+
+	// Record updates only carry the type when it changes, so their data
+	// cannot be checked without it
+	recordType, _ := fields["type"].(string)
+	if recordType == "" {
+		return nil
+	}
+
+	for _, name := range []string{"data", "conditional_data"} {
+		data, _ := fields[name].(map[string]interface{})
+		if len(data) == 0 || recordFieldsHaveTemplate(data) {
+			continue
+		}
+		if err := v.schema.ValidateRecordData(recordType, v.recordData.FromServer(recordType, data)); err != nil {
+			return fmt.Errorf("field %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ValidatePlannedRecordData checks record data from a plan against the
+// fields of its type, so malformed data fails at plan time. A nil validator,
+// used when schema validation is disabled, accepts everything. Unknown values
+// are not checked here; the request is validated again at apply.
+func (v *requestValidator) ValidatePlannedRecordData(recordType types.String, data RecordDataValue) error {
+	if v == nil || recordType.IsNull() || recordType.IsUnknown() || data.IsNull() || data.IsUnknown() {
+		return nil
+	}
+
+	fields := make(map[string]interface{}, len(data.Elements()))
+	for key, element := range data.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() {
+			return nil
+		}
+		if value.IsNull() {
+			continue
+		}
+		fields[key] = value.ValueString()
+	}
+
+	return v.schema.ValidateRecordData(recordType.ValueString(), fields)
+}
+
+// recordFieldsHaveTemplate reports whether a field of record data in a
+// request holds a placeholder, whose value is only known when SnitchDNS
+// answers a query and so cannot be checked against the field's format
+func recordFieldsHaveTemplate(data map[string]interface{}) bool {
+	for _, value := range data {
+		if s, ok := value.(string); ok && hasRecordTemplate(s) {
+			return true
+		}
+	}
+	return false
+}
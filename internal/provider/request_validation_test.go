@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestRequestValidator_LegacyFieldNames tests that record data is validated in canonical names for older servers
+func TestRequestValidator_LegacyFieldNames(t *testing.T) {
+	validator := newRequestValidator()
+	validator.version = "1.2.0"
+	validator.recordData = newRecordDataMapper("1.2.0")
+
+	body := `{"class": "IN", "type": "MX", "ttl": 300, "active": true,
+		"data": {"hostname": "mail.example.com.", "preference": "10"},
+		"is_conditional": false, "conditional_count": 0, "conditional_limit": 0,
+		"conditional_reset": false, "conditional_data": null}`
+
+	if err := validator.Validate("POST", "/zones/1/records", []byte(body)); err != nil {
+		t.Errorf("Expected legacy MX data to be accepted on 1.2.0, got %v", err)
+	}
+
+	body = strings.Replace(body, `"preference": "10"`, `"preference": "ten"`, 1)
+	err := validator.Validate("POST", "/zones/1/records", []byte(body))
+	if err == nil || !strings.Contains(err.Error(), `field "priority"`) {
+		t.Errorf("Expected the invalid priority to be reported by its canonical name, got %v", err)
+	}
+}
+
+// TestRequestValidator_UndescribedRoutes tests that routes missing from the schema are not checked
+func TestRequestValidator_UndescribedRoutes(t *testing.T) {
+	validator := newRequestValidator()
+
+	if err := validator.Validate("POST", "/some/future/route", []byte(`{"anything": 1}`)); err != nil {
+		t.Errorf("Expected undescribed routes to pass, got %v", err)
+	}
+}
+
+// TestRequestValidator_PlannedRecordData tests plan-time validation of record data per type
+func TestRequestValidator_PlannedRecordData(t *testing.T) {
+	validator := newRequestValidator()
+
+	dataValue := func(elements map[string]attr.Value) RecordDataValue {
+		return RecordDataValue{MapValue: types.MapValueMust(types.StringType, elements)}
+	}
+
+	tests := []struct {
+		name       string
+		recordType types.String
+		data       RecordDataValue
+		wantErr    string
+	}{
+		{"valid A", types.StringValue("A"), dataValue(map[string]attr.Value{"address": types.StringValue("10.0.0.1")}), ""},
+		{"IPv6 in A", types.StringValue("A"), dataValue(map[string]attr.Value{"address": types.StringValue("::1")}), "not an IPv4 address"},
+		{"MX priority", types.StringValue("MX"), dataValue(map[string]attr.Value{"hostname": types.StringValue("mail.example.com"), "priority": types.StringValue("high")}), `field "priority"`},
+		{"SRV missing port", types.StringValue("SRV"), dataValue(map[string]attr.Value{"target": types.StringValue("sip.example.com"), "priority": types.StringValue("10"), "weight": types.StringValue("5")}), `missing required field "port"`},
+		{"CAA flags", types.StringValue("CAA"), dataValue(map[string]attr.Value{"flags": types.StringValue("256"), "tag": types.StringValue("issue"), "value": types.StringValue("ca.example.com")}), `field "flags"`},
+		{"unknown field", types.StringValue("CNAME"), dataValue(map[string]attr.Value{"name": types.StringValue("www.example.com"), "target": types.StringValue("www.example.com")}), `unknown field "target"`},
+		{"unknown value", types.StringValue("A"), dataValue(map[string]attr.Value{"address": types.StringUnknown()}), ""},
+		{"unknown type", types.StringUnknown(), dataValue(map[string]attr.Value{"address": types.StringValue("bogus")}), ""},
+		{"undescribed type", types.StringValue("HINFO"), dataValue(map[string]attr.Value{"anything": types.StringValue("x")}), ""},
+		{"null data", types.StringValue("A"), NewRecordDataNull(), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidatePlannedRecordData(tt.recordType, tt.data)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	var disabled *requestValidator
+	bogus := dataValue(map[string]attr.Value{"address": types.StringValue("bogus")})
+	if err := disabled.ValidatePlannedRecordData(types.StringValue("A"), bogus); err != nil {
+		t.Errorf("Expected a disabled validator to accept everything, got %v", err)
+	}
+}
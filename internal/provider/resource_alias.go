@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AliasResource{}
+var _ resource.ResourceWithImportState = &AliasResource{}
+
+// NewAliasResource creates a new Alias resource.
+func NewAliasResource() resource.Resource {
+	return &AliasResource{}
+}
+
+// AliasResource defines the resource implementation.
+type AliasResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// AliasResourceModel describes the resource data model.
+type AliasResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	IP   types.String `tfsdk:"ip"`
+	Name types.String `tfsdk:"name"`
+}
+
+// Metadata sets the resource type name.
+func (r *AliasResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alias"
+}
+
+// Schema defines the resource schema.
+func (r *AliasResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an alias, a name SnitchDNS shows instead of a source IP address in the query logs and search results, " +
+			"such as the team behind an engagement's egress address.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the alias.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Source IP address the alias names, such as `198.51.100.7`, in canonical form.",
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name shown instead of the IP address.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *AliasResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// CRUD methods are implemented in resource_alias_impl.go
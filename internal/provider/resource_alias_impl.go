@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *AliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create alias")
+		return
+	}
+
+	var data AliasResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alias, err := r.client.CreateAlias(ctx, snitchdns.AliasRequest{
+		IP:   data.IP.ValueString(),
+		Name: data.Name.ValueString(),
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error creating alias",
+			fmt.Sprintf("Could not create alias %q for %s", data.Name.ValueString(), data.IP.ValueString()), err)
+		return
+	}
+
+	tflog.Debug(ctx, "Created alias", map[string]any{
+		"alias_id": alias.ID,
+		"ip":       alias.IP,
+	})
+
+	data.setFromAlias(alias)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *AliasResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data AliasResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alias, err := r.client.GetAlias(ctx, data.ID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "Alias not found, removing from state", map[string]any{
+				"alias_id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading alias",
+			fmt.Sprintf("Could not read alias ID %s", data.ID.ValueString()), err)
+		return
+	}
+
+	data.setFromAlias(alias)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *AliasResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update alias")
+		return
+	}
+
+	var data AliasResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alias, err := r.client.UpdateAlias(ctx, data.ID.ValueString(), snitchdns.AliasRequest{
+		IP:   data.IP.ValueString(),
+		Name: data.Name.ValueString(),
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating alias",
+			fmt.Sprintf("Could not update alias ID %s", data.ID.ValueString()), err)
+		return
+	}
+
+	data.setFromAlias(alias)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic
+func (r *AliasResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete alias")
+		return
+	}
+
+	var data AliasResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteAlias(ctx, data.ID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Alias is already deleted
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting alias",
+			fmt.Sprintf("Could not delete alias ID %s", data.ID.ValueString()), err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic. The import ID is the
+// alias ID.
+func (r *AliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import alias")
+		return
+	}
+
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected a numeric alias ID, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// setFromAlias maps the alias to the data model
+func (m *AliasResourceModel) setFromAlias(alias *snitchdns.Alias) {
+	m.ID = types.StringValue(strconv.Itoa(alias.ID))
+	m.IP = types.StringValue(alias.IP)
+	m.Name = types.StringValue(alias.Name)
+}
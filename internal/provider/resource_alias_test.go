@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccAliasResource tests creating, renaming and importing an alias
+func TestAccAliasResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAliasResourceConfig(container, "198.51.100.7", "red-team"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("snitchdns_alias.test", "id"),
+					resource.TestCheckResourceAttr("snitchdns_alias.test", "ip", "198.51.100.7"),
+					resource.TestCheckResourceAttr("snitchdns_alias.test", "name", "red-team"),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccAliasResourceConfig(container, "2001:db8::7", "blue-team"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_alias.test", "ip", "2001:db8::7"),
+					resource.TestCheckResourceAttr("snitchdns_alias.test", "name", "blue-team"),
+				),
+			},
+		},
+	})
+}
+
+// testAccAliasResourceConfig generates HCL configuration for alias testing
+func testAccAliasResourceConfig(container *testcontainer.SnitchDNSContainer, ip, name string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_alias" "test" {
+  ip   = %[3]q
+  name = %[4]q
+}
+`, container.GetAPIEndpoint(), container.APIKey, ip, name)
+}
+
+func TestAliasResource_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := newMockResource(t, NewAliasResource(), mock)
+
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"ip":   types.StringValue("198.51.100.7"),
+		"name": types.StringValue("red-team"),
+	})
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	var created AliasResourceModel
+	createResp.State.Get(ctx, &created)
+	if aliases, _ := mock.ListAliases(ctx); len(aliases) != 1 || created.ID.ValueString() != fmt.Sprint(aliases[0].ID) {
+		t.Fatalf("Expected the alias in state, got %+v for %+v", created, aliases)
+	}
+
+	// Both attributes are updated in place
+	renamed := createResp.State
+	renamed.SetAttribute(ctx, path.Root("ip"), "2001:db8::7")
+	renamed.SetAttribute(ctx, path.Root("name"), "blue-team")
+	updateResp := fwresource.UpdateResponse{State: renamed}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: tfsdk.Plan{Schema: renamed.Schema, Raw: renamed.Raw}}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected update error: %v", updateResp.Diagnostics)
+	}
+	alias, err := mock.GetAlias(ctx, created.ID.ValueString())
+	if err != nil || alias.IP != "2001:db8::7" || alias.Name != "blue-team" {
+		t.Fatalf("Expected the updated alias, got %+v, %v", alias, err)
+	}
+
+	// An alias deleted outside of Terraform is removed from state
+	if err := mock.DeleteAlias(ctx, created.ID.ValueString()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	readResp := fwresource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("Expected the deleted alias to be removed from state")
+	}
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create API key")
+		return
+	}
+
+	var data APIKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	enabled := data.Enabled.ValueBool()
+	createReq := snitchdns.CreateAPIKeyRequest{
+		Name:    data.Name.ValueString(),
+		Enabled: &enabled,
+	}
+
+	// Create the key for another user when an owner is configured
+	var owner *snitchdns.User
+	if !data.Owner.IsNull() {
+		var err error
+		owner, err = r.users.ByUsername(ctx, data.Owner.ValueString())
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error resolving API key owner",
+				fmt.Sprintf("Could not resolve username %q", data.Owner.ValueString()), err)
+			return
+		}
+		createReq.UserID = owner.ID
+	}
+
+	key, err := r.client.CreateAPIKey(ctx, createReq)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error creating API key",
+			fmt.Sprintf("Could not create API key %q", data.Name.ValueString()), err)
+		return
+	}
+
+	tflog.Debug(ctx, "Created API key", map[string]any{
+		"api_key_id": key.ID,
+		"user_id":    key.UserID,
+	})
+
+	data.setFromAPIKey(key)
+	data.Key = types.StringValue(key.Key)
+
+	// Servers that ignore user_id create the key for the API key's user.
+	// The key is kept in state so the next apply replaces it.
+	if owner != nil && key.UserID != owner.ID {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("owner"),
+			"API key created for a different owner",
+			fmt.Sprintf("API key %q was created for user ID %d instead of %s (user ID %d). "+
+				"The provider's API key must belong to an admin, and the SnitchDNS server must support creating keys for other users.",
+				key.Name, key.UserID, owner.Username, owner.ID),
+		)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic. The secret is only returned on
+// creation, so the key in state is kept.
+func (r *APIKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data APIKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.client.GetAPIKey(ctx, data.ID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "API key not found, removing from state", map[string]any{
+				"api_key_id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading API key",
+			fmt.Sprintf("Could not read API key ID %s", data.ID.ValueString()), err)
+		return
+	}
+
+	data.setFromAPIKey(key)
+	resp.Diagnostics.Append(r.readOwner(ctx, &data)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update API key")
+		return
+	}
+
+	var data APIKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	enabled := data.Enabled.ValueBool()
+	key, err := r.client.UpdateAPIKey(ctx, data.ID.ValueString(), snitchdns.UpdateAPIKeyRequest{
+		Name:    data.Name.ValueString(),
+		Enabled: &enabled,
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating API key",
+			fmt.Sprintf("Could not update API key ID %s", data.ID.ValueString()), err)
+		return
+	}
+
+	data.setFromAPIKey(key)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic, revoking the key
+func (r *APIKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete API key")
+		return
+	}
+
+	var data APIKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteAPIKey(ctx, data.ID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Key is already revoked
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting API key",
+			fmt.Sprintf("Could not revoke API key ID %s", data.ID.ValueString()), err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic. The import ID is the
+// key ID; the secret of an imported key is unknown to the provider.
+func (r *APIKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import API key")
+		return
+	}
+
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected a numeric API key ID, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// readOwner sets owner from the key's user ID when the key is managed by
+// username. The configured spelling is kept when it differs only in case.
+func (r *APIKeyResource) readOwner(ctx context.Context, data *APIKeyResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.Owner.IsNull() {
+		return diags
+	}
+
+	owner, err := r.users.ByID(ctx, int(data.UserID.ValueInt64()))
+	if err != nil {
+		diags.AddWarning("API key owner unavailable",
+			fmt.Sprintf("Could not resolve the owner of API key ID %s, keeping owner %q: %s",
+				data.ID.ValueString(), data.Owner.ValueString(), err))
+		return diags
+	}
+
+	if !strings.EqualFold(owner.Username, data.Owner.ValueString()) {
+		data.Owner = types.StringValue(owner.Username)
+	}
+	return diags
+}
+
+// setFromAPIKey maps the API key to the data model, except for the secret
+func (m *APIKeyResourceModel) setFromAPIKey(key *snitchdns.APIKey) {
+	m.ID = types.StringValue(strconv.Itoa(key.ID))
+	m.Name = types.StringValue(key.Name)
+	m.Enabled = types.BoolValue(key.Enabled)
+	m.UserID = types.Int64Value(int64(key.UserID))
+	m.CreatedAt = types.StringValue(key.CreatedAt)
+}
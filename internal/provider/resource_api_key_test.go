@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccAPIKeyResource tests creating, renaming, disabling and importing an API key
+func TestAccAPIKeyResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIKeyResourceConfig(container, "ci", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("snitchdns_api_key.test", "id"),
+					resource.TestCheckResourceAttrSet("snitchdns_api_key.test", "key"),
+					resource.TestCheckResourceAttrSet("snitchdns_api_key.test", "user_id"),
+					resource.TestCheckResourceAttr("snitchdns_api_key.test", "name", "ci"),
+					resource.TestCheckResourceAttr("snitchdns_api_key.test", "enabled", "true"),
+				),
+			},
+			{
+				ResourceName:            "snitchdns_api_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key"},
+			},
+			{
+				Config: testAccAPIKeyResourceConfig(container, "ci-renamed", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_api_key.test", "name", "ci-renamed"),
+					resource.TestCheckResourceAttr("snitchdns_api_key.test", "enabled", "false"),
+					resource.TestCheckResourceAttrSet("snitchdns_api_key.test", "key"),
+				),
+			},
+		},
+	})
+}
+
+// testAccAPIKeyResourceConfig generates HCL configuration for API key testing
+func testAccAPIKeyResourceConfig(container *testcontainer.SnitchDNSContainer, name string, enabled bool) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_api_key" "test" {
+  name    = %[3]q
+  enabled = %[4]t
+}
+`, container.GetAPIEndpoint(), container.APIKey, name, enabled)
+}
+
+// TestAPIKeyResource_Mock tests API key CRUD logic against the in-memory API
+func TestAPIKeyResource_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	mock.AddUser(snitchdns.User{ID: 7, Username: "ci-bot", Active: true})
+	r := newMockResource(t, NewAPIKeyResource(), mock)
+
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"name":    types.StringValue("pipeline"),
+		"enabled": types.BoolValue(true),
+		"owner":   types.StringValue("CI-Bot"),
+	})
+
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	var created APIKeyResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.UserID.ValueInt64() != 7 || created.Key.ValueString() == "" || created.CreatedAt.IsNull() {
+		t.Fatalf("Expected a key of user 7 with a secret, got %+v", created)
+	}
+
+	// The secret is kept in state, since the API only returns it on creation
+	disabled := createResp.State
+	disabled.SetAttribute(ctx, path.Root("enabled"), false)
+	updateResp := fwresource.UpdateResponse{State: disabled}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: tfsdk.Plan{Schema: disabled.Schema, Raw: disabled.Raw}}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected update error: %v", updateResp.Diagnostics)
+	}
+
+	readResp := fwresource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", readResp.Diagnostics)
+	}
+
+	var read APIKeyResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.Enabled.ValueBool() || read.Key != created.Key || read.Owner.ValueString() != "CI-Bot" {
+		t.Errorf("Expected a disabled key with the created secret and configured owner, got %+v", read)
+	}
+
+	// A key revoked outside of Terraform is removed from state
+	if err := mock.DeleteAPIKey(ctx, created.ID.ValueString()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	readResp = fwresource.ReadResponse{State: readResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: readResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("Expected the revoked key to be removed from state")
+	}
+
+	// An unknown owner fails before a key is created
+	plan = mockPlan(t, r, map[string]attr.Value{
+		"name":    types.StringValue("pipeline"),
+		"enabled": types.BoolValue(true),
+		"owner":   types.StringValue("nobody"),
+	})
+	createResp = fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Error("Expected an error for an unknown owner")
+	}
+	if keys, _ := mock.ListAPIKeys(ctx); len(keys) != 0 {
+		t.Errorf("Expected no key to be created, got %+v", keys)
+	}
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CanaryZoneResource{}
+var _ resource.ResourceWithImportState = &CanaryZoneResource{}
+
+// NewCanaryZoneResource creates a new Canary Zone resource.
+func NewCanaryZoneResource() resource.Resource {
+	return &CanaryZoneResource{}
+}
+
+// CanaryZoneResource defines the resource implementation. It manages the
+// usual canary combination as a unit: a catch-all zone, a record answering
+// every name below it with a sinkhole address, and a webhook notification
+// alerting when the zone is resolved.
+type CanaryZoneResource struct {
+	client        snitchdns.ClientInterface
+	records       *RecordCache
+	recordData    *recordDataMapper
+	offline       bool
+	defaultUserID int
+}
+
+// CanaryZoneResourceModel describes the resource data model.
+type CanaryZoneResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Domain     DomainValue  `tfsdk:"domain"`
+	WebhookURL types.String `tfsdk:"webhook_url"`
+	Sinkhole   types.String `tfsdk:"sinkhole"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+	Active     types.Bool   `tfsdk:"active"`
+	Tags       types.Set    `tfsdk:"tags"`
+	RecordID   types.String `tfsdk:"record_id"`
+}
+
+// Metadata sets the resource type name.
+func (r *CanaryZoneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_canary_zone"
+}
+
+// Schema defines the resource schema.
+func (r *CanaryZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a canary zone: a catch-all zone answering every name below it with a sinkhole address, " +
+			"and a webhook notification alerting whenever it is resolved. " +
+			"Replaces a `snitchdns_zone`, a `snitchdns_wildcard_record` and a `snitchdns_notification` with the usual canary settings.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the zone.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Domain name of the canary zone, e.g. `aws-backup.example.com`. Case and a trailing dot are ignored when comparing with the server's value. Changing this forces a new resource.",
+				CustomType:          DomainType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"webhook_url": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "URL a JSON notification is POSTed to whenever a name in the zone is resolved. Marked sensitive, since webhook URLs embed a token.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(notificationURLPattern, "must be an http:// or https:// URL"),
+				},
+			},
+			"sinkhole": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("0.0.0.0"),
+				MarkdownDescription: "Address every name in the zone resolves to, answered with an A record for IPv4 and an AAAA record for IPv6 addresses. Defaults to `0.0.0.0`.",
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(300),
+				MarkdownDescription: "Time to live of the sinkhole record in seconds. Defaults to `300`.",
+				Validators: []validator.Int64{
+					recordTTLValidator(),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the zone is answered. Defaults to `true`; set to `false` to disarm the canary without deleting it.",
+			},
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Tags of the zone, e.g. to tell canaries apart from other zones. Tags must not contain commas.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^,]+$`), "must not contain commas"),
+					),
+				},
+			},
+			"record_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the sinkhole record.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *CanaryZoneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.records = providerData.Records
+	r.recordData = providerData.RecordData
+	r.offline = providerData.Offline
+	r.defaultUserID = providerData.DefaultUserID
+}
+
+// CRUD methods are implemented in resource_canary_zone_impl.go
@@ -0,0 +1,379 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *CanaryZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create canary zone")
+		return
+	}
+
+	var data CanaryZoneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags, diags := data.tags(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.CreateZoneWithContext(ctx, snitchdns.CreateZoneRequest{
+		Domain:   data.Domain.ValueString(),
+		Active:   data.Active.ValueBool(),
+		CatchAll: true,
+		Tags:     tags,
+		UserID:   r.defaultUserID,
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error creating canary zone", "Could not create zone", err, zoneErrorAttributes...)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.Itoa(zone.ID))
+	data.RecordID = types.StringNull()
+	data.Domain = NewDomainValue(zone.Domain)
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		// Don't leave behind a half-configured canary nothing in the state
+		// refers to
+		err := r.client.DeleteZoneWithContext(ctx, data.ID.ValueString())
+		r.records.Invalidate(data.ID.ValueString())
+		if err == nil {
+			return
+		}
+
+		// Save the zone that could not be deleted instead, which Terraform
+		// taints so the next apply deletes and creates it again
+		addAPIError(&resp.Diagnostics, "Error deleting canary zone",
+			fmt.Sprintf("Could not delete zone %s after the failed create", zone.Domain), err)
+		resp.Diagnostics.AddWarning("Partially Applied",
+			fmt.Sprintf("Zone %s (ID %s) was created, but configuring it as a canary failed and the zone could not be deleted again.\n\n%s",
+				zone.Domain, data.ID.ValueString(), partialCreateRecovery))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *CanaryZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data CanaryZoneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.GetZoneWithContext(ctx, data.ID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "Canary zone not found, removing from state", map[string]any{
+				"zone_id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading canary zone",
+			fmt.Sprintf("Could not read zone ID %s", data.ID.ValueString()), err)
+		return
+	}
+
+	data.Domain = NewDomainValue(zone.Domain)
+	data.Active = types.BoolValue(zone.Active)
+	tags, diags := zoneTagsValue(ctx, data.Tags, zone.Tags)
+	resp.Diagnostics.Append(diags...)
+	data.Tags = tags
+
+	if !zone.CatchAll {
+		resp.Diagnostics.AddWarning(
+			"Canary zone no longer captures names",
+			fmt.Sprintf("Zone %s no longer has catch_all enabled, so only %s itself is answered. "+
+				"It is enabled again by the next update of this resource.", zone.Domain, zone.Domain),
+		)
+	}
+
+	record := r.sinkholeRecord(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if record == nil {
+		// A deleted record shows as a change of the sinkhole, which the next
+		// apply creates again
+		data.RecordID = types.StringNull()
+		data.Sinkhole = types.StringNull()
+	} else {
+		data.setFromRecord(r.recordData.FromServerRecord(record))
+	}
+
+	subscription, err := r.client.GetZoneNotification(ctx, data.ID.ValueString(), snitchdns.NotificationProviderWebhook)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading canary notification",
+			fmt.Sprintf("Could not read webhook notifications of zone %s", zone.Domain), err)
+		return
+	}
+	resp.Diagnostics.Append(data.setFromSubscription(subscription)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *CanaryZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update canary zone")
+		return
+	}
+
+	var data CanaryZoneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags, diags := data.tags(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	active := data.Active.ValueBool()
+	catchAll := true
+	zone, err := r.client.UpdateZoneWithContext(ctx, data.ID.ValueString(), snitchdns.UpdateZoneRequest{
+		Active:   &active,
+		CatchAll: &catchAll,
+		Tags:     &tags,
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating canary zone",
+			fmt.Sprintf("Could not update zone ID %s", data.ID.ValueString()), err, zoneErrorAttributes...)
+		return
+	}
+	data.Domain = NewDomainValue(zone.Domain)
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. Deleting the zone removes
+// its record and notification with it.
+func (r *CanaryZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete canary zone")
+		return
+	}
+
+	var data CanaryZoneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteZoneWithContext(ctx, data.ID.ValueString())
+	r.records.Invalidate(data.ID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Zone is already gone
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting canary zone",
+			fmt.Sprintf("Could not delete zone ID %s", data.ID.ValueString()), err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic
+func (r *CanaryZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import canary zone")
+		return
+	}
+
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected a numeric zone ID, got: %s", req.ID),
+		)
+		return
+	}
+
+	// Read picks up the zone's sinkhole record while record_id is null
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// apply writes the planned sinkhole record and webhook notification of the
+// zone and refreshes the model from the responses. The record is created
+// when the model has none.
+func (r *CanaryZoneResource) apply(ctx context.Context, data *CanaryZoneResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	zoneID := data.ID.ValueString()
+	recordType := sinkholeRecordType(data.Sinkhole.ValueString())
+	recordData := r.recordData.ToServer(recordType, map[string]interface{}{"address": data.Sinkhole.ValueString()})
+	ttl := int(data.TTL.ValueInt64())
+
+	var record *snitchdns.Record
+	var err error
+	if !data.RecordID.IsNull() && !data.RecordID.IsUnknown() {
+		record, err = r.client.UpdateRecordWithContext(ctx, zoneID, data.RecordID.ValueString(), snitchdns.UpdateRecordRequest{
+			Type: &recordType,
+			TTL:  &ttl,
+			Data: recordData,
+		})
+		if snitchdns.IsNotFound(err) {
+			record, err = nil, nil
+		}
+	}
+	if record == nil && err == nil {
+		record, err = r.client.CreateRecordWithContext(ctx, zoneID, snitchdns.CreateRecordRequest{
+			Active: true,
+			Class:  "IN",
+			Type:   recordType,
+			TTL:    ttl,
+			Data:   recordData,
+		})
+	}
+	r.records.Invalidate(zoneID)
+	if err != nil {
+		addAPIError(&diags, "Error setting canary record",
+			fmt.Sprintf("Could not write the sinkhole record of zone %s", data.Domain.ValueString()), err, ttlErrorAttribute)
+		return diags
+	}
+	data.setFromRecord(r.recordData.FromServerRecord(record))
+
+	enabled := true
+	subscription, err := r.client.UpdateZoneNotification(ctx, zoneID, snitchdns.NotificationProviderWebhook, snitchdns.UpdateNotificationRequest{
+		Enabled: &enabled,
+		Data:    data.WebhookURL.ValueString(),
+	})
+	if err != nil {
+		addAPIError(&diags, "Error setting canary notification",
+			fmt.Sprintf("Could not update webhook notifications of zone %s", data.Domain.ValueString()), err)
+		return diags
+	}
+	diags.Append(data.setFromSubscription(subscription)...)
+	return diags
+}
+
+// sinkholeRecord returns the zone's sinkhole record, or nil when it was
+// deleted. After import, when no record ID is known yet, the zone's only A
+// or AAAA record is taken.
+func (r *CanaryZoneResource) sinkholeRecord(ctx context.Context, data *CanaryZoneResourceModel, diags *diag.Diagnostics) *snitchdns.Record {
+	zoneID := data.ID.ValueString()
+
+	if !data.RecordID.IsNull() {
+		record, found, err := r.records.GetRecord(ctx, zoneID, data.RecordID.ValueString())
+		if err != nil {
+			addAPIError(diags, "Error reading canary record",
+				fmt.Sprintf("Could not read record ID %s in zone %s", data.RecordID.ValueString(), zoneID), err)
+			return nil
+		}
+		if !found {
+			return nil
+		}
+		return record
+	}
+
+	records, err := r.records.ListRecords(ctx, zoneID)
+	if err != nil {
+		addAPIError(diags, "Error reading canary record", fmt.Sprintf("Could not list the records of zone %s", zoneID), err)
+		return nil
+	}
+
+	var sinkholes []snitchdns.Record
+	for _, record := range records {
+		if strings.EqualFold(record.Type, "A") || strings.EqualFold(record.Type, "AAAA") {
+			sinkholes = append(sinkholes, record)
+		}
+	}
+	if len(sinkholes) > 1 {
+		diags.AddError(
+			"Ambiguous canary record",
+			fmt.Sprintf("Zone %s has %d A and AAAA records, but a canary zone has a single sinkhole record.",
+				data.Domain.ValueString(), len(sinkholes)),
+		)
+		return nil
+	}
+	if len(sinkholes) == 0 {
+		return nil
+	}
+	return &sinkholes[0]
+}
+
+// sinkholeRecordType returns the type of the record answering with an
+// address: AAAA for IPv6 and A for IPv4 addresses
+func sinkholeRecordType(address string) string {
+	if addr, err := netip.ParseAddr(address); err == nil && addr.Is6() && !addr.Is4In6() {
+		return "AAAA"
+	}
+	return "A"
+}
+
+// tags returns the planned zone tags
+func (m *CanaryZoneResourceModel) tags(ctx context.Context) (snitchdns.ZoneTags, diag.Diagnostics) {
+	var tags []string
+	var diags diag.Diagnostics
+	if !m.Tags.IsNull() {
+		diags.Append(m.Tags.ElementsAs(ctx, &tags, false)...)
+	}
+	return snitchdns.NewZoneTags(tags), diags
+}
+
+// setFromRecord maps the sinkhole record to the data model
+func (m *CanaryZoneResourceModel) setFromRecord(record *snitchdns.Record) {
+	m.RecordID = types.StringValue(strconv.Itoa(record.ID))
+	m.TTL = types.Int64Value(int64(record.TTL))
+	m.Sinkhole = types.StringValue(record.Data.Get("address"))
+}
+
+// setFromSubscription maps the webhook subscription to the data model. A
+// disabled subscription shows as a change of the URL, which the next apply
+// enables again.
+func (m *CanaryZoneResourceModel) setFromSubscription(subscription *snitchdns.NotificationSubscription) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !subscription.Enabled {
+		m.WebhookURL = types.StringNull()
+		return diags
+	}
+
+	url, err := subscription.URL()
+	if err != nil {
+		diags.AddError("Error reading canary notification", err.Error())
+		return diags
+	}
+	m.WebhookURL = types.StringValue(url)
+	return diags
+}
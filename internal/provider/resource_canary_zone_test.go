@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccCanaryZoneResource tests creating a canary zone with its record
+// and notification
+func TestAccCanaryZoneResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCanaryZoneResourceConfig(container, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_canary_zone.test", "domain", "canary.example.com"),
+					resource.TestCheckResourceAttr("snitchdns_canary_zone.test", "sinkhole", "0.0.0.0"),
+					resource.TestCheckResourceAttr("snitchdns_canary_zone.test", "active", "true"),
+					resource.TestCheckResourceAttrSet("snitchdns_canary_zone.test", "record_id"),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_canary_zone.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCanaryZoneResourceConfig(container, `sinkhole = "::1"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_canary_zone.test", "sinkhole", "::1"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCanaryZoneResourceConfig generates HCL configuration for canary zone testing
+func testAccCanaryZoneResourceConfig(container *testcontainer.SnitchDNSContainer, extra string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_canary_zone" "test" {
+  domain      = "canary.example.com"
+  webhook_url = "https://hooks.example.com/canary"
+  %[3]s
+}
+`, container.GetAPIEndpoint(), container.APIKey, extra)
+}
+
+// TestCanaryZoneResource_Mock tests that the zone, record and notification
+// are created together and drift is picked up
+func TestCanaryZoneResource_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := newMockResource(t, NewCanaryZoneResource(), mock)
+
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"domain":      types.StringValue("canary.example.com"),
+		"webhook_url": types.StringValue("https://hooks.example.com/canary"),
+		"sinkhole":    types.StringValue("0.0.0.0"),
+		"ttl":         types.Int64Value(300),
+		"active":      types.BoolValue(true),
+		"record_id":   types.StringUnknown(),
+	})
+
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	zones := mock.Zones()
+	if len(zones) != 1 || !zones[0].CatchAll || !zones[0].Active {
+		t.Fatalf("Expected an active catch-all zone, got %+v", zones)
+	}
+	records := mock.Records(zones[0].ID)
+	if len(records) != 1 || records[0].Type != "A" || records[0].Data.Get("address") != "0.0.0.0" {
+		t.Fatalf("Expected the sinkhole record, got %+v", records)
+	}
+	zoneID := fmt.Sprint(zones[0].ID)
+	subscription, err := mock.GetZoneNotification(ctx, zoneID, snitchdns.NotificationProviderWebhook)
+	if err != nil || !subscription.Enabled || string(subscription.Data) != `"https://hooks.example.com/canary"` {
+		t.Fatalf("Expected the webhook notification, got %+v, %v", subscription, err)
+	}
+
+	// A disabled notification shows as a change of the webhook URL
+	disabled := false
+	if _, err := mock.UpdateZoneNotification(ctx, zoneID, snitchdns.NotificationProviderWebhook, snitchdns.UpdateNotificationRequest{Enabled: &disabled}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	readResp := fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", readResp.Diagnostics)
+	}
+	var url types.String
+	readResp.State.GetAttribute(ctx, path.Root("webhook_url"), &url)
+	if !url.IsNull() {
+		t.Errorf("Expected a null webhook_url for a disabled notification, got %s", url)
+	}
+
+	// A failed record leaves no zone behind
+	mock.Fail("CreateRecord", errors.New("server on fire"))
+	plan = mockPlan(t, r, map[string]attr.Value{
+		"domain":      types.StringValue("other.example.com"),
+		"webhook_url": types.StringValue("https://hooks.example.com/canary"),
+		"sinkhole":    types.StringValue("::"),
+		"ttl":         types.Int64Value(300),
+		"active":      types.BoolValue(true),
+		"record_id":   types.StringUnknown(),
+	})
+	createResp = fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("Expected an error when the record cannot be created")
+	}
+	if zones := mock.Zones(); len(zones) != 1 {
+		t.Errorf("Expected the zone of the failed canary to be deleted, got %+v", zones)
+	}
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// Server settings of the DNS daemon
+	settingDNSDaemonBindIP   = "dns_daemon_bind_ip"
+	settingDNSDaemonBindPort = "dns_daemon_bind_port"
+
+	// dnsSettingsID is the fixed ID of the singleton resource
+	dnsSettingsID = "dns_settings"
+
+	// SnitchDNS defaults, restored on destroy
+	defaultDNSDaemonBindIP   = "0.0.0.0"
+	defaultDNSDaemonBindPort = 53
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DNSSettingsResource{}
+var _ resource.ResourceWithImportState = &DNSSettingsResource{}
+
+// NewDNSSettingsResource creates a new DNS Settings resource.
+func NewDNSSettingsResource() resource.Resource {
+	return &DNSSettingsResource{}
+}
+
+// DNSSettingsResource defines the resource implementation.
+type DNSSettingsResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// DNSSettingsResourceModel describes the resource data model.
+type DNSSettingsResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	BindIP       types.String `tfsdk:"bind_ip"`
+	BindPort     types.Int64  `tfsdk:"bind_port"`
+	InterceptAll types.Bool   `tfsdk:"intercept_all"`
+}
+
+// Metadata sets the resource type name.
+func (r *DNSSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_settings"
+}
+
+// Schema defines the resource schema.
+func (r *DNSSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Configures the SnitchDNS DNS daemon: the address and port it listens on and whether it answers every query. " +
+			"This is a server-wide setting and requires an admin API key; declare at most one instance per server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Fixed identifier of the setting.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bind_ip": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultDNSDaemonBindIP),
+				MarkdownDescription: fmt.Sprintf("IP address the DNS daemon listens on, `0.0.0.0` or `::` for all addresses. Defaults to `%s`.", defaultDNSDaemonBindIP),
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+			},
+			"bind_port": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultDNSDaemonBindPort),
+				MarkdownDescription: fmt.Sprintf("UDP and TCP port the DNS daemon listens on. Defaults to `%d`.", defaultDNSDaemonBindPort),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"intercept_all": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Answer queries for every name, including names no zone matches, instead of only the zones SnitchDNS serves. " +
+					"When omitted, the setting is left unchanged. Deprecated: use `snitchdns_interception_settings` instead.",
+				DeprecationMessage: "Use the intercept_all attribute of snitchdns_interception_settings instead. " +
+					"This attribute will be removed in the next major version.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *DNSSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// ipAddressValidator checks that a string is an IP address in its canonical
+// form, as the server reports it
+type ipAddressValidator struct{}
+
+// Description describes the validation in plain text.
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be an IP address"
+}
+
+// MarkdownDescription describes the validation in Markdown.
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v ipAddressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	addr, err := netip.ParseAddr(value)
+	switch {
+	case err != nil || addr.Zone() != "":
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP address", fmt.Sprintf("%q is not an IP address.", value))
+	case addr.String() != value:
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP address",
+			fmt.Sprintf("%q is not in canonical form, use %s.", value, addr))
+	}
+}
+
+// CRUD methods are implemented in resource_dns_settings_impl.go
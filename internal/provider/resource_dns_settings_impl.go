@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Create implements the resource create logic
+func (r *DNSSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create DNS settings")
+		return
+	}
+
+	var data DNSSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.client.UpdateSettings(ctx, data.settings())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error setting DNS settings", "Could not update server settings", err)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setFromSettings(settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *DNSSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data DNSSettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.client.GetSettings(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading DNS settings", "Could not read server settings", err)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setFromSettings(settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *DNSSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update DNS settings")
+		return
+	}
+
+	var data DNSSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.client.UpdateSettings(ctx, data.settings())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error setting DNS settings", "Could not update server settings", err)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setFromSettings(settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. The bind address and port
+// are returned to the SnitchDNS defaults; intercept_all is left unchanged.
+func (r *DNSSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete DNS settings")
+		return
+	}
+
+	var data DNSSettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defaults := DNSSettingsResourceModel{
+		BindIP:       types.StringValue(defaultDNSDaemonBindIP),
+		BindPort:     types.Int64Value(defaultDNSDaemonBindPort),
+		InterceptAll: types.BoolUnknown(),
+	}
+	if _, err := r.client.UpdateSettings(ctx, defaults.settings()); err != nil {
+		addAPIError(&resp.Diagnostics, "Error resetting DNS settings", "Could not update server settings", err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic. The setting is a
+// singleton, so any import ID refers to it.
+func (r *DNSSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import DNS settings")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dnsSettingsID)...)
+}
+
+// settings maps the data model to the server settings. An intercept_all
+// omitted from the configuration is planned unknown and left unchanged, so
+// it can be managed by snitchdns_interception_settings instead.
+func (m *DNSSettingsResourceModel) settings() snitchdns.Settings {
+	settings := snitchdns.Settings{
+		settingDNSDaemonBindIP:   m.BindIP.ValueString(),
+		settingDNSDaemonBindPort: strconv.FormatInt(m.BindPort.ValueInt64(), 10),
+	}
+	if !m.InterceptAll.IsNull() && !m.InterceptAll.IsUnknown() {
+		settings[snitchdns.SettingInterceptAll] = snitchdns.FormatBool(m.InterceptAll.ValueBool())
+	}
+	return settings
+}
+
+// setFromSettings maps the server settings to the data model. Settings the
+// server has never stored read as the SnitchDNS defaults.
+func (m *DNSSettingsResourceModel) setFromSettings(settings snitchdns.Settings) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(dnsSettingsID)
+	m.InterceptAll = types.BoolValue(settings.Bool(snitchdns.SettingInterceptAll))
+
+	m.BindIP = types.StringValue(defaultDNSDaemonBindIP)
+	if ip := settings[settingDNSDaemonBindIP]; ip != "" {
+		m.BindIP = types.StringValue(ip)
+	}
+
+	m.BindPort = types.Int64Value(defaultDNSDaemonBindPort)
+	if settings[settingDNSDaemonBindPort] != "" {
+		port, err := settings.Int(settingDNSDaemonBindPort)
+		if err != nil {
+			diags.AddError("Invalid DNS setting", err.Error())
+			return diags
+		}
+		m.BindPort = types.Int64Value(int64(port))
+	}
+
+	return diags
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccDNSSettingsResource tests configuring and changing the DNS daemon settings
+func TestAccDNSSettingsResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSSettingsResourceConfig(container, `
+  bind_port     = 5353
+  intercept_all = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_dns_settings.test", "id", "dns_settings"),
+					resource.TestCheckResourceAttr("snitchdns_dns_settings.test", "bind_ip", "0.0.0.0"),
+					resource.TestCheckResourceAttr("snitchdns_dns_settings.test", "bind_port", "5353"),
+					resource.TestCheckResourceAttr("snitchdns_dns_settings.test", "intercept_all", "true"),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_dns_settings.test",
+				ImportState:       true,
+				ImportStateId:     "dns_settings",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccDNSSettingsResourceConfig(container, `
+  bind_ip = "127.0.0.1"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_dns_settings.test", "bind_ip", "127.0.0.1"),
+					resource.TestCheckResourceAttr("snitchdns_dns_settings.test", "bind_port", "53"),
+					// An omitted intercept_all is left unchanged
+					resource.TestCheckResourceAttr("snitchdns_dns_settings.test", "intercept_all", "true"),
+				),
+			},
+			{
+				Config: testAccDNSSettingsResourceConfig(container, `
+  bind_ip = "localhost"
+`),
+				ExpectError: regexp.MustCompile(`Invalid IP address`),
+			},
+		},
+	})
+}
+
+// testAccDNSSettingsResourceConfig generates HCL configuration for DNS settings testing
+func testAccDNSSettingsResourceConfig(container *testcontainer.SnitchDNSContainer, body string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_dns_settings" "test" {%[3]s}
+`, container.GetAPIEndpoint(), container.APIKey, body)
+}
+
+// TestDNSSettingsSettings tests that the model round-trips through the server settings
+func TestDNSSettingsSettings(t *testing.T) {
+	model := DNSSettingsResourceModel{
+		BindIP:       types.StringValue("::"),
+		BindPort:     types.Int64Value(5353),
+		InterceptAll: types.BoolValue(true),
+	}
+
+	settings := model.settings()
+	if settings[settingDNSDaemonBindIP] != "::" || settings[settingDNSDaemonBindPort] != "5353" ||
+		settings[snitchdns.SettingInterceptAll] != "1" {
+		t.Errorf("Unexpected settings: %v", settings)
+	}
+
+	var read DNSSettingsResourceModel
+	if diags := read.setFromSettings(settings); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if read.ID.ValueString() != dnsSettingsID || read.BindIP != model.BindIP || read.BindPort != model.BindPort ||
+		read.InterceptAll != model.InterceptAll {
+		t.Errorf("Unexpected model: %+v", read)
+	}
+
+	read.setFromSettings(snitchdns.Settings{})
+	if read.BindIP.ValueString() != defaultDNSDaemonBindIP || read.BindPort.ValueInt64() != defaultDNSDaemonBindPort ||
+		read.InterceptAll.ValueBool() {
+		t.Errorf("Expected defaults for missing settings, got %+v", read)
+	}
+
+	// An omitted intercept_all is left unchanged
+	model.InterceptAll = types.BoolUnknown()
+	if _, ok := model.settings()[snitchdns.SettingInterceptAll]; ok {
+		t.Errorf("Expected an unknown intercept_all not to be sent, got %v", model.settings())
+	}
+
+	if diags := read.setFromSettings(snitchdns.Settings{settingDNSDaemonBindPort: "dns"}); !diags.HasError() {
+		t.Error("Expected an error for a non-numeric port")
+	}
+}
+
+// TestIPAddressValidator tests the accepted forms of bind addresses
+func TestIPAddressValidator(t *testing.T) {
+	tests := map[string]bool{
+		"0.0.0.0":        true,
+		"192.0.2.53":     true,
+		"::":             true,
+		"2001:db8::53":   true,
+		"2001:DB8::53":   false,
+		"2001:db8:0::53": false,
+		"fe80::1%eth0":   false,
+		"192.0.2.53:53":  false,
+		"localhost":      false,
+		"":               false,
+	}
+
+	for value, valid := range tests {
+		req := validator.StringRequest{Path: path.Root("bind_ip"), ConfigValue: types.StringValue(value)}
+		resp := &validator.StringResponse{}
+		ipAddressValidator{}.ValidateString(context.Background(), req, resp)
+
+		if valid && resp.Diagnostics.HasError() {
+			t.Errorf("Expected %q to be valid, got %v", value, resp.Diagnostics)
+		}
+		if !valid && !resp.Diagnostics.HasError() {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// Server settings controlling the forwarding of queries SnitchDNS has
+	// no answer for to upstream resolvers
+	settingForwardDNSEnabled = "forward_dns_enabled"
+	settingForwardDNSAddress = "forward_dns_address"
+
+	// forwardingSettingsID is the fixed ID of the singleton resource
+	forwardingSettingsID = "forwarding_settings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ForwardingSettingsResource{}
+var _ resource.ResourceWithImportState = &ForwardingSettingsResource{}
+var _ resource.ResourceWithValidateConfig = &ForwardingSettingsResource{}
+
+// NewForwardingSettingsResource creates a new Forwarding Settings resource.
+func NewForwardingSettingsResource() resource.Resource {
+	return &ForwardingSettingsResource{}
+}
+
+// ForwardingSettingsResource defines the resource implementation.
+type ForwardingSettingsResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// ForwardingSettingsResourceModel describes the resource data model.
+type ForwardingSettingsResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Servers types.List   `tfsdk:"servers"`
+}
+
+// Metadata sets the resource type name.
+func (r *ForwardingSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_forwarding_settings"
+}
+
+// Schema defines the resource schema.
+func (r *ForwardingSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Configures the upstream DNS servers SnitchDNS forwards queries to, for zones with `forwarding` enabled and names it has no zone for. " +
+			"This is a server-wide setting and requires an admin API key; declare at most one instance per server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Fixed identifier of the setting.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Required:            true,
+				MarkdownDescription: "Whether queries are forwarded to the upstream servers. Requires `servers`.",
+			},
+			"servers": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				MarkdownDescription: "Upstream DNS servers in the order they are tried, as IP addresses with an optional port, " +
+					"such as `9.9.9.9`, `192.0.2.53:5353` or `[2001:db8::53]:53`. When omitted, the servers stored on the server are kept.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(upstreamServerValidator{}),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig requires servers when forwarding is enabled, so the
+// upstream servers are always captured in the configuration.
+func (r *ForwardingSettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ForwardingSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Enabled.ValueBool() && data.Servers.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("servers"),
+			"Missing upstream servers",
+			"servers must be set when forwarding is enabled.",
+		)
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *ForwardingSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// upstreamServerValidator checks that a string is an IP address with an
+// optional port
+type upstreamServerValidator struct{}
+
+// Description describes the validation in plain text.
+func (v upstreamServerValidator) Description(_ context.Context) string {
+	return "value must be an IP address with an optional port"
+}
+
+// MarkdownDescription describes the validation in Markdown.
+func (v upstreamServerValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v upstreamServerValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateUpstreamServer(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid upstream server", err.Error())
+	}
+}
+
+// validateUpstreamServer checks a single upstream server address
+func validateUpstreamServer(value string) error {
+	if _, err := netip.ParseAddr(value); err == nil {
+		return nil
+	}
+
+	addrPort, err := netip.ParseAddrPort(value)
+	if err != nil {
+		return fmt.Errorf("%q is not an IP address or an address with a port, such as 192.0.2.53:53", value)
+	}
+	if addrPort.Port() == 0 {
+		return fmt.Errorf("%q has port 0", value)
+	}
+	return nil
+}
+
+// CRUD methods are implemented in resource_forwarding_settings_impl.go
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Create implements the resource create logic
+func (r *ForwardingSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create forwarding settings")
+		return
+	}
+
+	var data ForwardingSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *ForwardingSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data ForwardingSettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.client.GetSettings(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading forwarding settings", "Could not read server settings", err)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setFromSettings(ctx, settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *ForwardingSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update forwarding settings")
+		return
+	}
+
+	var data ForwardingSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. Forwarding is disabled, which
+// is the SnitchDNS default; the upstream servers are left unchanged.
+func (r *ForwardingSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete forwarding settings")
+		return
+	}
+
+	var data ForwardingSettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.UpdateSettings(ctx, snitchdns.Settings{
+		settingForwardDNSEnabled: snitchdns.FormatBool(false),
+	}); err != nil {
+		addAPIError(&resp.Diagnostics, "Error disabling forwarding", "Could not update server settings", err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic. The setting is a
+// singleton, so any import ID refers to it.
+func (r *ForwardingSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import forwarding settings")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), forwardingSettingsID)...)
+}
+
+// apply writes the planned settings to the server and reads back the stored
+// result
+func (r *ForwardingSettingsResource) apply(ctx context.Context, data *ForwardingSettingsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	desired, d := data.settings(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	settings, err := r.client.UpdateSettings(ctx, desired)
+	if err != nil {
+		addAPIError(&diags, "Error setting forwarding settings", "Could not update server settings", err)
+		return diags
+	}
+
+	diags.Append(data.setFromSettings(ctx, settings)...)
+	return diags
+}
+
+// settings maps the data model to the server settings. Unknown or omitted
+// servers are left unchanged.
+func (m *ForwardingSettingsResourceModel) settings(ctx context.Context) (snitchdns.Settings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	settings := snitchdns.Settings{
+		settingForwardDNSEnabled: snitchdns.FormatBool(m.Enabled.ValueBool()),
+	}
+
+	if !m.Servers.IsNull() && !m.Servers.IsUnknown() {
+		var servers []string
+		diags.Append(m.Servers.ElementsAs(ctx, &servers, false)...)
+		settings[settingForwardDNSAddress] = strings.Join(servers, ",")
+	}
+
+	return settings, diags
+}
+
+// setFromSettings maps the server settings to the data model. An empty
+// server list is stored as null.
+func (m *ForwardingSettingsResourceModel) setFromSettings(ctx context.Context, settings snitchdns.Settings) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(forwardingSettingsID)
+	m.Enabled = types.BoolValue(settings.Bool(settingForwardDNSEnabled))
+
+	servers := splitRestrictionList(settings[settingForwardDNSAddress])
+	if len(servers) == 0 {
+		m.Servers = types.ListNull(types.StringType)
+		return diags
+	}
+
+	list, d := types.ListValueFrom(ctx, types.StringType, servers)
+	diags.Append(d...)
+	m.Servers = list
+
+	return diags
+}
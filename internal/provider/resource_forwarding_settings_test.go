@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccForwardingSettingsResource tests configuring and changing the upstream DNS servers
+func TestAccForwardingSettingsResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccForwardingSettingsResourceConfig(container, `
+  enabled = true
+  servers = ["9.9.9.9", "[2620:fe::fe]:53"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_forwarding_settings.test", "id", "forwarding_settings"),
+					resource.TestCheckResourceAttr("snitchdns_forwarding_settings.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("snitchdns_forwarding_settings.test", "servers.#", "2"),
+					resource.TestCheckResourceAttr("snitchdns_forwarding_settings.test", "servers.0", "9.9.9.9"),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_forwarding_settings.test",
+				ImportState:       true,
+				ImportStateId:     "forwarding_settings",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccForwardingSettingsResourceConfig(container, `
+  enabled = false
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_forwarding_settings.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("snitchdns_forwarding_settings.test", "servers.#", "2"),
+				),
+			},
+			{
+				Config: testAccForwardingSettingsResourceConfig(container, `
+  enabled = true
+`),
+				ExpectError: regexp.MustCompile(`servers must be set when forwarding is enabled`),
+			},
+		},
+	})
+}
+
+// testAccForwardingSettingsResourceConfig generates HCL configuration for forwarding settings testing
+func testAccForwardingSettingsResourceConfig(container *testcontainer.SnitchDNSContainer, body string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_forwarding_settings" "test" {%[3]s}
+`, container.GetAPIEndpoint(), container.APIKey, body)
+}
+
+// TestForwardingSettingsSettings tests that the model round-trips through the server settings
+func TestForwardingSettingsSettings(t *testing.T) {
+	ctx := context.Background()
+
+	servers, _ := types.ListValueFrom(ctx, types.StringType, []string{"9.9.9.9", "192.0.2.53:5353"})
+	model := ForwardingSettingsResourceModel{
+		Enabled: types.BoolValue(true),
+		Servers: servers,
+	}
+
+	settings, diags := model.settings(ctx)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if settings[settingForwardDNSEnabled] != "1" || settings[settingForwardDNSAddress] != "9.9.9.9,192.0.2.53:5353" {
+		t.Errorf("Unexpected settings: %v", settings)
+	}
+
+	omitted := ForwardingSettingsResourceModel{Enabled: types.BoolValue(false), Servers: types.ListUnknown(types.StringType)}
+	settings, _ = omitted.settings(ctx)
+	if _, ok := settings[settingForwardDNSAddress]; ok {
+		t.Errorf("Expected omitted servers to be left unchanged, got %v", settings)
+	}
+
+	var read ForwardingSettingsResourceModel
+	diags = read.setFromSettings(ctx, snitchdns.Settings{
+		settingForwardDNSEnabled: "true",
+		settingForwardDNSAddress: " 8.8.8.8, 8.8.4.4 ,",
+	})
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	var values []string
+	read.Servers.ElementsAs(ctx, &values, false)
+	if !read.Enabled.ValueBool() || len(values) != 2 || values[0] != "8.8.8.8" {
+		t.Errorf("Unexpected model: %v, %v", read.Enabled, values)
+	}
+
+	read.setFromSettings(ctx, snitchdns.Settings{settingForwardDNSEnabled: "0"})
+	if read.Enabled.ValueBool() || !read.Servers.IsNull() {
+		t.Errorf("Expected disabled forwarding without servers, got %v, %v", read.Enabled, read.Servers)
+	}
+}
+
+// TestValidateUpstreamServer tests the accepted forms of upstream servers
+func TestValidateUpstreamServer(t *testing.T) {
+	tests := map[string]bool{
+		"9.9.9.9":           true,
+		"192.0.2.53:5353":   true,
+		"2001:db8::53":      true,
+		"[2001:db8::53]:53": true,
+		"192.0.2.53:0":      false,
+		"192.0.2.53:99999":  false,
+		"dns.example.com":   false,
+		"10.0.0.0/8":        false,
+		"":                  false,
+	}
+
+	for value, valid := range tests {
+		err := validateUpstreamServer(value)
+		if valid && err != nil {
+			t.Errorf("Expected %q to be valid, got %v", value, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// Server settings holding the comma-separated source addresses and
+	// ranges that are allowed and blocked server-wide
+	settingGlobalRestrictionsAllow = "global_restrictions_allow"
+	settingGlobalRestrictionsBlock = "global_restrictions_block"
+
+	// globalRestrictionsID is the fixed ID of the singleton resource
+	globalRestrictionsID = "global_restrictions"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GlobalRestrictionsResource{}
+var _ resource.ResourceWithImportState = &GlobalRestrictionsResource{}
+
+// NewGlobalRestrictionsResource creates a new Global Restrictions resource.
+func NewGlobalRestrictionsResource() resource.Resource {
+	return &GlobalRestrictionsResource{}
+}
+
+// GlobalRestrictionsResource defines the resource implementation.
+type GlobalRestrictionsResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// GlobalRestrictionsResourceModel describes the resource data model.
+type GlobalRestrictionsResourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Allow types.Set    `tfsdk:"allow"`
+	Block types.Set    `tfsdk:"block"`
+}
+
+// Metadata sets the resource type name.
+func (r *GlobalRestrictionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_restrictions"
+}
+
+// Schema defines the resource schema.
+func (r *GlobalRestrictionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the server-wide source IP restrictions of SnitchDNS, which apply to every zone in addition to the restrictions of each zone. " +
+			"This is a server-wide setting and requires an admin API key; declare at most one instance per server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Fixed identifier of the setting.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"allow": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				MarkdownDescription: "IP addresses and CIDR ranges allowed to query the server. When set, queries from any other source are not answered. " +
+					"Omit to allow every source that is not blocked.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(ipOrRangeValidator{}),
+				},
+			},
+			"block": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "IP addresses and CIDR ranges whose queries are never answered, even when they are allowed.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(ipOrRangeValidator{}),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *GlobalRestrictionsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// ipOrRangeValidator checks that a string is an IP address or a CIDR range.
+// Ranges must be given by their network address, as the server stores them
+// that way and any other form would show as a change on every plan.
+type ipOrRangeValidator struct{}
+
+// Description describes the validation in plain text.
+func (v ipOrRangeValidator) Description(_ context.Context) string {
+	return "value must be an IP address or a CIDR range"
+}
+
+// MarkdownDescription describes the validation in Markdown.
+func (v ipOrRangeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v ipOrRangeValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateIPOrRange(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP address or range", err.Error())
+	}
+}
+
+// validateIPOrRange checks a single IP address or CIDR range
+func validateIPOrRange(value string) error {
+	if net.ParseIP(value) != nil {
+		return nil
+	}
+
+	ip, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return fmt.Errorf("%q is not an IP address or a CIDR range", value)
+	}
+	if !ip.Equal(network.IP) {
+		return fmt.Errorf("%q has host bits set, use the network address %s", value, network)
+	}
+	return nil
+}
+
+// CRUD methods are implemented in resource_global_restrictions_impl.go
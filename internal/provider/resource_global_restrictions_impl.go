@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Create implements the resource create logic
+func (r *GlobalRestrictionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create global restrictions")
+		return
+	}
+
+	var data GlobalRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *GlobalRestrictionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data GlobalRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.client.GetSettings(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading global restrictions", "Could not read server settings", err)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setFromSettings(ctx, settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *GlobalRestrictionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update global restrictions")
+		return
+	}
+
+	var data GlobalRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. Both lists are cleared, so
+// every source may query the server again, which is the SnitchDNS default.
+func (r *GlobalRestrictionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete global restrictions")
+		return
+	}
+
+	var data GlobalRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cleared := snitchdns.Settings{
+		settingGlobalRestrictionsAllow: "",
+		settingGlobalRestrictionsBlock: "",
+	}
+	if _, err := r.client.UpdateSettings(ctx, cleared); err != nil {
+		addAPIError(&resp.Diagnostics, "Error clearing global restrictions", "Could not update server settings", err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic. The setting is a
+// singleton, so any import ID refers to it.
+func (r *GlobalRestrictionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import global restrictions")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), globalRestrictionsID)...)
+}
+
+// apply writes the planned lists to the server settings and reads back the
+// stored result
+func (r *GlobalRestrictionsResource) apply(ctx context.Context, data *GlobalRestrictionsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	desired, d := data.settings(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	settings, err := r.client.UpdateSettings(ctx, desired)
+	if err != nil {
+		addAPIError(&diags, "Error setting global restrictions", "Could not update server settings", err)
+		return diags
+	}
+
+	diags.Append(data.setFromSettings(ctx, settings)...)
+	return diags
+}
+
+// settings maps the data model to the server settings. An omitted list is
+// stored empty, lifting that restriction.
+func (m *GlobalRestrictionsResourceModel) settings(ctx context.Context) (snitchdns.Settings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	settings := snitchdns.Settings{}
+
+	for name, set := range map[string]types.Set{
+		settingGlobalRestrictionsAllow: m.Allow,
+		settingGlobalRestrictionsBlock: m.Block,
+	} {
+		var values []string
+		if !set.IsNull() {
+			diags.Append(set.ElementsAs(ctx, &values, false)...)
+		}
+		settings[name] = strings.Join(values, ",")
+	}
+
+	return settings, diags
+}
+
+// setFromSettings maps the server settings to the data model. Empty lists
+// are stored as null, matching an omitted attribute.
+func (m *GlobalRestrictionsResourceModel) setFromSettings(ctx context.Context, settings snitchdns.Settings) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(globalRestrictionsID)
+
+	for _, target := range []struct {
+		name string
+		set  *types.Set
+	}{
+		{settingGlobalRestrictionsAllow, &m.Allow},
+		{settingGlobalRestrictionsBlock, &m.Block},
+	} {
+		values := splitRestrictionList(settings[target.name])
+		if len(values) == 0 {
+			*target.set = types.SetNull(types.StringType)
+			continue
+		}
+
+		set, d := types.SetValueFrom(ctx, types.StringType, values)
+		diags.Append(d...)
+		*target.set = set
+	}
+
+	return diags
+}
+
+// splitRestrictionList splits a stored comma-separated list of addresses and
+// ranges, ignoring blanks
+func splitRestrictionList(value string) []string {
+	var values []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			values = append(values, entry)
+		}
+	}
+	return values
+}
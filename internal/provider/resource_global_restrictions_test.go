@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccGlobalRestrictionsResource tests configuring and changing the server-wide restrictions
+func TestAccGlobalRestrictionsResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalRestrictionsResourceConfig(container, `
+  allow = ["0.0.0.0/0", "::/0"]
+  block = ["203.0.113.7"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_global_restrictions.test", "id", "global_restrictions"),
+					resource.TestCheckResourceAttr("snitchdns_global_restrictions.test", "allow.#", "2"),
+					resource.TestCheckTypeSetElemAttr("snitchdns_global_restrictions.test", "block.*", "203.0.113.7"),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_global_restrictions.test",
+				ImportState:       true,
+				ImportStateId:     "global_restrictions",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccGlobalRestrictionsResourceConfig(container, `
+  block = ["203.0.113.0/24"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("snitchdns_global_restrictions.test", "allow"),
+					resource.TestCheckTypeSetElemAttr("snitchdns_global_restrictions.test", "block.*", "203.0.113.0/24"),
+				),
+			},
+		},
+	})
+}
+
+// testAccGlobalRestrictionsResourceConfig generates HCL configuration for global restrictions testing
+func testAccGlobalRestrictionsResourceConfig(container *testcontainer.SnitchDNSContainer, body string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_global_restrictions" "test" {%[3]s}
+`, container.GetAPIEndpoint(), container.APIKey, body)
+}
+
+// TestGlobalRestrictionsSettings tests that the model round-trips through the server settings
+func TestGlobalRestrictionsSettings(t *testing.T) {
+	ctx := context.Background()
+
+	allow, _ := types.SetValueFrom(ctx, types.StringType, []string{"10.0.0.0/8"})
+	model := GlobalRestrictionsResourceModel{
+		Allow: allow,
+		Block: types.SetNull(types.StringType),
+	}
+
+	settings, diags := model.settings(ctx)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if settings[settingGlobalRestrictionsAllow] != "10.0.0.0/8" {
+		t.Errorf("Unexpected allow setting: %q", settings[settingGlobalRestrictionsAllow])
+	}
+	if value, ok := settings[settingGlobalRestrictionsBlock]; !ok || value != "" {
+		t.Errorf("Expected an omitted block list to be cleared, got %q", value)
+	}
+
+	var read GlobalRestrictionsResourceModel
+	diags = read.setFromSettings(ctx, snitchdns.Settings{
+		settingGlobalRestrictionsAllow: " 10.0.0.0/8, 192.0.2.1 ,",
+		settingGlobalRestrictionsBlock: "",
+	})
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	var values []string
+	read.Allow.ElementsAs(ctx, &values, false)
+	if len(values) != 2 {
+		t.Errorf("Expected two allowed entries, got %v", values)
+	}
+	if !read.Block.IsNull() {
+		t.Errorf("Expected an empty block list to read as null, got %v", read.Block)
+	}
+}
+
+// TestValidateIPOrRange tests the accepted forms of restriction entries
+func TestValidateIPOrRange(t *testing.T) {
+	tests := map[string]bool{
+		"192.0.2.1":     true,
+		"2001:db8::1":   true,
+		"10.0.0.0/8":    true,
+		"2001:db8::/32": true,
+		"10.0.0.1/8":    false,
+		"10.0.0.0/33":   false,
+		"example.com":   false,
+		"192.0.2.1-20":  false,
+		"":              false,
+	}
+
+	for value, valid := range tests {
+		err := validateIPOrRange(value)
+		if valid && err != nil {
+			t.Errorf("Expected %q to be valid, got %v", value, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// interceptionSettingsID is the fixed ID of the singleton resource
+const interceptionSettingsID = "interception_settings"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &InterceptionSettingsResource{}
+var _ resource.ResourceWithImportState = &InterceptionSettingsResource{}
+
+// NewInterceptionSettingsResource creates a new Interception Settings resource.
+func NewInterceptionSettingsResource() resource.Resource {
+	return &InterceptionSettingsResource{}
+}
+
+// InterceptionSettingsResource defines the resource implementation.
+type InterceptionSettingsResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// InterceptionSettingsResourceModel describes the resource data model.
+type InterceptionSettingsResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	InterceptAll types.Bool   `tfsdk:"intercept_all"`
+}
+
+// Metadata sets the resource type name.
+func (r *InterceptionSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_interception_settings"
+}
+
+// Schema defines the resource schema.
+func (r *InterceptionSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Configures which queries SnitchDNS answers beyond the `catch_all` of single zones, such as answering every query in a lab. " +
+			"This is a server-wide setting and requires an admin API key; declare at most one instance per server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Fixed identifier of the setting.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"intercept_all": schema.BoolAttribute{
+				Required:            true,
+				MarkdownDescription: "Answer queries for every name, including names no zone matches, instead of only the zones SnitchDNS serves.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *InterceptionSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// CRUD methods are implemented in resource_interception_settings_impl.go
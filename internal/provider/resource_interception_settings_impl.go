@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Create implements the resource create logic
+func (r *InterceptionSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create interception settings")
+		return
+	}
+
+	var data InterceptionSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := snitchdns.UpdateInterceptionSettings(ctx, r.client, data.settings())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error setting interception settings", "Could not update server settings", err)
+		return
+	}
+
+	data.setFromSettings(settings)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *InterceptionSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data InterceptionSettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := snitchdns.GetInterceptionSettings(ctx, r.client)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading interception settings", "Could not read server settings", err)
+		return
+	}
+
+	data.setFromSettings(settings)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *InterceptionSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update interception settings")
+		return
+	}
+
+	var data InterceptionSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := snitchdns.UpdateInterceptionSettings(ctx, r.client, data.settings())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error setting interception settings", "Could not update server settings", err)
+		return
+	}
+
+	data.setFromSettings(settings)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. Interception is disabled,
+// which is the SnitchDNS default.
+func (r *InterceptionSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete interception settings")
+		return
+	}
+
+	var data InterceptionSettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := snitchdns.UpdateInterceptionSettings(ctx, r.client, snitchdns.InterceptionSettings{}); err != nil {
+		addAPIError(&resp.Diagnostics, "Error resetting interception settings", "Could not update server settings", err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic. The setting is a
+// singleton, so any import ID refers to it.
+func (r *InterceptionSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import interception settings")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), interceptionSettingsID)...)
+}
+
+// settings maps the data model to the interception settings
+func (m *InterceptionSettingsResourceModel) settings() snitchdns.InterceptionSettings {
+	return snitchdns.InterceptionSettings{
+		InterceptAll: m.InterceptAll.ValueBool(),
+	}
+}
+
+// setFromSettings maps the interception settings to the data model
+func (m *InterceptionSettingsResourceModel) setFromSettings(settings *snitchdns.InterceptionSettings) {
+	m.ID = types.StringValue(interceptionSettingsID)
+	m.InterceptAll = types.BoolValue(settings.InterceptAll)
+}
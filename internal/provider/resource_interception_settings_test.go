@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccInterceptionSettingsResource tests toggling the interception of
+// every query
+func TestAccInterceptionSettingsResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterceptionSettingsResourceConfig(container, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_interception_settings.test", "id", "interception_settings"),
+					resource.TestCheckResourceAttr("snitchdns_interception_settings.test", "intercept_all", "true"),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_interception_settings.test",
+				ImportState:       true,
+				ImportStateId:     "interception_settings",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccInterceptionSettingsResourceConfig(container, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_interception_settings.test", "intercept_all", "false"),
+				),
+			},
+		},
+	})
+}
+
+// testAccInterceptionSettingsResourceConfig generates HCL configuration for interception settings testing
+func testAccInterceptionSettingsResourceConfig(container *testcontainer.SnitchDNSContainer, interceptAll bool) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_interception_settings" "test" {
+  intercept_all = %[3]t
+}
+`, container.GetAPIEndpoint(), container.APIKey, interceptAll)
+}
+
+// TestInterceptionSettingsResource_Mock tests that interception is set on
+// create and disabled on destroy, leaving other settings unchanged
+func TestInterceptionSettingsResource_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	if _, err := mock.UpdateSettings(ctx, snitchdns.Settings{settingDNSDaemonBindPort: "5353"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	r := newMockResource(t, NewInterceptionSettingsResource(), mock)
+
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"intercept_all": types.BoolValue(true),
+	})
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	var data InterceptionSettingsResourceModel
+	createResp.State.Get(ctx, &data)
+	if data.ID.ValueString() != interceptionSettingsID || !data.InterceptAll.ValueBool() {
+		t.Errorf("Expected interception in state, got %+v", data)
+	}
+	settings, _ := mock.GetSettings(ctx)
+	if !settings.Bool(snitchdns.SettingInterceptAll) || settings[settingDNSDaemonBindPort] != "5353" {
+		t.Errorf("Expected only interception to be set, got %v", settings)
+	}
+
+	deleteResp := fwresource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: createResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected delete error: %v", deleteResp.Diagnostics)
+	}
+	if settings, _ := mock.GetSettings(ctx); settings.Bool(snitchdns.SettingInterceptAll) {
+		t.Errorf("Expected interception to be disabled, got %v", settings)
+	}
+}
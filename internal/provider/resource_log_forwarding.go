@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// Server settings controlling the forwarding of query events
+	settingLogForwardSyslogEnabled  = "log_forward_syslog_enabled"
+	settingLogForwardSyslogHost     = "log_forward_syslog_host"
+	settingLogForwardSyslogPort     = "log_forward_syslog_port"
+	settingLogForwardSyslogProtocol = "log_forward_syslog_protocol"
+	settingLogForwardWebhookEnabled = "log_forward_webhook_enabled"
+	settingLogForwardWebhookURL     = "log_forward_webhook_url"
+	settingLogForwardMatchedOnly    = "log_forward_matched_only"
+
+	// logForwardingID is the fixed ID of the singleton resource
+	logForwardingID = "log_forwarding"
+)
+
+// logForwardingURLPattern matches the webhook URLs SnitchDNS can post to
+var logForwardingURLPattern = regexp.MustCompile(`^https?://`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LogForwardingResource{}
+var _ resource.ResourceWithImportState = &LogForwardingResource{}
+
+// NewLogForwardingResource creates a new Log Forwarding resource.
+func NewLogForwardingResource() resource.Resource {
+	return &LogForwardingResource{}
+}
+
+// LogForwardingResource defines the resource implementation.
+type LogForwardingResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// LogForwardingResourceModel describes the resource data model.
+type LogForwardingResourceModel struct {
+	ID             types.String               `tfsdk:"id"`
+	Syslog         *LogForwardingSyslogModel  `tfsdk:"syslog"`
+	Webhook        *LogForwardingWebhookModel `tfsdk:"webhook"`
+	MatchedOnly    types.Bool                 `tfsdk:"matched_only"`
+	SecretsVersion types.Int64                `tfsdk:"secrets_version"`
+}
+
+// LogForwardingSyslogModel describes the syslog destination.
+type LogForwardingSyslogModel struct {
+	Host     types.String `tfsdk:"host"`
+	Port     types.Int64  `tfsdk:"port"`
+	Protocol types.String `tfsdk:"protocol"`
+}
+
+// LogForwardingWebhookModel describes the webhook destination.
+type LogForwardingWebhookModel struct {
+	URL   types.String `tfsdk:"url"`
+	URLWO types.String `tfsdk:"url_wo"`
+}
+
+// Metadata sets the resource type name.
+func (r *LogForwardingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_log_forwarding"
+}
+
+// Schema defines the resource schema.
+func (r *LogForwardingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Forwards SnitchDNS query events to syslog and/or a webhook as they happen, so canary hits reach a SIEM without polling the query logs. " +
+			"This is a server-wide setting and requires an admin API key; declare at most one instance per server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Fixed identifier of the setting.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"syslog": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Forward query events to a syslog server. Forwarding to syslog is disabled when omitted.",
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Host name or IP address of the syslog server.",
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"port": schema.Int64Attribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(514),
+						MarkdownDescription: "Port of the syslog server. Defaults to `514`.",
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+					},
+					"protocol": schema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("udp"),
+						MarkdownDescription: "Transport protocol, `udp` or `tcp`. Defaults to `udp`.",
+						Validators: []validator.String{
+							stringvalidator.OneOf("udp", "tcp"),
+						},
+					},
+				},
+			},
+			"webhook": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Push query events to a webhook as JSON. Forwarding to a webhook is disabled when omitted.",
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Optional:  true,
+						Sensitive: true,
+						MarkdownDescription: "URL each query event is POSTed to. Marked sensitive, since webhook URLs often embed a token, but still stored in state. " +
+							"Exactly one of `url` or `url_wo` must be set.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(logForwardingURLPattern, "must be an http:// or https:// URL"),
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("url_wo")),
+						},
+					},
+					"url_wo": schema.StringAttribute{
+						Optional:  true,
+						Sensitive: true,
+						WriteOnly: true,
+						MarkdownDescription: "URL each query event is POSTed to, like `url`, but never stored in the plan or state; " +
+							"change `secrets_version` to send a new URL. Requires Terraform 1.11 or later.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(logForwardingURLPattern, "must be an http:// or https:// URL"),
+						},
+					},
+				},
+			},
+			"matched_only": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Only forward queries that matched a zone, such as canary hits. Defaults to `false`, forwarding every logged query.",
+			},
+			"secrets_version": secretsVersionAttribute("`webhook.url_wo`"),
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *LogForwardingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// CRUD methods are implemented in resource_log_forwarding_impl.go
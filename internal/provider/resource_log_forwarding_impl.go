@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Create implements the resource create logic
+func (r *LogForwardingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create log forwarding")
+		return
+	}
+
+	var data LogForwardingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config LogForwardingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *LogForwardingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data LogForwardingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The response carries the URL kept in state
+	if data.Webhook != nil {
+		r.client.AddSensitiveValue(data.Webhook.URL.ValueString())
+	}
+
+	settings, err := r.client.GetSettings(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading log forwarding", "Could not read server settings", err)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setFromSettings(settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *LogForwardingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update log forwarding")
+		return
+	}
+
+	var data LogForwardingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config LogForwardingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. Forwarding is disabled, which
+// is the SnitchDNS default.
+func (r *LogForwardingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete log forwarding")
+		return
+	}
+
+	var data LogForwardingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	disabled := LogForwardingResourceModel{MatchedOnly: types.BoolValue(false)}
+	if _, err := r.client.UpdateSettings(ctx, disabled.settings(nil)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error disabling log forwarding", "Could not update server settings", err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic. The setting is a
+// singleton, so any import ID refers to it.
+func (r *LogForwardingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import log forwarding")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), logForwardingID)...)
+}
+
+// apply writes the planned settings and refreshes the model from the
+// response. The write-only webhook URL is taken from the configuration, as
+// the plan never holds it, and registered with the client's redactor so it
+// stays out of errors and debug logs.
+func (r *LogForwardingResource) apply(ctx context.Context, data, config *LogForwardingResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	request := data.settings(config)
+	r.client.AddSensitiveValue(request[settingLogForwardWebhookURL])
+
+	settings, err := r.client.UpdateSettings(ctx, request)
+	if err != nil {
+		addAPIError(&diags, "Error setting log forwarding", "Could not update server settings", err)
+		return diags
+	}
+
+	diags.Append(data.setFromSettings(settings)...)
+	return diags
+}
+
+// settings maps the data model to the server settings, with the write-only
+// webhook URL of the configuration. Destinations that are not configured
+// are disabled; their other settings are left unchanged.
+func (m *LogForwardingResourceModel) settings(config *LogForwardingResourceModel) snitchdns.Settings {
+	settings := snitchdns.Settings{
+		settingLogForwardSyslogEnabled:  snitchdns.FormatBool(m.Syslog != nil),
+		settingLogForwardWebhookEnabled: snitchdns.FormatBool(m.Webhook != nil),
+		settingLogForwardMatchedOnly:    snitchdns.FormatBool(m.MatchedOnly.ValueBool()),
+	}
+
+	if m.Syslog != nil {
+		settings[settingLogForwardSyslogHost] = m.Syslog.Host.ValueString()
+		settings[settingLogForwardSyslogPort] = strconv.FormatInt(m.Syslog.Port.ValueInt64(), 10)
+		settings[settingLogForwardSyslogProtocol] = m.Syslog.Protocol.ValueString()
+	}
+	if m.Webhook != nil {
+		settings[settingLogForwardWebhookURL] = m.Webhook.URL.ValueString()
+		if config != nil && config.Webhook != nil && !config.Webhook.URLWO.IsNull() {
+			settings[settingLogForwardWebhookURL] = config.Webhook.URLWO.ValueString()
+		}
+	}
+
+	return settings
+}
+
+// setFromSettings maps the server settings to the data model. A webhook URL
+// configured write-only stays out of state; an imported setting has no
+// webhook attribute yet and reads it.
+func (m *LogForwardingResourceModel) setFromSettings(settings snitchdns.Settings) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	writeOnly := m.Webhook != nil && m.Webhook.URL.IsNull()
+
+	m.ID = types.StringValue(logForwardingID)
+	m.MatchedOnly = types.BoolValue(settings.Bool(settingLogForwardMatchedOnly))
+
+	m.Syslog = nil
+	if settings.Bool(settingLogForwardSyslogEnabled) {
+		port, err := settings.Int(settingLogForwardSyslogPort)
+		if err != nil {
+			diags.AddError("Invalid log forwarding setting", err.Error())
+			return diags
+		}
+
+		m.Syslog = &LogForwardingSyslogModel{
+			Host:     types.StringValue(settings[settingLogForwardSyslogHost]),
+			Port:     types.Int64Value(int64(port)),
+			Protocol: types.StringValue(settings[settingLogForwardSyslogProtocol]),
+		}
+	}
+
+	m.Webhook = nil
+	if settings.Bool(settingLogForwardWebhookEnabled) {
+		m.Webhook = &LogForwardingWebhookModel{URL: types.StringNull(), URLWO: types.StringNull()}
+		if !writeOnly {
+			m.Webhook.URL = types.StringValue(settings[settingLogForwardWebhookURL])
+		}
+	}
+
+	return diags
+}
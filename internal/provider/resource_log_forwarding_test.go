@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccLogForwardingResource tests configuring and changing log forwarding destinations
+func TestAccLogForwardingResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLogForwardingResourceConfig(container, `
+  syslog = {
+    host = "siem.example.com"
+  }
+  matched_only = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_log_forwarding.test", "id", "log_forwarding"),
+					resource.TestCheckResourceAttr("snitchdns_log_forwarding.test", "syslog.port", "514"),
+					resource.TestCheckResourceAttr("snitchdns_log_forwarding.test", "syslog.protocol", "udp"),
+					resource.TestCheckNoResourceAttr("snitchdns_log_forwarding.test", "webhook"),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_log_forwarding.test",
+				ImportState:       true,
+				ImportStateId:     "log_forwarding",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccLogForwardingResourceConfig(container, `
+  webhook = {
+    url = "https://hooks.example.com/snitch"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("snitchdns_log_forwarding.test", "syslog"),
+					resource.TestCheckResourceAttr("snitchdns_log_forwarding.test", "webhook.url", "https://hooks.example.com/snitch"),
+					resource.TestCheckResourceAttr("snitchdns_log_forwarding.test", "matched_only", "false"),
+				),
+			},
+		},
+	})
+}
+
+// testAccLogForwardingResourceConfig generates HCL configuration for log forwarding testing
+func testAccLogForwardingResourceConfig(container *testcontainer.SnitchDNSContainer, body string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_log_forwarding" "test" {%[3]s}
+`, container.GetAPIEndpoint(), container.APIKey, body)
+}
+
+// TestLogForwardingSettings tests that the model round-trips through the server settings
+func TestLogForwardingSettings(t *testing.T) {
+	model := LogForwardingResourceModel{
+		Syslog: &LogForwardingSyslogModel{
+			Host:     types.StringValue("siem.example.com"),
+			Port:     types.Int64Value(6514),
+			Protocol: types.StringValue("tcp"),
+		},
+		MatchedOnly: types.BoolValue(true),
+	}
+
+	settings := model.settings(nil)
+	if settings[settingLogForwardSyslogEnabled] != "1" || settings[settingLogForwardWebhookEnabled] != "0" {
+		t.Errorf("Unexpected enabled settings: %v", settings)
+	}
+	if _, ok := settings[settingLogForwardWebhookURL]; ok {
+		t.Error("Expected the webhook URL of a disabled webhook to be left unchanged")
+	}
+
+	var read LogForwardingResourceModel
+	if diags := read.setFromSettings(settings); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if read.Syslog == nil || read.Syslog.Port.ValueInt64() != 6514 || read.Syslog.Protocol.ValueString() != "tcp" {
+		t.Errorf("Unexpected syslog settings: %+v", read.Syslog)
+	}
+	if read.Webhook != nil || !read.MatchedOnly.ValueBool() {
+		t.Errorf("Unexpected settings: %+v", read)
+	}
+}
+
+// TestLogForwardingResource_WriteOnly tests that a write-only webhook URL is
+// sent to the server and redacted, but kept out of state
+func TestLogForwardingResource_WriteOnly(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := newMockResource(t, NewLogForwardingResource(), mock)
+
+	webhook := func(url, urlWO types.String) types.Object {
+		return types.ObjectValueMust(
+			map[string]attr.Type{"url": types.StringType, "url_wo": types.StringType},
+			map[string]attr.Value{"url": url, "url_wo": urlWO},
+		)
+	}
+	attributes := map[string]attr.Value{
+		"matched_only":    types.BoolValue(true),
+		"secrets_version": types.Int64Value(1),
+		"webhook":         webhook(types.StringNull(), types.StringNull()),
+	}
+	// Terraform plans write-only attributes as null, so they are only set
+	// in the configuration
+	plan := mockPlan(t, r, attributes)
+	attributes["webhook"] = webhook(types.StringNull(), types.StringValue("https://hooks.example.com/secret-1"))
+	config := tfsdk.Config(mockPlan(t, r, attributes))
+
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan, Config: config}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+	settings, _ := mock.GetSettings(ctx)
+	if settings[settingLogForwardWebhookURL] != "https://hooks.example.com/secret-1" || !settings.Bool(settingLogForwardWebhookEnabled) {
+		t.Fatalf("Expected the write-only URL to be sent, got %v", settings)
+	}
+
+	var created LogForwardingResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.Webhook == nil || !created.Webhook.URL.IsNull() || !created.Webhook.URLWO.IsNull() {
+		t.Fatalf("Expected no URL in state, got %+v", created.Webhook)
+	}
+
+	// Reading keeps the URL out of state
+	readResp := fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", readResp.Diagnostics)
+	}
+	var read LogForwardingResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.Webhook == nil || !read.Webhook.URL.IsNull() {
+		t.Fatalf("Expected no URL in state after a read, got %+v", read.Webhook)
+	}
+
+	if got := mock.SensitiveValues(); !slices.Equal(got, []string{"https://hooks.example.com/secret-1"}) {
+		t.Errorf("Expected the write-only URL to be registered for redaction, got %v", got)
+	}
+}
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// notificationURLPattern accepts the http(s) URLs the webhook, Slack and
+// Teams providers post to
+var notificationURLPattern = regexp.MustCompile(`^https?://`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NotificationResource{}
+var _ resource.ResourceWithImportState = &NotificationResource{}
+var _ resource.ResourceWithConfigValidators = &NotificationResource{}
+
+// NewNotificationResource creates a new Notification resource.
+func NewNotificationResource() resource.Resource {
+	return &NotificationResource{}
+}
+
+// NotificationResource defines the resource implementation. Each instance
+// manages a zone's subscription to one notification provider, selected by
+// which provider attribute is configured.
+type NotificationResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// NotificationResourceModel describes the resource data model.
+type NotificationResourceModel struct {
+	ID             types.String              `tfsdk:"id"`
+	ZoneID         types.String              `tfsdk:"zone_id"`
+	Enabled        types.Bool                `tfsdk:"enabled"`
+	SecretsVersion types.Int64               `tfsdk:"secrets_version"`
+	Email          *NotificationEmailModel   `tfsdk:"email"`
+	Webhook        *NotificationWebhookModel `tfsdk:"webhook"`
+	Slack          *NotificationWebhookModel `tfsdk:"slack"`
+	Teams          *NotificationWebhookModel `tfsdk:"teams"`
+}
+
+// NotificationEmailModel describes the email provider configuration.
+type NotificationEmailModel struct {
+	Recipients types.Set `tfsdk:"recipients"`
+}
+
+// NotificationWebhookModel describes the configuration of the providers that
+// post to a URL: webhook, Slack and Teams. URLWO is write-only, so it is only
+// set in the configuration, never in the plan or state.
+type NotificationWebhookModel struct {
+	URL   types.String `tfsdk:"url"`
+	URLWO types.String `tfsdk:"url_wo"`
+}
+
+// Metadata sets the resource type name.
+func (r *NotificationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification"
+}
+
+// Schema defines the resource schema.
+func (r *NotificationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a zone's subscription to a notification provider, which alerts when the zone is resolved. " +
+			"Exactly one of `email`, `webhook`, `slack` or `teams` selects the provider; use one resource per provider. " +
+			"Do not combine the `email` provider with `snitchdns_notification_recipients` for the same zone.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, in the form `zone_id:provider`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone whose notifications are managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether notifications are sent. Defaults to `true`.",
+			},
+			"secrets_version": secretsVersionAttribute("`url_wo`"),
+			"email": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Notify by email.",
+				PlanModifiers:       []planmodifier.Object{notificationProviderReplace()},
+				Attributes: map[string]schema.Attribute{
+					"recipients": schema.SetAttribute{
+						ElementType:         types.StringType,
+						Required:            true,
+						MarkdownDescription: "Email addresses notified about queries to the zone.",
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(
+								stringvalidator.RegexMatches(emailAddressPattern, "must be an email address"),
+							),
+						},
+					},
+				},
+			},
+			"webhook": notificationWebhookAttribute("Post a JSON notification to a webhook.",
+				"URL the notification is POSTed to."),
+			"slack": notificationWebhookAttribute("Notify a Slack channel.",
+				"Slack incoming webhook URL."),
+			"teams": notificationWebhookAttribute("Notify a Microsoft Teams channel.",
+				"Teams incoming webhook URL."),
+		},
+	}
+}
+
+// notificationWebhookAttribute returns the schema of a provider configured by
+// a single URL
+func notificationWebhookAttribute(description, urlDescription string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: description,
+		PlanModifiers:       []planmodifier.Object{notificationProviderReplace()},
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				MarkdownDescription: urlDescription + " Marked sensitive, since webhook URLs embed a token, but still stored in state. " +
+					"Exactly one of `url` or `url_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(notificationURLPattern, "must be an http:// or https:// URL"),
+					stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("url_wo")),
+				},
+			},
+			"url_wo": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				MarkdownDescription: urlDescription + " Write-only, so the URL is never stored in the plan or state; " +
+					"change `secrets_version` to send a new URL. Requires Terraform 1.11 or later.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(notificationURLPattern, "must be an http:// or https:// URL"),
+				},
+			},
+		},
+	}
+}
+
+// notificationProviderReplace replaces the resource when the provider
+// changes, that is when a provider attribute is added or removed
+func notificationProviderReplace() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+		},
+		"Changing the notification provider requires replacement.",
+		"Changing the notification provider requires replacement.",
+	)
+}
+
+// ConfigValidators requires exactly one provider to be configured.
+func (r *NotificationResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("email"),
+			path.MatchRoot("webhook"),
+			path.MatchRoot("slack"),
+			path.MatchRoot("teams"),
+		),
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *NotificationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// CRUD methods are implemented in resource_notification_impl.go
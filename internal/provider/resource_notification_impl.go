@@ -0,0 +1,341 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// notificationProviders lists the provider names accepted on import
+var notificationProviders = []string{
+	snitchdns.NotificationProviderEmail,
+	snitchdns.NotificationProviderWebhook,
+	snitchdns.NotificationProviderSlack,
+	snitchdns.NotificationProviderTeams,
+}
+
+// Create implements the resource create logic
+func (r *NotificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create notification")
+		return
+	}
+
+	var data NotificationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config NotificationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *NotificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data NotificationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	provider := data.providerName()
+	subscription, err := r.client.GetZoneNotification(ctx, data.ZoneID.ValueString(), provider)
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "Zone or notification provider not found, removing notification from state", map[string]any{
+				"zone_id":  data.ZoneID.ValueString(),
+				"provider": provider,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading notification",
+			fmt.Sprintf("Could not read %s notifications of zone ID %s", provider, data.ZoneID.ValueString()), err)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setFromSubscription(ctx, provider, subscription)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *NotificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update notification")
+		return
+	}
+
+	var data NotificationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config NotificationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. The subscription is disabled;
+// its configuration is left on the server.
+func (r *NotificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete notification")
+		return
+	}
+
+	var data NotificationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	provider := data.providerName()
+	disabled := false
+	_, err := r.client.UpdateZoneNotification(ctx, data.ZoneID.ValueString(), provider,
+		snitchdns.UpdateNotificationRequest{Enabled: &disabled})
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Zone is already gone, nothing to disable
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error disabling notification",
+			fmt.Sprintf("Could not update %s notifications of zone ID %s", provider, data.ZoneID.ValueString()), err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic
+func (r *NotificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import notification")
+		return
+	}
+
+	// Import ID format: "zone_id:provider" or "zone_id/provider"
+	zoneID, provider, ok := strings.Cut(req.ID, ":")
+	if !ok {
+		zoneID, provider, ok = strings.Cut(req.ID, "/")
+	}
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID format",
+			fmt.Sprintf("Expected import ID format 'zone_id:provider' or 'zone_id/provider', got: %s", req.ID),
+		)
+		return
+	}
+
+	if _, err := strconv.Atoi(zoneID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Zone ID must be numeric, got: %s", req.ID),
+		)
+		return
+	}
+
+	provider = strings.ToLower(provider)
+	if !slices.Contains(notificationProviders, provider) {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Provider must be one of %s, got: %s", strings.Join(notificationProviders, ", "), provider),
+		)
+		return
+	}
+
+	// Read derives the provider from the ID until a provider attribute is set
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), zoneID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), zoneID+":"+provider)...)
+}
+
+// apply writes the planned subscription and refreshes the model from the
+// response. Write-only values are taken from the configuration, as the
+// plan never holds them.
+func (r *NotificationResource) apply(ctx context.Context, data, config *NotificationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	provider := data.providerName()
+	request, d := data.request(ctx, config)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// The URL of a write-only attribute must not show up in errors or
+	// debug logs of the request sending it
+	if url := data.writeOnlyURL(config); url != "" {
+		r.client.AddSensitiveValue(url)
+	}
+
+	subscription, err := r.client.UpdateZoneNotification(ctx, data.ZoneID.ValueString(), provider, request)
+	if err != nil {
+		addAPIError(&diags, "Error setting notification",
+			fmt.Sprintf("Could not update %s notifications of zone ID %s", provider, data.ZoneID.ValueString()), err)
+		return diags
+	}
+
+	diags.Append(data.setFromSubscription(ctx, provider, subscription)...)
+	return diags
+}
+
+// providerName returns the name of the configured provider. After import no
+// provider attribute is set yet, so the name is taken from the ID.
+func (m *NotificationResourceModel) providerName() string {
+	switch {
+	case m.Email != nil:
+		return snitchdns.NotificationProviderEmail
+	case m.Webhook != nil:
+		return snitchdns.NotificationProviderWebhook
+	case m.Slack != nil:
+		return snitchdns.NotificationProviderSlack
+	case m.Teams != nil:
+		return snitchdns.NotificationProviderTeams
+	}
+
+	_, provider, _ := strings.Cut(m.ID.ValueString(), ":")
+	return provider
+}
+
+// request converts the data model to an API request, with the write-only
+// URL of the configuration
+func (m *NotificationResourceModel) request(ctx context.Context, config *NotificationResourceModel) (snitchdns.UpdateNotificationRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	enabled := m.Enabled.ValueBool()
+	request := snitchdns.UpdateNotificationRequest{Enabled: &enabled}
+
+	switch {
+	case m.Email != nil:
+		var recipients []string
+		diags.Append(m.Email.Recipients.ElementsAs(ctx, &recipients, false)...)
+		sort.Strings(recipients)
+		request.Data = recipients
+	case m.Webhook != nil:
+		request.Data = m.Webhook.url(config.Webhook)
+	case m.Slack != nil:
+		request.Data = m.Slack.url(config.Slack)
+	case m.Teams != nil:
+		request.Data = m.Teams.url(config.Teams)
+	}
+
+	return request, diags
+}
+
+// url returns the URL to send: url_wo of the configuration when it is set,
+// url otherwise
+func (m *NotificationWebhookModel) url(config *NotificationWebhookModel) string {
+	if config != nil && !config.URLWO.IsNull() {
+		return config.URLWO.ValueString()
+	}
+	return m.URL.ValueString()
+}
+
+// writeOnlyURL returns the URL taken from url_wo of the configuration, or ""
+// when the URL is not configured write-only
+func (m *NotificationResourceModel) writeOnlyURL(config *NotificationResourceModel) string {
+	switch {
+	case m.Webhook != nil && config.Webhook != nil && !config.Webhook.URLWO.IsNull():
+		return config.Webhook.URLWO.ValueString()
+	case m.Slack != nil && config.Slack != nil && !config.Slack.URLWO.IsNull():
+		return config.Slack.URLWO.ValueString()
+	case m.Teams != nil && config.Teams != nil && !config.Teams.URLWO.IsNull():
+		return config.Teams.URLWO.ValueString()
+	}
+	return ""
+}
+
+// writeOnly reports whether the URL is configured write-only, so it is
+// kept out of state
+func (m *NotificationWebhookModel) writeOnly() bool {
+	return m != nil && m.URL.IsNull()
+}
+
+// setFromSubscription maps the subscription to the data model
+func (m *NotificationResourceModel) setFromSubscription(ctx context.Context, provider string, subscription *snitchdns.NotificationSubscription) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// A URL configured write-only stays out of state. Imported
+	// subscriptions have no URL attribute yet and read it.
+	writeOnly := m.Webhook.writeOnly() || m.Slack.writeOnly() || m.Teams.writeOnly()
+
+	m.ID = types.StringValue(m.ZoneID.ValueString() + ":" + provider)
+	m.Enabled = types.BoolValue(subscription.Enabled)
+	m.Email, m.Webhook, m.Slack, m.Teams = nil, nil, nil, nil
+
+	if provider == snitchdns.NotificationProviderEmail {
+		recipients, err := subscription.Recipients()
+		if err != nil {
+			diags.AddError("Error reading notification", err.Error())
+			return diags
+		}
+
+		emails, d := types.SetValueFrom(ctx, types.StringType, recipients)
+		diags.Append(d...)
+		m.Email = &NotificationEmailModel{Recipients: emails}
+		return diags
+	}
+
+	webhook := &NotificationWebhookModel{URL: types.StringNull(), URLWO: types.StringNull()}
+	if !writeOnly {
+		url, err := subscription.URL()
+		if err != nil {
+			diags.AddError("Error reading notification", err.Error())
+			return diags
+		}
+		webhook.URL = types.StringValue(url)
+	}
+	switch provider {
+	case snitchdns.NotificationProviderWebhook:
+		m.Webhook = webhook
+	case snitchdns.NotificationProviderSlack:
+		m.Slack = webhook
+	case snitchdns.NotificationProviderTeams:
+		m.Teams = webhook
+	}
+
+	return diags
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// emailAddressPattern is a deliberately loose email check; SnitchDNS performs
+// the authoritative validation
+var emailAddressPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+$`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NotificationRecipientsResource{}
+var _ resource.ResourceWithImportState = &NotificationRecipientsResource{}
+
+// NewNotificationRecipientsResource creates a new Notification Recipients resource.
+func NewNotificationRecipientsResource() resource.Resource {
+	return &NotificationRecipientsResource{}
+}
+
+// NotificationRecipientsResource defines the resource implementation.
+type NotificationRecipientsResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// NotificationRecipientsResourceModel describes the resource data model.
+type NotificationRecipientsResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	ZoneID types.String `tfsdk:"zone_id"`
+	Emails types.Set    `tfsdk:"emails"`
+}
+
+// Metadata sets the resource type name.
+func (r *NotificationRecipientsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_recipients"
+}
+
+// Schema defines the resource schema.
+func (r *NotificationRecipientsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the email recipients of a zone's notifications. " +
+			"Only the recipient list is managed; whether email notifications are enabled is left unchanged.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, equal to `zone_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone whose notification recipients are managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"emails": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Email addresses notified about queries to the zone.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(emailAddressPattern, "must be an email address"),
+					),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *NotificationRecipientsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// CRUD methods are implemented in resource_notification_recipients_impl.go
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *NotificationRecipientsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create notification recipients")
+		return
+	}
+
+	var data NotificationRecipientsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *NotificationRecipientsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data NotificationRecipientsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subscription, err := r.client.GetZoneNotification(ctx, data.ZoneID.ValueString(), snitchdns.NotificationProviderEmail)
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "Zone not found, removing notification recipients from state", map[string]any{
+				"zone_id": data.ZoneID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading notification recipients",
+			fmt.Sprintf("Could not read email notifications of zone ID %s", data.ZoneID.ValueString()), err)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setFromSubscription(ctx, subscription)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *NotificationRecipientsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update notification recipients")
+		return
+	}
+
+	var data NotificationRecipientsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. The recipient list is emptied.
+func (r *NotificationRecipientsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete notification recipients")
+		return
+	}
+
+	var data NotificationRecipientsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.UpdateZoneNotification(ctx, data.ZoneID.ValueString(), snitchdns.NotificationProviderEmail,
+		snitchdns.UpdateNotificationRequest{Data: []string{}})
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Zone is already gone, nothing to clear
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error clearing notification recipients",
+			fmt.Sprintf("Could not update email notifications of zone ID %s", data.ZoneID.ValueString()), err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic
+func (r *NotificationRecipientsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import notification recipients")
+		return
+	}
+
+	// Use the zone ID from the import request
+	resource.ImportStatePassthroughID(ctx, path.Root("zone_id"), req, resp)
+}
+
+// apply writes the planned recipients and refreshes the model from the
+// response
+func (r *NotificationRecipientsResource) apply(ctx context.Context, data *NotificationRecipientsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var emails []string
+	diags.Append(data.Emails.ElementsAs(ctx, &emails, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	sort.Strings(emails)
+
+	subscription, err := r.client.UpdateZoneNotification(ctx, data.ZoneID.ValueString(), snitchdns.NotificationProviderEmail,
+		snitchdns.UpdateNotificationRequest{Data: emails})
+	if err != nil {
+		addAPIError(&diags, "Error setting notification recipients",
+			fmt.Sprintf("Could not update email notifications of zone ID %s", data.ZoneID.ValueString()), err)
+		return diags
+	}
+
+	diags.Append(data.setFromSubscription(ctx, subscription)...)
+	return diags
+}
+
+// setFromSubscription maps the email subscription to the data model
+func (m *NotificationRecipientsResourceModel) setFromSubscription(ctx context.Context, subscription *snitchdns.NotificationSubscription) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	recipients, err := subscription.Recipients()
+	if err != nil {
+		diags.AddError("Error reading notification recipients", err.Error())
+		return diags
+	}
+
+	emails, d := types.SetValueFrom(ctx, types.StringType, recipients)
+	diags.Append(d...)
+
+	m.ID = m.ZoneID
+	m.Emails = emails
+	return diags
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccNotificationRecipientsResource tests adding and removing notification recipients
+func TestAccNotificationRecipientsResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotificationRecipientsResourceConfig(container, "notify.example.com", "alice@example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_notification_recipients.test", "emails.#", "1"),
+					resource.TestCheckTypeSetElemAttr("snitchdns_notification_recipients.test", "emails.*", "alice@example.com"),
+				),
+			},
+			{
+				ResourceName:                         "snitchdns_notification_recipients.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "zone_id",
+				ImportStateIdFunc:                    testAccNotificationRecipientsImportStateIdFunc,
+			},
+			{
+				Config: testAccNotificationRecipientsResourceConfig(container, "notify.example.com", "alice@example.com", "bob@example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_notification_recipients.test", "emails.#", "2"),
+					resource.TestCheckTypeSetElemAttr("snitchdns_notification_recipients.test", "emails.*", "bob@example.com"),
+				),
+			},
+		},
+	})
+}
+
+// testAccNotificationRecipientsImportStateIdFunc returns the zone ID of the recipients resource
+func testAccNotificationRecipientsImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["snitchdns_notification_recipients.test"]
+	if !ok {
+		return "", fmt.Errorf("Resource not found")
+	}
+
+	return rs.Primary.Attributes["zone_id"], nil
+}
+
+// testAccNotificationRecipientsResourceConfig generates HCL configuration for notification recipients testing
+func testAccNotificationRecipientsResourceConfig(container *testcontainer.SnitchDNSContainer, domain string, emails ...string) string {
+	quoted := make([]string, len(emails))
+	for i, email := range emails {
+		quoted[i] = fmt.Sprintf("%q", email)
+	}
+
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = %[3]q
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_notification_recipients" "test" {
+  zone_id = snitchdns_zone.test.id
+  emails  = [%[4]s]
+}
+`, container.GetAPIEndpoint(), container.APIKey, domain, strings.Join(quoted, ", "))
+}
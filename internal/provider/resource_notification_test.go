@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccNotificationResource tests configuring and switching notification providers
+func TestAccNotificationResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotificationResourceConfig(container, `
+  email = {
+    recipients = ["alice@example.com"]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("snitchdns_notification.test", "zone_id", "snitchdns_zone.test", "id"),
+					resource.TestCheckResourceAttr("snitchdns_notification.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("snitchdns_notification.test", "email.recipients.#", "1"),
+					resource.TestCheckTypeSetElemAttr("snitchdns_notification.test", "email.recipients.*", "alice@example.com"),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_notification.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccNotificationResourceConfig(container, `
+  enabled = false
+  webhook = {
+    url = "https://hooks.example.com/snitch"
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_notification.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("snitchdns_notification.test", "webhook.url", "https://hooks.example.com/snitch"),
+					resource.TestCheckNoResourceAttr("snitchdns_notification.test", "email.recipients.#"),
+				),
+			},
+		},
+	})
+}
+
+// TestNotificationProviderName tests that the provider is taken from the configured attribute or the ID
+func TestNotificationProviderName(t *testing.T) {
+	cases := []struct {
+		name  string
+		model NotificationResourceModel
+		want  string
+	}{
+		{"email", NotificationResourceModel{Email: &NotificationEmailModel{}}, "email"},
+		{"slack", NotificationResourceModel{Slack: &NotificationWebhookModel{}}, "slack"},
+		{"imported", NotificationResourceModel{ID: types.StringValue("5:teams")}, "teams"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.model.providerName(); got != tc.want {
+				t.Errorf("providerName() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestNotificationResource_WriteOnly tests that a write-only webhook URL is
+// sent to the server and redacted, but kept out of state
+func TestNotificationResource_WriteOnly(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	zone := mock.AddZone(snitchdns.Zone{Domain: "notification.example.com"})
+	r := newMockResource(t, NewNotificationResource(), mock)
+
+	webhook := func(url, urlWO types.String) types.Object {
+		return types.ObjectValueMust(
+			map[string]attr.Type{"url": types.StringType, "url_wo": types.StringType},
+			map[string]attr.Value{"url": url, "url_wo": urlWO},
+		)
+	}
+	attributes := map[string]attr.Value{
+		"zone_id":         types.StringValue(fmt.Sprint(zone.ID)),
+		"secrets_version": types.Int64Value(1),
+		"webhook":         webhook(types.StringNull(), types.StringNull()),
+	}
+	// Terraform plans write-only attributes as null, so they are only set
+	// in the configuration
+	plan := mockPlan(t, r, attributes)
+	attributes["webhook"] = webhook(types.StringNull(), types.StringValue("https://hooks.example.com/secret-1"))
+	config := tfsdk.Config(mockPlan(t, r, attributes))
+
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan, Config: config}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+	subscription, err := mock.GetZoneNotification(ctx, fmt.Sprint(zone.ID), snitchdns.NotificationProviderWebhook)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if url, _ := subscription.URL(); url != "https://hooks.example.com/secret-1" {
+		t.Fatalf("Expected the write-only URL to be sent, got %q", url)
+	}
+
+	var created NotificationResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.Webhook == nil || !created.Webhook.URL.IsNull() || !created.Webhook.URLWO.IsNull() {
+		t.Fatalf("Expected no URL in state, got %+v", created.Webhook)
+	}
+
+	// Reading keeps the URL out of state
+	readResp := fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", readResp.Diagnostics)
+	}
+	var read NotificationResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.Webhook == nil || !read.Webhook.URL.IsNull() {
+		t.Fatalf("Expected no URL in state after a read, got %+v", read.Webhook)
+	}
+
+	// A new secrets version sends the rotated URL
+	rotated := readResp.State
+	rotated.SetAttribute(ctx, path.Root("secrets_version"), int64(2))
+	attributes["secrets_version"] = types.Int64Value(2)
+	attributes["webhook"] = webhook(types.StringNull(), types.StringValue("https://hooks.example.com/secret-2"))
+	config = tfsdk.Config(mockPlan(t, r, attributes))
+	updateResp := fwresource.UpdateResponse{State: rotated}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: tfsdk.Plan(rotated), Config: config, State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected update error: %v", updateResp.Diagnostics)
+	}
+	subscription, _ = mock.GetZoneNotification(ctx, fmt.Sprint(zone.ID), snitchdns.NotificationProviderWebhook)
+	if url, _ := subscription.URL(); url != "https://hooks.example.com/secret-2" {
+		t.Errorf("Expected the rotated URL to be sent, got %q", url)
+	}
+
+	want := []string{"https://hooks.example.com/secret-1", "https://hooks.example.com/secret-2"}
+	if got := mock.SensitiveValues(); !slices.Equal(got, want) {
+		t.Errorf("Expected the write-only URLs to be registered for redaction, got %v", got)
+	}
+}
+
+// testAccNotificationResourceConfig generates HCL configuration for notification testing
+func testAccNotificationResourceConfig(container *testcontainer.SnitchDNSContainer, settings string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = "notification.example.com"
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_notification" "test" {
+  zone_id = snitchdns_zone.test.id
+%[3]s
+}
+`, container.GetAPIEndpoint(), container.APIKey, settings)
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PTRRecordResource{}
+var _ resource.ResourceWithImportState = &PTRRecordResource{}
+var _ resource.ResourceWithModifyPlan = &PTRRecordResource{}
+
+// NewPTRRecordResource creates a new PTR Record resource.
+func NewPTRRecordResource() resource.Resource {
+	return &PTRRecordResource{}
+}
+
+// PTRRecordResource defines the resource implementation. SnitchDNS answers
+// a name from the zone with exactly that domain, so the PTR record of an
+// address lives in a zone named after the address' reverse name, which the
+// resource creates unless one already exists or is given.
+type PTRRecordResource struct {
+	client        snitchdns.ClientInterface
+	records       *RecordCache
+	recordData    *recordDataMapper
+	offline       bool
+	defaultUserID int
+	defaultTTL    int64
+}
+
+// PTRRecordResourceModel describes the resource data model.
+type PTRRecordResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	IPAddress   types.String `tfsdk:"ip_address"`
+	Hostname    DomainValue  `tfsdk:"hostname"`
+	TTL         types.Int64  `tfsdk:"ttl"`
+	ZoneID      types.String `tfsdk:"zone_id"`
+	Name        DomainValue  `tfsdk:"name"`
+	ZoneManaged types.Bool   `tfsdk:"zone_managed"`
+}
+
+// Metadata sets the resource type name.
+func (r *PTRRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ptr_record"
+}
+
+// Schema defines the resource schema.
+func (r *PTRRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the PTR record of an IP address. " +
+			"The reverse name, such as `10.2.0.192.in-addr.arpa`, is computed from the address, and the zone serving it is created unless it already exists or `zone_id` is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the underlying DNS record.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "IPv4 or IPv6 address the record resolves, such as `192.0.2.10` or `2001:db8::1`. Changing this forces a new resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Host name the address resolves to, such as `gateway.example.com`. Case and a trailing dot are ignored when comparing with the server's value.",
+				CustomType:          DomainType{},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Time to live in seconds, between 1 and 2147483647. Defaults to the provider's `default_record_ttl`.",
+				Validators: []validator.Int64{
+					recordTTLValidator(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "ID of an existing zone for the reverse name, such as one managed by `snitchdns_zone`. " +
+					"When omitted, the zone with the reverse name is used, and created if there is none. Changing a configured value forces a new resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Reverse name of the address, e.g. `10.2.0.192.in-addr.arpa`.",
+				CustomType:          DomainType{},
+			},
+			"zone_managed": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the zone was created by this resource, in which case it is deleted with the record.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *PTRRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.records = providerData.Records
+	r.recordData = providerData.RecordData
+	r.offline = providerData.Offline
+	r.defaultUserID = providerData.DefaultUserID
+	r.defaultTTL = providerData.DefaultRecordTTL
+}
+
+// CRUD methods are implemented in resource_ptr_record_impl.go
@@ -0,0 +1,364 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *PTRRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create PTR record")
+		return
+	}
+
+	var data PTRRecordResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, err := ptrRecordName(data.IPAddress.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ip_address"), "Invalid IP Address", err.Error())
+		return
+	}
+
+	zone, created := r.reverseZone(ctx, data.ZoneID, name, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	zoneID := strconv.Itoa(zone.ID)
+
+	record, err := r.client.CreateRecordWithContext(ctx, zoneID, snitchdns.CreateRecordRequest{
+		Active: true,
+		Class:  "IN",
+		Type:   "PTR",
+		TTL:    int(data.TTL.ValueInt64()),
+		Data:   r.recordData.ToServer("PTR", map[string]interface{}{"name": data.Hostname.ValueString()}),
+	})
+	r.records.Invalidate(zoneID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error creating PTR record", "Could not create record", err, ttlErrorAttribute)
+		if created {
+			// Don't leave behind an empty zone nothing in the state refers to
+			if err := r.client.DeleteZoneWithContext(ctx, zoneID); err != nil {
+				addAPIError(&resp.Diagnostics, "Error deleting reverse zone",
+					fmt.Sprintf("Could not delete zone %s created for the PTR record", name), err)
+			}
+		}
+		return
+	}
+
+	data.ZoneID = types.StringValue(zoneID)
+	data.Name = NewDomainValue(name)
+	data.ZoneManaged = types.BoolValue(created)
+	data.setFromRecord(r.recordData.FromServerRecord(record))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *PTRRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data PTRRecordResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, err := ptrRecordName(data.IPAddress.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ip_address"), "Invalid IP Address", err.Error())
+		return
+	}
+	data.Name = NewDomainValue(name)
+
+	var record *snitchdns.Record
+	if data.ID.IsNull() {
+		// Imported by address, so the zone and record are looked up by name
+		record = r.importRecord(ctx, &data, &resp.Diagnostics)
+	} else {
+		var found bool
+		record, found, err = r.records.GetRecord(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
+		if err != nil && !snitchdns.IsNotFound(err) {
+			addAPIError(&resp.Diagnostics, "Error reading PTR record",
+				fmt.Sprintf("Could not read record ID %s in zone %s", data.ID.ValueString(), data.ZoneID.ValueString()), err)
+			return
+		}
+		if !found {
+			record = nil
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if record == nil {
+		tflog.Warn(ctx, "PTR record not found, removing from state", map[string]any{
+			"ip_address": data.IPAddress.ValueString(),
+			"zone_id":    data.ZoneID.ValueString(),
+			"record_id":  data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.setFromRecord(r.recordData.FromServerRecord(record))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *PTRRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update PTR record")
+		return
+	}
+
+	var data PTRRecordResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ttl := int(data.TTL.ValueInt64())
+
+	record, err := r.client.UpdateRecordWithContext(ctx, data.ZoneID.ValueString(), data.ID.ValueString(), snitchdns.UpdateRecordRequest{
+		TTL:  &ttl,
+		Data: r.recordData.ToServer("PTR", map[string]interface{}{"name": data.Hostname.ValueString()}),
+	})
+	r.records.Invalidate(data.ZoneID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating PTR record",
+			fmt.Sprintf("Could not update record ID %s", data.ID.ValueString()), err, ttlErrorAttribute)
+		return
+	}
+
+	data.setFromRecord(r.recordData.FromServerRecord(record))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic
+func (r *PTRRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete PTR record")
+		return
+	}
+
+	var data PTRRecordResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+
+	err := r.client.DeleteRecordWithContext(ctx, zoneID, data.ID.ValueString())
+	r.records.Invalidate(zoneID)
+	if err != nil && !snitchdns.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Error deleting PTR record",
+			fmt.Sprintf("Could not delete record ID %s", data.ID.ValueString()), err)
+		return
+	}
+
+	if !data.ZoneManaged.ValueBool() {
+		return
+	}
+
+	// Records added to the zone outside this resource keep it alive
+	records, err := r.records.ListRecords(ctx, zoneID)
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error reading reverse zone",
+			fmt.Sprintf("Could not list the records of zone %s", data.Name.ValueString()), err)
+		return
+	}
+	if len(records) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Reverse zone kept",
+			fmt.Sprintf("Zone %s was created for the PTR record of %s but still has %d other records, so it was not deleted.",
+				data.Name.ValueString(), data.IPAddress.ValueString(), len(records)),
+		)
+		return
+	}
+
+	err = r.client.DeleteZoneWithContext(ctx, zoneID)
+	r.records.Invalidate(zoneID)
+	if err != nil && !snitchdns.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Error deleting reverse zone",
+			fmt.Sprintf("Could not delete zone %s", data.Name.ValueString()), err)
+	}
+}
+
+// ImportState implements the resource import logic
+func (r *PTRRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import PTR record")
+		return
+	}
+
+	// Import ID format: the IP address
+	if _, err := ptrRecordName(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected the IP address of the PTR record, got: %s", req.ID),
+		)
+		return
+	}
+
+	// An imported zone is never deleted with the record
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip_address"), strings.TrimSpace(req.ID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_managed"), false)...)
+}
+
+// ModifyPlan plans the default TTL and computes the reverse name of the
+// planned address, so it is known to the resources referencing it and
+// malformed addresses fail at plan time
+func (r *PTRRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// The resource is being destroyed
+		return
+	}
+
+	planDefaultTTL(ctx, req, resp, r.defaultTTL)
+
+	var ip types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ip_address"), &ip)...)
+	if resp.Diagnostics.HasError() || ip.IsUnknown() || ip.IsNull() {
+		return
+	}
+
+	name, err := ptrRecordName(ip.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ip_address"), "Invalid IP Address", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// reverseZone returns the configured zone after checking it serves the
+// reverse name, or else the zone with the reverse name, creating it if there
+// is none. created reports whether the zone was created.
+func (r *PTRRecordResource) reverseZone(ctx context.Context, zoneID types.String, name string, diags *diag.Diagnostics) (zone *snitchdns.Zone, created bool) {
+	if !zoneID.IsUnknown() && !zoneID.IsNull() {
+		zone, err := r.client.GetZoneWithContext(ctx, zoneID.ValueString())
+		if err != nil {
+			addAPIError(diags, "Error reading reverse zone", fmt.Sprintf("Could not read zone ID %s", zoneID.ValueString()), err)
+			return nil, false
+		}
+
+		if !strings.EqualFold(strings.TrimSuffix(zone.Domain, "."), name) {
+			diags.AddAttributeError(
+				path.Root("zone_id"),
+				"Zone does not serve the reverse name",
+				fmt.Sprintf("Zone %s is not the zone of %s, so SnitchDNS would never answer the PTR record. "+
+					"Omit zone_id to use or create that zone.", zone.Domain, name),
+			)
+			return nil, false
+		}
+		return zone, false
+	}
+
+	zone, err := snitchdns.FindZoneByDomain(ctx, r.client, name)
+	if err == nil {
+		return zone, false
+	}
+	if !errors.Is(err, snitchdns.ErrZoneNotFound) {
+		addAPIError(diags, "Error reading reverse zone", fmt.Sprintf("Could not look up zone %s", name), err)
+		return nil, false
+	}
+
+	tflog.Debug(ctx, "Creating reverse zone for PTR record", map[string]any{
+		"domain": name,
+	})
+	zone, err = r.client.CreateZoneWithContext(ctx, snitchdns.CreateZoneRequest{
+		Domain: name,
+		Active: true,
+		Tags:   snitchdns.ZoneTags{},
+		UserID: r.defaultUserID,
+	})
+	if err != nil {
+		addAPIError(diags, "Error creating reverse zone", fmt.Sprintf("Could not create zone %s", name), err)
+		return nil, false
+	}
+	return zone, true
+}
+
+// importRecord finds the zone and the single PTR record of an imported
+// address and sets their IDs on the model. It returns nil when either does
+// not exist.
+func (r *PTRRecordResource) importRecord(ctx context.Context, data *PTRRecordResourceModel, diags *diag.Diagnostics) *snitchdns.Record {
+	zone, err := snitchdns.FindZoneByDomain(ctx, r.client, data.Name.ValueString())
+	if err != nil {
+		if !errors.Is(err, snitchdns.ErrZoneNotFound) {
+			addAPIError(diags, "Error reading reverse zone", fmt.Sprintf("Could not look up zone %s", data.Name.ValueString()), err)
+		}
+		return nil
+	}
+	zoneID := strconv.Itoa(zone.ID)
+
+	records, err := r.records.ListRecords(ctx, zoneID)
+	if err != nil {
+		addAPIError(diags, "Error reading PTR record", fmt.Sprintf("Could not list the records of zone %s", zone.Domain), err)
+		return nil
+	}
+
+	var ptrs []snitchdns.Record
+	for _, record := range records {
+		if strings.EqualFold(record.Type, "PTR") {
+			ptrs = append(ptrs, record)
+		}
+	}
+	switch len(ptrs) {
+	case 0:
+		return nil
+	case 1:
+		data.ZoneID = types.StringValue(zoneID)
+		return &ptrs[0]
+	default:
+		diags.AddError(
+			"Ambiguous PTR record",
+			fmt.Sprintf("Zone %s has %d PTR records; import them with snitchdns_record instead.", zone.Domain, len(ptrs)),
+		)
+		return nil
+	}
+}
+
+// ptrRecordName returns the reverse name of a single address; networks are
+// rejected, since they name a reverse zone rather than a record
+func ptrRecordName(ip string) (string, error) {
+	if _, err := netip.ParseAddr(strings.TrimSpace(ip)); err != nil {
+		return "", fmt.Errorf("invalid IP address %q: expected a single IPv4 or IPv6 address", ip)
+	}
+	return reversePTR(ip)
+}
+
+// setFromRecord maps the record to the data model
+func (m *PTRRecordResourceModel) setFromRecord(record *snitchdns.Record) {
+	m.ID = types.StringValue(strconv.Itoa(record.ID))
+	m.TTL = types.Int64Value(int64(record.TTL))
+	m.Hostname = NewDomainValue(record.Data.Get("name"))
+}
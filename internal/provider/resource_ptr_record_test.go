@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccPTRRecordResource tests managing a PTR record together with its
+// reverse zone
+func TestAccPTRRecordResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPTRRecordResourceConfig(container, "192.0.2.0/24", "gateway.example.com"),
+				ExpectError: regexp.MustCompile(`Invalid IP Address`),
+			},
+			{
+				Config: testAccPTRRecordResourceConfig(container, "192.0.2.10", "gateway.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_ptr_record.test", "name", "10.2.0.192.in-addr.arpa"),
+					resource.TestCheckResourceAttr("snitchdns_ptr_record.test", "hostname", "gateway.example.com"),
+					resource.TestCheckResourceAttr("snitchdns_ptr_record.test", "ttl", "300"),
+					resource.TestCheckResourceAttr("snitchdns_ptr_record.test", "zone_managed", "true"),
+					resource.TestCheckResourceAttrSet("snitchdns_ptr_record.test", "zone_id"),
+				),
+			},
+			{
+				ResourceName:            "snitchdns_ptr_record.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           "192.0.2.10",
+				ImportStateVerifyIgnore: []string{"zone_managed"},
+			},
+			{
+				Config: testAccPTRRecordResourceConfig(container, "192.0.2.10", "router.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_ptr_record.test", "hostname", "router.example.com"),
+				),
+			},
+		},
+	})
+}
+
+// testAccPTRRecordResourceConfig generates HCL configuration for PTR record testing
+func testAccPTRRecordResourceConfig(container *testcontainer.SnitchDNSContainer, ip, hostname string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_ptr_record" "test" {
+  ip_address = %[3]q
+  hostname   = %[4]q
+}
+`, container.GetAPIEndpoint(), container.APIKey, ip, hostname)
+}
+
+// TestPTRRecordResource_Mock tests that the reverse zone is created with the
+// record and deleted with it once empty
+func TestPTRRecordResource_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := newMockResource(t, NewPTRRecordResource(), mock)
+
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"ip_address": types.StringValue("2001:db8::1"),
+		"hostname":   types.StringValue("gateway.example.com"),
+		"ttl":        types.Int64Value(300),
+		"zone_id":    types.StringUnknown(),
+	})
+
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	zones := mock.Zones()
+	wantName := "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"
+	if len(zones) != 1 || zones[0].Domain != wantName {
+		t.Fatalf("Expected the reverse zone to be created, got %+v", zones)
+	}
+	records := mock.Records(zones[0].ID)
+	if len(records) != 1 || records[0].Type != "PTR" || records[0].Data.Get("name") != "gateway.example.com" {
+		t.Fatalf("Expected the PTR record to be created, got %+v", records)
+	}
+
+	var managed types.Bool
+	createResp.State.GetAttribute(ctx, path.Root("zone_managed"), &managed)
+	if !managed.ValueBool() {
+		t.Error("Expected the created zone to be managed")
+	}
+
+	deleteResp := fwresource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: createResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected delete error: %v", deleteResp.Diagnostics)
+	}
+	if zones := mock.Zones(); len(zones) != 0 {
+		t.Errorf("Expected the empty reverse zone to be deleted, got %+v", zones)
+	}
+}
+
+// TestPTRRecordResource_MockExistingZone tests that an existing reverse zone
+// is used and kept
+func TestPTRRecordResource_MockExistingZone(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	zone := mock.AddZone(snitchdns.Zone{Domain: "10.2.0.192.in-addr.arpa", Active: true})
+	other := mock.AddZone(snitchdns.Zone{Domain: "example.com", Active: true})
+	r := newMockResource(t, NewPTRRecordResource(), mock)
+
+	// A configured zone must serve the reverse name
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"ip_address": types.StringValue("192.0.2.10"),
+		"hostname":   types.StringValue("gateway.example.com"),
+		"ttl":        types.Int64Value(300),
+		"zone_id":    types.StringValue(fmt.Sprint(other.ID)),
+	})
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("Expected an error for a zone of another name")
+	}
+
+	plan = mockPlan(t, r, map[string]attr.Value{
+		"ip_address": types.StringValue("192.0.2.10"),
+		"hostname":   types.StringValue("gateway.example.com"),
+		"ttl":        types.Int64Value(300),
+		"zone_id":    types.StringUnknown(),
+	})
+	createResp = fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	var zoneID types.String
+	var managed types.Bool
+	createResp.State.GetAttribute(ctx, path.Root("zone_id"), &zoneID)
+	createResp.State.GetAttribute(ctx, path.Root("zone_managed"), &managed)
+	if zoneID.ValueString() != fmt.Sprint(zone.ID) || managed.ValueBool() {
+		t.Errorf("Expected the existing zone %d to be used, got %s (managed %s)", zone.ID, zoneID, managed)
+	}
+
+	deleteResp := fwresource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: createResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected delete error: %v", deleteResp.Diagnostics)
+	}
+	if zones := mock.Zones(); len(zones) != 2 || len(mock.Records(zone.ID)) != 0 {
+		t.Errorf("Expected only the record to be deleted, got %+v", zones)
+	}
+}
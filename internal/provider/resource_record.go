@@ -4,21 +4,36 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
-	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"snitchdns-tf/internal/client"
 )
 
+const (
+	// recordOnDestroyDelete deletes the record when the resource is destroyed
+	recordOnDestroyDelete = "delete"
+	// recordOnDestroyDisable deactivates the record instead, keeping its hit
+	// history in SnitchDNS
+	recordOnDestroyDisable = "disable"
+)
+
+// recordTypes are the record types SnitchDNS serves
+var recordTypes = []string{"A", "AAAA", "AFSDB", "CAA", "CNAME", "DNAME", "HINFO", "MX", "NAPTR", "NS", "PTR", "RP", "SOA", "SPF", "SRV", "SSHFP", "TSIG", "TXT"}
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RecordResource{}
 var _ resource.ResourceWithImportState = &RecordResource{}
+var _ resource.ResourceWithModifyPlan = &RecordResource{}
+var _ resource.ResourceWithConfigValidators = &RecordResource{}
 
 // NewRecordResource creates a new Record resource.
 func NewRecordResource() resource.Resource {
@@ -27,24 +42,42 @@ func NewRecordResource() resource.Resource {
 
 // RecordResource defines the resource implementation.
 type RecordResource struct {
-	client *client.Client
+	client             snitchdns.ClientInterface
+	records            *RecordCache
+	recordData         *recordDataMapper
+	validator          *requestValidator
+	dnsServer          string
+	offline            bool
+	skipReadAfterWrite bool
+	defaultTTL         int64
 }
 
 // RecordResourceModel describes the resource data model.
 type RecordResourceModel struct {
-	ID               types.String   `tfsdk:"id"`
-	ZoneID           types.String   `tfsdk:"zone_id"`
-	Active           types.Bool     `tfsdk:"active"`
-	Class            types.String   `tfsdk:"cls"`
-	Type             types.String   `tfsdk:"type"`
-	TTL              types.Int64    `tfsdk:"ttl"`
-	Data             types.Map      `tfsdk:"data"`
-	IsConditional    types.Bool     `tfsdk:"is_conditional"`
-	ConditionalCount types.Int64    `tfsdk:"conditional_count"`
-	ConditionalLimit types.Int64    `tfsdk:"conditional_limit"`
-	ConditionalReset types.Bool     `tfsdk:"conditional_reset"`
-	ConditionalData  types.Map      `tfsdk:"conditional_data"`
-	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	ID               types.String    `tfsdk:"id"`
+	ZoneID           types.String    `tfsdk:"zone_id"`
+	Active           types.Bool      `tfsdk:"active"`
+	ActiveFrom       types.String    `tfsdk:"active_from"`
+	ActiveUntil      types.String    `tfsdk:"active_until"`
+	Class            types.String    `tfsdk:"cls"`
+	Type             types.String    `tfsdk:"type"`
+	TTL              types.Int64     `tfsdk:"ttl"`
+	Data             RecordDataValue `tfsdk:"data"`
+	EnableTemplates  types.Bool      `tfsdk:"enable_templates"`
+	IsConditional    types.Bool      `tfsdk:"is_conditional"`
+	ConditionalCount types.Int64     `tfsdk:"conditional_count"`
+	ConditionalLimit types.Int64     `tfsdk:"conditional_limit"`
+	ConditionalReset types.Bool      `tfsdk:"conditional_reset"`
+	ConditionalData  RecordDataValue `tfsdk:"conditional_data"`
+	OnDestroy        types.String    `tfsdk:"on_destroy"`
+	Timeouts         timeouts.Value  `tfsdk:"timeouts"`
+
+	WaitForResolution *RecordWaitForResolutionModel `tfsdk:"wait_for_resolution"`
+
+	SRV   *RecordSRVModel   `tfsdk:"srv"`
+	NAPTR *RecordNAPTRModel `tfsdk:"naptr"`
+	CAA   *RecordCAAModel   `tfsdk:"caa"`
+	SOA   *RecordSOAModel   `tfsdk:"soa"`
 }
 
 // Metadata sets the resource type name.
@@ -54,7 +87,17 @@ func (r *RecordResource) Metadata(_ context.Context, req resource.MetadataReques
 
 // Schema defines the resource schema.
 func (r *RecordResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	blocks := recordTypedDataSchemaBlocks()
+	blocks["timeouts"] = timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Read:   true,
+		Update: true,
+		Delete: true,
+	})
+	blocks["wait_for_resolution"] = recordWaitForResolutionBlock()
+
 	resp.Schema = schema.Schema{
+		Version:             recordSchemaVersion,
 		MarkdownDescription: "Manages a DNS record within a SnitchDNS zone. Records define the actual DNS responses for queries and support all standard DNS record types (A, AAAA, CNAME, MX, TXT, etc.) as well as conditional responses.",
 
 		Attributes: map[string]schema.Attribute{
@@ -73,8 +116,23 @@ func (r *RecordResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"active": schema.BoolAttribute{
-				Required:            true,
-				MarkdownDescription: "Whether the record is active and will respond to DNS queries. Set to `false` to temporarily disable without deleting.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the record is active and will respond to DNS queries. Set to `false` to temporarily disable without deleting. Computed from `active_from` and `active_until` when either is set, and conflicts with them.",
+			},
+			"active_from": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "RFC 3339 time the record becomes active, such as `2024-06-01T08:00:00Z`. Before it, the record is planned inactive.",
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"active_until": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "RFC 3339 time the record stops being active. From it on, the record is planned inactive. Must be after `active_from`.",
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
 			},
 			"cls": schema.StringAttribute{
 				Required:            true,
@@ -87,23 +145,32 @@ func (r *RecordResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 				Required:            true,
 				MarkdownDescription: "DNS record type. Supported types: A, AAAA, AFSDB, CAA, CNAME, DNAME, HINFO, MX, NAPTR, NS, PTR, RP, SOA, SPF, SRV, SSHFP, TSIG, TXT.",
 				Validators: []validator.String{
-					stringvalidator.OneOf("A", "AAAA", "AFSDB", "CAA", "CNAME", "DNAME", "HINFO", "MX", "NAPTR", "NS", "PTR", "RP", "SOA", "SPF", "SRV", "SSHFP", "TSIG", "TXT"),
+					stringvalidator.OneOf(recordTypes...),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"ttl": schema.Int64Attribute{
-				Required:            true,
-				MarkdownDescription: "Time to live in seconds. Determines how long DNS resolvers should cache this record. Typical values range from 60 (1 minute) to 86400 (1 day).",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Time to live in seconds, between 1 and 2147483647. Determines how long DNS resolvers should cache this record. Typical values range from 60 (1 minute) to 86400 (1 day). Defaults to the provider's `default_record_ttl`.",
 				Validators: []validator.Int64{
-					int64validator.Between(1, 2147483647), // Max 32-bit int for TTL
+					recordTTLValidator(),
 				},
 			},
 			"data": schema.MapAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Record-specific data as key-value pairs. The required fields depend on the record type. For A records: `{address = \"192.168.1.1\"}`. For CNAME: `{name = \"target.example.com\"}`. For MX: `{priority = \"10\", hostname = \"mail.example.com\"}`.",
+				CustomType:          NewRecordDataType(),
+				MarkdownDescription: "Record-specific data as key-value pairs. The required fields depend on the record type. For A records: `{address = \"192.168.1.1\"}`. For CNAME: `{name = \"target.example.com\"}`. For MX: `{priority = \"10\", hostname = \"mail.example.com\"}`. The fields are checked against the record type at plan time. Exactly one of `data`, `srv`, `naptr`, `caa` and `soa` must be set; with a block, `data` holds its fields as strings.",
+			},
+			"enable_templates": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Treat `{{source_ip}}` and `{{query_name}}` in `data` and `conditional_data` as placeholders SnitchDNS substitutes when answering, " +
+					"such as `{{source_ip}}` to echo the IP address of the querying resolver. Placeholders are checked at plan time, " +
+					"and the way the server rewrites them, such as `{{ Source_IP }}` to `{{source_ip}}`, is not shown as a change. Defaults to `false`.",
 			},
 			"is_conditional": schema.BoolAttribute{
 				Optional:            true,
@@ -129,34 +196,69 @@ func (r *RecordResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 				Optional:            true,
 				Computed:            true,
 				ElementType:         types.StringType,
+				CustomType:          NewRecordDataType(),
 				MarkdownDescription: "Alternative data to return when conditional limit is reached. Uses the same format as the `data` attribute.",
 			},
+			"on_destroy": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(recordOnDestroyDelete),
+				MarkdownDescription: "What happens to the record when the resource is destroyed. `delete` (default) deletes it, " +
+					"`disable` sets `active = false` and leaves it in SnitchDNS, preserving its hit history while removing it from management.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(recordOnDestroyDelete, recordOnDestroyDisable),
+				},
+			},
 		},
-		Blocks: map[string]schema.Block{
-			"timeouts": timeouts.Block(ctx, timeouts.Opts{
-				Create: true,
-				Read:   true,
-				Update: true,
-				Delete: true,
-			}),
-		},
+		Blocks: blocks,
 	}
 }
 
+// ConfigValidators checks the conditional attributes are only set on
+// conditional records, the data is set once, matching the record type, and
+// active is either set or computed from an active window.
+func (r *RecordResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return append([]resource.ConfigValidator{
+		conditionalRecordValidator{},
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("active"),
+			path.MatchRoot("active_from"),
+			path.MatchRoot("active_until"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("active"),
+			path.MatchRoot("active_from"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("active"),
+			path.MatchRoot("active_until"),
+		),
+		recordActiveWindowValidator{},
+		recordTemplateValidator{},
+	}, recordTypedDataConfigValidators()...)
+}
+
 // Configure adds the provider-configured client to the resource.
 func (r *RecordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.records = providerData.Records
+	r.recordData = providerData.RecordData
+	r.validator = providerData.Validator
+	r.dnsServer = providerData.DNSServer
+	r.offline = providerData.Offline
+	r.skipReadAfterWrite = providerData.SkipReadAfterWrite
+	r.defaultTTL = providerData.DefaultRecordTTL
 }
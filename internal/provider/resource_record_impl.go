@@ -7,21 +7,28 @@ import (
 	"strings"
 	"time"
 
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"snitchdns-tf/internal/client"
 )
 
 // Create implements the resource create logic
 func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create record")
+		return
+	}
+
 	var data RecordResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	applyActiveWindow(&data)
 
 	// Create timeout context
 	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
@@ -33,12 +40,17 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 	ctx, cancel = context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
-	// Convert data map to map[string]interface{}
+	// Convert data map to map[string]interface{}, keeping the integers of
+	// typed data
 	dataMap := make(map[string]interface{})
-	for key, value := range data.Data.Elements() {
-		strVal, ok := value.(types.String)
-		if ok {
-			dataMap[key] = strVal.ValueString()
+	if typed, _ := data.typedData(); typed != nil {
+		dataMap = typed.Fields()
+	} else {
+		for key, value := range data.Data.Elements() {
+			strVal, ok := value.(types.String)
+			if ok {
+				dataMap[key] = strVal.ValueString()
+			}
 		}
 	}
 
@@ -54,73 +66,50 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Create record via API
-	createReq := client.CreateRecordRequest{
+	createReq := snitchdns.CreateRecordRequest{
 		Active:           data.Active.ValueBool(),
 		Class:            data.Class.ValueString(),
 		Type:             data.Type.ValueString(),
 		TTL:              int(data.TTL.ValueInt64()),
-		Data:             dataMap,
+		Data:             r.recordData.ToServer(data.Type.ValueString(), dataMap),
 		IsConditional:    data.IsConditional.ValueBoolPointer() != nil && data.IsConditional.ValueBool(),
 		ConditionalCount: int(data.ConditionalCount.ValueInt64()),
 		ConditionalLimit: int(data.ConditionalLimit.ValueInt64()),
 		ConditionalReset: data.ConditionalReset.ValueBoolPointer() != nil && data.ConditionalReset.ValueBool(),
-		ConditionalData:  conditionalDataMap,
+		ConditionalData:  r.recordData.ToServer(data.Type.ValueString(), conditionalDataMap),
 	}
 
-	record, err := r.client.CreateRecord(data.ZoneID.ValueString(), createReq)
+	record, err := r.client.CreateRecordWithContext(ctx, data.ZoneID.ValueString(), createReq)
+	r.records.Invalidate(data.ZoneID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating record",
-			fmt.Sprintf("Could not create record: %s", err),
-		)
+		addAPIError(&resp.Diagnostics, "Error creating record", "Could not create record", err, recordErrorAttributes...)
 		return
 	}
 
-	// Update data model from API response
-	data.ID = types.StringValue(fmt.Sprintf("%d", record.ID))
-	data.ZoneID = types.StringValue(fmt.Sprintf("%d", record.ZoneID))
-	data.Active = types.BoolValue(record.Active)
-	data.Class = types.StringValue(record.Class)
-	data.Type = types.StringValue(record.Type)
-	data.TTL = types.Int64Value(int64(record.TTL))
-	data.IsConditional = types.BoolValue(record.IsConditional)
-	data.ConditionalCount = types.Int64Value(int64(record.ConditionalCount))
-	data.ConditionalLimit = types.Int64Value(int64(record.ConditionalLimit))
-	data.ConditionalReset = types.BoolValue(record.ConditionalReset)
-
-	// Convert data map to types.Map
-	dataElements := make(map[string]types.String)
-	for key, value := range record.Data {
-		dataElements[key] = types.StringValue(fmt.Sprintf("%v", value))
-	}
-	dataValue, diags := types.MapValueFrom(ctx, types.StringType, dataElements)
-	resp.Diagnostics.Append(diags...)
+	// Update data model from the stored record
+	record = r.readBack(ctx, record, &resp.Diagnostics)
+	resp.Diagnostics.Append(data.setRecord(ctx, r.recordData.FromServerRecord(record))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	data.Data = dataValue
 
-	// Convert conditional_data map if present
-	if len(record.ConditionalData) > 0 {
-		condDataElements := make(map[string]types.String)
-		for key, value := range record.ConditionalData {
-			condDataElements[key] = types.StringValue(fmt.Sprintf("%v", value))
-		}
-		condDataValue, diags := types.MapValueFrom(ctx, types.StringType, condDataElements)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		data.ConditionalData = condDataValue
-	} else {
-		data.ConditionalData = types.MapNull(types.StringType)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	// The record is in state before waiting, so a record that never
+	// resolves is tainted rather than orphaned
+	resp.Diagnostics.Append(r.waitForResolution(ctx, &data)...)
 }
 
 // Read implements the resource read logic
 func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
 	var data RecordResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -143,11 +132,28 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		"record_id": data.ID.ValueString(),
 	})
 
-	// Get record from API
-	record, err := r.client.GetRecord(data.ZoneID.ValueString(), data.ID.ValueString())
+	// Serve the record from the per-zone listing; fall back to a direct GET
+	// when the zone cannot be listed
+	record, found, err := r.records.GetRecord(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, "Record cache unavailable, reading record directly", map[string]any{
+			"zone_id": data.ZoneID.ValueString(),
+			"error":   err.Error(),
+		})
+		record, err = r.client.GetRecordWithContext(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
+		found = err == nil
+	}
+	if !found && err == nil {
+		tflog.Warn(ctx, "Record not found, removing from state", map[string]any{
+			"zone_id":   data.ZoneID.ValueString(),
+			"record_id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		// Check if this is a 404 - resource was deleted outside Terraform
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+		if snitchdns.IsNotFound(err) {
 			tflog.Warn(ctx, "Record not found, removing from state", map[string]any{
 				"zone_id":   data.ZoneID.ValueString(),
 				"record_id": data.ID.ValueString(),
@@ -156,65 +162,37 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Error Reading DNS Record",
-			fmt.Sprintf("Could not read record ID %s in zone %s: %s",
-				data.ID.ValueString(), data.ZoneID.ValueString(), err),
-		)
+		addAPIError(&resp.Diagnostics, "Error Reading DNS Record",
+			fmt.Sprintf("Could not read record ID %s in zone %s", data.ID.ValueString(), data.ZoneID.ValueString()), err)
 		return
 	}
 
-	// Update data model from API response
-	data.ID = types.StringValue(fmt.Sprintf("%d", record.ID))
-	data.ZoneID = types.StringValue(fmt.Sprintf("%d", record.ZoneID))
-	data.Active = types.BoolValue(record.Active)
-	data.Class = types.StringValue(record.Class)
-	data.Type = types.StringValue(record.Type)
-	data.TTL = types.Int64Value(int64(record.TTL))
-	data.IsConditional = types.BoolValue(record.IsConditional)
-	data.ConditionalCount = types.Int64Value(int64(record.ConditionalCount))
-	data.ConditionalLimit = types.Int64Value(int64(record.ConditionalLimit))
-	data.ConditionalReset = types.BoolValue(record.ConditionalReset)
-
-	// Convert data map to types.Map
-	dataElements := make(map[string]types.String)
-	for key, value := range record.Data {
-		dataElements[key] = types.StringValue(fmt.Sprintf("%v", value))
-	}
-	dataValue, diags := types.MapValueFrom(ctx, types.StringType, dataElements)
-	resp.Diagnostics.Append(diags...)
+	// Update data model from API response, telling what changed outside
+	// Terraform beyond the new values the plan shows
+	prior := data
+	resp.Diagnostics.Append(data.setRecord(ctx, r.recordData.FromServerRecord(record))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	data.Data = dataValue
-
-	// Convert conditional_data map if present
-	if len(record.ConditionalData) > 0 {
-		condDataElements := make(map[string]types.String)
-		for key, value := range record.ConditionalData {
-			condDataElements[key] = types.StringValue(fmt.Sprintf("%v", value))
-		}
-		condDataValue, diags := types.MapValueFrom(ctx, types.StringType, condDataElements)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		data.ConditionalData = condDataValue
-	} else {
-		data.ConditionalData = types.MapNull(types.StringType)
-	}
+	addRecordDriftWarning(&resp.Diagnostics, prior, recordDrift(ctx, prior, data))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 // Update implements the resource update logic
 func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update record")
+		return
+	}
+
 	var data RecordResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	applyActiveWindow(&data)
 
 	// Create timeout context
 	updateTimeout, diags := data.Timeouts.Update(ctx, 2*time.Minute)
@@ -226,12 +204,17 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 	ctx, cancel = context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
-	// Convert data map to map[string]interface{}
+	// Convert data map to map[string]interface{}, keeping the integers of
+	// typed data
 	dataMap := make(map[string]interface{})
-	for key, value := range data.Data.Elements() {
-		strVal, ok := value.(types.String)
-		if ok {
-			dataMap[key] = strVal.ValueString()
+	if typed, _ := data.typedData(); typed != nil {
+		dataMap = typed.Fields()
+	} else {
+		for key, value := range data.Data.Elements() {
+			strVal, ok := value.(types.String)
+			if ok {
+				dataMap[key] = strVal.ValueString()
+			}
 		}
 	}
 
@@ -257,73 +240,51 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 	conditionalLimit := int(data.ConditionalLimit.ValueInt64())
 	conditionalReset := data.ConditionalReset.ValueBool()
 
-	updateReq := client.UpdateRecordRequest{
+	updateReq := snitchdns.UpdateRecordRequest{
 		Active:           &active,
 		Class:            &cls,
 		Type:             &typ,
 		TTL:              &ttl,
-		Data:             dataMap,
+		Data:             r.recordData.ToServer(data.Type.ValueString(), dataMap),
 		IsConditional:    &isConditional,
 		ConditionalCount: &conditionalCount,
 		ConditionalLimit: &conditionalLimit,
 		ConditionalReset: &conditionalReset,
-		ConditionalData:  conditionalDataMap,
+		ConditionalData:  r.recordData.ToServer(data.Type.ValueString(), conditionalDataMap),
 	}
 
-	record, err := r.client.UpdateRecord(data.ZoneID.ValueString(), data.ID.ValueString(), updateReq)
+	record, err := r.client.UpdateRecordWithContext(ctx, data.ZoneID.ValueString(), data.ID.ValueString(), updateReq)
+	r.records.Invalidate(data.ZoneID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating record",
-			fmt.Sprintf("Could not update record ID %s: %s", data.ID.ValueString(), err),
-		)
+		addAPIError(&resp.Diagnostics, "Error updating record",
+			fmt.Sprintf("Could not update record ID %s", data.ID.ValueString()), err, recordErrorAttributes...)
 		return
 	}
 
-	// Update data model from API response
-	data.ID = types.StringValue(fmt.Sprintf("%d", record.ID))
-	data.ZoneID = types.StringValue(fmt.Sprintf("%d", record.ZoneID))
-	data.Active = types.BoolValue(record.Active)
-	data.Class = types.StringValue(record.Class)
-	data.Type = types.StringValue(record.Type)
-	data.TTL = types.Int64Value(int64(record.TTL))
-	data.IsConditional = types.BoolValue(record.IsConditional)
-	data.ConditionalCount = types.Int64Value(int64(record.ConditionalCount))
-	data.ConditionalLimit = types.Int64Value(int64(record.ConditionalLimit))
-	data.ConditionalReset = types.BoolValue(record.ConditionalReset)
-
-	// Convert data map to types.Map
-	dataElements := make(map[string]types.String)
-	for key, value := range record.Data {
-		dataElements[key] = types.StringValue(fmt.Sprintf("%v", value))
-	}
-	dataValue, diags := types.MapValueFrom(ctx, types.StringType, dataElements)
-	resp.Diagnostics.Append(diags...)
+	// Update data model from the stored record
+	record = r.readBack(ctx, record, &resp.Diagnostics)
+	resp.Diagnostics.Append(data.setRecord(ctx, r.recordData.FromServerRecord(record))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	data.Data = dataValue
 
-	// Convert conditional_data map if present
-	if len(record.ConditionalData) > 0 {
-		condDataElements := make(map[string]types.String)
-		for key, value := range record.ConditionalData {
-			condDataElements[key] = types.StringValue(fmt.Sprintf("%v", value))
-		}
-		condDataValue, diags := types.MapValueFrom(ctx, types.StringType, condDataElements)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		data.ConditionalData = condDataValue
-	} else {
-		data.ConditionalData = types.MapNull(types.StringType)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	// The record is in state before waiting, so a record that never
+	// resolves is tainted rather than orphaned
+	resp.Diagnostics.Append(r.waitForResolution(ctx, &data)...)
 }
 
 // Delete implements the resource delete logic
 func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete record")
+		return
+	}
+
 	var data RecordResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -341,32 +302,75 @@ func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	ctx, cancel = context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
+	if data.OnDestroy.ValueString() == recordOnDestroyDisable {
+		r.disableRecord(ctx, data, &resp.Diagnostics)
+		return
+	}
+
 	// Delete record via API
 	err := r.client.DeleteRecordWithContext(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
+	r.records.Invalidate(data.ZoneID.ValueString())
+	if snitchdns.IsNotFound(err) {
+		// Already deleted outside Terraform
+		tflog.Warn(ctx, "Record not found, nothing to delete", map[string]any{
+			"zone_id":   data.ZoneID.ValueString(),
+			"record_id": data.ID.ValueString(),
+		})
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting record",
-			fmt.Sprintf("Could not delete record ID %s: %s", data.ID.ValueString(), err),
-		)
+		addAPIError(&resp.Diagnostics, "Error deleting record",
+			fmt.Sprintf("Could not delete record ID %s", data.ID.ValueString()), err)
+		return
+	}
+}
+
+// disableRecord deactivates the record on destroy instead of deleting it
+func (r *RecordResource) disableRecord(ctx context.Context, data RecordResourceModel, diags *diag.Diagnostics) {
+	active := false
+	_, err := r.client.UpdateRecordWithContext(ctx, data.ZoneID.ValueString(), data.ID.ValueString(), snitchdns.UpdateRecordRequest{
+		Active: &active,
+	})
+	r.records.Invalidate(data.ZoneID.ValueString())
+	if snitchdns.IsNotFound(err) {
+		tflog.Warn(ctx, "Record not found, nothing to disable", map[string]any{
+			"zone_id":   data.ZoneID.ValueString(),
+			"record_id": data.ID.ValueString(),
+		})
+		return
+	}
+	if err != nil {
+		addAPIError(diags, "Error disabling record",
+			fmt.Sprintf("Could not disable record ID %s", data.ID.ValueString()), err)
 		return
 	}
+
+	tflog.Info(ctx, "Disabled record instead of deleting it", map[string]any{
+		"zone_id":   data.ZoneID.ValueString(),
+		"record_id": data.ID.ValueString(),
+	})
 }
 
 // ImportState implements the resource import logic
 func (r *RecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import ID format: "zone_id:record_id"
-	parts := strings.Split(req.ID, ":")
-	if len(parts) != 2 {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import record")
+		return
+	}
+
+	// Import ID format: "zone_id:record_id" or "zone_id/record_id"
+	zoneID, recordID, ok := strings.Cut(req.ID, ":")
+	if !ok {
+		zoneID, recordID, ok = strings.Cut(req.ID, "/")
+	}
+	if !ok {
 		resp.Diagnostics.AddError(
 			"Invalid import ID format",
-			fmt.Sprintf("Expected import ID format 'zone_id:record_id', got: %s", req.ID),
+			fmt.Sprintf("Expected import ID format 'zone_id:record_id' or 'zone_id/record_id', got: %s", req.ID),
 		)
 		return
 	}
 
-	zoneID := parts[0]
-	recordID := parts[1]
-
 	// Validate they are numeric
 	if _, err := strconv.Atoi(zoneID); err != nil {
 		resp.Diagnostics.AddError(
@@ -385,4 +389,113 @@ func (r *RecordResource) ImportState(ctx context.Context, req resource.ImportSta
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), zoneID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), recordID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), recordOnDestroyDelete)...)
+}
+
+// ModifyPlan plans the default TTL, active from the active window and the
+// data of typed data blocks, and checks the planned record data against the
+// fields of the record type, so malformed data fails at plan time rather
+// than at the API
+func (r *RecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// The resource is being destroyed
+		return
+	}
+
+	planDefaultTTL(ctx, req, resp, r.defaultTTL)
+	planActiveWindow(ctx, req, resp)
+	planTypedData(ctx, resp)
+
+	var recordType types.String
+	var templates types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("enable_templates"), &templates)...)
+
+	for _, name := range []string{"data", "conditional_data"} {
+		var data RecordDataValue
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(name), &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// The fields of templates are only known when a query is answered
+		if templates.ValueBool() && recordDataHasTemplate(data) {
+			continue
+		}
+		if err := r.validator.ValidatePlannedRecordData(recordType, data); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid Record Data", err.Error())
+		}
+	}
+}
+
+// readBack returns the record as the server stores it after a write, whose
+// response may not reflect the server's normalization yet. Without a
+// request when skip_read_after_write is set, and with a warning when the
+// read fails, the written record is returned instead.
+func (r *RecordResource) readBack(ctx context.Context, written *snitchdns.Record, diags *diag.Diagnostics) *snitchdns.Record {
+	if r.skipReadAfterWrite {
+		return written
+	}
+
+	zoneID, recordID := strconv.Itoa(written.ZoneID), strconv.Itoa(written.ID)
+	record, err := r.client.GetRecordWithContext(ctx, zoneID, recordID)
+	if err != nil {
+		diags.AddWarning("Could not read back record",
+			fmt.Sprintf("Record ID %s in zone %s was written, but reading it back failed, so the state holds the write's response: %s",
+				recordID, zoneID, err))
+		return written
+	}
+	return record
+}
+
+// setRecord copies a record translated from the server's field names into
+// the model. With enable_templates, data the server only differs from the
+// model in by how it wrote the placeholders is kept as configured.
+func (m *RecordResourceModel) setRecord(ctx context.Context, record *snitchdns.Record) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(strconv.Itoa(record.ID))
+	m.ZoneID = types.StringValue(strconv.Itoa(record.ZoneID))
+	m.Active = types.BoolValue(record.Active)
+	m.Class = types.StringValue(record.Class)
+	m.Type = types.StringValue(record.Type)
+	m.TTL = types.Int64Value(int64(record.TTL))
+	m.IsConditional = types.BoolValue(record.IsConditional)
+	m.ConditionalCount = types.Int64Value(int64(record.ConditionalCount))
+	m.ConditionalLimit = types.Int64Value(int64(record.ConditionalLimit))
+	m.ConditionalReset = types.BoolValue(record.ConditionalReset)
+
+	// Convert data to types.Map, with numbers as the server encoded them
+	dataValue, d := NewRecordDataValue(ctx, record.Data.Strings())
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	if !m.EnableTemplates.ValueBool() || !recordTemplatesEquivalent(ctx, m.Data, dataValue) {
+		m.Data = dataValue
+	}
+
+	typed, err := record.TypedData()
+	if err != nil {
+		diags.AddError("Error reading record data",
+			fmt.Sprintf("Could not decode the data of record ID %d: %s", record.ID, err))
+		return diags
+	}
+	m.setTypedData(typed)
+
+	// Convert conditional_data map if present
+	if !record.ConditionalData.IsEmpty() {
+		condDataValue, d := NewRecordDataValue(ctx, record.ConditionalData.Strings())
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+		if !m.EnableTemplates.ValueBool() || !recordTemplatesEquivalent(ctx, m.ConditionalData, condDataValue) {
+			m.ConditionalData = condDataValue
+		}
+	} else {
+		m.ConditionalData = NewRecordDataNull()
+	}
+
+	return diags
 }
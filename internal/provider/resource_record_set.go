@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RecordSetResource{}
+var _ resource.ResourceWithImportState = &RecordSetResource{}
+
+// NewRecordSetResource creates a new Record Set resource.
+func NewRecordSetResource() resource.Resource {
+	return &RecordSetResource{}
+}
+
+// RecordSetResource defines the resource implementation. Like
+// snitchdns_records_csv it owns every record of a zone, but takes the
+// records as a set of objects rather than CSV content.
+type RecordSetResource struct {
+	recordReconciler
+	defaultTTL int64
+	offline    bool
+}
+
+// RecordSetResourceModel describes the resource data model.
+type RecordSetResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ZoneID      types.String `tfsdk:"zone_id"`
+	Records     types.Set    `tfsdk:"records"`
+	Parallelism types.Int64  `tfsdk:"parallelism"`
+}
+
+// RecordSetRecordModel describes a single record of the set.
+type RecordSetRecordModel struct {
+	Type   types.String `tfsdk:"type"`
+	Class  types.String `tfsdk:"cls"`
+	TTL    types.Int64  `tfsdk:"ttl"`
+	Active types.Bool   `tfsdk:"active"`
+	Data   types.Map    `tfsdk:"data"`
+}
+
+// recordSetRecordAttrTypes are the attribute types of a record in the set
+var recordSetRecordAttrTypes = map[string]attr.Type{
+	"type":   types.StringType,
+	"cls":    types.StringType,
+	"ttl":    types.Int64Type,
+	"active": types.BoolType,
+	"data":   types.MapType{ElemType: types.StringType},
+}
+
+// Metadata sets the resource type name.
+func (r *RecordSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_set"
+}
+
+// Schema defines the resource schema.
+func (r *RecordSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages all records of a zone as a set, reconciling them in a single apply. " +
+			"Records missing from the set are deleted, so do not combine this resource with `snitchdns_record` or `snitchdns_records_csv` on the same zone.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, equal to `zone_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone whose records are managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"records": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Records of the zone. Records are matched to existing ones by type, class and data.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "DNS record type, such as `A`, `MX` or `TXT`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(recordTypes...),
+							},
+						},
+						"cls": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "DNS class. Defaults to `IN`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("IN", "CH", "HS"),
+							},
+						},
+						"ttl": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Time to live in seconds. Defaults to the provider's `default_record_ttl`, or `300`.",
+							Validators: []validator.Int64{
+								recordTTLValidator(),
+							},
+						},
+						"active": schema.BoolAttribute{
+							Optional:            true,
+							MarkdownDescription: "Whether the record answers queries. Defaults to `true`.",
+						},
+						"data": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Required:            true,
+							MarkdownDescription: "Record data, in the same format as the `data` attribute of `snitchdns_record`.",
+							Validators: []validator.Map{
+								mapvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Number of record changes sent to the API at the same time. Defaults to `%d`.", defaultBatchParallelism),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 32),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *RecordSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.records = providerData.Records
+	r.recordData = providerData.RecordData
+	r.defaultTTL = providerData.DefaultRecordTTL
+	r.offline = providerData.Offline
+}
+
+// CRUD methods are implemented in resource_record_set_impl.go
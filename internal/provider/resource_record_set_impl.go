@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *RecordSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create record set")
+		return
+	}
+
+	var data RecordSetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ZoneID
+	persist, diags := r.apply(ctx, &data, partialCreateRecovery)
+	resp.Diagnostics.Append(diags...)
+	if !persist {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *RecordSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data RecordSetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.listRecords(ctx, data.ZoneID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "Zone not found, removing record set from state", map[string]any{
+				"zone_id": data.ZoneID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading records",
+			fmt.Sprintf("Could not list records of zone ID %s", data.ZoneID.ValueString()), err)
+		return
+	}
+
+	// Keep the configured records while the zone matches them, so omitted
+	// defaults do not show up as changes. Otherwise store the actual
+	// records, which Terraform reports as drift.
+	desired, diags := recordSetRecords(ctx, data.Records, r.defaultTTL)
+	if data.Records.IsNull() || diags.HasError() || !planRecords(desired, existing).empty() {
+		records, diags := recordSetValue(ctx, existing)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Records = records
+	}
+
+	data.ID = data.ZoneID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *RecordSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update record set")
+		return
+	}
+
+	var data RecordSetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	persist, diags := r.apply(ctx, &data, partialUpdateRecovery)
+	resp.Diagnostics.Append(diags...)
+	if !persist {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. Every record of the zone is
+// deleted, since the resource owns all of them.
+func (r *RecordSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete record set")
+		return
+	}
+
+	var data RecordSetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+
+	existing, err := r.listRecords(ctx, zoneID)
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Zone is already gone along with its records
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting records",
+			fmt.Sprintf("Could not list records of zone ID %s", zoneID), err)
+		return
+	}
+
+	ops := make([]batchOperation, 0, len(existing))
+	for _, record := range existing {
+		ops = append(ops, r.deleteOperation(zoneID, record))
+	}
+
+	results := runBatch(ctx, ops, int(data.Parallelism.ValueInt64()))
+	r.records.Invalidate(zoneID)
+	resp.Diagnostics.Append(batchDiagnostics("Error deleting records", results)...)
+}
+
+// ImportState implements the resource import logic. The import ID is the
+// zone ID; records are populated with the zone's current records.
+func (r *RecordSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import record set")
+		return
+	}
+
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected a numeric zone ID, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// apply reconciles the zone's records to the planned set through applyPlan,
+// which runs the deletes, then the updates, then the creates, each phase with
+// bounded parallelism, so a replaced record is gone before the record taking
+// its place is created and the server sees no duplicates. It reports whether
+// data is to be saved: when some operations failed after others were
+// applied, data holds the records of the zone, with recovery advising on
+// the remaining changes.
+func (r *RecordSetResource) apply(ctx context.Context, data *RecordSetResourceModel, recovery string) (bool, diag.Diagnostics) {
+	zoneID := data.ZoneID.ValueString()
+
+	desired, diags := recordSetRecords(ctx, data.Records, r.defaultTTL)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	existing, err := r.listRecords(ctx, zoneID)
+	if err != nil {
+		addAPIError(&diags, "Error reading records", fmt.Sprintf("Could not list records of zone ID %s", zoneID), err)
+		return false, diags
+	}
+
+	plan := planRecords(desired, existing)
+	tflog.Debug(ctx, "Reconciling zone records to record set", map[string]any{
+		"zone_id": zoneID,
+		"creates": len(plan.Creates),
+		"updates": len(plan.Updates),
+		"deletes": len(plan.Deletes),
+	})
+
+	results := r.applyPlan(ctx, zoneID, plan, int(data.Parallelism.ValueInt64()))
+	r.records.Invalidate(zoneID)
+	diags.Append(partialFailureDiagnostics("Error applying record set", recovery, results)...)
+	if !diags.HasError() {
+		return true, diags
+	}
+	if !anyApplied(results) {
+		return false, diags
+	}
+
+	// Save the records as they are now, which the next plan compares to
+	// the configuration
+	existing, err = r.listRecords(ctx, zoneID)
+	if err != nil {
+		addAPIError(&diags, "Error reading records", fmt.Sprintf("Could not list records of zone ID %s after the partial apply", zoneID), err)
+		return false, diags
+	}
+	records, d := recordSetValue(ctx, existing)
+	diags.Append(d...)
+	data.Records = records
+	return !d.HasError(), diags
+}
+
+// recordSetRecords converts the records attribute to records for
+// reconciliation, applying the defaults of omitted attributes. Records
+// omitting ttl get defaultTTL, where zero stands for defaultRecordTTL.
+func recordSetRecords(ctx context.Context, set types.Set, defaultTTL int64) ([]zoneRecord, diag.Diagnostics) {
+	if defaultTTL == 0 {
+		defaultTTL = defaultRecordTTL
+	}
+
+	var models []RecordSetRecordModel
+	diags := set.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	records := make([]zoneRecord, 0, len(models))
+	for _, model := range models {
+		record := zoneRecord{
+			Type:   strings.ToUpper(model.Type.ValueString()),
+			Class:  "IN",
+			TTL:    int(defaultTTL),
+			Active: true,
+		}
+		if !model.Class.IsNull() {
+			record.Class = model.Class.ValueString()
+		}
+		if !model.TTL.IsNull() {
+			record.TTL = int(model.TTL.ValueInt64())
+		}
+		if !model.Active.IsNull() {
+			record.Active = model.Active.ValueBool()
+		}
+
+		diags.Append(model.Data.ElementsAs(ctx, &record.Data, false)...)
+		records = append(records, record)
+	}
+
+	return records, diags
+}
+
+// recordSetValue converts API records to the records attribute
+func recordSetValue(ctx context.Context, records []snitchdns.Record) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]attr.Value, 0, len(records))
+	for _, record := range records {
+		data, d := types.MapValueFrom(ctx, types.StringType, record.Data.Strings())
+		diags.Append(d...)
+
+		value, d := types.ObjectValue(recordSetRecordAttrTypes, map[string]attr.Value{
+			"type":   types.StringValue(record.Type),
+			"cls":    types.StringValue(record.Class),
+			"ttl":    types.Int64Value(int64(record.TTL)),
+			"active": types.BoolValue(record.Active),
+			"data":   data,
+		})
+		diags.Append(d...)
+		values = append(values, value)
+	}
+	if diags.HasError() {
+		return types.SetNull(types.ObjectType{AttrTypes: recordSetRecordAttrTypes}), diags
+	}
+
+	set, d := types.SetValue(types.ObjectType{AttrTypes: recordSetRecordAttrTypes}, values)
+	diags.Append(d...)
+	return set, diags
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestRecordSetRecords tests converting the records attribute and applying
+// defaults for omitted attributes
+func TestRecordSetRecords(t *testing.T) {
+	ctx := context.Background()
+
+	element := func(recordType string, cls attr.Value, ttl attr.Value, active attr.Value, data map[string]string) attr.Value {
+		dataValue, _ := types.MapValueFrom(ctx, types.StringType, data)
+		value, diags := types.ObjectValue(recordSetRecordAttrTypes, map[string]attr.Value{
+			"type":   types.StringValue(recordType),
+			"cls":    cls,
+			"ttl":    ttl,
+			"active": active,
+			"data":   dataValue,
+		})
+		if diags.HasError() {
+			t.Fatalf("building element: %v", diags)
+		}
+		return value
+	}
+
+	tests := []struct {
+		name       string
+		element    attr.Value
+		defaultTTL int64
+		expected   zoneRecord
+	}{
+		{
+			name:    "defaults",
+			element: element("a", types.StringNull(), types.Int64Null(), types.BoolNull(), map[string]string{"address": "10.0.0.1"}),
+			expected: zoneRecord{
+				Type: "A", Class: "IN", TTL: 300, Active: true,
+				Data: map[string]string{"address": "10.0.0.1"},
+			},
+		},
+		{
+			name:       "provider default ttl",
+			element:    element("A", types.StringNull(), types.Int64Null(), types.BoolNull(), map[string]string{"address": "10.0.0.1"}),
+			defaultTTL: 3600,
+			expected: zoneRecord{
+				Type: "A", Class: "IN", TTL: 3600, Active: true,
+				Data: map[string]string{"address": "10.0.0.1"},
+			},
+		},
+		{
+			name:    "explicit values",
+			element: element("TXT", types.StringValue("CH"), types.Int64Value(60), types.BoolValue(false), map[string]string{"data": "hello"}),
+			expected: zoneRecord{
+				Type: "TXT", Class: "CH", TTL: 60, Active: false,
+				Data: map[string]string{"data": "hello"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, diags := types.SetValue(types.ObjectType{AttrTypes: recordSetRecordAttrTypes}, []attr.Value{tt.element})
+			if diags.HasError() {
+				t.Fatalf("building set: %v", diags)
+			}
+
+			records, diags := recordSetRecords(ctx, set, tt.defaultTTL)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if len(records) != 1 {
+				t.Fatalf("expected 1 record, got %d", len(records))
+			}
+
+			got := records[0]
+			if got.Type != tt.expected.Type || got.Class != tt.expected.Class || got.TTL != tt.expected.TTL || got.Active != tt.expected.Active {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+			if !stringMapsEqual(got.Data, tt.expected.Data) {
+				t.Errorf("expected data %v, got %v", tt.expected.Data, got.Data)
+			}
+		})
+	}
+}
+
+// TestRecordSetValueRoundTrip tests that records read from the API plan no
+// changes when applied again
+func TestRecordSetValueRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	existing := []snitchdns.Record{
+		{ID: 1, Type: "A", Class: "IN", TTL: 300, Active: true, Data: snitchdns.EncodeRecordData(map[string]interface{}{"address": "10.0.0.1"})},
+		{ID: 2, Type: "MX", Class: "IN", TTL: 3600, Active: false, Data: snitchdns.EncodeRecordData(map[string]interface{}{"hostname": "mail.example.com", "priority": float64(10)})},
+	}
+
+	set, diags := recordSetValue(ctx, existing)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	desired, diags := recordSetRecords(ctx, set, 0)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if plan := planRecords(desired, existing); !plan.empty() {
+		t.Errorf("expected no changes, got %+v", plan)
+	}
+}
+
+// TestRecordSetResource_MockPartialFailure tests that a create failing
+// after some operations were applied saves the records of the zone
+func TestRecordSetResource_MockPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	zone := mock.AddZone(snitchdns.Zone{Domain: "set.example.com"})
+	mock.AddRecord(zone.ID, snitchdns.Record{Type: "TXT", Class: "IN", TTL: 300, Active: true, Data: snitchdns.EncodeRecordData(map[string]interface{}{"data": "old"})})
+	mock.Fail("DeleteRecord", errors.New("status 500"))
+	r := newMockResource(t, NewRecordSetResource(), mock)
+
+	records, diags := recordSetValue(ctx, []snitchdns.Record{
+		{Type: "A", Class: "IN", TTL: 300, Active: true, Data: snitchdns.EncodeRecordData(map[string]interface{}{"address": "10.0.0.1"})},
+	})
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"zone_id": types.StringValue(strconv.Itoa(zone.ID)),
+		"records": records,
+	})
+
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("Expected the failed delete to be reported")
+	}
+	warnings := createResp.Diagnostics.Warnings()
+	if len(warnings) != 1 || warnings[0].Summary() != "Partially Applied" || !strings.Contains(warnings[0].Detail(), "create A") || !strings.Contains(warnings[0].Detail(), "terraform untaint") {
+		t.Errorf("Expected a warning listing the applied create, got %v", warnings)
+	}
+
+	// The state holds both records, so the next plan deletes the old one
+	var data RecordSetResourceModel
+	createResp.State.Get(ctx, &data)
+	if data.ID.ValueString() != strconv.Itoa(zone.ID) || len(data.Records.Elements()) != 2 {
+		t.Errorf("Expected the zone's records in state, got %s", data.Records)
+	}
+}
+
+// TestAccRecordSetResource tests reconciling a zone's records to a record set
+func TestAccRecordSetResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRecordSetResourceConfig(container, `
+    { type = "A", cls = "IN", ttl = 300, active = true, data = { address = "10.0.0.1" } },
+    { type = "TXT", cls = "IN", ttl = 60, active = true, data = { data = "hello" } },
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("snitchdns_record_set.test", "id", "snitchdns_zone.test", "id"),
+					resource.TestCheckResourceAttr("snitchdns_record_set.test", "records.#", "2"),
+				),
+			},
+			{
+				Config: testAccRecordSetResourceConfig(container, `
+    { type = "A", cls = "IN", ttl = 120, active = true, data = { address = "10.0.0.1" } },
+    { type = "AAAA", cls = "IN", ttl = 300, active = true, data = { address = "2001:db8::1" } },
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_record_set.test", "records.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("snitchdns_record_set.test", "records.*", map[string]string{
+						"type": "A",
+						"ttl":  "120",
+					}),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_record_set.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccRecordSetResourceConfig generates HCL configuration for record set testing
+func testAccRecordSetResourceConfig(container *testcontainer.SnitchDNSContainer, records string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = "set.example.com"
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_record_set" "test" {
+  zone_id = snitchdns_zone.test.id
+  records = [%[3]s  ]
+}
+`, container.GetAPIEndpoint(), container.APIKey, records)
+}
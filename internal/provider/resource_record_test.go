@@ -3,11 +3,22 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/EinDev/snitchdns-tf/internal/acctest"
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
-	"snitchdns-tf/internal/testcontainer"
 )
 
 // TestAccRecordResource tests the Record resource CRUD operations
@@ -39,7 +50,7 @@ func TestAccRecordResource(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create zone first, then create A record
 			{
-				Config: testAccRecordResourceConfigA(container, "record-test.example.com", "192.168.1.1"),
+				Config: testAccRecordResourceConfig(container, "record-test.example.com", "A", map[string]string{"address": "192.168.1.1"}),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("snitchdns_record.test", "id"),
 					resource.TestCheckResourceAttrSet("snitchdns_record.test", "zone_id"),
@@ -48,6 +59,8 @@ func TestAccRecordResource(t *testing.T) {
 					resource.TestCheckResourceAttr("snitchdns_record.test", "ttl", "300"),
 					resource.TestCheckResourceAttr("snitchdns_record.test", "active", "true"),
 					resource.TestCheckResourceAttr("snitchdns_record.test", "data.address", "192.168.1.1"),
+					acctest.CheckZoneExists(container, "snitchdns_zone.test"),
+					acctest.CheckRecordResolves(container, "record-test.example.com", "A", "address", "192.168.1.1"),
 				),
 			},
 			// ImportState testing
@@ -57,11 +70,22 @@ func TestAccRecordResource(t *testing.T) {
 				ImportStateIdFunc: testAccRecordImportStateIdFunc,
 				ImportStateVerify: true,
 			},
+			// ImportState testing with the slash separated ID
+			{
+				ResourceName: "snitchdns_record.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					id, err := testAccRecordImportStateIdFunc(s)
+					return strings.Replace(id, ":", "/", 1), err
+				},
+				ImportStateVerify: true,
+			},
 			// Update the A record IP address
 			{
-				Config: testAccRecordResourceConfigA(container, "record-test.example.com", "192.168.1.2"),
+				Config: testAccRecordResourceConfig(container, "record-test.example.com", "A", map[string]string{"address": "192.168.1.2"}),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("snitchdns_record.test", "data.address", "192.168.1.2"),
+					acctest.CheckRecordResolves(container, "record-test.example.com", "A", "address", "192.168.1.2"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -89,7 +113,7 @@ func TestAccRecordResource_CNAME(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRecordResourceConfigCNAME(container, "cname-test.example.com", "target.example.com"),
+				Config: testAccRecordResourceConfig(container, "cname-test.example.com", "CNAME", map[string]string{"name": "target.example.com"}),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("snitchdns_record.test", "type", "CNAME"),
 					resource.TestCheckResourceAttr("snitchdns_record.test", "data.name", "target.example.com"),
@@ -112,62 +136,275 @@ func testAccRecordImportStateIdFunc(s *terraform.State) (string, error) {
 	return fmt.Sprintf("%s:%s", zoneID, recordID), nil
 }
 
-// testAccRecordResourceConfigA generates HCL configuration for A record testing
-func testAccRecordResourceConfigA(container *testcontainer.SnitchDNSContainer, domain string, address string) string {
-	return fmt.Sprintf(`
-provider "snitchdns" {
-  api_url = %[1]q
-  api_key = %[2]q
+// testAccRecordResourceConfig generates HCL configuration for a record in
+// a new zone
+func testAccRecordResourceConfig(container *testcontainer.SnitchDNSContainer, domain, recordType string, data map[string]string) string {
+	zone := acctest.ZoneConfig{Domain: domain}
+	return acctest.Compose(acctest.Provider(container), zone, acctest.RecordConfig{
+		ZoneID: zone.Ref("id"),
+		Type:   recordType,
+		TTL:    300,
+		Data:   data,
+	})
 }
 
-resource "snitchdns_zone" "test" {
-  domain     = %[3]q
-  active     = true
-  catch_all  = false
-  forwarding = false
-  regex      = false
-}
+// TestRecordResourceDeleteDisable tests that on_destroy = "disable" keeps the
+// record and deactivates it
+func TestRecordResourceDeleteDisable(t *testing.T) {
+	ctx := context.Background()
 
-resource "snitchdns_record" "test" {
-  zone_id = snitchdns_zone.test.id
-  type    = "A"
-  cls     = "IN"
-  ttl     = 300
-  active  = true
+	mock := snitchdnsmock.New()
+	zone := mock.AddZone(snitchdns.Zone{Domain: "canary.example.com", Active: true})
+	record := mock.AddRecord(zone.ID, snitchdns.Record{Active: true, Class: "IN", Type: "A", TTL: 300, Data: snitchdns.EncodeRecordData(map[string]interface{}{"address": "192.0.2.1"})})
 
-  data = {
-    address = %[4]q
-  }
-}
-`, container.GetAPIEndpoint(), container.APIKey, domain, address)
+	r := NewRecordResource()
+	var configureResp fwresource.ConfigureResponse
+	r.(fwresource.ResourceWithConfigure).Configure(ctx, fwresource.ConfigureRequest{ProviderData: &ProviderData{
+		Client:  mock,
+		Records: NewRecordCache(mock),
+	}}, &configureResp)
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	for _, onDestroy := range []string{recordOnDestroyDisable, recordOnDestroyDelete} {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		state.SetAttribute(ctx, path.Root("zone_id"), strconv.Itoa(zone.ID))
+		state.SetAttribute(ctx, path.Root("id"), strconv.Itoa(record.ID))
+		state.SetAttribute(ctx, path.Root("on_destroy"), onDestroy)
+
+		resp := fwresource.DeleteResponse{State: state}
+		r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected delete error with on_destroy = %q: %v", onDestroy, resp.Diagnostics)
+		}
+
+		records := mock.Records(zone.ID)
+		if onDestroy == recordOnDestroyDisable && (len(records) != 1 || records[0].Active) {
+			t.Errorf("Expected the record to be kept and disabled, got %+v", records)
+		}
+		if onDestroy == recordOnDestroyDelete && len(records) != 0 {
+			t.Errorf("Expected the record to be deleted, got %+v", records)
+		}
+	}
 }
 
-// testAccRecordResourceConfigCNAME generates HCL configuration for CNAME record testing
-func testAccRecordResourceConfigCNAME(container *testcontainer.SnitchDNSContainer, domain string, target string) string {
-	return fmt.Sprintf(`
-provider "snitchdns" {
-  api_url = %[1]q
-  api_key = %[2]q
+// TestRecordResource_MockReadAfterWrite tests that created records are read
+// back unless the provider skips it
+func TestRecordResource_MockReadAfterWrite(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	zone := mock.AddZone(snitchdns.Zone{Domain: "example.com", Active: true})
+	r := newMockResource(t, NewRecordResource(), mock)
+
+	data, diags := NewRecordDataValue(ctx, map[string]string{"address": "192.0.2.1"})
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	create := func() fwresource.CreateResponse {
+		plan := mockPlan(t, r, map[string]attr.Value{
+			"zone_id": types.StringValue(strconv.Itoa(zone.ID)),
+			"active":  types.BoolValue(true),
+			"cls":     types.StringValue("IN"),
+			"type":    types.StringValue("A"),
+			"ttl":     types.Int64Value(300),
+			"data":    data,
+		})
+		resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+		r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected create error: %v", resp.Diagnostics)
+		}
+		return resp
+	}
+
+	createResp := create()
+	if got := mock.Calls("GetRecord"); got != 1 {
+		t.Errorf("Expected the record to be read back once, got %d reads", got)
+	}
+	var id types.String
+	createResp.State.GetAttribute(ctx, path.Root("id"), &id)
+	if records := mock.Records(zone.ID); len(records) != 1 || id.ValueString() != strconv.Itoa(records[0].ID) {
+		t.Errorf("Expected id of %+v in state, got %s", records, id)
+	}
+
+	r.(*RecordResource).skipReadAfterWrite = true
+	create()
+	if got := mock.Calls("GetRecord"); got != 1 {
+		t.Errorf("Expected no read back, got %d reads", got-1)
+	}
 }
 
-resource "snitchdns_zone" "test" {
-  domain     = %[3]q
-  active     = true
-  catch_all  = false
-  forwarding = false
-  regex      = false
+// TestRecordResource_ModifyPlanDefaultTTL tests that records omitting ttl
+// plan the provider's default TTL, or 300 without one
+func TestRecordResource_ModifyPlanDefaultTTL(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		configured types.Int64
+		defaultTTL int64
+		expected   int64
+	}{
+		"fallback":         {types.Int64Null(), 0, 300},
+		"provider default": {types.Int64Null(), 3600, 3600},
+		"configured":       {types.Int64Value(60), 3600, 60},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newMockResource(t, NewRecordResource(), snitchdnsmock.New())
+			r.(*RecordResource).defaultTTL = tt.defaultTTL
+
+			plan := mockPlan(t, r, map[string]attr.Value{
+				"zone_id": types.StringValue("1"),
+				"type":    types.StringValue("A"),
+				"ttl":     tt.configured,
+			})
+			config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+			if tt.configured.IsNull() {
+				plan.SetAttribute(ctx, path.Root("ttl"), types.Int64Unknown())
+			}
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+				Config: config,
+				Plan:   plan,
+			}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+			}
+
+			var ttl types.Int64
+			resp.Plan.GetAttribute(ctx, path.Root("ttl"), &ttl)
+			if ttl.ValueInt64() != tt.expected {
+				t.Errorf("Expected ttl %d, got %s", tt.expected, ttl)
+			}
+		})
+	}
 }
 
-resource "snitchdns_record" "test" {
-  zone_id = snitchdns_zone.test.id
-  type    = "CNAME"
-  cls     = "IN"
-  ttl     = 300
-  active  = true
+// TestRecordResource_MockTypedData tests that the srv block plans its data,
+// sends its numbers as integers and is read back exactly
+func TestRecordResource_MockTypedData(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	zone := mock.AddZone(snitchdns.Zone{Domain: "example.com", Active: true})
+	r := newMockResource(t, NewRecordResource(), mock)
+
+	srv := types.ObjectValueMust(
+		map[string]attr.Type{"priority": types.Int64Type, "weight": types.Int64Type, "port": types.Int64Type, "target": types.StringType},
+		map[string]attr.Value{
+			"priority": types.Int64Value(10),
+			"weight":   types.Int64Value(60),
+			"port":     types.Int64Value(5060),
+			"target":   types.StringValue("sip.example.com."),
+		},
+	)
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"zone_id": types.StringValue(strconv.Itoa(zone.ID)),
+		"active":  types.BoolValue(true),
+		"cls":     types.StringValue("IN"),
+		"type":    types.StringValue("SRV"),
+		"ttl":     types.Int64Value(300),
+		"srv":     srv,
+	})
+
+	modifyResp := fwresource.ModifyPlanResponse{Plan: plan}
+	r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		Plan:   plan,
+	}, &modifyResp)
+	if modifyResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected plan error: %v", modifyResp.Diagnostics)
+	}
+	var planned RecordDataValue
+	modifyResp.Plan.GetAttribute(ctx, path.Root("data"), &planned)
+	if got := recordDataStrings(planned); got["port"] != "5060" || got["target"] != "sip.example.com." || len(got) != 4 {
+		t.Errorf("Expected data planned from the srv block, got %v", got)
+	}
+
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: modifyResp.Plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: modifyResp.Plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	records := mock.Records(zone.ID)
+	if len(records) != 1 || !strings.Contains(records[0].DataRaw, `"port":5060`) {
+		t.Fatalf("Expected the port to be sent as an integer, got %+v", records)
+	}
 
-  data = {
-    name = %[4]q
-  }
+	var state RecordResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.SRV == nil || state.SRV.Port.ValueInt64() != 5060 || !state.Data.Equal(planned) {
+		t.Errorf("Expected the planned srv block and data in state, got %+v, %s", state.SRV, state.Data)
+	}
 }
-`, container.GetAPIEndpoint(), container.APIKey, domain, target)
+
+// TestRecordResource_MockReadDrift tests that a read warns about the
+// changes made to the record outside Terraform
+func TestRecordResource_MockReadDrift(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	zone := mock.AddZone(snitchdns.Zone{Domain: "example.com", Active: true})
+	r := newMockResource(t, NewRecordResource(), mock)
+
+	data, diags := NewRecordDataValue(ctx, map[string]string{"address": "192.0.2.1"})
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"zone_id": types.StringValue(strconv.Itoa(zone.ID)),
+		"active":  types.BoolValue(true),
+		"cls":     types.StringValue("IN"),
+		"type":    types.StringValue("A"),
+		"ttl":     types.Int64Value(300),
+		"data":    data,
+	})
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	// Each read is a fresh refresh, with a record listing of its own
+	read := func(state tfsdk.State) fwresource.ReadResponse {
+		resp := fwresource.ReadResponse{State: state}
+		newMockResource(t, NewRecordResource(), mock).Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected read error: %v", resp.Diagnostics)
+		}
+		return resp
+	}
+	if resp := read(createResp.State); resp.Diagnostics.WarningsCount() != 0 {
+		t.Errorf("Expected no warning without changes, got %v", resp.Diagnostics)
+	}
+
+	records := mock.Records(zone.ID)
+	ttl := 600
+	if _, err := mock.UpdateRecordWithContext(ctx, strconv.Itoa(zone.ID), strconv.Itoa(records[0].ID), snitchdns.UpdateRecordRequest{
+		TTL:  &ttl,
+		Data: map[string]interface{}{"address": "192.0.2.2"},
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resp := read(createResp.State)
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("Expected a drift warning, got %v", resp.Diagnostics)
+	}
+	for _, change := range []string{"TTL changed 300→600", `Data field "address" changed "192.0.2.1"→"192.0.2.2"`} {
+		if !strings.Contains(warnings[0].Detail(), change) {
+			t.Errorf("Expected the warning to report %s, got %s", change, warnings[0].Detail())
+		}
+	}
+
+	// Once in state, the changes are not reported again
+	if resp := read(resp.State); resp.Diagnostics.WarningsCount() != 0 {
+		t.Errorf("Expected no warning on the next read, got %v", resp.Diagnostics)
+	}
 }
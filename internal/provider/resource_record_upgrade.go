@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// recordSchemaVersion is the version of the snitchdns_record state. Bump it
+// and add an upgrader when an attribute changes type.
+const recordSchemaVersion = 1
+
+var _ resource.ResourceWithUpgradeState = &RecordResource{}
+
+// recordResourceModelV0 is the record state before schema versioning, when
+// data and conditional_data were plain maps
+type recordResourceModelV0 struct {
+	ID                types.String                  `tfsdk:"id"`
+	ZoneID            types.String                  `tfsdk:"zone_id"`
+	Active            types.Bool                    `tfsdk:"active"`
+	Class             types.String                  `tfsdk:"cls"`
+	Type              types.String                  `tfsdk:"type"`
+	TTL               types.Int64                   `tfsdk:"ttl"`
+	Data              types.Map                     `tfsdk:"data"`
+	IsConditional     types.Bool                    `tfsdk:"is_conditional"`
+	ConditionalCount  types.Int64                   `tfsdk:"conditional_count"`
+	ConditionalLimit  types.Int64                   `tfsdk:"conditional_limit"`
+	ConditionalReset  types.Bool                    `tfsdk:"conditional_reset"`
+	ConditionalData   types.Map                     `tfsdk:"conditional_data"`
+	OnDestroy         types.String                  `tfsdk:"on_destroy"`
+	Timeouts          timeouts.Value                `tfsdk:"timeouts"`
+	WaitForResolution *RecordWaitForResolutionModel `tfsdk:"wait_for_resolution"`
+}
+
+// recordSchemaV0 is the schema of recordResourceModelV0. Attributes added
+// before versioning are included; states written without them read them as
+// null.
+func recordSchemaV0(ctx context.Context) schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":      schema.StringAttribute{Computed: true},
+			"zone_id": schema.StringAttribute{Required: true},
+			"active":  schema.BoolAttribute{Required: true},
+			"cls":     schema.StringAttribute{Required: true},
+			"type":    schema.StringAttribute{Required: true},
+			"ttl":     schema.Int64Attribute{Required: true},
+			"data": schema.MapAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"is_conditional":    schema.BoolAttribute{Optional: true, Computed: true},
+			"conditional_count": schema.Int64Attribute{Optional: true, Computed: true},
+			"conditional_limit": schema.Int64Attribute{Optional: true, Computed: true},
+			"conditional_reset": schema.BoolAttribute{Optional: true, Computed: true},
+			"conditional_data": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			"on_destroy": schema.StringAttribute{Optional: true, Computed: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"wait_for_resolution": recordWaitForResolutionBlock(),
+		},
+	}
+}
+
+// UpgradeState returns the upgraders of earlier record state versions
+func (r *RecordResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := recordSchemaV0(ctx)
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &schemaV0,
+			StateUpgrader: upgradeRecordStateV0,
+		},
+	}
+}
+
+// upgradeRecordStateV0 wraps the data maps in the record data type and
+// fills in on_destroy for states written before it existed
+func upgradeRecordStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior recordResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	onDestroy := prior.OnDestroy
+	if onDestroy.IsNull() {
+		onDestroy = types.StringValue(recordOnDestroyDelete)
+	}
+
+	upgraded := RecordResourceModel{
+		ID:                prior.ID,
+		ZoneID:            prior.ZoneID,
+		Active:            prior.Active,
+		Class:             prior.Class,
+		Type:              prior.Type,
+		TTL:               prior.TTL,
+		Data:              RecordDataValue{MapValue: prior.Data},
+		IsConditional:     prior.IsConditional,
+		ConditionalCount:  prior.ConditionalCount,
+		ConditionalLimit:  prior.ConditionalLimit,
+		ConditionalReset:  prior.ConditionalReset,
+		ConditionalData:   RecordDataValue{MapValue: prior.ConditionalData},
+		OnDestroy:         onDestroy,
+		Timeouts:          prior.Timeouts,
+		WaitForResolution: prior.WaitForResolution,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestRecordUpgradeStateV0 tests that unversioned record states get the
+// record data type and the default on_destroy
+func TestRecordUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewRecordResource()
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Schema.Version != recordSchemaVersion {
+		t.Fatalf("Expected schema version %d, got %d", recordSchemaVersion, schemaResp.Schema.Version)
+	}
+
+	upgrader := r.(fwresource.ResourceWithUpgradeState).UpgradeState(ctx)[0]
+
+	prior := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	prior.SetAttribute(ctx, path.Root("id"), "12")
+	prior.SetAttribute(ctx, path.Root("zone_id"), "7")
+	prior.SetAttribute(ctx, path.Root("type"), "A")
+	prior.SetAttribute(ctx, path.Root("data"), map[string]string{"address": "192.0.2.1"})
+
+	resp := fwresource.UpgradeStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &prior}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected upgrade error: %v", resp.Diagnostics)
+	}
+
+	var data RecordResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected error reading upgraded state: %v", resp.Diagnostics)
+	}
+
+	var recordData map[string]string
+	data.Data.ElementsAs(ctx, &recordData, false)
+	if recordData["address"] != "192.0.2.1" || !data.ConditionalData.IsNull() {
+		t.Errorf("Unexpected upgraded data %v, conditional data %v", data.Data, data.ConditionalData)
+	}
+	if data.OnDestroy.ValueString() != recordOnDestroyDelete {
+		t.Errorf("Expected on_destroy %q, got %s", recordOnDestroyDelete, data.OnDestroy)
+	}
+	if data.ID.ValueString() != "12" || data.WaitForResolution != nil {
+		t.Errorf("Unexpected upgraded state %+v", data)
+	}
+}
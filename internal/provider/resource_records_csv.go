@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RecordsCSVResource{}
+var _ resource.ResourceWithImportState = &RecordsCSVResource{}
+
+// NewRecordsCSVResource creates a new Records CSV resource.
+func NewRecordsCSVResource() resource.Resource {
+	return &RecordsCSVResource{}
+}
+
+// RecordsCSVResource defines the resource implementation. It owns every
+// record of a zone and reconciles them to a CSV document.
+type RecordsCSVResource struct {
+	recordReconciler
+	offline bool
+}
+
+// RecordsCSVResourceModel describes the resource data model.
+type RecordsCSVResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ZoneID      types.String `tfsdk:"zone_id"`
+	Content     types.String `tfsdk:"content"`
+	RecordCount types.Int64  `tfsdk:"record_count"`
+}
+
+// Metadata sets the resource type name.
+func (r *RecordsCSVResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_records_csv"
+}
+
+// Schema defines the resource schema.
+func (r *RecordsCSVResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages all records of a zone from CSV content in the SnitchDNS export format. " +
+			"Records missing from the CSV are deleted, so do not combine this resource with `snitchdns_record` on the same zone.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, equal to `zone_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone whose records are managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "CSV content with a header row. Columns are `type`, `cls`, `ttl`, `active`, `data`, " +
+					"`is_conditional`, `conditional_limit`, `conditional_reset` and `conditional_data`; only `type` and `data` are required. " +
+					"`data` and `conditional_data` hold JSON objects.",
+			},
+			"record_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of records in the zone after the last apply or refresh.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *RecordsCSVResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.records = providerData.Records
+	r.recordData = providerData.RecordData
+	r.offline = providerData.Offline
+}
+
+// CRUD methods are implemented in resource_records_csv_impl.go
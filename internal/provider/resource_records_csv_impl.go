@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *RecordsCSVResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create records from CSV")
+		return
+	}
+
+	var data RecordsCSVResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ZoneID
+	persist, diags := r.apply(ctx, &data, partialCreateRecovery)
+	resp.Diagnostics.Append(diags...)
+	if !persist {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *RecordsCSVResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data RecordsCSVResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.listRecords(ctx, data.ZoneID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "Zone not found, removing records CSV from state", map[string]any{
+				"zone_id": data.ZoneID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading records",
+			fmt.Sprintf("Could not list records of zone ID %s", data.ZoneID.ValueString()), err)
+		return
+	}
+
+	// Keep the configured content while the zone matches it, so formatting
+	// and row order do not show up as changes. Otherwise store a rendering
+	// of the actual records, which Terraform reports as drift.
+	desired, err := parseRecordsCSV(data.Content.ValueString())
+	if data.Content.IsNull() || err != nil || !planRecords(desired, existing).empty() {
+		actual := make([]zoneRecord, 0, len(existing))
+		for i := range existing {
+			actual = append(actual, zoneRecordFromClient(&existing[i]))
+		}
+		data.Content = types.StringValue(renderRecordsCSV(actual))
+	}
+
+	data.ID = data.ZoneID
+	data.RecordCount = types.Int64Value(int64(len(existing)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *RecordsCSVResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update records from CSV")
+		return
+	}
+
+	var data RecordsCSVResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	persist, diags := r.apply(ctx, &data, partialUpdateRecovery)
+	resp.Diagnostics.Append(diags...)
+	if !persist {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. Every record of the zone is
+// deleted, since the resource owns all of them.
+func (r *RecordsCSVResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete records from CSV")
+		return
+	}
+
+	var data RecordsCSVResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+
+	existing, err := r.listRecords(ctx, zoneID)
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Zone is already gone along with its records
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting records",
+			fmt.Sprintf("Could not list records of zone ID %s", zoneID), err)
+		return
+	}
+
+	ops := make([]batchOperation, 0, len(existing))
+	for _, record := range existing {
+		ops = append(ops, r.deleteOperation(zoneID, record))
+	}
+
+	results := runBatch(ctx, ops, defaultBatchParallelism)
+	r.records.Invalidate(zoneID)
+	resp.Diagnostics.Append(batchDiagnostics("Error deleting records", results)...)
+}
+
+// ImportState implements the resource import logic. The import ID is the
+// zone ID; content is populated with the zone's current records.
+func (r *RecordsCSVResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import records CSV")
+		return
+	}
+
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected a numeric zone ID, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// apply reconciles the zone's records to the planned content and sets the
+// record count. New records are uploaded through the CSV import endpoint
+// when the server has one, and created one by one otherwise. It reports
+// whether data is to be saved: when some operations failed after others
+// were applied, data holds a rendering of the zone's records, with recovery
+// advising on the remaining changes.
+func (r *RecordsCSVResource) apply(ctx context.Context, data *RecordsCSVResourceModel, recovery string) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	zoneID := data.ZoneID.ValueString()
+
+	desired, err := parseRecordsCSV(data.Content.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("content"), "Invalid CSV content", err.Error())
+		return false, diags
+	}
+
+	existing, err := r.listRecords(ctx, zoneID)
+	if err != nil {
+		addAPIError(&diags, "Error reading records", fmt.Sprintf("Could not list records of zone ID %s", zoneID), err)
+		return false, diags
+	}
+
+	plan := planRecords(desired, existing)
+	tflog.Debug(ctx, "Reconciling zone records to CSV", map[string]any{
+		"zone_id": zoneID,
+		"creates": len(plan.Creates),
+		"updates": len(plan.Updates),
+		"deletes": len(plan.Deletes),
+	})
+
+	// Records are imported or created last, once the records they replace
+	// are gone
+	creates := plan.Creates
+	plan.Creates = nil
+	results := r.applyPlan(ctx, zoneID, plan, defaultBatchParallelism)
+
+	if len(creates) > 0 {
+		imported, err := r.importRecords(ctx, zoneID, creates)
+		switch {
+		case err != nil:
+			results = append(results, batchResult{Description: fmt.Sprintf("import %d records", len(creates)), Err: err})
+		case !imported:
+			results = append(results, r.applyPlan(ctx, zoneID, recordPlan{Creates: creates}, defaultBatchParallelism)...)
+		default:
+			results = append(results, batchResult{Description: fmt.Sprintf("import %d records", len(creates))})
+		}
+	}
+
+	r.records.Invalidate(zoneID)
+	diags.Append(partialFailureDiagnostics("Error applying records CSV", recovery, results)...)
+	partial := diags.HasError()
+	if partial && !anyApplied(results) {
+		return false, diags
+	}
+
+	records, err := r.listRecords(ctx, zoneID)
+	if err != nil {
+		addAPIError(&diags, "Error reading records", fmt.Sprintf("Could not list records of zone ID %s", zoneID), err)
+		return false, diags
+	}
+	data.RecordCount = types.Int64Value(int64(len(records)))
+
+	// Save the records as they are now, which the next plan compares to
+	// the configuration
+	if partial {
+		actual := make([]zoneRecord, 0, len(records))
+		for i := range records {
+			actual = append(actual, zoneRecordFromClient(&records[i]))
+		}
+		data.Content = types.StringValue(renderRecordsCSV(actual))
+	}
+
+	return true, diags
+}
+
+// importRecords uploads records through the CSV import endpoint. It returns
+// false without an error when the server has no such endpoint.
+func (r *RecordsCSVResource) importRecords(ctx context.Context, zoneID string, records []zoneRecord) (bool, error) {
+	mapped := make([]zoneRecord, 0, len(records))
+	for _, record := range records {
+		record.Data = stringifyRecordData(r.recordData.ToServer(record.Type, anyRecordData(record.Data)))
+		record.ConditionalData = stringifyRecordData(r.recordData.ToServer(record.Type, anyRecordData(record.ConditionalData)))
+		mapped = append(mapped, record)
+	}
+
+	err := r.client.ImportRecordsCSV(ctx, zoneID, []byte(renderRecordsCSV(mapped)))
+	r.records.Invalidate(zoneID)
+	if err != nil {
+		if status := snitchdns.StatusCode(err); status == http.StatusNotFound || status == http.StatusMethodNotAllowed {
+			tflog.Debug(ctx, "CSV import endpoint not available, creating records individually", map[string]any{
+				"zone_id": zoneID,
+			})
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
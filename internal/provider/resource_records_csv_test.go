@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccRecordsCSVResource tests reconciling a zone's records to CSV content
+func TestAccRecordsCSVResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	container, namespace := testAccSharedContainer(t)
+	domain := testcontainer.NamespacedDomain(namespace, "csv.example.com")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRecordsCSVResourceConfig(container, domain, `type,ttl,data
+A,300,"{""address"": ""10.0.0.1""}"
+TXT,60,"{""data"": ""hello""}"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("snitchdns_records_csv.test", "id", "snitchdns_zone.test", "id"),
+					resource.TestCheckResourceAttr("snitchdns_records_csv.test", "record_count", "2"),
+				),
+			},
+			{
+				Config: testAccRecordsCSVResourceConfig(container, domain, `type,ttl,data
+A,120,"{""address"": ""10.0.0.1""}"
+AAAA,300,"{""address"": ""2001:db8::1""}"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_records_csv.test", "record_count", "2"),
+				),
+			},
+			{
+				ResourceName:            "snitchdns_records_csv.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"content"},
+			},
+		},
+	})
+}
+
+// testAccRecordsCSVResourceConfig generates HCL configuration for records CSV testing
+func testAccRecordsCSVResourceConfig(container *testcontainer.SnitchDNSContainer, domain, content string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = %[3]q
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_records_csv" "test" {
+  zone_id = snitchdns_zone.test.id
+  content = <<-EOT
+%[4]sEOT
+}
+`, container.GetAPIEndpoint(), container.APIKey, domain, content)
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SubzoneResource{}
+var _ resource.ResourceWithImportState = &SubzoneResource{}
+var _ resource.ResourceWithModifyPlan = &SubzoneResource{}
+
+// subzoneLabelPattern matches one or more DNS labels separated by dots
+var subzoneLabelPattern = regexp.MustCompile(`^(?i)[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9_])?(\.[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9_])?)*$`)
+
+// NewSubzoneResource creates a new Subzone resource.
+func NewSubzoneResource() resource.Resource {
+	return &SubzoneResource{}
+}
+
+// SubzoneResource defines the resource implementation. It manages a zone
+// whose domain is a label below the domain of a parent zone, and whose
+// settings default to those of the parent.
+type SubzoneResource struct {
+	client             snitchdns.ClientInterface
+	quota              *ZoneQuota
+	offline            bool
+	defaultUserID      int
+	allowInPlaceRename bool
+}
+
+// SubzoneResourceModel describes the resource data model.
+type SubzoneResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ParentZoneID types.String `tfsdk:"parent_zone_id"`
+	Label        types.String `tfsdk:"label"`
+	Domain       DomainValue  `tfsdk:"domain"`
+	Active       types.Bool   `tfsdk:"active"`
+	Forwarding   types.Bool   `tfsdk:"forwarding"`
+	CatchAll     types.Bool   `tfsdk:"catch_all"`
+	Tags         types.Set    `tfsdk:"tags"`
+}
+
+// Metadata sets the resource type name.
+func (r *SubzoneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subzone"
+}
+
+// Schema defines the resource schema.
+func (r *SubzoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a zone below a parent zone, such as `<token>.<engagement>.canary.example.com`. " +
+			"The domain is composed from `label` and the parent's domain, and `active`, `forwarding` and `tags` default to the parent's settings.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the zone.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent_zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the parent zone whose domain the zone is created below. The parent must not be a regex zone. Changing this forces a new resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"label": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "Labels prepended to the parent's domain, e.g. `token` or `token.engagement`. " +
+					"Labels consist of letters, digits, hyphens and underscores. Changing this forces a new resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(subzoneLabelPattern, "must be one or more DNS labels separated by dots"),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Domain name of the zone: `label` followed by the parent's domain. Known at plan time when the parent zone exists. " +
+					"When the parent is renamed, the zone follows it, replacing the zone unless the provider's `allow_in_place_rename` is set.",
+				CustomType: DomainType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the zone is active and answers DNS queries. Defaults to the parent's setting.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"forwarding": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether unmatched queries are forwarded to the upstream resolver. Defaults to the parent's setting.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"catch_all": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the zone answers queries for any name below it. Defaults to `false`.",
+			},
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Tags of the zone. Defaults to the parent's tags; set an empty set for a zone without tags. Tags must not contain commas.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^,]+$`), "must not contain commas"),
+					),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *SubzoneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.quota = providerData.Quota
+	r.offline = providerData.Offline
+	r.defaultUserID = providerData.DefaultUserID
+	r.allowInPlaceRename = providerData.AllowInPlaceRename
+}
+
+// CRUD methods are implemented in resource_subzone_impl.go
@@ -0,0 +1,408 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *SubzoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create subzone")
+		return
+	}
+
+	var data SubzoneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The plan leaves the inherited settings unknown when the parent was
+	// created in the same apply
+	parent := r.parent(ctx, data.ParentZoneID.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(data.inherit(ctx, parent)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags, diags := data.tags(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.CreateZoneWithContext(ctx, snitchdns.CreateZoneRequest{
+		Domain:     data.Domain.ValueString(),
+		Active:     data.Active.ValueBool(),
+		CatchAll:   data.CatchAll.ValueBool(),
+		Forwarding: data.Forwarding.ValueBool(),
+		Tags:       tags,
+		UserID:     r.defaultUserID,
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error creating subzone",
+			fmt.Sprintf("Could not create zone %s", data.Domain.ValueString()), err, zoneErrorAttributes...)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setZone(ctx, zone)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *SubzoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data SubzoneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.GetZoneWithContext(ctx, data.ID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "Subzone not found, removing from state", map[string]any{
+				"zone_id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading subzone",
+			fmt.Sprintf("Could not read zone ID %s", data.ID.ValueString()), err)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setZone(ctx, zone)...)
+
+	// After import, the label is what the zone's domain adds to the parent's
+	if data.Label.IsNull() {
+		parent := r.parent(ctx, data.ParentZoneID.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		label, ok := subzoneLabel(zone.Domain, parent.Domain)
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Zone is not below its parent",
+				fmt.Sprintf("The domain %s of zone ID %s is not below the domain %s of parent zone ID %s.",
+					zone.Domain, data.ID.ValueString(), parent.Domain, data.ParentZoneID.ValueString()),
+			)
+			return
+		}
+		data.Label = types.StringValue(label)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *SubzoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update subzone")
+		return
+	}
+
+	var data SubzoneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.hasUnknown() {
+		parent := r.parent(ctx, data.ParentZoneID.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(data.inherit(ctx, parent)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tags, diags := data.tags(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := data.Domain.ValueString()
+	active := data.Active.ValueBool()
+	catchAll := data.CatchAll.ValueBool()
+	forwarding := data.Forwarding.ValueBool()
+	zone, err := r.client.UpdateZoneWithContext(ctx, data.ID.ValueString(), snitchdns.UpdateZoneRequest{
+		Domain:     &domain,
+		Active:     &active,
+		CatchAll:   &catchAll,
+		Forwarding: &forwarding,
+		Tags:       &tags,
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating subzone",
+			fmt.Sprintf("Could not update zone ID %s", data.ID.ValueString()), err, zoneErrorAttributes...)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setZone(ctx, zone)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic
+func (r *SubzoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete subzone")
+		return
+	}
+
+	var data SubzoneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteZoneWithContext(ctx, data.ID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Zone is already gone
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting subzone",
+			fmt.Sprintf("Could not delete zone ID %s", data.ID.ValueString()), err)
+		return
+	}
+}
+
+// ModifyPlan composes the domain from the label and the parent's domain,
+// and plans the parent's settings for those that are not configured, so
+// both are known at plan time. A renamed parent replaces the zone unless
+// the provider allows renaming zones in place.
+func (r *SubzoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// The resource is being destroyed
+		return
+	}
+
+	creating := req.State.Raw.IsNull()
+	if creating && !r.offline && r.quota != nil && r.defaultUserID == 0 {
+		resp.Diagnostics.Append(r.quota.Plan(ctx, 1)...)
+	}
+
+	var plan, config SubzoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || r.offline || plan.ParentZoneID.IsUnknown() || plan.Label.IsUnknown() {
+		return
+	}
+
+	parent, err := r.client.GetZoneWithContext(ctx, plan.ParentZoneID.ValueString())
+	if err != nil {
+		if !creating && snitchdns.IsNotFound(err) {
+			// The zone outlives its parent; keep its settings
+			tflog.Warn(ctx, "Parent zone of subzone not found, keeping its settings", map[string]any{
+				"zone_id":        plan.ID.ValueString(),
+				"parent_zone_id": plan.ParentZoneID.ValueString(),
+			})
+			return
+		}
+		resp.Diagnostics.Append(subzoneParentError(plan.ParentZoneID.ValueString(), err)...)
+		return
+	}
+	resp.Diagnostics.Append(checkSubzoneParent(parent)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Domain = NewDomainUnknown()
+	if config.Active.IsNull() {
+		plan.Active = types.BoolUnknown()
+	}
+	if config.Forwarding.IsNull() {
+		plan.Forwarding = types.BoolUnknown()
+	}
+	if config.Tags.IsNull() {
+		plan.Tags = types.SetUnknown(types.StringType)
+	}
+	resp.Diagnostics.Append(plan.inherit(ctx, parent)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+
+	if creating || r.allowInPlaceRename {
+		return
+	}
+	var prior DomainValue
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("domain"), &prior)...)
+	if snitchdns.NormalizeZoneDomain(plan.Domain.ValueString()) != snitchdns.NormalizeZoneDomain(prior.ValueString()) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("domain"))
+	}
+}
+
+// ImportState implements the resource import logic
+func (r *SubzoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import subzone")
+		return
+	}
+
+	// Import ID format: "parent_zone_id:zone_id" or "parent_zone_id/zone_id"
+	parentID, zoneID, ok := strings.Cut(req.ID, ":")
+	if !ok {
+		parentID, zoneID, ok = strings.Cut(req.ID, "/")
+	}
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID format",
+			fmt.Sprintf("Expected import ID format 'parent_zone_id:zone_id' or 'parent_zone_id/zone_id', got: %s", req.ID),
+		)
+		return
+	}
+
+	for _, id := range []string{parentID, zoneID} {
+		if _, err := strconv.Atoi(id); err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid zone ID",
+				fmt.Sprintf("Zone ID must be numeric, got: %s", id),
+			)
+			return
+		}
+	}
+
+	// Read derives the label while it is null
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("parent_zone_id"), parentID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), zoneID)...)
+}
+
+// parent reads the parent zone and checks that zones can be created below
+// it
+func (r *SubzoneResource) parent(ctx context.Context, id string, diags *diag.Diagnostics) *snitchdns.Zone {
+	zone, err := r.client.GetZoneWithContext(ctx, id)
+	if err != nil {
+		diags.Append(subzoneParentError(id, err)...)
+		return nil
+	}
+
+	diags.Append(checkSubzoneParent(zone)...)
+	if diags.HasError() {
+		return nil
+	}
+	return zone
+}
+
+// subzoneParentError reports a failed read of the parent zone
+func subzoneParentError(id string, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if snitchdns.IsNotFound(err) {
+		diags.AddAttributeError(path.Root("parent_zone_id"), "Parent zone not found",
+			fmt.Sprintf("Zone ID %s does not exist.", id))
+		return diags
+	}
+
+	addAPIError(&diags, "Error reading parent zone", fmt.Sprintf("Could not read zone ID %s", id), err)
+	return diags
+}
+
+// checkSubzoneParent checks that names can be composed with the domain of
+// the parent zone
+func checkSubzoneParent(parent *snitchdns.Zone) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if parent.Regex {
+		diags.AddAttributeError(path.Root("parent_zone_id"), "Invalid parent zone",
+			fmt.Sprintf("Zone %s is a regex zone. Its domain is a pattern, which subzone domains cannot be composed with.", parent.Domain))
+	}
+	return diags
+}
+
+// subzoneDomain composes the domain of a subzone
+func subzoneDomain(label, parentDomain string) string {
+	return label + "." + strings.TrimSuffix(parentDomain, ".")
+}
+
+// subzoneLabel returns the labels domain adds to the parent's domain, and
+// false when domain is not below it
+func subzoneLabel(domain, parentDomain string) (string, bool) {
+	suffix := "." + snitchdns.NormalizeZoneDomain(parentDomain)
+	name := strings.TrimSuffix(domain, ".")
+	if len(name) <= len(suffix) || !strings.HasSuffix(strings.ToLower(name), suffix) {
+		return "", false
+	}
+	return name[:len(name)-len(suffix)], true
+}
+
+// inherit composes the domain when it is unknown and sets the unknown
+// settings to those of the parent zone
+func (m *SubzoneResourceModel) inherit(ctx context.Context, parent *snitchdns.Zone) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if m.Domain.IsUnknown() {
+		domain := subzoneDomain(m.Label.ValueString(), parent.Domain)
+		if len(domain) > 255 {
+			diags.AddAttributeError(path.Root("label"), "Domain too long",
+				fmt.Sprintf("The domain %s is %d characters long, but zone domains are at most 255.", domain, len(domain)))
+			return diags
+		}
+		m.Domain = NewDomainValue(domain)
+	}
+	if m.Active.IsUnknown() {
+		m.Active = types.BoolValue(parent.Active)
+	}
+	if m.Forwarding.IsUnknown() {
+		m.Forwarding = types.BoolValue(parent.Forwarding)
+	}
+	if m.Tags.IsUnknown() {
+		m.Tags, diags = zoneTagsValue(ctx, types.SetNull(types.StringType), parent.Tags)
+	}
+	return diags
+}
+
+// hasUnknown reports whether the plan left the domain or an inherited
+// setting unknown
+func (m *SubzoneResourceModel) hasUnknown() bool {
+	return m.Domain.IsUnknown() || m.Active.IsUnknown() || m.Forwarding.IsUnknown() || m.Tags.IsUnknown()
+}
+
+// tags returns the planned zone tags
+func (m *SubzoneResourceModel) tags(ctx context.Context) (snitchdns.ZoneTags, diag.Diagnostics) {
+	var tags []string
+	var diags diag.Diagnostics
+	if !m.Tags.IsNull() {
+		diags.Append(m.Tags.ElementsAs(ctx, &tags, false)...)
+	}
+	return snitchdns.NewZoneTags(tags), diags
+}
+
+// setZone copies a zone read from the server into the model
+func (m *SubzoneResourceModel) setZone(ctx context.Context, zone *snitchdns.Zone) diag.Diagnostics {
+	m.ID = types.StringValue(strconv.Itoa(zone.ID))
+	m.Domain = NewDomainValue(zone.Domain)
+	m.Active = types.BoolValue(zone.Active)
+	m.Forwarding = types.BoolValue(zone.Forwarding)
+	m.CatchAll = types.BoolValue(zone.CatchAll)
+
+	tags, diags := zoneTagsValue(ctx, m.Tags, zone.Tags)
+	m.Tags = tags
+	return diags
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestSubzoneResource_Mock tests that the domain is composed and the
+// settings inherited from the parent at plan time, unless configured
+func TestSubzoneResource_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	parent := mock.AddZone(snitchdns.Zone{
+		Domain: "engagement.canary.example.com", Active: true, Forwarding: true, Tags: []string{"canary"},
+	})
+	r := newMockResource(t, NewSubzoneResource(), mock)
+
+	config := mockPlan(t, r, map[string]attr.Value{
+		"parent_zone_id": types.StringValue(fmt.Sprint(parent.ID)),
+		"label":          types.StringValue("token"),
+		"forwarding":     types.BoolValue(false),
+		"catch_all":      types.BoolValue(true),
+	})
+	// The framework plans unknown values for unconfigured computed attributes
+	plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw.Copy()}
+	plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())
+	plan.SetAttribute(ctx, path.Root("domain"), NewDomainUnknown())
+	plan.SetAttribute(ctx, path.Root("active"), types.BoolUnknown())
+	plan.SetAttribute(ctx, path.Root("tags"), types.SetUnknown(types.StringType))
+
+	modifyResp := fwresource.ModifyPlanResponse{Plan: plan}
+	r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+		Plan:   plan,
+		State:  tfsdk.State{Schema: config.Schema, Raw: tftypes.NewValue(config.Raw.Type(), nil)},
+	}, &modifyResp)
+	if modifyResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected plan error: %v", modifyResp.Diagnostics)
+	}
+
+	var planned SubzoneResourceModel
+	modifyResp.Plan.Get(ctx, &planned)
+	if planned.Domain.ValueString() != "token.engagement.canary.example.com" {
+		t.Errorf("Expected the composed domain, got %s", planned.Domain)
+	}
+	if !planned.Active.ValueBool() || planned.Forwarding.ValueBool() || len(planned.Tags.Elements()) != 1 {
+		t.Errorf("Expected active and tags of the parent and the configured forwarding, got %+v", planned)
+	}
+
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: modifyResp.Plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: modifyResp.Plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+	zone, err := snitchdns.FindZoneByDomain(ctx, mock, "token.engagement.canary.example.com")
+	if err != nil {
+		t.Fatalf("Expected the subzone to be created: %v", err)
+	}
+	if !zone.Active || zone.Forwarding || !zone.CatchAll || zone.Tags.String() != "canary" {
+		t.Errorf("Unexpected subzone %+v", zone)
+	}
+
+	// A parent created in the same apply is read when creating the zone
+	plan = mockPlan(t, r, map[string]attr.Value{
+		"parent_zone_id": types.StringValue(fmt.Sprint(parent.ID)),
+		"label":          types.StringValue("other.team"),
+		"id":             types.StringUnknown(),
+		"domain":         NewDomainUnknown(),
+		"active":         types.BoolUnknown(),
+		"forwarding":     types.BoolUnknown(),
+		"catch_all":      types.BoolValue(false),
+		"tags":           types.SetValueMust(types.StringType, nil),
+	})
+	createResp = fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+	zone, err = snitchdns.FindZoneByDomain(ctx, mock, "other.team.engagement.canary.example.com")
+	if err != nil || !zone.Forwarding || len(zone.Tags) != 0 {
+		t.Errorf("Expected the forwarding of the parent and no tags, got %+v, %v", zone, err)
+	}
+
+	// After import, the label is derived from the domains
+	importResp := fwresource.ImportStateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: mockPlan(t, r, nil).Raw}}
+	r.(fwresource.ResourceWithImportState).ImportState(ctx, fwresource.ImportStateRequest{
+		ID: fmt.Sprintf("%d/%d", parent.ID, zone.ID),
+	}, &importResp)
+	readResp := fwresource.ReadResponse{State: importResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", readResp.Diagnostics)
+	}
+	var label types.String
+	readResp.State.GetAttribute(ctx, path.Root("label"), &label)
+	if label.ValueString() != "other.team" {
+		t.Errorf("Expected label other.team, got %s", label)
+	}
+}
+
+// TestSubzoneResource_ModifyPlanParent tests the checks of the parent zone
+// and that a renamed parent replaces the zone
+func TestSubzoneResource_ModifyPlanParent(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	parent := mock.AddZone(snitchdns.Zone{Domain: "parent.example.com", Active: true})
+	pattern := mock.AddZone(snitchdns.Zone{Domain: `^x\d+\.example\.com$`, Regex: true})
+	r := newMockResource(t, NewSubzoneResource(), mock)
+
+	state := mockPlan(t, r, map[string]attr.Value{
+		"id":             types.StringValue("99"),
+		"parent_zone_id": types.StringValue(fmt.Sprint(parent.ID)),
+		"label":          types.StringValue("token"),
+		"domain":         NewDomainValue("token.old.example.com"),
+		"active":         types.BoolValue(true),
+		"forwarding":     types.BoolValue(false),
+		"catch_all":      types.BoolValue(false),
+	})
+	tests := map[string]struct {
+		parentID string
+		creating bool
+		allow    bool
+		replace  bool
+		err      bool
+	}{
+		"renamed parent":     {fmt.Sprint(parent.ID), false, false, true, false},
+		"renamed in place":   {fmt.Sprint(parent.ID), false, true, false, false},
+		"regex parent":       {fmt.Sprint(pattern.ID), true, false, false, true},
+		"missing parent":     {"1234", false, false, false, false},
+		"missing new parent": {"1234", true, false, false, true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r.(*SubzoneResource).allowInPlaceRename = tt.allow
+
+			config := mockPlan(t, r, map[string]attr.Value{
+				"parent_zone_id": types.StringValue(tt.parentID),
+				"label":          types.StringValue("token"),
+			})
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}
+			plan.SetAttribute(ctx, path.Root("parent_zone_id"), tt.parentID)
+			prior := tfsdk.State{Schema: state.Schema, Raw: state.Raw}
+			if tt.creating {
+				prior.Raw = tftypes.NewValue(state.Raw.Type(), nil)
+			}
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+				Plan:   plan,
+				State:  prior,
+			}, &resp)
+			if resp.Diagnostics.HasError() != tt.err {
+				t.Fatalf("Expected error %v, got %v", tt.err, resp.Diagnostics)
+			}
+			if replace := len(resp.RequiresReplace) > 0; replace != tt.replace {
+				t.Errorf("Expected replace %v, got %v", tt.replace, resp.RequiresReplace)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// settingLogUnmatchedQueries is the server setting controlling whether
+	// queries for names SnitchDNS does not serve are logged
+	settingLogUnmatchedQueries = "dns_log_unmatched"
+
+	// unmatchedQueryLoggingID is the fixed ID of the singleton resource
+	unmatchedQueryLoggingID = "unmatched_query_logging"
+
+	// defaultLogUnmatchedQueries is the SnitchDNS default, restored on destroy
+	defaultLogUnmatchedQueries = true
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UnmatchedQueryLoggingResource{}
+var _ resource.ResourceWithImportState = &UnmatchedQueryLoggingResource{}
+
+// NewUnmatchedQueryLoggingResource creates a new Unmatched Query Logging resource.
+func NewUnmatchedQueryLoggingResource() resource.Resource {
+	return &UnmatchedQueryLoggingResource{}
+}
+
+// UnmatchedQueryLoggingResource defines the resource implementation.
+type UnmatchedQueryLoggingResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// UnmatchedQueryLoggingResourceModel describes the resource data model.
+type UnmatchedQueryLoggingResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+// Metadata sets the resource type name.
+func (r *UnmatchedQueryLoggingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_unmatched_query_logging"
+}
+
+// Schema defines the resource schema.
+func (r *UnmatchedQueryLoggingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Controls whether SnitchDNS logs queries for zones and names it does not serve. " +
+			"This is a server-wide setting and requires an admin API key; declare at most one instance per server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Fixed identifier of the setting.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Required:            true,
+				MarkdownDescription: "Whether queries that do not match any zone are logged.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *UnmatchedQueryLoggingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// CRUD methods are implemented in resource_unmatched_query_logging_impl.go
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Create implements the resource create logic
+func (r *UnmatchedQueryLoggingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create unmatched query logging")
+		return
+	}
+
+	var data UnmatchedQueryLoggingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.apply(ctx, data.Enabled.ValueBool())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error setting unmatched query logging", "Could not update server settings", err)
+		return
+	}
+
+	data.setFromSettings(settings)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *UnmatchedQueryLoggingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data UnmatchedQueryLoggingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.client.GetSettings(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading unmatched query logging", "Could not read server settings", err)
+		return
+	}
+
+	data.setFromSettings(settings)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *UnmatchedQueryLoggingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update unmatched query logging")
+		return
+	}
+
+	var data UnmatchedQueryLoggingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.apply(ctx, data.Enabled.ValueBool())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error setting unmatched query logging", "Could not update server settings", err)
+		return
+	}
+
+	data.setFromSettings(settings)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. The setting is returned to the
+// SnitchDNS default.
+func (r *UnmatchedQueryLoggingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete unmatched query logging")
+		return
+	}
+
+	var data UnmatchedQueryLoggingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.apply(ctx, defaultLogUnmatchedQueries); err != nil {
+		addAPIError(&resp.Diagnostics, "Error resetting unmatched query logging", "Could not update server settings", err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic. The setting is a
+// singleton, so any import ID refers to it.
+func (r *UnmatchedQueryLoggingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import unmatched query logging")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), unmatchedQueryLoggingID)...)
+}
+
+// apply updates the unmatched query logging setting
+func (r *UnmatchedQueryLoggingResource) apply(ctx context.Context, enabled bool) (snitchdns.Settings, error) {
+	return r.client.UpdateSettings(ctx, snitchdns.Settings{
+		settingLogUnmatchedQueries: snitchdns.FormatBool(enabled),
+	})
+}
+
+// setFromSettings maps the server settings to the data model
+func (m *UnmatchedQueryLoggingResourceModel) setFromSettings(settings snitchdns.Settings) {
+	m.ID = types.StringValue(unmatchedQueryLoggingID)
+	m.Enabled = types.BoolValue(settings.Bool(settingLogUnmatchedQueries))
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccUnmatchedQueryLoggingResource tests toggling unmatched query logging
+func TestAccUnmatchedQueryLoggingResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUnmatchedQueryLoggingResourceConfig(container, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_unmatched_query_logging.test", "id", "unmatched_query_logging"),
+					resource.TestCheckResourceAttr("snitchdns_unmatched_query_logging.test", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_unmatched_query_logging.test",
+				ImportState:       true,
+				ImportStateId:     "unmatched_query_logging",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccUnmatchedQueryLoggingResourceConfig(container, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_unmatched_query_logging.test", "enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+// testAccUnmatchedQueryLoggingResourceConfig generates HCL configuration for unmatched query logging testing
+func testAccUnmatchedQueryLoggingResourceConfig(container *testcontainer.SnitchDNSContainer, enabled bool) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_unmatched_query_logging" "test" {
+  enabled = %[3]t
+}
+`, container.GetAPIEndpoint(), container.APIKey, enabled)
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WildcardRecordResource{}
+var _ resource.ResourceWithImportState = &WildcardRecordResource{}
+var _ resource.ResourceWithModifyPlan = &WildcardRecordResource{}
+
+// NewWildcardRecordResource creates a new Wildcard Record resource.
+func NewWildcardRecordResource() resource.Resource {
+	return &WildcardRecordResource{}
+}
+
+// WildcardRecordResource defines the resource implementation. SnitchDNS has
+// no wildcard owner names; a zone with catch_all set answers every name below
+// it with its records, so a wildcard record is a record in a catch-all zone.
+type WildcardRecordResource struct {
+	client     snitchdns.ClientInterface
+	records    *RecordCache
+	recordData *recordDataMapper
+	validator  *requestValidator
+	offline    bool
+	defaultTTL int64
+}
+
+// WildcardRecordResourceModel describes the resource data model.
+type WildcardRecordResourceModel struct {
+	ID      types.String    `tfsdk:"id"`
+	ZoneID  types.String    `tfsdk:"zone_id"`
+	Class   types.String    `tfsdk:"cls"`
+	Type    types.String    `tfsdk:"type"`
+	TTL     types.Int64     `tfsdk:"ttl"`
+	Data    RecordDataValue `tfsdk:"data"`
+	Matches types.String    `tfsdk:"matches"`
+}
+
+// Metadata sets the resource type name.
+func (r *WildcardRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_wildcard_record"
+}
+
+// Schema defines the resource schema.
+func (r *WildcardRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a record answered for every name below a zone (`*.zone`). " +
+			"SnitchDNS models wildcards through the zone's catch-all setting, so the zone must have `catch_all` enabled, " +
+			"either on `snitchdns_zone` or through `snitchdns_zone_capture` with mode `catch_all`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the underlying DNS record.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the catch-all zone the wildcard belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cls": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("IN"),
+				MarkdownDescription: "DNS class. Defaults to `IN`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("IN", "CH", "HS"),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "DNS record type answered for every name below the zone.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("A", "AAAA", "CNAME", "MX", "TXT", "SPF", "CAA", "SRV", "PTR", "NS"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Time to live in seconds, between 1 and 2147483647. Defaults to the provider's `default_record_ttl`.",
+				Validators: []validator.Int64{
+					recordTTLValidator(),
+				},
+			},
+			"data": schema.MapAttribute{
+				ElementType:         types.StringType,
+				CustomType:          NewRecordDataType(),
+				Required:            true,
+				MarkdownDescription: "Record data, in the same format as the `data` attribute of `snitchdns_record`, checked against the record type at plan time.",
+			},
+			"matches": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The wildcard name answered by this record, e.g. `*.canary.example.com`.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *WildcardRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.records = providerData.Records
+	r.recordData = providerData.RecordData
+	r.validator = providerData.Validator
+	r.offline = providerData.Offline
+	r.defaultTTL = providerData.DefaultRecordTTL
+}
+
+// CRUD methods are implemented in resource_wildcard_record_impl.go
@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *WildcardRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create wildcard record")
+		return
+	}
+
+	var data WildcardRecordResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone := r.catchAllZone(ctx, data.ZoneID.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordData, diags := recordDataFromMap(ctx, data.Data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	record, err := r.client.CreateRecordWithContext(ctx, data.ZoneID.ValueString(), snitchdns.CreateRecordRequest{
+		Active: true,
+		Class:  data.Class.ValueString(),
+		Type:   data.Type.ValueString(),
+		TTL:    int(data.TTL.ValueInt64()),
+		Data:   r.recordData.ToServer(data.Type.ValueString(), recordData),
+	})
+	r.records.Invalidate(data.ZoneID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error creating wildcard record", "Could not create record", err, recordErrorAttributes...)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setFromRecord(ctx, r.recordData.FromServerRecord(record), zone)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *WildcardRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data WildcardRecordResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.GetZoneWithContext(ctx, data.ZoneID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "Wildcard record zone not found, removing from state", map[string]any{
+				"zone_id": data.ZoneID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading wildcard zone",
+			fmt.Sprintf("Could not read zone ID %s", data.ZoneID.ValueString()), err)
+		return
+	}
+
+	record, found, err := r.records.GetRecord(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading wildcard record",
+			fmt.Sprintf("Could not read record ID %s in zone %s", data.ID.ValueString(), data.ZoneID.ValueString()), err)
+		return
+	}
+	if !found {
+		tflog.Warn(ctx, "Wildcard record not found, removing from state", map[string]any{
+			"zone_id":   data.ZoneID.ValueString(),
+			"record_id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if !zone.CatchAll {
+		resp.Diagnostics.AddWarning(
+			"Zone no longer captures wildcard names",
+			fmt.Sprintf("Zone %s no longer has catch_all enabled, so the wildcard record %s is only answered for %s itself.",
+				zone.Domain, data.ID.ValueString(), zone.Domain),
+		)
+	}
+
+	resp.Diagnostics.Append(data.setFromRecord(ctx, r.recordData.FromServerRecord(record), zone)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *WildcardRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update wildcard record")
+		return
+	}
+
+	var data WildcardRecordResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone := r.catchAllZone(ctx, data.ZoneID.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordData, diags := recordDataFromMap(ctx, data.Data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cls := data.Class.ValueString()
+	ttl := int(data.TTL.ValueInt64())
+
+	record, err := r.client.UpdateRecordWithContext(ctx, data.ZoneID.ValueString(), data.ID.ValueString(), snitchdns.UpdateRecordRequest{
+		Class: &cls,
+		TTL:   &ttl,
+		Data:  r.recordData.ToServer(data.Type.ValueString(), recordData),
+	})
+	r.records.Invalidate(data.ZoneID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating wildcard record",
+			fmt.Sprintf("Could not update record ID %s", data.ID.ValueString()), err, recordErrorAttributes...)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setFromRecord(ctx, r.recordData.FromServerRecord(record), zone)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic
+func (r *WildcardRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete wildcard record")
+		return
+	}
+
+	var data WildcardRecordResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteRecordWithContext(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
+	r.records.Invalidate(data.ZoneID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Record is already gone
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting wildcard record",
+			fmt.Sprintf("Could not delete record ID %s", data.ID.ValueString()), err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic
+func (r *WildcardRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import wildcard record")
+		return
+	}
+
+	// Import ID format: "zone_id:record_id"
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID format",
+			fmt.Sprintf("Expected import ID format 'zone_id:record_id', got: %s", req.ID),
+		)
+		return
+	}
+
+	for _, id := range parts {
+		if _, err := strconv.Atoi(id); err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid import ID",
+				fmt.Sprintf("Zone and record IDs must be numeric, got: %s", req.ID),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// ModifyPlan plans the default TTL and checks the planned record data
+// against the fields of the record type, so malformed data fails at plan
+// time rather than at the API
+func (r *WildcardRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// The resource is being destroyed
+		return
+	}
+
+	planDefaultTTL(ctx, req, resp, r.defaultTTL)
+
+	var recordType types.String
+	var data RecordDataValue
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("data"), &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.validator.ValidatePlannedRecordData(recordType, data); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("data"), "Invalid Record Data", err.Error())
+	}
+}
+
+// catchAllZone reads the zone and adds an error unless it answers every name
+// below it, which is what makes its records wildcards
+func (r *WildcardRecordResource) catchAllZone(ctx context.Context, zoneID string, diags *diag.Diagnostics) *snitchdns.Zone {
+	zone, err := r.client.GetZoneWithContext(ctx, zoneID)
+	if err != nil {
+		addAPIError(diags, "Error reading wildcard zone", fmt.Sprintf("Could not read zone ID %s", zoneID), err)
+		return nil
+	}
+
+	if !zone.CatchAll {
+		diags.AddAttributeError(
+			path.Root("zone_id"),
+			"Zone does not capture wildcard names",
+			fmt.Sprintf("Zone %s does not have catch_all enabled, so SnitchDNS would only answer the record for %s itself. "+
+				"Set catch_all = true on the zone, or manage it with snitchdns_zone_capture (mode = \"catch_all\") "+
+				"and use that resource's zone_id so it is applied first.", zone.Domain, zone.Domain),
+		)
+		return nil
+	}
+
+	return zone
+}
+
+// recordDataFromMap converts a data attribute to the API representation
+func recordDataFromMap(ctx context.Context, m RecordDataValue) (map[string]interface{}, diag.Diagnostics) {
+	var values map[string]string
+	diags := m.ElementsAs(ctx, &values, false)
+
+	data := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		data[key] = value
+	}
+	return data, diags
+}
+
+// setFromRecord maps the record and its zone to the data model
+func (m *WildcardRecordResourceModel) setFromRecord(ctx context.Context, record *snitchdns.Record, zone *snitchdns.Zone) diag.Diagnostics {
+	dataValue, diags := NewRecordDataValue(ctx, record.Data.Strings())
+
+	m.ID = types.StringValue(strconv.Itoa(record.ID))
+	m.Class = types.StringValue(record.Class)
+	m.Type = types.StringValue(record.Type)
+	m.TTL = types.Int64Value(int64(record.TTL))
+	m.Data = dataValue
+	m.Matches = types.StringValue("*." + strings.TrimSuffix(zone.Domain, "."))
+	return diags
+}
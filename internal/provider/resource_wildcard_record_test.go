@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccWildcardRecordResource tests creating a wildcard record in a catch-all zone
+func TestAccWildcardRecordResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWildcardRecordResourceConfig(container, "exact.example.com", false, "10.0.0.1"),
+				ExpectError: regexp.MustCompile(`Zone does not capture wildcard names`),
+			},
+			{
+				Config: testAccWildcardRecordResourceConfig(container, "wild.example.com", true, "10.0.0.1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_wildcard_record.test", "matches", "*.wild.example.com"),
+					resource.TestCheckResourceAttr("snitchdns_wildcard_record.test", "cls", "IN"),
+					resource.TestCheckResourceAttr("snitchdns_wildcard_record.test", "ttl", "300"),
+					resource.TestCheckResourceAttr("snitchdns_wildcard_record.test", "data.address", "10.0.0.1"),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_wildcard_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccWildcardRecordImportStateIdFunc,
+			},
+			{
+				Config: testAccWildcardRecordResourceConfig(container, "wild.example.com", true, "10.0.0.2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_wildcard_record.test", "data.address", "10.0.0.2"),
+				),
+			},
+		},
+	})
+}
+
+// testAccWildcardRecordImportStateIdFunc returns the import ID of the wildcard record
+func testAccWildcardRecordImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["snitchdns_wildcard_record.test"]
+	if !ok {
+		return "", fmt.Errorf("Resource not found")
+	}
+
+	return fmt.Sprintf("%s:%s", rs.Primary.Attributes["zone_id"], rs.Primary.ID), nil
+}
+
+// testAccWildcardRecordResourceConfig generates HCL configuration for wildcard record testing
+func testAccWildcardRecordResourceConfig(container *testcontainer.SnitchDNSContainer, domain string, catchAll bool, address string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain    = %[3]q
+  active    = true
+  catch_all = %[4]t
+  regex     = false
+}
+
+resource "snitchdns_wildcard_record" "test" {
+  zone_id = snitchdns_zone.test.id
+  type    = "A"
+  data    = { address = %[5]q }
+}
+`, container.GetAPIEndpoint(), container.APIKey, domain, catchAll, address)
+}
@@ -3,21 +3,28 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
 
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"snitchdns-tf/internal/client"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ZoneResource{}
 var _ resource.ResourceWithImportState = &ZoneResource{}
+var _ resource.ResourceWithModifyPlan = &ZoneResource{}
 
 // NewZoneResource creates a new Zone resource.
 func NewZoneResource() resource.Resource {
@@ -26,23 +33,50 @@ func NewZoneResource() resource.Resource {
 
 // ZoneResource defines the resource implementation.
 type ZoneResource struct {
-	client *client.Client
+	client               snitchdns.ClientInterface
+	users                *UserResolver
+	zones                *ZoneResolver
+	quota                *ZoneQuota
+	skipUnchangedRefresh bool
+	skipReadAfterWrite   bool
+	offline              bool
+	defaultUserID        int
+	allowInPlaceRename   bool
 }
 
 // ZoneResourceModel describes the resource data model.
 type ZoneResourceModel struct {
-	ID         types.String   `tfsdk:"id"`
-	UserID     types.Int64    `tfsdk:"user_id"`
-	Domain     types.String   `tfsdk:"domain"`
-	Active     types.Bool     `tfsdk:"active"`
-	CatchAll   types.Bool     `tfsdk:"catch_all"`
-	Forwarding types.Bool     `tfsdk:"forwarding"`
-	Regex      types.Bool     `tfsdk:"regex"`
-	Master     types.Bool     `tfsdk:"master"`
-	Tags       types.List     `tfsdk:"tags"`
-	CreatedAt  types.String   `tfsdk:"created_at"`
-	UpdatedAt  types.String   `tfsdk:"updated_at"`
-	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+	ID          types.String          `tfsdk:"id"`
+	UserID      types.Int64           `tfsdk:"user_id"`
+	Owner       types.String          `tfsdk:"owner"`
+	Domain      DomainValue           `tfsdk:"domain"`
+	Active      types.Bool            `tfsdk:"active"`
+	CatchAll    types.Bool            `tfsdk:"catch_all"`
+	Forwarding  types.Bool            `tfsdk:"forwarding"`
+	Regex       types.Bool            `tfsdk:"regex"`
+	Master      types.Bool            `tfsdk:"master"`
+	Tags        types.Set             `tfsdk:"tags"`
+	TestQueries *ZoneTestQueriesModel `tfsdk:"test_queries"`
+	CreatedAt   types.String          `tfsdk:"created_at"`
+	UpdatedAt   types.String          `tfsdk:"updated_at"`
+	Timeouts    timeouts.Value        `tfsdk:"timeouts"`
+
+	// DetectConflicts makes updates fail when the zone changed since it
+	// was read
+	DetectConflicts types.Bool `tfsdk:"detect_conflicts"`
+
+	// AdoptExisting takes over a zone that already holds the domain
+	// instead of failing the create
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
+
+	// AllowInPlaceRename updates the zone in place when its domain
+	// changes; null follows the provider's allow_in_place_rename
+	AllowInPlaceRename types.Bool `tfsdk:"allow_in_place_rename"`
+
+	// Activity, from the zone stats
+	RecordCount  types.Int64  `tfsdk:"record_count"`
+	TotalHits    types.Int64  `tfsdk:"total_hits"`
+	LastActivity types.String `tfsdk:"last_activity"`
 }
 
 // Metadata sets the resource type name.
@@ -53,6 +87,7 @@ func (r *ZoneResource) Metadata(_ context.Context, req resource.MetadataRequest,
 // Schema defines the resource schema.
 func (r *ZoneResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             zoneSchemaVersion,
 		MarkdownDescription: "Manages a DNS zone in SnitchDNS. Zones are containers for DNS records and can be configured with various options like catch-all, forwarding, and regex matching.",
 
 		Attributes: map[string]schema.Attribute{
@@ -64,12 +99,34 @@ func (r *ZoneResource) Schema(ctx context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"user_id": schema.Int64Attribute{
-				Computed:            true,
-				MarkdownDescription: "ID of the user who owns this zone. Automatically set by the API based on authentication.",
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "ID of the user who owns this zone. Set it to create the zone for another user, which requires an admin API key. " +
+					"Omit to create the zone for the user named by `owner`, the provider's `default_user_id`, or the user owning the API key. Changing a configured value forces a new resource.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.ConflictsWith(path.MatchRoot("owner")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Username of the user the zone is created for. Requires an admin API key, as the username is resolved through the users API. " +
+					"Omit to create the zone for the user owning the API key. Changing this forces a new resource.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The domain name for this zone (e.g., `example.com`). This is the base domain that will be used for DNS queries.",
+				MarkdownDescription: "The domain name for this zone (e.g., `example.com`). This is the base domain that will be used for DNS queries. Case and a trailing dot are ignored when comparing with the server's value. Changing this forces a new resource unless `allow_in_place_rename` is set.",
 				Required:            true,
+				CustomType:          DomainType{},
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(1, 255),
 				},
@@ -79,25 +136,55 @@ func (r *ZoneResource) Schema(ctx context.Context, _ resource.SchemaRequest, res
 				Required:            true,
 			},
 			"catch_all": schema.BoolAttribute{
-				MarkdownDescription: "Enable catch-all DNS queries for this zone. When enabled, the zone will respond to queries for any subdomain, even if no specific record exists.",
-				Required:            true,
+				MarkdownDescription: "Enable catch-all DNS queries for this zone. When enabled, the zone will respond to queries for any subdomain, even if no specific record exists. Omit when the capture behavior is managed by `snitchdns_zone_capture`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"forwarding": schema.BoolAttribute{
-				MarkdownDescription: "Enable DNS forwarding to upstream DNS servers. When enabled, unmatched queries will be forwarded to a configured upstream resolver.",
-				Required:            true,
+				MarkdownDescription: "Enable DNS forwarding to upstream DNS servers. When enabled, unmatched queries will be forwarded to a configured upstream resolver. Omit when the capture behavior is managed by `snitchdns_zone_capture`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"regex": schema.BoolAttribute{
 				MarkdownDescription: "Use regular expression matching for the domain name. When enabled, the domain field can contain a regex pattern instead of a literal domain.",
 				Required:            true,
 			},
+			"test_queries": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Names the `domain` pattern of a regex zone is tested against during plan, so a broken pattern fails the plan instead of queries once it is applied. " +
+					"Names are matched whole and case-insensitively, without a trailing dot. Only valid with `regex = true`.",
+				Attributes: map[string]schema.Attribute{
+					"match": schema.ListAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Names the pattern must match.",
+					},
+					"no_match": schema.ListAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Names the pattern must not match.",
+					},
+				},
+			},
 			"master": schema.BoolAttribute{
 				Computed:            true,
 				MarkdownDescription: "Indicates if this is a master zone. Master zones have special privileges and cannot be modified via the API. This is set automatically during creation.",
 			},
-			"tags": schema.ListAttribute{
+			"tags": schema.SetAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
-				MarkdownDescription: "List of tags to organize and categorize zones. Tags can be used for filtering and grouping zones.",
+				MarkdownDescription: "Set of tags to organize and categorize zones. Tags can be used for filtering and grouping zones. Tags must not contain commas.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^,]+$`), "must not contain commas"),
+					),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				Computed:            true,
@@ -107,6 +194,33 @@ func (r *ZoneResource) Schema(ctx context.Context, _ resource.SchemaRequest, res
 				Computed:            true,
 				MarkdownDescription: "Timestamp when the zone was last updated in RFC3339 format.",
 			},
+			"detect_conflicts": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Fail updates of the zone when it was changed since Terraform last read it, e.g. by another workspace managing the same zone, instead of overwriting the other change. " +
+					"The `updated_at` in the state is compared with the server's before the update is sent. Defaults to `false`.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Adopt the zone already holding `domain` when creating the resource, instead of failing with a duplicate domain error, which makes bootstrap configurations safe to re-run. " +
+					"The zone must match `regex` and the owner; its `active`, `catch_all`, `forwarding` and `tags` are updated to the configuration. Defaults to `false`.",
+			},
+			"allow_in_place_rename": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Update the zone in place when `domain` changes, instead of replacing it. A replaced zone gets a new ID, so queries logged against the old domain are not mixed with those of the new one; " +
+					"in place, the zone keeps its ID, records and logged queries. Defaults to the provider's `allow_in_place_rename`.",
+			},
+			"record_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of records in the zone. Null if the server does not provide zone statistics.",
+			},
+			"total_hits": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of queries SnitchDNS has logged for the zone. Null if the server does not provide zone statistics.",
+			},
+			"last_activity": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timestamp of the most recent query for the zone. Null if the zone has never been queried or the server does not provide zone statistics.",
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -125,16 +239,24 @@ func (r *ZoneResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.users = providerData.Users
+	r.zones = providerData.Zones
+	r.quota = providerData.Quota
+	r.skipUnchangedRefresh = providerData.SkipUnchangedRefresh
+	r.skipReadAfterWrite = providerData.SkipReadAfterWrite
+	r.offline = providerData.Offline
+	r.defaultUserID = providerData.DefaultUserID
+	r.allowInPlaceRename = providerData.AllowInPlaceRename
 }
 
 // CRUD methods are implemented in resource_zone_impl.go
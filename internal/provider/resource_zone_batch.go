@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneBatchResource{}
+var _ resource.ResourceWithModifyPlan = &ZoneBatchResource{}
+
+// NewZoneBatchResource creates a new Zone Batch resource.
+func NewZoneBatchResource() resource.Resource {
+	return &ZoneBatchResource{}
+}
+
+// ZoneBatchResource defines the resource implementation. It manages many
+// zones sharing the same settings, creating them in parallel, for
+// engagements that need hundreds of canary zones.
+type ZoneBatchResource struct {
+	client        snitchdns.ClientInterface
+	quota         *ZoneQuota
+	offline       bool
+	defaultUserID int
+}
+
+// ZoneBatchResourceModel describes the resource data model.
+type ZoneBatchResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Domains     types.Set    `tfsdk:"domains"`
+	Active      types.Bool   `tfsdk:"active"`
+	CatchAll    types.Bool   `tfsdk:"catch_all"`
+	Forwarding  types.Bool   `tfsdk:"forwarding"`
+	Regex       types.Bool   `tfsdk:"regex"`
+	Tags        types.Set    `tfsdk:"tags"`
+	UserID      types.Int64  `tfsdk:"user_id"`
+	Parallelism types.Int64  `tfsdk:"parallelism"`
+	ZoneIDs     types.Map    `tfsdk:"zone_ids"`
+}
+
+// Metadata sets the resource type name.
+func (r *ZoneBatchResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_batch"
+}
+
+// Schema defines the resource schema.
+func (r *ZoneBatchResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a batch of zones sharing the same settings. Zones are created in parallel, " +
+			"which is much faster than one `snitchdns_zone` per domain for hundreds of canary zones. " +
+			"Adding or removing a domain only creates or deletes that zone.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the batch, the ID of the first zone created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domains": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Domains of the zones. With `regex = true` these are regular expressions.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 255)),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the zones respond to DNS queries. Defaults to `true`.",
+				Default:             booldefault.StaticBool(true),
+			},
+			"catch_all": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Enable catch-all DNS queries for the zones. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"forwarding": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Enable DNS forwarding of unmatched queries for the zones. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"regex": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Use regular expression matching for the domains. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Tags of every zone. Tags must not contain commas.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^,]+$`), "must not contain commas"),
+					),
+				},
+			},
+			"user_id": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "ID of the user the zones are created for, which requires an admin API key. " +
+					"Defaults to the provider's `default_user_id`, or the user owning the API key. Changing this forces new zones.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Number of zone changes sent to the API at the same time. Defaults to `%d`.", snitchdns.DefaultZoneBatchParallelism),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 32),
+				},
+			},
+			"zone_ids": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "IDs of the zones, keyed by domain. Use them as `zone_id` of `snitchdns_record`.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *ZoneBatchResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.quota = providerData.Quota
+	r.offline = providerData.Offline
+	r.defaultUserID = providerData.DefaultUserID
+}
+
+// CRUD methods are implemented in resource_zone_batch_impl.go
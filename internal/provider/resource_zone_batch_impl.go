@@ -0,0 +1,461 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// zoneBatchSettings are the settings every zone of a batch shares
+type zoneBatchSettings struct {
+	Active     bool
+	CatchAll   bool
+	Forwarding bool
+	Regex      bool
+	Tags       snitchdns.ZoneTags
+}
+
+// matches reports whether the zone has the batch settings
+func (s zoneBatchSettings) matches(zone snitchdns.Zone) bool {
+	return zone.Active == s.Active &&
+		zone.CatchAll == s.CatchAll &&
+		zone.Forwarding == s.Forwarding &&
+		zone.Regex == s.Regex &&
+		zone.Tags.String() == s.Tags.String()
+}
+
+// Create implements the resource create logic. Zones created before an
+// error are kept in state, so a partial apply does not orphan them.
+func (r *ZoneBatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create zone batch")
+		return
+	}
+
+	var data ZoneBatchResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneIDs, diags := r.apply(ctx, &data, nil)
+	resp.Diagnostics.Append(diags...)
+	if len(zoneIDs) == 0 {
+		return
+	}
+
+	// The batch is identified by its first zone
+	domains := sortedKeys(zoneIDs)
+	data.ID = types.StringValue(zoneIDs[domains[0]])
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic. Zones deleted outside of
+// Terraform are dropped from domains, so the next apply creates them again,
+// and zones whose settings drifted show up as a change of the settings.
+func (r *ZoneBatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data ZoneBatchResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var zoneIDs map[string]string
+	resp.Diagnostics.Append(data.ZoneIDs.ElementsAs(ctx, &zoneIDs, false)...)
+	settings, diags := data.settings(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zones, err := r.client.ListZonesWithContext(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading zone batch", "Could not list zones", err)
+		return
+	}
+	byID := make(map[string]snitchdns.Zone, len(zones))
+	for _, zone := range zones {
+		byID[strconv.Itoa(zone.ID)] = zone
+	}
+
+	present := make(map[string]string, len(zoneIDs))
+	var drifted *snitchdns.Zone
+	for _, domain := range sortedKeys(zoneIDs) {
+		zone, ok := byID[zoneIDs[domain]]
+		if !ok {
+			tflog.Warn(ctx, "Zone of batch not found, removing it from state", map[string]any{
+				"domain":  domain,
+				"zone_id": zoneIDs[domain],
+			})
+			continue
+		}
+		present[domain] = zoneIDs[domain]
+		if drifted == nil && !settings.matches(zone) {
+			drifted = &zone
+		}
+	}
+
+	if len(present) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if len(present) != len(zoneIDs) {
+		resp.Diagnostics.Append(data.setZoneIDs(ctx, present)...)
+	}
+
+	// Settings are shared, so report the first zone that differs
+	if drifted != nil {
+		data.Active = types.BoolValue(drifted.Active)
+		data.CatchAll = types.BoolValue(drifted.CatchAll)
+		data.Forwarding = types.BoolValue(drifted.Forwarding)
+		data.Regex = types.BoolValue(drifted.Regex)
+
+		tags, diags := zoneTagsValue(ctx, data.Tags, drifted.Tags)
+		resp.Diagnostics.Append(diags...)
+		data.Tags = tags
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *ZoneBatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update zone batch")
+		return
+	}
+
+	var data, state ZoneBatchResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var prior map[string]string
+	resp.Diagnostics.Append(state.ZoneIDs.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := r.apply(ctx, &data, prior)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic
+func (r *ZoneBatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete zone batch")
+		return
+	}
+
+	var data ZoneBatchResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var zoneIDs map[string]string
+	resp.Diagnostics.Append(data.ZoneIDs.ElementsAs(ctx, &zoneIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results := r.deleteZones(ctx, sortedKeys(zoneIDs), zoneIDs, int(data.Parallelism.ValueInt64()))
+	resp.Diagnostics.Append(batchDiagnostics("Error deleting zones", results)...)
+}
+
+// ModifyPlan keeps the known zone IDs while the domains do not change, so
+// changing a shared setting does not show every ID as known after apply.
+// Zones of added domains are counted against the zone quota.
+func (r *ZoneBatchResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// The resource is being destroyed
+		return
+	}
+
+	var planned, prior types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("domains"), &planned)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("domains"), &prior)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.planQuota(ctx, req.Plan, planned, prior)...)
+
+	if req.State.Raw.IsNull() || !planned.Equal(prior) {
+		// The resource is being created, or its domains change
+		return
+	}
+
+	var zoneIDs types.Map
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("zone_ids"), &zoneIDs)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("zone_ids"), zoneIDs)...)
+}
+
+// planQuota counts the zones the planned domains add to the batch against
+// the zone quota of the API key's user. Zones of removed domains are
+// deleted before new ones are created, so only the growth of the batch is
+// counted. Batches created for another user and domains not yet known are
+// not counted.
+func (r *ZoneBatchResource) planQuota(ctx context.Context, plan tfsdk.Plan, planned, prior types.Set) diag.Diagnostics {
+	if r.offline || r.quota == nil || planned.IsUnknown() {
+		return nil
+	}
+
+	var userID types.Int64
+	diags := plan.GetAttribute(ctx, path.Root("user_id"), &userID)
+	if diags.HasError() || !userID.IsNull() || r.defaultUserID != 0 {
+		return diags
+	}
+
+	growth := -len(prior.Elements())
+	for _, element := range planned.Elements() {
+		if !element.IsUnknown() {
+			growth++
+		}
+	}
+
+	diags.Append(r.quota.Plan(ctx, growth)...)
+	return diags
+}
+
+// apply reconciles the zones to the planned batch: zones of removed domains
+// are deleted, zones whose settings differ are updated, and zones of new
+// domains are created. prior maps the domains in state to zone IDs. The
+// domains and zone IDs of data are set to the zones that exist afterwards,
+// which are also returned.
+func (r *ZoneBatchResource) apply(ctx context.Context, data *ZoneBatchResourceModel, prior map[string]string) (map[string]string, diag.Diagnostics) {
+	var domains []string
+	diags := data.Domains.ElementsAs(ctx, &domains, false)
+	settings, d := data.settings(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	sort.Strings(domains)
+	parallelism := int(data.Parallelism.ValueInt64())
+
+	wanted := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		wanted[domain] = true
+	}
+
+	zoneIDs := make(map[string]string, len(domains))
+	var results []batchResult
+
+	// Delete the zones of removed domains, keeping those that fail
+	var removed []string
+	for _, domain := range sortedKeys(prior) {
+		if !wanted[domain] {
+			removed = append(removed, domain)
+		}
+	}
+	for i, result := range r.deleteZones(ctx, removed, prior, parallelism) {
+		if result.Err != nil {
+			zoneIDs[removed[i]] = prior[removed[i]]
+		}
+		results = append(results, result)
+	}
+
+	// Update kept zones whose settings differ. Zones deleted outside of
+	// Terraform are created again.
+	var creates []snitchdns.CreateZoneRequest
+	var ops []batchOperation
+	existing, err := r.existingZones(ctx, prior)
+	if err != nil {
+		addAPIError(&diags, "Error applying zone batch", "Could not list zones", err)
+		for _, domain := range domains {
+			if id, ok := prior[domain]; ok {
+				zoneIDs[domain] = id
+			}
+		}
+		diags.Append(data.setZoneIDs(ctx, zoneIDs)...)
+		return zoneIDs, diags
+	}
+	for _, domain := range domains {
+		zone, ok := existing[prior[domain]]
+		if !ok {
+			creates = append(creates, settings.createRequest(domain, r.userID(data)))
+			continue
+		}
+		zoneIDs[domain] = prior[domain]
+		if !settings.matches(zone) {
+			ops = append(ops, r.updateOperation(domain, prior[domain], settings))
+		}
+	}
+	results = append(results, runBatch(ctx, ops, parallelism)...)
+
+	tflog.Debug(ctx, "Applying zone batch", map[string]any{
+		"creates": len(creates),
+		"updates": len(ops),
+		"deletes": len(removed),
+	})
+
+	for _, result := range snitchdns.CreateZones(ctx, r.client, creates, parallelism) {
+		if result.Err == nil {
+			zoneIDs[result.Request.Domain] = strconv.Itoa(result.Zone.ID)
+		}
+		results = append(results, batchResult{Description: "create zone " + result.Request.Domain, Err: result.Err})
+	}
+
+	recovery := partialUpdateRecovery
+	if prior == nil {
+		recovery = partialCreateRecovery
+	}
+	diags.Append(partialFailureDiagnostics("Error applying zone batch", recovery, results)...)
+	diags.Append(data.setZoneIDs(ctx, zoneIDs)...)
+	return zoneIDs, diags
+}
+
+// existingZones returns the zones in prior that still exist, by ID
+func (r *ZoneBatchResource) existingZones(ctx context.Context, prior map[string]string) (map[string]snitchdns.Zone, error) {
+	existing := make(map[string]snitchdns.Zone)
+	if len(prior) == 0 {
+		return existing, nil
+	}
+
+	zones, err := r.client.ListZonesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, zone := range zones {
+		existing[strconv.Itoa(zone.ID)] = zone
+	}
+	return existing, nil
+}
+
+// userID returns the ID of the user zones are created for, or 0 for the
+// API key's user
+func (r *ZoneBatchResource) userID(data *ZoneBatchResourceModel) int {
+	if !data.UserID.IsNull() {
+		return int(data.UserID.ValueInt64())
+	}
+	return r.defaultUserID
+}
+
+// deleteZones deletes the zones of domains, whose IDs zoneIDs maps, with
+// snitchdns.DeleteZones. A result is returned for each domain, in order.
+func (r *ZoneBatchResource) deleteZones(ctx context.Context, domains []string, zoneIDs map[string]string, parallelism int) []batchResult {
+	if len(domains) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(domains))
+	for i, domain := range domains {
+		ids[i] = zoneIDs[domain]
+	}
+
+	var failed *snitchdns.ZoneDeleteError
+	err := snitchdns.DeleteZones(ctx, r.client, ids, parallelism)
+	errors.As(err, &failed)
+
+	results := make([]batchResult, len(domains))
+	for i, domain := range domains {
+		results[i].Description = "delete zone " + domain
+		if failed != nil {
+			results[i].Err = failed.Failed[ids[i]]
+		}
+	}
+	return results
+}
+
+// updateOperation applies the batch settings to the zone of a domain
+func (r *ZoneBatchResource) updateOperation(domain, zoneID string, settings zoneBatchSettings) batchOperation {
+	return batchOperation{
+		Description: "update zone " + domain,
+		Run: func(ctx context.Context) error {
+			_, err := r.client.UpdateZoneWithContext(ctx, zoneID, snitchdns.UpdateZoneRequest{
+				Active:     &settings.Active,
+				CatchAll:   &settings.CatchAll,
+				Forwarding: &settings.Forwarding,
+				Regex:      &settings.Regex,
+				Tags:       &settings.Tags,
+			})
+			return err
+		},
+	}
+}
+
+// createRequest returns the request creating the zone of a domain
+func (s zoneBatchSettings) createRequest(domain string, userID int) snitchdns.CreateZoneRequest {
+	return snitchdns.CreateZoneRequest{
+		Domain:     domain,
+		Active:     s.Active,
+		CatchAll:   s.CatchAll,
+		Forwarding: s.Forwarding,
+		Regex:      s.Regex,
+		Tags:       s.Tags,
+		UserID:     userID,
+	}
+}
+
+// settings returns the shared zone settings of the batch
+func (m *ZoneBatchResourceModel) settings(ctx context.Context) (zoneBatchSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var tags []string
+	if !m.Tags.IsNull() {
+		diags.Append(m.Tags.ElementsAs(ctx, &tags, false)...)
+	}
+
+	return zoneBatchSettings{
+		Active:     m.Active.ValueBool(),
+		CatchAll:   m.CatchAll.ValueBool(),
+		Forwarding: m.Forwarding.ValueBool(),
+		Regex:      m.Regex.ValueBool(),
+		Tags:       snitchdns.NewZoneTags(tags),
+	}, diags
+}
+
+// setZoneIDs sets zone_ids, and domains to its keys
+func (m *ZoneBatchResourceModel) setZoneIDs(ctx context.Context, zoneIDs map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ids, d := types.MapValueFrom(ctx, types.StringType, zoneIDs)
+	diags.Append(d...)
+	domains, d := types.SetValueFrom(ctx, types.StringType, sortedKeys(zoneIDs))
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	m.ZoneIDs = ids
+	m.Domains = domains
+	return diags
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// zoneBatchPlan returns a plan of a batch of the given domains
+func zoneBatchPlan(t *testing.T, r fwresource.Resource, tag string, domains ...string) tfsdk.Plan {
+	t.Helper()
+
+	values := make([]attr.Value, len(domains))
+	for i, domain := range domains {
+		values[i] = types.StringValue(domain)
+	}
+	return mockPlan(t, r, map[string]attr.Value{
+		"domains":    types.SetValueMust(types.StringType, values),
+		"active":     types.BoolValue(true),
+		"catch_all":  types.BoolValue(false),
+		"forwarding": types.BoolValue(false),
+		"regex":      types.BoolValue(true),
+		"tags":       types.SetValueMust(types.StringType, []attr.Value{types.StringValue(tag)}),
+	})
+}
+
+// TestZoneBatchResource_Mock tests creating, reconciling and deleting a batch
+// of zones against the in-memory API
+func TestZoneBatchResource_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := newMockResource(t, NewZoneBatchResource(), mock)
+
+	plan := zoneBatchPlan(t, r, "a", "one", "two", "three")
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	var data ZoneBatchResourceModel
+	createResp.State.Get(ctx, &data)
+	if len(data.ZoneIDs.Elements()) != 3 || len(mock.Zones()) != 3 {
+		t.Fatalf("Expected 3 zones, got %s and %+v", data.ZoneIDs, mock.Zones())
+	}
+	for _, zone := range mock.Zones() {
+		if !zone.Regex || zone.Tags.String() != "a" {
+			t.Errorf("Expected zone %s to have the batch settings, got %+v", zone.Domain, zone)
+		}
+	}
+
+	// Removing a domain deletes its zone, adding one creates it, and a
+	// changed setting updates the kept zones
+	plan = zoneBatchPlan(t, r, "b", "one", "two", "four")
+	updateResp := fwresource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected update error: %v", updateResp.Diagnostics)
+	}
+
+	domains := map[string]string{}
+	for _, zone := range mock.Zones() {
+		domains[zone.Domain] = zone.Tags.String()
+	}
+	if len(domains) != 3 || domains["one"] != "b" || domains["four"] != "b" || domains["three"] != "" {
+		t.Errorf("Expected zones one, two and four tagged b, got %v", domains)
+	}
+	if calls := mock.Calls("CreateZone"); calls != 4 {
+		t.Errorf("Expected 4 zones created, got %d", calls)
+	}
+
+	// A zone deleted outside of Terraform is dropped from domains
+	updateResp.State.Get(ctx, &data)
+	var zoneIDs map[string]string
+	data.ZoneIDs.ElementsAs(ctx, &zoneIDs, false)
+	if err := mock.DeleteZone(zoneIDs["two"]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	readResp := fwresource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &data)
+	if len(data.Domains.Elements()) != 2 || len(data.ZoneIDs.Elements()) != 2 {
+		t.Errorf("Expected the deleted zone to be dropped, got %s", data.Domains)
+	}
+
+	deleteResp := fwresource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected delete error: %v", deleteResp.Diagnostics)
+	}
+	if zones := mock.Zones(); len(zones) != 0 {
+		t.Errorf("Expected all zones to be deleted, got %+v", zones)
+	}
+}
+
+// TestZoneBatchResource_ModifyPlanQuota tests that the zones a batch grows
+// by are counted against the zone limit
+func TestZoneBatchResource_ModifyPlanQuota(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	mock.ZoneLimit = 4
+	r := newMockResource(t, NewZoneBatchResource(), mock)
+
+	plan := zoneBatchPlan(t, r, "a", "one", "two", "three")
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	modifyPlan := func(plan tfsdk.Plan) diag.Diagnostics {
+		// A new provider instance, as for every Terraform run
+		r := newMockResource(t, NewZoneBatchResource(), mock)
+		resp := fwresource.ModifyPlanResponse{Plan: plan}
+		r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: plan, State: createResp.State}, &resp)
+		return resp.Diagnostics
+	}
+
+	// Replacing a domain does not grow the batch
+	if diags := modifyPlan(zoneBatchPlan(t, r, "a", "one", "two", "four")); len(diags) != 0 {
+		t.Errorf("Expected no diagnostics, got %v", diags)
+	}
+	if warnings := warningSummaries(modifyPlan(zoneBatchPlan(t, r, "a", "one", "two", "three", "four"))); len(warnings) != 1 || warnings[0] != "Zone Quota Nearly Reached" {
+		t.Errorf("Expected the threshold warning, got %v", warnings)
+	}
+	if warnings := warningSummaries(modifyPlan(zoneBatchPlan(t, r, "a", "one", "two", "three", "four", "five"))); len(warnings) != 1 || warnings[0] != "Zone Quota Would Be Exceeded" {
+		t.Errorf("Expected the limit warning, got %v", warnings)
+	}
+}
+
+// TestAccZoneBatchResource tests creating and resizing a batch of zones
+func TestAccZoneBatchResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneBatchResourceConfig(container, 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_zone_batch.test", "domains.#", "10"),
+					resource.TestCheckResourceAttr("snitchdns_zone_batch.test", "zone_ids.%", "10"),
+					resource.TestCheckResourceAttrSet("snitchdns_zone_batch.test", "id"),
+				),
+			},
+			{
+				Config: testAccZoneBatchResourceConfig(container, 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_zone_batch.test", "zone_ids.%", "5"),
+				),
+			},
+		},
+	})
+}
+
+// testAccZoneBatchResourceConfig generates HCL configuration for zone batch testing
+func testAccZoneBatchResourceConfig(container *testcontainer.SnitchDNSContainer, count int) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone_batch" "test" {
+  domains     = [for i in range(%[3]d) : "^c${i}\\.batch\\.example\\.com$"]
+  regex       = true
+  parallelism = 4
+}
+`, container.GetAPIEndpoint(), container.APIKey, count)
+}
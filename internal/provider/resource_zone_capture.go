@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Capture modes of a zone, describing what happens to queries for names
+// without an explicit record
+const (
+	// captureModeExact answers only names with records; other names are
+	// not answered
+	captureModeExact = "exact"
+	// captureModeCatchAll answers every name below the zone with the zone's
+	// records
+	captureModeCatchAll = "catch_all"
+	// captureModeForward forwards unmatched names to the upstream resolvers
+	captureModeForward = "forward"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneCaptureResource{}
+var _ resource.ResourceWithImportState = &ZoneCaptureResource{}
+
+// NewZoneCaptureResource creates a new Zone Capture resource.
+func NewZoneCaptureResource() resource.Resource {
+	return &ZoneCaptureResource{}
+}
+
+// ZoneCaptureResource defines the resource implementation.
+type ZoneCaptureResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// ZoneCaptureResourceModel describes the resource data model.
+type ZoneCaptureResourceModel struct {
+	ZoneID            types.String `tfsdk:"zone_id"`
+	Mode              types.String `tfsdk:"mode"`
+	AnswersUnmatched  types.Bool   `tfsdk:"answers_unmatched"`
+	ForwardsUnmatched types.Bool   `tfsdk:"forwards_unmatched"`
+}
+
+// Metadata sets the resource type name.
+func (r *ZoneCaptureResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_capture"
+}
+
+// Schema defines the resource schema.
+func (r *ZoneCaptureResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the capture behavior of a SnitchDNS zone: what is answered for names below the zone that have no explicit record. " +
+			"All queries reaching SnitchDNS are logged regardless of the mode. Do not set `catch_all` or `forwarding` on the zone when using this resource.",
+
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone whose capture behavior is managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(captureModeCatchAll),
+				MarkdownDescription: "How names without an explicit record are handled. `catch_all` (default) answers every name below the zone with the zone's records, " +
+					"`forward` forwards them to the upstream resolvers, `exact` answers only names with a record.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(captureModeExact, captureModeCatchAll, captureModeForward),
+				},
+			},
+			"answers_unmatched": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether SnitchDNS answers names without an explicit record from the zone's records.",
+			},
+			"forwards_unmatched": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether SnitchDNS forwards names without an explicit record to the upstream resolvers.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *ZoneCaptureResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// captureModeFlags returns the zone catch_all and forwarding flags of a mode
+func captureModeFlags(mode string) (catchAll, forwarding bool) {
+	switch mode {
+	case captureModeCatchAll:
+		return true, false
+	case captureModeForward:
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// captureModeOf returns the mode matching the zone catch_all and forwarding
+// flags. Catch-all takes precedence, as SnitchDNS answers a name before it
+// would forward it.
+func captureModeOf(catchAll, forwarding bool) string {
+	switch {
+	case catchAll:
+		return captureModeCatchAll
+	case forwarding:
+		return captureModeForward
+	default:
+		return captureModeExact
+	}
+}
+
+// CRUD methods are implemented in resource_zone_capture_impl.go
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *ZoneCaptureResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create zone capture mode")
+		return
+	}
+
+	var data ZoneCaptureResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.applyMode(ctx, data.ZoneID.ValueString(), data.Mode.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error setting zone capture mode",
+			fmt.Sprintf("Could not update zone ID %s", data.ZoneID.ValueString()), err)
+		return
+	}
+
+	data.setFromZone(zone)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *ZoneCaptureResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data ZoneCaptureResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.GetZoneWithContext(ctx, data.ZoneID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "Zone not found, removing capture settings from state", map[string]any{
+				"zone_id": data.ZoneID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading zone capture mode",
+			fmt.Sprintf("Could not read zone ID %s", data.ZoneID.ValueString()), err)
+		return
+	}
+
+	data.setFromZone(zone)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *ZoneCaptureResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update zone capture mode")
+		return
+	}
+
+	var data ZoneCaptureResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.applyMode(ctx, data.ZoneID.ValueString(), data.Mode.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error setting zone capture mode",
+			fmt.Sprintf("Could not update zone ID %s", data.ZoneID.ValueString()), err)
+		return
+	}
+
+	data.setFromZone(zone)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. The zone is returned to exact
+// matching, which is the SnitchDNS default for new zones.
+func (r *ZoneCaptureResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete zone capture mode")
+		return
+	}
+
+	var data ZoneCaptureResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.applyMode(ctx, data.ZoneID.ValueString(), captureModeExact)
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Zone is already gone, nothing to reset
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error resetting zone capture mode",
+			fmt.Sprintf("Could not update zone ID %s", data.ZoneID.ValueString()), err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic
+func (r *ZoneCaptureResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import zone capture mode")
+		return
+	}
+
+	// Use the zone ID from the import request
+	resource.ImportStatePassthroughID(ctx, path.Root("zone_id"), req, resp)
+}
+
+// applyMode updates the zone flags to match a capture mode
+func (r *ZoneCaptureResource) applyMode(ctx context.Context, zoneID, mode string) (*snitchdns.Zone, error) {
+	catchAll, forwarding := captureModeFlags(mode)
+
+	return r.client.UpdateZoneWithContext(ctx, zoneID, snitchdns.UpdateZoneRequest{
+		CatchAll:   &catchAll,
+		Forwarding: &forwarding,
+	})
+}
+
+// setFromZone maps the zone flags to the data model
+func (m *ZoneCaptureResourceModel) setFromZone(zone *snitchdns.Zone) {
+	m.Mode = types.StringValue(captureModeOf(zone.CatchAll, zone.Forwarding))
+	m.AnswersUnmatched = types.BoolValue(zone.CatchAll)
+	m.ForwardsUnmatched = types.BoolValue(!zone.CatchAll && zone.Forwarding)
+}
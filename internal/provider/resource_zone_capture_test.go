@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccZoneCaptureResource tests switching the capture mode of a zone
+func TestAccZoneCaptureResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneCaptureResourceConfig(container, "capture.example.com", "catch_all"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_zone_capture.test", "mode", "catch_all"),
+					resource.TestCheckResourceAttr("snitchdns_zone_capture.test", "answers_unmatched", "true"),
+					resource.TestCheckResourceAttr("snitchdns_zone_capture.test", "forwards_unmatched", "false"),
+				),
+			},
+			{
+				ResourceName:                         "snitchdns_zone_capture.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "zone_id",
+				ImportStateIdFunc:                    testAccZoneCaptureImportStateIdFunc,
+			},
+			{
+				Config: testAccZoneCaptureResourceConfig(container, "capture.example.com", "forward"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_zone_capture.test", "mode", "forward"),
+					resource.TestCheckResourceAttr("snitchdns_zone_capture.test", "answers_unmatched", "false"),
+					resource.TestCheckResourceAttr("snitchdns_zone_capture.test", "forwards_unmatched", "true"),
+				),
+			},
+		},
+	})
+}
+
+// TestCaptureModeRoundTrip tests that every mode maps to zone flags and back
+func TestCaptureModeRoundTrip(t *testing.T) {
+	for _, mode := range []string{captureModeExact, captureModeCatchAll, captureModeForward} {
+		catchAll, forwarding := captureModeFlags(mode)
+		if got := captureModeOf(catchAll, forwarding); got != mode {
+			t.Errorf("Expected mode %s to round-trip, got %s", mode, got)
+		}
+	}
+
+	// Catch-all wins when both flags were set outside Terraform
+	if got := captureModeOf(true, true); got != captureModeCatchAll {
+		t.Errorf("Expected catch_all for both flags set, got %s", got)
+	}
+}
+
+// testAccZoneCaptureImportStateIdFunc returns the zone ID of the capture resource
+func testAccZoneCaptureImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["snitchdns_zone_capture.test"]
+	if !ok {
+		return "", fmt.Errorf("Resource not found")
+	}
+
+	return rs.Primary.Attributes["zone_id"], nil
+}
+
+// testAccZoneCaptureResourceConfig generates HCL configuration for capture testing
+func testAccZoneCaptureResourceConfig(container *testcontainer.SnitchDNSContainer, domain string, mode string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = %[3]q
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_zone_capture" "test" {
+  zone_id = snitchdns_zone.test.id
+  mode    = %[4]q
+}
+`, container.GetAPIEndpoint(), container.APIKey, domain, mode)
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneFileResource{}
+var _ resource.ResourceWithImportState = &ZoneFileResource{}
+
+// NewZoneFileResource creates a new Zone File resource.
+func NewZoneFileResource() resource.Resource {
+	return &ZoneFileResource{}
+}
+
+// ZoneFileResource defines the resource implementation. Like
+// snitchdns_records_csv it owns every record of a zone, but takes the
+// records as BIND zone file text.
+type ZoneFileResource struct {
+	recordReconciler
+	offline bool
+}
+
+// ZoneFileResourceModel describes the resource data model.
+type ZoneFileResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ZoneID      types.String `tfsdk:"zone_id"`
+	Content     types.String `tfsdk:"content"`
+	Origin      types.String `tfsdk:"origin"`
+	Parallelism types.Int64  `tfsdk:"parallelism"`
+	RecordCount types.Int64  `tfsdk:"record_count"`
+	Skipped     types.List   `tfsdk:"skipped"`
+}
+
+// Metadata sets the resource type name.
+func (r *ZoneFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_file"
+}
+
+// Schema defines the resource schema.
+func (r *ZoneFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages all records of a zone from RFC 1035 zone file text, such as a BIND zone file. " +
+			"Records missing from the file are deleted, so do not combine this resource with `snitchdns_record` or `snitchdns_records_csv` on the same zone.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, equal to `zone_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone whose records are managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "Zone file text. The `$ORIGIN` and `$TTL` directives are supported; `$INCLUDE` and `$GENERATE` are not. " +
+					"Only records owned by the origin are managed, since a SnitchDNS zone holds the records of a single domain.",
+			},
+			"origin": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Origin for relative names until the file sets one with `$ORIGIN`. Defaults to the domain of the zone.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Number of record changes sent to the API at the same time. Defaults to `%d`.", defaultBatchParallelism),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 32),
+				},
+			},
+			"record_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of records in the zone after the last apply or refresh.",
+			},
+			"skipped": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				MarkdownDescription: "Records of the file that are not managed, as `name type`: records of other owner names, " +
+					"and records of types SnitchDNS data cannot represent.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *ZoneFileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.records = providerData.Records
+	r.recordData = providerData.RecordData
+	r.offline = providerData.Offline
+}
+
+// CRUD methods are implemented in resource_zone_file_impl.go
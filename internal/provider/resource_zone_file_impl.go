@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/internal/zonefile"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *ZoneFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create records from zone file")
+		return
+	}
+
+	var data ZoneFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ZoneID
+	persist, diags := r.apply(ctx, &data, partialCreateRecovery)
+	resp.Diagnostics.Append(diags...)
+	if !persist {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *ZoneFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data ZoneFileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+
+	if data.Origin.IsNull() || data.Origin.IsUnknown() {
+		zone, err := r.client.GetZoneWithContext(ctx, zoneID)
+		if err != nil {
+			if snitchdns.IsNotFound(err) {
+				tflog.Warn(ctx, "Zone not found, removing zone file from state", map[string]any{
+					"zone_id": zoneID,
+				})
+				resp.State.RemoveResource(ctx)
+				return
+			}
+
+			addAPIError(&resp.Diagnostics, "Error reading zone", fmt.Sprintf("Could not read zone ID %s", zoneID), err)
+			return
+		}
+		data.Origin = types.StringValue(zone.Domain)
+	}
+
+	existing, err := r.listRecords(ctx, zoneID)
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "Zone not found, removing zone file from state", map[string]any{
+				"zone_id": zoneID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading records",
+			fmt.Sprintf("Could not list records of zone ID %s", zoneID), err)
+		return
+	}
+
+	// Keep the configured content while the zone matches it, so formatting,
+	// comments and skipped records do not show up as changes. Otherwise
+	// store a rendering of the actual records, which Terraform reports as
+	// drift.
+	desired, skipped, err := zoneFileRecords(data.Content.ValueString(), data.Origin.ValueString())
+	if data.Content.IsNull() || err != nil || !planRecords(desired, existing).empty() {
+		data.Content = types.StringValue(renderZoneFile(data.Origin.ValueString(), existing))
+		skipped = nil
+	}
+
+	skippedList, diags := types.ListValueFrom(ctx, types.StringType, skipped)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ZoneID
+	data.RecordCount = types.Int64Value(int64(len(existing)))
+	data.Skipped = skippedList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *ZoneFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update records from zone file")
+		return
+	}
+
+	var data ZoneFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	persist, diags := r.apply(ctx, &data, partialUpdateRecovery)
+	resp.Diagnostics.Append(diags...)
+	if !persist {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. Every record of the zone is
+// deleted, since the resource owns all of them.
+func (r *ZoneFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete records from zone file")
+		return
+	}
+
+	var data ZoneFileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+
+	existing, err := r.listRecords(ctx, zoneID)
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Zone is already gone along with its records
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting records",
+			fmt.Sprintf("Could not list records of zone ID %s", zoneID), err)
+		return
+	}
+
+	ops := make([]batchOperation, 0, len(existing))
+	for _, record := range existing {
+		ops = append(ops, r.deleteOperation(zoneID, record))
+	}
+
+	results := runBatch(ctx, ops, int(data.Parallelism.ValueInt64()))
+	r.records.Invalidate(zoneID)
+	resp.Diagnostics.Append(batchDiagnostics("Error deleting records", results)...)
+}
+
+// ImportState implements the resource import logic. The import ID is the
+// zone ID; content is populated with a rendering of the zone's current
+// records.
+func (r *ZoneFileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import zone file")
+		return
+	}
+
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected a numeric zone ID, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// apply reconciles the zone's records to the planned zone file and sets the
+// computed attributes. The origin defaults to the domain of the zone. It
+// reports whether data is to be saved: when some operations failed after
+// others were applied, data holds a rendering of the zone's records, with
+// recovery advising on the remaining changes.
+func (r *ZoneFileResource) apply(ctx context.Context, data *ZoneFileResourceModel, recovery string) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	zoneID := data.ZoneID.ValueString()
+
+	if data.Origin.IsNull() || data.Origin.IsUnknown() {
+		zone, err := r.client.GetZoneWithContext(ctx, zoneID)
+		if err != nil {
+			addAPIError(&diags, "Error reading zone", fmt.Sprintf("Could not read zone ID %s", zoneID), err)
+			return false, diags
+		}
+		data.Origin = types.StringValue(zone.Domain)
+	}
+
+	desired, skipped, err := zoneFileRecords(data.Content.ValueString(), data.Origin.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("content"), "Invalid zone file", err.Error())
+		return false, diags
+	}
+	if len(skipped) > 0 {
+		diags.AddAttributeWarning(path.Root("content"), "Zone File Records Skipped",
+			fmt.Sprintf("%d records of the zone file are not managed, see the skipped attribute: %s",
+				len(skipped), strings.Join(skipped, ", ")))
+	}
+
+	existing, err := r.listRecords(ctx, zoneID)
+	if err != nil {
+		addAPIError(&diags, "Error reading records", fmt.Sprintf("Could not list records of zone ID %s", zoneID), err)
+		return false, diags
+	}
+
+	plan := planRecords(desired, existing)
+	tflog.Debug(ctx, "Reconciling zone records to zone file", map[string]any{
+		"zone_id": zoneID,
+		"creates": len(plan.Creates),
+		"updates": len(plan.Updates),
+		"deletes": len(plan.Deletes),
+		"skipped": len(skipped),
+	})
+
+	results := r.applyPlan(ctx, zoneID, plan, int(data.Parallelism.ValueInt64()))
+	r.records.Invalidate(zoneID)
+	diags.Append(partialFailureDiagnostics("Error applying zone file", recovery, results)...)
+	partial := diags.HasError()
+	if partial && !anyApplied(results) {
+		return false, diags
+	}
+
+	records, err := r.listRecords(ctx, zoneID)
+	if err != nil {
+		addAPIError(&diags, "Error reading records", fmt.Sprintf("Could not list records of zone ID %s", zoneID), err)
+		return false, diags
+	}
+
+	skippedList, d := types.ListValueFrom(ctx, types.StringType, skipped)
+	diags.Append(d...)
+
+	data.RecordCount = types.Int64Value(int64(len(records)))
+	data.Skipped = skippedList
+
+	// Save the records as they are now, which the next plan compares to
+	// the configuration
+	if partial {
+		data.Content = types.StringValue(renderZoneFile(data.Origin.ValueString(), records))
+	}
+
+	return !d.HasError(), diags
+}
+
+// zoneFileRecords parses zone file content into records for reconciliation.
+// Records of other owner names than origin and records of unsupported types
+// are returned as skipped, as "name type". Zone files have no status, so
+// every record is active.
+func zoneFileRecords(content, origin string) ([]zoneRecord, []string, error) {
+	result, err := zonefile.Parse(content, origin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	origin = strings.TrimSuffix(origin, ".") + "."
+
+	records := make([]zoneRecord, 0, len(result.Records))
+	skipped := []string{}
+	for _, record := range result.Records {
+		if !strings.EqualFold(record.Name, origin) {
+			skipped = append(skipped, record.Name+" "+record.Type)
+			continue
+		}
+		records = append(records, zoneRecord{
+			Type:   record.Type,
+			Class:  record.Class,
+			TTL:    int(record.TTL),
+			Active: true,
+			Data:   record.Data,
+		})
+	}
+	skipped = append(skipped, result.Skipped...)
+
+	return records, skipped, nil
+}
+
+// renderZoneFile renders the zone's records as a zone file for origin
+func renderZoneFile(origin string, records []snitchdns.Record) string {
+	origin = strings.TrimSuffix(origin, ".") + "."
+
+	entries := make([]zonefile.Record, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, zonefile.Record{
+			Name:  origin,
+			Type:  record.Type,
+			Class: record.Class,
+			TTL:   uint32(record.TTL),
+			Data:  record.Data.Strings(),
+		})
+	}
+	return zonefile.Format(origin, entries)
+}
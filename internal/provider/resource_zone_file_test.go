@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestZoneFileRecords tests selecting the records of the origin from a zone
+// file
+func TestZoneFileRecords(t *testing.T) {
+	content := `$TTL 300
+@     A     192.0.2.1
+      MX    10 mail
+www   A     192.0.2.2
+@     HINFO "PC" "Linux"
+`
+
+	records, skipped, err := zoneFileRecords(content, "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d: %+v", len(records), records)
+	}
+	if records[0].Type != "A" || records[0].Data["address"] != "192.0.2.1" || !records[0].Active || records[0].TTL != 300 {
+		t.Errorf("unexpected A record: %+v", records[0])
+	}
+	if records[1].Type != "MX" || records[1].Data["hostname"] != "mail.example.com." {
+		t.Errorf("unexpected MX record: %+v", records[1])
+	}
+
+	expectedSkipped := []string{"www.example.com. A", "example.com. HINFO"}
+	if !reflect.DeepEqual(skipped, expectedSkipped) {
+		t.Errorf("expected skipped %v, got %v", expectedSkipped, skipped)
+	}
+
+	if _, _, err := zoneFileRecords("$INCLUDE other.zone\n", "example.com"); err == nil {
+		t.Error("expected an error for $INCLUDE")
+	}
+}
+
+// TestRenderZoneFileRoundTrip tests that a rendering of the zone's records
+// plans no changes
+func TestRenderZoneFileRoundTrip(t *testing.T) {
+	existing := []snitchdns.Record{
+		{ID: 1, Type: "A", Class: "IN", TTL: 300, Active: true, Data: snitchdns.EncodeRecordData(map[string]interface{}{"address": "192.0.2.1"})},
+		{ID: 2, Type: "TXT", Class: "IN", TTL: 60, Active: true, Data: snitchdns.EncodeRecordData(map[string]interface{}{"data": "v=spf1 -all"})},
+		{ID: 3, Type: "MX", Class: "IN", TTL: 3600, Active: true, Data: snitchdns.EncodeRecordData(map[string]interface{}{"priority": float64(10), "hostname": "mail.example.com."})},
+	}
+
+	content := renderZoneFile("example.com", existing)
+
+	desired, skipped, err := zoneFileRecords(content, "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error parsing %q: %v", content, err)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("expected no skipped records, got %v", skipped)
+	}
+	if plan := planRecords(desired, existing); !plan.empty() {
+		t.Errorf("expected no changes for %q, got %+v", content, plan)
+	}
+}
+
+// TestAccZoneFileResource tests reconciling a zone's records to a zone file
+func TestAccZoneFileResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneFileResourceConfig(container, `$TTL 300
+@    IN A   10.0.0.1
+@    IN TXT "hello"
+www  IN A   10.0.0.2
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("snitchdns_zone_file.test", "id", "snitchdns_zone.test", "id"),
+					resource.TestCheckResourceAttr("snitchdns_zone_file.test", "origin", "zonefile.example.com"),
+					resource.TestCheckResourceAttr("snitchdns_zone_file.test", "record_count", "2"),
+					resource.TestCheckResourceAttr("snitchdns_zone_file.test", "skipped.#", "1"),
+					resource.TestCheckResourceAttr("snitchdns_zone_file.test", "skipped.0", "www.zonefile.example.com. A"),
+				),
+			},
+			{
+				Config: testAccZoneFileResourceConfig(container, `$TTL 120
+@    IN A    10.0.0.1
+@    IN AAAA 2001:db8::1
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_zone_file.test", "record_count", "2"),
+					resource.TestCheckResourceAttr("snitchdns_zone_file.test", "skipped.#", "0"),
+				),
+			},
+			{
+				ResourceName:            "snitchdns_zone_file.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"content"},
+			},
+		},
+	})
+}
+
+// testAccZoneFileResourceConfig generates HCL configuration for zone file testing
+func testAccZoneFileResourceConfig(container *testcontainer.SnitchDNSContainer, content string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = "zonefile.example.com"
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_zone_file" "test" {
+  zone_id = snitchdns_zone.test.id
+  content = <<-EOT
+%[3]sEOT
+}
+`, container.GetAPIEndpoint(), container.APIKey, content)
+}
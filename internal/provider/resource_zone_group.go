@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneGroupResource{}
+var _ resource.ResourceWithImportState = &ZoneGroupResource{}
+
+// NewZoneGroupResource creates a new Zone Group resource.
+func NewZoneGroupResource() resource.Resource {
+	return &ZoneGroupResource{}
+}
+
+// ZoneGroupResource defines the resource implementation. It manages which
+// zones carry a tag, leaving their other tags alone, so a group of zones
+// such as those of an engagement is defined in one place.
+type ZoneGroupResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// ZoneGroupResourceModel describes the resource data model.
+type ZoneGroupResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Tag       types.String `tfsdk:"tag"`
+	ZoneIDs   types.Set    `tfsdk:"zone_ids"`
+	Exclusive types.Bool   `tfsdk:"exclusive"`
+	Domains   types.Map    `tfsdk:"domains"`
+}
+
+// Metadata sets the resource type name.
+func (r *ZoneGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_group"
+}
+
+// Schema defines the resource schema.
+func (r *ZoneGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages which zones carry a tag, e.g. to group the zones of an engagement. " +
+			"The tag is added to every zone in `zone_ids` and removed from zones leaving the group; other tags of the zones are kept.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, equal to `tag`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tag": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Tag marking the zones of the group. Must not contain commas. Changing this forces a new resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^,\s]([^,]*[^,\s])?$`), "must not contain commas or surrounding whitespace"),
+				},
+			},
+			"zone_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "IDs of the zones in the group.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9]+$`), "must be a numeric zone ID"),
+					),
+				},
+			},
+			"exclusive": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the group owns the tag, removing it from zones not in `zone_ids` that were tagged outside this resource. Defaults to `false`.",
+			},
+			"domains": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Domains of the zones in the group, keyed by zone ID.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *ZoneGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// CRUD methods are implemented in resource_zone_group_impl.go
@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *ZoneGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create zone group")
+		return
+	}
+
+	var data ZoneGroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *ZoneGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data ZoneGroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zones, err := r.client.ListZonesWithContext(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading zone group", "Could not list zones", err)
+		return
+	}
+
+	tag := data.Tag.ValueString()
+	tracked := map[string]bool{}
+	var prior []string
+	resp.Diagnostics.Append(data.ZoneIDs.ElementsAs(ctx, &prior, false)...)
+	for _, id := range prior {
+		tracked[id] = true
+	}
+
+	// After import, or when the group owns the tag, every tagged zone is a
+	// member; otherwise zones tagged outside the group are ignored
+	all := data.ZoneIDs.IsNull() || data.Exclusive.ValueBool()
+
+	members := map[string]string{}
+	for _, zone := range zones {
+		id := strconv.Itoa(zone.ID)
+		if slices.Contains(zone.Tags, tag) && (all || tracked[id]) {
+			members[id] = zone.Domain
+		}
+	}
+
+	resp.Diagnostics.Append(data.setMembers(ctx, members)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *ZoneGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update zone group")
+		return
+	}
+
+	var data, state ZoneGroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var prior []string
+	resp.Diagnostics.Append(state.ZoneIDs.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic. The tag is removed from the
+// zones; the zones themselves are kept.
+func (r *ZoneGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete zone group")
+		return
+	}
+
+	var data ZoneGroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var prior []string
+	resp.Diagnostics.Append(data.ZoneIDs.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ZoneIDs = types.SetValueMust(types.StringType, []attr.Value{})
+	resp.Diagnostics.Append(r.apply(ctx, &data, prior)...)
+}
+
+// ImportState implements the resource import logic
+func (r *ZoneGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import zone group")
+		return
+	}
+
+	// Import ID format: the tag. Every zone carrying it becomes a member.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("exclusive"), false)...)
+}
+
+// apply tags the planned zones and untags the zones of prior that left the
+// group, as well as, for an exclusive group, any other tagged zone. The
+// model's members are refreshed from the result.
+func (r *ZoneGroupResource) apply(ctx context.Context, data *ZoneGroupResourceModel, prior []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var planned []string
+	diags.Append(data.ZoneIDs.ElementsAs(ctx, &planned, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	sort.Strings(planned)
+
+	zones, err := r.client.ListZonesWithContext(ctx)
+	if err != nil {
+		addAPIError(&diags, "Error reading zone group", "Could not list zones", err)
+		return diags
+	}
+
+	byID := make(map[string]snitchdns.Zone, len(zones))
+	for _, zone := range zones {
+		byID[strconv.Itoa(zone.ID)] = zone
+	}
+
+	tag := data.Tag.ValueString()
+	members := make(map[string]string, len(planned))
+	for _, id := range planned {
+		zone, ok := byID[id]
+		if !ok {
+			diags.AddAttributeError(path.Root("zone_ids"), "Zone not found", fmt.Sprintf("Zone %s does not exist.", id))
+			continue
+		}
+		members[id] = zone.Domain
+		if slices.Contains(zone.Tags, tag) {
+			continue
+		}
+
+		diags.Append(r.retag(ctx, zone, func(tags []string) []string { return append(tags, tag) })...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	leaving := map[string]bool{}
+	for _, id := range prior {
+		leaving[id] = true
+	}
+	if data.Exclusive.ValueBool() {
+		for id := range byID {
+			leaving[id] = true
+		}
+	}
+	for id := range members {
+		delete(leaving, id)
+	}
+
+	ids := make([]string, 0, len(leaving))
+	for id := range leaving {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		zone, ok := byID[id]
+		if !ok || !slices.Contains(zone.Tags, tag) {
+			// Deleted zones and zones without the tag need no change
+			continue
+		}
+
+		diags.Append(r.retag(ctx, zone, func(tags []string) []string {
+			return slices.DeleteFunc(tags, func(t string) bool { return t == tag })
+		})...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(data.setMembers(ctx, members)...)
+	return diags
+}
+
+// retag replaces the zone's tags with the result of change. The update only
+// applies while the zone is unchanged since it was listed, so concurrent
+// changes to its tags are not lost; on a conflict the zone is read again
+// and the change retried once.
+func (r *ZoneGroupResource) retag(ctx context.Context, zone snitchdns.Zone, change func(tags []string) []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	id := strconv.Itoa(zone.ID)
+
+	for attempt := 0; ; attempt++ {
+		tags := snitchdns.NewZoneTags(change(slices.Clone(zone.Tags)))
+		tflog.Debug(ctx, "Updating zone group tags", map[string]any{
+			"zone_id": id,
+			"tags":    tags.String(),
+		})
+
+		_, err := r.client.UpdateZoneWithContext(ctx, id, snitchdns.UpdateZoneRequest{
+			Tags:              &tags,
+			ExpectedUpdatedAt: zone.UpdatedAt,
+		})
+		if err == nil || snitchdns.IsNotFound(err) {
+			return diags
+		}
+		if !snitchdns.IsConflict(err) || attempt > 0 {
+			addAPIError(&diags, "Error updating zone group", fmt.Sprintf("Could not update the tags of zone %s", zone.Domain), err)
+			return diags
+		}
+
+		current, err := r.client.GetZoneWithContext(ctx, id)
+		if err != nil {
+			addAPIError(&diags, "Error updating zone group", fmt.Sprintf("Could not read zone %s", zone.Domain), err)
+			return diags
+		}
+		zone = *current
+	}
+}
+
+// setMembers sets the ID, zone IDs and domains of the group's members
+func (m *ZoneGroupResourceModel) setMembers(ctx context.Context, members map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ids := make([]string, 0, len(members))
+	for id := range members {
+		ids = append(ids, id)
+	}
+
+	zoneIDs, d := types.SetValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	domains, d := types.MapValueFrom(ctx, types.StringType, members)
+	diags.Append(d...)
+
+	m.ID = m.Tag
+	m.ZoneIDs = zoneIDs
+	m.Domains = domains
+	return diags
+}
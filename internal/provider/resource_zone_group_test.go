@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// zoneGroupPlan returns a plan of a zone group with the given members
+func zoneGroupPlan(t *testing.T, r fwresource.Resource, exclusive bool, zones ...snitchdns.Zone) tfsdk.Plan {
+	ids := make([]attr.Value, 0, len(zones))
+	for _, zone := range zones {
+		ids = append(ids, types.StringValue(fmt.Sprint(zone.ID)))
+	}
+
+	return mockPlan(t, r, map[string]attr.Value{
+		"tag":       types.StringValue("engagement-42"),
+		"zone_ids":  types.SetValueMust(types.StringType, ids),
+		"exclusive": types.BoolValue(exclusive),
+		"domains":   types.MapUnknown(types.StringType),
+		"id":        types.StringUnknown(),
+	})
+}
+
+// TestZoneGroupResource_Mock tests that the tag follows the group's members
+// and the zones' other tags are kept
+func TestZoneGroupResource_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	one := mock.AddZone(snitchdns.Zone{Domain: "one.example.com", Tags: snitchdns.ZoneTags{"canary"}})
+	two := mock.AddZone(snitchdns.Zone{Domain: "two.example.com"})
+	other := mock.AddZone(snitchdns.Zone{Domain: "other.example.com", Tags: snitchdns.ZoneTags{"engagement-42"}})
+	r := newMockResource(t, NewZoneGroupResource(), mock)
+
+	tags := func() map[string]string {
+		result := map[string]string{}
+		for _, zone := range mock.Zones() {
+			result[zone.Domain] = zone.Tags.String()
+		}
+		return result
+	}
+
+	plan := zoneGroupPlan(t, r, false, one, two)
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+	if got := tags(); got["one.example.com"] != "canary,engagement-42" || got["two.example.com"] != "engagement-42" {
+		t.Fatalf("Expected both zones to be tagged, got %v", got)
+	}
+
+	// Zones tagged outside a non-exclusive group are not members
+	var data ZoneGroupResourceModel
+	readResp := fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, &readResp)
+	readResp.State.Get(ctx, &data)
+	if len(data.ZoneIDs.Elements()) != 2 || len(data.Domains.Elements()) != 2 {
+		t.Errorf("Expected 2 members, got %s", data.ZoneIDs)
+	}
+
+	// A zone leaving an exclusive group loses the tag, as does any other
+	// tagged zone
+	plan = zoneGroupPlan(t, r, true, one)
+	updateResp := fwresource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected update error: %v", updateResp.Diagnostics)
+	}
+	if got := tags(); got["two.example.com"] != "" || got["other.example.com"] != "" || got["one.example.com"] != "canary,engagement-42" {
+		t.Errorf("Expected only one.example.com to keep the tag, got %v", got)
+	}
+
+	deleteResp := fwresource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected delete error: %v", deleteResp.Diagnostics)
+	}
+	if got := tags(); got["one.example.com"] != "canary" || len(mock.Zones()) != 3 {
+		t.Errorf("Expected the tag to be removed and the zones kept, got %v", got)
+	}
+
+	// Unknown zones are rejected
+	plan = zoneGroupPlan(t, r, false, snitchdns.Zone{ID: 999}, other)
+	createResp = fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Error("Expected an error for a missing zone")
+	}
+}
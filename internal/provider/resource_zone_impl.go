@@ -7,15 +7,22 @@ import (
 	"strings"
 	"time"
 
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"snitchdns-tf/internal/client"
 )
 
 // Create implements the resource create logic
 func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create zone")
+		return
+	}
+
 	var data ZoneResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -36,7 +43,6 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 		"domain": data.Domain.ValueString(),
 	})
 
-	// Convert tags list to comma-separated string
 	var tags []string
 	if !data.Tags.IsNull() {
 		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
@@ -44,40 +50,90 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 			return
 		}
 	}
-	tagsStr := strings.Join(tags, ",")
+	zoneTags := snitchdns.NewZoneTags(tags)
 
 	// Create zone via API
-	createReq := client.CreateZoneRequest{
+	createReq := snitchdns.CreateZoneRequest{
 		Domain:     data.Domain.ValueString(),
 		Active:     data.Active.ValueBool(),
 		CatchAll:   data.CatchAll.ValueBool(),
 		Forwarding: data.Forwarding.ValueBool(),
 		Regex:      data.Regex.ValueBool(),
 		Master:     false, // Always false for user-created zones
-		Tags:       tagsStr,
+		Tags:       zoneTags,
 	}
 
-	zone, err := r.client.CreateZone(createReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating zone",
-			fmt.Sprintf("Could not create zone: %s", err),
-		)
+	// Create the zone for another user when an owner is configured, by
+	// username, by ID, or as the provider default
+	var owner *snitchdns.User
+	ownerAttr := path.Root("owner")
+	switch {
+	case !data.Owner.IsNull():
+		var err error
+		owner, err = r.users.ByUsername(ctx, data.Owner.ValueString())
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error resolving zone owner",
+				fmt.Sprintf("Could not resolve username %q", data.Owner.ValueString()), err)
+			return
+		}
+	case !data.UserID.IsUnknown() && !data.UserID.IsNull():
+		owner = &snitchdns.User{ID: int(data.UserID.ValueInt64())}
+		ownerAttr = path.Root("user_id")
+	case r.defaultUserID != 0:
+		owner = &snitchdns.User{ID: r.defaultUserID}
+		ownerAttr = path.Root("user_id")
+	}
+	if owner != nil {
+		createReq.UserID = owner.ID
+	}
+
+	zone, err := r.client.CreateZoneWithContext(ctx, createReq)
+	switch {
+	case err != nil && data.AdoptExisting.ValueBool() && isZoneExistsError(err):
+		zone = r.adoptZone(ctx, data, createReq, &resp.Diagnostics)
+		if zone == nil {
+			return
+		}
+	case err != nil:
+		addAPIError(&resp.Diagnostics, "Error creating zone", "Could not create zone", err, adoptableZoneErrorAttributes...)
+		return
+	}
+
+	// Map the stored zone to data model
+	zone = r.readBack(ctx, zone, &resp.Diagnostics)
+	resp.Diagnostics.Append(data.setZone(ctx, zone)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Map response to data model
-	data.ID = types.StringValue(strconv.Itoa(zone.ID))
-	data.UserID = types.Int64Value(int64(zone.UserID))
-	data.Master = types.BoolValue(zone.Master)
-	data.CreatedAt = types.StringValue(zone.CreatedAt)
-	data.UpdatedAt = types.StringValue(zone.UpdatedAt)
+	resp.Diagnostics.Append(r.readActivity(ctx, &data)...)
+
+	// Servers that ignore user_id create the zone for the API key's user.
+	// The zone is kept in state so the next apply replaces it.
+	if owner != nil && zone.UserID != owner.ID {
+		name := fmt.Sprintf("user ID %d", owner.ID)
+		if owner.Username != "" {
+			name = fmt.Sprintf("%s (user ID %d)", owner.Username, owner.ID)
+		}
+		resp.Diagnostics.AddAttributeError(
+			ownerAttr,
+			"Zone created for a different owner",
+			fmt.Sprintf("Zone %s was created for user ID %d instead of %s. "+
+				"The API key must belong to an admin, and the SnitchDNS server must support creating zones for other users.",
+				zone.Domain, zone.UserID, name),
+		)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 // Read implements the resource read logic
 func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
 	var data ZoneResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -95,11 +151,27 @@ func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	ctx, cancel = context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
-	// Get zone from API
-	zone, err := r.client.GetZone(data.ID.ValueString())
+	// Get zone from API, keeping the state when the zone is unchanged
+	var zone *snitchdns.Zone
+	var err error
+	if r.skipUnchangedRefresh {
+		var modified bool
+		zone, modified, err = r.client.GetZoneIfModified(ctx, data.ID.ValueString(), data.UpdatedAt.ValueString())
+		if err == nil && !modified {
+			// The prior activity and owner are kept as well, sparing their
+			// lookups; a full refresh picks up changes to them that do not
+			// touch updated_at
+			tflog.Debug(ctx, "Zone unchanged since last refresh, keeping state", map[string]any{
+				"id": data.ID.ValueString(),
+			})
+			return
+		}
+	} else {
+		zone, err = r.client.GetZoneWithContext(ctx, data.ID.ValueString())
+	}
 	if err != nil {
 		// Check if this is a 404 - resource was deleted outside Terraform
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+		if snitchdns.IsNotFound(err) {
 			tflog.Warn(ctx, "Zone not found, removing from state", map[string]any{
 				"id": data.ID.ValueString(),
 			})
@@ -107,41 +179,30 @@ func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Error reading zone",
-			fmt.Sprintf("Could not read zone ID %s: %s", data.ID.ValueString(), err),
-		)
+		addAPIError(&resp.Diagnostics, "Error reading zone",
+			fmt.Sprintf("Could not read zone ID %s", data.ID.ValueString()), err)
 		return
 	}
 
 	// Update data model from API response
-	data.UserID = types.Int64Value(int64(zone.UserID))
-	data.Domain = types.StringValue(zone.Domain)
-	data.Active = types.BoolValue(zone.Active)
-	data.CatchAll = types.BoolValue(zone.CatchAll)
-	data.Forwarding = types.BoolValue(zone.Forwarding)
-	data.Regex = types.BoolValue(zone.Regex)
-	data.Master = types.BoolValue(zone.Master)
-	data.CreatedAt = types.StringValue(zone.CreatedAt)
-	data.UpdatedAt = types.StringValue(zone.UpdatedAt)
-
-	// Convert tags array to list
-	if len(zone.Tags) > 0 {
-		tagsValue, diags := types.ListValueFrom(ctx, types.StringType, zone.Tags)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		data.Tags = tagsValue
-	} else {
-		data.Tags = types.ListNull(types.StringType)
+	resp.Diagnostics.Append(data.setZone(ctx, zone)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	resp.Diagnostics.Append(r.readActivity(ctx, &data)...)
+	resp.Diagnostics.Append(r.readOwner(ctx, &data)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 // Update implements the resource update logic
 func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update zone")
+		return
+	}
+
 	var data ZoneResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -159,7 +220,6 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	ctx, cancel = context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
-	// Convert tags list to comma-separated string
 	var tags []string
 	if !data.Tags.IsNull() {
 		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
@@ -167,7 +227,7 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			return
 		}
 	}
-	tagsStr := strings.Join(tags, ",")
+	zoneTags := snitchdns.NewZoneTags(tags)
 
 	// Update zone via API
 	domain := data.Domain.ValueString()
@@ -176,53 +236,51 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	forwarding := data.Forwarding.ValueBool()
 	regex := data.Regex.ValueBool()
 
-	updateReq := client.UpdateZoneRequest{
+	updateReq := snitchdns.UpdateZoneRequest{
 		Domain:     &domain,
 		Active:     &active,
 		CatchAll:   &catchAll,
 		Forwarding: &forwarding,
 		Regex:      &regex,
-		Tags:       &tagsStr,
+		Tags:       &zoneTags,
+	}
+
+	// The plan's updated_at is unknown; the state holds the last read one
+	if data.DetectConflicts.ValueBool() {
+		var updatedAt types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("updated_at"), &updatedAt)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		updateReq.ExpectedUpdatedAt = updatedAt.ValueString()
 	}
 
-	zone, err := r.client.UpdateZone(data.ID.ValueString(), updateReq)
+	zone, err := r.client.UpdateZoneWithContext(ctx, data.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating zone",
-			fmt.Sprintf("Could not update zone ID %s: %s", data.ID.ValueString(), err),
-		)
+		addAPIError(&resp.Diagnostics, "Error updating zone",
+			fmt.Sprintf("Could not update zone ID %s", data.ID.ValueString()), err, zoneErrorAttributes...)
 		return
 	}
 
-	// Update data model from API response
-	data.ID = types.StringValue(fmt.Sprintf("%d", zone.ID))
-	data.UserID = types.Int64Value(int64(zone.UserID))
-	data.Domain = types.StringValue(zone.Domain)
-	data.Active = types.BoolValue(zone.Active)
-	data.CatchAll = types.BoolValue(zone.CatchAll)
-	data.Forwarding = types.BoolValue(zone.Forwarding)
-	data.Regex = types.BoolValue(zone.Regex)
-	data.Master = types.BoolValue(zone.Master)
-	data.CreatedAt = types.StringValue(zone.CreatedAt)
-	data.UpdatedAt = types.StringValue(zone.UpdatedAt)
-
-	// Convert tags array to list
-	if len(zone.Tags) > 0 {
-		tagsValue, diags := types.ListValueFrom(ctx, types.StringType, zone.Tags)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		data.Tags = tagsValue
-	} else {
-		data.Tags = types.ListNull(types.StringType)
+	// Update data model from the stored zone
+	zone = r.readBack(ctx, zone, &resp.Diagnostics)
+	resp.Diagnostics.Append(data.setZone(ctx, zone)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	resp.Diagnostics.Append(r.readActivity(ctx, &data)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 // Delete implements the resource delete logic
 func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete zone")
+		return
+	}
+
 	var data ZoneResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -242,17 +300,260 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 	// Delete zone via API
 	err := r.client.DeleteZoneWithContext(ctx, data.ID.ValueString())
+	if snitchdns.IsNotFound(err) {
+		// Already deleted outside Terraform
+		tflog.Warn(ctx, "Zone not found, nothing to delete", map[string]any{
+			"id": data.ID.ValueString(),
+		})
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting zone",
-			fmt.Sprintf("Could not delete zone ID %s: %s", data.ID.ValueString(), err),
-		)
+		addAPIError(&resp.Diagnostics, "Error deleting zone",
+			fmt.Sprintf("Could not delete zone ID %s", data.ID.ValueString()), err)
+		return
+	}
+}
+
+// ModifyPlan replaces the zone when its domain changes, unless the zone or
+// the provider allows renaming it in place. A renamed zone keeps its ID, so
+// queries logged against the old domain would be attributed to the new one.
+// Zones being created are counted against the zone quota.
+func (r *ZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// The resource is being destroyed
+		return
+	}
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(r.planQuota(ctx, req.Plan)...)
+		return
+	}
+
+	var planned, prior DomainValue
+	var allow types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("domain"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("domain"), &prior)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_in_place_rename"), &allow)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !planned.IsUnknown() && snitchdns.NormalizeZoneDomain(planned.ValueString()) == snitchdns.NormalizeZoneDomain(prior.ValueString()) {
+		return
+	}
+	allowed := r.allowInPlaceRename
+	if !allow.IsNull() && !allow.IsUnknown() {
+		allowed = allow.ValueBool()
+	}
+	if allowed {
 		return
 	}
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("domain"))
+}
+
+// planQuota counts a zone being created against the zone quota of the API
+// key's user. Zones created for another user and zones that may be adopted
+// are not counted.
+func (r *ZoneResource) planQuota(ctx context.Context, plan tfsdk.Plan) diag.Diagnostics {
+	if r.offline || r.quota == nil {
+		return nil
+	}
+
+	var owner types.String
+	var userID types.Int64
+	var adopt types.Bool
+	var diags diag.Diagnostics
+	diags.Append(plan.GetAttribute(ctx, path.Root("owner"), &owner)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("user_id"), &userID)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("adopt_existing"), &adopt)...)
+	if diags.HasError() {
+		return diags
+	}
+	otherUser := !owner.IsNull() || userID.ValueInt64() != 0 || (userID.IsUnknown() && r.defaultUserID != 0)
+	if otherUser || adopt.ValueBool() {
+		return diags
+	}
+
+	diags.Append(r.quota.Plan(ctx, 1)...)
+	return diags
 }
 
 // ImportState implements the resource import logic
 func (r *ZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the ID from the import request
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import zone")
+		return
+	}
+
+	// Import ID format: "zone_id", "domain", or "username:domain" to adopt a
+	// zone of another user
+	username, domain, ok := strings.Cut(req.ID, ":")
+	if !ok {
+		if _, err := strconv.Atoi(req.ID); err == nil {
+			resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+			return
+		}
+		r.importByDomain(ctx, req.ID, resp)
+		return
+	}
+
+	if username == "" || domain == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID format",
+			fmt.Sprintf("Expected import ID format 'zone_id', 'domain' or 'username:domain', got: %s", req.ID),
+		)
+		return
+	}
+
+	owner, err := r.users.ByUsername(ctx, username)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error resolving zone owner",
+			fmt.Sprintf("Could not resolve username %q", username), err)
+		return
+	}
+
+	zones, err := r.zones.ListZones(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error importing zone", "Could not list zones", err)
+		return
+	}
+
+	domain = snitchdns.NormalizeZoneDomain(domain)
+	for _, zone := range zones {
+		if zone.UserID == owner.ID && snitchdns.NormalizeZoneDomain(zone.Domain) == domain {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(zone.ID))...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), owner.Username)...)
+			return
+		}
+	}
+
+	resp.Diagnostics.AddError(
+		"Zone not found",
+		fmt.Sprintf("User %s has no zone %s. The API key must belong to an admin to see zones of other users.", owner.Username, domain),
+	)
+}
+
+// importByDomain imports the zone of the API key's user with the given domain
+func (r *ZoneResource) importByDomain(ctx context.Context, domain string, resp *resource.ImportStateResponse) {
+	zones, err := r.zones.ListZones(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error importing zone", "Could not list zones", err)
+		return
+	}
+
+	zone, ok := snitchdns.MatchZoneDomain(zones, domain)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Zone not found",
+			fmt.Sprintf("No zone found for domain %s. Import zones of other users with 'username:domain'.", domain),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(zone.ID))...)
+}
+
+// readOwner sets owner from the zone's user ID when the zone is managed by
+// username. The configured spelling is kept when it differs only in case.
+func (r *ZoneResource) readOwner(ctx context.Context, data *ZoneResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.Owner.IsNull() {
+		return diags
+	}
+
+	owner, err := r.users.ByID(ctx, int(data.UserID.ValueInt64()))
+	if err != nil {
+		diags.AddWarning("Zone owner unavailable",
+			fmt.Sprintf("Could not resolve the owner of zone ID %s, keeping owner %q: %s",
+				data.ID.ValueString(), data.Owner.ValueString(), err))
+		return diags
+	}
+
+	if !strings.EqualFold(owner.Username, data.Owner.ValueString()) {
+		data.Owner = types.StringValue(owner.Username)
+	}
+	return diags
+}
+
+// readActivity sets the activity attributes from the zone stats. Activity is
+// informational, so when the stats cannot be read the attributes are left
+// null: silently for servers without the stats endpoint, with a warning for
+// other failures.
+func (r *ZoneResource) readActivity(ctx context.Context, data *ZoneResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.RecordCount = types.Int64Null()
+	data.TotalHits = types.Int64Null()
+	data.LastActivity = types.StringNull()
+
+	stats, err := r.client.GetZoneStats(ctx, data.ID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Debug(ctx, "Zone stats not available, leaving activity unset", map[string]any{
+				"id": data.ID.ValueString(),
+			})
+			return diags
+		}
+
+		diags.AddWarning("Zone activity unavailable",
+			fmt.Sprintf("Could not read stats of zone ID %s, record_count, total_hits and last_activity are left unset: %s",
+				data.ID.ValueString(), err))
+		return diags
+	}
+
+	data.RecordCount = types.Int64Value(int64(stats.RecordCount))
+	data.TotalHits = types.Int64Value(int64(stats.TotalHits))
+	if stats.LastActivity != "" {
+		data.LastActivity = types.StringValue(stats.LastActivity)
+	}
+	return diags
+}
+
+// readBack returns the zone as the server stores it after a write, whose
+// response may not reflect the server's normalization and timestamps yet.
+// Without a request when skip_read_after_write is set, and with a warning
+// when the read fails, the written zone is returned instead.
+func (r *ZoneResource) readBack(ctx context.Context, written *snitchdns.Zone, diags *diag.Diagnostics) *snitchdns.Zone {
+	if r.skipReadAfterWrite {
+		return written
+	}
+
+	zone, err := r.client.GetZoneWithContext(ctx, strconv.Itoa(written.ID))
+	if err != nil {
+		diags.AddWarning("Could not read back zone",
+			fmt.Sprintf("Zone %s was written, but reading it back failed, so the state holds the write's response: %s",
+				written.Domain, err))
+		return written
+	}
+	return zone
+}
+
+// setZone copies a zone read from the server into the model
+func (m *ZoneResourceModel) setZone(ctx context.Context, zone *snitchdns.Zone) diag.Diagnostics {
+	m.ID = types.StringValue(strconv.Itoa(zone.ID))
+	m.UserID = types.Int64Value(int64(zone.UserID))
+	m.Domain = NewDomainValue(zone.Domain)
+	m.Active = types.BoolValue(zone.Active)
+	m.CatchAll = types.BoolValue(zone.CatchAll)
+	m.Forwarding = types.BoolValue(zone.Forwarding)
+	m.Regex = types.BoolValue(zone.Regex)
+	m.Master = types.BoolValue(zone.Master)
+	m.CreatedAt = types.StringValue(zone.CreatedAt)
+	m.UpdatedAt = types.StringValue(zone.UpdatedAt)
+
+	tags, diags := zoneTagsValue(ctx, m.Tags, zone.Tags)
+	m.Tags = tags
+	return diags
+}
+
+// zoneTagsValue converts the zone's tags to the tags attribute. A zone
+// without tags keeps a configured empty set rather than turning it null.
+func zoneTagsValue(ctx context.Context, prior types.Set, tags snitchdns.ZoneTags) (types.Set, diag.Diagnostics) {
+	if len(tags) == 0 {
+		if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+			return prior, nil
+		}
+		return types.SetNull(types.StringType), nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, []string(tags))
 }
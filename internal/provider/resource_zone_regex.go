@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithValidateConfig = &ZoneResource{}
+
+// ZoneTestQueriesModel describes the names a regex zone's pattern is tested
+// against during plan
+type ZoneTestQueriesModel struct {
+	Match   types.List `tfsdk:"match"`
+	NoMatch types.List `tfsdk:"no_match"`
+}
+
+// ValidateConfig checks that the domain of a regex zone compiles and
+// matches the names of test_queries as expected, so a broken pattern fails
+// the plan instead of queries once it is applied.
+func (r *ZoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ZoneResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Regex.IsUnknown() || data.Domain.IsUnknown() || data.Domain.IsNull() {
+		return
+	}
+	if !data.Regex.ValueBool() {
+		if data.TestQueries != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("test_queries"),
+				"Test Queries Without Regex",
+				"test_queries only applies to regex zones. Set regex = true or remove test_queries.",
+			)
+		}
+		return
+	}
+
+	pattern, err := snitchdns.CompileZonePattern(data.Domain.ValueString())
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) && (syntaxErr.Code == syntax.ErrInvalidPerlOp || syntaxErr.Code == syntax.ErrInvalidEscape) {
+		// Lookarounds and backreferences are valid for the server, but
+		// not for Go's regular expressions
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("domain"),
+			"Regex Zone Not Checked",
+			fmt.Sprintf("The domain pattern uses syntax the provider cannot evaluate, so it and test_queries were not checked: %s", err),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Invalid Regex Zone",
+			fmt.Sprintf("The domain of a regex zone must be a valid regular expression: %s", err),
+		)
+		return
+	}
+
+	if data.TestQueries != nil {
+		resp.Diagnostics.Append(testZonePattern(ctx, pattern, data.TestQueries.Match, path.Root("test_queries").AtName("match"), true)...)
+		resp.Diagnostics.Append(testZonePattern(ctx, pattern, data.TestQueries.NoMatch, path.Root("test_queries").AtName("no_match"), false)...)
+	}
+}
+
+// testZonePattern reports the names of a test_queries list that the
+// pattern does not match as expected. Names are compared without a
+// trailing dot.
+func testZonePattern(ctx context.Context, pattern *regexp.Regexp, names types.List, attribute path.Path, match bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if names.IsNull() || names.IsUnknown() {
+		return diags
+	}
+
+	var values []types.String
+	diags.Append(names.ElementsAs(ctx, &values, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	var failed []string
+	for _, name := range values {
+		if name.IsNull() || name.IsUnknown() {
+			continue
+		}
+		if pattern.MatchString(strings.TrimSuffix(name.ValueString(), ".")) != match {
+			failed = append(failed, fmt.Sprintf("%q", name.ValueString()))
+		}
+	}
+	if len(failed) == 0 {
+		return diags
+	}
+
+	detail := "The domain pattern does not match " + strings.Join(failed, ", ") + ", which it must."
+	if !match {
+		detail = "The domain pattern matches " + strings.Join(failed, ", ") + ", which it must not."
+	}
+	diags.AddAttributeError(attribute, "Regex Zone Test Failed", detail)
+	return diags
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestZoneResource_ValidateConfigRegex tests that regex zone patterns are
+// compiled and tested against test_queries
+func TestZoneResource_ValidateConfigRegex(t *testing.T) {
+	ctx := context.Background()
+	r := newMockResource(t, NewZoneResource(), snitchdnsmock.New())
+
+	testQueries := func(match, noMatch []string) types.Object {
+		list := func(names []string) types.List {
+			if names == nil {
+				return types.ListNull(types.StringType)
+			}
+			values := make([]attr.Value, len(names))
+			for i, name := range names {
+				values[i] = types.StringValue(name)
+			}
+			return types.ListValueMust(types.StringType, values)
+		}
+		return types.ObjectValueMust(
+			map[string]attr.Type{"match": types.ListType{ElemType: types.StringType}, "no_match": types.ListType{ElemType: types.StringType}},
+			map[string]attr.Value{"match": list(match), "no_match": list(noMatch)},
+		)
+	}
+
+	tests := map[string]struct {
+		domain      string
+		regex       bool
+		testQueries types.Object
+		wantError   bool
+		wantWarning bool
+	}{
+		"literal zone": {
+			domain: "example.com", regex: false,
+		},
+		"valid pattern": {
+			domain: `.*\.test\.example\.com`, regex: true,
+			testQueries: testQueries([]string{"a.test.example.com", "B.Test.Example.COM."}, []string{"test.example.com", "a.test.example.com.evil.net"}),
+		},
+		"invalid pattern": {
+			domain: `(.*\.example\.com`, regex: true,
+			wantError: true,
+		},
+		"name not matched": {
+			domain: `canary-[0-9]+\.example\.com`, regex: true,
+			testQueries: testQueries([]string{"canary-a.example.com"}, nil),
+			wantError:   true,
+		},
+		"name matched": {
+			domain: `canary-[0-9]+\.example\.com`, regex: true,
+			testQueries: testQueries(nil, []string{"canary-1.example.com"}),
+			wantError:   true,
+		},
+		"tests without regex": {
+			domain: "example.com", regex: false,
+			testQueries: testQueries([]string{"example.com"}, nil),
+			wantError:   true,
+		},
+		"unsupported syntax": {
+			domain: `(?!www\.).*\.example\.com`, regex: true,
+			testQueries: testQueries([]string{"www.example.com"}, nil),
+			wantWarning: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			attributes := map[string]attr.Value{
+				"domain": types.StringValue(tt.domain),
+				"active": types.BoolValue(true),
+				"regex":  types.BoolValue(tt.regex),
+			}
+			if !tt.testQueries.IsNull() {
+				attributes["test_queries"] = tt.testQueries
+			}
+			config := tfsdk.Config(mockPlan(t, r, attributes))
+
+			var resp fwresource.ValidateConfigResponse
+			r.(fwresource.ResourceWithValidateConfig).ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: config}, &resp)
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error %v, got %v", tt.wantError, resp.Diagnostics)
+			}
+			if warning := resp.Diagnostics.WarningsCount() > 0; warning != tt.wantWarning {
+				t.Errorf("Expected warning %v, got %v", tt.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
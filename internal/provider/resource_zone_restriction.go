@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneRestrictionResource{}
+var _ resource.ResourceWithImportState = &ZoneRestrictionResource{}
+
+// NewZoneRestrictionResource creates a new Zone Restriction resource.
+func NewZoneRestrictionResource() resource.Resource {
+	return &ZoneRestrictionResource{}
+}
+
+// ZoneRestrictionResource defines the resource implementation. Each
+// restriction allows or blocks queries to a zone from one IP range.
+type ZoneRestrictionResource struct {
+	client  snitchdns.ClientInterface
+	offline bool
+}
+
+// ZoneRestrictionResourceModel describes the resource data model.
+type ZoneRestrictionResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	ZoneID  types.String `tfsdk:"zone_id"`
+	IPRange types.String `tfsdk:"ip_range"`
+	Type    types.String `tfsdk:"type"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+// Metadata sets the resource type name.
+func (r *ZoneRestrictionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_restriction"
+}
+
+// Schema defines the resource schema.
+func (r *ZoneRestrictionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a rule allowing or blocking DNS queries to a zone from a source IP range. " +
+			"Once a zone has an enabled `allow` rule, only the allowed ranges get answers.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the restriction.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone the restriction applies to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ip_range": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Source IP address or CIDR range, such as `203.0.113.0/24`. Ranges must be given by their network address.",
+				Validators: []validator.String{
+					ipOrRangeValidator{},
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Whether queries from the range are answered (`allow`) or dropped (`block`).",
+				Validators: []validator.String{
+					stringvalidator.OneOf(snitchdns.RestrictionAllow, snitchdns.RestrictionBlock),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the restriction is applied. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *ZoneRestrictionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.offline = providerData.Offline
+}
+
+// CRUD methods are implemented in resource_zone_restriction_impl.go
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create implements the resource create logic
+func (r *ZoneRestrictionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "create zone restriction")
+		return
+	}
+
+	var data ZoneRestrictionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restriction, err := r.client.CreateRestriction(ctx, data.ZoneID.ValueString(), data.request())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error creating zone restriction",
+			fmt.Sprintf("Could not create restriction for zone %s", data.ZoneID.ValueString()), err)
+		return
+	}
+
+	data.setFromRestriction(restriction)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *ZoneRestrictionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.offline {
+		// Keep the prior state, which the response already holds
+		return
+	}
+
+	var data ZoneRestrictionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restriction, err := r.client.GetRestriction(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			tflog.Warn(ctx, "Zone restriction not found, removing from state", map[string]any{
+				"zone_id":        data.ZoneID.ValueString(),
+				"restriction_id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error reading zone restriction",
+			fmt.Sprintf("Could not read restriction ID %s in zone %s", data.ID.ValueString(), data.ZoneID.ValueString()), err)
+		return
+	}
+
+	data.setFromRestriction(restriction)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *ZoneRestrictionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "update zone restriction")
+		return
+	}
+
+	var data ZoneRestrictionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restriction, err := r.client.UpdateRestriction(ctx, data.ZoneID.ValueString(), data.ID.ValueString(), data.request())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating zone restriction",
+			fmt.Sprintf("Could not update restriction ID %s", data.ID.ValueString()), err)
+		return
+	}
+
+	data.setFromRestriction(restriction)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic
+func (r *ZoneRestrictionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "delete zone restriction")
+		return
+	}
+
+	var data ZoneRestrictionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteRestriction(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		if snitchdns.IsNotFound(err) {
+			// Restriction or zone is already gone
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting zone restriction",
+			fmt.Sprintf("Could not delete restriction ID %s", data.ID.ValueString()), err)
+		return
+	}
+}
+
+// ImportState implements the resource import logic
+func (r *ZoneRestrictionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.offline {
+		addOfflineError(&resp.Diagnostics, "import zone restriction")
+		return
+	}
+
+	// Import ID format: "zone_id:restriction_id" or "zone_id/restriction_id"
+	zoneID, restrictionID, ok := strings.Cut(req.ID, ":")
+	if !ok {
+		zoneID, restrictionID, ok = strings.Cut(req.ID, "/")
+	}
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID format",
+			fmt.Sprintf("Expected import ID format 'zone_id:restriction_id' or 'zone_id/restriction_id', got: %s", req.ID),
+		)
+		return
+	}
+
+	for _, id := range []string{zoneID, restrictionID} {
+		if _, err := strconv.Atoi(id); err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid import ID",
+				fmt.Sprintf("Zone and restriction IDs must be numeric, got: %s", req.ID),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), zoneID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), restrictionID)...)
+}
+
+// request converts the data model to an API request
+func (m *ZoneRestrictionResourceModel) request() snitchdns.RestrictionRequest {
+	enabled := m.Enabled.ValueBool()
+	return snitchdns.RestrictionRequest{
+		IPRange: m.IPRange.ValueString(),
+		Type:    m.Type.ValueString(),
+		Enabled: &enabled,
+	}
+}
+
+// setFromRestriction maps the API restriction to the data model
+func (m *ZoneRestrictionResourceModel) setFromRestriction(restriction *snitchdns.ZoneRestriction) {
+	m.ID = types.StringValue(strconv.Itoa(restriction.ID))
+	m.IPRange = types.StringValue(restriction.IPRange)
+	m.Type = types.StringValue(restriction.Type)
+	m.Enabled = types.BoolValue(restriction.Enabled)
+}
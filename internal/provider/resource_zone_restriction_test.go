@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccZoneRestrictionResource tests managing a zone restriction
+func TestAccZoneRestrictionResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneRestrictionResourceConfig(container, "10.0.0.0/8", "allow", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("snitchdns_zone_restriction.test", "id"),
+					resource.TestCheckResourceAttrPair("snitchdns_zone_restriction.test", "zone_id", "snitchdns_zone.test", "id"),
+					resource.TestCheckResourceAttr("snitchdns_zone_restriction.test", "ip_range", "10.0.0.0/8"),
+					resource.TestCheckResourceAttr("snitchdns_zone_restriction.test", "type", "allow"),
+					resource.TestCheckResourceAttr("snitchdns_zone_restriction.test", "enabled", "true"),
+				),
+			},
+			{
+				ResourceName:      "snitchdns_zone_restriction.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccZoneRestrictionImportStateIdFunc,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccZoneRestrictionResourceConfig(container, "203.0.113.0/24", "block", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_zone_restriction.test", "ip_range", "203.0.113.0/24"),
+					resource.TestCheckResourceAttr("snitchdns_zone_restriction.test", "type", "block"),
+					resource.TestCheckResourceAttr("snitchdns_zone_restriction.test", "enabled", "false"),
+				),
+			},
+			{
+				Config:      testAccZoneRestrictionResourceConfig(container, "203.0.113.1/24", "block", true),
+				ExpectError: regexp.MustCompile(`has host bits set`),
+			},
+		},
+	})
+}
+
+// testAccZoneRestrictionImportStateIdFunc returns the import ID in format "zone_id/restriction_id"
+func testAccZoneRestrictionImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["snitchdns_zone_restriction.test"]
+	if !ok {
+		return "", fmt.Errorf("Resource not found")
+	}
+
+	return fmt.Sprintf("%s/%s", rs.Primary.Attributes["zone_id"], rs.Primary.ID), nil
+}
+
+// testAccZoneRestrictionResourceConfig generates HCL configuration for zone restriction testing
+func testAccZoneRestrictionResourceConfig(container *testcontainer.SnitchDNSContainer, ipRange, restrictionType string, enabled bool) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = "restricted.example.com"
+  active = true
+  regex  = false
+}
+
+resource "snitchdns_zone_restriction" "test" {
+  zone_id  = snitchdns_zone.test.id
+  ip_range = %[3]q
+  type     = %[4]q
+  enabled  = %[5]t
+}
+`, container.GetAPIEndpoint(), container.APIKey, ipRange, restrictionType, enabled)
+}
@@ -2,155 +2,580 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/EinDev/snitchdns-tf/internal/acctest"
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
-	"github.com/hashicorp/terraform-plugin-testing/terraform"
-	"snitchdns-tf/internal/testcontainer"
 )
 
-// TestAccZoneResource tests the Zone resource CRUD operations
+// TestAccZoneResource tests the Zone resource CRUD operations against
+// every SnitchDNS version of the test matrix
 func TestAccZoneResource(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping acceptance test in short mode")
 	}
 
-	ctx := context.Background()
+	testAccVersionMatrix(t, func(t *testing.T, container *testcontainer.SnitchDNSContainer, namespace string) {
+		domain := testcontainer.NamespacedDomain(namespace, "example.com")
 
-	// Start SnitchDNS test container
-	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
-		ExposePorts: true,
-	})
-	if err != nil {
-		t.Fatalf("Failed to start container: %v", err)
-	}
-	defer func() {
-		if err := container.Terminate(ctx); err != nil {
-			t.Logf("Failed to terminate container: %v", err)
-		}
-	}()
-
-	t.Logf("Container started at: %s", container.HTTPHost)
-	t.Logf("API Key: %s", container.APIKey[:10]+"...")
-
-	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
-		Steps: []resource.TestStep{
-			// Create and Read testing
-			{
-				Config: testAccZoneResourceConfig(container, "test.example.com", true, false),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "domain", "test.example.com"),
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "active", "true"),
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "catch_all", "false"),
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "forwarding", "false"),
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "regex", "false"),
-					resource.TestCheckResourceAttrSet("snitchdns_zone.test", "id"),
-					resource.TestCheckResourceAttrSet("snitchdns_zone.test", "created_at"),
-					resource.TestCheckResourceAttrSet("snitchdns_zone.test", "updated_at"),
-				),
-			},
-			// ImportState testing
-			{
-				ResourceName:      "snitchdns_zone.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+		resource.ParallelTest(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+			CheckDestroy:             acctest.CheckZonesDestroyed(container),
+			Steps: []resource.TestStep{
+				// Create and Read testing
+				{
+					Config: testAccZoneResourceConfig(container, domain, true, false),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						acctest.CheckZoneExists(container, "snitchdns_zone.test"),
+						resource.TestCheckResourceAttr("snitchdns_zone.test", "domain", domain),
+						resource.TestCheckResourceAttr("snitchdns_zone.test", "active", "true"),
+						resource.TestCheckResourceAttr("snitchdns_zone.test", "catch_all", "false"),
+						resource.TestCheckResourceAttr("snitchdns_zone.test", "forwarding", "false"),
+						resource.TestCheckResourceAttr("snitchdns_zone.test", "regex", "false"),
+						resource.TestCheckResourceAttrSet("snitchdns_zone.test", "id"),
+						resource.TestCheckResourceAttrSet("snitchdns_zone.test", "created_at"),
+						resource.TestCheckResourceAttrSet("snitchdns_zone.test", "updated_at"),
+					),
+				},
+				// ImportState testing
+				{
+					ResourceName:      "snitchdns_zone.test",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				// ImportState testing by domain
+				{
+					ResourceName:      "snitchdns_zone.test",
+					ImportState:       true,
+					ImportStateId:     strings.ToUpper(domain) + ".",
+					ImportStateVerify: true,
+				},
+				// Update and Read testing
+				{
+					Config: testAccZoneResourceConfig(container, domain, false, true),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("snitchdns_zone.test", "domain", domain),
+						resource.TestCheckResourceAttr("snitchdns_zone.test", "active", "false"),
+						resource.TestCheckResourceAttr("snitchdns_zone.test", "catch_all", "true"),
+					),
+				},
+				// Delete testing automatically occurs in TestCase
 			},
-			// Update and Read testing
-			{
-				Config: testAccZoneResourceConfig(container, "test.example.com", false, true),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "domain", "test.example.com"),
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "active", "false"),
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "catch_all", "true"),
-				),
-			},
-			// Delete testing automatically occurs in TestCase
-		},
+		})
 	})
 }
 
-// TestAccZoneResource_WithTags tests Zone resource with tags
+// TestAccZoneResource_WithTags tests Zone resource with tags, on the
+// SnitchDNS versions of the test matrix that have zone tags
 func TestAccZoneResource_WithTags(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping acceptance test in short mode")
 	}
 
-	ctx := context.Background()
+	testAccVersionMatrix(t, func(t *testing.T, container *testcontainer.SnitchDNSContainer, namespace string) {
+		testAccRequireFeature(t, container, "supports_zone_tags")
+		domain := testcontainer.NamespacedDomain(namespace, "example.com")
 
-	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
-		ExposePorts: true,
-	})
-	if err != nil {
-		t.Fatalf("Failed to start container: %v", err)
-	}
-	defer container.Terminate(ctx)
-
-	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
-		Steps: []resource.TestStep{
-			{
-				Config: testAccZoneResourceConfigWithTags(container, "tagged.example.com", []string{"production", "web"}),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "domain", "tagged.example.com"),
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "tags.#", "2"),
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "tags.0", "production"),
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "tags.1", "web"),
-				),
+		resource.ParallelTest(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccZoneResourceConfigWithTags(container, domain, []string{"production", "web"}),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("snitchdns_zone.test", "domain", domain),
+						resource.TestCheckResourceAttr("snitchdns_zone.test", "tags.#", "2"),
+						resource.TestCheckTypeSetElemAttr("snitchdns_zone.test", "tags.*", "production"),
+						resource.TestCheckTypeSetElemAttr("snitchdns_zone.test", "tags.*", "web"),
+					),
+				},
+				{
+					// Reordering tags must not produce a diff
+					Config:   testAccZoneResourceConfigWithTags(container, domain, []string{"web", "production"}),
+					PlanOnly: true,
+				},
 			},
-		},
+		})
 	})
 }
 
+// TestZoneTagsValue tests converting zone tags to the tags attribute
+func TestZoneTagsValue(t *testing.T) {
+	ctx := context.Background()
+
+	emptySet := types.SetValueMust(types.StringType, []attr.Value{})
+	tags := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("prod"), types.StringValue("web")})
+
+	tests := []struct {
+		name     string
+		prior    types.Set
+		tags     snitchdns.ZoneTags
+		expected types.Set
+	}{
+		{name: "tags", prior: types.SetNull(types.StringType), tags: snitchdns.ZoneTags{"web", "prod"}, expected: tags},
+		{name: "no tags", prior: types.SetNull(types.StringType), tags: snitchdns.ZoneTags{}, expected: types.SetNull(types.StringType)},
+		{name: "configured empty", prior: emptySet, tags: nil, expected: emptySet},
+		{name: "tags removed outside", prior: tags, tags: nil, expected: types.SetNull(types.StringType)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := zoneTagsValue(ctx, tt.prior, tt.tags)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
 // testAccZoneResourceConfig generates HCL configuration for testing
 func testAccZoneResourceConfig(container *testcontainer.SnitchDNSContainer, domain string, active bool, catchAll bool) string {
-	return fmt.Sprintf(`
-provider "snitchdns" {
-  api_url = %[1]q
-  api_key = %[2]q
+	return acctest.Compose(acctest.Provider(container), acctest.ZoneConfig{
+		Domain:   domain,
+		Inactive: !active,
+		CatchAll: catchAll,
+	})
 }
 
-resource "snitchdns_zone" "test" {
-  domain     = %[3]q
-  active     = %[4]t
-  catch_all  = %[5]t
-  forwarding = false
-  regex      = false
+// testAccZoneResourceConfigWithTags generates HCL configuration with tags
+func testAccZoneResourceConfigWithTags(container *testcontainer.SnitchDNSContainer, domain string, tags []string) string {
+	return acctest.Compose(acctest.Provider(container), acctest.ZoneConfig{Domain: domain, Tags: tags})
 }
-`, container.GetAPIEndpoint(), container.APIKey, domain, active, catchAll)
+
+// TestZoneResource_Mock tests zone CRUD logic against the in-memory API
+func TestZoneResource_Mock(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := newMockResource(t, NewZoneResource(), mock)
+
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"domain":    types.StringValue("example.com"),
+		"active":    types.BoolValue(true),
+		"catch_all": types.BoolValue(true),
+		"tags":      types.SetValueMust(types.StringType, []attr.Value{types.StringValue("b"), types.StringValue("a")}),
+	})
+
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	zones := mock.Zones()
+	if len(zones) != 1 || zones[0].Domain != "example.com" || !zones[0].CatchAll || zones[0].Tags.String() != "a,b" {
+		t.Fatalf("Expected the zone to be created, got %+v", zones)
+	}
+
+	var id types.String
+	createResp.State.GetAttribute(ctx, path.Root("id"), &id)
+	if id.ValueString() != fmt.Sprint(zones[0].ID) {
+		t.Errorf("Expected id %d in state, got %s", zones[0].ID, id)
+	}
+
+	// A zone deleted outside of Terraform is removed from state
+	if err := mock.DeleteZone(id.ValueString()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	readResp := fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("Expected the deleted zone to be removed from state")
+	}
+
+	// API errors surface as diagnostics
+	mock.Fail("CreateZone", errors.New("server on fire"))
+	createResp = fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Error("Expected an error when the API fails")
+	}
 }
 
-// testAccZoneResourceConfigWithTags generates HCL configuration with tags
-func testAccZoneResourceConfigWithTags(container *testcontainer.SnitchDNSContainer, domain string, tags []string) string {
-	tagsHCL := "["
-	for i, tag := range tags {
-		if i > 0 {
-			tagsHCL += ", "
+// TestZoneResource_MockReadAfterWrite tests that created zones are read
+// back, filling computed attributes the plan left unknown, unless the
+// provider skips it
+func TestZoneResource_MockReadAfterWrite(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := newMockResource(t, NewZoneResource(), mock)
+
+	create := func(domain string) fwresource.CreateResponse {
+		plan := mockPlan(t, r, map[string]attr.Value{
+			"domain":     types.StringValue(domain),
+			"active":     types.BoolValue(true),
+			"catch_all":  types.BoolUnknown(),
+			"forwarding": types.BoolUnknown(),
+		})
+		resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+		r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected create error: %v", resp.Diagnostics)
 		}
-		tagsHCL += fmt.Sprintf("%q", tag)
+		return resp
+	}
+
+	createResp := create("read.example.com")
+	if got := mock.Calls("GetZone"); got != 1 {
+		t.Errorf("Expected the zone to be read back once, got %d reads", got)
+	}
+	var catchAll types.Bool
+	createResp.State.GetAttribute(ctx, path.Root("catch_all"), &catchAll)
+	if catchAll.IsUnknown() || catchAll.ValueBool() {
+		t.Errorf("Expected catch_all false in state, got %s", catchAll)
+	}
+
+	// A failing read back keeps the write's response with a warning
+	mock.Fail("GetZone", errors.New("server on fire"))
+	createResp = create("warn.example.com")
+	if createResp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("Expected a warning, got %v", createResp.Diagnostics)
+	}
+	mock.Fail("GetZone", nil)
+
+	r.(*ZoneResource).skipReadAfterWrite = true
+	calls := mock.Calls("GetZone")
+	create("skip.example.com")
+	if got := mock.Calls("GetZone"); got != calls {
+		t.Errorf("Expected no read back, got %d reads", got-calls)
+	}
+}
+
+// TestZoneResource_ModifyPlanRename tests that changing the domain replaces
+// the zone unless the zone or provider allows renaming it in place
+func TestZoneResource_ModifyPlanRename(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := newMockResource(t, NewZoneResource(), mock)
+
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"domain": types.StringValue("old.example.com"),
+		"active": types.BoolValue(true),
+	})
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	tests := map[string]struct {
+		domain   string
+		allow    types.Bool
+		provider bool
+		replace  bool
+	}{
+		"renamed":             {"new.example.com", types.BoolNull(), false, true},
+		"case only":           {"Old.Example.COM.", types.BoolNull(), false, false},
+		"allowed by zone":     {"new.example.com", types.BoolValue(true), false, false},
+		"allowed by provider": {"new.example.com", types.BoolNull(), true, false},
+		"denied by zone":      {"new.example.com", types.BoolValue(false), true, true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r.(*ZoneResource).allowInPlaceRename = tt.provider
+
+			updatePlan := tfsdk.Plan{Schema: createResp.State.Schema, Raw: createResp.State.Raw.Copy()}
+			updatePlan.SetAttribute(ctx, path.Root("domain"), tt.domain)
+			updatePlan.SetAttribute(ctx, path.Root("allow_in_place_rename"), tt.allow)
+
+			resp := fwresource.ModifyPlanResponse{Plan: updatePlan}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+				Plan:  updatePlan,
+				State: createResp.State,
+			}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+			}
+			if replace := len(resp.RequiresReplace) > 0; replace != tt.replace {
+				t.Errorf("Expected replace %v, got %v", tt.replace, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+// TestZoneResource_ModifyPlanQuota tests that planning a zone warns about
+// the zone limit of the API key's user, and only for zones created for it
+func TestZoneResource_ModifyPlanQuota(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		attributes map[string]attr.Value
+		warned     bool
+	}{
+		"own zone":       {map[string]attr.Value{}, true},
+		"other user":     {map[string]attr.Value{"user_id": types.Int64Value(5)}, false},
+		"owner":          {map[string]attr.Value{"owner": types.StringValue("alice")}, false},
+		"adopt existing": {map[string]attr.Value{"adopt_existing": types.BoolValue(true)}, false},
 	}
-	tagsHCL += "]"
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock := snitchdnsmock.New()
+			mock.ZoneLimit = 1
+			mock.AddZone(snitchdns.Zone{Domain: "existing.example.com"})
+			r := newMockResource(t, NewZoneResource(), mock)
 
-	return fmt.Sprintf(`
-provider "snitchdns" {
-  api_url = %[1]q
-  api_key = %[2]q
+			tt.attributes["domain"] = types.StringValue("new.example.com")
+			if _, ok := tt.attributes["user_id"]; !ok {
+				tt.attributes["user_id"] = types.Int64Unknown()
+			}
+			plan := mockPlan(t, r, tt.attributes)
+			state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+			}
+			if warned := resp.Diagnostics.WarningsCount() == 1; warned != tt.warned {
+				t.Errorf("Expected warned %v, got %v", tt.warned, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// TestZoneResource_MockConflict tests that updates with detect_conflicts
+// fail when the zone was changed since it was read
+func TestZoneResource_MockConflict(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := newMockResource(t, NewZoneResource(), mock)
+
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"domain":           types.StringValue("conflict.example.com"),
+		"active":           types.BoolValue(true),
+		"detect_conflicts": types.BoolValue(true),
+	})
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	var id types.String
+	createResp.State.GetAttribute(ctx, path.Root("id"), &id)
+
+	// Another workspace changes the zone
+	catchAll := true
+	if _, err := mock.UpdateZone(id.ValueString(), snitchdns.UpdateZoneRequest{CatchAll: &catchAll}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	update := func(state tfsdk.State) fwresource.UpdateResponse {
+		updatePlan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}
+		updatePlan.SetAttribute(ctx, path.Root("active"), types.BoolValue(false))
+		resp := fwresource.UpdateResponse{State: state}
+		r.Update(ctx, fwresource.UpdateRequest{Plan: updatePlan, State: state}, &resp)
+		return resp
+	}
+
+	updateResp := update(createResp.State)
+	if !updateResp.Diagnostics.HasError() || updateResp.Diagnostics[0].Summary() != "Conflicting Update" {
+		t.Fatalf("Expected a conflict, got %v", updateResp.Diagnostics)
+	}
+	if zone, _ := mock.GetZone(id.ValueString()); !zone.Active {
+		t.Error("Expected the conflicting update not to be made")
+	}
+
+	// After a refresh the update goes through
+	readResp := fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", readResp.Diagnostics)
+	}
+	if updateResp = update(readResp.State); updateResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected update error: %v", updateResp.Diagnostics)
+	}
+	if zone, _ := mock.GetZone(id.ValueString()); zone.Active || !zone.CatchAll {
+		t.Errorf("Expected the update to keep the other change, got %+v", zone)
+	}
 }
 
-resource "snitchdns_zone" "test" {
-  domain     = %[3]q
-  active     = true
-  catch_all  = false
-  forwarding = false
-  regex      = false
-  tags       = %[4]s
+// TestZoneResource_MockUserID tests creating zones for other users by ID
+// and with the provider's default_user_id
+func TestZoneResource_MockUserID(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := newMockResource(t, NewZoneResource(), mock)
+
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"domain":  types.StringValue("configured.example.com"),
+		"active":  types.BoolValue(true),
+		"user_id": types.Int64Value(7),
+	})
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	// The provider default applies to zones without user_id or owner
+	r.(*ZoneResource).defaultUserID = 9
+	plan = mockPlan(t, r, map[string]attr.Value{
+		"domain":  types.StringValue("default.example.com"),
+		"active":  types.BoolValue(true),
+		"user_id": types.Int64Unknown(),
+	})
+	createResp = fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	var userID types.Int64
+	createResp.State.GetAttribute(ctx, path.Root("user_id"), &userID)
+	if userID.ValueInt64() != 9 {
+		t.Errorf("Expected user_id 9 in state, got %s", userID)
+	}
+
+	owners := map[string]int{}
+	for _, zone := range mock.Zones() {
+		owners[zone.Domain] = zone.UserID
+	}
+	if owners["configured.example.com"] != 7 || owners["default.example.com"] != 9 {
+		t.Errorf("Expected zones owned by users 7 and 9, got %v", owners)
+	}
 }
-`, container.GetAPIEndpoint(), container.APIKey, domain, tagsHCL)
+
+// TestZoneResource_MockAdoptExisting tests that creating a zone whose domain
+// is taken adopts the existing zone when adopt_existing is set, updating
+// its divergent attributes, and fails otherwise or when it is incompatible
+func TestZoneResource_MockAdoptExisting(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	r := newMockResource(t, NewZoneResource(), mock)
+
+	existing := mock.AddZone(snitchdns.Zone{Domain: "Bootstrap.example.com", Active: false, CatchAll: true, Tags: snitchdns.ZoneTags{"old"}})
+	mock.AddZone(snitchdns.Zone{Domain: "regex.example.com", Regex: true})
+
+	planFor := func(domain string, adopt bool) tfsdk.Plan {
+		return mockPlan(t, r, map[string]attr.Value{
+			"domain":         types.StringValue(domain),
+			"active":         types.BoolValue(true),
+			"regex":          types.BoolValue(false),
+			"catch_all":      types.BoolUnknown(),
+			"forwarding":     types.BoolValue(false),
+			"tags":           types.SetValueMust(types.StringType, []attr.Value{types.StringValue("new")}),
+			"adopt_existing": types.BoolValue(adopt),
+		})
+	}
+
+	// Without adopt_existing the duplicate fails the create
+	plan := planFor("bootstrap.example.com", false)
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("Expected a duplicate domain error")
+	}
+
+	plan = planFor("bootstrap.example.com", true)
+	createResp = fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+	if createResp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("Expected a warning naming the updated attributes, got %v", createResp.Diagnostics)
+	}
+
+	var id types.String
+	createResp.State.GetAttribute(ctx, path.Root("id"), &id)
+	if id.ValueString() != fmt.Sprint(existing.ID) {
+		t.Errorf("Expected the existing zone %d in state, got %s", existing.ID, id)
+	}
+	zone, err := mock.GetZone(id.ValueString())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// catch_all is not configured, so the adopted zone keeps it
+	if !zone.Active || !zone.CatchAll || zone.Tags.String() != "new" || len(mock.Zones()) != 2 {
+		t.Errorf("Expected the existing zone to be updated, got %+v", zone)
+	}
+
+	// A regex zone is not adopted for a literal domain
+	plan = planFor("regex.example.com", true)
+	createResp = fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Error("Expected an incompatible zone not to be adopted")
+	}
+
+	// A regex zone listed before the plain zone of the same domain does
+	// not hide it
+	mock.AddZone(snitchdns.Zone{Domain: "shadowed.example.com", Regex: true})
+	plain := mock.AddZone(snitchdns.Zone{Domain: " Shadowed.example.com. ", Active: true, Tags: snitchdns.ZoneTags{"new"}})
+	plan = planFor("shadowed.example.com", true)
+	createResp = fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+	createResp.State.GetAttribute(ctx, path.Root("id"), &id)
+	if id.ValueString() != fmt.Sprint(plain.ID) {
+		t.Errorf("Expected the plain zone %d to be adopted, got %s", plain.ID, id)
+	}
 }
 
-// testAccCheckZoneDestroy verifies the zone has been destroyed
-func testAccCheckZoneDestroy(s *terraform.State) error {
-	// This will be implemented when we have the client
-	return nil
+// TestZoneResource_MockSkipUnchangedRefresh tests that refreshing an
+// unchanged zone keeps its state, including the owner and activity, without
+// reading them again
+func TestZoneResource_MockSkipUnchangedRefresh(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	mock.AddUser(snitchdns.User{ID: 7, Username: "alice"})
+	r := newMockResource(t, NewZoneResource(), mock)
+	r.(*ZoneResource).skipUnchangedRefresh = true
+
+	plan := mockPlan(t, r, map[string]attr.Value{
+		"domain": types.StringValue("owned.example.com"),
+		"active": types.BoolValue(true),
+		"owner":  types.StringValue("alice"),
+	})
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected create error: %v", createResp.Diagnostics)
+	}
+
+	// The owner was renamed, which does not change the zone's updated_at
+	state := createResp.State
+	state.SetAttribute(ctx, path.Root("owner"), types.StringValue("bob"))
+
+	calls := mock.Calls("GetZone")
+	statsCalls, userCalls := mock.Calls("GetZoneStats"), mock.Calls("ListUsers")
+	readResp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected read error: %v", readResp.Diagnostics)
+	}
+	if mock.Calls("GetZoneIfModified") != 1 || mock.Calls("GetZone") != calls {
+		t.Errorf("Expected a single conditional read, got %d conditional and %d full reads",
+			mock.Calls("GetZoneIfModified"), mock.Calls("GetZone")-calls)
+	}
+
+	if mock.Calls("GetZoneStats") != statsCalls || mock.Calls("ListUsers") != userCalls {
+		t.Errorf("Expected no activity or owner lookups, got %d and %d",
+			mock.Calls("GetZoneStats")-statsCalls, mock.Calls("ListUsers")-userCalls)
+	}
+
+	var owner types.String
+	readResp.State.GetAttribute(ctx, path.Root("owner"), &owner)
+	if owner.ValueString() != "bob" {
+		t.Errorf("Expected the prior owner bob to be kept, got %s", owner)
+	}
 }
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// zoneSchemaVersion is the version of the snitchdns_zone state. Bump it and
+// add an upgrader when an attribute changes type.
+const zoneSchemaVersion = 1
+
+var _ resource.ResourceWithUpgradeState = &ZoneResource{}
+
+// zoneResourceModelV0 is the zone state before schema versioning, when tags
+// were a list
+type zoneResourceModelV0 struct {
+	ID           types.String   `tfsdk:"id"`
+	UserID       types.Int64    `tfsdk:"user_id"`
+	Owner        types.String   `tfsdk:"owner"`
+	Domain       types.String   `tfsdk:"domain"`
+	Active       types.Bool     `tfsdk:"active"`
+	CatchAll     types.Bool     `tfsdk:"catch_all"`
+	Forwarding   types.Bool     `tfsdk:"forwarding"`
+	Regex        types.Bool     `tfsdk:"regex"`
+	Master       types.Bool     `tfsdk:"master"`
+	Tags         types.List     `tfsdk:"tags"`
+	CreatedAt    types.String   `tfsdk:"created_at"`
+	UpdatedAt    types.String   `tfsdk:"updated_at"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+	RecordCount  types.Int64    `tfsdk:"record_count"`
+	TotalHits    types.Int64    `tfsdk:"total_hits"`
+	LastActivity types.String   `tfsdk:"last_activity"`
+}
+
+// zoneSchemaV0 is the schema of zoneResourceModelV0. Attributes added before
+// versioning are included; states written without them read them as null.
+func zoneSchemaV0(ctx context.Context) schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":         schema.StringAttribute{Computed: true},
+			"user_id":    schema.Int64Attribute{Computed: true},
+			"owner":      schema.StringAttribute{Optional: true},
+			"domain":     schema.StringAttribute{Required: true},
+			"active":     schema.BoolAttribute{Required: true},
+			"catch_all":  schema.BoolAttribute{Optional: true, Computed: true},
+			"forwarding": schema.BoolAttribute{Optional: true, Computed: true},
+			"regex":      schema.BoolAttribute{Required: true},
+			"master":     schema.BoolAttribute{Computed: true},
+			"tags": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"created_at":    schema.StringAttribute{Computed: true},
+			"updated_at":    schema.StringAttribute{Computed: true},
+			"record_count":  schema.Int64Attribute{Computed: true},
+			"total_hits":    schema.Int64Attribute{Computed: true},
+			"last_activity": schema.StringAttribute{Computed: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// UpgradeState returns the upgraders of earlier zone state versions
+func (r *ZoneResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := zoneSchemaV0(ctx)
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &schemaV0,
+			StateUpgrader: upgradeZoneStateV0,
+		},
+	}
+}
+
+// upgradeZoneStateV0 converts the tags list to a set, dropping duplicates
+// the set cannot hold
+func upgradeZoneStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior zoneResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags := types.SetNull(types.StringType)
+	if !prior.Tags.IsNull() {
+		var elements []string
+		resp.Diagnostics.Append(prior.Tags.ElementsAs(ctx, &elements, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		set, diags := types.SetValueFrom(ctx, types.StringType, []string(snitchdns.NewZoneTags(elements)))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tags = set
+	}
+
+	upgraded := ZoneResourceModel{
+		ID:           prior.ID,
+		UserID:       prior.UserID,
+		Owner:        prior.Owner,
+		Domain:       DomainValue{StringValue: prior.Domain},
+		Active:       prior.Active,
+		CatchAll:     prior.CatchAll,
+		Forwarding:   prior.Forwarding,
+		Regex:        prior.Regex,
+		Master:       prior.Master,
+		Tags:         tags,
+		CreatedAt:    prior.CreatedAt,
+		UpdatedAt:    prior.UpdatedAt,
+		Timeouts:     prior.Timeouts,
+		RecordCount:  prior.RecordCount,
+		TotalHits:    prior.TotalHits,
+		LastActivity: prior.LastActivity,
+
+		DetectConflicts:    types.BoolNull(),
+		AllowInPlaceRename: types.BoolNull(),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestZoneUpgradeStateV0 tests that the tags list of unversioned states is
+// converted to a set
+func TestZoneUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewZoneResource()
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Schema.Version != zoneSchemaVersion {
+		t.Fatalf("Expected schema version %d, got %d", zoneSchemaVersion, schemaResp.Schema.Version)
+	}
+
+	upgrader := r.(fwresource.ResourceWithUpgradeState).UpgradeState(ctx)[0]
+
+	prior := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	prior.SetAttribute(ctx, path.Root("id"), "7")
+	prior.SetAttribute(ctx, path.Root("domain"), "canary.example.com")
+	prior.SetAttribute(ctx, path.Root("active"), true)
+	prior.SetAttribute(ctx, path.Root("regex"), false)
+	prior.SetAttribute(ctx, path.Root("tags"), []string{"prod", "canary", "prod"})
+
+	resp := fwresource.UpgradeStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &prior}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected upgrade error: %v", resp.Diagnostics)
+	}
+
+	var data ZoneResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected error reading upgraded state: %v", resp.Diagnostics)
+	}
+
+	var tags []string
+	data.Tags.ElementsAs(ctx, &tags, false)
+	if !reflect.DeepEqual(tags, []string{"canary", "prod"}) {
+		t.Errorf("Expected deduplicated tags, got %v", tags)
+	}
+	if data.ID.ValueString() != "7" || data.Domain.ValueString() != "canary.example.com" || !data.RecordCount.IsNull() {
+		t.Errorf("Unexpected upgraded state %+v", data)
+	}
+	if !data.Owner.Equal(types.StringNull()) {
+		t.Errorf("Expected owner to stay null, got %s", data.Owner)
+	}
+}
@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// NewProtocol6Server returns the factory of the plugin protocol version 6
+// server of the provider, which main and the acceptance tests both serve.
+// It is the framework server, which implements resources, data sources,
+// functions, actions and ephemeral resources, and is not muxed: the module
+// does not depend on terraform-plugin-mux. Servers written directly against
+// terraform-plugin-go would be combined with it here, so main and the tests
+// keep serving whatever this returns.
+func NewProtocol6Server(version string, container *testcontainer.SnitchDNSContainer) func() tfprotov6.ProviderServer {
+	return providerserver.NewProtocol6(New(version, container)())
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Range of SnitchDNS releases the provider is tested against. The maximum is
+// exclusive.
+var (
+	minTestedServerVersion = serverVersion{1, 3, 0}
+	maxTestedServerVersion = serverVersion{1, 5, 0}
+)
+
+// minSupportedServerVersion is the oldest SnitchDNS release the provider
+// works with. Record requests always carry the conditional fields added in
+// 1.2.0, which older servers reject.
+var minSupportedServerVersion = serverVersion{1, 2, 0}
+
+// versionedFeature is a provider feature that depends on server behavior that
+// changed between SnitchDNS releases
+type versionedFeature struct {
+	Name  string
+	Since serverVersion
+
+	// Capability is the attribute of snitchdns_server_capabilities reporting
+	// whether a server has the feature
+	Capability string
+}
+
+// versionedFeatures lists the features likely to misbehave on servers outside
+// the tested range
+var versionedFeatures = []versionedFeature{
+	{Name: "conditional records (is_conditional, conditional_*)", Since: serverVersion{1, 2, 0}, Capability: "supports_conditional"},
+	{Name: "notifications API", Since: serverVersion{1, 3, 0}, Capability: "supports_notifications"},
+	{Name: "zone tags", Since: serverVersion{1, 3, 0}, Capability: "supports_zone_tags"},
+}
+
+// serverCapabilities returns whether a server of the version has each
+// versioned feature, by capability. A version that is not reported or
+// cannot be parsed is taken for a current server, which has all of them.
+func serverCapabilities(version string) map[string]bool {
+	v, ok := parseServerVersion(version)
+	capabilities := make(map[string]bool, len(versionedFeatures))
+	for _, feature := range versionedFeatures {
+		capabilities[feature.Capability] = !ok || !v.Less(feature.Since)
+	}
+	return capabilities
+}
+
+// serverVersion is a parsed major.minor.patch SnitchDNS version
+type serverVersion [3]int
+
+// parseServerVersion parses versions such as "1.4.2", "v1.4" or "1.4.2-dev"
+func parseServerVersion(s string) (serverVersion, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+ "); i >= 0 {
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if s == "" || len(parts) > 3 {
+		return serverVersion{}, false
+	}
+
+	var v serverVersion
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return serverVersion{}, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+// Less reports whether v is an older version than other
+func (v serverVersion) Less(other serverVersion) bool {
+	for i := range v {
+		if v[i] != other[i] {
+			return v[i] < other[i]
+		}
+	}
+	return false
+}
+
+// String returns the version in major.minor.patch form
+func (v serverVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+// unsupportedServerVersionError returns the detail of the error for a server
+// version older than the oldest supported one, or "" when the version is
+// supported or cannot be parsed
+func unsupportedServerVersionError(version string) string {
+	v, ok := parseServerVersion(version)
+	if !ok || !v.Less(minSupportedServerVersion) {
+		return ""
+	}
+	return fmt.Sprintf("The SnitchDNS server reports version %s, but this provider requires version %s or later.", version, minSupportedServerVersion)
+}
+
+// untestedServerVersionWarning returns the detail of the warning emitted for
+// a server version outside the tested range, or "" when the version is tested
+// or cannot be parsed
+func untestedServerVersionWarning(version string) string {
+	v, ok := parseServerVersion(version)
+	if !ok {
+		return ""
+	}
+
+	if !v.Less(minTestedServerVersion) && v.Less(maxTestedServerVersion) {
+		return ""
+	}
+
+	// Older servers are named the features they predate; for newer servers
+	// (or when nothing is known to be missing) every feature is suspect
+	var features []string
+	for _, feature := range versionedFeatures {
+		if v.Less(feature.Since) {
+			features = append(features, feature.Name)
+		}
+	}
+	if len(features) == 0 {
+		for _, feature := range versionedFeatures {
+			features = append(features, feature.Name)
+		}
+	}
+
+	return fmt.Sprintf("The SnitchDNS server reports version %s, but this provider is tested against versions %s to before %s. "+
+		"The following features may not work as expected:\n  - %s",
+		version, minTestedServerVersion, maxTestedServerVersion, strings.Join(features, "\n  - "))
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseServerVersion tests parsing of the version formats reported by SnitchDNS
+func TestParseServerVersion(t *testing.T) {
+	tests := map[string]struct {
+		want serverVersion
+		ok   bool
+	}{
+		"1.4.2":     {serverVersion{1, 4, 2}, true},
+		"v1.3":      {serverVersion{1, 3, 0}, true},
+		"1.4.2-dev": {serverVersion{1, 4, 2}, true},
+		"2":         {serverVersion{2, 0, 0}, true},
+		"":          {serverVersion{}, false},
+		"unknown":   {serverVersion{}, false},
+		"1.2.3.4":   {serverVersion{}, false},
+	}
+
+	for input, tt := range tests {
+		got, ok := parseServerVersion(input)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseServerVersion(%q) = %v, %t; want %v, %t", input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestUntestedServerVersionWarning tests which versions produce a warning and which features it names
+func TestUntestedServerVersionWarning(t *testing.T) {
+	if detail := untestedServerVersionWarning("1.4.0"); detail != "" {
+		t.Errorf("Expected no warning for a tested version, got: %s", detail)
+	}
+	if detail := untestedServerVersionWarning("garbage"); detail != "" {
+		t.Errorf("Expected no warning for an unparseable version, got: %s", detail)
+	}
+
+	old := untestedServerVersionWarning("1.1.0")
+	if !strings.Contains(old, "conditional records") || !strings.Contains(old, "notifications API") {
+		t.Errorf("Expected old version warning to name conditional records and notifications, got: %s", old)
+	}
+
+	newer := untestedServerVersionWarning("2.0.0")
+	if !strings.Contains(newer, "version 2.0.0") || !strings.Contains(newer, "zone tags") {
+		t.Errorf("Expected newer version warning to name the version and all features, got: %s", newer)
+	}
+}
+
+// TestUnsupportedServerVersionError tests which versions are rejected
+func TestUnsupportedServerVersionError(t *testing.T) {
+	for _, version := range []string{"1.2.0", "1.4.1", "2.0.0", "", "garbage"} {
+		if detail := unsupportedServerVersionError(version); detail != "" {
+			t.Errorf("Expected version %q to be supported, got: %s", version, detail)
+		}
+	}
+
+	if detail := unsupportedServerVersionError("1.1.9"); !strings.Contains(detail, "requires version 1.2.0") {
+		t.Errorf("Expected version 1.1.9 to be rejected, got: %q", detail)
+	}
+}
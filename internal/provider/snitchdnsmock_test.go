@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newMockResource configures a resource with provider data backed by an
+// in-memory fake of the API, for unit tests of CRUD logic without Docker
+func newMockResource(t *testing.T, r resource.Resource, mock *snitchdnsmock.Client) resource.Resource {
+	t.Helper()
+
+	providerData := &ProviderData{
+		Client:  mock,
+		Records: NewRecordCache(mock),
+		Zones:   NewZoneResolver(mock),
+		Users:   NewUserResolver(mock),
+		Quota:   NewZoneQuota(mock),
+	}
+
+	if configurable, ok := r.(resource.ResourceWithConfigure); ok {
+		var resp resource.ConfigureResponse
+		configurable.Configure(context.Background(), resource.ConfigureRequest{ProviderData: providerData}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected configure error: %v", resp.Diagnostics)
+		}
+	}
+	return r
+}
+
+// mockPlan returns a plan of the resource with the given attributes set
+// and all others null, as for a configuration that only sets them
+func mockPlan(t *testing.T, r resource.Resource, attributes map[string]attr.Value) tfsdk.Plan {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range attributes {
+		if diags := plan.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("Unexpected error setting %s: %v", name, diags)
+		}
+	}
+	return plan
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/internal/testcontainer"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccZonePrefix starts the domains of zones created by acceptance
+// tests, which keep them below their testcontainer.Namespace, so the
+// sweeper can tell them from other zones of the instance
+const testAccZonePrefix = testcontainer.NamespacePrefix
+
+// TestMain runs the tests, or the sweepers when -sweep is given, e.g.
+// go test ./internal/provider -v -sweep=default
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("snitchdns_zone", &resource.Sweeper{
+		Name: "snitchdns_zone",
+		F:    sweepZones,
+	})
+}
+
+// sweepZones deletes the zones left behind by failed acceptance runs on the
+// instance named by SNITCHDNS_API_URL and SNITCHDNS_API_KEY. The region is
+// ignored, as an instance has none.
+func sweepZones(_ string) error {
+	apiURL, apiKey := os.Getenv("SNITCHDNS_API_URL"), os.Getenv("SNITCHDNS_API_KEY")
+	if apiURL == "" || apiKey == "" {
+		return fmt.Errorf("SNITCHDNS_API_URL and SNITCHDNS_API_KEY must be set to sweep")
+	}
+
+	ctx := context.Background()
+	client := snitchdns.NewClient(apiURL, apiKey)
+	zones, err := client.ListZonesWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list zones: %w", err)
+	}
+
+	var errs []string
+	for _, zone := range zones {
+		if !strings.HasPrefix(strings.ToLower(zone.Domain), testAccZonePrefix) {
+			continue
+		}
+		if err := client.DeleteZoneWithContext(ctx, strconv.Itoa(zone.ID)); err != nil && !snitchdns.IsNotFound(err) {
+			errs = append(errs, fmt.Sprintf("%s: %s", zone.Domain, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to delete zones: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingServiceName is the service name of exported spans unless
+// OTEL_SERVICE_NAME overrides it
+const tracingServiceName = "terraform-provider-snitchdns"
+
+// tracing holds the process-wide tracer provider. Terraform may configure
+// the provider several times in one process, e.g. once per alias, and all
+// configurations share one exporter.
+var tracing struct {
+	once     sync.Once
+	provider *sdktrace.TracerProvider
+	err      error
+}
+
+// otlpEndpointConfigured reports whether an OTLP endpoint is set in the
+// environment, for all signals or for traces
+func otlpEndpointConfigured() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// tracerProvider returns the tracer provider exporting spans over OTLP/HTTP
+// to the endpoint configured by the standard OTEL_EXPORTER_OTLP_*
+// environment variables, creating it on first use
+func tracerProvider(ctx context.Context, version string) (trace.TracerProvider, error) {
+	tracing.once.Do(func() {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			tracing.err = err
+			return
+		}
+
+		// Attributes from OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES
+		// take precedence over the defaults
+		res, err := resource.New(ctx,
+			resource.WithAttributes(
+				attribute.String("service.name", tracingServiceName),
+				attribute.String("service.version", version),
+			),
+			resource.WithFromEnv(),
+			resource.WithTelemetrySDK(),
+		)
+		if err != nil {
+			tracing.err = err
+			return
+		}
+
+		tracing.provider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(res),
+		)
+	})
+
+	if tracing.err != nil {
+		return nil, tracing.err
+	}
+	return tracing.provider, nil
+}
+
+// ShutdownTracing exports the spans still buffered and stops the exporter.
+// It is called when the provider server stops and does nothing when tracing
+// was never enabled.
+func ShutdownTracing(ctx context.Context) error {
+	if tracing.provider == nil {
+		return nil
+	}
+	return tracing.provider.Shutdown(ctx)
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// TestTracingOptions tests that tracing is only enabled with an OTLP endpoint
+func TestTracingOptions(t *testing.T) {
+	ctx := context.Background()
+	p := &SnitchDNSProvider{version: "test"}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	var opts []snitchdns.Option
+	diags := p.tracingOptions(ctx, &opts)
+	if diags.WarningsCount() != 1 || diags.HasError() || len(opts) != 0 {
+		t.Errorf("Expected a warning and no tracing without an endpoint, got %v and %d options", diags, len(opts))
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://127.0.0.1:4318")
+	t.Cleanup(func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		ShutdownTracing(shutdownCtx)
+	})
+
+	diags = p.tracingOptions(ctx, &opts)
+	if diags.WarningsCount() != 0 || diags.HasError() || len(opts) != 1 {
+		t.Errorf("Expected tracing to be enabled, got %v and %d options", diags, len(opts))
+	}
+}
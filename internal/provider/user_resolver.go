@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// UserResolver maps between usernames and user IDs. Users are listed once
+// per provider instance, and only when a configuration refers to a user by
+// name, since listing users requires an admin API key. A failed listing is
+// retried by the next lookup.
+type UserResolver struct {
+	client snitchdns.ClientInterface
+
+	mu     sync.Mutex
+	listed bool
+	users  []snitchdns.User
+}
+
+// NewUserResolver creates a resolver backed by the given client.
+func NewUserResolver(c snitchdns.ClientInterface) *UserResolver {
+	return &UserResolver{client: c}
+}
+
+// ByUsername returns the user with the given username, compared
+// case-insensitively.
+func (r *UserResolver) ByUsername(ctx context.Context, username string) (*snitchdns.User, error) {
+	users, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		if strings.EqualFold(users[i].Username, username) {
+			return &users[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no SnitchDNS user named %q", username)
+}
+
+// ByID returns the user with the given ID.
+func (r *UserResolver) ByID(ctx context.Context, id int) (*snitchdns.User, error) {
+	users, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		if users[i].ID == id {
+			return &users[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no SnitchDNS user with ID %d", id)
+}
+
+// list returns all users, listing them on first use
+func (r *UserResolver) list(ctx context.Context) ([]snitchdns.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.listed {
+		return r.users, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "Listing users for username resolution")
+	// The listing is shared with the lookups waiting for it
+	users, err := r.client.ListUsers(context.WithoutCancel(ctx))
+	if err != nil {
+		return nil, err
+	}
+	r.users, r.listed = users, true
+	return users, nil
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+)
+
+// TestUserResolver tests that users are listed once and resolved by name and ID
+func TestUserResolver(t *testing.T) {
+	listings := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listings.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id": 1, "username": "admin", "admin": true}, {"id": 7, "username": "alice"}]`))
+	}))
+	defer server.Close()
+
+	resolver := NewUserResolver(snitchdns.NewClient(server.URL, "test-key"))
+	ctx := context.Background()
+
+	user, err := resolver.ByUsername(ctx, "Alice")
+	if err != nil || user.ID != 7 {
+		t.Errorf("Expected user ID 7, got %+v (err=%v)", user, err)
+	}
+
+	user, err = resolver.ByID(ctx, 1)
+	if err != nil || user.Username != "admin" {
+		t.Errorf("Expected user admin, got %+v (err=%v)", user, err)
+	}
+
+	if _, err := resolver.ByUsername(ctx, "bob"); err == nil {
+		t.Error("Expected an error for an unknown username")
+	}
+
+	if listings.Load() != 1 {
+		t.Errorf("Expected 1 listing, got %d", listings.Load())
+	}
+}
+
+// TestUserResolver_FailureNotCached tests that a failed listing is retried
+// by the next lookup
+func TestUserResolver_FailureNotCached(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	mock.AddUser(snitchdns.User{ID: 7, Username: "alice"})
+	resolver := NewUserResolver(mock)
+
+	mock.Fail("ListUsers", errors.New("connection refused"))
+	if _, err := resolver.ByUsername(ctx, "alice"); err == nil {
+		t.Fatal("Expected the first lookup to fail")
+	}
+
+	mock.Fail("ListUsers", nil)
+	user, err := resolver.ByUsername(ctx, "alice")
+	if err != nil || user.ID != 7 {
+		t.Errorf("Expected user ID 7, got %+v (err=%v)", user, err)
+	}
+	if calls := mock.Calls("ListUsers"); calls != 2 {
+		t.Errorf("Expected 2 listings, got %d", calls)
+	}
+}
@@ -0,0 +1,17 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// secretsVersionAttribute returns the schema of the secrets_version attribute
+// of resources with write-only secrets. Terraform never plans a change of a
+// write-only attribute, since its value is not kept, so changing the
+// version is what makes it update the resource and send the secrets again.
+func secretsVersionAttribute(secrets string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional: true,
+		MarkdownDescription: "Version of the write-only " + secrets + ". Terraform cannot tell when a write-only value changed, " +
+			"so change this, for example by incrementing it, to send new values to SnitchDNS.",
+	}
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// adoptableZoneErrorAttributes maps the errors of snitchdns_zone writes to its
+// attributes, pointing duplicate domains at adopt_existing
+var adoptableZoneErrorAttributes = append([]errorAttribute{{
+	code: snitchdns.ErrCodeZoneExists,
+	attr: path.Root("domain"),
+	hint: "A zone with this domain already exists in SnitchDNS. Import it with `terraform import` or set `adopt_existing = true` instead of creating it, or choose a different domain.",
+}}, zoneErrorAttributes...)
+
+// isZoneExistsError reports whether a zone could not be created because its
+// domain is taken
+func isZoneExistsError(err error) bool {
+	var apiErr *snitchdns.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == snitchdns.ErrCodeZoneExists
+}
+
+// findAdoptableZone returns the zone holding the domain of a zone creation
+// from a fresh listing. Plain domains are matched like everywhere else with
+// snitchdns.MatchZoneDomain, which skips regex zones; regex zones are
+// matched by their exact pattern.
+func findAdoptableZone(ctx context.Context, c snitchdns.ClientInterface, req snitchdns.CreateZoneRequest) (*snitchdns.Zone, error) {
+	zones, err := c.ListZonesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Regex {
+		for i := range zones {
+			if zones[i].Regex && zones[i].Domain == req.Domain {
+				zone := zones[i]
+				return &zone, nil
+			}
+		}
+	} else if zone, ok := snitchdns.MatchZoneDomain(zones, req.Domain); ok {
+		return zone, nil
+	}
+	return nil, fmt.Errorf("%w: %s", snitchdns.ErrZoneNotFound, req.Domain)
+}
+
+// zoneAdoptionConflict returns why an existing zone cannot be adopted for a
+// zone creation, or "" when it can: the attributes that cannot be changed
+// in place must already match
+func zoneAdoptionConflict(existing *snitchdns.Zone, req snitchdns.CreateZoneRequest) string {
+	switch {
+	case existing.Master:
+		return fmt.Sprintf("Zone %s (ID %d) is a master zone, which cannot be managed through the API.", existing.Domain, existing.ID)
+	case existing.Regex != req.Regex:
+		return fmt.Sprintf("Zone %s (ID %d) has regex = %t, but the configuration sets regex = %t.", existing.Domain, existing.ID, existing.Regex, req.Regex)
+	case req.UserID != 0 && existing.UserID != req.UserID:
+		return fmt.Sprintf("Zone %s (ID %d) belongs to user ID %d, but the configuration creates it for user ID %d.", existing.Domain, existing.ID, existing.UserID, req.UserID)
+	}
+	return ""
+}
+
+// zoneAdoptionChanges returns the attributes of an existing zone that
+// differ from a zone creation, which adopting it updates
+func zoneAdoptionChanges(existing *snitchdns.Zone, req snitchdns.CreateZoneRequest) []string {
+	var changes []string
+	for _, field := range []struct {
+		name          string
+		current, want bool
+	}{
+		{"active", existing.Active, req.Active},
+		{"catch_all", existing.CatchAll, req.CatchAll},
+		{"forwarding", existing.Forwarding, req.Forwarding},
+	} {
+		if field.current != field.want {
+			changes = append(changes, fmt.Sprintf("%s %t→%t", field.name, field.current, field.want))
+		}
+	}
+	if current, want := existing.Tags.String(), req.Tags.String(); current != want {
+		changes = append(changes, fmt.Sprintf("tags %q→%q", current, want))
+	}
+	return changes
+}
+
+// adoptZone takes over the existing zone a creation failed for with a
+// duplicate domain: it checks the zone is compatible with the
+// configuration and updates the attributes that differ. catch_all and
+// forwarding are kept when not configured. It returns nil after adding an
+// error.
+func (r *ZoneResource) adoptZone(ctx context.Context, data ZoneResourceModel, req snitchdns.CreateZoneRequest, diags *diag.Diagnostics) *snitchdns.Zone {
+	existing, err := findAdoptableZone(ctx, r.client, req)
+	if err != nil {
+		addAPIError(diags, "Error adopting zone",
+			fmt.Sprintf("Zone %s already exists, but could not be found to adopt it", req.Domain), err)
+		return nil
+	}
+
+	if conflict := zoneAdoptionConflict(existing, req); conflict != "" {
+		diags.AddAttributeError(path.Root("adopt_existing"), "Zone Cannot Be Adopted",
+			conflict+" Change the configuration to match, or import or delete the existing zone.")
+		return nil
+	}
+
+	if data.CatchAll.IsUnknown() {
+		req.CatchAll = existing.CatchAll
+	}
+	if data.Forwarding.IsUnknown() {
+		req.Forwarding = existing.Forwarding
+	}
+
+	tflog.Info(ctx, "Adopting existing zone", map[string]any{
+		"id":     existing.ID,
+		"domain": existing.Domain,
+	})
+
+	changes := zoneAdoptionChanges(existing, req)
+	if len(changes) == 0 {
+		return existing
+	}
+
+	zone, err := r.client.UpdateZoneWithContext(ctx, strconv.Itoa(existing.ID), snitchdns.UpdateZoneRequest{
+		Active:     &req.Active,
+		CatchAll:   &req.CatchAll,
+		Forwarding: &req.Forwarding,
+		Tags:       &req.Tags,
+	})
+	if err != nil {
+		addAPIError(diags, "Error adopting zone",
+			fmt.Sprintf("Could not update the adopted zone ID %d", existing.ID), err, zoneErrorAttributes...)
+		return nil
+	}
+
+	diags.AddWarning("Existing Zone Adopted",
+		fmt.Sprintf("Zone %s (ID %d) already existed and was adopted. Its attributes were updated to match the configuration:\n  - %s",
+			existing.Domain, existing.ID, strings.Join(changes, "\n  - ")))
+	return zone
+}
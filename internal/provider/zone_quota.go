@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// zoneQuotaWarnRatio is the share of the zone limit a plan may fill before
+// it is warned about
+const zoneQuotaWarnRatio = 0.9
+
+// ZoneQuota counts the zones a plan creates for the API key's user against
+// the user's zone limit, so a plan that would hit the limit is warned about
+// before the creations start failing mid-apply. The quota is read once per
+// provider instance, at the first planned creation; a failed read is retried
+// at the next one. Servers without zone limits answer the quota request with
+// 404 and are never warned about.
+type ZoneQuota struct {
+	client snitchdns.ClientInterface
+
+	// quotaMu serializes quota reads, so callers waiting for one share it
+	quotaMu sync.Mutex
+	loaded  bool
+	quota   *snitchdns.Quota
+
+	mu      sync.Mutex
+	planned int
+}
+
+// NewZoneQuota creates a quota counter backed by the given client.
+func NewZoneQuota(c snitchdns.ClientInterface) *ZoneQuota {
+	return &ZoneQuota{client: c}
+}
+
+// Plan counts n more zones created by the plan and returns a warning when
+// they take the user past the warning threshold or the limit. Each is
+// warned about once, by the resource whose zones cross it. A quota that
+// cannot be read is logged, since the check is advisory.
+func (q *ZoneQuota) Plan(ctx context.Context, n int) diag.Diagnostics {
+	if q == nil || n <= 0 {
+		return nil
+	}
+
+	quota, err := q.get(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Could not read the zone quota, skipping the quota check", map[string]any{
+			"error": err.Error(),
+		})
+		return nil
+	}
+	if quota == nil || quota.Unlimited() {
+		return nil
+	}
+
+	q.mu.Lock()
+	before := quota.ZonesUsed + q.planned
+	q.planned += n
+	after := quota.ZonesUsed + q.planned
+	q.mu.Unlock()
+
+	return zoneQuotaWarnings(*quota, before, after)
+}
+
+// get returns the quota, reading it on first use. It is nil for servers
+// without zone limits.
+func (q *ZoneQuota) get(ctx context.Context) (*snitchdns.Quota, error) {
+	q.quotaMu.Lock()
+	defer q.quotaMu.Unlock()
+
+	if q.loaded {
+		return q.quota, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	quota, err := q.client.GetQuota(context.WithoutCancel(ctx))
+	switch {
+	case snitchdns.IsNotFound(err):
+		tflog.Debug(ctx, "The server does not limit zones")
+	case err != nil:
+		return nil, err
+	default:
+		tflog.Debug(ctx, "Read the zone quota", map[string]any{
+			"zone_limit": quota.ZoneLimit,
+			"zones_used": quota.ZonesUsed,
+		})
+		q.quota = quota
+	}
+	q.loaded = true
+	return q.quota, nil
+}
+
+// zoneQuotaWarnings returns the warning for planned creations taking the
+// zones of a user from before to after, if they cross the limit or the
+// warning threshold. A user already over the limit is warned about once,
+// at the first creation.
+func zoneQuotaWarnings(quota snitchdns.Quota, before, after int) diag.Diagnostics {
+	var diags diag.Diagnostics
+	limit := quota.ZoneLimit
+	threshold := int(math.Ceil(float64(limit) * zoneQuotaWarnRatio))
+	planned := after - quota.ZonesUsed
+
+	switch {
+	case after > limit && (before <= limit || before == quota.ZonesUsed):
+		diags.AddWarning("Zone Quota Would Be Exceeded",
+			fmt.Sprintf("The API key's user owns %d of the %d zones they may own, and this plan creates at least %d more. "+
+				"SnitchDNS rejects zones past the limit, so the apply would fail partway, after creating %d of them. "+
+				"Delete unused zones or ask a SnitchDNS administrator to raise the limit before applying.",
+				quota.ZonesUsed, limit, planned, quota.ZonesRemaining()))
+	case after <= limit && after >= threshold && before < threshold:
+		diags.AddWarning("Zone Quota Nearly Reached",
+			fmt.Sprintf("The API key's user owns %d of the %d zones they may own, and this plan creates at least %d more, "+
+				"leaving room for %d. Creating zones past the limit fails.",
+				quota.ZonesUsed, limit, planned, limit-after))
+	}
+	return diags
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// warningSummaries returns the summaries of the warnings in diags
+func warningSummaries(diags diag.Diagnostics) []string {
+	var summaries []string
+	for _, d := range diags.Warnings() {
+		summaries = append(summaries, d.Summary())
+	}
+	return summaries
+}
+
+// TestZoneQuota_Plan tests that planned zone creations are warned about
+// once when they cross the warning threshold and once when they cross the
+// limit
+func TestZoneQuota_Plan(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	mock.ZoneLimit = 10
+	for i := 0; i < 7; i++ {
+		mock.AddZone(snitchdns.Zone{Domain: "existing.example.com"})
+	}
+	quota := NewZoneQuota(mock)
+
+	steps := []struct {
+		zones int
+		want  string
+	}{
+		{1, ""},
+		{1, "Zone Quota Nearly Reached"},
+		{1, ""},
+		{2, "Zone Quota Would Be Exceeded"},
+		{1, ""},
+	}
+	for i, step := range steps {
+		warnings := warningSummaries(quota.Plan(ctx, step.zones))
+		switch {
+		case step.want == "" && len(warnings) != 0:
+			t.Errorf("Step %d: expected no warning, got %v", i, warnings)
+		case step.want != "" && (len(warnings) != 1 || warnings[0] != step.want):
+			t.Errorf("Step %d: expected %q, got %v", i, step.want, warnings)
+		}
+	}
+	if calls := mock.Calls("GetQuota"); calls != 1 {
+		t.Errorf("Expected the quota to be read once, got %d reads", calls)
+	}
+}
+
+// TestZoneQuota_PlanOverLimit tests that a user already over the limit is
+// warned about at the first creation only
+func TestZoneQuota_PlanOverLimit(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	mock.ZoneLimit = 1
+	mock.AddZone(snitchdns.Zone{Domain: "one.example.com"})
+	mock.AddZone(snitchdns.Zone{Domain: "two.example.com"})
+	quota := NewZoneQuota(mock)
+
+	if warnings := warningSummaries(quota.Plan(ctx, 1)); len(warnings) != 1 || warnings[0] != "Zone Quota Would Be Exceeded" {
+		t.Errorf("Expected the limit warning, got %v", warnings)
+	}
+	if warnings := warningSummaries(quota.Plan(ctx, 1)); len(warnings) != 0 {
+		t.Errorf("Expected no second warning, got %v", warnings)
+	}
+}
+
+// TestZoneQuota_PlanWithoutLimit tests that servers without zone limits,
+// and quotas that cannot be read, are not warned about
+func TestZoneQuota_PlanWithoutLimit(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]func(mock *snitchdnsmock.Client){
+		"unlimited": func(mock *snitchdnsmock.Client) {},
+		"not served": func(mock *snitchdnsmock.Client) {
+			mock.Fail("GetQuota", &snitchdns.APIError{StatusCode: 404, Message: "Not Found"})
+		},
+		"failing": func(mock *snitchdnsmock.Client) {
+			mock.Fail("GetQuota", errors.New("connection refused"))
+		},
+	}
+	for name, setup := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock := snitchdnsmock.New()
+			mock.AddZone(snitchdns.Zone{Domain: "example.com"})
+			setup(mock)
+
+			if diags := NewZoneQuota(mock).Plan(ctx, 100); len(diags) != 0 {
+				t.Errorf("Expected no diagnostics, got %v", diags)
+			}
+		})
+	}
+}
+
+// TestZoneQuota_FailureNotCached tests that a quota that could not be read
+// is read again at the next planned creation
+func TestZoneQuota_FailureNotCached(t *testing.T) {
+	ctx := context.Background()
+	mock := snitchdnsmock.New()
+	mock.ZoneLimit = 1
+	mock.AddZone(snitchdns.Zone{Domain: "one.example.com"})
+	quota := NewZoneQuota(mock)
+
+	mock.Fail("GetQuota", errors.New("connection refused"))
+	if diags := quota.Plan(ctx, 1); len(diags) != 0 {
+		t.Errorf("Expected no diagnostics, got %v", diags)
+	}
+
+	mock.Fail("GetQuota", nil)
+	if warnings := warningSummaries(quota.Plan(ctx, 1)); len(warnings) != 1 || warnings[0] != "Zone Quota Would Be Exceeded" {
+		t.Errorf("Expected the limit warning, got %v", warnings)
+	}
+	if calls := mock.Calls("GetQuota"); calls != 2 {
+		t.Errorf("Expected the quota to be read again, got %d reads", calls)
+	}
+}
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ZoneResolver resolves zone domains to zones, looking each domain up once
+// per provider instance so that many resources referencing the same zone by
+// domain share a single API call. It also holds the zone listing shared by
+// all data sources. Only successful lookups and listings are kept, so a
+// failure or a canceled caller never fails later callers.
+type ZoneResolver struct {
+	client snitchdns.ClientInterface
+
+	mu      sync.Mutex
+	domains map[string]*resolvedZone
+
+	// listMu serializes listings, so callers waiting for one share it
+	listMu sync.Mutex
+	listed bool
+	zones  []snitchdns.Zone
+}
+
+// resolvedZone is the memoized lookup result for a single domain
+type resolvedZone struct {
+	mu   sync.Mutex
+	zone *snitchdns.Zone
+}
+
+// NewZoneResolver creates an empty resolver backed by the given client.
+func NewZoneResolver(c snitchdns.ClientInterface) *ZoneResolver {
+	return &ZoneResolver{
+		client:  c,
+		domains: make(map[string]*resolvedZone),
+	}
+}
+
+// Resolve returns the zone serving the given domain. Domains are compared
+// case-insensitively, ignoring surrounding space and a trailing dot.
+func (r *ZoneResolver) Resolve(ctx context.Context, domain string) (*snitchdns.Zone, error) {
+	key := snitchdns.NormalizeZoneDomain(domain)
+	entry := r.entry(key)
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.zone != nil {
+		return entry.zone, nil
+	}
+
+	// Prefer an existing listing over another request
+	if zone, ok := r.fromListing(key); ok {
+		entry.zone = zone
+		return zone, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "Resolving zone domain", map[string]any{
+		"domain": key,
+	})
+	// The API accepts a domain name wherever a zone ID is expected. Callers
+	// waiting for the lookup share it, so it does not end with the context
+	// of the caller that started it.
+	zone, err := r.client.GetZoneWithContext(context.WithoutCancel(ctx), url.PathEscape(key))
+	if err != nil {
+		return nil, err
+	}
+	entry.zone = zone
+	return zone, nil
+}
+
+// ResolveID returns the ID of the zone serving the given domain.
+func (r *ZoneResolver) ResolveID(ctx context.Context, domain string) (int, error) {
+	zone, err := r.Resolve(ctx, domain)
+	if err != nil {
+		return 0, err
+	}
+	return zone.ID, nil
+}
+
+// ListZones returns all zones, listing them once per provider instance so
+// that every data source shares a single listing. A failed listing is
+// retried by the next caller.
+func (r *ZoneResolver) ListZones(ctx context.Context) ([]snitchdns.Zone, error) {
+	r.listMu.Lock()
+	defer r.listMu.Unlock()
+
+	r.mu.Lock()
+	listed, zones := r.listed, r.zones
+	r.mu.Unlock()
+	if listed {
+		return zones, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "Listing zones for cache")
+
+	// Like lookups, the listing is shared with the callers waiting for it
+	zones, err := r.client.ListZonesWithContext(context.WithoutCancel(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.zones, r.listed = zones, true
+	return zones, nil
+}
+
+// SearchZones returns the zones whose domain may contain search. The shared
+// listing is reused once it exists; until then the server narrows the
+// listing, so a search never pulls every zone of a large server. The result
+// is a superset that callers filter again.
+func (r *ZoneResolver) SearchZones(ctx context.Context, search string) ([]snitchdns.Zone, error) {
+	r.mu.Lock()
+	listed := r.listed
+	r.mu.Unlock()
+
+	if listed || search == "" {
+		return r.ListZones(ctx)
+	}
+
+	tflog.Debug(ctx, "Searching zones", map[string]any{
+		"search": search,
+	})
+	return r.client.SearchZonesWithContext(ctx, search)
+}
+
+// fromListing looks a normalized domain up in the zone listing, if the zones
+// have already been listed. Regex zones are never matched.
+func (r *ZoneResolver) fromListing(key string) (*snitchdns.Zone, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.listed {
+		return nil, false
+	}
+	for i := range r.zones {
+		zone := r.zones[i]
+		if !zone.Regex && snitchdns.NormalizeZoneDomain(zone.Domain) == key {
+			return &zone, true
+		}
+	}
+	return nil, false
+}
+
+// Forget drops a memoized lookup, e.g. after the zone was renamed or deleted.
+func (r *ZoneResolver) Forget(domain string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.domains, snitchdns.NormalizeZoneDomain(domain))
+}
+
+// entry returns the memoization slot for a normalized domain
+func (r *ZoneResolver) entry(key string) *resolvedZone {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.domains[key]
+	if !ok {
+		entry = &resolvedZone{}
+		r.domains[key] = entry
+	}
+	return entry
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// TestZoneResolver_SingleLookupPerDomain tests that repeated resolutions of one domain share a lookup
+func TestZoneResolver_SingleLookupPerDomain(t *testing.T) {
+	lookups := atomic.Int32{}
+	var capturedPath atomic.Value
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups.Add(1)
+		capturedPath.Store(r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 42, "domain": "example.com", "active": true, "catch_all": false, "forwarding": false, "regex": false}`))
+	}))
+	defer server.Close()
+
+	resolver := NewZoneResolver(snitchdns.NewClient(server.URL, "test-key"))
+
+	var wg sync.WaitGroup
+	for _, domain := range []string{"example.com", "EXAMPLE.com", "example.com."} {
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(domain string) {
+				defer wg.Done()
+				id, err := resolver.ResolveID(context.Background(), domain)
+				if err != nil || id != 42 {
+					t.Errorf("Expected zone ID 42, got %d (err=%v)", id, err)
+				}
+			}(domain)
+		}
+	}
+	wg.Wait()
+
+	if lookups.Load() != 1 {
+		t.Errorf("Expected 1 lookup, got %d", lookups.Load())
+	}
+	if capturedPath.Load() != "/zones/example.com" {
+		t.Errorf("Expected path '/zones/example.com', got '%v'", capturedPath.Load())
+	}
+
+	resolver.Forget("example.com")
+	if _, err := resolver.Resolve(context.Background(), "example.com"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lookups.Load() != 2 {
+		t.Errorf("Expected a second lookup after Forget, got %d", lookups.Load())
+	}
+}
+
+// TestZoneResolver_SharedListing tests that listings are shared and used to resolve domains
+func TestZoneResolver_SharedListing(t *testing.T) {
+	requests := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"page": 1, "pages": 1, "per_page": 50, "total": 2, "results": [
+			{"id": 1, "domain": "a.example.com"},
+			{"id": 2, "domain": "b.example.com"}
+		]}`))
+	}))
+	defer server.Close()
+
+	resolver := NewZoneResolver(snitchdns.NewClient(server.URL, "test-key"))
+
+	for i := 0; i < 5; i++ {
+		zones, err := resolver.ListZones(context.Background())
+		if err != nil || len(zones) != 2 {
+			t.Fatalf("Expected 2 zones, got %d (err=%v)", len(zones), err)
+		}
+	}
+
+	id, err := resolver.ResolveID(context.Background(), "B.example.com")
+	if err != nil || id != 2 {
+		t.Errorf("Expected zone ID 2, got %d (err=%v)", id, err)
+	}
+
+	if requests.Load() != 1 {
+		t.Errorf("Expected a single listing request, got %d", requests.Load())
+	}
+}
+
+// TestZoneResolver_FailureNotCached tests that a failed listing or lookup is
+// retried by the next caller instead of failing the rest of the run
+func TestZoneResolver_FailureNotCached(t *testing.T) {
+	requests := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "try again"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"page": 1, "pages": 1, "per_page": 50, "total": 1, "results": [
+			{"id": 1, "domain": "a.example.com"}
+		]}`))
+	}))
+	defer server.Close()
+
+	resolver := NewZoneResolver(snitchdns.NewClient(server.URL, "test-key"))
+
+	if _, err := resolver.ListZones(context.Background()); err == nil {
+		t.Fatal("Expected the first listing to fail")
+	}
+	if _, err := resolver.Resolve(context.Background(), "a.example.com"); err == nil {
+		t.Fatal("Expected the first lookup to fail")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := resolver.ListZones(ctx); err == nil {
+		t.Fatal("Expected a canceled listing to fail")
+	}
+
+	zones, err := resolver.ListZones(context.Background())
+	if err != nil || len(zones) != 1 {
+		t.Fatalf("Expected the listing to be retried, got %d zones (err=%v)", len(zones), err)
+	}
+	id, err := resolver.ResolveID(context.Background(), "a.example.com")
+	if err != nil || id != 1 {
+		t.Errorf("Expected zone ID 1 from the listing, got %d (err=%v)", id, err)
+	}
+
+	if requests.Load() != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests.Load())
+	}
+}
+
+// TestZoneResolver_Search tests that searches are narrowed by the server
+// until the zones have been listed
+func TestZoneResolver_Search(t *testing.T) {
+	var queries []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("search"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"page": 1, "pages": 1, "per_page": 50, "total": 1, "results": [
+			{"id": 1, "domain": "a.example.com"}
+		]}`))
+	}))
+	defer server.Close()
+
+	resolver := NewZoneResolver(snitchdns.NewClient(server.URL, "test-key"))
+
+	if _, err := resolver.SearchZones(context.Background(), "a.example"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := resolver.ListZones(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := resolver.SearchZones(context.Background(), "b.example"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(queries) != 2 || queries[0] != "a.example" || queries[1] != "" {
+		t.Errorf("Expected a search and a full listing, got %q", queries)
+	}
+}
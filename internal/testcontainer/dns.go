@@ -0,0 +1,33 @@
+package testcontainer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/internal/dnslookup"
+)
+
+// Query sends a DNS query for name to the DNS server of the container over
+// UDP, see DNSAddress, and returns the response. qtype is a record type
+// mnemonic such as "A"; see dnslookup.SupportedTypes. Responses with an
+// error code such as NXDOMAIN are returned, not failed.
+func (c *SnitchDNSContainer) Query(ctx context.Context, name, qtype string) (*dnslookup.Response, error) {
+	if c.Container == nil {
+		return nil, ErrNoContainer
+	}
+	server, err := c.DNSAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := dnslookup.Lookup(ctx, dnslookup.Query{
+		Server:   server,
+		Name:     name,
+		Type:     qtype,
+		Protocol: dnslookup.ProtocolUDP,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s %s: %w", qtype, name, err)
+	}
+	return resp, nil
+}
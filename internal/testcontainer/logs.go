@@ -0,0 +1,113 @@
+package testcontainer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+const (
+	// LogDirEnvVar names a directory WithFailureLogs writes the logs of
+	// failed tests to, such as a CI artifacts directory, instead of the test
+	// output
+	LogDirEnvVar = "SNITCHDNS_TESTCONTAINER_LOG_DIR"
+
+	// AppLogDir is the directory SnitchDNS writes its application logs to
+	// in the container
+	AppLogDir = "/opt/snitchdns/data/logs"
+
+	// maxAppLogLines is the number of lines kept of each application log
+	maxAppLogLines = 1000
+)
+
+// WithFailureLogs registers a cleanup that writes the container logs and the
+// SnitchDNS application logs when the test failed, to the test output or to
+// the directory named by LogDirEnvVar. Cleanups run after the test function
+// returns, so register it after the cleanup releasing the container, or a
+// container stopped by a deferred Terminate has no logs left to read. A
+// fake server has no logs; nothing is registered for it.
+func WithFailureLogs(t testing.TB, c *SnitchDNSContainer) {
+	t.Helper()
+	if c.Container == nil {
+		return
+	}
+
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+		ctx := context.Background()
+
+		logs, err := c.Logs(ctx)
+		if err != nil {
+			t.Logf("Failed to read container logs: %v", err)
+		}
+		appLogs, err := c.AppLogs(ctx)
+		if err != nil {
+			t.Logf("Failed to read SnitchDNS logs: %v", err)
+		}
+
+		reportLogs(t, os.Getenv(LogDirEnvVar), []namedLog{{"container", logs}, {"snitchdns", appLogs}})
+	})
+}
+
+// namedLog is a log reported by WithFailureLogs
+type namedLog struct {
+	name    string
+	content string
+}
+
+// reportLogs writes the logs to the test output, or to files named after
+// the test in dir
+func reportLogs(t testing.TB, dir string, logs []namedLog) {
+	t.Helper()
+	name := invalidTagChars.ReplaceAllString(t.Name(), "-")
+	for _, log := range logs {
+		if dir == "" {
+			t.Logf("%s logs:\n%s", log.name, log.content)
+			continue
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("%s-%s.log", name, log.name))
+		if err := writeLog(path, log.content); err != nil {
+			t.Logf("Failed to write %s logs: %v", log.name, err)
+			continue
+		}
+		t.Logf("Wrote %s logs to %s", log.name, path)
+	}
+}
+
+// writeLog writes a log file, creating its directory
+func writeLog(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+// AppLogs returns the last lines of each SnitchDNS application log in
+// AppLogDir, headed by its path
+func (c *SnitchDNSContainer) AppLogs(ctx context.Context) (string, error) {
+	if c.Container == nil {
+		return "", ErrNoContainer
+	}
+
+	script := fmt.Sprintf(`for f in %s/*.log; do [ -f "$f" ] && echo "==> $f <==" && tail -n %d "$f"; done; true`, AppLogDir, maxAppLogLines)
+	code, reader, err := c.Container.Exec(ctx, []string{"sh", "-c", script}, tcexec.Multiplexed())
+	if err != nil {
+		return "", fmt.Errorf("failed to read application logs: %w", err)
+	}
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read application logs: %w", err)
+	}
+	if code != 0 {
+		return "", fmt.Errorf("failed to read application logs: exit code %d: %s", code, output)
+	}
+	return string(output), nil
+}
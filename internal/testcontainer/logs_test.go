@@ -0,0 +1,27 @@
+package testcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestReportLogs tests writing the logs of a failed test to files named
+// after the test
+func TestReportLogs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "artifacts")
+
+	t.Run("Sub/Test", func(t *testing.T) {
+		reportLogs(t, dir, []namedLog{{"container", "started"}, {"snitchdns", "==> daemon.log <=="}})
+	})
+
+	for file, expected := range map[string]string{
+		"TestReportLogs-Sub-Test-container.log": "started",
+		"TestReportLogs-Sub-Test-snitchdns.log": "==> daemon.log <==",
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil || string(content) != expected {
+			t.Errorf("Expected %s to hold %q, got %q, %v", file, expected, content, err)
+		}
+	}
+}
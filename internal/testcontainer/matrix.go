@@ -0,0 +1,219 @@
+package testcontainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	// VersionsEnvVar lists the SnitchDNS versions RunVersions runs tests
+	// against, separated by commas, such as "1.3.0,1.4.0". Versions are git
+	// refs of SnitchDNS, as for VersionEnvVar.
+	VersionsEnvVar = "SNITCHDNS_TEST_VERSIONS"
+
+	// MatrixReportEnvVar names a file RunVersions appends the result of
+	// every test and version to, as JSON lines of MatrixResult
+	MatrixReportEnvVar = "SNITCHDNS_TEST_MATRIX_REPORT"
+)
+
+// Results of a test against a version in the matrix report
+const (
+	MatrixPass = "pass"
+	MatrixFail = "fail"
+	MatrixSkip = "skip"
+)
+
+// MatrixResult is the result of a test against one SnitchDNS version, as
+// written to the matrix report
+type MatrixResult struct {
+	Test    string `json:"test"`
+	Version string `json:"version"`
+	Result  string `json:"result"`
+
+	// Reason is why a test was skipped, see RequireVersion
+	Reason string `json:"reason,omitempty"`
+}
+
+var (
+	// skipReasons are the reasons of RequireVersion by test name
+	skipReasons sync.Map
+
+	// reportMu serializes writes to the matrix report
+	reportMu sync.Mutex
+)
+
+// Versions returns the versions of VersionsEnvVar, or nil when it is not
+// set
+func Versions() []string {
+	var versions []string
+	for _, version := range strings.Split(os.Getenv(VersionsEnvVar), ",") {
+		if version = strings.TrimSpace(version); version != "" {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// RunVersions runs test against the shared container of every version of
+// VersionsEnvVar, each in a subtest named after the version, such as
+// TestAccZoneResource/snitchdns-1.4.0. Without versions, or with the fake
+// server, test runs once against the container of Shared. The container is
+// released when the test ends and logged when it failed, see
+// WithFailureLogs; tests keep to their Namespace as with Shared.
+//
+// Tests of features older servers lack call RequireVersion, which skips
+// them for those versions. With MatrixReportEnvVar set, the result of every
+// version is appended to the report, so a run shows which versions each
+// test passes on.
+func RunVersions(t *testing.T, test func(t *testing.T, container *SnitchDNSContainer)) {
+	t.Helper()
+
+	versions := Versions()
+	if len(versions) == 0 || os.Getenv(FakeServerEnvVar) != "" {
+		runVersion(t, "", test)
+		return
+	}
+
+	for _, version := range versions {
+		t.Run("snitchdns-"+version, func(t *testing.T) {
+			runVersion(t, version, test)
+		})
+	}
+}
+
+// runVersion runs test against the shared container of version
+func runVersion(t *testing.T, version string, test func(t *testing.T, container *SnitchDNSContainer)) {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := SharedVersion(ctx, version)
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("Failed to release container: %v", err)
+		}
+	})
+	WithFailureLogs(t, container)
+	t.Cleanup(func() {
+		recordMatrixResult(t, container)
+	})
+
+	test(t, container)
+}
+
+// RequireVersion skips the test when the container runs a SnitchDNS
+// version older than since, for tests of a feature that version lacks. The
+// reason names the feature and is recorded in the matrix report.
+// Containers of the default branch, and versions that are not numbers such
+// as branch names, are taken for current servers.
+func RequireVersion(t testing.TB, container *SnitchDNSContainer, feature, since string) {
+	t.Helper()
+
+	if !versionLess(container.Version(), since) {
+		return
+	}
+
+	reason := fmt.Sprintf("%s requires SnitchDNS %s, the container runs %s", feature, since, container.Version())
+	skipReasons.Store(t.Name(), reason)
+	t.Skip(reason)
+}
+
+// recordMatrixResult appends the result of the test to the matrix report
+func recordMatrixResult(t *testing.T, container *SnitchDNSContainer) {
+	path := os.Getenv(MatrixReportEnvVar)
+	if path == "" {
+		return
+	}
+
+	version := container.Version()
+	if version == "" {
+		version = "default"
+	}
+	result := MatrixResult{Test: t.Name(), Version: version, Result: MatrixPass}
+	switch {
+	case t.Failed():
+		result.Result = MatrixFail
+	case t.Skipped():
+		result.Result = MatrixSkip
+		if reason, ok := skipReasons.Load(t.Name()); ok {
+			result.Reason = reason.(string)
+		}
+	}
+
+	if err := appendMatrixResult(path, result); err != nil {
+		t.Logf("Failed to write matrix report: %v", err)
+	}
+}
+
+// appendMatrixResult appends a result to the report at path
+func appendMatrixResult(path string, result MatrixResult) error {
+	line, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	reportMu.Lock()
+	defer reportMu.Unlock()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// versionLess reports whether version a is older than b. Versions such as
+// "1.4", "v1.4.2" or "1.4.2-dev" are compared by their numbers; false is
+// returned when either is not such a version.
+func versionLess(a, b string) bool {
+	va, ok := parseVersion(a)
+	if !ok {
+		return false
+	}
+	vb, ok := parseVersion(b)
+	if !ok {
+		return false
+	}
+
+	for i := range va {
+		if va[i] != vb[i] {
+			return va[i] < vb[i]
+		}
+	}
+	return false
+}
+
+// parseVersion parses a major.minor.patch version with optional parts
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if s == "" || len(parts) > 3 {
+		return v, false
+	}
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
@@ -0,0 +1,100 @@
+package testcontainer
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestVersions tests parsing the versions of the matrix
+func TestVersions(t *testing.T) {
+	t.Setenv(VersionsEnvVar, " 1.3.0, v1.4,,main ")
+	if versions := Versions(); !slices.Equal(versions, []string{"1.3.0", "v1.4", "main"}) {
+		t.Errorf("Unexpected versions %v", versions)
+	}
+
+	t.Setenv(VersionsEnvVar, "")
+	if versions := Versions(); versions != nil {
+		t.Errorf("Expected no versions, got %v", versions)
+	}
+}
+
+// TestVersionLess tests comparing versions, where versions that are not
+// numbers are taken for current ones
+func TestVersionLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		less bool
+	}{
+		{"1.3", "1.4.0", true},
+		{"v1.4.2", "1.4", false},
+		{"1.4.0-dev", "1.4.1", true},
+		{"1.10", "1.9", false},
+		{"", "1.4", false},
+		{"main", "1.4", false},
+		{"1.4", "1.4.0", false},
+	}
+	for _, tt := range tests {
+		if less := versionLess(tt.a, tt.b); less != tt.less {
+			t.Errorf("Expected versionLess(%q, %q) = %v, got %v", tt.a, tt.b, tt.less, less)
+		}
+	}
+}
+
+// TestRunVersions tests that tests skipped for old versions are recorded
+// with their reason, and that the fake server runs tests once
+func TestRunVersions(t *testing.T) {
+	report := filepath.Join(t.TempDir(), "matrix.jsonl")
+	t.Setenv(MatrixReportEnvVar, report)
+	t.Setenv(FakeServerEnvVar, "1")
+	t.Setenv(VersionsEnvVar, "1.3.0,1.4.0")
+
+	runs := 0
+	t.Run("fake", func(t *testing.T) {
+		RunVersions(t, func(t *testing.T, container *SnitchDNSContainer) {
+			runs++
+			RequireVersion(t, container, "zone tags", "1.3.0")
+		})
+	})
+	if runs != 1 {
+		t.Errorf("Expected a single run against the fake server, got %d", runs)
+	}
+
+	old := &SnitchDNSContainer{version: "1.2.0"}
+	reached := false
+	t.Run("old", func(t *testing.T) {
+		t.Cleanup(func() { recordMatrixResult(t, old) })
+		RequireVersion(t, old, "zone tags", "1.3.0")
+		reached = true
+	})
+	if reached {
+		t.Error("Expected the test to be skipped for SnitchDNS 1.2.0")
+	}
+
+	f, err := os.Open(report)
+	if err != nil {
+		t.Fatalf("Failed to open report: %v", err)
+	}
+	defer f.Close()
+	var results []MatrixResult
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var result MatrixResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			t.Fatalf("Invalid report line %s: %v", scanner.Text(), err)
+		}
+		results = append(results, result)
+	}
+
+	want := []MatrixResult{
+		{Test: "TestRunVersions/fake", Version: "default", Result: MatrixPass},
+		{Test: "TestRunVersions/old", Version: "1.2.0", Result: MatrixSkip,
+			Reason: "zone tags requires SnitchDNS 1.3.0, the container runs 1.2.0"},
+	}
+	if !slices.Equal(results, want) {
+		t.Errorf("Unexpected report %+v", results)
+	}
+}
@@ -0,0 +1,264 @@
+package testcontainer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"gopkg.in/yaml.v3"
+)
+
+// SeedData are fixtures created in a container after it started, so tests
+// of data sources and imports find existing objects. Fixtures can be given
+// as Go values, read from a YAML file, or both.
+type SeedData struct {
+	Users []SeedUser `yaml:"users"`
+	Zones []SeedZone `yaml:"zones"`
+
+	// FixturePath is the path of a YAML file with users and zones in the
+	// same layout, created after the ones above
+	FixturePath string `yaml:"-"`
+}
+
+// SeedUser is a user account created with the SnitchDNS CLI, since the API
+// cannot create users
+type SeedUser struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	FullName string `yaml:"full_name"`
+	Email    string `yaml:"email"`
+	Admin    bool   `yaml:"admin"`
+}
+
+// SeedZone is a zone created through the API, together with its records
+type SeedZone struct {
+	Domain     string   `yaml:"domain"`
+	Inactive   bool     `yaml:"inactive"`
+	CatchAll   bool     `yaml:"catch_all"`
+	Forwarding bool     `yaml:"forwarding"`
+	Regex      bool     `yaml:"regex"`
+	Tags       []string `yaml:"tags"`
+
+	// Owner is the username of the user the zone is created for; empty
+	// creates it for the test admin
+	Owner string `yaml:"owner"`
+
+	Records []SeedRecord `yaml:"records"`
+}
+
+// SeedRecord is a record of a seeded zone. Class defaults to IN and TTL to
+// 3600.
+type SeedRecord struct {
+	Type     string                 `yaml:"type"`
+	Class    string                 `yaml:"class"`
+	TTL      int                    `yaml:"ttl"`
+	Inactive bool                   `yaml:"inactive"`
+	Data     map[string]interface{} `yaml:"data"`
+}
+
+// SeedResult holds the IDs of seeded objects
+type SeedResult struct {
+	// UserIDs maps usernames to user IDs
+	UserIDs map[string]int
+	// ZoneIDs maps zone domains to zone IDs
+	ZoneIDs map[string]string
+	// RecordIDs maps zone domains to the IDs of their records, in the order
+	// they were seeded
+	RecordIDs map[string][]string
+}
+
+// LoadSeedFixture reads seed data from a YAML file
+func LoadSeedFixture(path string) (SeedData, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return SeedData{}, fmt.Errorf("failed to read seed fixture: %w", err)
+	}
+
+	var data SeedData
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&data); err != nil && err != io.EOF {
+		return SeedData{}, fmt.Errorf("failed to parse seed fixture %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// Seed creates users, zones and records in the container. Users are
+// created first, so zones can be owned by them. The IDs of the created
+// objects are added to c.Seeded.
+func (c *SnitchDNSContainer) Seed(ctx context.Context, data SeedData) error {
+	if c.Seeded.UserIDs == nil {
+		c.Seeded = newSeedResult()
+	}
+	return c.seed(ctx, data, "", &c.Seeded)
+}
+
+// SeedNamespace creates users, zones and records like Seed, with the zone
+// domains below the namespace, see Namespace. It returns the IDs of the
+// created objects keyed by the domains of data instead of adding them to
+// c.Seeded, so tests sharing a container can seed the same fixture in
+// parallel.
+func (c *SnitchDNSContainer) SeedNamespace(ctx context.Context, namespace string, data SeedData) (SeedResult, error) {
+	result := newSeedResult()
+	err := c.seed(ctx, data, namespace, &result)
+	return result, err
+}
+
+// newSeedResult returns an empty SeedResult
+func newSeedResult() SeedResult {
+	return SeedResult{UserIDs: map[string]int{}, ZoneIDs: map[string]string{}, RecordIDs: map[string][]string{}}
+}
+
+// seed creates the seed data, adding the IDs of the created objects to
+// result
+func (c *SnitchDNSContainer) seed(ctx context.Context, data SeedData, namespace string, result *SeedResult) error {
+	if data.FixturePath != "" {
+		fixture, err := LoadSeedFixture(data.FixturePath)
+		if err != nil {
+			return err
+		}
+		data.Users = append(data.Users, fixture.Users...)
+		data.Zones = append(data.Zones, fixture.Zones...)
+	}
+
+	api := snitchdns.NewClient(c.GetAPIEndpoint(), c.APIKey)
+
+	if len(data.Users) > 0 {
+		if err := c.seedUsers(ctx, api, data.Users, result); err != nil {
+			return err
+		}
+	}
+
+	for _, zone := range data.Zones {
+		if err := c.seedZone(ctx, api, zone, namespace, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedUsers creates the users missing in the container. Users are global,
+// so tests sharing a container create them one at a time.
+func (c *SnitchDNSContainer) seedUsers(ctx context.Context, api *snitchdns.Client, users []SeedUser, result *SeedResult) error {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+
+	// Users survive ResetState, so a reused container may have them
+	existing, err := listUsers(ctx, api, result)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if _, ok := existing[user.Username]; ok {
+			continue
+		}
+		if err := c.addUser(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	_, err = listUsers(ctx, api, result)
+	return err
+}
+
+// listUsers adds the IDs of all users to result and returns them
+func listUsers(ctx context.Context, api *snitchdns.Client, result *SeedResult) (map[string]int, error) {
+	users, err := api.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seeded users: %w", err)
+	}
+	for _, user := range users {
+		result.UserIDs[user.Username] = user.ID
+	}
+	return result.UserIDs, nil
+}
+
+// addUser creates a user account with the SnitchDNS CLI
+func (c *SnitchDNSContainer) addUser(ctx context.Context, user SeedUser) error {
+	if user.Username == "" {
+		return fmt.Errorf("seed user without username")
+	}
+
+	admin := "no"
+	if user.Admin {
+		admin = "yes"
+	}
+	cmd := []string{
+		"/opt/snitchdns/venv.sh", "flask", "users", "add",
+		"--username", user.Username,
+		"--password", user.Password,
+		"--full_name", user.FullName,
+		"--email", user.Email,
+		"--active", "yes",
+		"--admin", admin,
+		"--auth", "local",
+	}
+
+	if c.Container == nil {
+		return ErrNoContainer
+	}
+
+	code, reader, err := c.Container.Exec(ctx, cmd)
+	if err != nil {
+		return fmt.Errorf("failed to create seed user %s: %w", user.Username, err)
+	}
+	if code != 0 {
+		output, _ := io.ReadAll(reader)
+		return fmt.Errorf("failed to create seed user %s: exit code %d: %s", user.Username, code, output)
+	}
+	return nil
+}
+
+// seedZone creates a zone and its records through the API, below the
+// namespace if one is given
+func (c *SnitchDNSContainer) seedZone(ctx context.Context, api *snitchdns.Client, zone SeedZone, namespace string, result *SeedResult) error {
+	request := snitchdns.CreateZoneRequest{
+		Domain:     NamespacedDomain(namespace, zone.Domain),
+		Active:     !zone.Inactive,
+		CatchAll:   zone.CatchAll,
+		Forwarding: zone.Forwarding,
+		Regex:      zone.Regex,
+		Tags:       snitchdns.NewZoneTags(zone.Tags),
+	}
+	if zone.Owner != "" {
+		userID, ok := result.UserIDs[zone.Owner]
+		if !ok {
+			return fmt.Errorf("seed zone %s: unknown owner %s", zone.Domain, zone.Owner)
+		}
+		request.UserID = userID
+	}
+
+	created, err := api.CreateZoneWithContext(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to create seed zone %s: %w", request.Domain, err)
+	}
+	zoneID := strconv.Itoa(created.ID)
+	result.ZoneIDs[zone.Domain] = zoneID
+
+	for i, record := range zone.Records {
+		if record.Class == "" {
+			record.Class = "IN"
+		}
+		if record.TTL == 0 {
+			record.TTL = 3600
+		}
+
+		createdRecord, err := api.CreateRecordWithContext(ctx, zoneID, snitchdns.CreateRecordRequest{
+			Active: !record.Inactive,
+			Class:  record.Class,
+			Type:   strings.ToUpper(record.Type),
+			TTL:    record.TTL,
+			Data:   record.Data,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create record %d of seed zone %s: %w", i, zone.Domain, err)
+		}
+		result.RecordIDs[zone.Domain] = append(result.RecordIDs[zone.Domain], strconv.Itoa(createdRecord.ID))
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package testcontainer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestLoadSeedFixture tests reading users, zones and records from YAML
+func TestLoadSeedFixture(t *testing.T) {
+	data, err := LoadSeedFixture("testdata/seed.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedUsers := []SeedUser{{Username: "tenant", Password: "tenant-password", FullName: "Tenant User", Email: "tenant@example.com"}}
+	if !reflect.DeepEqual(data.Users, expectedUsers) {
+		t.Errorf("Expected users %+v, got %+v", expectedUsers, data.Users)
+	}
+
+	if len(data.Zones) != 2 {
+		t.Fatalf("Expected 2 zones, got %d", len(data.Zones))
+	}
+	zone := data.Zones[0]
+	if zone.Domain != "seeded.example.com" || !reflect.DeepEqual(zone.Tags, []string{"seed", "fixture"}) || len(zone.Records) != 2 {
+		t.Errorf("Unexpected zone %+v", zone)
+	}
+	if record := zone.Records[1]; record.Type != "MX" || record.Data["priority"] != 10 || record.Data["hostname"] != "mail.seeded.example.com" {
+		t.Errorf("Unexpected record %+v", record)
+	}
+	if tenant := data.Zones[1]; tenant.Owner != "tenant" || !tenant.Inactive {
+		t.Errorf("Unexpected zone %+v", tenant)
+	}
+}
+
+// TestLoadSeedFixtureUnknownField tests that misspelled fields are rejected
+func TestLoadSeedFixtureUnknownField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.yaml")
+	if err := os.WriteFile(path, []byte("zones:\n  - domian: typo.example.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadSeedFixture(path); err == nil || !strings.Contains(err.Error(), "domian") {
+		t.Errorf("Expected an error naming the unknown field, got %v", err)
+	}
+}
+
+// TestSeedContainer tests that seeded zones and records exist after startup
+func TestSeedContainer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := NewSnitchDNSContainer(ctx, SnitchDNSContainerRequest{
+		ExposePorts: true,
+		SeedData:    &SeedData{FixturePath: "testdata/seed.yaml"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("Failed to terminate container: %v", err)
+		}
+	}()
+
+	if container.Seeded.ZoneIDs["seeded.example.com"] == "" || len(container.Seeded.RecordIDs["seeded.example.com"]) != 2 {
+		t.Errorf("Unexpected seed result %+v", container.Seeded)
+	}
+	if container.Seeded.UserIDs["tenant"] == 0 {
+		t.Errorf("Expected the seeded user to have an ID, got %+v", container.Seeded.UserIDs)
+	}
+
+	resp, err := container.Query(ctx, "seeded.example.com", "A")
+	if err != nil {
+		t.Fatalf("Failed to query seeded record: %v", err)
+	}
+	if len(resp.Answers) != 1 || resp.Answers[0].Data["address"] != "192.0.2.10" {
+		t.Errorf("Expected the seeded A record to be served, got %s %+v", resp.RCode, resp.Answers)
+	}
+}
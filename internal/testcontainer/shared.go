@@ -0,0 +1,133 @@
+package testcontainer
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	// DefaultSharedName is the name of the container returned by Shared,
+	// followed by the image tag for other images than the default one
+	DefaultSharedName = "snitchdns-test-shared"
+
+	// NamespacePrefix starts the namespaces returned by Namespace, so the
+	// zones of tests sharing a container are swept like other tf-acc-* zones
+	NamespacePrefix = "tf-acc-"
+
+	// maxNamespaceName is the length the test name is cut to in a namespace,
+	// keeping the namespace a valid DNS label
+	maxNamespaceName = 40
+)
+
+// invalidNamespaceChars are the characters replaced in test names to form
+// a DNS label
+var invalidNamespaceChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// shared are the containers of SharedVersion by SnitchDNS version, with the
+// number of references to each
+var shared struct {
+	sync.Mutex
+	containers map[string]*sharedContainer
+}
+
+// sharedContainer is a shared container and the number of references to it
+type sharedContainer struct {
+	container *SnitchDNSContainer
+	refs      int
+}
+
+// Shared returns the container shared by the tests of the process, starting
+// it on the first call. Each call takes a reference, which Terminate
+// releases; the last release closes a fake server.
+//
+// The container is reused by name, so the test binaries of all packages of
+// one `go test ./...` run share it too. It is not reset: tests sharing it
+// run in parallel and must keep to the domains of their Namespace. The
+// container is kept running after the last release, as other packages may
+// still use it, and removed by the Ryuk reaper when the run ends.
+func Shared(ctx context.Context) (*SnitchDNSContainer, error) {
+	return SharedVersion(ctx, "")
+}
+
+// SharedVersion returns the shared container of a SnitchDNS version, such
+// as "1.4.0", like Shared does for the default version. Each version is a
+// container of its own; see RunVersions.
+func SharedVersion(ctx context.Context, version string) (*SnitchDNSContainer, error) {
+	shared.Lock()
+	defer shared.Unlock()
+
+	entry, ok := shared.containers[version]
+	if !ok {
+		container, err := NewSnitchDNSContainer(ctx, SnitchDNSContainerRequest{
+			ExposePorts:      true,
+			Reuse:            true,
+			SnitchDNSVersion: version,
+			shared:           true,
+		})
+		if err != nil {
+			if version == "" {
+				return nil, fmt.Errorf("failed to start shared container: %w", err)
+			}
+			return nil, fmt.Errorf("failed to start shared container of SnitchDNS %s: %w", version, err)
+		}
+		container.shared = true
+		container.sharedVersion = version
+
+		if shared.containers == nil {
+			shared.containers = make(map[string]*sharedContainer)
+		}
+		entry = &sharedContainer{container: container}
+		shared.containers[version] = entry
+	}
+
+	entry.refs++
+	return entry.container, nil
+}
+
+// releaseShared drops a reference taken by SharedVersion
+func releaseShared(version string) error {
+	shared.Lock()
+	defer shared.Unlock()
+
+	entry, ok := shared.containers[version]
+	if !ok {
+		return nil
+	}
+	entry.refs--
+	if entry.refs > 0 {
+		return nil
+	}
+
+	delete(shared.containers, version)
+	if entry.container.fake != nil {
+		entry.container.fake.Close()
+	}
+	return nil
+}
+
+// Namespace returns a DNS label unique to the test, such as
+// tf-acc-testacczoneresource-4821937, for the domains of zones the test
+// creates in a shared container
+func Namespace(t testing.TB) string {
+	name := invalidNamespaceChars.ReplaceAllString(strings.ToLower(t.Name()), "-")
+	if len(name) > maxNamespaceName {
+		name = name[:maxNamespaceName]
+	}
+	name = strings.Trim(name, "-")
+	return fmt.Sprintf("%s%s-%07d", NamespacePrefix, name, rand.IntN(10000000))
+}
+
+// NamespacedDomain returns domain below the namespace, such as
+// tf-acc-testacczoneresource-4821937.seeded.example.com. Domains are
+// returned unchanged without a namespace.
+func NamespacedDomain(namespace, domain string) string {
+	if namespace == "" {
+		return domain
+	}
+	return namespace + "." + domain
+}
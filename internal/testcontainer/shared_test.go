@@ -0,0 +1,94 @@
+package testcontainer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestNamespace tests that namespaces are unique DNS labels carrying the
+// test name
+func TestNamespace(t *testing.T) {
+	first, second := Namespace(t), Namespace(t)
+	if first == second {
+		t.Errorf("Expected unique namespaces, got %s twice", first)
+	}
+	if !regexp.MustCompile(`^tf-acc-testnamespace-[0-9]{7}$`).MatchString(first) {
+		t.Errorf("Unexpected namespace %s", first)
+	}
+
+	t.Run("Sub Test/With_A_Very_Long_Name_That_Does_Not_Fit_In_A_Label", func(t *testing.T) {
+		namespace := Namespace(t)
+		if len(namespace) > 63 || strings.Contains(namespace, "--") || !strings.HasPrefix(namespace, NamespacePrefix+"testnamespace-sub-test-") {
+			t.Errorf("Unexpected namespace %s", namespace)
+		}
+	})
+
+	if domain := NamespacedDomain("tf-acc-x-1", "seeded.example.com"); domain != "tf-acc-x-1.seeded.example.com" {
+		t.Errorf("Unexpected domain %s", domain)
+	}
+	if domain := NamespacedDomain("", "seeded.example.com"); domain != "seeded.example.com" {
+		t.Errorf("Unexpected domain %s", domain)
+	}
+}
+
+// TestShared tests that the shared fake server is reference counted and
+// that parallel tests seed the same fixture in their namespaces
+func TestShared(t *testing.T) {
+	t.Setenv(FakeServerEnvVar, "1")
+	ctx := context.Background()
+
+	first, err := Shared(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, err := Shared(ctx)
+	if err != nil || second != first {
+		t.Fatalf("Expected the same container, got %p and %p, %v", first, second, err)
+	}
+	if err := first.ResetState(ctx); err == nil {
+		t.Error("Expected the shared container to refuse a reset")
+	}
+
+	zone := SeedZone{Domain: "seeded.example.com", Records: []SeedRecord{{Type: "A", Data: map[string]interface{}{"address": "192.0.2.10"}}}}
+	var wg sync.WaitGroup
+	results := make([]SeedResult, 8)
+	errs := make([]error, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = first.SeedNamespace(ctx, fmt.Sprintf("tf-acc-shared-%d", i), SeedData{Zones: []SeedZone{zone}})
+		}()
+	}
+	wg.Wait()
+	for i, result := range results {
+		if errs[i] != nil || result.ZoneIDs["seeded.example.com"] == "" || len(result.RecordIDs["seeded.example.com"]) != 1 {
+			t.Errorf("Unexpected seed result %+v, %v", result, errs[i])
+		}
+	}
+	if zones, _ := first.fake.API.ListZonesWithContext(ctx); len(zones) != 8 || !strings.HasPrefix(zones[0].Domain, "tf-acc-shared-") {
+		t.Errorf("Expected 8 namespaced zones, got %+v", zones)
+	}
+
+	// The fake server is closed with the last reference
+	if err := first.Terminate(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := first.fake.API.ListZonesWithContext(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := second.Terminate(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	third, err := Shared(ctx)
+	if err != nil || third == first {
+		t.Fatalf("Expected a new container after the last release, got %p, %v", third, err)
+	}
+	if err := third.Terminate(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -3,12 +3,20 @@ package testcontainer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
+	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/EinDev/snitchdns-tf/internal/fakeserver"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -20,13 +28,85 @@ const (
 	DefaultPassword = "password123"
 	HTTPPort        = "80/tcp"
 	DNSPort         = "2024/udp"
+
+	// DefaultReuseName is the name of the container reused when Reuse is set
+	// without a name
+	DefaultReuseName = "snitchdns-test-reuse"
+	// ReuseEnvVar enables Reuse for every container when set to a non-empty
+	// value, so local runs of the acceptance tests share one container
+	ReuseEnvVar = "SNITCHDNS_TESTCONTAINER_REUSE"
+
+	// ImageEnvVar sets ImageTag for containers requested without one
+	ImageEnvVar = "SNITCHDNS_TESTCONTAINER_IMAGE"
+	// VersionEnvVar sets SnitchDNSVersion for containers requested without
+	// one, so the acceptance tests can run against several releases
+	VersionEnvVar = "SNITCHDNS_VERSION"
+
+	// ContainerIPEnvVar makes the tests reach every container by its IP
+	// address instead of the ports mapped on the Docker host when set to a
+	// non-empty value, as if requested without ExposePorts
+	ContainerIPEnvVar = "SNITCHDNS_TESTCONTAINER_CONTAINER_IP"
+
+	// PodmanEnvVar starts containers with Podman when set to a non-empty
+	// value. Podman is also used when DOCKER_HOST names a podman.sock.
+	PodmanEnvVar = "SNITCHDNS_TESTCONTAINER_PODMAN"
+
+	// PullEnvVar sets PullImage for every container when set to a non-empty
+	// value
+	PullEnvVar = "SNITCHDNS_TESTCONTAINER_PULL"
+
+	// FakeServerEnvVar makes NewSnitchDNSContainer start an in-memory
+	// fakeserver instead of a container when set to a non-empty value, so
+	// the acceptance tests of zones and records run without Docker
+	FakeServerEnvVar = "SNITCHDNS_FAKESERVER"
+
+	// imageRepository is the repository of images built from the Dockerfile
+	// for a SnitchDNS version
+	imageRepository = "snitchdns-test"
 )
 
+// ErrNoContainer is returned by the methods needing a container, such as
+// DNS queries and seeding users, when the API is served by a fakeserver
+var ErrNoContainer = errors.New("no SnitchDNS container: the API is served by the fake server")
+
+// invalidTagChars are the characters Docker does not allow in image tags
+var invalidTagChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
 // SnitchDNSContainer represents a SnitchDNS test container
 type SnitchDNSContainer struct {
 	Container testcontainers.Container
 	HTTPHost  string
 	APIKey    string
+
+	// containerIP is the address the container is reached at when its
+	// ports are not exposed; empty for mapped ports
+	containerIP string
+
+	// Seeded holds the IDs of the objects created from seed data
+	Seeded SeedResult
+
+	// reused is set for containers kept running after Terminate
+	reused bool
+
+	// shared is set for the containers returned by SharedVersion;
+	// Terminate releases a reference to it
+	shared bool
+
+	// sharedVersion is the SnitchDNS version a shared container was
+	// requested for
+	sharedVersion string
+
+	// version is the SnitchDNS version the image was built from; empty for
+	// the default branch, prebuilt images and the fake server
+	version string
+
+	// usersMu serializes seeding users, which tests sharing the container
+	// may do in parallel
+	usersMu sync.Mutex
+
+	// fake serves the API instead of a container when FakeServerEnvVar is
+	// set; Container is nil then
+	fake *fakeserver.Server
 }
 
 // SnitchDNSContainerRequest configures the SnitchDNS container
@@ -35,16 +115,82 @@ type SnitchDNSContainerRequest struct {
 	// Defaults to "./testcontainer"
 	DockerfilePath string
 
-	// ExposePorts determines if container ports should be exposed to host
-	// Set to false in CI environments where network access is direct
+	// ExposePorts reaches the container through the ports mapped on the
+	// Docker host, which is the local machine or the host named by
+	// DOCKER_HOST. Set to false in CI environments where the tests run in a
+	// container on the same network, to reach the container by its IP
+	// address instead. ContainerIPEnvVar clears it for every container.
 	ExposePorts bool
+
+	// Podman starts the container with Podman instead of Docker; see
+	// PodmanEnvVar
+	Podman bool
+
+	// PullImage pulls ImageTag from its registry before starting it, even
+	// when it is present locally, so moving tags such as "latest" are
+	// current
+	PullImage bool
+
+	// SeedData, if set, is created after the container started; see
+	// SnitchDNSContainer.Seed
+	SeedData *SeedData
+
+	// Reuse starts the container with a fixed name, or reuses the running
+	// container of that name, and keeps it running on Terminate. A reused
+	// container is reset with ResetState before it is returned. Containers
+	// are only kept across test runs with the Ryuk reaper disabled
+	// (TESTCONTAINERS_RYUK_DISABLED=true).
+	Reuse bool
+
+	// Name is the name of the reused container. Defaults to
+	// DefaultReuseName, followed by the image tag for other images than the
+	// default one
+	Name string
+
+	// ImageTag is a prebuilt image, such as "snitchdns-test:latest" or an
+	// image in a registry like "ghcr.io/example/snitchdns-test:1.4.0",
+	// started instead of building the Dockerfile. The image is pulled when
+	// it is not present on the Docker host.
+	ImageTag string
+
+	// SnitchDNSVersion is the git ref of SnitchDNS, such as a release tag,
+	// the image is built from; it is passed to the Dockerfile as the
+	// SNITCHDNS_VERSION build arg and the image is tagged
+	// snitchdns-test:<version>. Defaults to the default branch. Ignored with
+	// ImageTag.
+	SnitchDNSVersion string
+
+	// shared starts the container of Shared, which is named
+	// DefaultSharedName and not reset when reused
+	shared bool
 }
 
 // NewSnitchDNSContainer creates and starts a new SnitchDNS container
 func NewSnitchDNSContainer(ctx context.Context, req SnitchDNSContainerRequest) (*SnitchDNSContainer, error) {
+	if os.Getenv(FakeServerEnvVar) != "" {
+		return newFakeServer(ctx, req)
+	}
 	if req.DockerfilePath == "" {
 		req.DockerfilePath = "../../testcontainer"
 	}
+	if os.Getenv(ReuseEnvVar) != "" {
+		req.Reuse = true
+	}
+	if os.Getenv(ContainerIPEnvVar) != "" {
+		req.ExposePorts = false
+	}
+	if os.Getenv(PodmanEnvVar) != "" {
+		req.Podman = true
+	}
+	if os.Getenv(PullEnvVar) != "" {
+		req.PullImage = true
+	}
+	if req.ImageTag == "" {
+		req.ImageTag = os.Getenv(ImageEnvVar)
+	}
+	if req.SnitchDNSVersion == "" {
+		req.SnitchDNSVersion = os.Getenv(VersionEnvVar)
+	}
 
 	// Convert to absolute path from the current package directory
 	absPath, err := filepath.Abs(req.DockerfilePath)
@@ -62,44 +208,160 @@ func NewSnitchDNSContainer(ctx context.Context, req SnitchDNSContainerRequest) (
 			PrintBuildLog: false, // Reduce noise in test output
 		},
 		ExposedPorts: []string{HTTPPort, DNSPort},
-		WaitingFor: wait.ForAll(
-			wait.ForLog("Starting Flask web application on port 80").WithStartupTimeout(120*time.Second),
-			wait.ForHTTP("/").WithPort(HTTPPort).WithStartupTimeout(120*time.Second),
-		),
+		WaitingFor:   waitStrategy(req.ExposePorts),
+	}
+
+	// Containers of other images than the default one are reused separately
+	reuseSuffix := ""
+	switch {
+	case req.ImageTag != "":
+		containerReq.FromDockerfile = testcontainers.FromDockerfile{}
+		containerReq.Image = req.ImageTag
+		containerReq.AlwaysPullImage = req.PullImage
+		reuseSuffix = imageTag(req.ImageTag)
+	case req.SnitchDNSVersion != "":
+		version := req.SnitchDNSVersion
+		containerReq.FromDockerfile.Repo = imageRepository
+		containerReq.FromDockerfile.Tag = imageTag(version)
+		containerReq.FromDockerfile.BuildArgs = map[string]*string{"SNITCHDNS_VERSION": &version}
+		reuseSuffix = imageTag(version)
+	}
+
+	if req.Reuse && req.Name == "" {
+		req.Name = DefaultReuseName
+		if req.shared {
+			req.Name = DefaultSharedName
+		}
+		if reuseSuffix != "" {
+			req.Name += "-" + reuseSuffix
+		}
+	}
+	containerReq.Name = req.Name
+
+	providerType := testcontainers.ProviderDefault
+	if req.Podman {
+		providerType = testcontainers.ProviderPodman
 	}
 
 	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: containerReq,
+		ProviderType:     providerType,
 		Started:          true,
+		Reuse:            req.Reuse,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to start container: %w", err)
 	}
 
-	// Get the host and port
-	host, err := container.Host(ctx)
+	httpHost, containerIP, err := httpHost(ctx, container, req.ExposePorts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get container host: %w", err)
+		return nil, err
 	}
 
-	port, err := container.MappedPort(ctx, HTTPPort)
+	// Extract API key from container logs
+	apiKey, err := extractAPIKey(ctx, container)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get container port: %w", err)
+		return nil, fmt.Errorf("failed to extract API key: %w", err)
 	}
 
-	httpHost := fmt.Sprintf("http://%s:%s", host, port.Port())
+	snitch := &SnitchDNSContainer{
+		Container:   container,
+		HTTPHost:    httpHost,
+		APIKey:      apiKey,
+		containerIP: containerIP,
+		reused:      req.Reuse,
+	}
+	if req.ImageTag == "" {
+		snitch.version = req.SnitchDNSVersion
+	}
 
-	// Extract API key from container logs
-	apiKey, err := extractAPIKey(ctx, container)
+	// A reused container may hold zones of earlier tests. The shared
+	// container is used by other tests at the same time.
+	if req.Reuse && !req.shared {
+		if err := snitch.ResetState(ctx); err != nil {
+			return nil, fmt.Errorf("failed to reset reused container: %w", err)
+		}
+	}
+
+	if req.SeedData != nil {
+		if err := snitch.Seed(ctx, *req.SeedData); err != nil {
+			_ = container.Terminate(ctx)
+			return nil, fmt.Errorf("failed to seed container: %w", err)
+		}
+	}
+
+	return snitch, nil
+}
+
+// newFakeServer starts a fakeserver in place of a container and seeds it
+func newFakeServer(ctx context.Context, req SnitchDNSContainerRequest) (*SnitchDNSContainer, error) {
+	fake := fakeserver.New()
+	snitch := &SnitchDNSContainer{
+		HTTPHost: fake.URL,
+		APIKey:   fakeserver.APIKey,
+		fake:     fake,
+	}
+
+	if req.SeedData != nil {
+		if err := snitch.Seed(ctx, *req.SeedData); err != nil {
+			fake.Close()
+			return nil, fmt.Errorf("failed to seed fake server: %w", err)
+		}
+	}
+	return snitch, nil
+}
+
+// waitStrategy waits for the web application to start. Its HTTP port is
+// only probed when it is mapped on the Docker host, as the container IP may
+// not be reachable from there.
+func waitStrategy(exposePorts bool) wait.Strategy {
+	started := wait.ForLog("Starting Flask web application on port 80").WithStartupTimeout(120 * time.Second)
+	if !exposePorts {
+		return started
+	}
+	return wait.ForAll(started, wait.ForHTTP("/").WithPort(HTTPPort).WithStartupTimeout(120*time.Second))
+}
+
+// httpHost returns the URL of the web application: the port mapped on the
+// Docker host, whose address testcontainers takes from DOCKER_HOST for
+// remote hosts, or port 80 of the container IP when ports are not exposed.
+// The container IP is returned too in that case.
+func httpHost(ctx context.Context, container testcontainers.Container, exposePorts bool) (string, string, error) {
+	if !exposePorts {
+		ip, err := container.ContainerIP(ctx)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get container IP: %w", err)
+		}
+		if ip == "" {
+			return "", "", errors.New("failed to get container IP: the container has no IP address on its network")
+		}
+		return "http://" + net.JoinHostPort(ip, portNumber(HTTPPort)), ip, nil
+	}
+
+	host, err := container.Host(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract API key: %w", err)
+		return "", "", fmt.Errorf("failed to get container host: %w", err)
 	}
+	port, err := container.MappedPort(ctx, HTTPPort)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get container port: %w", err)
+	}
+	return "http://" + net.JoinHostPort(host, port.Port()), "", nil
+}
 
-	return &SnitchDNSContainer{
-		Container: container,
-		HTTPHost:  httpHost,
-		APIKey:    apiKey,
-	}, nil
+// portNumber returns the number of a port such as "80/tcp"
+func portNumber(port string) string {
+	number, _, _ := strings.Cut(port, "/")
+	return number
+}
+
+// imageTag converts an image reference or git ref to a valid image tag
+func imageTag(ref string) string {
+	tag := strings.TrimLeft(invalidTagChars.ReplaceAllString(ref, "-"), ".-")
+	if len(tag) > 128 {
+		tag = tag[:128]
+	}
+	return tag
 }
 
 // extractAPIKey reads the API key from the container
@@ -154,21 +416,68 @@ func extractAPIKey(ctx context.Context, container testcontainers.Container) (str
 	return apiKey, nil
 }
 
-// Terminate stops and removes the container
+// Version returns the SnitchDNS version the container was built from, or
+// "" for the default branch, prebuilt images and the fake server
+func (c *SnitchDNSContainer) Version() string {
+	return c.version
+}
+
+// Terminate stops and removes the container. Reused containers are kept
+// running for the next test, and the shared container until its last
+// reference is released.
 func (c *SnitchDNSContainer) Terminate(ctx context.Context) error {
-	if c.Container != nil {
+	if c.shared {
+		return releaseShared(c.sharedVersion)
+	}
+	if c.fake != nil {
+		c.fake.Close()
+		return nil
+	}
+	if c.Container != nil && !c.reused {
 		return c.Container.Terminate(ctx)
 	}
 	return nil
 }
 
+// ResetState deletes all zones and their records, so the next test starts
+// from an empty server. Users are kept. The shared container is not reset,
+// as other tests use it at the same time.
+func (c *SnitchDNSContainer) ResetState(ctx context.Context) error {
+	if c.shared {
+		return errors.New("the shared container cannot be reset: tests sharing it keep to their namespace")
+	}
+
+	api := snitchdns.NewClient(c.GetAPIEndpoint(), c.APIKey)
+
+	zones, err := api.ListZonesWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list zones: %w", err)
+	}
+	for _, zone := range zones {
+		if err := api.DeleteZoneWithContext(ctx, strconv.Itoa(zone.ID)); err != nil {
+			return fmt.Errorf("failed to delete zone %s: %w", zone.Domain, err)
+		}
+	}
+
+	c.Seeded.ZoneIDs = map[string]string{}
+	c.Seeded.RecordIDs = map[string][]string{}
+	return nil
+}
+
 // GetAPIEndpoint returns the full API endpoint URL
 func (c *SnitchDNSContainer) GetAPIEndpoint() string {
 	return c.HTTPHost + "/api/v1"
 }
 
-// GetDNSPort returns the mapped DNS port
+// GetDNSPort returns the mapped DNS port, or the DNS port of the container
+// when it is reached by its IP address
 func (c *SnitchDNSContainer) GetDNSPort(ctx context.Context) (string, error) {
+	if c.Container == nil {
+		return "", ErrNoContainer
+	}
+	if c.containerIP != "" {
+		return portNumber(DNSPort), nil
+	}
 	port, err := c.Container.MappedPort(ctx, DNSPort)
 	if err != nil {
 		return "", err
@@ -176,8 +485,31 @@ func (c *SnitchDNSContainer) GetDNSPort(ctx context.Context) (string, error) {
 	return port.Port(), nil
 }
 
+// DNSAddress returns the host and port the DNS server of the container is
+// reached at, such as localhost:32768
+func (c *SnitchDNSContainer) DNSAddress(ctx context.Context) (string, error) {
+	if c.Container == nil {
+		return "", ErrNoContainer
+	}
+	port, err := c.GetDNSPort(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get DNS port: %w", err)
+	}
+
+	host := c.containerIP
+	if host == "" {
+		if host, err = c.Container.Host(ctx); err != nil {
+			return "", fmt.Errorf("failed to get container host: %w", err)
+		}
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
 // Logs returns the container logs
 func (c *SnitchDNSContainer) Logs(ctx context.Context) (string, error) {
+	if c.Container == nil {
+		return "", ErrNoContainer
+	}
 	reader, err := c.Container.Logs(ctx)
 	if err != nil {
 		return "", err
@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
 )
 
 func TestSnitchDNSContainer(t *testing.T) {
@@ -48,6 +52,102 @@ func TestSnitchDNSContainer(t *testing.T) {
 	})
 }
 
+func TestSnitchDNSContainerReuse(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	req := SnitchDNSContainerRequest{
+		ExposePorts: true,
+		Reuse:       true,
+		Name:        "snitchdns-test-reuse-test",
+		SeedData:    &SeedData{FixturePath: "testdata/seed.yaml"},
+	}
+
+	first, err := NewSnitchDNSContainer(ctx, req)
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer func() {
+		// Reused containers are kept by Terminate
+		if err := first.Container.Terminate(ctx); err != nil {
+			t.Logf("Failed to terminate container: %v", err)
+		}
+	}()
+	if err := first.Terminate(ctx); err != nil {
+		t.Fatalf("Failed to release container: %v", err)
+	}
+
+	second, err := NewSnitchDNSContainer(ctx, req)
+	if err != nil {
+		t.Fatalf("Failed to reuse container: %v", err)
+	}
+	if second.Container.GetContainerID() != first.Container.GetContainerID() {
+		t.Errorf("Expected container %s to be reused, got %s", first.Container.GetContainerID(), second.Container.GetContainerID())
+	}
+	// Seeding fails on zones left from the first start unless they were reset
+	if second.Seeded.ZoneIDs["seeded.example.com"] == "" {
+		t.Errorf("Expected the seeded zone to be recreated, got %+v", second.Seeded)
+	}
+
+	if err := second.ResetState(ctx); err != nil {
+		t.Fatalf("Failed to reset state: %v", err)
+	}
+	zones, err := snitchdns.NewClient(second.GetAPIEndpoint(), second.APIKey).ListZonesWithContext(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list zones: %v", err)
+	}
+	if len(zones) != 0 {
+		t.Errorf("Expected no zones after reset, got %d", len(zones))
+	}
+}
+
+// TestSnitchDNSContainerIP tests reaching the API and DNS server by the
+// container IP, as CI jobs running in a container on the Docker network do.
+// It needs the container network to be routable from the test host.
+func TestSnitchDNSContainerIP(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	container, err := NewSnitchDNSContainer(ctx, SnitchDNSContainerRequest{})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("Failed to terminate container: %v", err)
+		}
+	}()
+
+	if container.containerIP == "" || !strings.Contains(container.HTTPHost, container.containerIP+":80") {
+		t.Fatalf("Expected the API to be reached by the container IP, got %s", container.HTTPHost)
+	}
+	testAPIAuthentication(t, container)
+
+	address, err := container.DNSAddress(ctx)
+	if err != nil || address != net.JoinHostPort(container.containerIP, "2024") {
+		t.Errorf("Expected the DNS port of the container IP, got %s, %v", address, err)
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	tests := map[string]string{
+		"v1.2.0":                    "v1.2.0",
+		"release/1.2":               "release-1.2",
+		"ghcr.io/example/snitch:v1": "ghcr.io-example-snitch-v1",
+		".hidden":                   "hidden",
+	}
+
+	for ref, expected := range tests {
+		if tag := imageTag(ref); tag != expected {
+			t.Errorf("imageTag(%q) = %q, expected %q", ref, tag, expected)
+		}
+	}
+}
+
 func testAPIAuthentication(t *testing.T, container *SnitchDNSContainer) {
 	client := &http.Client{Timeout: 10 * time.Second}
 
@@ -0,0 +1,65 @@
+package testcontainer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// testAPIKeyName is the name of the API keys created by CreateAPIKey
+const testAPIKeyName = "testcontainer"
+
+// CreateUser creates an active user account with the SnitchDNS CLI, since
+// the API cannot create users, and returns its ID. Tests use it with
+// CreateAPIKey to act as a non-admin user or as several tenants.
+func (c *SnitchDNSContainer) CreateUser(ctx context.Context, username, password string, admin bool) (int, error) {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+
+	err := c.addUser(ctx, SeedUser{
+		Username: username,
+		Password: password,
+		FullName: username,
+		Email:    username + "@example.com",
+		Admin:    admin,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return c.userID(ctx, username)
+}
+
+// CreateAPIKey creates an enabled API key for a user through the API, using
+// the test admin's key, and returns its secret
+func (c *SnitchDNSContainer) CreateAPIKey(ctx context.Context, username string) (string, error) {
+	userID, err := c.userID(ctx, username)
+	if err != nil {
+		return "", err
+	}
+
+	api := snitchdns.NewClient(c.GetAPIEndpoint(), c.APIKey)
+	key, err := api.CreateAPIKey(ctx, snitchdns.CreateAPIKeyRequest{Name: testAPIKeyName, UserID: userID})
+	if err != nil {
+		return "", fmt.Errorf("failed to create API key for %s: %w", username, err)
+	}
+	if key.Key == "" {
+		return "", fmt.Errorf("failed to create API key for %s: no secret returned", username)
+	}
+	return key.Key, nil
+}
+
+// userID looks up the ID of a user by username
+func (c *SnitchDNSContainer) userID(ctx context.Context, username string) (int, error) {
+	api := snitchdns.NewClient(c.GetAPIEndpoint(), c.APIKey)
+	users, err := api.ListUsers(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	for _, user := range users {
+		if user.Username == username {
+			return user.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("user %s not found", username)
+}
@@ -0,0 +1,52 @@
+package testcontainer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// TestCreateUserAPIKey tests acting as a non-admin user with a key created
+// for it
+func TestCreateUserAPIKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	container, err := NewSnitchDNSContainer(ctx, SnitchDNSContainerRequest{ExposePorts: true})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("Failed to terminate container: %v", err)
+		}
+	}()
+
+	userID, err := container.CreateUser(ctx, "tenant", "tenant-password", false)
+	if err != nil || userID == 0 {
+		t.Fatalf("Failed to create user: %d, %v", userID, err)
+	}
+	key, err := container.CreateAPIKey(ctx, "tenant")
+	if err != nil {
+		t.Fatalf("Failed to create API key: %v", err)
+	}
+
+	tenant := snitchdns.NewClient(container.GetAPIEndpoint(), key)
+	zone, err := tenant.CreateZoneWithContext(ctx, snitchdns.CreateZoneRequest{Domain: "tenant.example.com", Active: true})
+	if err != nil || zone.UserID != userID {
+		t.Errorf("Expected the zone to be owned by the tenant, got %+v, %v", zone, err)
+	}
+
+	// Listing users needs an admin key
+	if _, err := tenant.ListUsers(ctx); snitchdns.StatusCode(err) != http.StatusUnauthorized && snitchdns.StatusCode(err) != http.StatusForbidden {
+		t.Errorf("Expected the tenant to be refused the user list, got %v", err)
+	}
+
+	if _, err := container.CreateAPIKey(ctx, "nobody"); err == nil {
+		t.Error("Expected an error for an unknown user")
+	}
+}
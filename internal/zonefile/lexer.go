@@ -0,0 +1,159 @@
+package zonefile
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// token is a field of a zone file entry
+type token struct {
+	text string
+	// quoted is true for character strings in double quotes
+	quoted bool
+}
+
+// logicalLine is a zone file entry, which parentheses may spread over
+// several physical lines
+type logicalLine struct {
+	// number is the physical line the entry starts on
+	number int
+	// blankOwner is true when the entry starts with whitespace, so it
+	// belongs to the previous owner name
+	blankOwner bool
+	tokens     []token
+}
+
+// tokenize splits zone file text into entries, removing comments and empty
+// lines. Escapes in the \X and \DDD forms are decoded.
+func tokenize(text string) ([]logicalLine, error) {
+	var (
+		lines   []logicalLine
+		current logicalLine
+		field   strings.Builder
+		inField bool
+		quoted  bool
+		depth   int
+	)
+
+	lineNumber := 1
+	atLineStart := true
+	current.number = 1
+
+	flushField := func() {
+		if inField {
+			current.tokens = append(current.tokens, token{text: field.String(), quoted: quoted})
+		}
+		field.Reset()
+		inField = false
+		quoted = false
+	}
+	flushLine := func() {
+		if len(current.tokens) > 0 {
+			lines = append(lines, current)
+		}
+		current = logicalLine{number: lineNumber}
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		if quoted {
+			switch c {
+			case '"':
+				flushField()
+			case '\\':
+				decoded, width, err := unescape(runes[i+1:])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+				}
+				field.WriteString(decoded)
+				i += width
+			case '\n':
+				return nil, fmt.Errorf("line %d: unterminated quoted string", lineNumber)
+			default:
+				field.WriteRune(c)
+			}
+			continue
+		}
+
+		switch c {
+		case '\n':
+			flushField()
+			lineNumber++
+			if depth == 0 {
+				flushLine()
+			}
+			atLineStart = true
+			continue
+		case ' ', '\t', '\r':
+			if atLineStart && depth == 0 && len(current.tokens) == 0 {
+				current.blankOwner = true
+			}
+			flushField()
+		case ';':
+			flushField()
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		case '(':
+			flushField()
+			depth++
+		case ')':
+			flushField()
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parenthesis", lineNumber)
+			}
+			depth--
+		case '"':
+			flushField()
+			inField = true
+			quoted = true
+		case '\\':
+			decoded, width, err := unescape(runes[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			field.WriteString(decoded)
+			inField = true
+			i += width
+		default:
+			field.WriteRune(c)
+			inField = true
+		}
+		atLineStart = false
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("line %d: unterminated quoted string", lineNumber)
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parenthesis", current.number)
+	}
+	flushField()
+	flushLine()
+
+	return lines, nil
+}
+
+// unescape decodes the escape following a backslash and returns the number
+// of runes consumed
+func unescape(rest []rune) (string, int, error) {
+	if len(rest) == 0 {
+		return "", 0, errors.New("trailing backslash")
+	}
+	if len(rest) >= 3 && isDigit(rest[0]) && isDigit(rest[1]) && isDigit(rest[2]) {
+		value := int(rest[0]-'0')*100 + int(rest[1]-'0')*10 + int(rest[2]-'0')
+		if value > 255 {
+			return "", 0, fmt.Errorf("invalid escape \\%s", string(rest[:3]))
+		}
+		return string([]byte{byte(value)}), 3, nil
+	}
+	return string(rest[0]), 1, nil
+}
+
+// isDigit reports whether c is an ASCII digit
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
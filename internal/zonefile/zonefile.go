@@ -0,0 +1,452 @@
+// Package zonefile parses RFC 1035 master files, such as BIND zone files,
+// into records in the SnitchDNS data format, and renders records back into
+// that format.
+package zonefile
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Record is a resource record of a zone file. Data uses the same field names
+// as the data attribute of SnitchDNS records, e.g. "address" for A records.
+type Record struct {
+	// Name is the fully qualified owner name with a trailing dot
+	Name  string
+	Type  string
+	Class string
+	TTL   uint32
+	Data  map[string]string
+}
+
+// Result is the outcome of parsing a zone file
+type Result struct {
+	Records []Record
+	// Skipped lists records whose type cannot be mapped to the SnitchDNS
+	// data format, as "name type", one entry per skipped record
+	Skipped []string
+}
+
+// classes are the class mnemonics accepted in a zone file
+var classes = map[string]bool{"IN": true, "CH": true, "HS": true, "CS": true}
+
+// Parse parses the zone file text. origin is the initial origin for relative
+// names and may be empty when the file starts with an $ORIGIN directive.
+// $INCLUDE and $GENERATE directives are not supported.
+func Parse(text, origin string) (*Result, error) {
+	p := parser{
+		origin: absoluteOrigin(origin),
+		class:  "IN",
+	}
+
+	lines, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for _, line := range lines {
+		record, err := p.parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		if record == nil {
+			continue
+		}
+		if record.Data == nil {
+			result.Skipped = append(result.Skipped, record.Name+" "+record.Type)
+			continue
+		}
+		result.Records = append(result.Records, *record)
+	}
+
+	return result, nil
+}
+
+// parser holds the state carried between the entries of a zone file
+type parser struct {
+	origin     string
+	owner      string
+	class      string
+	defaultTTL *uint32
+	lastTTL    *uint32
+}
+
+// parseLine handles a directive or a resource record. It returns nil for
+// directives.
+func (p *parser) parseLine(line logicalLine) (*Record, error) {
+	tokens := line.tokens
+
+	if !line.blankOwner && !tokens[0].quoted && strings.HasPrefix(tokens[0].text, "$") {
+		return nil, p.parseDirective(tokens)
+	}
+
+	if line.blankOwner {
+		if p.owner == "" {
+			return nil, errors.New("record without owner name and no previous owner")
+		}
+	} else {
+		owner, err := p.absoluteName(tokens[0].text)
+		if err != nil {
+			return nil, err
+		}
+		p.owner = owner
+		tokens = tokens[1:]
+	}
+
+	var ttl *uint32
+	class := ""
+	for len(tokens) > 0 && (ttl == nil || class == "") {
+		text := tokens[0].text
+		if class == "" && classes[strings.ToUpper(text)] {
+			class = strings.ToUpper(text)
+		} else if value, err := parseTTL(text); ttl == nil && err == nil {
+			ttl = &value
+		} else {
+			break
+		}
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("record for %s has no type", p.owner)
+	}
+	recordType := strings.ToUpper(tokens[0].text)
+	rdata := tokens[1:]
+
+	if class == "" {
+		class = p.class
+	}
+	p.class = class
+
+	switch {
+	case ttl != nil:
+		p.lastTTL = ttl
+	case p.defaultTTL != nil:
+		ttl = p.defaultTTL
+	case p.lastTTL != nil:
+		ttl = p.lastTTL
+	default:
+		return nil, fmt.Errorf("record %s %s has no TTL and there is no $TTL directive", p.owner, recordType)
+	}
+
+	data, err := p.parseData(recordType, rdata)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", p.owner, recordType, err)
+	}
+
+	return &Record{
+		Name:  p.owner,
+		Type:  recordType,
+		Class: class,
+		TTL:   *ttl,
+		Data:  data,
+	}, nil
+}
+
+// parseDirective handles $ORIGIN and $TTL
+func (p *parser) parseDirective(tokens []token) error {
+	directive := strings.ToUpper(tokens[0].text)
+	switch directive {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return errors.New("$ORIGIN takes one domain name")
+		}
+		origin, err := p.absoluteName(tokens[1].text)
+		if err != nil {
+			return err
+		}
+		p.origin = origin
+	case "$TTL":
+		if len(tokens) != 2 {
+			return errors.New("$TTL takes one TTL value")
+		}
+		ttl, err := parseTTL(tokens[1].text)
+		if err != nil {
+			return err
+		}
+		p.defaultTTL = &ttl
+	default:
+		return fmt.Errorf("unsupported directive %s", tokens[0].text)
+	}
+	return nil
+}
+
+// parseData maps the rdata of a record to the SnitchDNS data format. It
+// returns nil data for types SnitchDNS data cannot represent.
+func (p *parser) parseData(recordType string, rdata []token) (map[string]string, error) {
+	texts := make([]string, len(rdata))
+	for i, t := range rdata {
+		texts[i] = t.text
+	}
+
+	switch recordType {
+	case "A", "AAAA":
+		if err := wantFields(texts, "address"); err != nil {
+			return nil, err
+		}
+		ip := net.ParseIP(texts[0])
+		if ip == nil || (recordType == "A") != (ip.To4() != nil) {
+			return nil, fmt.Errorf("invalid address %q", texts[0])
+		}
+		return map[string]string{"address": ip.String()}, nil
+	case "CNAME", "NS", "PTR":
+		if err := wantFields(texts, "name"); err != nil {
+			return nil, err
+		}
+		name, err := p.absoluteName(texts[0])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"name": name}, nil
+	case "MX":
+		if err := wantFields(texts, "priority", "hostname"); err != nil {
+			return nil, err
+		}
+		if err := checkUint(texts[0], 16); err != nil {
+			return nil, err
+		}
+		hostname, err := p.absoluteName(texts[1])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"priority": texts[0], "hostname": hostname}, nil
+	case "TXT", "SPF":
+		if len(texts) == 0 {
+			return nil, errors.New("missing text")
+		}
+		return map[string]string{"data": strings.Join(texts, "")}, nil
+	case "SRV":
+		if err := wantFields(texts, "priority", "weight", "port", "target"); err != nil {
+			return nil, err
+		}
+		for _, value := range texts[:3] {
+			if err := checkUint(value, 16); err != nil {
+				return nil, err
+			}
+		}
+		target, err := p.absoluteName(texts[3])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"priority": texts[0],
+			"weight":   texts[1],
+			"port":     texts[2],
+			"target":   target,
+		}, nil
+	case "CAA":
+		if err := wantFields(texts, "flags", "tag", "value"); err != nil {
+			return nil, err
+		}
+		if err := checkUint(texts[0], 8); err != nil {
+			return nil, err
+		}
+		return map[string]string{"flags": texts[0], "tag": texts[1], "value": texts[2]}, nil
+	case "SOA":
+		if err := wantFields(texts, "mname", "rname", "serial", "refresh", "retry", "expire", "minimum"); err != nil {
+			return nil, err
+		}
+		data := map[string]string{}
+		for i, field := range []string{"mname", "rname"} {
+			name, err := p.absoluteName(texts[i])
+			if err != nil {
+				return nil, err
+			}
+			data[field] = name
+		}
+		if err := checkUint(texts[2], 32); err != nil {
+			return nil, err
+		}
+		data["serial"] = texts[2]
+		// Timers may use TTL units such as 1h
+		for i, field := range []string{"refresh", "retry", "expire", "minimum"} {
+			value, err := parseTTL(texts[3+i])
+			if err != nil {
+				return nil, err
+			}
+			data[field] = strconv.FormatUint(uint64(value), 10)
+		}
+		return data, nil
+	default:
+		return nil, nil
+	}
+}
+
+// absoluteName resolves a name against the current origin. "@" is the
+// origin itself.
+func (p *parser) absoluteName(name string) (string, error) {
+	if name == "@" {
+		if p.origin == "" {
+			return "", errors.New("@ used without an origin")
+		}
+		return p.origin, nil
+	}
+	if strings.HasSuffix(name, ".") {
+		return name, nil
+	}
+	if p.origin == "" {
+		return "", fmt.Errorf("relative name %s used without an origin", name)
+	}
+	if p.origin == "." {
+		return name + ".", nil
+	}
+	return name + "." + p.origin, nil
+}
+
+// absoluteOrigin returns origin with a trailing dot, or "" when unset
+func absoluteOrigin(origin string) string {
+	if origin == "" || strings.HasSuffix(origin, ".") {
+		return origin
+	}
+	return origin + "."
+}
+
+// wantFields checks that rdata has one value per field
+func wantFields(values []string, fields ...string) error {
+	if len(values) != len(fields) {
+		return fmt.Errorf("expected %d values (%s), got %d", len(fields), strings.Join(fields, ", "), len(values))
+	}
+	return nil
+}
+
+// checkUint checks that value is an unsigned integer of the given bit size
+func checkUint(value string, bits int) error {
+	if _, err := strconv.ParseUint(value, 10, bits); err != nil {
+		return fmt.Errorf("invalid number %q", value)
+	}
+	return nil
+}
+
+// parseTTL parses a TTL in seconds, or with the BIND units s, m, h, d and w
+// such as 1h30m
+func parseTTL(text string) (uint32, error) {
+	if value, err := strconv.ParseUint(text, 10, 32); err == nil {
+		return uint32(value), nil
+	}
+
+	var total uint64
+	number := ""
+	for _, c := range strings.ToLower(text) {
+		if c >= '0' && c <= '9' {
+			number += string(c)
+			continue
+		}
+
+		unit := map[rune]uint64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}[c]
+		if unit == 0 || number == "" {
+			return 0, fmt.Errorf("invalid TTL %q", text)
+		}
+		value, _ := strconv.ParseUint(number, 10, 32)
+		total += value * unit
+		number = ""
+	}
+	if text == "" || number != "" || total > 1<<32-1 {
+		return 0, fmt.Errorf("invalid TTL %q", text)
+	}
+	return uint32(total), nil
+}
+
+// Format renders records as a zone file for origin. Names are written
+// relative to origin where possible. Records of types SnitchDNS data cannot
+// represent are written as comments.
+func Format(origin string, records []Record) string {
+	origin = absoluteOrigin(origin)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s\n", origin)
+	for _, record := range records {
+		rdata, ok := formatData(record.Type, record.Data)
+		if !ok {
+			fmt.Fprintf(&b, "; %s record with data %s cannot be represented\n", record.Type, formatMap(record.Data))
+			continue
+		}
+		fmt.Fprintf(&b, "%s\t%d\t%s\t%s\t%s\n", relativeName(record.Name, origin), record.TTL, record.Class, record.Type, rdata)
+	}
+	return b.String()
+}
+
+// nameFields are the data fields holding domain names, which are written as
+// absolute names
+var nameFields = map[string]bool{
+	"name":     true,
+	"hostname": true,
+	"target":   true,
+	"mname":    true,
+	"rname":    true,
+}
+
+// formatData renders the rdata of a record in zone file syntax
+func formatData(recordType string, data map[string]string) (string, bool) {
+	fields := map[string][]string{
+		"A":     {"address"},
+		"AAAA":  {"address"},
+		"CNAME": {"name"},
+		"NS":    {"name"},
+		"PTR":   {"name"},
+		"MX":    {"priority", "hostname"},
+		"SRV":   {"priority", "weight", "port", "target"},
+		"SOA":   {"mname", "rname", "serial", "refresh", "retry", "expire", "minimum"},
+	}[recordType]
+
+	switch recordType {
+	case "TXT", "SPF":
+		return quote(data["data"]), true
+	case "CAA":
+		return data["flags"] + " " + data["tag"] + " " + quote(data["value"]), true
+	}
+	if fields == nil {
+		return "", false
+	}
+
+	values := make([]string, 0, len(fields))
+	for _, field := range fields {
+		value, ok := data[field]
+		if !ok || value == "" {
+			return "", false
+		}
+		if nameFields[field] {
+			// SnitchDNS data holds absolute names, often without the dot
+			value = absoluteOrigin(value)
+		}
+		values = append(values, value)
+	}
+	return strings.Join(values, " "), true
+}
+
+// relativeName returns name relative to origin, or "@" for the origin. Names
+// outside origin are returned unchanged.
+func relativeName(name, origin string) string {
+	if strings.EqualFold(name, origin) {
+		return "@"
+	}
+	suffix := "." + origin
+	if len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+		return name[:len(name)-len(suffix)]
+	}
+	return name
+}
+
+// quote renders a character string, escaping quotes and backslashes
+func quote(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
+
+// formatMap renders data in a stable order for comments
+func formatMap(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+strconv.Quote(data[key]))
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
@@ -0,0 +1,150 @@
+package zonefile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const exampleZone = `$ORIGIN example.com.
+$TTL 1h
+; Zone apex
+@       IN  SOA ns1 hostmaster (
+                2024010101 ; serial
+                3h         ; refresh
+                15m        ; retry
+                1w         ; expire
+                1d )       ; minimum
+        IN  NS  ns1
+            NS  ns2.example.net.
+        300 IN  A     192.0.2.1
+            IN  AAAA  2001:db8::1
+@           MX  10 mail
+@           TXT "v=spf1 mx" " -all"
+@           CAA 0 issue "letsencrypt.org"
+_sip._tcp   SRV 10 60 5060 sip
+www     60  CNAME @
+mail        A   192.0.2.2
+@           HINFO "PC" "Linux"
+`
+
+func TestParse(t *testing.T) {
+	result, err := Parse(exampleZone, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Record{
+		{Name: "example.com.", Type: "SOA", Class: "IN", TTL: 3600, Data: map[string]string{
+			"mname": "ns1.example.com.", "rname": "hostmaster.example.com.", "serial": "2024010101",
+			"refresh": "10800", "retry": "900", "expire": "604800", "minimum": "86400",
+		}},
+		{Name: "example.com.", Type: "NS", Class: "IN", TTL: 3600, Data: map[string]string{"name": "ns1.example.com."}},
+		{Name: "example.com.", Type: "NS", Class: "IN", TTL: 3600, Data: map[string]string{"name": "ns2.example.net."}},
+		{Name: "example.com.", Type: "A", Class: "IN", TTL: 300, Data: map[string]string{"address": "192.0.2.1"}},
+		{Name: "example.com.", Type: "AAAA", Class: "IN", TTL: 3600, Data: map[string]string{"address": "2001:db8::1"}},
+		{Name: "example.com.", Type: "MX", Class: "IN", TTL: 3600, Data: map[string]string{"priority": "10", "hostname": "mail.example.com."}},
+		{Name: "example.com.", Type: "TXT", Class: "IN", TTL: 3600, Data: map[string]string{"data": "v=spf1 mx -all"}},
+		{Name: "example.com.", Type: "CAA", Class: "IN", TTL: 3600, Data: map[string]string{"flags": "0", "tag": "issue", "value": "letsencrypt.org"}},
+		{Name: "_sip._tcp.example.com.", Type: "SRV", Class: "IN", TTL: 3600, Data: map[string]string{
+			"priority": "10", "weight": "60", "port": "5060", "target": "sip.example.com.",
+		}},
+		{Name: "www.example.com.", Type: "CNAME", Class: "IN", TTL: 60, Data: map[string]string{"name": "example.com."}},
+		{Name: "mail.example.com.", Type: "A", Class: "IN", TTL: 3600, Data: map[string]string{"address": "192.0.2.2"}},
+	}
+
+	if len(result.Records) != len(expected) {
+		t.Fatalf("expected %d records, got %d: %+v", len(expected), len(result.Records), result.Records)
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(result.Records[i], expected[i]) {
+			t.Errorf("record %d: expected %+v, got %+v", i, expected[i], result.Records[i])
+		}
+	}
+
+	if !reflect.DeepEqual(result.Skipped, []string{"example.com. HINFO"}) {
+		t.Errorf("unexpected skipped records: %v", result.Skipped)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		origin  string
+		wantErr string
+	}{
+		{name: "no origin", text: "www 300 A 192.0.2.1\n", wantErr: "without an origin"},
+		{name: "no TTL", text: "@ A 192.0.2.1\n", origin: "example.com", wantErr: "no TTL"},
+		{name: "include", text: "$INCLUDE other.zone\n", origin: "example.com", wantErr: "unsupported directive"},
+		{name: "bad address", text: "@ 300 A 2001:db8::1\n", origin: "example.com", wantErr: "invalid address"},
+		{name: "missing field", text: "@ 300 MX mail\n", origin: "example.com", wantErr: "expected 2 values"},
+		{name: "unbalanced", text: "@ 300 SOA ns1 host ( 1 2 3 4 5\n", origin: "example.com", wantErr: "unbalanced"},
+		{name: "unterminated", text: "@ 300 TXT \"open\n", origin: "example.com", wantErr: "unterminated"},
+		{name: "blank owner first", text: "  300 A 192.0.2.1\n", origin: "example.com", wantErr: "no previous owner"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.text, tt.origin)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	tests := map[string]uint32{
+		"300":   300,
+		"1h":    3600,
+		"1h30m": 5400,
+		"2D":    172800,
+		"1w1d":  691200,
+	}
+	for text, expected := range tests {
+		got, err := parseTTL(text)
+		if err != nil || got != expected {
+			t.Errorf("parseTTL(%q) = %d, %v; expected %d", text, got, err, expected)
+		}
+	}
+
+	for _, text := range []string{"", "h", "10x", "1h30", "A"} {
+		if _, err := parseTTL(text); err == nil {
+			t.Errorf("parseTTL(%q) expected an error", text)
+		}
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	records := []Record{
+		{Name: "example.com.", Type: "A", Class: "IN", TTL: 300, Data: map[string]string{"address": "192.0.2.1"}},
+		{Name: "example.com.", Type: "TXT", Class: "IN", TTL: 60, Data: map[string]string{"data": `say "hi" \o/`}},
+		{Name: "example.com.", Type: "MX", Class: "IN", TTL: 3600, Data: map[string]string{"priority": "10", "hostname": "mail.example.com."}},
+		{Name: "example.com.", Type: "CAA", Class: "IN", TTL: 3600, Data: map[string]string{"flags": "0", "tag": "issue", "value": "ca.example.net"}},
+	}
+
+	text := Format("example.com", append(records, Record{
+		Name: "example.com.", Type: "HINFO", Class: "IN", TTL: 300, Data: map[string]string{"cpu": "PC"},
+	}))
+
+	result, err := Parse(text, "")
+	if err != nil {
+		t.Fatalf("unexpected error parsing %q: %v", text, err)
+	}
+	if !reflect.DeepEqual(result.Records, records) {
+		t.Errorf("round trip mismatch:\n%s\nexpected %+v\ngot      %+v", text, records, result.Records)
+	}
+}
+
+func TestFormatAbsoluteNames(t *testing.T) {
+	text := Format("example.com", []Record{
+		{Name: "example.com.", Type: "MX", Class: "IN", TTL: 300, Data: map[string]string{"priority": "10", "hostname": "mail.example.com"}},
+		{Name: "example.com.", Type: "CNAME", Class: "IN", TTL: 300, Data: map[string]string{"name": "target.example.net"}},
+	})
+
+	expected := "$ORIGIN example.com.\n@\t300\tIN\tMX\t10 mail.example.com.\n@\t300\tIN\tCNAME\ttarget.example.net.\n"
+	if text != expected {
+		t.Errorf("expected %q, got %q", expected, text)
+	}
+}
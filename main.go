@@ -5,26 +5,51 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-	"snitchdns-tf/internal/provider"
+	"github.com/EinDev/snitchdns-tf/internal/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
 )
 
 // version is set by the goreleaser at build time
 var version = "dev"
 
+// providerAddress is the registry address Terraform knows the provider by
+const providerAddress = "registry.terraform.io/EinDev/snitchdns"
+
+// providerLoggerName is the name tf6server derives from providerAddress for
+// the provider logger, whose level TF_LOG_PROVIDER_SNITCHDNS sets
+const providerLoggerName = "snitchdns"
+
 func main() {
 	var debug bool
 
-	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.BoolVar(&debug, "debug", false, "run the provider with support for debuggers like delve and print the TF_REATTACH_PROVIDERS value to attach Terraform with")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		Address: "registry.terraform.io/EinDev/snitchdns",
-		Debug:   debug,
+	var opts []tf6server.ServeOpt
+	if debug {
+		opts = append(opts, tf6server.WithManagedDebug())
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version, nil), opts)
+	err := tf6server.Serve(providerAddress, provider.NewProtocol6Server(version, nil), opts...)
+
+	// Terraform stops the provider before killing it, which leaves a short
+	// window to export the remaining spans
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	if shutdownErr := provider.ShutdownTracing(shutdownCtx); shutdownErr != nil {
+		log.Printf("[WARN] Could not export traces: %s", shutdownErr)
+	}
+	cancel()
+
+	// The logger of the provider server is gone once Serve returns, so the
+	// summary gets a provider logger set up like the one Serve uses
+	logCtx := tfsdklog.NewRootProviderLogger(context.Background(),
+		tfsdklog.WithStderrFromInit(),
+		tfsdklog.WithLogName(providerLoggerName),
+		tfsdklog.WithLevelFromEnv("TF_LOG_PROVIDER", providerLoggerName))
+	provider.LogMetricsSummary(logCtx)
 
 	if err != nil {
 		log.Fatal(err.Error())
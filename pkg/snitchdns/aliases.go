@@ -0,0 +1,67 @@
+package snitchdns
+
+import (
+	"context"
+	"fmt"
+)
+
+// Alias is a name SnitchDNS shows instead of a source IP address in the
+// query logs and search results
+type Alias struct {
+	ID   int    `json:"id"`
+	IP   string `json:"ip"`
+	Name string `json:"name"`
+}
+
+// AliasRequest is the request body for creating or updating an alias.
+// Empty fields are left unchanged on update.
+type AliasRequest struct {
+	IP   string `json:"ip,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// ListAliases retrieves all aliases
+func (c *Client) ListAliases(ctx context.Context) ([]Alias, error) {
+	var aliases []Alias
+	if err := c.doJSONRequest(ctx, "GET", "/aliases", nil, &aliases); err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+// CreateAlias gives a source IP address a name
+func (c *Client) CreateAlias(ctx context.Context, req AliasRequest) (*Alias, error) {
+	var alias Alias
+	if err := c.doJSONRequest(ctx, "POST", "/aliases", req, &alias); err != nil {
+		return nil, err
+	}
+
+	return &alias, nil
+}
+
+// GetAlias retrieves a single alias
+func (c *Client) GetAlias(ctx context.Context, id string) (*Alias, error) {
+	var alias Alias
+	if err := c.doJSONRequest(ctx, "GET", fmt.Sprintf("/aliases/%s", id), nil, &alias); err != nil {
+		return nil, err
+	}
+
+	return &alias, nil
+}
+
+// UpdateAlias changes the IP address or name of an alias
+func (c *Client) UpdateAlias(ctx context.Context, id string, req AliasRequest) (*Alias, error) {
+	var alias Alias
+	if err := c.doJSONRequest(ctx, "POST", fmt.Sprintf("/aliases/%s", id), req, &alias); err != nil {
+		return nil, err
+	}
+
+	return &alias, nil
+}
+
+// DeleteAlias removes an alias
+func (c *Client) DeleteAlias(ctx context.Context, id string) error {
+	_, err := c.doRequestWithContext(ctx, "DELETE", fmt.Sprintf("/aliases/%s", id), nil)
+	return err
+}
@@ -0,0 +1,68 @@
+package snitchdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestAliases tests the alias routes and request bodies
+func TestAliases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /aliases":
+			w.Write([]byte(`[{"id": 1, "ip": "192.0.2.10", "name": "red-team"}]`))
+		case "POST /aliases":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["ip"] != "198.51.100.7" || body["name"] != "blue-team" {
+				t.Errorf("Unexpected create body: %v", body)
+			}
+			w.Write([]byte(`{"id": 2, "ip": "198.51.100.7", "name": "blue-team"}`))
+		case "GET /aliases/2":
+			w.Write([]byte(`{"id": 2, "ip": "198.51.100.7", "name": "blue-team"}`))
+		case "POST /aliases/2":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if _, ok := body["ip"]; ok || body["name"] != "purple-team" {
+				t.Errorf("Expected only name to be sent, got %v", body)
+			}
+			w.Write([]byte(`{"id": 2, "ip": "198.51.100.7", "name": "purple-team"}`))
+		case "DELETE /aliases/2":
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	ctx := context.Background()
+
+	aliases, err := client.ListAliases(ctx)
+	if err != nil || len(aliases) != 1 || aliases[0].Name != "red-team" {
+		t.Fatalf("Unexpected list result %+v, error %v", aliases, err)
+	}
+
+	created, err := client.CreateAlias(ctx, AliasRequest{IP: "198.51.100.7", Name: "blue-team"})
+	if err != nil || created.ID != 2 {
+		t.Fatalf("Unexpected create result %+v, error %v", created, err)
+	}
+
+	alias, err := client.GetAlias(ctx, "2")
+	if err != nil || alias.IP != "198.51.100.7" {
+		t.Fatalf("Unexpected get result %+v, error %v", alias, err)
+	}
+
+	updated, err := client.UpdateAlias(ctx, "2", AliasRequest{Name: "purple-team"})
+	if err != nil || updated.Name != "purple-team" {
+		t.Fatalf("Unexpected update result %+v, error %v", updated, err)
+	}
+
+	if err := client.DeleteAlias(ctx, "2"); err != nil {
+		t.Errorf("Unexpected delete error %v", err)
+	}
+}
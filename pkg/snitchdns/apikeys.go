@@ -0,0 +1,83 @@
+package snitchdns
+
+import (
+	"context"
+	"fmt"
+)
+
+// APIKey is an API key of a SnitchDNS user. Key holds the secret and is
+// only returned when the key is created.
+type APIKey struct {
+	ID        int    `json:"id"`
+	UserID    int    `json:"user_id,omitempty"`
+	Name      string `json:"name"`
+	Key       string `json:"apikey,omitempty"`
+	Enabled   bool   `json:"enabled"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// CreateAPIKeyRequest is the request body for creating an API key. A nil
+// Enabled creates an enabled key.
+type CreateAPIKeyRequest struct {
+	Name    string `json:"name"`
+	Enabled *bool  `json:"enabled,omitempty"`
+	// UserID creates the key for another user; only admin API keys may set it
+	UserID int `json:"user_id,omitempty"`
+}
+
+// UpdateAPIKeyRequest is the request body for updating an API key. Empty
+// and nil fields are left unchanged.
+type UpdateAPIKeyRequest struct {
+	Name    string `json:"name,omitempty"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
+
+// ListAPIKeys retrieves the API keys of the authenticated user, without
+// their secrets
+func (c *Client) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	var keys []APIKey
+	if err := c.doJSONRequest(ctx, "GET", "/apikeys", nil, &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// CreateAPIKey creates an API key, for the authenticated user unless the
+// request sets a user ID. The secret of the new key is redacted from the
+// client's errors and logs from then on.
+func (c *Client) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*APIKey, error) {
+	var key APIKey
+	if err := c.doJSONRequest(ctx, "POST", "/apikeys", req, &key); err != nil {
+		return nil, err
+	}
+	c.redactor.addValue(key.Key)
+
+	return &key, nil
+}
+
+// GetAPIKey retrieves a single API key without its secret
+func (c *Client) GetAPIKey(ctx context.Context, id string) (*APIKey, error) {
+	var key APIKey
+	if err := c.doJSONRequest(ctx, "GET", fmt.Sprintf("/apikeys/%s", id), nil, &key); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// UpdateAPIKey renames, enables or disables an API key
+func (c *Client) UpdateAPIKey(ctx context.Context, id string, req UpdateAPIKeyRequest) (*APIKey, error) {
+	var key APIKey
+	if err := c.doJSONRequest(ctx, "POST", fmt.Sprintf("/apikeys/%s", id), req, &key); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// DeleteAPIKey revokes an API key
+func (c *Client) DeleteAPIKey(ctx context.Context, id string) error {
+	_, err := c.doRequestWithContext(ctx, "DELETE", fmt.Sprintf("/apikeys/%s", id), nil)
+	return err
+}
@@ -0,0 +1,86 @@
+package snitchdns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestAPIKeys tests creating, reading, updating, listing and revoking API keys
+func TestAPIKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /apikeys":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"name":"ci"}` {
+				t.Errorf("Unexpected request body: %s", body)
+			}
+			w.Write([]byte(`{"id": 3, "name": "ci", "apikey": "secret-value", "enabled": true}`))
+		case "GET /apikeys/3":
+			w.Write([]byte(`{"id": 3, "user_id": 2, "name": "ci", "enabled": true}`))
+		case "POST /apikeys/3":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"enabled":false}` {
+				t.Errorf("Unexpected request body: %s", body)
+			}
+			w.Write([]byte(`{"id": 3, "user_id": 2, "name": "ci", "enabled": false}`))
+		case "GET /apikeys":
+			w.Write([]byte(`[{"id": 3, "name": "ci", "enabled": true}]`))
+		case "DELETE /apikeys/3":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "bad key secret-value"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(0, 0, 0))
+	ctx := context.Background()
+
+	key, err := client.CreateAPIKey(ctx, CreateAPIKeyRequest{Name: "ci"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key.ID != 3 || key.Key != "secret-value" || !key.Enabled {
+		t.Errorf("Unexpected key: %+v", key)
+	}
+
+	key, err = client.GetAPIKey(ctx, "3")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key.UserID != 2 || key.Key != "" {
+		t.Errorf("Unexpected key: %+v", key)
+	}
+
+	enabled := false
+	key, err = client.UpdateAPIKey(ctx, "3", UpdateAPIKeyRequest{Enabled: &enabled})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key.Enabled {
+		t.Errorf("Expected a disabled key, got %+v", key)
+	}
+
+	keys, err := client.ListAPIKeys(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Name != "ci" || keys[0].Key != "" {
+		t.Errorf("Unexpected keys: %+v", keys)
+	}
+
+	if err := client.DeleteAPIKey(ctx, "3"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The secret of a created key never appears in errors
+	err = client.DeleteAPIKey(ctx, "4")
+	if err == nil || strings.Contains(err.Error(), "secret-value") {
+		t.Errorf("Expected an error without the secret, got %v", err)
+	}
+}
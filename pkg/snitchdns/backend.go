@@ -0,0 +1,12 @@
+//go:build !openapi
+
+package snitchdns
+
+// BackendName identifies the API backend compiled into the binary
+const BackendName = "handwritten"
+
+// NewBackend returns the API backend selected at build time, sending its
+// requests through c. Default builds use c itself.
+func NewBackend(c *Client) ClientInterface {
+	return c
+}
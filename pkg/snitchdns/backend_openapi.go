@@ -0,0 +1,521 @@
+//go:build openapi
+
+package snitchdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// BackendName identifies the API backend compiled into the binary
+const BackendName = "openapi"
+
+// NewBackend returns the API backend selected at build time, sending its
+// requests through c. Builds with the openapi tag use the operations
+// generated from openapi.json.
+func NewBackend(c *Client) ClientInterface {
+	return &openAPIClient{transport: c}
+}
+
+// openAPIClient implements ClientInterface on top of the operations
+// generated from openapi.json. When the API changes between server
+// releases, regenerating the operations turns removed or renamed routes
+// into compile errors here instead of silent 404s.
+type openAPIClient struct {
+	transport *Client
+}
+
+// Ensure the generated backend implements the full API
+var _ ClientInterface = &openAPIClient{}
+
+// withQuery appends encoded query parameters to a route
+func withQuery(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// decodeResponse decodes the body of a successful operation
+func decodeResponse[T any](respBody []byte, err error) (T, error) {
+	var result T
+	if err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return result, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result, nil
+}
+
+// decodeRecord decodes a record response including its data
+func decodeRecord(respBody []byte, err error) (*Record, error) {
+	record, err := decodeResponse[Record](respBody, err)
+	if err != nil {
+		return nil, err
+	}
+	if err := record.parseData(); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// GetServerInfo retrieves the server status, including its version
+func (c *openAPIClient) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	info, err := decodeResponse[ServerInfo](c.getStatus(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Ping checks that the SnitchDNS API answers and accepts the API key
+func (c *openAPIClient) Ping(ctx context.Context) error {
+	_, err := c.getStatus(ctx)
+	if err != nil && !IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// AddSensitiveValue registers a secret with the redactor of the transport
+// client, which sends every request of the backend
+func (c *openAPIClient) AddSensitiveValue(value string) {
+	c.transport.AddSensitiveValue(value)
+}
+
+// HasFeature reports whether the server serves the endpoints of an optional
+// feature. The probe and its cache are shared with the transport client.
+func (c *openAPIClient) HasFeature(ctx context.Context, feature Feature) (bool, error) {
+	return c.transport.HasFeature(ctx, feature)
+}
+
+// ListZones retrieves all zones the API key has access to
+func (c *openAPIClient) ListZones() ([]Zone, error) {
+	return c.ListZonesWithContext(context.Background())
+}
+
+// ListZonesWithContext retrieves all zones the API key has access to with
+// context, following the pagination until the last page
+func (c *openAPIClient) ListZonesWithContext(ctx context.Context) ([]Zone, error) {
+	return c.SearchZonesWithContext(ctx, "")
+}
+
+// SearchZonesWithContext retrieves the zones whose domain contains search,
+// letting the server narrow the listing. The server's matching is not
+// guaranteed to be exact, so callers filter the result again.
+func (c *openAPIClient) SearchZonesWithContext(ctx context.Context, search string) ([]Zone, error) {
+	var zones []Zone
+
+	for page := 1; ; page++ {
+		query := url.Values{
+			"page":     {strconv.Itoa(page)},
+			"per_page": {strconv.Itoa(zonePageSize)},
+		}
+		if search != "" {
+			query.Set("search", search)
+		}
+
+		result, err := decodeResponse[zonePage](c.listZones(ctx, query))
+		if err != nil {
+			return nil, err
+		}
+
+		zones = append(zones, result.Results...)
+
+		if page >= result.Pages || len(result.Results) == 0 {
+			break
+		}
+	}
+
+	return zones, nil
+}
+
+// CreateZone creates a new DNS zone
+func (c *openAPIClient) CreateZone(req CreateZoneRequest) (*Zone, error) {
+	return c.CreateZoneWithContext(context.Background(), req)
+}
+
+// CreateZoneWithContext creates a new DNS zone with context
+func (c *openAPIClient) CreateZoneWithContext(ctx context.Context, req CreateZoneRequest) (*Zone, error) {
+	zone, err := decodeResponse[Zone](c.createZone(ctx, req))
+	if err != nil {
+		return adoptRetriedZone(ctx, c, req, err)
+	}
+	return &zone, nil
+}
+
+// GetZone retrieves a zone by ID
+func (c *openAPIClient) GetZone(id string) (*Zone, error) {
+	return c.GetZoneWithContext(context.Background(), id)
+}
+
+// GetZoneWithContext retrieves a zone by ID with context
+func (c *openAPIClient) GetZoneWithContext(ctx context.Context, id string) (*Zone, error) {
+	zone, err := decodeResponse[Zone](c.getZone(ctx, id))
+	if err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+// GetZoneIfModified retrieves a zone only if its updated_at timestamp
+// differs from the given one. The generated operations cannot send
+// If-Modified-Since, so the transport client sends the conditional request.
+// The boolean result is false when the zone is unchanged, in which case the
+// zone is nil.
+func (c *openAPIClient) GetZoneIfModified(ctx context.Context, id, updatedAt string) (*Zone, bool, error) {
+	return c.transport.GetZoneIfModified(ctx, id, updatedAt)
+}
+
+// UpdateZone updates an existing zone
+func (c *openAPIClient) UpdateZone(id string, req UpdateZoneRequest) (*Zone, error) {
+	return c.UpdateZoneWithContext(context.Background(), id, req)
+}
+
+// UpdateZoneWithContext updates an existing zone with context
+func (c *openAPIClient) UpdateZoneWithContext(ctx context.Context, id string, req UpdateZoneRequest) (*Zone, error) {
+	if err := checkZoneUnmodified(ctx, c, id, req.ExpectedUpdatedAt); err != nil {
+		return nil, err
+	}
+
+	zone, err := decodeResponse[Zone](c.updateZone(ctx, id, req))
+	if err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+// DeleteZone deletes a zone
+func (c *openAPIClient) DeleteZone(id string) error {
+	return c.DeleteZoneWithContext(context.Background(), id)
+}
+
+// DeleteZoneWithContext deletes a zone with context
+func (c *openAPIClient) DeleteZoneWithContext(ctx context.Context, id string) error {
+	_, err := c.deleteZone(ctx, id)
+	return err
+}
+
+// GetZoneStats retrieves the activity summary of a zone
+func (c *openAPIClient) GetZoneStats(ctx context.Context, zoneID string) (*ZoneStats, error) {
+	stats, err := decodeResponse[ZoneStats](c.getZoneStats(ctx, zoneID))
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GetZoneQueryStats retrieves the number of queries a zone answered per
+// query type and per day
+func (c *openAPIClient) GetZoneQueryStats(ctx context.Context, zoneID string, params ZoneQueryStatsParams) (*ZoneQueryStats, error) {
+	stats, err := decodeResponse[ZoneQueryStats](c.getZoneQueryStats(ctx, zoneID, params.query()))
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// CreateRecord creates a new DNS record
+func (c *openAPIClient) CreateRecord(zoneID string, req CreateRecordRequest) (*Record, error) {
+	return c.CreateRecordWithContext(context.Background(), zoneID, req)
+}
+
+// CreateRecordWithContext creates a new DNS record with context
+func (c *openAPIClient) CreateRecordWithContext(ctx context.Context, zoneID string, req CreateRecordRequest) (*Record, error) {
+	return decodeRecord(c.createRecord(ctx, zoneID, req))
+}
+
+// GetRecord retrieves a record by zone ID and record ID
+func (c *openAPIClient) GetRecord(zoneID, recordID string) (*Record, error) {
+	return c.GetRecordWithContext(context.Background(), zoneID, recordID)
+}
+
+// GetRecordWithContext retrieves a record by zone ID and record ID with
+// context
+func (c *openAPIClient) GetRecordWithContext(ctx context.Context, zoneID, recordID string) (*Record, error) {
+	return decodeRecord(c.getRecord(ctx, zoneID, recordID))
+}
+
+// ListRecords retrieves all records of a zone
+func (c *openAPIClient) ListRecords(zoneID string) ([]Record, error) {
+	return c.ListRecordsWithContext(context.Background(), zoneID)
+}
+
+// ListRecordsWithContext retrieves all records of a zone with context
+func (c *openAPIClient) ListRecordsWithContext(ctx context.Context, zoneID string) ([]Record, error) {
+	records, err := decodeResponse[[]Record](c.listRecords(ctx, zoneID))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range records {
+		if err := records[i].parseData(); err != nil {
+			return nil, err
+		}
+	}
+
+	return records, nil
+}
+
+// UpdateRecord updates an existing DNS record
+func (c *openAPIClient) UpdateRecord(zoneID, recordID string, req UpdateRecordRequest) (*Record, error) {
+	return c.UpdateRecordWithContext(context.Background(), zoneID, recordID, req)
+}
+
+// UpdateRecordWithContext updates an existing DNS record with context
+func (c *openAPIClient) UpdateRecordWithContext(ctx context.Context, zoneID, recordID string, req UpdateRecordRequest) (*Record, error) {
+	return decodeRecord(c.updateRecord(ctx, zoneID, recordID, req))
+}
+
+// ResetConditionalCount sets the query count of a conditional record back
+// to zero
+func (c *openAPIClient) ResetConditionalCount(ctx context.Context, zoneID, recordID string) (*Record, error) {
+	zero := 0
+	return c.UpdateRecordWithContext(ctx, zoneID, recordID, UpdateRecordRequest{ConditionalCount: &zero})
+}
+
+// DeleteRecord deletes a DNS record
+func (c *openAPIClient) DeleteRecord(zoneID, recordID string) error {
+	return c.DeleteRecordWithContext(context.Background(), zoneID, recordID)
+}
+
+// DeleteRecordWithContext deletes a DNS record with context
+func (c *openAPIClient) DeleteRecordWithContext(ctx context.Context, zoneID, recordID string) error {
+	_, err := c.deleteRecord(ctx, zoneID, recordID)
+	return err
+}
+
+// ImportRecordsCSV uploads records in the SnitchDNS CSV export format to the
+// zone's multipart import endpoint
+func (c *openAPIClient) ImportRecordsCSV(ctx context.Context, zoneID string, csv []byte) error {
+	contentType, body, err := csvImportBody("records.csv", bytes.NewReader(csv))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.importRecords(ctx, zoneID, contentType, body)
+	return err
+}
+
+// ImportZonesCSV uploads zones in the SnitchDNS CSV export format to the
+// zone import endpoint
+func (c *openAPIClient) ImportZonesCSV(ctx context.Context, csv io.Reader) error {
+	contentType, body, err := csvImportBody("zones.csv", csv)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.importZones(ctx, contentType, body)
+	return err
+}
+
+// ListRestrictions retrieves all restrictions of a zone
+func (c *openAPIClient) ListRestrictions(ctx context.Context, zoneID string) ([]ZoneRestriction, error) {
+	return decodeResponse[[]ZoneRestriction](c.listRestrictions(ctx, zoneID))
+}
+
+// CreateRestriction adds a restriction to a zone
+func (c *openAPIClient) CreateRestriction(ctx context.Context, zoneID string, req RestrictionRequest) (*ZoneRestriction, error) {
+	restriction, err := decodeResponse[ZoneRestriction](c.createRestriction(ctx, zoneID, req))
+	if err != nil {
+		return nil, err
+	}
+	return &restriction, nil
+}
+
+// GetRestriction retrieves a single restriction of a zone
+func (c *openAPIClient) GetRestriction(ctx context.Context, zoneID, restrictionID string) (*ZoneRestriction, error) {
+	restriction, err := decodeResponse[ZoneRestriction](c.getRestriction(ctx, zoneID, restrictionID))
+	if err != nil {
+		return nil, err
+	}
+	return &restriction, nil
+}
+
+// UpdateRestriction updates a restriction of a zone
+func (c *openAPIClient) UpdateRestriction(ctx context.Context, zoneID, restrictionID string, req RestrictionRequest) (*ZoneRestriction, error) {
+	restriction, err := decodeResponse[ZoneRestriction](c.updateRestriction(ctx, zoneID, restrictionID, req))
+	if err != nil {
+		return nil, err
+	}
+	return &restriction, nil
+}
+
+// DeleteRestriction removes a restriction from a zone
+func (c *openAPIClient) DeleteRestriction(ctx context.Context, zoneID, restrictionID string) error {
+	_, err := c.deleteRestriction(ctx, zoneID, restrictionID)
+	return err
+}
+
+// ListNotificationProviders retrieves the notification providers available
+// on the server
+func (c *openAPIClient) ListNotificationProviders(ctx context.Context) ([]NotificationProvider, error) {
+	return decodeResponse[[]NotificationProvider](c.listNotificationProviders(ctx))
+}
+
+// ListZoneNotifications retrieves all notification subscriptions of a zone
+func (c *openAPIClient) ListZoneNotifications(ctx context.Context, zoneID string) ([]NotificationSubscription, error) {
+	return decodeResponse[[]NotificationSubscription](c.listZoneNotifications(ctx, zoneID))
+}
+
+// GetZoneNotification retrieves a zone's subscription to a provider
+func (c *openAPIClient) GetZoneNotification(ctx context.Context, zoneID, provider string) (*NotificationSubscription, error) {
+	subscription, err := decodeResponse[NotificationSubscription](c.getZoneNotification(ctx, zoneID, provider))
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// UpdateZoneNotification updates a zone's subscription to a provider
+func (c *openAPIClient) UpdateZoneNotification(ctx context.Context, zoneID, provider string, req UpdateNotificationRequest) (*NotificationSubscription, error) {
+	subscription, err := decodeResponse[NotificationSubscription](c.updateZoneNotification(ctx, zoneID, provider, req))
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// SearchLogs retrieves a page of the query log, newest entries first
+func (c *openAPIClient) SearchLogs(ctx context.Context, params SearchParams) (*SearchPage, error) {
+	page, err := decodeResponse[SearchPage](c.searchLogs(ctx, params.query()))
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ExportQueryLog returns every query log entry matching params as a file in
+// the given format
+func (c *openAPIClient) ExportQueryLog(ctx context.Context, params SearchParams, format QueryLogFormat) ([]byte, error) {
+	query, err := params.exportQuery(format)
+	if err != nil {
+		return nil, err
+	}
+	return c.exportLogs(ctx, query)
+}
+
+// ClearZoneLogs deletes the logged queries answered by a zone
+func (c *openAPIClient) ClearZoneLogs(ctx context.Context, zoneID string) error {
+	_, err := c.clearZoneLogs(ctx, zoneID)
+	return err
+}
+
+// GetSettings retrieves all server settings. Requires an admin API key.
+func (c *openAPIClient) GetSettings(ctx context.Context) (Settings, error) {
+	respBody, err := c.getSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return parseSettings(respBody)
+}
+
+// UpdateSettings updates the given server settings and returns the
+// resulting settings. Requires an admin API key.
+func (c *openAPIClient) UpdateSettings(ctx context.Context, settings Settings) (Settings, error) {
+	respBody, err := c.updateSettings(ctx, settings)
+	if err != nil {
+		return nil, err
+	}
+	return parseSettings(respBody)
+}
+
+// ListUsers retrieves all user accounts
+func (c *openAPIClient) ListUsers(ctx context.Context) ([]User, error) {
+	return decodeResponse[[]User](c.listUsers(ctx))
+}
+
+// GetQuota retrieves the zone quota of the API key's user
+func (c *openAPIClient) GetQuota(ctx context.Context) (*Quota, error) {
+	quota, err := decodeResponse[Quota](c.getQuota(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// ListAPIKeys retrieves the API keys of the authenticated user, without
+// their secrets
+func (c *openAPIClient) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	return decodeResponse[[]APIKey](c.listAPIKeys(ctx))
+}
+
+// CreateAPIKey creates an API key, for the authenticated user unless the
+// request sets a user ID. The secret of the new key is redacted from the
+// client's errors and logs from then on.
+func (c *openAPIClient) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*APIKey, error) {
+	key, err := decodeResponse[APIKey](c.createAPIKey(ctx, req))
+	if err != nil {
+		return nil, err
+	}
+	c.transport.redactor.addValue(key.Key)
+	return &key, nil
+}
+
+// GetAPIKey retrieves a single API key without its secret
+func (c *openAPIClient) GetAPIKey(ctx context.Context, id string) (*APIKey, error) {
+	key, err := decodeResponse[APIKey](c.getAPIKey(ctx, id))
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// UpdateAPIKey renames, enables or disables an API key
+func (c *openAPIClient) UpdateAPIKey(ctx context.Context, id string, req UpdateAPIKeyRequest) (*APIKey, error) {
+	key, err := decodeResponse[APIKey](c.updateAPIKey(ctx, id, req))
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// DeleteAPIKey revokes an API key
+func (c *openAPIClient) DeleteAPIKey(ctx context.Context, id string) error {
+	_, err := c.deleteAPIKey(ctx, id)
+	return err
+}
+
+// ListAliases retrieves all aliases
+func (c *openAPIClient) ListAliases(ctx context.Context) ([]Alias, error) {
+	return decodeResponse[[]Alias](c.listAliases(ctx))
+}
+
+// CreateAlias gives a source IP address a name
+func (c *openAPIClient) CreateAlias(ctx context.Context, req AliasRequest) (*Alias, error) {
+	alias, err := decodeResponse[Alias](c.createAlias(ctx, req))
+	if err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+// GetAlias retrieves a single alias
+func (c *openAPIClient) GetAlias(ctx context.Context, id string) (*Alias, error) {
+	alias, err := decodeResponse[Alias](c.getAlias(ctx, id))
+	if err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+// UpdateAlias changes the IP address or name of an alias
+func (c *openAPIClient) UpdateAlias(ctx context.Context, id string, req AliasRequest) (*Alias, error) {
+	alias, err := decodeResponse[Alias](c.updateAlias(ctx, id, req))
+	if err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+// DeleteAlias removes an alias
+func (c *openAPIClient) DeleteAlias(ctx context.Context, id string) error {
+	_, err := c.deleteAlias(ctx, id)
+	return err
+}
@@ -0,0 +1,73 @@
+//go:build openapi
+
+package snitchdns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestOpenAPIBackend tests that the generated backend sends requests to the
+// documented routes and decodes their responses
+func TestOpenAPIBackend(t *testing.T) {
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		if r.Header.Get("X-SnitchDNS-Auth") != "test-key" {
+			t.Errorf("Expected the API key header to be set")
+		}
+
+		switch r.URL.Path {
+		case "/zones":
+			w.Write([]byte(`{"page": 1, "pages": 1, "results": [{"id": 1, "domain": "example.com"}]}`))
+		case "/zones/1/records/2":
+			w.Write([]byte(`{"id": 2, "zone_id": 1, "type": "A", "cls": "IN", "ttl": 300, "data": "{\"address\": \"192.0.2.1\"}"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	backend := NewBackend(NewClient(server.URL, "test-key"))
+	if BackendName != "openapi" {
+		t.Fatalf("Expected the openapi backend, got %s", BackendName)
+	}
+
+	zones, err := backend.ListZonesWithContext(context.Background())
+	if err != nil || len(zones) != 1 || zones[0].Domain != "example.com" {
+		t.Fatalf("Unexpected zones %v, error %v", zones, err)
+	}
+
+	record, err := backend.GetRecord("1", "2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if record.Data.Get("address") != "192.0.2.1" {
+		t.Errorf("Expected record data to be decoded, got %v", record.Data)
+	}
+
+	_, err = backend.GetZoneStats(context.Background(), "1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+
+	expected := []string{
+		"GET /zones?page=1&per_page=50",
+		"GET /zones/1/records/2",
+		"GET /zones/1/stats",
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("Expected requests %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("Expected request %s, got %s", expected[i], requests[i])
+		}
+	}
+}
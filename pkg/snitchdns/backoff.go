@@ -0,0 +1,127 @@
+package snitchdns
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Names of the built-in backoff strategies, as accepted by ParseBackoff
+const (
+	BackoffExponential        = "exponential"
+	BackoffFullJitter         = "full_jitter"
+	BackoffConstant           = "constant"
+	BackoffDecorrelatedJitter = "decorrelated_jitter"
+)
+
+// Backoff decides how long to wait before retrying a failed request. The
+// wait of a Retry-After header takes precedence over it.
+//
+// Wait is called for every retry of every request, from any goroutine, so
+// implementations must be safe for concurrent use; the built-in ones keep no
+// state. attempt counts the retries of a request from 1, waitMin and waitMax
+// are the client's RetryWaitMin and RetryWaitMax, and previous is the wait
+// before the last retry, 0 before the first.
+type Backoff interface {
+	Wait(attempt int, waitMin, waitMax, previous time.Duration) time.Duration
+}
+
+// BackoffFunc adapts a function to the Backoff interface
+type BackoffFunc func(attempt int, waitMin, waitMax, previous time.Duration) time.Duration
+
+// Wait implements Backoff
+func (f BackoffFunc) Wait(attempt int, waitMin, waitMax, previous time.Duration) time.Duration {
+	return f(attempt, waitMin, waitMax, previous)
+}
+
+// ExponentialBackoff doubles the wait with every retry from waitMin up to
+// waitMax, varied by ±25% so clients failing together do not retry
+// together. It is the default.
+type ExponentialBackoff struct{}
+
+// Wait implements Backoff
+func (ExponentialBackoff) Wait(attempt int, waitMin, waitMax, _ time.Duration) time.Duration {
+	backoff := exponentialWait(attempt, waitMin, waitMax)
+
+	// Add jitter (±25%) using crypto/rand for security
+	jitter := backoff * 0.25
+	return time.Duration(backoff - jitter + secureRandomFloat()*jitter*2)
+}
+
+// FullJitterBackoff waits a random time between zero and the exponential
+// wait of ExponentialBackoff, which spreads the retries of many clients the
+// most, at the cost of some retries following almost at once
+type FullJitterBackoff struct{}
+
+// Wait implements Backoff
+func (FullJitterBackoff) Wait(attempt int, waitMin, waitMax, _ time.Duration) time.Duration {
+	return time.Duration(secureRandomFloat() * exponentialWait(attempt, waitMin, waitMax))
+}
+
+// ConstantBackoff waits waitMin before every retry, for servers that fail
+// briefly and recover at once, such as behind a restarting load balancer
+type ConstantBackoff struct{}
+
+// Wait implements Backoff
+func (ConstantBackoff) Wait(_ int, waitMin, _, _ time.Duration) time.Duration {
+	return waitMin
+}
+
+// DecorrelatedJitterBackoff waits a random time between waitMin and three
+// times the previous wait, up to waitMax. The waits grow like exponential
+// backoff, but are not tied to the retry count, so they spread more evenly.
+type DecorrelatedJitterBackoff struct{}
+
+// Wait implements Backoff
+func (DecorrelatedJitterBackoff) Wait(_ int, waitMin, waitMax, previous time.Duration) time.Duration {
+	upper := float64(max(previous, waitMin)) * 3
+	wait := float64(waitMin) + secureRandomFloat()*(upper-float64(waitMin))
+	return time.Duration(min(wait, float64(waitMax)))
+}
+
+// ParseBackoff returns the built-in backoff strategy of the given name, one
+// of the Backoff* constants
+func ParseBackoff(name string) (Backoff, error) {
+	switch name {
+	case BackoffExponential:
+		return ExponentialBackoff{}, nil
+	case BackoffFullJitter:
+		return FullJitterBackoff{}, nil
+	case BackoffConstant:
+		return ConstantBackoff{}, nil
+	case BackoffDecorrelatedJitter:
+		return DecorrelatedJitterBackoff{}, nil
+	default:
+		return nil, fmt.Errorf("unknown backoff strategy %q", name)
+	}
+}
+
+// exponentialWait returns waitMin doubled for every retry after the first,
+// capped at waitMax
+func exponentialWait(attempt int, waitMin, waitMax time.Duration) float64 {
+	return min(float64(waitMin)*math.Pow(2, float64(attempt-1)), float64(waitMax))
+}
+
+// calculateBackoff returns the wait before a retry with the client's backoff
+// strategy, ExponentialBackoff when none is set
+func (c *Client) calculateBackoff(attempt int, previous time.Duration) time.Duration {
+	backoff := c.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff{}
+	}
+	return backoff.Wait(attempt, c.RetryWaitMin, c.RetryWaitMax, previous)
+}
+
+// secureRandomFloat returns a cryptographically secure random float64 between 0 and 1
+func secureRandomFloat() float64 {
+	var b [8]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		// Fallback to using timestamp if crypto/rand fails (should never happen)
+		return float64(time.Now().UnixNano()%1000) / 1000.0
+	}
+	// Convert bytes to uint64 and normalize to [0, 1)
+	return float64(binary.BigEndian.Uint64(b[:])) / float64(1<<64)
+}
@@ -0,0 +1,89 @@
+package snitchdns
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestBackoffBounds tests that the built-in strategies keep their waits
+// within their documented bounds
+func TestBackoffBounds(t *testing.T) {
+	waitMin, waitMax := 100*time.Millisecond, 2*time.Second
+
+	cases := []struct {
+		name   string
+		bounds func(attempt int, previous time.Duration) (time.Duration, time.Duration)
+	}{
+		{BackoffExponential, func(attempt int, _ time.Duration) (time.Duration, time.Duration) {
+			wait := time.Duration(exponentialWait(attempt, waitMin, waitMax))
+			return wait * 3 / 4, wait * 5 / 4
+		}},
+		{BackoffFullJitter, func(attempt int, _ time.Duration) (time.Duration, time.Duration) {
+			return 0, time.Duration(exponentialWait(attempt, waitMin, waitMax))
+		}},
+		{BackoffConstant, func(int, time.Duration) (time.Duration, time.Duration) {
+			return waitMin, waitMin
+		}},
+		{BackoffDecorrelatedJitter, func(_ int, previous time.Duration) (time.Duration, time.Duration) {
+			return waitMin, min(max(previous, waitMin)*3, waitMax)
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			backoff, err := ParseBackoff(tc.name)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for run := 0; run < 20; run++ {
+				var previous time.Duration
+				for attempt := 1; attempt <= 8; attempt++ {
+					wait := backoff.Wait(attempt, waitMin, waitMax, previous)
+					low, high := tc.bounds(attempt, previous)
+					if wait < low || wait > high {
+						t.Fatalf("Retry %d after %s: wait %s outside [%s, %s]", attempt, previous, wait, low, high)
+					}
+					previous = wait
+				}
+			}
+		})
+	}
+
+	if _, err := ParseBackoff("linear"); err == nil {
+		t.Error("Expected an unknown strategy to be rejected")
+	}
+}
+
+// TestWithBackoff tests that a custom strategy decides the waits between
+// retries and gets the previous wait
+func TestWithBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var previousWaits []time.Duration
+	client := NewClient(server.URL, "test-key",
+		WithRetry(3, time.Millisecond, 10*time.Millisecond),
+		WithBackoff(BackoffFunc(func(attempt int, waitMin, _, previous time.Duration) time.Duration {
+			previousWaits = append(previousWaits, previous)
+			return time.Duration(attempt) * waitMin
+		})),
+	)
+
+	_, err := client.GetZone("1")
+	if StatusCode(err) != http.StatusServiceUnavailable {
+		t.Fatalf("Expected the request to fail with 503, got %v", err)
+	}
+	want := []time.Duration{0, time.Millisecond, 2 * time.Millisecond}
+	if len(previousWaits) != len(want) {
+		t.Fatalf("Expected %d retries, got previous waits %v", len(want), previousWaits)
+	}
+	for i := range want {
+		if previousWaits[i] != want[i] {
+			t.Errorf("Expected previous waits %v, got %v", want, previousWaits)
+			break
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package snitchdns
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitOpenError is returned without sending a request while the
+// client's circuit breaker is open, because the server failed too many
+// requests in a row. Every caller of the client then fails fast instead of
+// retrying against a server that is down.
+type CircuitOpenError struct {
+	Method string
+	Path   string
+
+	// Failures is the number of consecutive failed attempts that opened
+	// the circuit
+	Failures int
+
+	// RetryAt is when the next request is let through to probe whether the
+	// server recovered
+	RetryAt time.Time
+
+	// Err is the failure of the last attempt
+	Err error
+}
+
+// Error implements the error interface
+func (e *CircuitOpenError) Error() string {
+	msg := fmt.Sprintf("SnitchDNS unavailable, circuit open after %d consecutive failures: API request %s %s was not sent", e.Failures, e.Method, e.Path)
+	if wait := time.Until(e.RetryAt).Round(time.Second); wait > 0 {
+		msg += fmt.Sprintf(", next attempt in %s", wait)
+	}
+	if e.Err != nil {
+		msg += fmt.Sprintf("; last error: %s", e.Err)
+	}
+	return msg
+}
+
+// Unwrap returns the failure of the last attempt
+func (e *CircuitOpenError) Unwrap() error {
+	return e.Err
+}
+
+// IsCircuitOpen reports whether err is a request that was not sent because
+// the client's circuit breaker is open
+func IsCircuitOpen(err error) bool {
+	var circuitErr *CircuitOpenError
+	return errors.As(err, &circuitErr)
+}
+
+// circuitBreaker counts consecutive failed attempts across all requests of
+// a client. Once threshold is reached the circuit is open and requests are
+// rejected until cooldown has passed; then a single request is let through
+// as a probe, whose outcome closes the circuit or opens it again.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	lastErr  error
+	openedAt time.Time
+	probing  bool
+}
+
+// allow returns nil when a request attempt may be sent, and reports whether
+// the attempt is the probe of an open circuit. The caller must report the
+// outcome of an allowed attempt with done, or call release when it makes
+// no attempt after all.
+func (b *circuitBreaker) allow(method, path string) (probe bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return false, nil
+	}
+
+	retryAt := b.openedAt.Add(b.cooldown)
+	if !b.probing && !time.Now().Before(retryAt) {
+		b.probing = true
+		return true, nil
+	}
+
+	return false, &CircuitOpenError{
+		Method:   method,
+		Path:     path,
+		Failures: b.failures,
+		RetryAt:  retryAt,
+		Err:      b.lastErr,
+	}
+}
+
+// done records the outcome of an allowed attempt: failure is nil when the
+// server answered, and the error of the attempt when it returned a server
+// error or no response at all
+func (b *circuitBreaker) done(probe bool, failure error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	if failure == nil {
+		b.failures = 0
+		b.lastErr = nil
+		return
+	}
+
+	b.failures++
+	b.lastErr = failure
+	if b.failures >= b.threshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// release frees the probe of an attempt that was not made or was cancelled
+// by its caller, which tells nothing about the server
+func (b *circuitBreaker) release(probe bool) {
+	if b == nil || !probe {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// CircuitBreaker returns the number of consecutive failures opening the
+// client's circuit breaker and the time it stays open, 0 when there is no
+// circuit breaker
+func (c *Client) CircuitBreaker() (threshold int, cooldown time.Duration) {
+	if c.breaker == nil {
+		return 0, 0
+	}
+	return c.breaker.threshold, c.breaker.cooldown
+}
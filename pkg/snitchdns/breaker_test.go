@@ -0,0 +1,122 @@
+package snitchdns
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker tests that the circuit opens after consecutive server
+// errors, fails requests without sending them, and closes after a
+// successful probe
+func TestCircuitBreaker(t *testing.T) {
+	healthy := atomic.Bool{}
+	requests := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key",
+		WithRetry(1, time.Millisecond, time.Millisecond),
+		WithCircuitBreaker(3, 50*time.Millisecond),
+	)
+
+	// Two attempts of the first request and one of the second open it
+	if _, err := client.GetZone("1"); IsCircuitOpen(err) || StatusCode(err) != http.StatusBadGateway {
+		t.Fatalf("Expected the first request to fail with 502, got %v", err)
+	}
+	_, err := client.GetZone("1")
+	if !IsCircuitOpen(err) || requests.Load() != 3 {
+		t.Fatalf("Expected the retry of the second request to fail fast, got %v after %d requests", err, requests.Load())
+	}
+	if StatusCode(err) != http.StatusBadGateway || !strings.Contains(err.Error(), "circuit open after 3 consecutive failures") {
+		t.Errorf("Expected the error to name the failures and wrap the last one, got %v", err)
+	}
+
+	// Requests fail fast during the cooldown
+	if _, err := client.GetZone("1"); !IsCircuitOpen(err) || requests.Load() != 3 {
+		t.Fatalf("Expected the request not to be sent, got %v after %d requests", err, requests.Load())
+	}
+
+	// A failed probe keeps it open for another cooldown, so the probe is
+	// not retried
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.GetZone("1"); !IsCircuitOpen(err) || requests.Load() != 4 {
+		t.Fatalf("Expected a single probe to be sent, got %v after %d requests", err, requests.Load())
+	}
+	if _, err := client.GetZone("1"); !IsCircuitOpen(err) || requests.Load() != 4 {
+		t.Fatalf("Expected the circuit to stay open, got %v after %d requests", err, requests.Load())
+	}
+
+	// A successful probe closes it
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetZone("1"); err != nil {
+			t.Fatalf("Expected request %d to succeed, got %v", i+1, err)
+		}
+	}
+	if requests.Load() != 6 {
+		t.Errorf("Expected 6 requests, got %d", requests.Load())
+	}
+}
+
+// TestCircuitBreakerClientErrors tests that responses other than server
+// errors reset the failure count
+func TestCircuitBreakerClientErrors(t *testing.T) {
+	requests := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1)%2 == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(0, 0, 0), WithCircuitBreaker(2, time.Minute))
+
+	for i := 0; i < 6; i++ {
+		if _, err := client.GetZone("1"); IsCircuitOpen(err) {
+			t.Fatalf("Expected alternating 503 and 404 not to open the circuit, got %v", err)
+		}
+	}
+}
+
+// TestCircuitBreakerProbe tests that only one request probes an open
+// circuit at a time
+func TestCircuitBreakerProbe(t *testing.T) {
+	b := &circuitBreaker{threshold: 1, cooldown: 0}
+	b.done(false, errors.New("connection refused"))
+
+	probe, err := b.allow("GET", "/zones")
+	if err != nil || !probe {
+		t.Fatalf("Expected a probe after the cooldown, got %t, %v", probe, err)
+	}
+	if _, err := b.allow("GET", "/zones"); !IsCircuitOpen(err) {
+		t.Errorf("Expected other requests to fail while probing, got %v", err)
+	}
+
+	// A cancelled probe lets the next request probe
+	b.release(probe)
+	if probe, err := b.allow("GET", "/zones"); err != nil || !probe {
+		t.Errorf("Expected a new probe after a cancelled one, got %t, %v", probe, err)
+	}
+
+	var disabled *circuitBreaker
+	if probe, err := disabled.allow("GET", "/zones"); err != nil || probe {
+		t.Errorf("Expected no circuit breaker to allow everything, got %t, %v", probe, err)
+	}
+}
@@ -0,0 +1,216 @@
+package snitchdns
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// responseCache keeps the bodies of successful GET responses by cacheKey.
+// Entries with an ETag or Last-Modified validator are revalidated with a
+// conditional request, which the server answers with 304 Not Modified while
+// they are current. Entries without a validator are served without a
+// request for ttl. Any other request than a GET drops all entries, since it
+// may have changed what they hold.
+type responseCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+
+	// generation counts the invalidations, so a response read while a
+	// change was made is not stored
+	generation uint64
+}
+
+// cacheEntry is a cached response body and its validators
+type cacheEntry struct {
+	body         []byte
+	etag         string
+	lastModified string
+	stored       time.Time
+}
+
+// validated reports whether the entry can be revalidated with a conditional
+// request
+func (e *cacheEntry) validated() bool {
+	return e.etag != "" || e.lastModified != ""
+}
+
+// newResponseCache returns an empty cache serving entries without
+// validators for ttl
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{ttl: ttl, entries: map[string]*cacheEntry{}}
+}
+
+// WithCache caches the responses of GET requests, cutting the time spent
+// refreshing many resources that read the same zones and records. Responses
+// carrying an ETag or Last-Modified header are revalidated with
+// If-None-Match or If-Modified-Since on every read; responses without one
+// are reused for ttl without asking the server. Any other request than a
+// GET empties the cache. A ttl of 0 or less leaves the cache disabled.
+//
+// Responses are cached by method, URL and the API key or session user they
+// were requested with. Changes made by other clients show up only once a
+// cached response without a validator expired, so keep ttl short.
+func WithCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = nil
+		if ttl > 0 {
+			c.cache = newResponseCache(ttl)
+		}
+	}
+}
+
+// requestCacheKey returns the cache key of a request for path, empty when
+// the cache is disabled
+func (c *Client) requestCacheKey(method, path string) string {
+	if c.cache == nil {
+		return ""
+	}
+	req, err := http.NewRequest(method, c.BaseURL+c.rewritePath(path), nil)
+	if err != nil {
+		return ""
+	}
+	c.addExtras(req)
+	return c.cacheKey(req)
+}
+
+// cacheKey returns the key of the response to a request: its method, the
+// URL it is sent to and who it is sent as, so responses are never served to
+// another user
+func (c *Client) cacheKey(req *http.Request) string {
+	if c.cache == nil {
+		return ""
+	}
+
+	// Requests carry the API key unless only a session authenticates them
+	var identity string
+	if c.APIKey != "" || c.session == nil {
+		sum := sha256.Sum256([]byte(c.APIKey))
+		identity = "key:" + hex.EncodeToString(sum[:8])
+	} else {
+		identity = "session:" + c.session.username
+	}
+	return req.Method + " " + req.URL.String() + " " + identity
+}
+
+// CacheTTL returns how long responses without a validator are cached, 0
+// when the response cache is disabled
+func (c *Client) CacheTTL() time.Duration {
+	if c.cache == nil {
+		return 0
+	}
+	return c.cache.ttl
+}
+
+// fresh returns the cached body of a GET request when it may be used
+// without asking the server
+func (rc *responseCache) fresh(method, key string) ([]byte, bool) {
+	if rc == nil || method != http.MethodGet {
+		return nil, false
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[key]
+	if !ok || entry.validated() {
+		return nil, false
+	}
+	if time.Since(entry.stored) >= rc.ttl {
+		delete(rc.entries, key)
+		return nil, false
+	}
+	return entry.body, true
+}
+
+// lookup returns the entry of a GET request that can be revalidated, nil
+// if there is none, and the generation to store the response with
+func (rc *responseCache) lookup(method, key string) (*cacheEntry, uint64) {
+	if rc == nil || method != http.MethodGet {
+		return nil, 0
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[key]
+	if !ok || !entry.validated() {
+		return nil, rc.generation
+	}
+	return entry, rc.generation
+}
+
+// stores reports whether successful responses to the method are stored,
+// and so must be read in full
+func (rc *responseCache) stores(method string) bool {
+	return rc != nil && method == http.MethodGet
+}
+
+// store keeps the successful response to a GET request, unless the cache
+// was invalidated since generation
+func (rc *responseCache) store(method, key string, generation uint64, body []byte, header http.Header) {
+	if !rc.stores(method) {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if generation != rc.generation {
+		return
+	}
+	rc.entries[key] = &cacheEntry{
+		body:         bytes.Clone(body),
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+		stored:       time.Now(),
+	}
+}
+
+// invalidate drops all entries after a request with the method, unless it
+// was a GET
+func (rc *responseCache) invalidate(method string) {
+	if rc == nil || method == http.MethodGet {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries = map[string]*cacheEntry{}
+	rc.generation++
+}
+
+// setValidators adds the validators of a cached entry to a GET request
+func (e *cacheEntry) setValidators(req *http.Request) {
+	if e == nil {
+		return
+	}
+	if e.etag != "" {
+		req.Header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		req.Header.Set("If-Modified-Since", e.lastModified)
+	}
+}
+
+// cachedResponse returns a cached body, or streams it into decode. The body
+// is copied, so callers cannot change the cache.
+func cachedResponse(body []byte, decode responseDecoder) ([]byte, error) {
+	if decode == nil {
+		return bytes.Clone(body), nil
+	}
+	err := streamResponse(bytes.NewReader(body), decode)
+	var invalidBody *invalidResponseError
+	if errors.As(err, &invalidBody) {
+		return nil, fmt.Errorf("failed to parse response: %w", invalidBody.Err)
+	}
+	return nil, err
+}
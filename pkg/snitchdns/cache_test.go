@@ -0,0 +1,154 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestResponseCacheETag tests that responses with an ETag are revalidated
+// with If-None-Match and served from the cache on 304
+func TestResponseCacheETag(t *testing.T) {
+	requests := atomic.Int32{}
+	notModified := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`[{"id": 1, "type": "A", "data": "{\"address\": \"192.0.2.1\"}"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithCache(time.Minute))
+
+	for i := 0; i < 3; i++ {
+		records, err := client.ListRecords("1")
+		if err != nil || len(records) != 1 || records[0].Data.Get("address") != "192.0.2.1" {
+			t.Fatalf("Expected the record on read %d, got %+v, %v", i+1, records, err)
+		}
+	}
+	if requests.Load() != 3 || notModified.Load() != 2 {
+		t.Errorf("Expected every read to be revalidated, got %d requests, %d not modified", requests.Load(), notModified.Load())
+	}
+}
+
+// TestResponseCacheTTL tests that responses without a validator are served
+// without a request until the TTL passed
+func TestResponseCacheTTL(t *testing.T) {
+	requests := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	var cached []bool
+	client := NewClient(server.URL, "test-key",
+		WithCache(50*time.Millisecond),
+		WithRequestHook(func(_ context.Context, info RequestInfo) {
+			cached = append(cached, info.Cached)
+		}),
+	)
+
+	for i := 0; i < 2; i++ {
+		zone, err := client.GetZone("1")
+		if err != nil || zone.Domain != "example.com" {
+			t.Fatalf("Expected the zone on read %d, got %+v, %v", i+1, zone, err)
+		}
+	}
+	if requests.Load() != 1 || len(cached) != 2 || cached[0] || !cached[1] {
+		t.Fatalf("Expected the second read from the cache, got %d requests, cached %v", requests.Load(), cached)
+	}
+
+	// Other paths are not served from the cache
+	if _, err := client.GetZone("2"); err != nil || requests.Load() != 2 {
+		t.Fatalf("Expected another zone to be requested, got %d requests, %v", requests.Load(), err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.GetZone("1"); err != nil || requests.Load() != 3 {
+		t.Errorf("Expected an expired entry to be requested again, got %d requests, %v", requests.Load(), err)
+	}
+}
+
+// TestResponseCacheInvalidation tests that changes empty the cache, even
+// when they fail
+func TestResponseCacheInvalidation(t *testing.T) {
+	gets := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gets.Add(1)
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithCache(time.Minute))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetZone("1"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := client.DeleteZone("2"); StatusCode(err) != http.StatusNotFound {
+		t.Fatalf("Expected the delete to fail, got %v", err)
+	}
+	if _, err := client.GetZone("1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gets.Load() != 2 {
+		t.Errorf("Expected the zone to be read again after the delete, got %d requests", gets.Load())
+	}
+
+	// A disabled cache sends every request
+	client = NewClient(server.URL, "test-key", WithCache(0))
+	if _, err := client.GetZone("1"); err != nil || gets.Load() != 3 {
+		t.Errorf("Expected the zone to be requested, got %d requests, %v", gets.Load(), err)
+	}
+}
+
+// TestResponseCacheKey tests that responses are cached per API key and
+// extra query parameters
+func TestResponseCacheKey(t *testing.T) {
+	requests := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"id": 1, "domain": "` + r.Header.Get("X-SnitchDNS-Auth") + r.URL.Query().Get("tenant") + `.example.com"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		key, tenant string
+		requests    int32
+		domain      string
+	}{
+		{"a", "", 1, "a.example.com"},
+		{"a", "", 1, "a.example.com"},
+		{"b", "", 2, "b.example.com"},
+		{"a", "x", 3, "ax.example.com"},
+	}
+
+	cache := newResponseCache(time.Minute)
+	for i, tt := range tests {
+		client := NewClient(server.URL, tt.key, WithExtraQueryParams(map[string]string{"tenant": tt.tenant}))
+		client.cache = cache
+
+		zone, err := client.GetZone("1")
+		if err != nil || zone.Domain != tt.domain || requests.Load() != tt.requests {
+			t.Errorf("Read %d: expected %s after %d requests, got %+v after %d, %v", i+1, tt.domain, tt.requests, zone, requests.Load(), err)
+		}
+	}
+}
@@ -0,0 +1,1025 @@
+package snitchdns
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	emptyJSON = "{}"
+)
+
+// Client is the SnitchDNS API client.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its exported
+// fields are configuration and must not be modified once the client has been
+// shared; use the Option functions passed to NewClient instead. Any state that
+// changes while requests are in flight is kept in unexported fields guarded by
+// the client itself.
+type Client struct {
+	BaseURL      string
+	APIKey       string
+	HTTPClient   *http.Client
+	UserAgent    string
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	// RetryWaitMax also bounds the wait a Retry-After header asks for; a
+	// request told to wait longer fails without waiting
+	RetryWaitMax time.Duration
+
+	// Backoff decides the wait before each retry between RetryWaitMin and
+	// RetryWaitMax; nil uses ExponentialBackoff
+	Backoff Backoff
+
+	// DisableCompression stops requesting gzip-compressed responses
+	DisableCompression bool
+
+	// MaxElapsedTime bounds all attempts of a request and the waits between
+	// them; 0 leaves only MaxRetries and the context deadline
+	MaxElapsedTime time.Duration
+
+	// DebugLogging logs every HTTP request attempt, including redacted
+	// headers and bodies, to Logger
+	DebugLogging bool
+
+	// Logger receives the debug entries when DebugLogging is enabled
+	Logger Logger
+
+	// RetryableStatusCodes are the response statuses that are retried; nil
+	// retries 429 Too Many Requests and every 5xx status
+	RetryableStatusCodes []int
+
+	// RetryOnConnectionErrors retries requests that got no response, such
+	// as after a connection reset. Certificate and DNS lookup failures are
+	// never retried, since they do not go away on their own.
+	RetryOnConnectionErrors bool
+
+	// IdempotentRetries retries POST, PUT, PATCH and DELETE requests on the
+	// same failures as reads. Without it, they are only retried when the
+	// server cannot have applied them, after a 429 Too Many Requests or a
+	// connection that could not be made, since retrying a change that timed
+	// out may apply it twice.
+	IdempotentRetries bool
+
+	// RequestHook, if set, is called after every API call with its outcome
+	RequestHook RequestHook
+
+	// AttemptHook, if set, is called after every HTTP request attempt
+	AttemptHook AttemptHook
+
+	// MetricsCollector, if set, receives the outcome and duration of every
+	// HTTP request attempt and every retry
+	MetricsCollector MetricsCollector
+
+	// PathRewrites maps upstream route prefixes to the ones the server uses
+	PathRewrites map[string]string
+
+	// ExtraHeaders are sent with every request; the client's own headers
+	// take precedence
+	ExtraHeaders map[string]string
+
+	// ExtraQueryParams are added to every request; the request's own
+	// parameters take precedence
+	ExtraQueryParams map[string]string
+
+	// RequestValidator, if set, checks every JSON request body before it is
+	// sent; requests it rejects are never sent
+	RequestValidator RequestValidator
+
+	redactor *redactor
+
+	// sensitiveKeys are redacted in addition to the default sensitive keys,
+	// see WithSensitiveKeys
+	sensitiveKeys []string
+
+	// tracer, if set, records a span per API call
+	tracer trace.Tracer
+
+	// requestSlots bounds the number of HTTP requests in flight; nil
+	// leaves them unbounded
+	requestSlots chan struct{}
+
+	// middleware wraps every HTTP request attempt, outermost first
+	middleware []Middleware
+
+	// breaker fails requests fast while the server keeps failing; nil
+	// never does
+	breaker *circuitBreaker
+
+	// transport is the transport options such as WithTLSConfig and
+	// WithProxy configure, see ownTransport
+	transport *http.Transport
+
+	// session, if set, authenticates requests with a web session instead
+	// of the API key
+	session *sessionAuth
+
+	// cache, if set, keeps the responses of GET requests, see WithCache
+	cache *responseCache
+
+	// features caches whether the server serves each optional feature, see
+	// HasFeature
+	featuresMu sync.Mutex
+	features   map[Feature]bool
+}
+
+// NewClient creates a new SnitchDNS API client
+func NewClient(baseURL, apiKey string, opts ...Option) *Client {
+	c := &Client{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		UserAgent:    "terraform-provider-snitchdns/dev",
+		MaxRetries:   3,
+		RetryWaitMin: 1 * time.Second,
+		RetryWaitMax: 30 * time.Second,
+		DebugLogging: false,
+
+		RetryOnConnectionErrors: true,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.redactor = newRedactor()
+	c.redactor.addKeys(c.sensitiveKeys...)
+	c.redactor.addValue(c.APIKey)
+	if c.session != nil {
+		c.redactor.addValue(c.session.password)
+	}
+	for _, value := range c.ExtraHeaders {
+		c.redactor.addValue(value)
+	}
+
+	return c
+}
+
+// doRequestWithContext performs an HTTP request with authentication and context
+func (c *Client) doRequestWithContext(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	var jsonData []byte
+	var err error
+
+	if body != nil {
+		jsonData, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+
+	return c.doRawRequestWithContext(ctx, method, path, "application/json", jsonData)
+}
+
+// doJSONRequest performs an HTTP request like doRequestWithContext and
+// decodes the response into result. A response that cannot be decoded fails
+// with an APIError naming the request ID, like a failed request.
+func (c *Client) doJSONRequest(ctx context.Context, method, path string, body, result interface{}) error {
+	var jsonData []byte
+	if body != nil {
+		var err error
+		if jsonData, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+
+	_, err := c.doRequest(ctx, method, path, "application/json", jsonData, func(r io.Reader) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, result)
+	})
+	return err
+}
+
+// doRawRequestWithContext performs an HTTP request with an already encoded
+// body of the given content type
+func (c *Client) doRawRequestWithContext(ctx context.Context, method, path, contentType string, body []byte) ([]byte, error) {
+	return c.doRequest(ctx, method, path, contentType, body, nil)
+}
+
+// doRequest performs an API call. With a decode function, successful
+// responses are streamed into it instead of being returned.
+func (c *Client) doRequest(ctx context.Context, method, path, contentType string, body []byte, decode responseDecoder) ([]byte, error) {
+	if c.RequestValidator != nil && body != nil && contentType == "application/json" {
+		if err := c.RequestValidator(method, path, body); err != nil {
+			return nil, &RequestValidationError{Method: method, Path: path, Err: err}
+		}
+	}
+
+	info := RequestInfo{
+		Method:    method,
+		Path:      path,
+		RequestID: newRequestID(),
+	}
+	start := time.Now()
+
+	ctx, span := c.startSpan(ctx, info)
+	var respBody []byte
+	var err error
+	if cached, ok := c.cache.fresh(method, c.requestCacheKey(method, path)); ok {
+		info.StatusCode = http.StatusOK
+		info.Cached = true
+		respBody, err = cachedResponse(cached, decode)
+	} else {
+		respBody, err = c.retryRequest(ctx, body, contentType, decode, &info)
+		// Even a failed change may have been applied
+		c.cache.invalidate(method)
+	}
+
+	info.Duration = time.Since(start)
+	info.Err = err
+	endSpan(span, info)
+
+	if c.RequestHook != nil {
+		c.RequestHook(ctx, info)
+	}
+
+	return respBody, err
+}
+
+// retryRequest performs the request attempts of an API call, recording the
+// number of attempts and the last status code in info
+func (c *Client) retryRequest(ctx context.Context, body []byte, contentType string, decode responseDecoder, info *RequestInfo) ([]byte, error) {
+	method, path, requestID := info.Method, info.Path, info.RequestID
+	metrics, endpoint := c.metrics(), metricsEndpoint(info.Method, info.Path)
+
+	// Retry logic
+	start := time.Now()
+	var attempts []Attempt
+	var lastErr error
+	var retryAfter, previousWait time.Duration
+	var hasRetryAfter bool
+
+	// The retry budget bounds the attempts and the waits between them
+	budgetCtx, budget := ctx, time.Time{}
+	if c.MaxElapsedTime > 0 {
+		var cancel context.CancelFunc
+		budgetCtx, cancel = context.WithTimeout(ctx, c.MaxElapsedTime)
+		defer cancel()
+		budget, _ = budgetCtx.Deadline()
+	}
+	budgetExhausted, retryAfterExceeded := false, false
+	relogin := false
+	// earlierMayHaveApplied is set once an attempt failed in a way that
+	// leaves open whether the server applied it
+	earlierMayHaveApplied := false
+
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		// While the server keeps failing, fail fast instead of retrying
+		probe, err := c.breaker.allow(method, path)
+		if err != nil {
+			return nil, err
+		}
+
+		var wait time.Duration
+		if attempt > 0 {
+			// Wait as the backoff strategy says, unless the server said
+			// when to retry
+			wait = c.calculateBackoff(attempt, previousWait)
+			if hasRetryAfter {
+				wait = retryAfter
+			}
+			previousWait = wait
+
+			// Don't wait longer than any computed backoff would; a retry
+			// made before the server's Retry-After would be refused again
+			if hasRetryAfter && c.RetryWaitMax > 0 && retryAfter > c.RetryWaitMax {
+				retryAfterExceeded = true
+				c.breaker.release(probe)
+				break
+			}
+
+			// Don't wait for a retry the context deadline or the retry
+			// budget won't allow
+			if deadline, ok := budgetCtx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+				budgetExhausted = deadline.Equal(budget)
+				c.breaker.release(probe)
+				break
+			}
+
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				c.breaker.release(probe)
+				return nil, &APIError{Method: method, Path: path, RequestID: requestID, Err: ctx.Err()}
+			}
+			metrics.ObserveRetry(endpoint)
+		}
+
+		info.Attempts++
+		sent := time.Now()
+		respBody, statusCode, header, err := c.executeRequest(budgetCtx, method, path, requestID, info.Attempts, body, contentType, decode)
+		metrics.ObserveRequest(endpoint, statusCode, time.Since(sent))
+		if c.AttemptHook != nil {
+			c.AttemptHook(ctx, AttemptInfo{
+				Method:     method,
+				Path:       path,
+				RequestID:  requestID,
+				Attempt:    info.Attempts,
+				StatusCode: statusCode,
+				Duration:   time.Since(sent),
+				Err:        err,
+			})
+		}
+		info.StatusCode = statusCode
+		hasRetryAfter = false
+		var invalidBody *invalidResponseError
+		if errors.As(err, &invalidBody) {
+			// A malformed response is not fixed by asking again
+			c.breaker.done(probe, nil)
+			return nil, &APIError{
+				Method:     method,
+				Path:       path,
+				StatusCode: statusCode,
+				RequestID:  requestID,
+				Err:        fmt.Errorf("failed to parse response: %w", invalidBody.Err),
+			}
+		}
+		if err != nil {
+			// Check if error is context-related (don't retry)
+			if ctx.Err() != nil {
+				c.breaker.release(probe)
+				return nil, &APIError{Method: method, Path: path, RequestID: requestID, Err: ctx.Err()}
+			}
+			lastErr = &APIError{
+				Method:                       method,
+				Path:                         path,
+				RequestID:                    requestID,
+				Attempt:                      info.Attempts,
+				EarlierAttemptMayHaveApplied: earlierMayHaveApplied,
+				Err:                          err,
+			}
+			c.breaker.done(probe, lastErr)
+			mayHaveApplied := mayHaveReachedServer(0, err)
+			earlierMayHaveApplied = earlierMayHaveApplied || mayHaveApplied
+			if budgetCtx.Err() != nil {
+				// The retry budget ran out during the attempt
+				attempts = append(attempts, Attempt{Wait: wait, MayHaveApplied: mayHaveApplied, Err: lastErr})
+				budgetExhausted = true
+				break
+			}
+			if !c.RetryOnConnectionErrors || !isTransientError(err) || !c.retrySafe(method, 0, err) {
+				return nil, lastErr
+			}
+			attempts = append(attempts, Attempt{Wait: wait, MayHaveApplied: mayHaveApplied, Err: lastErr})
+			continue
+		}
+
+		// Success
+		if statusCode >= 200 && statusCode < 300 {
+			c.breaker.done(probe, nil)
+			return respBody, nil
+		}
+
+		apiErr := &APIError{
+			Method:     method,
+			Path:       path,
+			StatusCode: statusCode,
+			RequestID:  requestID,
+			Body:       string(respBody),
+			Attempt:    info.Attempts,
+
+			EarlierAttemptMayHaveApplied: earlierMayHaveApplied,
+		}
+		apiErr.parseBody()
+		apiErr.redact(c.redactor)
+
+		// Only server errors count against the circuit breaker; any other
+		// response shows the server is up
+		if statusCode >= http.StatusInternalServerError {
+			c.breaker.done(probe, apiErr)
+		} else {
+			c.breaker.done(probe, nil)
+		}
+
+		if statusCode == http.StatusUnauthorized && c.session != nil && !relogin {
+			// The session expired; the next attempt logs in again and does
+			// not count as a retry
+			relogin = true
+			attempt--
+			continue
+		}
+
+		if !c.retryableStatus(statusCode) || !c.retrySafe(method, statusCode, nil) {
+			return nil, apiErr
+		}
+
+		retryAfter, hasRetryAfter = parseRetryAfter(header.Get("Retry-After"), time.Now())
+		lastErr = apiErr
+		mayHaveApplied := mayHaveReachedServer(statusCode, nil)
+		earlierMayHaveApplied = earlierMayHaveApplied || mayHaveApplied
+		attempts = append(attempts, Attempt{Wait: wait, StatusCode: statusCode, MayHaveApplied: mayHaveApplied, Err: lastErr})
+	}
+
+	retryErr := &RetryError{
+		Retries:  len(attempts) - 1,
+		Attempts: attempts,
+		Elapsed:  time.Since(start),
+	}
+	if budgetExhausted {
+		retryErr.MaxElapsedTime = c.MaxElapsedTime
+	}
+	if retryAfterExceeded {
+		retryErr.RetryAfter = retryAfter
+	}
+	return nil, retryErr
+}
+
+// MaxConcurrentRequests returns the limit of requests in flight, 0 when
+// they are unbounded
+func (c *Client) MaxConcurrentRequests() int {
+	return cap(c.requestSlots)
+}
+
+// acquireRequestSlot waits until fewer than the configured maximum of
+// requests are in flight. The returned function frees the slot again.
+func (c *Client) acquireRequestSlot(ctx context.Context) (func(), error) {
+	if c.requestSlots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.requestSlots <- struct{}{}:
+		return func() { <-c.requestSlots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// executeRequest performs a single HTTP request attempt. attempt numbers
+// the attempts of an API call from 1 and is only used for logging. With a
+// decode function, a successful response body is streamed into it and no
+// body is returned.
+func (c *Client) executeRequest(ctx context.Context, method, path, requestID string, attempt int, body []byte, contentType string, decode responseDecoder) (respBody []byte, statusCode int, header http.Header, err error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewBuffer(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+c.rewritePath(path), reqBody)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addExtras(req)
+	if c.APIKey != "" || c.session == nil {
+		req.Header.Set("X-SnitchDNS-Auth", c.APIKey)
+	}
+	req.Header.Set(RequestIDHeader, requestID)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if !c.DisableCompression {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	cacheKey := c.cacheKey(req)
+	cached, cacheGeneration := c.cache.lookup(method, cacheKey)
+	cached.setValidators(req)
+	if since, ok := ctx.Value(ifModifiedSinceKey{}).(time.Time); ok && cached == nil {
+		req.Header.Set("If-Modified-Since", since.UTC().Format(http.TimeFormat))
+	}
+
+	sessionGeneration := 0
+	if c.session != nil {
+		if sessionGeneration, err = c.authenticate(ctx, req); err != nil {
+			return nil, 0, nil, err
+		}
+	}
+
+	release, err := c.acquireRequestSlot(ctx)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	defer release()
+
+	c.logRequest(ctx, req, attempt, body)
+	sent := time.Now()
+
+	resp, err := c.send(req)
+	if err != nil {
+		c.logResponse(ctx, req, attempt, nil, nil, time.Since(sent), err)
+		return nil, 0, nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			// Log the error but don't override the main error
+			_ = closeErr
+		}
+	}()
+
+	if c.session != nil {
+		c.session.saveCookies(resp)
+		if resp.StatusCode == http.StatusUnauthorized {
+			c.session.expire(sessionGeneration)
+		}
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		// The cached response is still current
+		c.logResponse(ctx, req, attempt, resp, nil, time.Since(sent), nil)
+		if decode != nil {
+			return nil, http.StatusOK, resp.Header, streamResponse(bytes.NewReader(cached.body), decode)
+		}
+		return bytes.Clone(cached.body), http.StatusOK, resp.Header, nil
+	}
+
+	reader, err := responseBody(resp)
+	if err != nil {
+		c.logResponse(ctx, req, attempt, resp, nil, time.Since(sent), err)
+		return nil, resp.StatusCode, resp.Header, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Bodies are read in full when they are logged, since debugging is
+	// not about memory, and when they are cached
+	if decode != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 && !c.debugEnabled() && !c.cache.stores(method) {
+		err = streamResponse(reader, decode)
+		c.logResponse(ctx, req, attempt, resp, nil, time.Since(sent), err)
+		return nil, resp.StatusCode, resp.Header, err
+	}
+
+	respBody, err = io.ReadAll(reader)
+	c.logResponse(ctx, req, attempt, resp, respBody, time.Since(sent), err)
+	if err != nil {
+		return nil, resp.StatusCode, resp.Header, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		c.cache.store(method, cacheKey, cacheGeneration, respBody, resp.Header)
+	}
+	if decode != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil, resp.StatusCode, resp.Header, streamResponse(bytes.NewReader(respBody), decode)
+	}
+	return respBody, resp.StatusCode, resp.Header, nil
+}
+
+// retryableStatus reports whether a response with the given status is
+// retried
+func (c *Client) retryableStatus(statusCode int) bool {
+	if c.RetryableStatusCodes == nil {
+		// Client errors are not retried, except rate limiting
+		return statusCode >= 500 || statusCode == http.StatusTooManyRequests
+	}
+	return slices.Contains(c.RetryableStatusCodes, statusCode)
+}
+
+// retrySafe reports whether a failed attempt of a request may be retried
+// without the risk of applying it twice: reads always may, changes only
+// with IdempotentRetries or when the server cannot have received them. A
+// 429 response is sent before the request is handled, and an error dialing
+// the server before it is sent.
+func (c *Client) retrySafe(method string, statusCode int, err error) bool {
+	if c.IdempotentRetries || !isMutatingMethod(method) {
+		return true
+	}
+	if err != nil {
+		return !mayHaveReachedServer(0, err)
+	}
+	return statusCode == http.StatusTooManyRequests
+}
+
+// mayHaveReachedServer reports whether a failed attempt could have been
+// received and applied by the server. An error dialing the server, such as
+// a refused connection, kept the request from it, and a 4xx response such
+// as 429 says it was not handled; a timeout, a connection broken after the
+// request was sent, or a 5xx response leave it open.
+func mayHaveReachedServer(statusCode int, err error) bool {
+	if err != nil {
+		var opErr *net.OpError
+		return !errors.As(err, &opErr) || opErr.Op != "dial"
+	}
+	return statusCode >= http.StatusInternalServerError
+}
+
+// isMutatingMethod reports whether requests with the method may change
+// server state
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// isTransientError reports whether a transport error may go away on a
+// retry. Failed certificate checks and unknown host names are permanent;
+// resets, refused connections, timeouts and unexpected EOFs are not.
+func isTransientError(err error) bool {
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidCert x509.CertificateInvalidError
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(err, &certErr), errors.As(err, &unknownAuthority),
+		errors.As(err, &hostnameErr), errors.As(err, &invalidCert):
+		return false
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		return false
+	}
+	return true
+}
+
+// parseRetryAfter parses a Retry-After header value, given either as delay
+// seconds or as an HTTP-date, into the time to wait from now. It reports
+// false when the value is missing or invalid.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
+
+// Zone represents a DNS zone
+type Zone struct {
+	ID         int      `json:"id,omitempty"`
+	UserID     int      `json:"user_id,omitempty"`
+	Domain     string   `json:"domain"`
+	Active     bool     `json:"active"`
+	CatchAll   bool     `json:"catch_all"`
+	Forwarding bool     `json:"forwarding"`
+	Regex      bool     `json:"regex"`
+	Master     bool     `json:"master,omitempty"`
+	Tags       ZoneTags `json:"tags,omitempty"`
+	CreatedAt  string   `json:"created_at,omitempty"`
+	UpdatedAt  string   `json:"updated_at,omitempty"`
+}
+
+// CreateZoneRequest is the request body for creating a zone
+type CreateZoneRequest struct {
+	Domain     string   `json:"domain"`
+	Active     bool     `json:"active"`
+	CatchAll   bool     `json:"catch_all"`
+	Forwarding bool     `json:"forwarding"`
+	Regex      bool     `json:"regex"`
+	Master     bool     `json:"master"`
+	Tags       ZoneTags `json:"tags"`
+	// UserID creates the zone for another user; only admin API keys may set it
+	UserID int `json:"user_id,omitempty"`
+}
+
+// UpdateZoneRequest is the request body for updating a zone
+type UpdateZoneRequest struct {
+	Domain     *string   `json:"domain,omitempty"`
+	Active     *bool     `json:"active,omitempty"`
+	CatchAll   *bool     `json:"catch_all,omitempty"`
+	Forwarding *bool     `json:"forwarding,omitempty"`
+	Regex      *bool     `json:"regex,omitempty"`
+	Tags       *ZoneTags `json:"tags,omitempty"`
+
+	// ExpectedUpdatedAt, if set, is the updated_at of the zone when it was
+	// last read. The update fails with a *ConflictError instead of being
+	// made when the zone was changed since.
+	ExpectedUpdatedAt string `json:"expected_updated_at,omitempty"`
+}
+
+// zonePageSize is the number of zones requested per page when listing zones
+const zonePageSize = 50
+
+// zonePage is a single page of the paginated zone listing
+type zonePage struct {
+	Page    int    `json:"page"`
+	Pages   int    `json:"pages"`
+	PerPage int    `json:"per_page"`
+	Total   int    `json:"total"`
+	Results []Zone `json:"results"`
+}
+
+// ListZones retrieves all zones the API key has access to
+func (c *Client) ListZones() ([]Zone, error) {
+	return c.ListZonesWithContext(context.Background())
+}
+
+// ListZonesWithContext retrieves all zones the API key has access to with
+// context, following the pagination until the last page
+func (c *Client) ListZonesWithContext(ctx context.Context) ([]Zone, error) {
+	var zones []Zone
+	err := c.WalkZones(ctx, func(zone Zone) error {
+		zones = append(zones, zone)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// SearchZonesWithContext retrieves the zones whose domain contains search,
+// letting the server narrow the listing. The server's matching is not
+// guaranteed to be exact, so callers filter the result again.
+func (c *Client) SearchZonesWithContext(ctx context.Context, search string) ([]Zone, error) {
+	var zones []Zone
+	err := c.walkZones(ctx, search, func(zone Zone) error {
+		zones = append(zones, zone)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// CreateZone creates a new DNS zone
+func (c *Client) CreateZone(req CreateZoneRequest) (*Zone, error) {
+	return c.CreateZoneWithContext(context.Background(), req)
+}
+
+// CreateZoneWithContext creates a new DNS zone with context
+func (c *Client) CreateZoneWithContext(ctx context.Context, req CreateZoneRequest) (*Zone, error) {
+	var zone Zone
+	if err := c.doJSONRequest(ctx, "POST", "/zones", req, &zone); err != nil {
+		return adoptRetriedZone(ctx, c, req, err)
+	}
+
+	return &zone, nil
+}
+
+// GetZone retrieves a zone by ID
+func (c *Client) GetZone(id string) (*Zone, error) {
+	return c.GetZoneWithContext(context.Background(), id)
+}
+
+// GetZoneWithContext retrieves a zone by ID with context
+func (c *Client) GetZoneWithContext(ctx context.Context, id string) (*Zone, error) {
+	var zone Zone
+	if err := c.doJSONRequest(ctx, "GET", fmt.Sprintf("/zones/%s", id), nil, &zone); err != nil {
+		return nil, err
+	}
+
+	return &zone, nil
+}
+
+// GetZoneIfModified retrieves a zone only if its updated_at timestamp differs
+// from the given one. The zone is requested with If-Modified-Since set to
+// the timestamp, so servers supporting conditional requests answer an
+// unchanged zone with 304 Not Modified and no body. Servers ignoring the
+// header send the zone, which is then compared by its timestamp. The boolean
+// result is false when the zone is unchanged, in which case the zone is nil.
+func (c *Client) GetZoneIfModified(ctx context.Context, id, updatedAt string) (*Zone, bool, error) {
+	if since, ok := parseUpdatedAt(updatedAt); ok {
+		ctx = context.WithValue(ctx, ifModifiedSinceKey{}, since)
+	}
+
+	var zone Zone
+	err := c.doJSONRequest(ctx, "GET", fmt.Sprintf("/zones/%s", id), nil, &zone)
+	if StatusCode(err) == http.StatusNotModified {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if updatedAt != "" && zone.UpdatedAt == updatedAt {
+		return nil, false, nil
+	}
+
+	return &zone, true, nil
+}
+
+// ifModifiedSinceKey is the context key of the time a conditional GET sends
+// as If-Modified-Since
+type ifModifiedSinceKey struct{}
+
+// updatedAtLayouts are the formats of updated_at timestamps
+var updatedAtLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05"}
+
+// parseUpdatedAt parses an updated_at timestamp, taking times without a
+// time zone for UTC
+func parseUpdatedAt(s string) (time.Time, bool) {
+	for _, layout := range updatedAtLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// UpdateZone updates an existing zone
+func (c *Client) UpdateZone(id string, req UpdateZoneRequest) (*Zone, error) {
+	return c.UpdateZoneWithContext(context.Background(), id, req)
+}
+
+// UpdateZoneWithContext updates an existing zone with context
+func (c *Client) UpdateZoneWithContext(ctx context.Context, id string, req UpdateZoneRequest) (*Zone, error) {
+	if err := checkZoneUnmodified(ctx, c, id, req.ExpectedUpdatedAt); err != nil {
+		return nil, err
+	}
+
+	var zone Zone
+	if err := c.doJSONRequest(ctx, "POST", fmt.Sprintf("/zones/%s", id), req, &zone); err != nil {
+		return nil, err
+	}
+
+	return &zone, nil
+}
+
+// DeleteZone deletes a zone
+func (c *Client) DeleteZone(id string) error {
+	return c.DeleteZoneWithContext(context.Background(), id)
+}
+
+// DeleteZoneWithContext deletes a zone with context
+func (c *Client) DeleteZoneWithContext(ctx context.Context, id string) error {
+	_, err := c.doRequestWithContext(ctx, "DELETE", fmt.Sprintf("/zones/%s", id), nil)
+	return err
+}
+
+// Record represents a DNS record
+type Record struct {
+	ID                 int    `json:"id,omitempty"`
+	ZoneID             int    `json:"zone_id,omitempty"`
+	Active             bool   `json:"active"`
+	Class              string `json:"cls"`
+	Type               string `json:"type"`
+	TTL                int    `json:"ttl"`
+	DataRaw            string `json:"data"`
+	IsConditional      bool   `json:"is_conditional"`
+	ConditionalCount   int    `json:"conditional_count,omitempty"`
+	ConditionalLimit   int    `json:"conditional_limit,omitempty"`
+	ConditionalReset   bool   `json:"conditional_reset,omitempty"`
+	ConditionalDataRaw string `json:"conditional_data,omitempty"`
+
+	// Parsed versions (not from JSON), still encoded so numbers are exact
+	Data            RawRecordData `json:"-"`
+	ConditionalData RawRecordData `json:"-"`
+}
+
+// parseData checks the JSON-encoded data and conditional_data strings
+// returned by the API and keeps them as raw record data.
+func (r *Record) parseData() error {
+	var err error
+
+	// Parse the data JSON string
+	if r.DataRaw != "" {
+		if r.Data, err = parseRawRecordData(r.DataRaw); err != nil {
+			return fmt.Errorf("failed to parse data field: %w", err)
+		}
+	}
+
+	// Parse the conditional_data JSON string
+	if r.ConditionalDataRaw != "" && r.ConditionalDataRaw != emptyJSON {
+		if r.ConditionalData, err = parseRawRecordData(r.ConditionalDataRaw); err != nil {
+			return fmt.Errorf("failed to parse conditional_data field: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CreateRecordRequest is the request body for creating a record
+type CreateRecordRequest struct {
+	Active           bool                   `json:"active"`
+	Class            string                 `json:"class"`
+	Type             string                 `json:"type"`
+	TTL              int                    `json:"ttl"`
+	Data             map[string]interface{} `json:"data"`
+	IsConditional    bool                   `json:"is_conditional"`
+	ConditionalCount int                    `json:"conditional_count"`
+	ConditionalLimit int                    `json:"conditional_limit"`
+	ConditionalReset bool                   `json:"conditional_reset"`
+	ConditionalData  map[string]interface{} `json:"conditional_data"`
+}
+
+// UpdateRecordRequest is the request body for updating a record
+type UpdateRecordRequest struct {
+	Active           *bool                  `json:"active,omitempty"`
+	Class            *string                `json:"class,omitempty"`
+	Type             *string                `json:"type,omitempty"`
+	TTL              *int                   `json:"ttl,omitempty"`
+	Data             map[string]interface{} `json:"data,omitempty"`
+	IsConditional    *bool                  `json:"is_conditional,omitempty"`
+	ConditionalCount *int                   `json:"conditional_count,omitempty"`
+	ConditionalLimit *int                   `json:"conditional_limit,omitempty"`
+	ConditionalReset *bool                  `json:"conditional_reset,omitempty"`
+	ConditionalData  map[string]interface{} `json:"conditional_data,omitempty"`
+}
+
+// CreateRecord creates a new DNS record
+func (c *Client) CreateRecord(zoneID string, req CreateRecordRequest) (*Record, error) {
+	return c.CreateRecordWithContext(context.Background(), zoneID, req)
+}
+
+// CreateRecordWithContext creates a new DNS record with context
+func (c *Client) CreateRecordWithContext(ctx context.Context, zoneID string, req CreateRecordRequest) (*Record, error) {
+	var record Record
+	if err := c.doJSONRequest(ctx, "POST", fmt.Sprintf("/zones/%s/records", zoneID), req, &record); err != nil {
+		return nil, err
+	}
+
+	if err := record.parseData(); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// GetRecord retrieves a record by zone ID and record ID
+func (c *Client) GetRecord(zoneID, recordID string) (*Record, error) {
+	return c.GetRecordWithContext(context.Background(), zoneID, recordID)
+}
+
+// GetRecordWithContext retrieves a record by zone ID and record ID with
+// context
+func (c *Client) GetRecordWithContext(ctx context.Context, zoneID, recordID string) (*Record, error) {
+	var record Record
+	if err := c.doJSONRequest(ctx, "GET", fmt.Sprintf("/zones/%s/records/%s", zoneID, recordID), nil, &record); err != nil {
+		return nil, err
+	}
+
+	if err := record.parseData(); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// ListRecords retrieves all records of a zone
+func (c *Client) ListRecords(zoneID string) ([]Record, error) {
+	return c.ListRecordsWithContext(context.Background(), zoneID)
+}
+
+// ListRecordsWithContext retrieves all records of a zone with context
+func (c *Client) ListRecordsWithContext(ctx context.Context, zoneID string) ([]Record, error) {
+	var records []Record
+	err := c.WalkRecords(ctx, zoneID, func(record Record) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// UpdateRecord updates an existing DNS record
+func (c *Client) UpdateRecord(zoneID, recordID string, req UpdateRecordRequest) (*Record, error) {
+	return c.UpdateRecordWithContext(context.Background(), zoneID, recordID, req)
+}
+
+// UpdateRecordWithContext updates an existing DNS record with context
+func (c *Client) UpdateRecordWithContext(ctx context.Context, zoneID, recordID string, req UpdateRecordRequest) (*Record, error) {
+	var record Record
+	if err := c.doJSONRequest(ctx, "POST", fmt.Sprintf("/zones/%s/records/%s", zoneID, recordID), req, &record); err != nil {
+		return nil, err
+	}
+
+	if err := record.parseData(); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// ResetConditionalCount sets the query count of a conditional record back
+// to zero, so it answers with its regular data again until the limit is hit
+func (c *Client) ResetConditionalCount(ctx context.Context, zoneID, recordID string) (*Record, error) {
+	zero := 0
+	return c.UpdateRecordWithContext(ctx, zoneID, recordID, UpdateRecordRequest{ConditionalCount: &zero})
+}
+
+// DeleteRecord deletes a DNS record
+func (c *Client) DeleteRecord(zoneID, recordID string) error {
+	return c.DeleteRecordWithContext(context.Background(), zoneID, recordID)
+}
+
+// DeleteRecordWithContext deletes a DNS record with context
+func (c *Client) DeleteRecordWithContext(ctx context.Context, zoneID, recordID string) error {
+	_, err := c.doRequestWithContext(ctx, "DELETE", fmt.Sprintf("/zones/%s/records/%s", zoneID, recordID), nil)
+	return err
+}
@@ -0,0 +1,914 @@
+package snitchdns
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+const testUserAgent = "terraform-provider-snitchdns/1.0.0"
+
+// TestRetryLogic tests that the client retries on transient failures
+func TestRetryLogic(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		count := attempts.Add(1)
+		if count < 3 {
+			// Fail the first 2 attempts with 503 Service Unavailable
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		// Succeed on the 3rd attempt
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "active": true, "catch_all": false, "forwarding": false, "regex": false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.MaxRetries = 3
+	client.RetryWaitMin = 10 * time.Millisecond
+	client.RetryWaitMax = 50 * time.Millisecond
+
+	zone, err := client.GetZone("1")
+	if err != nil {
+		t.Fatalf("Expected request to succeed after retries, got error: %v", err)
+	}
+
+	if zone == nil {
+		t.Fatal("Expected zone to be returned")
+	}
+
+	if attempts.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts.Load())
+	}
+}
+
+// TestRetryExhausted tests that the client gives up after max retries
+func TestRetryExhausted(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.MaxRetries = 2
+	client.RetryWaitMin = 1 * time.Millisecond
+	client.RetryWaitMax = 5 * time.Millisecond
+
+	_, err := client.GetZone("1")
+	if err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+
+	// Should attempt initial request + 2 retries = 3 total
+	if attempts.Load() != 3 {
+		t.Errorf("Expected 3 attempts (1 initial + 2 retries), got %d", attempts.Load())
+	}
+}
+
+// TestMaxElapsedTime tests that the retry budget stops retries and reports
+// the attempts made
+func TestMaxElapsedTime(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key",
+		WithRetry(100, 20*time.Millisecond, 20*time.Millisecond),
+		WithMaxElapsedTime(100*time.Millisecond),
+	)
+
+	start := time.Now()
+	_, err := client.GetZone("1")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the retry budget to stop retries, took %s", elapsed)
+	}
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Expected a RetryError, got %v", err)
+	}
+	if retryErr.MaxElapsedTime != 100*time.Millisecond || len(retryErr.Attempts) != int(attempts.Load()) {
+		t.Errorf("Expected the exhausted budget and %d attempts, got %+v", attempts.Load(), retryErr)
+	}
+	if attempts.Load() < 2 || attempts.Load() > 10 {
+		t.Errorf("Expected a few attempts within the budget, got %d", attempts.Load())
+	}
+}
+
+// TestMaxElapsedTimeSlowAttempt tests that an attempt still running when the
+// retry budget runs out is cancelled
+func TestMaxElapsedTimeSlowAttempt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithMaxElapsedTime(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := client.GetZone("1")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the attempt to be cancelled, took %s", elapsed)
+	}
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.MaxElapsedTime == 0 || len(retryErr.Attempts) != 1 {
+		t.Fatalf("Expected a RetryError for one attempt, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "retry budget of 50ms exhausted") {
+		t.Errorf("Expected the budget in the error, got %q", err)
+	}
+}
+
+// TestNoRetryOn4xx tests that 4xx errors are not retried
+func TestNoRetryOn4xx(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.MaxRetries = 3
+
+	_, err := client.GetZone("1")
+	if err == nil {
+		t.Fatal("Expected error for 404")
+	}
+
+	// Should only attempt once (no retries for 4xx)
+	if attempts.Load() != 1 {
+		t.Errorf("Expected 1 attempt (no retry on 4xx), got %d", attempts.Load())
+	}
+}
+
+// TestRetryOnTooManyRequests tests that 429 responses are retried after the
+// Retry-After delay
+func TestRetryOnTooManyRequests(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "active": true, "catch_all": false, "forwarding": false, "regex": false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.MaxRetries = 3
+	// A computed backoff this long would time the test out
+	client.RetryWaitMin = time.Minute
+	client.RetryWaitMax = time.Minute
+
+	if _, err := client.GetZone("1"); err != nil {
+		t.Fatalf("Expected request to succeed after retry, got error: %v", err)
+	}
+
+	if attempts.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+// TestRetryAfterDeadline tests that a Retry-After beyond the context deadline
+// fails the request without waiting
+func TestRetryAfterDeadline(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.MaxRetries = 3
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetZoneWithContext(ctx, "1")
+	if StatusCode(err) != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected no wait for the retry, took %s", elapsed)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts.Load())
+	}
+}
+
+// TestRetryAfterExceedsMaxWait tests that a Retry-After longer than
+// RetryWaitMax fails the request without waiting, even without a deadline
+func TestRetryAfterExceedsMaxWait(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(3, time.Millisecond, time.Second))
+
+	start := time.Now()
+	_, err := client.GetZoneWithContext(context.Background(), "1")
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.RetryAfter != time.Minute {
+		t.Fatalf("Expected a retry error for the Retry-After of 1m, got %v", err)
+	}
+	if StatusCode(err) != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected no wait for the retry, took %s", elapsed)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts.Load())
+	}
+}
+
+// TestParseRetryAfter tests parsing of delay seconds and HTTP-dates
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		wait  time.Duration
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "120", wait: 2 * time.Minute, ok: true},
+		{value: " 0 ", wait: 0, ok: true},
+		{value: "-1", ok: false},
+		{value: "soon", ok: false},
+		{value: "Mon, 01 Jan 2024 12:00:30 GMT", wait: 30 * time.Second, ok: true},
+		{value: "Mon, 01 Jan 2024 11:00:00 GMT", wait: 0, ok: true},
+	}
+
+	for _, tt := range tests {
+		wait, ok := parseRetryAfter(tt.value, now)
+		if wait != tt.wait || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %s, %t; expected %s, %t", tt.value, wait, ok, tt.wait, tt.ok)
+		}
+	}
+}
+
+// TestRetryableStatusCodes tests that only the configured statuses are retried
+func TestRetryableStatusCodes(t *testing.T) {
+	attempts := atomic.Int32{}
+	status := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key",
+		WithRetry(2, time.Millisecond, 5*time.Millisecond),
+		WithRetryPolicy([]int{http.StatusConflict}, true),
+	)
+
+	status.Store(http.StatusServiceUnavailable)
+	if _, err := client.GetZone("1"); err == nil {
+		t.Fatal("Expected error for 503")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Expected 503 not to be retried, got %d attempts", attempts.Load())
+	}
+
+	attempts.Store(0)
+	status.Store(http.StatusConflict)
+	if _, err := client.GetZone("1"); err == nil {
+		t.Fatal("Expected error for 409")
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("Expected 409 to be retried, got %d attempts", attempts.Load())
+	}
+}
+
+// TestRetryOnConnectionErrors tests that dropped connections are retried
+// unless disabled
+func TestRetryOnConnectionErrors(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		// Drop the connection without a response, like a flaky load balancer
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Failed to hijack connection: %v", err)
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(2, time.Millisecond, 5*time.Millisecond))
+	if _, err := client.GetZone("1"); err == nil {
+		t.Fatal("Expected error for a dropped connection")
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("Expected dropped connections to be retried, got %d attempts", attempts.Load())
+	}
+
+	attempts.Store(0)
+	client = NewClient(server.URL, "test-key",
+		WithRetry(2, time.Millisecond, 5*time.Millisecond),
+		WithRetryPolicy(nil, false),
+	)
+	if _, err := client.GetZone("1"); err == nil {
+		t.Fatal("Expected error for a dropped connection")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Expected no retries, got %d attempts", attempts.Load())
+	}
+}
+
+// TestIsTransientError tests the classification of transport errors
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{name: "EOF", err: fmt.Errorf("failed to execute request: %w", io.EOF), transient: true},
+		{name: "reset", err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}, transient: true},
+		{name: "refused", err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, transient: true},
+		{name: "unknown authority", err: fmt.Errorf("tls: %w", x509.UnknownAuthorityError{}), transient: false},
+		{name: "unknown host", err: &net.DNSError{Err: "no such host", Name: "snitch.invalid", IsNotFound: true}, transient: false},
+		{name: "DNS timeout", err: &net.DNSError{Err: "i/o timeout", Name: "snitch.example", IsTimeout: true}, transient: true},
+	}
+
+	for _, tt := range tests {
+		if got := isTransientError(tt.err); got != tt.transient {
+			t.Errorf("%s: expected transient=%t, got %t", tt.name, tt.transient, got)
+		}
+	}
+}
+
+// TestRetryOfChanges tests that changes are only retried when the server
+// cannot have applied them, unless IdempotentRetries is set
+func TestRetryOfChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		status     int
+		idempotent bool
+		attempts   int32
+	}{
+		{name: "read", method: http.MethodGet, status: http.StatusServiceUnavailable, attempts: 3},
+		{name: "change", method: http.MethodPost, status: http.StatusServiceUnavailable, attempts: 1},
+		{name: "change rate limited", method: http.MethodPut, status: http.StatusTooManyRequests, attempts: 3},
+		{name: "idempotent change", method: http.MethodDelete, status: http.StatusServiceUnavailable, idempotent: true, attempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := atomic.Int32{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key",
+				WithRetry(2, time.Millisecond, 5*time.Millisecond),
+				WithIdempotentRetries(tt.idempotent),
+			)
+			_, err := client.doRequestWithContext(context.Background(), tt.method, "/zones/1", nil)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Attempt != int(tt.attempts) {
+				t.Fatalf("Expected an API error of attempt %d, got %v", tt.attempts, err)
+			}
+			if attempts.Load() != tt.attempts {
+				t.Errorf("Expected %d attempts, got %d", tt.attempts, attempts.Load())
+			}
+		})
+	}
+}
+
+// TestRetrySafe tests which connection errors a change is retried on
+func TestRetrySafe(t *testing.T) {
+	client := NewClient("http://snitch.example", "test-key")
+
+	refused := &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}
+	reset := &net.OpError{Op: "read", Err: syscall.ECONNRESET}
+	if !client.retrySafe(http.MethodPost, 0, refused) {
+		t.Error("Expected a change to be retried when the connection was refused")
+	}
+	if client.retrySafe(http.MethodPost, 0, reset) {
+		t.Error("Expected a change not to be retried when the connection was reset")
+	}
+	if !client.retrySafe(http.MethodGet, 0, reset) {
+		t.Error("Expected a read to be retried when the connection was reset")
+	}
+}
+
+// TestUserAgentHeader tests that the user-agent header is set
+func TestUserAgentHeader(t *testing.T) {
+	var capturedUserAgent string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedUserAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "active": true, "catch_all": false, "forwarding": false, "regex": false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.UserAgent = testUserAgent
+
+	_, err := client.GetZone("1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if capturedUserAgent != testUserAgent {
+		t.Errorf("Expected User-Agent to be '%s', got '%s'", testUserAgent, capturedUserAgent)
+	}
+}
+
+// TestContextTimeout tests that context timeout is respected
+func TestContextTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// Delay longer than the context timeout
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetZoneWithContext(ctx, "1")
+	if err == nil {
+		t.Fatal("Expected timeout error")
+	}
+}
+
+// TestContextCancelWrite tests that cancelled contexts abort write operations
+func TestContextCancelWrite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("Expected no request with a cancelled context")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.CreateZoneWithContext(ctx, CreateZoneRequest{Domain: "example.com"}); err == nil {
+		t.Error("Expected error creating zone with a cancelled context")
+	}
+	if _, err := client.CreateRecordWithContext(ctx, "1", CreateRecordRequest{Type: "A", Class: "IN", TTL: 300}); err == nil {
+		t.Error("Expected error creating record with a cancelled context")
+	}
+}
+
+// TestDebugLogging tests that debug logging can be enabled
+func TestDebugLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "active": true, "catch_all": false, "forwarding": false, "regex": false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	// This test just ensures the client can be configured with debug logging
+	// Actual logging behavior is tested via tflog in provider tests
+	client.DebugLogging = true
+
+	_, err := client.GetZone("1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+// TestExponentialBackoff tests that retry delays increase exponentially
+func TestExponentialBackoff(t *testing.T) {
+	attempts := atomic.Int32{}
+	var timestamps []time.Time
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		timestamps = append(timestamps, time.Now())
+		count := attempts.Add(1)
+		if count < 4 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "active": true, "catch_all": false, "forwarding": false, "regex": false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.MaxRetries = 4
+	client.RetryWaitMin = 10 * time.Millisecond
+	client.RetryWaitMax = 100 * time.Millisecond
+
+	_, err := client.GetZone("1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Verify we made 4 attempts
+	if len(timestamps) != 4 {
+		t.Fatalf("Expected 4 attempts, got %d", len(timestamps))
+	}
+
+	// Verify delays are increasing (with some tolerance for timing variance)
+	delay1 := timestamps[1].Sub(timestamps[0])
+	delay2 := timestamps[2].Sub(timestamps[1])
+
+	// Each delay should be close to RetryWaitMin (allow 20% variance for timing jitter)
+	minDelay := 8 * time.Millisecond // 10ms - 20% tolerance
+	if delay1 < minDelay {
+		t.Errorf("First delay too short: %v (expected at least %v)", delay1, minDelay)
+	}
+
+	// Later delays should generally be longer (exponential backoff)
+	// We use a loose check because of timing variance in tests
+	if delay2 < delay1/2 {
+		t.Errorf("Expected exponential backoff, but delay2 (%v) < delay1/2 (%v)", delay2, delay1/2)
+	}
+}
+
+// TestListRecords tests that record listings are decoded including their data payloads
+func TestListRecords(t *testing.T) {
+	var capturedPath string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{"id": 1, "zone_id": 7, "active": true, "cls": "IN", "type": "A", "ttl": 300, "data": "{\"address\": \"10.0.0.1\"}", "is_conditional": false},
+			{"id": 2, "zone_id": 7, "active": true, "cls": "IN", "type": "CNAME", "ttl": 60, "data": "{\"name\": \"www.example.com\"}", "is_conditional": false}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	records, err := client.ListRecords("7")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if capturedPath != "/zones/7/records" {
+		t.Errorf("Expected path '/zones/7/records', got '%s'", capturedPath)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	if records[0].Data.Get("address") != "10.0.0.1" {
+		t.Errorf("Expected first record address '10.0.0.1', got '%v'", records[0].Data.Get("address"))
+	}
+
+	if records[1].Data.Get("name") != "www.example.com" {
+		t.Errorf("Expected second record name 'www.example.com', got '%v'", records[1].Data.Get("name"))
+	}
+}
+
+// TestResetConditionalCount tests that resetting sends only a zero count
+// to the record's update route
+func TestResetConditionalCount(t *testing.T) {
+	var capturedBody string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/zones/7/records/3" {
+			t.Errorf("Expected POST /zones/7/records/3, got %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		capturedBody = string(body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 3, "zone_id": 7, "active": true, "cls": "IN", "type": "A", "ttl": 300, "data": "{\"address\": \"10.0.0.1\"}", "is_conditional": true, "conditional_count": 0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	record, err := client.ResetConditionalCount(context.Background(), "7", "3")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if capturedBody != `{"conditional_count":0}` {
+		t.Errorf("Expected only conditional_count to be sent, got %s", capturedBody)
+	}
+	if record.ConditionalCount != 0 {
+		t.Errorf("Expected count 0, got %d", record.ConditionalCount)
+	}
+}
+
+// TestClientOptions tests that options are applied on construction
+func TestClientOptions(t *testing.T) {
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+
+	client := NewClient("http://localhost", "test-key",
+		WithHTTPClient(httpClient),
+		WithUserAgent(testUserAgent),
+		WithRetry(7, 2*time.Millisecond, 4*time.Millisecond),
+		WithDebugLogging(true),
+	)
+
+	if client.HTTPClient != httpClient {
+		t.Error("Expected custom HTTP client to be used")
+	}
+	if client.UserAgent != testUserAgent {
+		t.Errorf("Expected User-Agent '%s', got '%s'", testUserAgent, client.UserAgent)
+	}
+	if client.MaxRetries != 7 || client.RetryWaitMin != 2*time.Millisecond || client.RetryWaitMax != 4*time.Millisecond {
+		t.Errorf("Unexpected retry settings: %d, %v, %v", client.MaxRetries, client.RetryWaitMin, client.RetryWaitMax)
+	}
+	if !client.DebugLogging {
+		t.Error("Expected debug logging to be enabled")
+	}
+}
+
+// TestWithTimeout tests that the timeout is set on a copy of the HTTP client
+func TestWithTimeout(t *testing.T) {
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+
+	client := NewClient("http://localhost", "test-key",
+		WithHTTPClient(httpClient),
+		WithTimeout(2*time.Minute),
+	)
+
+	if client.HTTPClient.Timeout != 2*time.Minute {
+		t.Errorf("Expected timeout 2m, got %v", client.HTTPClient.Timeout)
+	}
+	if httpClient.Timeout != 5*time.Second {
+		t.Errorf("Expected the custom HTTP client to be left unchanged, got timeout %v", httpClient.Timeout)
+	}
+}
+
+// TestWithTLSConfig tests that requests use the configured TLS settings
+func TestWithTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "active": true, "catch_all": false, "forwarding": false, "regex": false}`))
+	}))
+	defer server.Close()
+
+	untrusted := NewClient(server.URL, "test-key", WithRetry(0, time.Millisecond, time.Millisecond))
+	if _, err := untrusted.GetZone("1"); err == nil {
+		t.Error("Expected an error for a server certificate from an unknown authority")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	trusted := NewClient(server.URL, "test-key", WithTLSConfig(&tls.Config{RootCAs: pool}))
+	if _, err := trusted.GetZone("1"); err != nil {
+		t.Errorf("Expected the configured CA to be trusted, got: %v", err)
+	}
+}
+
+// TestConcurrentRequests tests that a single client can be shared between goroutines
+func TestConcurrentRequests(t *testing.T) {
+	requests := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		count := requests.Add(1)
+		if count%3 == 0 {
+			// Force some requests through the retry path
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "active": true, "catch_all": false, "forwarding": false, "regex": false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(5, time.Millisecond, 5*time.Millisecond))
+
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		go func() {
+			_, err := client.GetZone("1")
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+}
+
+// TestMaxConcurrentRequests tests that requests over the limit wait for a free slot
+func TestMaxConcurrentRequests(t *testing.T) {
+	inFlight := atomic.Int32{}
+	peak := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithMaxConcurrentRequests(2))
+
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			_, err := client.GetZone("1")
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("Expected at most 2 requests in flight, got a peak of %d", got)
+	}
+
+	// A request waiting for a slot gives up with its context
+	release, _ := client.acquireRequestSlot(context.Background())
+	defer release()
+	other, _ := client.acquireRequestSlot(context.Background())
+	defer other()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetZoneWithContext(ctx, "1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to expire while waiting, got %v", err)
+	}
+}
+
+// TestGetZoneIfModified tests that unchanged zones are reported by their
+// timestamp when the server ignores If-Modified-Since
+func TestGetZoneIfModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "active": true, "catch_all": false, "forwarding": false, "regex": false, "updated_at": "2025-01-01T10:00:00"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	zone, modified, err := client.GetZoneIfModified(context.Background(), "1", "2025-01-01T10:00:00")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if modified || zone != nil {
+		t.Errorf("Expected unchanged zone to be skipped, got modified=%t zone=%v", modified, zone)
+	}
+
+	zone, modified, err = client.GetZoneIfModified(context.Background(), "1", "2024-12-31T09:00:00")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !modified || zone == nil || zone.Domain != "example.com" {
+		t.Errorf("Expected changed zone to be returned, got modified=%t zone=%v", modified, zone)
+	}
+}
+
+// TestGetZoneIfModifiedConditional tests that the zone is requested with
+// If-Modified-Since and that 304 Not Modified counts as unchanged
+func TestGetZoneIfModifiedConditional(t *testing.T) {
+	lastModified := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if err == nil && !lastModified.After(since) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "updated_at": "2025-01-01 10:00:00"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(0, 0, 0))
+
+	zone, modified, err := client.GetZoneIfModified(context.Background(), "1", "2025-01-01 10:00:00")
+	if err != nil || modified || zone != nil {
+		t.Errorf("Expected 304 to report an unchanged zone, got modified=%t zone=%v err=%v", modified, zone, err)
+	}
+
+	zone, modified, err = client.GetZoneIfModified(context.Background(), "1", "2024-12-31 09:00:00")
+	if err != nil || !modified || zone == nil || zone.Domain != "example.com" {
+		t.Errorf("Expected changed zone to be returned, got modified=%t zone=%v err=%v", modified, zone, err)
+	}
+}
+
+// TestListZonesPagination tests that all pages of the zone listing are fetched
+func TestListZonesPagination(t *testing.T) {
+	var pages []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		w.WriteHeader(http.StatusOK)
+		switch page {
+		case "1":
+			w.Write([]byte(`{"page": 1, "pages": 2, "per_page": 50, "total": 3, "results": [{"id": 1, "domain": "a.example.com"}, {"id": 2, "domain": "b.example.com"}]}`))
+		default:
+			w.Write([]byte(`{"page": 2, "pages": 2, "per_page": 50, "total": 3, "results": [{"id": 3, "domain": "c.example.com"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	zones, err := client.ListZones()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(pages) != 2 {
+		t.Errorf("Expected 2 page requests, got %d", len(pages))
+	}
+
+	if len(zones) != 3 || zones[2].Domain != "c.example.com" {
+		t.Errorf("Expected 3 zones ending with c.example.com, got %v", zones)
+	}
+}
+
+// TestRequestValidator tests that rejected requests are never sent
+func TestRequestValidator(t *testing.T) {
+	requests := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "active": true, "catch_all": false, "forwarding": false, "regex": false}`))
+	}))
+	defer server.Close()
+
+	rejection := errors.New("missing required field \"regex\"")
+	client := NewClient(server.URL, "test-key", WithRequestValidator(func(method, path string, body []byte) error {
+		if method == "POST" && path == "/zones" {
+			return rejection
+		}
+		return nil
+	}))
+
+	_, err := client.CreateZone(CreateZoneRequest{Domain: "example.com"})
+
+	var validationErr *RequestValidationError
+	if !errors.As(err, &validationErr) || !errors.Is(err, rejection) {
+		t.Fatalf("Expected a RequestValidationError wrapping the rejection, got %v", err)
+	}
+	if requests.Load() != 0 {
+		t.Errorf("Expected the rejected request not to be sent, got %d requests", requests.Load())
+	}
+
+	// Requests without a body are not validated
+	if _, err := client.GetZone("1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
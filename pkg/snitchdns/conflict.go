@@ -0,0 +1,64 @@
+package snitchdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ConflictError is returned by an update with an expected updated_at when
+// the object was changed since, e.g. by another Terraform workspace. The
+// update was not made, so the other change is not silently overwritten.
+type ConflictError struct {
+	Method string
+	Path   string
+
+	// ExpectedUpdatedAt is the updated_at the caller last read, UpdatedAt
+	// the one of the object on the server
+	ExpectedUpdatedAt string
+	UpdatedAt         string
+}
+
+// Error implements the error interface
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("API request %s %s was not sent: the object was changed at %s, after it was last read at %s", e.Method, e.Path, e.UpdatedAt, e.ExpectedUpdatedAt)
+}
+
+// IsConflict reports whether err is an update rejected because the object
+// was changed since it was read, by the client or by the server with a 409
+// Conflict or 412 Precondition Failed response
+func IsConflict(err error) bool {
+	var conflictErr *ConflictError
+	if errors.As(err, &conflictErr) {
+		return true
+	}
+	status := StatusCode(err)
+	return status == http.StatusConflict || status == http.StatusPreconditionFailed
+}
+
+// zoneModificationChecker is the part of a backend needed to check a zone
+// for changes
+type zoneModificationChecker interface {
+	GetZoneIfModified(ctx context.Context, id, updatedAt string) (*Zone, bool, error)
+}
+
+// checkZoneUnmodified returns a *ConflictError when the zone's updated_at
+// is no longer the expected one. SnitchDNS servers ignore the
+// expected_updated_at field of the update, so the client checks it before
+// sending the update; a change in between is not detected. Nothing is
+// checked when expected is empty.
+func checkZoneUnmodified(ctx context.Context, backend zoneModificationChecker, id, expected string) error {
+	if expected == "" {
+		return nil
+	}
+
+	zone, modified, err := backend.GetZoneIfModified(ctx, id, expected)
+	if err != nil {
+		return err
+	}
+	if modified {
+		return &ConflictError{Method: "POST", Path: "/zones/" + id, ExpectedUpdatedAt: expected, UpdatedAt: zone.UpdatedAt}
+	}
+	return nil
+}
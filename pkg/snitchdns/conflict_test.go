@@ -0,0 +1,56 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestUpdateZoneConflict tests that an update with an expected updated_at
+// is only sent while the zone is unchanged
+func TestUpdateZoneConflict(t *testing.T) {
+	var updates int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			updates++
+			w.Write([]byte(`{"id": 1, "domain": "example.com", "updated_at": "2025-01-02T10:00:00"}`))
+			return
+		}
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "updated_at": "2025-01-01T10:00:00"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	active := true
+
+	_, err := client.UpdateZoneWithContext(context.Background(), "1", UpdateZoneRequest{Active: &active, ExpectedUpdatedAt: "2024-12-31T09:00:00"})
+	if !IsConflict(err) || updates != 0 {
+		t.Fatalf("Expected a conflict without an update, got %v after %d updates", err, updates)
+	}
+
+	zone, err := client.UpdateZoneWithContext(context.Background(), "1", UpdateZoneRequest{Active: &active, ExpectedUpdatedAt: "2025-01-01T10:00:00"})
+	if err != nil || updates != 1 || zone.UpdatedAt != "2025-01-02T10:00:00" {
+		t.Fatalf("Expected the update to be sent, got %+v, %v", zone, err)
+	}
+}
+
+// TestIsConflict tests conflicts detected by the client and by the server
+func TestIsConflict(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&ConflictError{Method: "POST", Path: "/zones/1"}, true},
+		{&APIError{StatusCode: http.StatusConflict}, true},
+		{&APIError{StatusCode: http.StatusPreconditionFailed}, true},
+		{&APIError{StatusCode: http.StatusBadRequest}, false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsConflict(tt.err); got != tt.want {
+			t.Errorf("IsConflict(%v) = %t, want %t", tt.err, got, tt.want)
+		}
+	}
+}
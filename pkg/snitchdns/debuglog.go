@@ -0,0 +1,103 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxDebugBodySize is the number of body bytes included in a debug entry;
+// longer bodies are truncated
+const maxDebugBodySize = 64 * 1024
+
+// sensitiveHeaders are headers whose values are never logged
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie", "X-Snitchdns-Auth"}
+
+// Logger receives the debug entries of a client with DebugLogging enabled.
+// Implementations must be safe for concurrent use.
+type Logger interface {
+	Debug(ctx context.Context, msg string, fields map[string]any)
+}
+
+// LoggerFunc adapts a function to the Logger interface
+type LoggerFunc func(ctx context.Context, msg string, fields map[string]any)
+
+// Debug calls f
+func (f LoggerFunc) Debug(ctx context.Context, msg string, fields map[string]any) {
+	f(ctx, msg, fields)
+}
+
+// debugEnabled reports whether request attempts are logged
+func (c *Client) debugEnabled() bool {
+	return c.DebugLogging && c.Logger != nil
+}
+
+// logRequest logs an HTTP request attempt before it is sent
+func (c *Client) logRequest(ctx context.Context, req *http.Request, attempt int, body []byte) {
+	if !c.debugEnabled() {
+		return
+	}
+
+	c.Logger.Debug(ctx, "SnitchDNS API HTTP request", map[string]any{
+		"http_method":  req.Method,
+		"http_url":     c.redactor.redactURL(req.URL.String()),
+		"http_headers": c.redactHeaders(req.Header),
+		"http_body":    c.debugBody(body),
+		"request_id":   req.Header.Get(RequestIDHeader),
+		"attempt":      attempt,
+	})
+}
+
+// logResponse logs the outcome of an HTTP request attempt. resp is nil when
+// no response was received.
+func (c *Client) logResponse(ctx context.Context, req *http.Request, attempt int, resp *http.Response, body []byte, elapsed time.Duration, err error) {
+	if !c.debugEnabled() {
+		return
+	}
+
+	fields := map[string]any{
+		"http_method": req.Method,
+		"http_url":    c.redactor.redactURL(req.URL.String()),
+		"request_id":  req.Header.Get(RequestIDHeader),
+		"attempt":     attempt,
+		"duration_ms": elapsed.Milliseconds(),
+	}
+	if resp != nil {
+		fields["status_code"] = resp.StatusCode
+		fields["http_headers"] = c.redactHeaders(resp.Header)
+		fields["http_body"] = c.debugBody(body)
+	}
+	if err != nil {
+		fields["error"] = c.redactor.redactString(err.Error())
+	}
+
+	c.Logger.Debug(ctx, "SnitchDNS API HTTP response", fields)
+}
+
+// redactHeaders renders headers for a debug entry, with the values of
+// sensitive headers, headers named like a sensitive key and registered
+// secrets replaced
+func (c *Client) redactHeaders(header http.Header) map[string]string {
+	rendered := make(map[string]string, len(header))
+	for name, values := range header {
+		name = http.CanonicalHeaderKey(name)
+		if slices.Contains(sensitiveHeaders, name) || c.redactor.isSensitiveKey(name) {
+			rendered[name] = redactedValue
+			continue
+		}
+		rendered[name] = c.redactor.redactString(strings.Join(values, ", "))
+	}
+	return rendered
+}
+
+// debugBody renders a request or response body for a debug entry, redacted
+// like bodies embedded into errors and truncated to maxDebugBodySize
+func (c *Client) debugBody(body []byte) string {
+	text := c.redactor.redactBody(string(body))
+	if len(text) > maxDebugBodySize {
+		text = text[:maxDebugBodySize] + "... (truncated)"
+	}
+	return text
+}
@@ -0,0 +1,159 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingLogger collects debug entries
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []map[string]any
+}
+
+func (l *recordingLogger) Debug(_ context.Context, msg string, fields map[string]any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := map[string]any{"msg": msg}
+	for key, value := range fields {
+		entry[key] = value
+	}
+	l.entries = append(l.entries, entry)
+}
+
+// TestDebugLoggingAttempts tests that every attempt is logged with redacted headers and bodies
+func TestDebugLoggingAttempts(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "username": "alice", "password": "hunter2"}`))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewClient(server.URL, "secret-key",
+		WithRetry(3, time.Millisecond, 5*time.Millisecond),
+		WithIdempotentRetries(true),
+		WithDebugLogging(true),
+		WithLogger(logger),
+	)
+
+	if _, err := client.doRequestWithContext(context.Background(), "POST", "/users", map[string]string{
+		"username": "alice", "password": "hunter2",
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(logger.entries) != 4 {
+		t.Fatalf("Expected a request and a response entry per attempt, got %d: %v", len(logger.entries), logger.entries)
+	}
+
+	request := logger.entries[2]
+	if request["msg"] != "SnitchDNS API HTTP request" || request["attempt"] != 2 || request["http_method"] != "POST" {
+		t.Errorf("Unexpected request entry: %v", request)
+	}
+	if url, _ := request["http_url"].(string); !strings.HasSuffix(url, "/users") {
+		t.Errorf("Expected the request URL to be logged, got %v", request["http_url"])
+	}
+	headers, _ := request["http_headers"].(map[string]string)
+	if headers["X-Snitchdns-Auth"] != redactedValue || headers["Content-Type"] != "application/json" {
+		t.Errorf("Expected the auth header to be redacted, got %v", headers)
+	}
+
+	response := logger.entries[3]
+	if response["status_code"] != http.StatusOK {
+		t.Errorf("Unexpected response entry: %v", response)
+	}
+	if headers, _ := response["http_headers"].(map[string]string); headers["Set-Cookie"] != redactedValue {
+		t.Errorf("Expected the cookie to be redacted, got %v", headers)
+	}
+
+	for _, entry := range logger.entries {
+		for key, value := range entry {
+			text, _ := value.(string)
+			if strings.Contains(text, "hunter2") || strings.Contains(text, "secret-key") {
+				t.Errorf("Expected secrets to be redacted from %s, got %q", key, text)
+			}
+		}
+		if body, ok := entry["http_body"].(string); ok && entry["status_code"] == http.StatusOK && !strings.Contains(body, "alice") {
+			t.Errorf("Expected the response body to be logged, got %q", body)
+		}
+	}
+}
+
+// TestDebugLoggingSensitiveKeys tests that configured sensitive keys are
+// redacted from query parameters, headers and bodies
+func TestDebugLoggingSensitiveKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Signature", "resp-sig")
+		w.Write([]byte(`{"id": 1, "x_signature": "body-sig", "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewClient(server.URL, "secret-key",
+		WithExtraQueryParams(map[string]string{"x_signature": "query-sig", "tenant": "blue"}),
+		WithSensitiveKeys("X_Signature", "X-Signature"),
+		WithDebugLogging(true),
+		WithLogger(logger),
+	)
+
+	if _, err := client.GetZone("1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(logger.entries) != 2 {
+		t.Fatalf("Expected a request and a response entry, got %v", logger.entries)
+	}
+	url, _ := logger.entries[0]["http_url"].(string)
+	if strings.Contains(url, "query-sig") || !strings.Contains(url, "x_signature="+redactedValue) || !strings.Contains(url, "tenant=blue") {
+		t.Errorf("Expected only the sensitive query parameter to be redacted, got %s", url)
+	}
+	response := logger.entries[1]
+	if headers, _ := response["http_headers"].(map[string]string); headers["X-Signature"] != redactedValue {
+		t.Errorf("Expected the sensitive header to be redacted, got %v", headers)
+	}
+	if body, _ := response["http_body"].(string); strings.Contains(body, "body-sig") || !strings.Contains(body, "example.com") {
+		t.Errorf("Expected the sensitive body field to be redacted, got %s", body)
+	}
+}
+
+// TestDebugLoggingDisabled tests that nothing is logged unless debug logging is enabled
+func TestDebugLoggingDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(emptyJSON))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewClient(server.URL, "secret-key", WithLogger(logger))
+
+	if _, err := client.doRequestWithContext(context.Background(), "GET", "/zones/1", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(logger.entries) != 0 {
+		t.Errorf("Expected no entries, got %v", logger.entries)
+	}
+}
+
+// TestDebugBodyTruncated tests that long bodies are truncated
+func TestDebugBodyTruncated(t *testing.T) {
+	client := NewClient("http://localhost", "secret-key")
+
+	body := client.debugBody([]byte(strings.Repeat("x", maxDebugBodySize+10)))
+	if len(body) != maxDebugBodySize+len("... (truncated)") || !strings.HasSuffix(body, "(truncated)") {
+		t.Errorf("Expected the body to be truncated, got %d bytes", len(body))
+	}
+}
@@ -0,0 +1,46 @@
+// Package snitchdns is a Go client for the SnitchDNS API. It backs the
+// Terraform provider and can be used on its own, for example by scripts that
+// seed canary zones or read the query logs.
+//
+// Create a client with NewClient and configure it with Options:
+//
+//	c := snitchdns.NewClient("https://snitch.example.com", apiKey,
+//		snitchdns.WithTimeout(30*time.Second),
+//		snitchdns.WithRetry(3, time.Second, 30*time.Second),
+//	)
+//	zone, err := c.CreateZoneWithContext(ctx, snitchdns.CreateZoneRequest{
+//		Domain: "canary.example.com",
+//		Active: true,
+//	})
+//
+// ClientInterface lists every supported endpoint: zones, records, conditional
+// record counters, restrictions, notifications, query logs, settings, users
+// and API keys. Code written against it can be tested with the in-memory fake
+// in the snitchdnsmock subpackage. Helpers such as FindZoneByDomain and
+// CreateZones take a ClientInterface and work with any backend; RunParallel
+// runs such per-item requests over a bounded pool.
+// DiffRecords compares desired records with the records of a zone, returning
+// the creates, updates and deletes that reconcile them, for sync and audit
+// tooling.
+//
+// Errors of failed requests wrap an *APIError; use IsNotFound and the other
+// Is helpers, or StatusCode, instead of inspecting the error. Requests are
+// retried on connection errors and 429 and 5xx responses, see WithRetry and
+// WithRetryPolicy. WithCircuitBreaker makes all callers fail fast with a
+// *CircuitOpenError while the server keeps failing. Requests can be observed
+// or changed with WithRequestHook, WithAttemptHook and WithMiddleware; the
+// API key, and the values of keys added with WithSensitiveKeys, are redacted
+// from errors and debug logs. WithMetrics counts requests, retries and their
+// latency per endpoint; NewMetrics keeps them in memory for expvar or
+// Prometheus.
+//
+// Responses are requested gzip-compressed unless disabled with
+// WithCompression. Listings are decoded as they arrive instead of being read
+// in full first; WalkZones and WalkRecords hand them out one object at a
+// time.
+//
+// The exported API follows semantic versioning with the provider releases:
+// methods are only added to ClientInterface, never removed or changed, within
+// a major version. Implementations outside this module should embed a
+// ClientInterface to keep compiling when methods are added.
+package snitchdns
@@ -0,0 +1,260 @@
+package snitchdns
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestIDHeader is the header carrying the request correlation ID. The same
+// ID is sent on every attempt of a request so retries can be correlated too.
+const RequestIDHeader = "X-Request-ID"
+
+// SnitchDNS error codes returned in the "code" field of error responses
+const (
+	ErrCodeMissingFields       = 5000
+	ErrCodeEmptyDomain         = 5001
+	ErrCodeTags                = 5002
+	ErrCodeZoneExists          = 5003
+	ErrCodeInvalidData         = 5004
+	ErrCodeInvalidValue        = 5005
+	ErrCodeInvalidNotification = 5006
+	ErrCodeInvalidSubscription = 5007
+	ErrCodeNoData              = 5008
+	ErrCodeProviderDisabled    = 5009
+)
+
+// APIError describes a failed API request
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	RequestID  string
+	Body       string
+
+	// Code, Message and Details are parsed from the SnitchDNS error
+	// response body when it has the standard format
+	Code    int
+	Message string
+	Details string
+
+	// Attempt numbers the attempt of a retried request the error is the
+	// outcome of, from 1
+	Attempt int
+
+	// EarlierAttemptMayHaveApplied is set when an earlier attempt of the
+	// request could have been applied by the server, see
+	// Attempt.MayHaveApplied. A change failing on such a later attempt,
+	// e.g. as a duplicate, may have been made by the earlier one.
+	EarlierAttemptMayHaveApplied bool
+
+	// Err is the transport error when no response was received, the
+	// context error when the request was canceled, or the error parsing a
+	// successful response
+	Err error
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("API request %s %s (request ID %s) failed: %s", e.Method, e.Path, e.RequestID, e.Err)
+	}
+	return fmt.Sprintf("API request %s %s (request ID %s) failed with status %d: %s", e.Method, e.Path, e.RequestID, e.StatusCode, e.Body)
+}
+
+// Unwrap returns the underlying transport error, if any
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the HTTP status of the API error in err's chain, or 0
+// when no response was received
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// RequestID returns the correlation ID of the API request that failed with
+// err, or "" when no request was sent. Quote it to the SnitchDNS server
+// admins to find the request in their logs.
+func RequestID(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RequestID
+	}
+	return ""
+}
+
+// IsNotFound reports whether err is an API error for a missing object, such
+// as a zone or record deleted outside of Terraform
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsForbidden reports whether err is an API error for a request the API key
+// is not allowed to make
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsUnprocessable reports whether err is an API error for a request the
+// server understood but rejected, such as invalid record data
+func IsUnprocessable(err error) bool {
+	return StatusCode(err) == http.StatusUnprocessableEntity
+}
+
+// RequestValidationError is returned for a request rejected by the client's
+// RequestValidator. The request was not sent.
+type RequestValidationError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+// Error implements the error interface
+func (e *RequestValidationError) Error() string {
+	return fmt.Sprintf("API request %s %s was not sent: %s", e.Method, e.Path, e.Err)
+}
+
+// Unwrap returns the validation failure
+func (e *RequestValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Attempt is the outcome of a single failed attempt of a retried request
+type Attempt struct {
+	// Wait is the backoff waited before this attempt
+	Wait time.Duration
+
+	// StatusCode is 0 when no response was received
+	StatusCode int
+
+	// MayHaveApplied is set when the server could have received and
+	// applied the attempt: it timed out, the connection broke after the
+	// request was sent, or the server answered with a 5xx status
+	MayHaveApplied bool
+
+	Err error
+}
+
+// RetryError is returned when every attempt of a request failed. It keeps
+// the outcome of all attempts so a dead server can be told apart from
+// intermittent failures, and a first failure such as a DNS timeout is not
+// hidden by the ones that followed.
+type RetryError struct {
+	Retries  int
+	Attempts []Attempt
+	Elapsed  time.Duration
+
+	// MaxElapsedTime is set when retries stopped because the client's
+	// retry budget ran out rather than after the last retry
+	MaxElapsedTime time.Duration
+
+	// RetryAfter is set when retries stopped because the server asked to
+	// wait longer than the client's RetryWaitMax before retrying
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *RetryError) Error() string {
+	if e.MaxElapsedTime > 0 {
+		return fmt.Sprintf("request failed after %d retries, retry budget of %s exhausted: %s (%s)", e.Retries, e.MaxElapsedTime, e.last(), e.Summary())
+	}
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("request failed after %d retries, server asked to retry after %s, longer than the maximum retry wait: %s (%s)", e.Retries, e.RetryAfter, e.last(), e.Summary())
+	}
+	return fmt.Sprintf("request failed after %d retries: %s (%s)", e.Retries, e.last(), e.Summary())
+}
+
+// Unwrap returns the errors of all attempts, the last attempt first, so
+// errors.As and StatusCode see the final outcome while errors.Is matches a
+// failure of any attempt
+func (e *RetryError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Attempts))
+	for i := len(e.Attempts) - 1; i >= 0; i-- {
+		if e.Attempts[i].Err != nil {
+			errs = append(errs, e.Attempts[i].Err)
+		}
+	}
+	return errs
+}
+
+// History returns one line per attempt with its full error, oldest first,
+// e.g. "attempt 2 after 1s: API request GET /zones/1 ... failed with status 503: ..."
+func (e *RetryError) History() string {
+	lines := make([]string, 0, len(e.Attempts))
+	for i, attempt := range e.Attempts {
+		line := fmt.Sprintf("attempt %d", i+1)
+		if attempt.Wait > 0 {
+			line += " after " + attempt.Wait.Round(time.Millisecond).String()
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", line, attempt.Err))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Summary returns a compact description of all attempts, e.g.
+// "attempts: 503, 503 after 1s, connection error after 2s; total 3.1s"
+func (e *RetryError) Summary() string {
+	parts := make([]string, 0, len(e.Attempts))
+	for _, attempt := range e.Attempts {
+		outcome := "connection error"
+		if attempt.StatusCode != 0 {
+			outcome = fmt.Sprintf("%d", attempt.StatusCode)
+		}
+		if attempt.Wait > 0 {
+			outcome += " after " + attempt.Wait.Round(time.Millisecond).String()
+		}
+		parts = append(parts, outcome)
+	}
+	return fmt.Sprintf("attempts: %s; total %s", strings.Join(parts, ", "), e.Elapsed.Round(time.Millisecond))
+}
+
+// last returns the error of the last attempt
+func (e *RetryError) last() error {
+	if len(e.Attempts) == 0 {
+		return nil
+	}
+	return e.Attempts[len(e.Attempts)-1].Err
+}
+
+// parseBody fills Code, Message and Details from the error response body,
+// leaving them empty when the body is not a SnitchDNS error response
+func (e *APIError) parseBody() {
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Details string `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(e.Body), &body); err != nil {
+		return
+	}
+	e.Code = body.Code
+	e.Message = body.Message
+	e.Details = body.Details
+}
+
+// redact removes secrets from the response body and the parsed fields
+func (e *APIError) redact(r *redactor) {
+	e.Body = r.redactBody(e.Body)
+	e.Message = r.redactString(e.Message)
+	e.Details = r.redactString(e.Details)
+}
+
+// newRequestID generates a random correlation ID for a request
+func newRequestID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// Fallback to using timestamp if crypto/rand fails (should never happen)
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
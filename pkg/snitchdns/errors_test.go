@@ -0,0 +1,240 @@
+package snitchdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestRequestIDHeader tests that a request ID is sent and reused across retries
+func TestRequestIDHeader(t *testing.T) {
+	var mu sync.Mutex
+	var requestIDs []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestIDs = append(requestIDs, r.Header.Get(RequestIDHeader))
+		mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.MaxRetries = 2
+	client.RetryWaitMin = 1 * time.Millisecond
+	client.RetryWaitMax = 5 * time.Millisecond
+
+	_, err := client.GetZone("1")
+	if err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+
+	if len(requestIDs) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(requestIDs))
+	}
+	if requestIDs[0] == "" {
+		t.Fatal("Expected a request ID header to be sent")
+	}
+	for _, id := range requestIDs[1:] {
+		if id != requestIDs[0] {
+			t.Errorf("Expected all attempts to share request ID %s, got %s", requestIDs[0], id)
+		}
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an APIError, got %T", err)
+	}
+	if apiErr.RequestID != requestIDs[0] || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Unexpected error fields: %+v", apiErr)
+	}
+	if !strings.Contains(err.Error(), "GET /zones/1") || !strings.Contains(err.Error(), requestIDs[0]) {
+		t.Errorf("Expected error to name the request and its ID, got: %s", err)
+	}
+}
+
+// TestRequestIDUniquePerCall tests that separate calls use separate request IDs
+func TestRequestIDUniquePerCall(t *testing.T) {
+	seen := map[string]bool{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen[r.Header.Get(RequestIDHeader)] = true
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	for i := 0; i < 5; i++ {
+		if _, err := client.GetZone("1"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if len(seen) != 5 {
+		t.Errorf("Expected 5 distinct request IDs, got %d", len(seen))
+	}
+}
+
+// TestRequestIDInErrors tests that requests failing after they were sent
+// name their request ID, even when no error status was returned
+func TestRequestIDInErrors(t *testing.T) {
+	var requestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.Header.Get(RequestIDHeader)
+		if r.URL.Path == "/zones/2" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		w.Write([]byte(`{"id": "not a number"`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	_, err := client.GetZone("1")
+	if err == nil || !strings.Contains(err.Error(), "failed to parse response") {
+		t.Fatalf("Expected a parse error, got %v", err)
+	}
+	if RequestID(err) != requestID || !strings.Contains(err.Error(), requestID) {
+		t.Errorf("Expected the parse error to name request ID %s, got %q: %s", requestID, RequestID(err), err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.GetZoneWithContext(ctx, "2")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the deadline to be exceeded, got %v", err)
+	}
+	if RequestID(err) == "" || !strings.Contains(err.Error(), RequestID(err)) {
+		t.Errorf("Expected the canceled request to name its request ID, got %s", err)
+	}
+
+	if RequestID(errors.New("not sent")) != "" {
+		t.Error("Expected no request ID for other errors")
+	}
+}
+
+// TestAPIErrorParsesBody tests that SnitchDNS error bodies are parsed into the error
+func TestAPIErrorParsesBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"success": false, "code": 5003, "message": "Domain already exists", "details": "example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	_, err := client.CreateZone(CreateZoneRequest{Domain: "example.com"})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an APIError, got %T", err)
+	}
+	if apiErr.Code != ErrCodeZoneExists || apiErr.Message != "Domain already exists" || apiErr.Details != "example.com" {
+		t.Errorf("Unexpected parsed error: %+v", apiErr)
+	}
+}
+
+// TestErrorStatusHelpers tests classifying errors by HTTP status, also when
+// wrapped by other errors
+func TestErrorStatusHelpers(t *testing.T) {
+	notFound := &APIError{StatusCode: http.StatusNotFound}
+	wrapped := fmt.Errorf("reading zone: %w", &RetryError{Attempts: []Attempt{{Err: &APIError{StatusCode: http.StatusUnprocessableEntity}}}})
+
+	tests := []struct {
+		name          string
+		err           error
+		notFound      bool
+		forbidden     bool
+		unprocessable bool
+		status        int
+	}{
+		{"not found", notFound, true, false, false, 404},
+		{"forbidden", &APIError{StatusCode: http.StatusForbidden}, false, true, false, 403},
+		{"wrapped unprocessable", wrapped, false, false, true, 422},
+		{"transport error", &APIError{Err: errors.New("connection refused")}, false, false, false, 0},
+		{"other error", errors.New("boom"), false, false, false, 0},
+		{"nil", nil, false, false, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(tt.err); got != tt.notFound {
+				t.Errorf("IsNotFound() = %v, want %v", got, tt.notFound)
+			}
+			if got := IsForbidden(tt.err); got != tt.forbidden {
+				t.Errorf("IsForbidden() = %v, want %v", got, tt.forbidden)
+			}
+			if got := IsUnprocessable(tt.err); got != tt.unprocessable {
+				t.Errorf("IsUnprocessable() = %v, want %v", got, tt.unprocessable)
+			}
+			if got := StatusCode(tt.err); got != tt.status {
+				t.Errorf("StatusCode() = %d, want %d", got, tt.status)
+			}
+		})
+	}
+}
+
+// TestRetryErrorSummary tests that exhausted retries report every attempt
+func TestRetryErrorSummary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(2, time.Millisecond, 5*time.Millisecond))
+
+	_, err := client.GetZone("1")
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Expected a RetryError, got %T", err)
+	}
+	if len(retryErr.Attempts) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(retryErr.Attempts))
+	}
+	if retryErr.Attempts[0].Wait != 0 || retryErr.Attempts[1].Wait == 0 {
+		t.Errorf("Expected only retries to have a wait, got %+v", retryErr.Attempts)
+	}
+
+	summary := retryErr.Summary()
+	if !strings.HasPrefix(summary, "attempts: 502, 502 after ") || !strings.Contains(summary, "; total ") {
+		t.Errorf("Unexpected summary: %s", summary)
+	}
+
+	// The last attempt is still reachable for status checks
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected last attempt's APIError to be unwrappable, got %v", err)
+	}
+
+	if history := strings.Split(retryErr.History(), "\n"); len(history) != 3 ||
+		!strings.HasPrefix(history[0], "attempt 1: ") || !strings.HasPrefix(history[2], "attempt 3 after ") {
+		t.Errorf("Unexpected history: %s", retryErr.History())
+	}
+}
+
+// TestRetryErrorUnwrap tests that every attempt's error is reachable, and
+// that the last attempt decides the status
+func TestRetryErrorUnwrap(t *testing.T) {
+	dnsErr := &net.DNSError{Err: "i/o timeout", Name: "snitch.example.com", IsTimeout: true}
+	err := fmt.Errorf("reading zone: %w", &RetryError{Attempts: []Attempt{
+		{Err: &APIError{Err: dnsErr}},
+		{Wait: time.Second, StatusCode: http.StatusServiceUnavailable, Err: &APIError{StatusCode: http.StatusServiceUnavailable}},
+	}})
+
+	var target *net.DNSError
+	if !errors.As(err, &target) || target != dnsErr {
+		t.Errorf("Expected the first attempt's DNS error to be reachable, got %v", err)
+	}
+	if StatusCode(err) != http.StatusServiceUnavailable {
+		t.Errorf("Expected the last attempt's status, got %d", StatusCode(err))
+	}
+}
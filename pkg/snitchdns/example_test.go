@@ -0,0 +1,40 @@
+package snitchdns_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns/snitchdnsmock"
+)
+
+func ExampleNewClient() {
+	c := snitchdns.NewClient("https://snitch.example.com", "api-key",
+		snitchdns.WithTimeout(30*time.Second),
+		snitchdns.WithRetry(3, time.Second, 30*time.Second),
+	)
+
+	zone, err := c.CreateZoneWithContext(context.Background(), snitchdns.CreateZoneRequest{
+		Domain: "canary.example.com",
+		Active: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(zone.ID)
+}
+
+func ExampleFindZoneByDomain() {
+	// Any ClientInterface works, here the in-memory fake
+	mock := snitchdnsmock.New()
+	mock.AddZone(snitchdns.Zone{Domain: "canary.example.com"})
+
+	zone, err := snitchdns.FindZoneByDomain(context.Background(), mock, "canary.example.com")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(zone.Domain)
+	// Output: canary.example.com
+}
@@ -0,0 +1,49 @@
+package snitchdns
+
+import (
+	"net/http"
+)
+
+// WithExtraHeaders sets headers sent with every request, e.g. for an
+// authenticating proxy in front of the server. They cannot replace the
+// headers the client sets itself, such as the API key. Their values are
+// treated as secrets and redacted from errors and debug logs.
+func WithExtraHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		c.ExtraHeaders = make(map[string]string, len(headers))
+		for name, value := range headers {
+			c.ExtraHeaders[name] = value
+		}
+	}
+}
+
+// WithExtraQueryParams sets query parameters added to every request, e.g.
+// a tenant selector of a proxy in front of the server. Parameters of the
+// request itself, such as the page of a listing, take precedence.
+func WithExtraQueryParams(params map[string]string) Option {
+	return func(c *Client) {
+		c.ExtraQueryParams = make(map[string]string, len(params))
+		for name, value := range params {
+			c.ExtraQueryParams[name] = value
+		}
+	}
+}
+
+// addExtras adds the extra headers and query parameters to a request. It
+// runs before the client sets its own headers, so those always win.
+func (c *Client) addExtras(req *http.Request) {
+	for name, value := range c.ExtraHeaders {
+		req.Header.Set(name, value)
+	}
+
+	if len(c.ExtraQueryParams) == 0 {
+		return
+	}
+	query := req.URL.Query()
+	for name, value := range c.ExtraQueryParams {
+		if !query.Has(name) {
+			query.Set(name, value)
+		}
+	}
+	req.URL.RawQuery = query.Encode()
+}
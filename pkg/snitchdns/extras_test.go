@@ -0,0 +1,51 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestExtraHeadersAndQueryParams tests that extras are added to every
+// request without replacing the client's own headers and parameters
+func TestExtraHeadersAndQueryParams(t *testing.T) {
+	var requests []*http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "org-secret is not a zone"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key",
+		WithExtraHeaders(map[string]string{"X-Org-Token": "org-secret", "X-SnitchDNS-Auth": "other-key"}),
+		WithExtraQueryParams(map[string]string{"tenant": "acme", "page": "99"}),
+		WithRetry(0, 0, 0),
+	)
+
+	_, err := client.SearchZonesWithContext(context.Background(), "example")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if strings.Contains(err.Error(), "org-secret") {
+		t.Errorf("Expected the extra header value to be redacted, got %v", err)
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(requests))
+	}
+	r := requests[0]
+	if got := r.Header.Get("X-Org-Token"); got != "org-secret" {
+		t.Errorf("Expected the extra header to be sent, got %q", got)
+	}
+	if got := r.Header.Get("X-SnitchDNS-Auth"); got != "test-key" {
+		t.Errorf("Expected the API key to win over an extra header, got %q", got)
+	}
+	query := r.URL.Query()
+	if query.Get("tenant") != "acme" || query.Get("page") != "1" || query.Get("search") != "example" {
+		t.Errorf("Expected the tenant to be added and the request's parameters kept, got %s", r.URL.RawQuery)
+	}
+}
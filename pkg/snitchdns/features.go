@@ -0,0 +1,65 @@
+package snitchdns
+
+import (
+	"context"
+	"fmt"
+)
+
+// Feature is an optional part of the SnitchDNS API. Stripped-down builds of
+// SnitchDNS may not serve its endpoints, which then answer 404 Not Found.
+type Feature string
+
+// Optional features of the API
+const (
+	// FeatureQueryLogs is the query log search and export, used by
+	// SearchLogs and ExportQueryLog
+	FeatureQueryLogs Feature = "query_logs"
+
+	// FeatureNotifications is the notifications API, used by
+	// ListNotificationProviders and the zone notification methods
+	FeatureNotifications Feature = "notifications"
+)
+
+// featureProbes are the cheap, read-only endpoints probed for each feature
+var featureProbes = map[Feature]string{
+	FeatureQueryLogs:     "/search?per_page=1",
+	FeatureNotifications: "/notifications/providers",
+}
+
+// HasFeature reports whether the server serves the endpoints of an optional
+// feature. The first call for a feature probes one of its endpoints, and the
+// server is taken to lack the feature when it answers 404 Not Found. The
+// result is kept for the lifetime of the client. Other failures, such as a
+// rejected API key, are returned and not kept, so the next call probes
+// again.
+func (c *Client) HasFeature(ctx context.Context, feature Feature) (bool, error) {
+	probe, ok := featureProbes[feature]
+	if !ok {
+		return false, fmt.Errorf("unknown feature %q", feature)
+	}
+
+	c.featuresMu.Lock()
+	available, probed := c.features[feature]
+	c.featuresMu.Unlock()
+	if probed {
+		return available, nil
+	}
+
+	_, err := c.doRequestWithContext(ctx, "GET", probe, nil)
+	switch {
+	case err == nil:
+		available = true
+	case IsNotFound(err):
+		available = false
+	default:
+		return false, err
+	}
+
+	c.featuresMu.Lock()
+	defer c.featuresMu.Unlock()
+	if c.features == nil {
+		c.features = map[Feature]bool{}
+	}
+	c.features[feature] = available
+	return available, nil
+}
@@ -0,0 +1,66 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// TestHasFeature tests that optional features are probed once and that a
+// missing endpoint marks the feature missing
+func TestHasFeature(t *testing.T) {
+	var probes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		if r.URL.Path == "/notifications/providers" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(0, 0, 0))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if ok, err := client.HasFeature(ctx, FeatureNotifications); err != nil || !ok {
+			t.Errorf("Expected notifications to be available, got %t, %v", ok, err)
+		}
+		if ok, err := client.HasFeature(ctx, FeatureQueryLogs); err != nil || ok {
+			t.Errorf("Expected query logs to be missing, got %t, %v", ok, err)
+		}
+	}
+	if n := probes.Load(); n != 2 {
+		t.Errorf("Expected 2 probes, got %d", n)
+	}
+
+	if _, err := client.HasFeature(ctx, Feature("unknown")); err == nil {
+		t.Error("Expected an error for an unknown feature")
+	}
+}
+
+// TestHasFeatureError tests that failed probes are returned and retried
+func TestHasFeatureError(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusUnauthorized)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(0, 0, 0))
+	ctx := context.Background()
+
+	if _, err := client.HasFeature(ctx, FeatureQueryLogs); StatusCode(err) != http.StatusUnauthorized {
+		t.Fatalf("Expected a 401 error, got %v", err)
+	}
+
+	status.Store(http.StatusOK)
+	if ok, err := client.HasFeature(ctx, FeatureQueryLogs); err != nil || !ok {
+		t.Errorf("Expected query logs to be available after a failed probe, got %t, %v", ok, err)
+	}
+}
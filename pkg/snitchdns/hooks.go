@@ -0,0 +1,84 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes a completed API call, including all of its attempts
+type RequestInfo struct {
+	Method    string
+	Path      string
+	RequestID string
+
+	// StatusCode is the status of the last attempt, 0 if no response was received
+	StatusCode int
+
+	// Attempts is the number of HTTP requests made, including retries
+	Attempts int
+
+	// Cached reports that the response was served from the response cache
+	// without a request, see WithCache
+	Cached bool
+
+	// Duration is the total time spent, including backoff between attempts
+	Duration time.Duration
+
+	// Err is the error returned to the caller, nil on success
+	Err error
+}
+
+// RequestHook is called after every API call with the call's context, so
+// implementations can log with fields already attached to the context.
+type RequestHook func(ctx context.Context, info RequestInfo)
+
+// AttemptInfo describes a single HTTP request attempt of an API call
+type AttemptInfo struct {
+	Method    string
+	Path      string
+	RequestID string
+
+	// Attempt numbers the attempt, from 1
+	Attempt int
+
+	// StatusCode is 0 if no response was received
+	StatusCode int
+
+	// Duration is the time the attempt took, without backoff
+	Duration time.Duration
+
+	// Err is the connection error of the attempt, nil when a response was
+	// received
+	Err error
+}
+
+// AttemptHook is called after every HTTP request attempt, including
+// retries, with the call's context
+type AttemptHook func(ctx context.Context, info AttemptInfo)
+
+// RequestValidator checks the JSON body of a request before it is sent. The
+// path is the upstream route, before any path rewrites.
+type RequestValidator func(method, path string, body []byte) error
+
+// RoundTripFunc sends a single HTTP request attempt
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of every HTTP request attempt, including
+// retries, so embedders can add headers such as signatures, record metrics
+// or audit requests. A middleware calls next to continue the chain, and may
+// return without calling it to answer the request itself. The request
+// already carries the authentication and request ID headers; headers added
+// by a middleware are not shown in debug logs.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// send sends a request attempt through the middleware chain. The first
+// middleware is the outermost, so it sees the request first and the
+// response last.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(c.HTTPClient.Do)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+	return next(req)
+}
@@ -0,0 +1,175 @@
+package snitchdns
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRequestHook tests that the hook is called once per API call with its outcome
+func TestRequestHook(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	var calls []RequestInfo
+	client := NewClient(server.URL, "test-key",
+		WithRetry(3, time.Millisecond, 5*time.Millisecond),
+		WithRequestHook(func(_ context.Context, info RequestInfo) {
+			calls = append(calls, info)
+		}),
+	)
+
+	if _, err := client.GetZone("1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected 1 hook call, got %d", len(calls))
+	}
+
+	info := calls[0]
+	if info.Method != "GET" || info.Path != "/zones/1" {
+		t.Errorf("Expected operation 'GET /zones/1', got '%s %s'", info.Method, info.Path)
+	}
+	if info.Attempts != 2 || info.StatusCode != http.StatusOK || info.Err != nil {
+		t.Errorf("Expected 2 attempts ending in 200 without error, got %+v", info)
+	}
+	if info.Duration <= 0 || info.RequestID == "" {
+		t.Errorf("Expected duration and request ID to be set, got %+v", info)
+	}
+}
+
+// TestAttemptHook tests that the hook is called for every attempt of a
+// retried call
+func TestAttemptHook(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	var calls []AttemptInfo
+	client := NewClient(server.URL, "test-key",
+		WithRetry(3, time.Millisecond, 5*time.Millisecond),
+		WithAttemptHook(func(_ context.Context, info AttemptInfo) {
+			calls = append(calls, info)
+		}),
+	)
+
+	if _, err := client.GetZone("1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(calls) != 3 {
+		t.Fatalf("Expected 3 hook calls, got %+v", calls)
+	}
+	for i, info := range calls {
+		status := http.StatusServiceUnavailable
+		if i == 2 {
+			status = http.StatusOK
+		}
+		if info.Attempt != i+1 || info.StatusCode != status || info.Method != "GET" || info.Path != "/zones/1" || info.Err != nil {
+			t.Errorf("Unexpected attempt %d: %+v", i+1, info)
+		}
+	}
+	if calls[0].RequestID == "" || calls[0].RequestID != calls[2].RequestID {
+		t.Errorf("Expected the attempts to share the request ID, got %q and %q", calls[0].RequestID, calls[2].RequestID)
+	}
+}
+
+// TestMiddleware tests that middleware wraps every attempt in the order it
+// was added and can change the request
+func TestMiddleware(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Signature") != "signed" {
+			t.Errorf("Expected the signature header, got %q", r.Header.Get("X-Signature"))
+		}
+		if attempts.Add(1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				resp, err := next(req)
+				order = append(order, name+" done")
+				return resp, err
+			}
+		}
+	}
+	sign := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Signature", "signed")
+			return next(req)
+		}
+	}
+
+	client := NewClient(server.URL, "test-key",
+		WithRetry(3, time.Millisecond, 5*time.Millisecond),
+		WithMiddleware(trace("outer"), sign),
+		WithMiddleware(trace("inner")),
+	)
+
+	if _, err := client.GetZone("1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"outer", "inner", "inner done", "outer done", "outer", "inner", "inner done", "outer done"}
+	if fmt.Sprint(order) != fmt.Sprint(expected) {
+		t.Errorf("Expected middleware calls %v, got %v", expected, order)
+	}
+}
+
+// TestMiddlewareShortCircuit tests that middleware can answer a request
+// without sending it
+func TestMiddlewareShortCircuit(t *testing.T) {
+	client := NewClient("http://127.0.0.1:0", "test-key",
+		WithMiddleware(func(RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(`{"id": 7, "domain": "cached.example.com"}`)),
+					Request:    req,
+				}, nil
+			}
+		}),
+	)
+
+	zone, err := client.GetZone("7")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if zone.Domain != "cached.example.com" {
+		t.Errorf("Expected the middleware's response, got %+v", zone)
+	}
+}
@@ -0,0 +1,51 @@
+package snitchdns
+
+import "context"
+
+// SettingInterceptAll is the server setting making the DNS daemon answer
+// queries for every name, including names no zone matches
+const SettingInterceptAll = "dns_daemon_intercept_all"
+
+// InterceptionSettings are the server-wide settings deciding which queries
+// SnitchDNS answers, beyond the catch_all of single zones
+type InterceptionSettings struct {
+	// InterceptAll answers queries for every name, the "respond to
+	// everything" mode of a lab server
+	InterceptAll bool
+}
+
+// settings returns the server settings of the interception settings
+func (s InterceptionSettings) settings() Settings {
+	return Settings{
+		SettingInterceptAll: FormatBool(s.InterceptAll),
+	}
+}
+
+// interceptionSettings reads the interception settings from the server
+// settings. Settings the server has never stored are disabled.
+func interceptionSettings(settings Settings) *InterceptionSettings {
+	return &InterceptionSettings{
+		InterceptAll: settings.Bool(SettingInterceptAll),
+	}
+}
+
+// GetInterceptionSettings retrieves the interception settings. Requires an
+// admin API key. It works with any backend.
+func GetInterceptionSettings(ctx context.Context, c ClientInterface) (*InterceptionSettings, error) {
+	settings, err := c.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return interceptionSettings(settings), nil
+}
+
+// UpdateInterceptionSettings sets the interception settings, leaving all
+// other server settings unchanged, and returns them as stored. Requires an
+// admin API key. It works with any backend.
+func UpdateInterceptionSettings(ctx context.Context, c ClientInterface, s InterceptionSettings) (*InterceptionSettings, error) {
+	settings, err := c.UpdateSettings(ctx, s.settings())
+	if err != nil {
+		return nil, err
+	}
+	return interceptionSettings(settings), nil
+}
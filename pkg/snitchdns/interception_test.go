@@ -0,0 +1,47 @@
+package snitchdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestInterceptionSettings tests that only the interception settings are
+// sent, and read back from the server settings
+func TestInterceptionSettings(t *testing.T) {
+	stored := map[string]interface{}{"dns_daemon_bind_port": 53}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			var sent map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Fatalf("Failed to decode request: %v", err)
+			}
+			if len(sent) != 1 {
+				t.Errorf("Expected only the interception settings to be sent, got %v", sent)
+			}
+			for name, value := range sent {
+				stored[name] = value
+			}
+		}
+		json.NewEncoder(w).Encode(stored)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	settings, err := GetInterceptionSettings(context.Background(), client)
+	if err != nil || settings.InterceptAll {
+		t.Fatalf("Expected interception to be disabled by default, got %+v, %v", settings, err)
+	}
+
+	settings, err = UpdateInterceptionSettings(context.Background(), client, InterceptionSettings{InterceptAll: true})
+	if err != nil || !settings.InterceptAll {
+		t.Fatalf("Expected interception to be enabled, got %+v, %v", settings, err)
+	}
+	if stored[SettingInterceptAll] != "1" || stored["dns_daemon_bind_port"] != 53 {
+		t.Errorf("Expected only %s to change, got %v", SettingInterceptAll, stored)
+	}
+}
@@ -0,0 +1,93 @@
+package snitchdns
+
+import (
+	"context"
+	"io"
+)
+
+//go:generate go run ./openapigen -spec openapi.json -out openapi_gen.go
+
+// ClientInterface is the SnitchDNS API as used by the provider. Client is
+// the hand-written implementation; builds with the openapi tag use a backend
+// generated from openapi.json instead, see NewBackend.
+type ClientInterface interface {
+	// Server
+	GetServerInfo(ctx context.Context) (*ServerInfo, error)
+	Ping(ctx context.Context) error
+	HasFeature(ctx context.Context, feature Feature) (bool, error)
+
+	// Zones
+	ListZones() ([]Zone, error)
+	ListZonesWithContext(ctx context.Context) ([]Zone, error)
+	SearchZonesWithContext(ctx context.Context, search string) ([]Zone, error)
+	CreateZone(req CreateZoneRequest) (*Zone, error)
+	CreateZoneWithContext(ctx context.Context, req CreateZoneRequest) (*Zone, error)
+	GetZone(id string) (*Zone, error)
+	GetZoneWithContext(ctx context.Context, id string) (*Zone, error)
+	GetZoneIfModified(ctx context.Context, id, updatedAt string) (*Zone, bool, error)
+	UpdateZone(id string, req UpdateZoneRequest) (*Zone, error)
+	UpdateZoneWithContext(ctx context.Context, id string, req UpdateZoneRequest) (*Zone, error)
+	DeleteZone(id string) error
+	DeleteZoneWithContext(ctx context.Context, id string) error
+	GetZoneStats(ctx context.Context, zoneID string) (*ZoneStats, error)
+	GetZoneQueryStats(ctx context.Context, zoneID string, params ZoneQueryStatsParams) (*ZoneQueryStats, error)
+	ImportZonesCSV(ctx context.Context, csv io.Reader) error
+
+	// Records
+	CreateRecord(zoneID string, req CreateRecordRequest) (*Record, error)
+	CreateRecordWithContext(ctx context.Context, zoneID string, req CreateRecordRequest) (*Record, error)
+	GetRecord(zoneID, recordID string) (*Record, error)
+	GetRecordWithContext(ctx context.Context, zoneID, recordID string) (*Record, error)
+	ListRecords(zoneID string) ([]Record, error)
+	ListRecordsWithContext(ctx context.Context, zoneID string) ([]Record, error)
+	UpdateRecord(zoneID, recordID string, req UpdateRecordRequest) (*Record, error)
+	UpdateRecordWithContext(ctx context.Context, zoneID, recordID string, req UpdateRecordRequest) (*Record, error)
+	DeleteRecord(zoneID, recordID string) error
+	DeleteRecordWithContext(ctx context.Context, zoneID, recordID string) error
+	ResetConditionalCount(ctx context.Context, zoneID, recordID string) (*Record, error)
+	ImportRecordsCSV(ctx context.Context, zoneID string, csv []byte) error
+
+	// Restrictions
+	ListRestrictions(ctx context.Context, zoneID string) ([]ZoneRestriction, error)
+	CreateRestriction(ctx context.Context, zoneID string, req RestrictionRequest) (*ZoneRestriction, error)
+	GetRestriction(ctx context.Context, zoneID, restrictionID string) (*ZoneRestriction, error)
+	UpdateRestriction(ctx context.Context, zoneID, restrictionID string, req RestrictionRequest) (*ZoneRestriction, error)
+	DeleteRestriction(ctx context.Context, zoneID, restrictionID string) error
+
+	// Notifications
+	ListNotificationProviders(ctx context.Context) ([]NotificationProvider, error)
+	ListZoneNotifications(ctx context.Context, zoneID string) ([]NotificationSubscription, error)
+	GetZoneNotification(ctx context.Context, zoneID, provider string) (*NotificationSubscription, error)
+	UpdateZoneNotification(ctx context.Context, zoneID, provider string, req UpdateNotificationRequest) (*NotificationSubscription, error)
+
+	// Query logs
+	SearchLogs(ctx context.Context, params SearchParams) (*SearchPage, error)
+	ExportQueryLog(ctx context.Context, params SearchParams, format QueryLogFormat) ([]byte, error)
+	ClearZoneLogs(ctx context.Context, zoneID string) error
+
+	// Administration
+	GetSettings(ctx context.Context) (Settings, error)
+	UpdateSettings(ctx context.Context, settings Settings) (Settings, error)
+	ListUsers(ctx context.Context) ([]User, error)
+	GetQuota(ctx context.Context) (*Quota, error)
+
+	// API keys
+	ListAPIKeys(ctx context.Context) ([]APIKey, error)
+	CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*APIKey, error)
+	GetAPIKey(ctx context.Context, id string) (*APIKey, error)
+	UpdateAPIKey(ctx context.Context, id string, req UpdateAPIKeyRequest) (*APIKey, error)
+	DeleteAPIKey(ctx context.Context, id string) error
+
+	// Aliases
+	ListAliases(ctx context.Context) ([]Alias, error)
+	CreateAlias(ctx context.Context, req AliasRequest) (*Alias, error)
+	GetAlias(ctx context.Context, id string) (*Alias, error)
+	UpdateAlias(ctx context.Context, id string, req AliasRequest) (*Alias, error)
+	DeleteAlias(ctx context.Context, id string) error
+
+	// Redaction
+	AddSensitiveValue(value string)
+}
+
+// Ensure the hand-written client implements the full API
+var _ ClientInterface = &Client{}
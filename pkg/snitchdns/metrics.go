@@ -0,0 +1,297 @@
+package snitchdns
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsCollector receives measurements of the client's HTTP requests, so
+// tooling built on the client can watch the health of the API. Endpoints
+// are the method and the route with numeric IDs replaced, such as
+// "GET /zones/{id}/records", which keeps them few enough to be used as
+// metric labels. Implementations must be safe for concurrent use.
+type MetricsCollector interface {
+	// ObserveRequest is called after every HTTP request attempt, including
+	// retries, with the response status, 0 if no response was received, and
+	// the time the attempt took
+	ObserveRequest(endpoint string, statusCode int, duration time.Duration)
+
+	// ObserveRetry is called before every retry of a request
+	ObserveRetry(endpoint string)
+}
+
+// NoopMetrics is a MetricsCollector discarding every measurement. Clients
+// without a collector use it.
+type NoopMetrics struct{}
+
+// ObserveRequest implements MetricsCollector
+func (NoopMetrics) ObserveRequest(string, int, time.Duration) {}
+
+// ObserveRetry implements MetricsCollector
+func (NoopMetrics) ObserveRetry(string) {}
+
+// DefaultLatencyBuckets are the upper bounds of the latency histogram
+// buckets of NewMetrics when none are given
+var DefaultLatencyBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Metrics is a MetricsCollector keeping request and retry counters and a
+// latency histogram per endpoint in memory.
+//
+// It implements expvar.Var, so it can be published with expvar.Publish,
+// and serves the counters in the Prometheus text format as an
+// http.Handler, without depending on a Prometheus library.
+type Metrics struct {
+	buckets []time.Duration
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointMetrics
+}
+
+// endpointMetrics are the measurements of a single endpoint
+type endpointMetrics struct {
+	requests map[int]int64
+	retries  int64
+	counts   []int64
+	sum      time.Duration
+}
+
+// NewMetrics creates an empty Metrics collector with the given latency
+// histogram bucket bounds, or DefaultLatencyBuckets when none are given
+func NewMetrics(buckets ...time.Duration) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+
+	return &Metrics{
+		buckets:   slices.Compact(buckets),
+		endpoints: map[string]*endpointMetrics{},
+	}
+}
+
+// endpoint returns the measurements of an endpoint, creating them on first
+// use. The caller must hold mu.
+func (m *Metrics) endpoint(name string) *endpointMetrics {
+	e, ok := m.endpoints[name]
+	if !ok {
+		e = &endpointMetrics{
+			requests: map[int]int64{},
+			counts:   make([]int64, len(m.buckets)+1),
+		}
+		m.endpoints[name] = e
+	}
+	return e
+}
+
+// ObserveRequest implements MetricsCollector
+func (m *Metrics) ObserveRequest(endpoint string, statusCode int, duration time.Duration) {
+	// The first bucket the duration fits in; the last one counts the
+	// durations above every bound
+	bucket, _ := slices.BinarySearch(m.buckets, duration)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := m.endpoint(endpoint)
+	e.requests[statusCode]++
+	e.counts[bucket]++
+	e.sum += duration
+}
+
+// ObserveRetry implements MetricsCollector
+func (m *Metrics) ObserveRetry(endpoint string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.endpoint(endpoint).retries++
+}
+
+// MetricsSnapshot is a copy of the measurements of a Metrics collector
+type MetricsSnapshot struct {
+	Endpoints map[string]EndpointMetrics `json:"endpoints"`
+}
+
+// EndpointMetrics are the measurements of a single endpoint
+type EndpointMetrics struct {
+	// Requests counts the request attempts by response status, with 0 for
+	// attempts that got no response
+	Requests map[int]int64 `json:"requests"`
+
+	// Retries counts the retried attempts
+	Retries int64 `json:"retries"`
+
+	// Latency is the distribution of the attempt durations
+	Latency LatencyHistogram `json:"latency"`
+}
+
+// LatencyHistogram is the distribution of request durations
+type LatencyHistogram struct {
+	// Bounds are the upper bounds of the buckets, in ascending order
+	Bounds []time.Duration `json:"bounds"`
+
+	// Counts holds the number of requests per bucket, one more than there
+	// are bounds: the last entry counts the requests slower than every
+	// bound. Counts are not cumulative.
+	Counts []int64 `json:"counts"`
+
+	// Count is the number of requests and Sum their total duration
+	Count int64         `json:"count"`
+	Sum   time.Duration `json:"sum"`
+}
+
+// Mean returns the average request duration, 0 without requests
+func (h LatencyHistogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// Snapshot returns a copy of the current measurements
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := MetricsSnapshot{Endpoints: make(map[string]EndpointMetrics, len(m.endpoints))}
+	for name, e := range m.endpoints {
+		var count int64
+		for _, n := range e.counts {
+			count += n
+		}
+
+		requests := make(map[int]int64, len(e.requests))
+		for status, n := range e.requests {
+			requests[status] = n
+		}
+
+		snapshot.Endpoints[name] = EndpointMetrics{
+			Requests: requests,
+			Retries:  e.retries,
+			Latency: LatencyHistogram{
+				Bounds: slices.Clone(m.buckets),
+				Counts: slices.Clone(e.counts),
+				Count:  count,
+				Sum:    e.sum,
+			},
+		}
+	}
+	return snapshot
+}
+
+// String returns the current measurements as JSON, which makes Metrics an
+// expvar.Var. Durations are in nanoseconds.
+func (m *Metrics) String() string {
+	data, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		// A snapshot only holds maps, numbers and strings
+		return emptyJSON
+	}
+	return string(data)
+}
+
+// ServeHTTP serves the current measurements in the Prometheus text format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+// WritePrometheus writes the current measurements in the Prometheus text
+// exposition format: the counters snitchdns_client_requests_total and
+// snitchdns_client_retries_total, and the histogram
+// snitchdns_client_request_duration_seconds, labeled by endpoint
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	snapshot := m.Snapshot()
+
+	endpoints := make([]string, 0, len(snapshot.Endpoints))
+	for name := range snapshot.Endpoints {
+		endpoints = append(endpoints, name)
+	}
+	sort.Strings(endpoints)
+
+	var b strings.Builder
+
+	b.WriteString("# HELP snitchdns_client_requests_total SnitchDNS API request attempts by endpoint and response status.\n")
+	b.WriteString("# TYPE snitchdns_client_requests_total counter\n")
+	for _, name := range endpoints {
+		requests := snapshot.Endpoints[name].Requests
+		statuses := make([]int, 0, len(requests))
+		for status := range requests {
+			statuses = append(statuses, status)
+		}
+		sort.Ints(statuses)
+		for _, status := range statuses {
+			fmt.Fprintf(&b, "snitchdns_client_requests_total{endpoint=%s,status=\"%d\"} %d\n", prometheusLabel(name), status, requests[status])
+		}
+	}
+
+	b.WriteString("# HELP snitchdns_client_retries_total SnitchDNS API request attempts that were retries, by endpoint.\n")
+	b.WriteString("# TYPE snitchdns_client_retries_total counter\n")
+	for _, name := range endpoints {
+		fmt.Fprintf(&b, "snitchdns_client_retries_total{endpoint=%s} %d\n", prometheusLabel(name), snapshot.Endpoints[name].Retries)
+	}
+
+	b.WriteString("# HELP snitchdns_client_request_duration_seconds Duration of SnitchDNS API request attempts, by endpoint.\n")
+	b.WriteString("# TYPE snitchdns_client_request_duration_seconds histogram\n")
+	for _, name := range endpoints {
+		latency := snapshot.Endpoints[name].Latency
+		label := prometheusLabel(name)
+
+		// Prometheus buckets are cumulative
+		var cumulative int64
+		for i, bound := range latency.Bounds {
+			cumulative += latency.Counts[i]
+			fmt.Fprintf(&b, "snitchdns_client_request_duration_seconds_bucket{endpoint=%s,le=\"%s\"} %d\n",
+				label, strconv.FormatFloat(bound.Seconds(), 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&b, "snitchdns_client_request_duration_seconds_bucket{endpoint=%s,le=\"+Inf\"} %d\n", label, latency.Count)
+		fmt.Fprintf(&b, "snitchdns_client_request_duration_seconds_sum{endpoint=%s} %s\n",
+			label, strconv.FormatFloat(latency.Sum.Seconds(), 'g', -1, 64))
+		fmt.Fprintf(&b, "snitchdns_client_request_duration_seconds_count{endpoint=%s} %d\n", label, latency.Count)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// prometheusLabel quotes a label value for the Prometheus text format,
+// which escapes backslashes, quotes and newlines
+func prometheusLabel(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+	return `"` + value + `"`
+}
+
+// metricsEndpoint returns the endpoint name of a request for metrics: the
+// method and the path without query, with numeric IDs replaced
+func metricsEndpoint(method, path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	return method + " " + spanRoute(path)
+}
+
+// metrics returns the client's collector, a NoopMetrics if none is set
+func (c *Client) metrics() MetricsCollector {
+	if c.MetricsCollector == nil {
+		return NoopMetrics{}
+	}
+	return c.MetricsCollector
+}
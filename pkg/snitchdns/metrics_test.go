@@ -0,0 +1,114 @@
+package snitchdns
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Metrics must be publishable with expvar.Publish
+var _ expvar.Var = &Metrics{}
+
+// TestMetrics tests that attempts, statuses and retries are counted per
+// endpoint
+func TestMetrics(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	metrics := NewMetrics()
+	client := NewClient(server.URL, "test-key",
+		WithRetry(3, time.Millisecond, 5*time.Millisecond),
+		WithMetrics(metrics),
+	)
+
+	if _, err := client.GetZone("1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.GetZone("2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	snapshot := metrics.Snapshot()
+	if len(snapshot.Endpoints) != 1 {
+		t.Fatalf("Expected IDs to share one endpoint, got %v", snapshot.Endpoints)
+	}
+
+	e, ok := snapshot.Endpoints["GET /zones/{id}"]
+	if !ok {
+		t.Fatalf("Expected endpoint 'GET /zones/{id}', got %v", snapshot.Endpoints)
+	}
+	if e.Requests[http.StatusServiceUnavailable] != 1 || e.Requests[http.StatusOK] != 2 || e.Retries != 1 {
+		t.Errorf("Expected one 503, two 200 and one retry, got %+v", e)
+	}
+	if e.Latency.Count != 3 || e.Latency.Sum <= 0 || e.Latency.Mean() <= 0 {
+		t.Errorf("Expected 3 timed attempts, got %+v", e.Latency)
+	}
+}
+
+// TestMetricsHistogram tests bucketing of durations, including durations on
+// a bound and above every bound
+func TestMetricsHistogram(t *testing.T) {
+	metrics := NewMetrics(time.Second, 100*time.Millisecond)
+	for _, d := range []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 500 * time.Millisecond, 2 * time.Second} {
+		metrics.ObserveRequest("GET /zones", 200, d)
+	}
+
+	latency := metrics.Snapshot().Endpoints["GET /zones"].Latency
+	if len(latency.Bounds) != 2 || latency.Bounds[0] != 100*time.Millisecond {
+		t.Errorf("Expected sorted bounds, got %v", latency.Bounds)
+	}
+	if want := []int64{2, 1, 1}; len(latency.Counts) != 3 || latency.Counts[0] != want[0] || latency.Counts[1] != want[1] || latency.Counts[2] != want[2] {
+		t.Errorf("Expected bucket counts %v, got %v", want, latency.Counts)
+	}
+	if latency.Mean() != 662500*time.Microsecond {
+		t.Errorf("Expected a mean of 662.5ms, got %s", latency.Mean())
+	}
+}
+
+// TestMetricsExport tests the expvar JSON and the Prometheus text format
+func TestMetricsExport(t *testing.T) {
+	metrics := NewMetrics(100 * time.Millisecond)
+	metrics.ObserveRequest("GET /zones", 200, 50*time.Millisecond)
+	metrics.ObserveRequest("GET /zones", 503, 200*time.Millisecond)
+	metrics.ObserveRetry("GET /zones")
+
+	var decoded MetricsSnapshot
+	if err := json.Unmarshal([]byte(metrics.String()), &decoded); err != nil {
+		t.Fatalf("Expected String to return JSON, got %v", err)
+	}
+	if decoded.Endpoints["GET /zones"].Requests[503] != 1 {
+		t.Errorf("Expected the JSON to round-trip, got %+v", decoded)
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	output := rec.Body.String()
+	for _, want := range []string{
+		`snitchdns_client_requests_total{endpoint="GET /zones",status="200"} 1`,
+		`snitchdns_client_requests_total{endpoint="GET /zones",status="503"} 1`,
+		`snitchdns_client_retries_total{endpoint="GET /zones"} 1`,
+		`snitchdns_client_request_duration_seconds_bucket{endpoint="GET /zones",le="0.1"} 1`,
+		`snitchdns_client_request_duration_seconds_bucket{endpoint="GET /zones",le="+Inf"} 2`,
+		`snitchdns_client_request_duration_seconds_sum{endpoint="GET /zones"} 0.25`,
+		`snitchdns_client_request_duration_seconds_count{endpoint="GET /zones"} 2`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package snitchdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Names of the notification providers shipped with SnitchDNS
+const (
+	NotificationProviderEmail   = "email"
+	NotificationProviderWebhook = "webhook"
+	NotificationProviderSlack   = "slack"
+	NotificationProviderTeams   = "teams"
+)
+
+// NotificationProvider is a notification channel available on the server
+type NotificationProvider struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// NotificationSubscription is a zone's subscription to a notification
+// provider. Data is provider specific: a list of addresses for email, a
+// string or object for other providers.
+type NotificationSubscription struct {
+	ZoneID  int             `json:"zone_id"`
+	TypeID  int             `json:"type_id"`
+	Type    string          `json:"type"`
+	Enabled bool            `json:"enabled"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// UpdateNotificationRequest is the request body for updating a subscription.
+// Nil fields are left unchanged.
+type UpdateNotificationRequest struct {
+	Enabled *bool       `json:"enabled,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Recipients returns the subscription data as a list of addresses. Both a
+// JSON array and a comma-separated string are accepted.
+func (s *NotificationSubscription) Recipients() ([]string, error) {
+	if len(s.Data) == 0 || string(s.Data) == "null" {
+		return nil, nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(s.Data, &list); err == nil {
+		return list, nil
+	}
+
+	var joined string
+	if err := json.Unmarshal(s.Data, &joined); err != nil {
+		return nil, fmt.Errorf("failed to parse notification data: %w", err)
+	}
+
+	var recipients []string
+	for _, recipient := range strings.Split(joined, ",") {
+		if recipient = strings.TrimSpace(recipient); recipient != "" {
+			recipients = append(recipients, recipient)
+		}
+	}
+	return recipients, nil
+}
+
+// URL returns the subscription data as a single URL, as used by the webhook,
+// Slack and Teams providers. A one-element list is accepted as well.
+func (s *NotificationSubscription) URL() (string, error) {
+	if len(s.Data) == 0 || string(s.Data) == "null" {
+		return "", nil
+	}
+
+	var value string
+	if err := json.Unmarshal(s.Data, &value); err == nil {
+		return strings.TrimSpace(value), nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(s.Data, &list); err != nil {
+		return "", fmt.Errorf("failed to parse notification data: %w", err)
+	}
+	if len(list) > 1 {
+		return "", fmt.Errorf("expected a single notification URL, got %d", len(list))
+	}
+	if len(list) == 0 {
+		return "", nil
+	}
+	return strings.TrimSpace(list[0]), nil
+}
+
+// ListNotificationProviders retrieves the notification providers available
+// on the server
+func (c *Client) ListNotificationProviders(ctx context.Context) ([]NotificationProvider, error) {
+	var providers []NotificationProvider
+	if err := c.doJSONRequest(ctx, "GET", "/notifications/providers", nil, &providers); err != nil {
+		return nil, err
+	}
+
+	return providers, nil
+}
+
+// ListZoneNotifications retrieves all notification subscriptions of a zone
+func (c *Client) ListZoneNotifications(ctx context.Context, zoneID string) ([]NotificationSubscription, error) {
+	var subscriptions []NotificationSubscription
+	if err := c.doJSONRequest(ctx, "GET", fmt.Sprintf("/zones/%s/notifications", zoneID), nil, &subscriptions); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// GetZoneNotification retrieves a zone's subscription to a provider
+func (c *Client) GetZoneNotification(ctx context.Context, zoneID, provider string) (*NotificationSubscription, error) {
+	var subscription NotificationSubscription
+	if err := c.doJSONRequest(ctx, "GET",
+		fmt.Sprintf("/zones/%s/notifications/%s", zoneID, url.PathEscape(provider)), nil, &subscription); err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// UpdateZoneNotification updates a zone's subscription to a provider
+func (c *Client) UpdateZoneNotification(ctx context.Context, zoneID, provider string, req UpdateNotificationRequest) (*NotificationSubscription, error) {
+	var subscription NotificationSubscription
+	if err := c.doJSONRequest(ctx, "POST",
+		fmt.Sprintf("/zones/%s/notifications/%s", zoneID, url.PathEscape(provider)), req, &subscription); err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
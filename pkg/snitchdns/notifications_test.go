@@ -0,0 +1,95 @@
+package snitchdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestUpdateZoneNotification tests that only the recipients are sent and the response is decoded
+func TestUpdateZoneNotification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/zones/5/notifications/email" {
+			t.Errorf("Expected POST /zones/5/notifications/email, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if _, ok := body["enabled"]; ok {
+			t.Error("Expected enabled to be omitted")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"zone_id": 5, "type_id": 1, "type": "email", "enabled": true, "data": ["a@example.com", "b@example.com"]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	subscription, err := client.UpdateZoneNotification(context.Background(), "5", NotificationProviderEmail, UpdateNotificationRequest{
+		Data: []string{"a@example.com", "b@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	recipients, err := subscription.Recipients()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(recipients, []string{"a@example.com", "b@example.com"}) {
+		t.Errorf("Unexpected recipients: %v", recipients)
+	}
+}
+
+// TestNotificationRecipients tests that recipients are parsed from both lists and comma-separated strings
+func TestNotificationRecipients(t *testing.T) {
+	cases := map[string][]string{
+		`["a@example.com"]`:               {"a@example.com"},
+		`"a@example.com, b@example.com,"`: {"a@example.com", "b@example.com"},
+		`null`:                            nil,
+		`""`:                              nil,
+	}
+	for data, want := range cases {
+		subscription := NotificationSubscription{Data: json.RawMessage(data)}
+		got, err := subscription.Recipients()
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", data, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Recipients(%s) = %v, want %v", data, got, want)
+		}
+	}
+}
+
+// TestNotificationURL tests that URLs are parsed from strings and one-element lists
+func TestNotificationURL(t *testing.T) {
+	cases := map[string]string{
+		`"https://hooks.example.com/a"`:   "https://hooks.example.com/a",
+		`["https://hooks.example.com/b"]`: "https://hooks.example.com/b",
+		`[]`:                              "",
+		`null`:                            "",
+	}
+	for data, want := range cases {
+		subscription := NotificationSubscription{Data: json.RawMessage(data)}
+		got, err := subscription.URL()
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", data, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("URL(%s) = %q, want %q", data, got, want)
+		}
+	}
+
+	subscription := NotificationSubscription{Data: json.RawMessage(`["a", "b"]`)}
+	if _, err := subscription.URL(); err == nil {
+		t.Error("Expected an error for multiple URLs")
+	}
+}
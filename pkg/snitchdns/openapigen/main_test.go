@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedOperationsUpToDate tests that openapi_gen.go matches openapi.json
+func TestGeneratedOperationsUpToDate(t *testing.T) {
+	document, err := os.ReadFile("../openapi.json")
+	if err != nil {
+		t.Fatalf("Failed to read OpenAPI document: %v", err)
+	}
+	committed, err := os.ReadFile("../openapi_gen.go")
+	if err != nil {
+		t.Fatalf("Failed to read generated operations: %v", err)
+	}
+
+	generated, err := generate(document, "openapi.json")
+	if err != nil {
+		t.Fatalf("Failed to generate operations: %v", err)
+	}
+
+	if !bytes.Equal(generated, committed) {
+		t.Error("openapi_gen.go is out of date, run go generate ./pkg/snitchdns")
+	}
+}
+
+// TestGenerate tests operation rendering
+func TestGenerate(t *testing.T) {
+	document := `{
+		"components": {"parameters": {"zone": {"name": "zone", "in": "path"}}},
+		"paths": {
+			"/zones/{zone}/records/{record_id}": {
+				"parameters": [{"$ref": "#/components/parameters/zone"}],
+				"post": {
+					"operationId": "updateRecord",
+					"parameters": [{"name": "record_id", "in": "path"}],
+					"requestBody": {"content": {"application/json": {}}}
+				}
+			},
+			"/zones": {
+				"get": {"operationId": "listZones", "parameters": [{"name": "page", "in": "query"}]}
+			}
+		}
+	}`
+
+	source, err := generate([]byte(document), "test.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		`func (c *openAPIClient) updateRecord(ctx context.Context, zone string, recordID string, body interface{}) ([]byte, error)`,
+		`"/zones/"+url.PathEscape(zone)+"/records/"+url.PathEscape(recordID)`,
+		`func (c *openAPIClient) listZones(ctx context.Context, query url.Values) ([]byte, error)`,
+		`withQuery("/zones", query)`,
+	} {
+		if !strings.Contains(string(source), expected) {
+			t.Errorf("Expected generated source to contain %s, got:\n%s", expected, source)
+		}
+	}
+}
+
+// TestGenerate_Errors tests that inconsistent documents are rejected
+func TestGenerate_Errors(t *testing.T) {
+	tests := map[string]string{
+		"missing operationId":   `{"paths": {"/zones": {"get": {}}}}`,
+		"undeclared parameter":  `{"paths": {"/zones/{zone}": {"get": {"operationId": "getZone"}}}}`,
+		"unresolved reference":  `{"paths": {"/zones/{zone}": {"parameters": [{"$ref": "#/components/parameters/zone"}], "get": {"operationId": "getZone"}}}}`,
+		"duplicate operationId": `{"paths": {"/a": {"get": {"operationId": "op"}}, "/b": {"get": {"operationId": "op"}}}}`,
+	}
+
+	for name, document := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := generate([]byte(document), "test.json"); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package snitchdns
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures a Client at construction time. Options are the preferred
+// way to configure a client that will be shared between goroutines, since the
+// configuration is complete before the client is handed out.
+type Option func(*Client)
+
+// WithHTTPClient sets the underlying HTTP client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithRetry sets the maximum number of retries and the backoff bounds
+func WithRetry(maxRetries int, waitMin, waitMax time.Duration) Option {
+	return func(c *Client) {
+		c.MaxRetries = maxRetries
+		c.RetryWaitMin = waitMin
+		c.RetryWaitMax = waitMax
+	}
+}
+
+// WithBackoff sets the strategy deciding the wait before each retry, such
+// as ConstantBackoff or one returned by ParseBackoff. A nil backoff keeps
+// the default ExponentialBackoff.
+func WithBackoff(backoff Backoff) Option {
+	return func(c *Client) {
+		c.Backoff = backoff
+	}
+}
+
+// WithCompression sets whether gzip-compressed responses are requested,
+// which they are by default. Compression shrinks large listings on slow
+// links at the cost of some CPU on both ends.
+func WithCompression(enabled bool) Option {
+	return func(c *Client) {
+		c.DisableCompression = !enabled
+	}
+}
+
+// WithMaxElapsedTime bounds the total time of a request, including all
+// retries and the waits between them. A retry that would start after the
+// budget is not made, and an attempt still running when it runs out is
+// cancelled; the request then fails with a *RetryError listing the attempts.
+func WithMaxElapsedTime(maxElapsedTime time.Duration) Option {
+	return func(c *Client) {
+		c.MaxElapsedTime = maxElapsedTime
+	}
+}
+
+// WithRetryPolicy sets which failures are retried: responses with one of
+// statusCodes, and with connectionErrors, requests that got no response.
+// A nil statusCodes keeps the default of 429 and every 5xx status.
+func WithRetryPolicy(statusCodes []int, connectionErrors bool) Option {
+	return func(c *Client) {
+		c.RetryableStatusCodes = statusCodes
+		c.RetryOnConnectionErrors = connectionErrors
+	}
+}
+
+// WithIdempotentRetries sets whether POST, PUT, PATCH and DELETE requests
+// are retried on the same failures as reads. It is off by default, so a
+// change that timed out is not applied twice; enable it for servers whose
+// changes are safe to repeat. Zone creations are safe either way: a retry
+// finding the domain taken adopts the zone an earlier attempt created.
+func WithIdempotentRetries(enabled bool) Option {
+	return func(c *Client) {
+		c.IdempotentRetries = enabled
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of HTTP requests in flight
+// at the same time across all callers of the client. Requests over the
+// limit wait for a free slot; waiting for a retry does not hold one. A
+// limit of zero or less leaves requests unbounded.
+func WithMaxConcurrentRequests(limit int) Option {
+	return func(c *Client) {
+		if limit <= 0 {
+			c.requestSlots = nil
+			return
+		}
+		c.requestSlots = make(chan struct{}, limit)
+	}
+}
+
+// WithCircuitBreaker makes the client fail fast while the server is down.
+// After threshold consecutive request attempts failed with a 5xx status or
+// without a response, across all callers of the client, requests fail with
+// a *CircuitOpenError without being sent. Once cooldown has passed, a single
+// request is let through; its success closes the circuit, its failure keeps
+// it open for another cooldown. A threshold of zero or less disables the
+// circuit breaker, which is the default.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		if threshold <= 0 {
+			c.breaker = nil
+			return
+		}
+		c.breaker = &circuitBreaker{threshold: threshold, cooldown: cooldown}
+	}
+}
+
+// WithTimeout sets the timeout of each HTTP request, including reading the
+// response body. The HTTP client is copied, so a client passed to
+// WithHTTPClient is not modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		httpClient := *c.HTTPClient
+		httpClient.Timeout = timeout
+		c.HTTPClient = &httpClient
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the API. The
+// HTTP client is copied and given a clone of its transport, so proxy
+// settings keep working.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) {
+		c.ownTransport().TLSClientConfig = config
+	}
+}
+
+// WithDebugLogging enables debug logging of requests and responses. Entries
+// go to the logger set with WithLogger.
+func WithDebugLogging(enabled bool) Option {
+	return func(c *Client) {
+		c.DebugLogging = enabled
+	}
+}
+
+// WithLogger sets the logger receiving debug entries
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.Logger = logger
+	}
+}
+
+// WithTracerProvider records a span per API call with the given tracer
+// provider, carrying the method, path, response status and retry count
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.tracer = provider.Tracer(tracerName)
+	}
+}
+
+// WithRequestHook sets a hook called after every API call
+func WithRequestHook(hook RequestHook) Option {
+	return func(c *Client) {
+		c.RequestHook = hook
+	}
+}
+
+// WithAttemptHook sets a hook called after every HTTP request attempt,
+// including retries
+func WithAttemptHook(hook AttemptHook) Option {
+	return func(c *Client) {
+		c.AttemptHook = hook
+	}
+}
+
+// WithSensitiveKeys adds names of JSON fields, headers and query parameters
+// whose values are redacted from errors and debug logs, compared
+// case-insensitively. They extend the built-in keys such as password and
+// token.
+func WithSensitiveKeys(keys ...string) Option {
+	return func(c *Client) {
+		c.sensitiveKeys = append(c.sensitiveKeys, keys...)
+	}
+}
+
+// WithMetrics sets the collector receiving request, retry and latency
+// measurements, such as a *Metrics created with NewMetrics
+func WithMetrics(collector MetricsCollector) Option {
+	return func(c *Client) {
+		c.MetricsCollector = collector
+	}
+}
+
+// WithMiddleware adds middleware wrapping every HTTP request attempt. It
+// can be passed more than once; middleware runs in the order it was added.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// WithRequestValidator sets a validator checking JSON request bodies before
+// they are sent
+func WithRequestValidator(validator RequestValidator) Option {
+	return func(c *Client) {
+		c.RequestValidator = validator
+	}
+}
@@ -0,0 +1,52 @@
+package snitchdns
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultParallelism is the number of calls RunParallel makes at the same
+// time unless told otherwise
+const DefaultParallelism = 8
+
+// RunParallel calls fn for every index from 0 to n-1, with at most
+// parallelism calls running at the same time, and returns their errors in
+// index order. SnitchDNS has no bulk endpoints, so this is how helpers such
+// as CreateZones and DeleteZones spread one request per item over a bounded
+// pool; every call still gets the client's retries. Every index is attempted
+// even when others fail, so callers can keep what succeeded. Indexes not yet
+// started when ctx is cancelled fail with the context error without calling
+// fn. A parallelism below 1 means DefaultParallelism.
+func RunParallel(ctx context.Context, n, parallelism int, fn func(ctx context.Context, i int) error) []error {
+	if parallelism < 1 {
+		parallelism = DefaultParallelism
+	}
+
+	errs := make([]error, n)
+	sem := make(chan struct{}, parallelism)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			errs[i] = fn(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+
+	return errs
+}
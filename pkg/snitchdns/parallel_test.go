@@ -0,0 +1,68 @@
+package snitchdns
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRunParallel tests that calls run up to the limit, every index is
+// attempted and errors are returned in index order
+func TestRunParallel(t *testing.T) {
+	var inFlight, maxInFlight, calls int32
+	failing := errors.New("failed")
+
+	errs := RunParallel(context.Background(), 6, 2, func(_ context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if i == 2 {
+			return failing
+		}
+		return nil
+	})
+
+	if calls != 6 {
+		t.Errorf("Expected 6 calls, got %d", calls)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 calls in flight, got %d", maxInFlight)
+	}
+	for i, err := range errs {
+		if (i == 2) != errors.Is(err, failing) {
+			t.Errorf("Unexpected error for index %d: %v", i, err)
+		}
+	}
+}
+
+// TestRunParallelCancelled tests that indexes not started when the context
+// is cancelled fail with the context error without being called
+func TestRunParallelCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int32
+	errs := RunParallel(ctx, 3, 0, func(context.Context, int) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	if calls != 0 {
+		t.Errorf("Expected no calls, got %d", calls)
+	}
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected index %d to fail with context.Canceled, got %v", i, err)
+		}
+	}
+}
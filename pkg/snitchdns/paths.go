@@ -0,0 +1,76 @@
+package snitchdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIPathCandidates are the paths below the base URL where SnitchDNS
+// installs are known to serve the API, in the order DetectAPIPath tries them.
+// Upstream SnitchDNS serves it at /api/v1; some forks drop the version.
+var APIPathCandidates = []string{"", "/api/v1", "/api"}
+
+// apiPathProbe is a cheap, read-only endpoint every SnitchDNS version serves
+const apiPathProbe = "/records/types"
+
+// WithPathRewrites sets route prefix rewrites applied to every request path,
+// for deployments whose routes differ from upstream SnitchDNS. Keys are the
+// upstream path prefixes, e.g. "/records/types", and values the prefixes the
+// server uses instead. The longest matching prefix wins.
+func WithPathRewrites(rewrites map[string]string) Option {
+	return func(c *Client) {
+		c.PathRewrites = make(map[string]string, len(rewrites))
+		for from, to := range rewrites {
+			c.PathRewrites[from] = to
+		}
+	}
+}
+
+// rewritePath applies the longest matching path rewrite. Prefixes only match
+// whole path segments, so "/zones" does not rewrite "/zonesets". A query
+// string is kept as is.
+func (c *Client) rewritePath(path string) string {
+	path, query, hasQuery := strings.Cut(path, "?")
+	if hasQuery {
+		query = "?" + query
+	}
+
+	best := ""
+	for from := range c.PathRewrites {
+		if len(from) <= len(best) {
+			continue
+		}
+		if path == from || strings.HasPrefix(path, strings.TrimSuffix(from, "/")+"/") {
+			best = from
+		}
+	}
+
+	if best == "" {
+		return path + query
+	}
+	return c.PathRewrites[best] + strings.TrimPrefix(path, best) + query
+}
+
+// DetectAPIPath returns the first of APIPathCandidates below the base URL
+// that serves the SnitchDNS API. A candidate is accepted when the probe
+// endpoint exists, even if the API key is rejected. Each candidate is tried
+// once, without retries.
+func (c *Client) DetectAPIPath(ctx context.Context) (string, error) {
+	var tried []string
+	for _, candidate := range APIPathCandidates {
+		_, status, _, err := c.executeRequest(ctx, "GET", candidate+apiPathProbe, newRequestID(), 1, nil, "", nil)
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case status >= 200 && status < 300, status == http.StatusUnauthorized, status == http.StatusForbidden:
+			return candidate, nil
+		}
+		tried = append(tried, fmt.Sprintf("%s%s (status %d)", c.BaseURL, candidate, status))
+	}
+
+	return "", fmt.Errorf("no SnitchDNS API found at %s", strings.Join(tried, ", "))
+}
@@ -0,0 +1,76 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestDetectAPIPath tests that the first candidate serving the API is returned, even when the key is rejected
+func TestDetectAPIPath(t *testing.T) {
+	var probed []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probed = append(probed, r.URL.Path)
+		if r.URL.Path == "/api/records/types" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	path, err := client.DetectAPIPath(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if path != "/api" {
+		t.Errorf("Expected /api, got %q", path)
+	}
+	if len(probed) != 3 {
+		t.Errorf("Expected 3 probes, got %v", probed)
+	}
+}
+
+// TestDetectAPIPathNotFound tests that an error naming every tried URL is returned when no candidate matches
+func TestDetectAPIPathNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	if _, err := client.DetectAPIPath(context.Background()); err == nil {
+		t.Fatal("Expected an error when no candidate serves the API")
+	}
+}
+
+// TestPathRewrites tests that the longest matching prefix is rewritten on
+// whole path segments only, leaving query strings alone
+func TestPathRewrites(t *testing.T) {
+	client := NewClient("http://example.invalid", "test-key", WithPathRewrites(map[string]string{
+		"/zones":         "/domains",
+		"/records/types": "/records/type",
+	}))
+
+	cases := map[string]string{
+		"/zones":                 "/domains",
+		"/zones/5/records/7":     "/domains/5/records/7",
+		"/zonesets":              "/zonesets",
+		"/records/types":         "/records/type",
+		"/records/classes":       "/records/classes",
+		"/notifications/provide": "/notifications/provide",
+		"/zones?page=1":          "/domains?page=1",
+		"/zonesets?page=1":       "/zonesets?page=1",
+		"/zones/5?a=/zones/6":    "/domains/5?a=/zones/6",
+	}
+	for path, want := range cases {
+		if got := client.rewritePath(path); got != want {
+			t.Errorf("rewritePath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package snitchdns
+
+import (
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// WithProxy sends requests through the proxy at proxyURL, which may use the
+// http, https, socks5 or socks5h scheme. Hosts listed in the NO_PROXY
+// environment variable are still reached directly. A nil proxyURL uses the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Client) {
+		transport := c.ownTransport()
+		if proxyURL == nil {
+			transport.Proxy = http.ProxyFromEnvironment
+			return
+		}
+
+		config := httpproxy.FromEnvironment()
+		config.HTTPProxy = proxyURL.String()
+		config.HTTPSProxy = proxyURL.String()
+		proxy := config.ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxy(req.URL)
+		}
+	}
+}
+
+// ownTransport returns a transport only this client uses, so options
+// changing the transport compose instead of replacing each other's
+// changes. The first call clones the current transport, or the default one
+// when the HTTP client has a transport of another type, and gives a copy
+// of the HTTP client the clone; a client passed to WithHTTPClient is never
+// modified.
+func (c *Client) ownTransport() *http.Transport {
+	if c.transport != nil && c.HTTPClient.Transport == c.transport {
+		return c.transport
+	}
+
+	base, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+	c.transport = base.Clone()
+
+	httpClient := *c.HTTPClient
+	httpClient.Transport = c.transport
+	c.HTTPClient = &httpClient
+	return c.transport
+}
@@ -0,0 +1,48 @@
+package snitchdns
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestWithProxy tests that requests go through the proxy, also when the TLS
+// configuration is set afterwards, and that NO_PROXY hosts bypass it
+func TestWithProxy(t *testing.T) {
+	t.Setenv("NO_PROXY", "direct.internal")
+
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute URL of the request
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"page": 1, "pages": 1, "results": []}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	client := NewClient("http://snitchdns.internal", "test-key",
+		WithProxy(proxyURL),
+		WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}),
+	)
+
+	if _, err := client.ListZonesWithContext(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "http://snitchdns.internal/zones?page=1&per_page=50" {
+		t.Errorf("Expected the request to go through the proxy, got %v", proxied)
+	}
+
+	transport := client.HTTPClient.Transport.(*http.Transport)
+	if transport.TLSClientConfig == nil || transport == http.DefaultTransport {
+		t.Error("Expected the TLS configuration on a transport of the client's own")
+	}
+
+	req, _ := http.NewRequest("GET", "http://direct.internal/zones", nil)
+	if got, err := transport.Proxy(req); err != nil || got != nil {
+		t.Errorf("Expected NO_PROXY hosts to be reached directly, got %v (err=%v)", got, err)
+	}
+}
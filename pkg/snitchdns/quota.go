@@ -0,0 +1,32 @@
+package snitchdns
+
+import "context"
+
+// Quota is the number of zones the authenticated user may own and owns.
+// Servers without zone limits do not serve it.
+type Quota struct {
+	// ZoneLimit is the number of zones the user may own, 0 for no limit
+	ZoneLimit int `json:"zone_limit"`
+	ZonesUsed int `json:"zones_used"`
+}
+
+// Unlimited reports whether the user may own any number of zones
+func (q Quota) Unlimited() bool {
+	return q.ZoneLimit <= 0
+}
+
+// ZonesRemaining returns the number of zones the user can still create, 0
+// when the limit is reached. It is meaningless for unlimited quotas.
+func (q Quota) ZonesRemaining() int {
+	return max(q.ZoneLimit-q.ZonesUsed, 0)
+}
+
+// GetQuota retrieves the zone quota of the API key's user
+func (c *Client) GetQuota(ctx context.Context) (*Quota, error) {
+	var quota Quota
+	if err := c.doJSONRequest(ctx, "GET", "/quota", nil, &quota); err != nil {
+		return nil, err
+	}
+
+	return &quota, nil
+}
@@ -0,0 +1,33 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetQuota tests reading the zone quota
+func TestGetQuota(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/quota" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"zone_limit": 10, "zones_used": 12}`))
+	}))
+	defer server.Close()
+
+	quota, err := NewClient(server.URL, "test-key").GetQuota(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if quota.ZoneLimit != 10 || quota.ZonesUsed != 12 || quota.Unlimited() {
+		t.Errorf("Unexpected quota %+v", quota)
+	}
+	if remaining := quota.ZonesRemaining(); remaining != 0 {
+		t.Errorf("Expected no zones remaining over the limit, got %d", remaining)
+	}
+	if !(Quota{ZonesUsed: 3}).Unlimited() {
+		t.Error("Expected a zone limit of 0 to be unlimited")
+	}
+}
@@ -0,0 +1,498 @@
+package snitchdns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RawRecordData is record data as the JSON object SnitchDNS stores. It is
+// kept encoded, so numbers keep their digits and fields their order; read
+// it as strings with Strings, or decode it into the typed data of the
+// record type with ParseRecordData.
+type RawRecordData json.RawMessage
+
+// EncodeRecordData encodes the fields of record requests as raw record
+// data. Empty fields encode to nil.
+func EncodeRecordData(fields map[string]interface{}) RawRecordData {
+	if len(fields) == 0 {
+		return nil
+	}
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	return RawRecordData(encoded)
+}
+
+// parseRawRecordData checks that raw is a JSON object and returns it as raw
+// record data. Empty objects return nil.
+func parseRawRecordData(raw string) (RawRecordData, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return RawRecordData(raw), nil
+}
+
+// IsEmpty reports whether the data has no fields
+func (d RawRecordData) IsEmpty() bool {
+	return len(d.Fields()) == 0
+}
+
+// Fields returns the fields of the data. Numbers are json.Number, so they
+// are not rounded through float64.
+func (d RawRecordData) Fields() map[string]interface{} {
+	if len(d) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(d))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// Strings returns the fields of the data as strings. Numbers are written as
+// encoded rather than in exponent form, and nested objects and arrays as
+// compact JSON with sorted keys, so the same data always converts to the
+// same strings.
+func (d RawRecordData) Strings() map[string]string {
+	fields := d.Fields()
+	values := make(map[string]string, len(fields))
+	for key, value := range fields {
+		values[key] = recordDataString(value)
+	}
+	return values
+}
+
+// Get returns a field of the data as a string, or "" when it is not set
+func (d RawRecordData) Get(name string) string {
+	return d.Strings()[name]
+}
+
+// recordDataString converts a decoded record data value to a string
+func recordDataString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(encoded)
+	}
+}
+
+// RecordData is the typed data of a record type. Unlike the fields of raw
+// data, its numbers are the integers they stand for, and they are sent as
+// JSON numbers.
+type RecordData interface {
+	// RecordType returns the record type the data belongs to
+	RecordType() string
+
+	// Fields returns the data as the field map of record requests
+	Fields() map[string]interface{}
+}
+
+// ARecordData is the data of an A record
+type ARecordData struct {
+	Address string `json:"address"`
+}
+
+// RecordType returns A
+func (d ARecordData) RecordType() string { return "A" }
+
+// Fields returns the data as the field map of record requests
+func (d ARecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{"address": d.Address}
+}
+
+// AAAARecordData is the data of an AAAA record
+type AAAARecordData struct {
+	Address string `json:"address"`
+}
+
+// RecordType returns AAAA
+func (d AAAARecordData) RecordType() string { return "AAAA" }
+
+// Fields returns the data as the field map of record requests
+func (d AAAARecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{"address": d.Address}
+}
+
+// CNAMERecordData is the data of a CNAME record
+type CNAMERecordData struct {
+	Name string `json:"name"`
+}
+
+// RecordType returns CNAME
+func (d CNAMERecordData) RecordType() string { return "CNAME" }
+
+// Fields returns the data as the field map of record requests
+func (d CNAMERecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{"name": d.Name}
+}
+
+// NSRecordData is the data of an NS record
+type NSRecordData struct {
+	Name string `json:"name"`
+}
+
+// RecordType returns NS
+func (d NSRecordData) RecordType() string { return "NS" }
+
+// Fields returns the data as the field map of record requests
+func (d NSRecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{"name": d.Name}
+}
+
+// PTRRecordData is the data of a PTR record
+type PTRRecordData struct {
+	Name string `json:"name"`
+}
+
+// RecordType returns PTR
+func (d PTRRecordData) RecordType() string { return "PTR" }
+
+// Fields returns the data as the field map of record requests
+func (d PTRRecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{"name": d.Name}
+}
+
+// TXTRecordData is the data of a TXT record
+type TXTRecordData struct {
+	Data string `json:"data"`
+}
+
+// RecordType returns TXT
+func (d TXTRecordData) RecordType() string { return "TXT" }
+
+// Fields returns the data as the field map of record requests
+func (d TXTRecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{"data": d.Data}
+}
+
+// SPFRecordData is the data of an SPF record
+type SPFRecordData struct {
+	Data string `json:"data"`
+}
+
+// RecordType returns SPF
+func (d SPFRecordData) RecordType() string { return "SPF" }
+
+// Fields returns the data as the field map of record requests
+func (d SPFRecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{"data": d.Data}
+}
+
+// MXRecordData is the data of an MX record
+type MXRecordData struct {
+	Priority uint16 `json:"priority"`
+	Hostname string `json:"hostname"`
+}
+
+// RecordType returns MX
+func (d MXRecordData) RecordType() string { return "MX" }
+
+// Fields returns the data as the field map of record requests
+func (d MXRecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		"priority": d.Priority,
+		"hostname": d.Hostname,
+	}
+}
+
+// UnmarshalJSON decodes MX data, with numbers as JSON numbers or strings
+func (d *MXRecordData) UnmarshalJSON(b []byte) error {
+	f, err := newDataFields(b)
+	if err != nil {
+		return err
+	}
+	*d = MXRecordData{
+		Priority: uint16(f.uint("priority", 16)),
+		Hostname: f.string("hostname"),
+	}
+	return f.err
+}
+
+// SRVRecordData is the data of an SRV record
+type SRVRecordData struct {
+	Priority uint16 `json:"priority"`
+	Weight   uint16 `json:"weight"`
+	Port     uint16 `json:"port"`
+	Target   string `json:"target"`
+}
+
+// RecordType returns SRV
+func (d SRVRecordData) RecordType() string { return "SRV" }
+
+// Fields returns the data as the field map of record requests
+func (d SRVRecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		"priority": d.Priority,
+		"weight":   d.Weight,
+		"port":     d.Port,
+		"target":   d.Target,
+	}
+}
+
+// UnmarshalJSON decodes SRV data, with numbers as JSON numbers or strings
+func (d *SRVRecordData) UnmarshalJSON(b []byte) error {
+	f, err := newDataFields(b)
+	if err != nil {
+		return err
+	}
+	*d = SRVRecordData{
+		Priority: uint16(f.uint("priority", 16)),
+		Weight:   uint16(f.uint("weight", 16)),
+		Port:     uint16(f.uint("port", 16)),
+		Target:   f.string("target"),
+	}
+	return f.err
+}
+
+// NAPTRRecordData is the data of a NAPTR record
+type NAPTRRecordData struct {
+	Order       uint16 `json:"order"`
+	Preference  uint16 `json:"preference"`
+	Flags       string `json:"flags"`
+	Service     string `json:"service"`
+	Regexp      string `json:"regexp"`
+	Replacement string `json:"replacement"`
+}
+
+// RecordType returns NAPTR
+func (d NAPTRRecordData) RecordType() string { return "NAPTR" }
+
+// Fields returns the data as the field map of record requests
+func (d NAPTRRecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		"order":       d.Order,
+		"preference":  d.Preference,
+		"flags":       d.Flags,
+		"service":     d.Service,
+		"regexp":      d.Regexp,
+		"replacement": d.Replacement,
+	}
+}
+
+// UnmarshalJSON decodes NAPTR data, with numbers as JSON numbers or strings
+func (d *NAPTRRecordData) UnmarshalJSON(b []byte) error {
+	f, err := newDataFields(b)
+	if err != nil {
+		return err
+	}
+	*d = NAPTRRecordData{
+		Order:       uint16(f.uint("order", 16)),
+		Preference:  uint16(f.uint("preference", 16)),
+		Flags:       f.string("flags"),
+		Service:     f.string("service"),
+		Regexp:      f.string("regexp"),
+		Replacement: f.string("replacement"),
+	}
+	return f.err
+}
+
+// CAARecordData is the data of a CAA record
+type CAARecordData struct {
+	Flags uint8  `json:"flags"`
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// RecordType returns CAA
+func (d CAARecordData) RecordType() string { return "CAA" }
+
+// Fields returns the data as the field map of record requests
+func (d CAARecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		"flags": d.Flags,
+		"tag":   d.Tag,
+		"value": d.Value,
+	}
+}
+
+// UnmarshalJSON decodes CAA data, with numbers as JSON numbers or strings
+func (d *CAARecordData) UnmarshalJSON(b []byte) error {
+	f, err := newDataFields(b)
+	if err != nil {
+		return err
+	}
+	*d = CAARecordData{
+		Flags: uint8(f.uint("flags", 8)),
+		Tag:   f.string("tag"),
+		Value: f.string("value"),
+	}
+	return f.err
+}
+
+// SOARecordData is the data of an SOA record
+type SOARecordData struct {
+	MName   string `json:"mname"`
+	RName   string `json:"rname"`
+	Serial  uint32 `json:"serial"`
+	Refresh uint32 `json:"refresh"`
+	Retry   uint32 `json:"retry"`
+	Expire  uint32 `json:"expire"`
+	Minimum uint32 `json:"minimum"`
+}
+
+// RecordType returns SOA
+func (d SOARecordData) RecordType() string { return "SOA" }
+
+// Fields returns the data as the field map of record requests
+func (d SOARecordData) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		"mname":   d.MName,
+		"rname":   d.RName,
+		"serial":  d.Serial,
+		"refresh": d.Refresh,
+		"retry":   d.Retry,
+		"expire":  d.Expire,
+		"minimum": d.Minimum,
+	}
+}
+
+// UnmarshalJSON decodes SOA data, with numbers as JSON numbers or strings
+func (d *SOARecordData) UnmarshalJSON(b []byte) error {
+	f, err := newDataFields(b)
+	if err != nil {
+		return err
+	}
+	*d = SOARecordData{
+		MName:   f.string("mname"),
+		RName:   f.string("rname"),
+		Serial:  uint32(f.uint("serial", 32)),
+		Refresh: uint32(f.uint("refresh", 32)),
+		Retry:   uint32(f.uint("retry", 32)),
+		Expire:  uint32(f.uint("expire", 32)),
+		Minimum: uint32(f.uint("minimum", 32)),
+	}
+	return f.err
+}
+
+// ParseRecordData decodes raw data as the typed data of the record type. It
+// returns nil for record types without typed data.
+func ParseRecordData(recordType string, raw RawRecordData) (RecordData, error) {
+	var data RecordData
+	switch strings.ToUpper(recordType) {
+	case "A":
+		data = &ARecordData{}
+	case "AAAA":
+		data = &AAAARecordData{}
+	case "CNAME":
+		data = &CNAMERecordData{}
+	case "NS":
+		data = &NSRecordData{}
+	case "PTR":
+		data = &PTRRecordData{}
+	case "TXT":
+		data = &TXTRecordData{}
+	case "SPF":
+		data = &SPFRecordData{}
+	case "MX":
+		data = &MXRecordData{}
+	case "SRV":
+		data = &SRVRecordData{}
+	case "NAPTR":
+		data = &NAPTRRecordData{}
+	case "CAA":
+		data = &CAARecordData{}
+	case "SOA":
+		data = &SOARecordData{}
+	default:
+		return nil, nil
+	}
+
+	if len(raw) == 0 {
+		raw = RawRecordData(emptyJSON)
+	}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s data: %w", recordType, err)
+	}
+	return data, nil
+}
+
+// TypedData returns the record's data decoded as the typed data of its
+// type, or nil when the type has none
+func (r *Record) TypedData() (RecordData, error) {
+	return ParseRecordData(r.Type, r.Data)
+}
+
+// dataFields decodes the fields of record data. SnitchDNS returns numbers
+// as JSON numbers, or as strings when they were sent as such. Missing
+// fields decode to their zero value; the first malformed field is kept in
+// err.
+type dataFields struct {
+	fields map[string]json.RawMessage
+	err    error
+}
+
+// newDataFields splits JSON-encoded record data into its fields
+func newDataFields(b []byte) (*dataFields, error) {
+	f := &dataFields{}
+	if err := json.Unmarshal(b, &f.fields); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// string returns a string field
+func (f *dataFields) string(name string) string {
+	raw, ok := f.fields[name]
+	if !ok || string(raw) == "null" {
+		return ""
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		f.fail(name, err)
+	}
+	return value
+}
+
+// uint returns an unsigned integer field of the given bit size
+func (f *dataFields) uint(name string, bitSize int) uint64 {
+	raw, ok := f.fields[name]
+	if !ok || string(raw) == "null" {
+		return 0
+	}
+
+	text := string(raw)
+	var quoted string
+	if json.Unmarshal(raw, &quoted) == nil {
+		text = strings.TrimSpace(quoted)
+	}
+	if text == "" {
+		return 0
+	}
+
+	value, err := strconv.ParseUint(text, 10, bitSize)
+	if err != nil {
+		f.fail(name, err)
+	}
+	return value
+}
+
+// fail records the error of a field unless an earlier field failed
+func (f *dataFields) fail(name string, err error) {
+	if f.err == nil {
+		f.err = fmt.Errorf("field %q: %w", name, err)
+	}
+}
@@ -0,0 +1,150 @@
+package snitchdns
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseRecordData(t *testing.T) {
+	tests := []struct {
+		name       string
+		recordType string
+		raw        string
+		expected   RecordData
+	}{
+		{
+			name:       "SRV numbers",
+			recordType: "SRV",
+			raw:        `{"priority": 10, "weight": 60, "port": 5060, "target": "sip.example.com."}`,
+			expected:   &SRVRecordData{Priority: 10, Weight: 60, Port: 5060, Target: "sip.example.com."},
+		},
+		{
+			name:       "SRV strings",
+			recordType: "srv",
+			raw:        `{"priority": "10", "weight": "", "port": "5060", "target": "sip.example.com."}`,
+			expected:   &SRVRecordData{Priority: 10, Port: 5060, Target: "sip.example.com."},
+		},
+		{
+			name:       "NAPTR",
+			recordType: "NAPTR",
+			raw:        `{"order": 100, "preference": 10, "flags": "U", "service": "E2U+sip", "regexp": "!^.*$!sip:info@example.com!", "replacement": "."}`,
+			expected:   &NAPTRRecordData{Order: 100, Preference: 10, Flags: "U", Service: "E2U+sip", Regexp: "!^.*$!sip:info@example.com!", Replacement: "."},
+		},
+		{
+			name:       "CAA",
+			recordType: "CAA",
+			raw:        `{"flags": 128, "tag": "issue", "value": "letsencrypt.org"}`,
+			expected:   &CAARecordData{Flags: 128, Tag: "issue", Value: "letsencrypt.org"},
+		},
+		{
+			name:       "SOA largest serial",
+			recordType: "SOA",
+			raw:        `{"mname": "ns1.example.com.", "rname": "admin.example.com.", "serial": 4294967295, "refresh": 3600, "retry": 600, "expire": 86400, "minimum": 3600}`,
+			expected:   &SOARecordData{MName: "ns1.example.com.", RName: "admin.example.com.", Serial: 4294967295, Refresh: 3600, Retry: 600, Expire: 86400, Minimum: 3600},
+		},
+		{
+			name:       "empty",
+			recordType: "CAA",
+			raw:        "",
+			expected:   &CAARecordData{},
+		},
+		{
+			name:       "A",
+			recordType: "A",
+			raw:        `{"address": "192.0.2.1"}`,
+			expected:   &ARecordData{Address: "192.0.2.1"},
+		},
+		{
+			name:       "MX",
+			recordType: "MX",
+			raw:        `{"priority": "10", "hostname": "mail.example.com."}`,
+			expected:   &MXRecordData{Priority: 10, Hostname: "mail.example.com."},
+		},
+		{
+			name:       "untyped",
+			recordType: "HINFO",
+			raw:        `{"cpu": "x86", "os": "linux"}`,
+			expected:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ParseRecordData(tt.recordType, RawRecordData(tt.raw))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(data, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, data)
+			}
+		})
+	}
+
+	for _, raw := range []string{`{"port": 65536}`, `{"port": -1}`, `{"port": 1.5}`, `{"target": 1}`, `[]`} {
+		if _, err := ParseRecordData("SRV", RawRecordData(raw)); err == nil {
+			t.Errorf("expected an error for %s", raw)
+		}
+	}
+}
+
+func TestRecordDataFields(t *testing.T) {
+	data := SOARecordData{MName: "ns1.example.com.", RName: "admin.example.com.", Serial: 2024010101, Refresh: 3600}
+	body, err := json.Marshal(CreateRecordRequest{Type: data.RecordType(), Data: data.Fields()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Integers are sent as JSON numbers, never in exponent form
+	var decoded struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(decoded.Data["serial"]) != "2024010101" || string(decoded.Data["retry"]) != "0" {
+		t.Errorf("expected integer fields, got %s", body)
+	}
+
+	// The request data decodes back to the same typed data
+	fields, _ := json.Marshal(decoded.Data)
+	record := Record{Type: "SOA", Data: RawRecordData(fields)}
+	typed, err := record.TypedData()
+	if err != nil || !reflect.DeepEqual(typed, &data) {
+		t.Errorf("expected %+v, got %+v, %v", data, typed, err)
+	}
+}
+
+func TestRawRecordDataStrings(t *testing.T) {
+	record := Record{DataRaw: `{"serial": 2024010101, "big": 12345678901234567890, "text": "v=spf1", "flag": true, "none": null, "nested": {"b": 2, "a": "x"}, "list": ["x", 1.50]}`}
+	if err := record.parseData(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"serial": "2024010101",
+		"big":    "12345678901234567890",
+		"text":   "v=spf1",
+		"flag":   "true",
+		"none":   "",
+		"nested": `{"a":"x","b":2}`,
+		"list":   `["x",1.50]`,
+	}
+	if got := record.Data.Strings(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := record.Data.Get("serial"); got != "2024010101" {
+		t.Errorf("expected the serial, got %q", got)
+	}
+
+	// Empty data is kept as nil
+	record = Record{DataRaw: "{}"}
+	if err := record.parseData(); err != nil || record.Data != nil || !record.Data.IsEmpty() {
+		t.Errorf("expected no data, got %s, %v", record.Data, err)
+	}
+
+	record = Record{DataRaw: "[1]"}
+	if err := record.parseData(); err == nil {
+		t.Error("expected an error for an array")
+	}
+}
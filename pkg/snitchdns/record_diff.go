@@ -0,0 +1,204 @@
+package snitchdns
+
+import (
+	"encoding/json"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// RecordUpdate is an actual record whose attributes are changed to those of
+// the desired record it was matched with
+type RecordUpdate struct {
+	Actual  Record
+	Desired Record
+
+	// Fields names the attributes that differ, by their JSON names, such as
+	// ttl and active
+	Fields []string
+}
+
+// Fields of record data compared as domain names, IP addresses and numbers
+// by record type. Other fields are compared as written.
+var (
+	recordNameFields = map[string][]string{
+		"CNAME": {"name"},
+		"NS":    {"name"},
+		"PTR":   {"name"},
+		"MX":    {"hostname"},
+		"SRV":   {"target"},
+		"NAPTR": {"replacement"},
+		"SOA":   {"mname", "rname"},
+	}
+	recordAddressFields = map[string][]string{
+		"A":    {"address"},
+		"AAAA": {"address"},
+	}
+	recordNumberFields = map[string][]string{
+		"MX":    {"priority"},
+		"SRV":   {"priority", "weight", "port"},
+		"NAPTR": {"order", "preference"},
+		"CAA":   {"flags"},
+		"SOA":   {"serial", "refresh", "retry", "expire", "minimum"},
+	}
+)
+
+// DiffRecords compares the desired records of a zone with its actual ones
+// and returns the changes that reconcile them: desired records to create,
+// actual records to update and actual records to delete. It works with
+// records of any backend, and with desired records built by hand, whose
+// data may be set as Data or DataRaw.
+//
+// Records are matched by type, class and data, so a changed address
+// deletes the old record and creates a new one. Matched records are updated
+// when their TTL, active flag or conditional response differ; a matching
+// record needing no update is preferred, so duplicates are not shuffled
+// around. The comparison is semantic: types and classes ignore case, domain
+// names also ignore a trailing dot, IP addresses and the numbers of MX,
+// SRV, NAPTR, CAA and SOA data compare by value, JSON values ignore key
+// order and whitespace, and the conditional attributes of records that are
+// not conditional, the conditional counter and IDs are ignored.
+func DiffRecords(desired, actual []Record) (create []Record, update []RecordUpdate, remove []Record) {
+	// Indices of the unmatched actual records by key
+	available := make(map[string][]int)
+	for i, record := range actual {
+		key := recordKey(record)
+		available[key] = append(available[key], i)
+	}
+	matched := make([]bool, len(actual))
+
+	for _, record := range desired {
+		key := recordKey(record)
+		candidates := available[key]
+		if len(candidates) == 0 {
+			create = append(create, record)
+			continue
+		}
+
+		match := 0
+		for i, index := range candidates {
+			if len(recordChanges(actual[index], record)) == 0 {
+				match = i
+				break
+			}
+		}
+
+		current := actual[candidates[match]]
+		matched[candidates[match]] = true
+		available[key] = append(candidates[:match:match], candidates[match+1:]...)
+
+		if fields := recordChanges(current, record); len(fields) > 0 {
+			update = append(update, RecordUpdate{Actual: current, Desired: record, Fields: fields})
+		}
+	}
+
+	for i, record := range actual {
+		if !matched[i] {
+			remove = append(remove, record)
+		}
+	}
+
+	return create, update, remove
+}
+
+// RecordsEqual reports whether two records are the same as DiffRecords
+// compares them
+func RecordsEqual(a, b Record) bool {
+	return recordKey(a) == recordKey(b) && len(recordChanges(a, b)) == 0
+}
+
+// recordKey identifies a record for matching: its type, class and
+// normalized data
+func recordKey(r Record) string {
+	recordType := strings.ToUpper(r.Type)
+	return recordType + "|" + strings.ToUpper(r.Class) + "|" + normalizedRecordData(recordType, r.Data, r.DataRaw)
+}
+
+// recordChanges returns the JSON names of the attributes of two records
+// with the same key that differ
+func recordChanges(actual, desired Record) []string {
+	var fields []string
+	if actual.TTL != desired.TTL {
+		fields = append(fields, "ttl")
+	}
+	if actual.Active != desired.Active {
+		fields = append(fields, "active")
+	}
+	if actual.IsConditional != desired.IsConditional {
+		fields = append(fields, "is_conditional")
+	}
+	if !actual.IsConditional || !desired.IsConditional {
+		return fields
+	}
+
+	if actual.ConditionalLimit != desired.ConditionalLimit {
+		fields = append(fields, "conditional_limit")
+	}
+	if actual.ConditionalReset != desired.ConditionalReset {
+		fields = append(fields, "conditional_reset")
+	}
+	recordType := strings.ToUpper(desired.Type)
+	if normalizedRecordData(recordType, actual.ConditionalData, actual.ConditionalDataRaw) !=
+		normalizedRecordData(recordType, desired.ConditionalData, desired.ConditionalDataRaw) {
+		fields = append(fields, "conditional_data")
+	}
+	return fields
+}
+
+// normalizedRecordData returns record data, or its raw JSON string when
+// data is empty, as JSON with sorted keys and every value normalized for
+// the record type. Empty and malformed data normalize to {}.
+func normalizedRecordData(recordType string, data RawRecordData, raw string) string {
+	if len(data) == 0 && raw != "" {
+		data, _ = parseRawRecordData(raw)
+	}
+
+	values := data.Strings()
+	for name, value := range values {
+		values[name] = normalizeRecordDataValue(recordType, name, value)
+	}
+	encoded, _ := json.Marshal(values)
+	return string(encoded)
+}
+
+// normalizeRecordDataValue returns the form of a record data value that
+// equal values share
+func normalizeRecordDataValue(recordType, name, value string) string {
+	trimmed := strings.TrimSpace(value)
+	switch {
+	case recordFieldIs(recordNameFields, recordType, name):
+		if trimmed == "." {
+			return trimmed
+		}
+		return strings.TrimSuffix(strings.ToLower(trimmed), ".")
+	case recordFieldIs(recordAddressFields, recordType, name):
+		if addr, err := netip.ParseAddr(trimmed); err == nil {
+			return addr.String()
+		}
+	case recordFieldIs(recordNumberFields, recordType, name):
+		if n, err := strconv.ParseUint(trimmed, 10, 64); err == nil {
+			return strconv.FormatUint(n, 10)
+		}
+		if f, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+	case len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '['):
+		var decoded interface{}
+		if json.Unmarshal([]byte(trimmed), &decoded) == nil {
+			if encoded, err := json.Marshal(decoded); err == nil {
+				return string(encoded)
+			}
+		}
+	}
+	return value
+}
+
+// recordFieldIs reports whether fields lists name for the record type
+func recordFieldIs(fields map[string][]string, recordType, name string) bool {
+	for _, field := range fields[recordType] {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
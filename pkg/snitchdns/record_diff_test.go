@@ -0,0 +1,102 @@
+package snitchdns
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestDiffRecords tests matching desired records against actual ones
+func TestDiffRecords(t *testing.T) {
+	actual := []Record{
+		{ID: 1, Type: "A", Class: "IN", TTL: 300, Active: true, DataRaw: `{"address": "10.0.0.1"}`},
+		{ID: 2, Type: "A", Class: "IN", TTL: 300, Active: true, DataRaw: `{"address": "10.0.0.2"}`},
+		{ID: 3, Type: "MX", Class: "IN", TTL: 300, Active: true, DataRaw: `{"priority": 10, "hostname": "Mail.Example.com."}`},
+		{ID: 4, Type: "TXT", Class: "IN", TTL: 300, Active: true, DataRaw: `{"data": "old"}`},
+		{ID: 5, Type: "AAAA", Class: "IN", TTL: 300, Active: true, DataRaw: `{"address": "2001:db8:0:0::1"}`},
+	}
+	desired := []Record{
+		// Unchanged, with the data written differently
+		{Type: "a", Class: "in", TTL: 300, Active: true, Data: EncodeRecordData(map[string]interface{}{"address": "10.0.0.1"})},
+		{Type: "MX", Class: "IN", TTL: 300, Active: true, Data: EncodeRecordData(map[string]interface{}{"priority": "10", "hostname": "mail.example.com"})},
+		{Type: "AAAA", Class: "IN", TTL: 300, Active: true, DataRaw: `{"address": "2001:db8::1"}`},
+		// TTL and active changed
+		{Type: "A", Class: "IN", TTL: 60, Active: false, DataRaw: `{"address": "10.0.0.2"}`},
+		// New data
+		{Type: "TXT", Class: "IN", TTL: 300, Active: true, DataRaw: `{"data": "new"}`},
+	}
+
+	create, update, remove := DiffRecords(desired, actual)
+	if len(create) != 1 || create[0].DataRaw != `{"data": "new"}` {
+		t.Errorf("Expected the new TXT record to be created, got %+v", create)
+	}
+	if len(update) != 1 || update[0].Actual.ID != 2 || !slices.Equal(update[0].Fields, []string{"ttl", "active"}) {
+		t.Errorf("Expected record 2 to be updated for ttl and active, got %+v", update)
+	}
+	if len(remove) != 1 || remove[0].ID != 4 {
+		t.Errorf("Expected the old TXT record to be deleted, got %+v", remove)
+	}
+}
+
+// TestDiffRecords_Duplicates tests that of duplicate records the one needing
+// no update is matched, and the others are deleted in order
+func TestDiffRecords_Duplicates(t *testing.T) {
+	actual := []Record{
+		{ID: 1, Type: "A", Class: "IN", TTL: 60, DataRaw: `{"address": "10.0.0.1"}`},
+		{ID: 2, Type: "A", Class: "IN", TTL: 300, DataRaw: `{"address": "10.0.0.1"}`},
+		{ID: 3, Type: "A", Class: "IN", TTL: 600, DataRaw: `{"address": "10.0.0.1"}`},
+	}
+	desired := []Record{{Type: "A", Class: "IN", TTL: 300, DataRaw: `{"address": "10.0.0.1"}`}}
+
+	create, update, remove := DiffRecords(desired, actual)
+	if len(create) != 0 || len(update) != 0 {
+		t.Errorf("Expected no creates or updates, got %+v and %+v", create, update)
+	}
+	if len(remove) != 2 || remove[0].ID != 1 || remove[1].ID != 3 {
+		t.Errorf("Expected records 1 and 3 to be deleted, got %+v", remove)
+	}
+}
+
+// TestRecordsEqual tests the semantic comparison of records
+func TestRecordsEqual(t *testing.T) {
+	base := Record{Type: "SRV", Class: "IN", TTL: 300, Active: true,
+		DataRaw: `{"priority": 10, "weight": 5, "port": 5060, "target": "sip.example.com."}`}
+
+	tests := map[string]struct {
+		other Record
+		equal bool
+	}{
+		"numbers as strings": {Record{Type: "srv", Class: "IN", TTL: 300, Active: true,
+			DataRaw: `{"target": "SIP.example.com", "port": "5060", "weight": "5", "priority": "10"}`}, true},
+		"other port": {Record{Type: "SRV", Class: "IN", TTL: 300, Active: true,
+			DataRaw: `{"priority": 10, "weight": 5, "port": 5061, "target": "sip.example.com"}`}, false},
+		"other class": {Record{Type: "SRV", Class: "CH", TTL: 300, Active: true, DataRaw: base.DataRaw}, false},
+		"other ttl":   {Record{Type: "SRV", Class: "IN", TTL: 60, Active: true, DataRaw: base.DataRaw}, false},
+		"unused conditional attributes": {Record{Type: "SRV", Class: "IN", TTL: 300, Active: true, DataRaw: base.DataRaw,
+			ConditionalLimit: 5, ConditionalCount: 2, ConditionalDataRaw: `{"target": "other.example.com"}`}, true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if equal := RecordsEqual(base, tt.other); equal != tt.equal {
+				t.Errorf("Expected equal %v, got %v", tt.equal, equal)
+			}
+		})
+	}
+
+	// Names are only compared case-insensitively where the type makes them
+	// names, and JSON values ignore key order
+	txt := Record{Type: "TXT", Class: "IN", DataRaw: `{"data": "Hello."}`}
+	if RecordsEqual(txt, Record{Type: "TXT", Class: "IN", DataRaw: `{"data": "hello"}`}) {
+		t.Error("Expected TXT data to be compared as written")
+	}
+	conditional := Record{Type: "A", Class: "IN", IsConditional: true, DataRaw: `{"address": "10.0.0.1"}`,
+		ConditionalDataRaw: `{"address": "10.0.0.9"}`}
+	changed := conditional
+	changed.ConditionalDataRaw = `{"address": "10.0.0.8"}`
+	if RecordsEqual(conditional, changed) {
+		t.Error("Expected a changed conditional response to differ")
+	}
+	nested := Record{Type: "X", DataRaw: `{"v": "{\"a\": 1, \"b\": 2}"}`}
+	if !RecordsEqual(nested, Record{Type: "X", DataRaw: `{"v": "{\"b\":2,\"a\":1}"}`}) {
+		t.Error("Expected JSON values to ignore key order and whitespace")
+	}
+}
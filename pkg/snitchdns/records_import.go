@@ -0,0 +1,44 @@
+package snitchdns
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+)
+
+// ImportRecordsCSV uploads records in the SnitchDNS CSV export format to the
+// zone's multipart import endpoint. Not every SnitchDNS release has the
+// endpoint; callers should fall back to creating records one by one when it
+// fails with 404 Not Found or 405 Method Not Allowed.
+func (c *Client) ImportRecordsCSV(ctx context.Context, zoneID string, csv []byte) error {
+	contentType, body, err := csvImportBody("records.csv", bytes.NewReader(csv))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRawRequestWithContext(ctx, "POST", fmt.Sprintf("/zones/%s/records/import", zoneID), contentType, body)
+	return err
+}
+
+// csvImportBody builds the multipart body of a CSV import, returning its
+// content type and encoded form. The body is buffered so that retries can
+// send it again.
+func csvImportBody(filename string, csv io.Reader) (string, []byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("csvfile", filename)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build import request: %w", err)
+	}
+	if _, err := io.Copy(part, csv); err != nil {
+		return "", nil, fmt.Errorf("failed to build import request: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", nil, fmt.Errorf("failed to build import request: %w", err)
+	}
+
+	return writer.FormDataContentType(), body.Bytes(), nil
+}
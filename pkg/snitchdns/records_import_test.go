@@ -0,0 +1,39 @@
+package snitchdns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestImportRecordsCSV tests that the CSV is uploaded as a multipart file
+func TestImportRecordsCSV(t *testing.T) {
+	csv := "type,cls,ttl,active,data\nA,IN,300,true,\"{\"\"address\"\": \"\"10.0.0.1\"\"}\"\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/zones/5/records/import" {
+			t.Errorf("Expected POST /zones/5/records/import, got %s %s", r.Method, r.URL.Path)
+		}
+
+		file, _, err := r.FormFile("csvfile")
+		if err != nil {
+			t.Fatalf("Expected a csvfile upload: %v", err)
+		}
+		content, _ := io.ReadAll(file)
+		if string(content) != csv {
+			t.Errorf("Unexpected upload: %q", content)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success": true, "message": "OK"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	if err := client.ImportRecordsCSV(context.Background(), "5", []byte(csv)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package snitchdns
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// redactedValue replaces secrets in error messages and logs
+const redactedValue = "[REDACTED]"
+
+// defaultSensitiveKeys are JSON keys whose values are redacted from response
+// bodies before they are embedded into errors. Notification configurations
+// carry webhook URLs and SMTP credentials under these keys, and the server
+// settings the log forwarding webhook URL.
+var defaultSensitiveKeys = []string{
+	"api_key", "apikey", "key", "password", "secret", "token",
+	"session", "csrf_token", "webhook", "webhook_url", "url",
+	"log_forward_webhook_url",
+}
+
+// redactor removes secrets from text that leaves the client. A nil redactor
+// leaves text unchanged.
+type redactor struct {
+	mu     sync.RWMutex
+	values []string
+	keys   map[string]bool
+}
+
+// newRedactor creates a redactor for the default sensitive keys
+func newRedactor() *redactor {
+	keys := make(map[string]bool, len(defaultSensitiveKeys))
+	for _, key := range defaultSensitiveKeys {
+		keys[key] = true
+	}
+	return &redactor{keys: keys}
+}
+
+// addKeys registers more sensitive keys
+func (r *redactor) addKeys(keys ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range keys {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			r.keys[key] = true
+		}
+	}
+}
+
+// isSensitiveKey reports whether the values of a JSON field, header or
+// query parameter named name are redacted
+func (r *redactor) isSensitiveKey(name string) bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.keys[strings.ToLower(name)]
+}
+
+// addValue registers a secret value to be redacted wherever it appears
+func (r *redactor) addValue(value string) {
+	if r == nil || value == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values = append(r.values, value)
+}
+
+// redactString replaces every registered secret value in s
+func (r *redactor) redactString(s string) string {
+	if r == nil {
+		return s
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, value := range r.values {
+		s = strings.ReplaceAll(s, value, redactedValue)
+	}
+	return s
+}
+
+// redactBody redacts a response body. JSON bodies have the values of
+// sensitive keys replaced at any depth; registered secret values are
+// replaced in every body.
+func (r *redactor) redactBody(body string) string {
+	if r == nil {
+		return body
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(body), &decoded); err == nil {
+		if r.redactJSON(decoded) {
+			if encoded, err := json.Marshal(decoded); err == nil {
+				body = string(encoded)
+			}
+		}
+	}
+	return r.redactString(body)
+}
+
+// redactURL redacts a request URL: the values of sensitive query
+// parameters are replaced, and registered secret values anywhere
+func (r *redactor) redactURL(rawURL string) string {
+	if r == nil {
+		return rawURL
+	}
+
+	base, query, found := strings.Cut(rawURL, "?")
+	if found {
+		params := strings.Split(query, "&")
+		for i, param := range params {
+			name, _, hasValue := strings.Cut(param, "=")
+			if unescaped, err := url.QueryUnescape(name); err == nil && hasValue && r.isSensitiveKey(unescaped) {
+				params[i] = name + "=" + redactedValue
+			}
+		}
+		rawURL = base + "?" + strings.Join(params, "&")
+	}
+	return r.redactString(rawURL)
+}
+
+// redactJSON replaces the values of sensitive keys in a decoded JSON value,
+// reporting whether anything was replaced
+func (r *redactor) redactJSON(v interface{}) bool {
+	changed := false
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if r.isSensitiveKey(key) {
+				v[key] = redactedValue
+				changed = true
+				continue
+			}
+			changed = r.redactJSON(value) || changed
+		}
+	case []interface{}:
+		for _, value := range v {
+			changed = r.redactJSON(value) || changed
+		}
+	}
+
+	return changed
+}
+
+// AddSensitiveValue registers a secret, such as a session token or a
+// write-only attribute value, that must never appear in errors or logs. It is
+// safe to call while requests are in flight.
+func (c *Client) AddSensitiveValue(value string) {
+	c.redactor.addValue(value)
+}
+
+// Redact removes the API key and registered secrets from s. Callers embedding
+// client data into logs or diagnostics should pass it through Redact.
+func (c *Client) Redact(s string) string {
+	return c.redactor.redactString(s)
+}
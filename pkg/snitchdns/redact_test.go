@@ -0,0 +1,54 @@
+package snitchdns
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestErrorBodyRedaction tests that secrets in error response bodies never reach the error string
+func TestErrorBodyRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"success": false, "code": 5007, "message": "Invalid key secret-api-key", "details": "",
+			"data": {"webhook_url": "https://hooks.example.com/T000/B000/XXXX", "recipients": ["soc@example.com"]},
+			"session_token": "s3ss10n"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "secret-api-key")
+	client.AddSensitiveValue("s3ss10n")
+
+	_, err := client.GetZone("1")
+	if err == nil {
+		t.Fatal("Expected error for 400")
+	}
+
+	message := err.Error()
+	for _, secret := range []string{"secret-api-key", "hooks.example.com", "s3ss10n"} {
+		if strings.Contains(message, secret) {
+			t.Errorf("Expected %q to be redacted, got: %s", secret, message)
+		}
+	}
+	if !strings.Contains(message, "soc@example.com") {
+		t.Errorf("Expected non-sensitive fields to be kept, got: %s", message)
+	}
+	if !strings.Contains(message, redactedValue) {
+		t.Errorf("Expected redaction marker in error, got: %s", message)
+	}
+}
+
+// TestRedactNonJSONBody tests that plain-text bodies have registered secrets removed
+func TestRedactNonJSONBody(t *testing.T) {
+	client := NewClient("http://localhost", "secret-api-key")
+
+	got := client.redactor.redactBody("<html>bad key secret-api-key</html>")
+	if strings.Contains(got, "secret-api-key") {
+		t.Errorf("Expected API key to be redacted, got: %s", got)
+	}
+
+	if got := client.Redact("nothing to hide"); got != "nothing to hide" {
+		t.Errorf("Expected text without secrets to be unchanged, got: %s", got)
+	}
+}
@@ -0,0 +1,76 @@
+package snitchdns
+
+import (
+	"context"
+	"fmt"
+)
+
+// Zone restriction types
+const (
+	RestrictionAllow = "allow"
+	RestrictionBlock = "block"
+)
+
+// ZoneRestriction is a rule allowing or blocking queries to a zone from a
+// source IP range
+type ZoneRestriction struct {
+	ID      int    `json:"id"`
+	ZoneID  int    `json:"zone_id"`
+	IPRange string `json:"ip_range"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// RestrictionRequest is the request body for creating or updating a zone
+// restriction. Empty and nil fields are left unchanged on update.
+type RestrictionRequest struct {
+	IPRange string `json:"ip_range,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
+
+// ListRestrictions retrieves all restrictions of a zone
+func (c *Client) ListRestrictions(ctx context.Context, zoneID string) ([]ZoneRestriction, error) {
+	var restrictions []ZoneRestriction
+	if err := c.doJSONRequest(ctx, "GET", fmt.Sprintf("/zones/%s/restrictions", zoneID), nil, &restrictions); err != nil {
+		return nil, err
+	}
+
+	return restrictions, nil
+}
+
+// CreateRestriction adds a restriction to a zone
+func (c *Client) CreateRestriction(ctx context.Context, zoneID string, req RestrictionRequest) (*ZoneRestriction, error) {
+	var restriction ZoneRestriction
+	if err := c.doJSONRequest(ctx, "POST", fmt.Sprintf("/zones/%s/restrictions", zoneID), req, &restriction); err != nil {
+		return nil, err
+	}
+
+	return &restriction, nil
+}
+
+// GetRestriction retrieves a single restriction of a zone
+func (c *Client) GetRestriction(ctx context.Context, zoneID, restrictionID string) (*ZoneRestriction, error) {
+	var restriction ZoneRestriction
+	if err := c.doJSONRequest(ctx, "GET", fmt.Sprintf("/zones/%s/restrictions/%s", zoneID, restrictionID), nil, &restriction); err != nil {
+		return nil, err
+	}
+
+	return &restriction, nil
+}
+
+// UpdateRestriction updates a restriction of a zone
+func (c *Client) UpdateRestriction(ctx context.Context, zoneID, restrictionID string, req RestrictionRequest) (*ZoneRestriction, error) {
+	var restriction ZoneRestriction
+	if err := c.doJSONRequest(ctx, "POST", fmt.Sprintf("/zones/%s/restrictions/%s", zoneID, restrictionID), req, &restriction); err != nil {
+		return nil, err
+	}
+
+	return &restriction, nil
+}
+
+// DeleteRestriction removes a restriction from a zone
+func (c *Client) DeleteRestriction(ctx context.Context, zoneID, restrictionID string) error {
+	_, err := c.doRequestWithContext(ctx, "DELETE", fmt.Sprintf("/zones/%s/restrictions/%s", zoneID, restrictionID), nil)
+	return err
+}
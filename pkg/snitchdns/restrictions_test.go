@@ -0,0 +1,63 @@
+package snitchdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRestrictions tests the zone restriction routes and request bodies
+func TestRestrictions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /zones/3/restrictions":
+			w.Write([]byte(`[{"id": 1, "zone_id": 3, "ip_range": "10.0.0.0/8", "type": "allow", "enabled": true}]`))
+		case "POST /zones/3/restrictions":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["ip_range"] != "192.0.2.0/24" || body["type"] != "block" || body["enabled"] != true {
+				t.Errorf("Unexpected create body: %v", body)
+			}
+			w.Write([]byte(`{"id": 2, "zone_id": 3, "ip_range": "192.0.2.0/24", "type": "block", "enabled": true}`))
+		case "POST /zones/3/restrictions/2":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if _, ok := body["ip_range"]; ok || body["enabled"] != false {
+				t.Errorf("Expected only enabled to be sent, got %v", body)
+			}
+			w.Write([]byte(`{"id": 2, "zone_id": 3, "ip_range": "192.0.2.0/24", "type": "block", "enabled": false}`))
+		case "DELETE /zones/3/restrictions/2":
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	ctx := context.Background()
+
+	restrictions, err := client.ListRestrictions(ctx, "3")
+	if err != nil || len(restrictions) != 1 || restrictions[0].IPRange != "10.0.0.0/8" {
+		t.Fatalf("Unexpected list result %+v, error %v", restrictions, err)
+	}
+
+	enabled := true
+	created, err := client.CreateRestriction(ctx, "3", RestrictionRequest{IPRange: "192.0.2.0/24", Type: RestrictionBlock, Enabled: &enabled})
+	if err != nil || created.ID != 2 {
+		t.Fatalf("Unexpected create result %+v, error %v", created, err)
+	}
+
+	enabled = false
+	updated, err := client.UpdateRestriction(ctx, "3", "2", RestrictionRequest{Enabled: &enabled})
+	if err != nil || updated.Enabled {
+		t.Fatalf("Unexpected update result %+v, error %v", updated, err)
+	}
+
+	if err := client.DeleteRestriction(ctx, "3", "2"); err != nil {
+		t.Fatalf("Unexpected delete error: %v", err)
+	}
+}
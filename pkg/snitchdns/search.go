@@ -0,0 +1,156 @@
+package snitchdns
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// QueryLog is a DNS query logged by SnitchDNS
+type QueryLog struct {
+	ID        int    `json:"id"`
+	Domain    string `json:"domain"`
+	SourceIP  string `json:"source_ip"`
+	Type      string `json:"type"`
+	Matched   bool   `json:"matched"`
+	Forwarded bool   `json:"forwarded"`
+	Blocked   bool   `json:"blocked"`
+	// Date is the time the query was logged, in the server's format
+	Date string `json:"date"`
+	// ZoneID and RecordID identify what answered the query, zero when
+	// nothing matched
+	ZoneID   int `json:"zone_id"`
+	RecordID int `json:"record_id"`
+}
+
+// SearchParams filters a query log search. Empty fields do not filter.
+type SearchParams struct {
+	Domain    string
+	SourceIP  string
+	Type      string
+	Matched   *bool
+	Forwarded *bool
+	Blocked   *bool
+	// From and To bound the time the query was logged. They are sent in UTC
+	// with a resolution of one second.
+	From    time.Time
+	To      time.Time
+	Page    int
+	PerPage int
+}
+
+// searchDateLayout and searchTimeLayout are the formats of the date and time
+// range parameters
+const (
+	searchDateLayout = "2006-01-02"
+	searchTimeLayout = "15:04:05"
+)
+
+// SearchPage is a single page of query log search results
+type SearchPage struct {
+	Page    int        `json:"page"`
+	Pages   int        `json:"pages"`
+	Count   int        `json:"count"`
+	Results []QueryLog `json:"results"`
+}
+
+// query encodes the parameters as a query string
+func (p SearchParams) query() url.Values {
+	query := url.Values{}
+	for name, value := range map[string]string{
+		"domain":    p.Domain,
+		"source_ip": p.SourceIP,
+		"type":      p.Type,
+	} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	if p.Matched != nil {
+		query.Set("matched", strconv.FormatBool(*p.Matched))
+	}
+	if p.Forwarded != nil {
+		query.Set("forwarded", strconv.FormatBool(*p.Forwarded))
+	}
+	if p.Blocked != nil {
+		query.Set("blocked", strconv.FormatBool(*p.Blocked))
+	}
+	if !p.From.IsZero() {
+		query.Set("date_from", p.From.UTC().Format(searchDateLayout))
+		query.Set("time_from", p.From.UTC().Format(searchTimeLayout))
+	}
+	if !p.To.IsZero() {
+		query.Set("date_to", p.To.UTC().Format(searchDateLayout))
+		query.Set("time_to", p.To.UTC().Format(searchTimeLayout))
+	}
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(p.PerPage))
+	}
+	return query
+}
+
+// SearchLogs retrieves a page of the query log, newest entries first
+func (c *Client) SearchLogs(ctx context.Context, params SearchParams) (*SearchPage, error) {
+	path := "/search"
+	if query := params.query(); len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var page SearchPage
+	if err := c.doJSONRequest(ctx, "GET", path, nil, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// QueryLogFormat is a file format of query log exports
+type QueryLogFormat string
+
+// Query log export formats
+const (
+	QueryLogFormatCSV  QueryLogFormat = "csv"
+	QueryLogFormatJSON QueryLogFormat = "json"
+)
+
+// Valid reports whether the server can export in the format
+func (f QueryLogFormat) Valid() bool {
+	return f == QueryLogFormatCSV || f == QueryLogFormatJSON
+}
+
+// exportQuery encodes the parameters of a query log export. Exports hold
+// every matching entry, so the page parameters are dropped.
+func (p SearchParams) exportQuery(format QueryLogFormat) (url.Values, error) {
+	if !format.Valid() {
+		return nil, fmt.Errorf("unsupported query log export format %q, expected %q or %q", format, QueryLogFormatCSV, QueryLogFormatJSON)
+	}
+
+	query := p.query()
+	query.Del("page")
+	query.Del("per_page")
+	query.Set("format", string(format))
+	return query, nil
+}
+
+// ExportQueryLog returns every query log entry matching params as a file in
+// the given format, as produced by the server's search export. The whole
+// export is held in memory.
+func (c *Client) ExportQueryLog(ctx context.Context, params SearchParams, format QueryLogFormat) ([]byte, error) {
+	query, err := params.exportQuery(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doRequestWithContext(ctx, "GET", "/search/export?"+query.Encode(), nil)
+}
+
+// ClearZoneLogs deletes the logged queries answered by a zone
+func (c *Client) ClearZoneLogs(ctx context.Context, zoneID string) error {
+	_, err := c.doRequestWithContext(ctx, "DELETE", fmt.Sprintf("/zones/%s/logs", zoneID), nil)
+	return err
+}
@@ -0,0 +1,132 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestSearchLogs tests that filters are sent as query parameters and results are decoded
+func TestSearchLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/search" {
+			t.Errorf("Expected GET /search, got %s %s", r.Method, r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("domain") != "canary.example.com" || query.Get("matched") != "true" || query.Get("per_page") != "100" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		if _, ok := query["source_ip"]; ok {
+			t.Error("Expected empty filters not to be sent")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"page": 1, "pages": 1, "count": 1, "results": [
+			{"id": 7, "domain": "canary.example.com", "source_ip": "198.51.100.4", "type": "A",
+			 "matched": true, "forwarded": false, "blocked": false, "date": "2024-05-01 10:00:00", "zone_id": 3, "record_id": 9}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	matched := true
+	page, err := client.SearchLogs(context.Background(), SearchParams{
+		Domain:  "canary.example.com",
+		Matched: &matched,
+		PerPage: 100,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(page.Results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(page.Results))
+	}
+	hit := page.Results[0]
+	if hit.SourceIP != "198.51.100.4" || hit.ZoneID != 3 || hit.RecordID != 9 || !hit.Matched {
+		t.Errorf("Unexpected result: %+v", hit)
+	}
+}
+
+// TestExportQueryLog tests that exports send the filters and format without
+// paging, and return the raw export
+func TestExportQueryLog(t *testing.T) {
+	const export = "id,date,source_ip,domain\n7,2024-05-01 10:00:00,198.51.100.4,canary.example.com\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/search/export" {
+			t.Errorf("Expected GET /search/export, got %s %s", r.Method, r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("format") != "csv" || query.Get("domain") != "canary.example.com" || query.Has("page") || query.Has("per_page") {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "text/csv")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(export))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	body, err := client.ExportQueryLog(context.Background(), SearchParams{Domain: "canary.example.com", Page: 2, PerPage: 100}, QueryLogFormatCSV)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(body) != export {
+		t.Errorf("Expected the raw export, got %q", body)
+	}
+
+	if _, err := client.ExportQueryLog(context.Background(), SearchParams{}, "xml"); err == nil {
+		t.Error("Expected an unsupported format to fail")
+	}
+}
+
+// TestClearZoneLogs tests that clearing logs deletes the zone's log route
+func TestClearZoneLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/zones/3/logs" {
+			t.Errorf("Expected DELETE /zones/3/logs, got %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	if err := client.ClearZoneLogs(context.Background(), "3"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+// TestSearchParamsTimeRange tests that the forwarded and blocked filters
+// and time range are sent in UTC
+func TestSearchParamsTimeRange(t *testing.T) {
+	forwarded, blocked := false, true
+	from := time.Date(2024, 5, 1, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	to := time.Date(2024, 5, 2, 8, 0, 5, 0, time.UTC)
+
+	query := SearchParams{Forwarded: &forwarded, Blocked: &blocked, From: from, To: to}.query()
+
+	expected := map[string]string{
+		"forwarded": "false",
+		"blocked":   "true",
+		"date_from": "2024-05-01",
+		"time_from": "10:30:00",
+		"date_to":   "2024-05-02",
+		"time_to":   "08:00:05",
+	}
+	for name, value := range expected {
+		if got := query.Get(name); got != value {
+			t.Errorf("Expected %s=%s, got %q", name, value, got)
+		}
+	}
+	if len(query) != len(expected) {
+		t.Errorf("Unexpected query %s", query.Encode())
+	}
+}
@@ -0,0 +1,29 @@
+package snitchdns
+
+import "context"
+
+// ServerInfo describes the SnitchDNS server the client talks to
+type ServerInfo struct {
+	Version string `json:"version"`
+}
+
+// GetServerInfo retrieves the server status, including its version
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	var info ServerInfo
+	if err := c.doJSONRequest(ctx, "GET", "/status", nil, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// Ping checks that the SnitchDNS API answers and accepts the API key. Servers
+// without the status endpoint answer it with not found, which counts as a
+// successful ping.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.doRequestWithContext(ctx, "GET", "/status", nil)
+	if err != nil && !IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestPing tests which status endpoint responses count as a reachable server
+func TestPing(t *testing.T) {
+	tests := map[string]struct {
+		status  int
+		wantErr bool
+	}{
+		"ok":           {http.StatusOK, false},
+		"no endpoint":  {http.StatusNotFound, false},
+		"bad key":      {http.StatusUnauthorized, true},
+		"server error": {http.StatusBadGateway, true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/status" {
+					t.Errorf("Expected path /status, got %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"version": "1.4.0"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key", WithRetry(0, 0, 0))
+
+			err := client.Ping(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestPingUnreachable tests that a server without a listener fails the ping
+func TestPingUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	client := NewClient(url, "test-key", WithRetry(0, 0, 0))
+
+	err := client.Ping(context.Background())
+	if err == nil || StatusCode(err) != 0 {
+		t.Errorf("Expected a transport error, got %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package snitchdns
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// SessionLoginPath is the path of the SnitchDNS web login form, below the
+// server's root rather than the API path
+const SessionLoginPath = "/auth/login"
+
+// csrfHeader carries the CSRF token of the session on requests that change
+// state, as Flask-WTF accepts it
+const csrfHeader = "X-CSRFToken"
+
+// csrfInputPattern finds the hidden CSRF input of the login form, and
+// valuePattern its value
+var (
+	csrfInputPattern = regexp.MustCompile(`<input[^>]*name="csrf_token"[^>]*>`)
+	valuePattern     = regexp.MustCompile(`value="([^"]*)"`)
+)
+
+// LoginError is returned when logging in with a username and password fails
+type LoginError struct {
+	Username string
+	Reason   string
+}
+
+// Error implements the error interface
+func (e *LoginError) Error() string {
+	return fmt.Sprintf("login as %s failed: %s", e.Username, e.Reason)
+}
+
+// sessionAuth holds the web session of a client authenticating with a
+// username and password, for servers with the API key feature disabled
+type sessionAuth struct {
+	username string
+	password string
+
+	mu         sync.Mutex
+	jar        *cookiejar.Jar
+	csrfToken  string
+	loggedIn   bool
+	generation int
+}
+
+// WithSessionAuth authenticates with a username and password instead of the
+// API key. The client logs in through the web login form on its first
+// request, sends the session cookie and CSRF token with every request, and
+// logs in again when a request is rejected with 401 Unauthorized. The
+// password, session cookie and CSRF token are redacted from errors and
+// debug logs.
+func WithSessionAuth(username, password string) Option {
+	return func(c *Client) {
+		jar, _ := cookiejar.New(nil)
+		c.session = &sessionAuth{username: username, password: password, jar: jar}
+	}
+}
+
+// loginURL returns the URL of the login form: the base URL without a known
+// API path, plus SessionLoginPath
+func (c *Client) loginURL() string {
+	root := strings.TrimSuffix(c.BaseURL, "/")
+	for _, candidate := range APIPathCandidates {
+		if candidate != "" && strings.HasSuffix(root, candidate) {
+			root = strings.TrimSuffix(root, candidate)
+			break
+		}
+	}
+	return root + SessionLoginPath
+}
+
+// authenticate adds the session to a request, logging in first if there is
+// no session yet. It returns the generation of the session used, to pass to
+// expire when the server rejects it.
+func (c *Client) authenticate(ctx context.Context, req *http.Request) (int, error) {
+	s := c.session
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loggedIn {
+		if err := c.login(ctx); err != nil {
+			return 0, err
+		}
+		s.loggedIn = true
+		s.generation++
+	}
+
+	for _, cookie := range s.jar.Cookies(req.URL) {
+		req.AddCookie(cookie)
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead && s.csrfToken != "" {
+		req.Header.Set(csrfHeader, s.csrfToken)
+	}
+	return s.generation, nil
+}
+
+// expire drops the session of the given generation, so the next request
+// logs in again. A session renewed in the meantime by another request is
+// kept.
+func (s *sessionAuth) expire(generation int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.generation == generation {
+		s.loggedIn = false
+	}
+}
+
+// saveCookies keeps cookies the server set or renewed on a response
+func (s *sessionAuth) saveCookies(resp *http.Response) {
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		s.jar.SetCookies(resp.Request.URL, cookies)
+	}
+}
+
+// login fetches the login form for its CSRF token and submits the
+// credentials. The caller must hold the session lock.
+func (c *Client) login(ctx context.Context) error {
+	s := c.session
+	loginURL := c.loginURL()
+
+	// The jar keeps the cookies of every response, and redirects are not
+	// followed so a successful login can be told apart from the form being
+	// shown again
+	httpClient := *c.HTTPClient
+	httpClient.Jar = s.jar
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	form, location, err := c.loginRequest(ctx, &httpClient, http.MethodGet, loginURL, nil)
+	if err != nil {
+		return err
+	}
+	match := valuePattern.FindStringSubmatch(csrfInputPattern.FindString(form))
+	if location != "" || match == nil {
+		return &LoginError{Username: s.username, Reason: fmt.Sprintf("no login form with a CSRF token at %s", loginURL)}
+	}
+	token := html.UnescapeString(match[1])
+	c.redactor.addValue(token)
+
+	values := url.Values{
+		"username":   {s.username},
+		"password":   {s.password},
+		"csrf_token": {token},
+	}
+	_, location, err = c.loginRequest(ctx, &httpClient, http.MethodPost, loginURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	if location == "" || strings.Contains(location, SessionLoginPath) {
+		// The form is shown again when the credentials are rejected
+		return &LoginError{Username: s.username, Reason: "the username or password was rejected"}
+	}
+
+	u, _ := url.Parse(loginURL)
+	for _, cookie := range s.jar.Cookies(u) {
+		c.redactor.addValue(cookie.Value)
+	}
+	s.csrfToken = token
+	return nil
+}
+
+// loginRequest sends a request to the login form. It returns the body of a
+// successful response, or the location of a redirect.
+func (c *Client) loginRequest(ctx context.Context, httpClient *http.Client, method, loginURL string, body io.Reader) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, loginURL, body)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create login request: %w", err)
+	}
+	c.addExtras(req)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", "", &APIError{Method: method, Path: SessionLoginPath, Err: err}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read login response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		return "", resp.Header.Get("Location"), nil
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return string(respBody), "", nil
+	}
+
+	apiErr := &APIError{Method: method, Path: SessionLoginPath, StatusCode: resp.StatusCode, Body: string(respBody)}
+	apiErr.parseBody()
+	apiErr.redact(c.redactor)
+	return "", "", apiErr
+}
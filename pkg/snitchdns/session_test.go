@@ -0,0 +1,114 @@
+package snitchdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// sessionServer emulates the login form and session cookies of SnitchDNS
+type sessionServer struct {
+	mu       sync.Mutex
+	logins   int
+	session  string
+	requests []string
+}
+
+// ServeHTTP implements http.Handler
+func (s *sessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	switch {
+	case r.URL.Path == "/auth/login" && r.Method == http.MethodGet:
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "anonymous", Path: "/"})
+		w.Write([]byte(`<form method="post"><input id="csrf_token" name="csrf_token" type="hidden" value="csrf-1&amp;2">` +
+			`<input name="username"><input name="password" type="password"></form>`))
+
+	case r.URL.Path == "/auth/login":
+		cookie, _ := r.Cookie("session")
+		if cookie == nil || cookie.Value != "anonymous" || r.PostFormValue("csrf_token") != "csrf-1&2" ||
+			r.PostFormValue("username") != "admin" || r.PostFormValue("password") != "hunter2" {
+			w.Write([]byte(`<p>Invalid credentials</p>`))
+			return
+		}
+		s.logins++
+		s.session = fmt.Sprintf("session-%d", s.logins)
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: s.session, Path: "/"})
+		http.Redirect(w, r, "/home", http.StatusFound)
+
+	default:
+		cookie, _ := r.Cookie("session")
+		if cookie == nil || cookie.Value != s.session || r.Header.Get("X-SnitchDNS-Auth") != "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "unauthorized"}`))
+			return
+		}
+		if r.Method != http.MethodGet && r.Header.Get("X-CSRFToken") != "csrf-1&2" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "The CSRF token is missing."}`))
+			return
+		}
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}
+}
+
+// TestSessionAuth tests that the client logs in, sends the session and CSRF
+// token, and logs in again once the session expired
+func TestSessionAuth(t *testing.T) {
+	ctx := context.Background()
+	server := &sessionServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	client := NewClient(ts.URL+"/api/v1", "", WithSessionAuth("admin", "hunter2"), WithRetry(0, 0, 0))
+
+	if _, err := client.GetZoneWithContext(ctx, "1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.UpdateZoneWithContext(ctx, "1", UpdateZoneRequest{}); err != nil {
+		t.Fatalf("Expected the CSRF token to be sent, got %v", err)
+	}
+
+	// The server forgets the session
+	server.mu.Lock()
+	server.session = "expired"
+	server.mu.Unlock()
+
+	if _, err := client.GetZoneWithContext(ctx, "1"); err != nil {
+		t.Fatalf("Expected the client to log in again, got %v", err)
+	}
+
+	expected := []string{
+		"GET /auth/login", "POST /auth/login", "GET /api/v1/zones/1", "POST /api/v1/zones/1",
+		"GET /api/v1/zones/1", "GET /auth/login", "POST /auth/login", "GET /api/v1/zones/1",
+	}
+	if got := strings.Join(server.requests, ", "); got != strings.Join(expected, ", ") {
+		t.Errorf("Unexpected requests:\n got %s\nwant %s", got, strings.Join(expected, ", "))
+	}
+}
+
+// TestSessionAuthRejected tests that rejected credentials fail with a
+// *LoginError that does not reveal the password
+func TestSessionAuthRejected(t *testing.T) {
+	ts := httptest.NewServer(&sessionServer{})
+	defer ts.Close()
+
+	client := NewClient(ts.URL, "", WithSessionAuth("admin", "wrong-password"), WithRetry(0, 0, 0))
+
+	_, err := client.GetZoneWithContext(context.Background(), "1")
+	var loginErr *LoginError
+	if !errors.As(err, &loginErr) {
+		t.Fatalf("Expected a *LoginError, got %v", err)
+	}
+	if strings.Contains(err.Error(), "wrong-password") {
+		t.Errorf("Expected the password to be redacted, got %v", err)
+	}
+}
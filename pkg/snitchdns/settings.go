@@ -0,0 +1,85 @@
+package snitchdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Settings are the server-wide SnitchDNS settings, keyed by setting name.
+// SnitchDNS stores every setting as a string.
+type Settings map[string]string
+
+// Bool returns a setting interpreted as a boolean. Missing settings are false.
+func (s Settings) Bool(name string) bool {
+	switch strings.ToLower(strings.TrimSpace(s[name])) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// Int returns a setting interpreted as an integer
+func (s Settings) Int(name string) (int, error) {
+	value, err := strconv.Atoi(strings.TrimSpace(s[name]))
+	if err != nil {
+		return 0, fmt.Errorf("setting %s is not a number: %q", name, s[name])
+	}
+	return value, nil
+}
+
+// FormatBool formats a boolean the way SnitchDNS stores boolean settings
+func FormatBool(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// GetSettings retrieves all server settings. Requires an admin API key.
+func (c *Client) GetSettings(ctx context.Context) (Settings, error) {
+	respBody, err := c.doRequestWithContext(ctx, "GET", "/settings", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSettings(respBody)
+}
+
+// UpdateSettings updates the given server settings, leaving all others
+// unchanged, and returns the resulting settings. Requires an admin API key.
+func (c *Client) UpdateSettings(ctx context.Context, settings Settings) (Settings, error) {
+	respBody, err := c.doRequestWithContext(ctx, "POST", "/settings", settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSettings(respBody)
+}
+
+// parseSettings decodes a settings response, converting non-string values
+// to their string form
+func parseSettings(respBody []byte) (Settings, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(respBody, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	settings := make(Settings, len(raw))
+	for name, value := range raw {
+		switch v := value.(type) {
+		case string:
+			settings[name] = v
+		case bool:
+			settings[name] = FormatBool(v)
+		case nil:
+			settings[name] = ""
+		default:
+			settings[name] = fmt.Sprintf("%v", v)
+		}
+	}
+	return settings, nil
+}
@@ -0,0 +1,46 @@
+package snitchdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestUpdateSettings tests that only the given settings are sent and the response is normalized to strings
+func TestUpdateSettings(t *testing.T) {
+	var sent map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/settings" {
+			t.Errorf("Expected POST /settings, got %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"dns_log_unmatched": true, "dns_daemon_bind_port": 53, "forwarding_servers": "8.8.8.8,1.1.1.1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	settings, err := client.UpdateSettings(context.Background(), Settings{"dns_log_unmatched": FormatBool(true)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(sent) != 1 || sent["dns_log_unmatched"] != "1" {
+		t.Errorf("Expected only dns_log_unmatched=1 to be sent, got %v", sent)
+	}
+	if !settings.Bool("dns_log_unmatched") {
+		t.Error("Expected dns_log_unmatched to be true")
+	}
+	if port, err := settings.Int("dns_daemon_bind_port"); err != nil || port != 53 {
+		t.Errorf("Expected port 53, got %d (err=%v)", port, err)
+	}
+	if settings.Bool("missing") {
+		t.Error("Expected missing settings to be false")
+	}
+}
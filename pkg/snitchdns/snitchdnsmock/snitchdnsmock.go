@@ -0,0 +1,1685 @@
+// Package snitchdnsmock provides an in-memory fake of the SnitchDNS API, so code
+// depending on snitchdns.ClientInterface can be unit-tested without a server.
+//
+// The fake keeps zones, records, restrictions, notification subscriptions,
+// query logs, settings, users, API keys and aliases in memory and answers like SnitchDNS does:
+// missing objects fail with a 404 snitchdns.APIError, so snitchdns.IsNotFound
+// works as against a real server. Failures can be injected per method with
+// Fail.
+package snitchdnsmock
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// dateLayout is the format of timestamps set by the fake and of the query
+// log dates it filters by time
+const dateLayout = "2006-01-02 15:04:05"
+
+// Ensure the fake implements the full API
+var _ snitchdns.ClientInterface = &Client{}
+
+// Client is an in-memory fake of the SnitchDNS API. The zero value is not
+// usable; create one with New. A Client is safe for concurrent use.
+type Client struct {
+	mu sync.Mutex
+
+	// Version is the server version reported by GetServerInfo
+	Version string
+
+	// ZoneLimit is the zone limit GetQuota reports for the API key's user,
+	// who owns the zones created without a user ID; 0 for no limit. Zone
+	// creation does not enforce it.
+	ZoneLimit int
+
+	// Now returns the time used for the created_at and updated_at
+	// timestamps of zones. Every change advances updated_at by at least
+	// one second, so GetZoneIfModified sees it.
+	Now func() time.Time
+
+	nextID        int
+	lastStamp     time.Time
+	zones         map[int]*snitchdns.Zone
+	records       map[int]map[int]*snitchdns.Record
+	restrictions  map[int]map[int]*snitchdns.ZoneRestriction
+	notifications map[int]map[string]*snitchdns.NotificationSubscription
+	providers     []snitchdns.NotificationProvider
+	logs          []snitchdns.QueryLog
+	settings      snitchdns.Settings
+	users         []snitchdns.User
+	apiKeys       map[int]*snitchdns.APIKey
+	aliases       map[int]*snitchdns.Alias
+
+	// missing are the optional features the fake does not serve, see
+	// RemoveFeature
+	missing map[snitchdns.Feature]bool
+
+	failures  map[string]error
+	calls     map[string]int
+	sensitive []string
+}
+
+// New creates an empty fake with the notification providers shipped with
+// SnitchDNS enabled
+func New() *Client {
+	return &Client{
+		Version:       "1.0.0",
+		Now:           time.Now,
+		zones:         map[int]*snitchdns.Zone{},
+		records:       map[int]map[int]*snitchdns.Record{},
+		restrictions:  map[int]map[int]*snitchdns.ZoneRestriction{},
+		notifications: map[int]map[string]*snitchdns.NotificationSubscription{},
+		providers: []snitchdns.NotificationProvider{
+			{ID: 1, Name: snitchdns.NotificationProviderEmail, Enabled: true},
+			{ID: 2, Name: snitchdns.NotificationProviderWebhook, Enabled: true},
+			{ID: 3, Name: snitchdns.NotificationProviderSlack, Enabled: true},
+			{ID: 4, Name: snitchdns.NotificationProviderTeams, Enabled: true},
+		},
+		settings: snitchdns.Settings{},
+		apiKeys:  map[int]*snitchdns.APIKey{},
+		aliases:  map[int]*snitchdns.Alias{},
+		missing:  map[snitchdns.Feature]bool{},
+		failures: map[string]error{},
+		calls:    map[string]int{},
+	}
+}
+
+// Fail makes every later call of the named method return err, e.g.
+// Fail("CreateRecord", err). Methods with a context variant share the name
+// of the variant without the WithContext suffix. A nil err stops failing.
+func (c *Client) Fail(method string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		delete(c.failures, method)
+		return
+	}
+	c.failures[method] = err
+}
+
+// Calls returns the number of calls of the named method, including failed
+// ones
+func (c *Client) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.calls[method]
+}
+
+// AddSensitiveValue records a secret registered for redaction, see
+// SensitiveValues
+func (c *Client) AddSensitiveValue(value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if value != "" {
+		c.sensitive = append(c.sensitive, value)
+	}
+}
+
+// SensitiveValues returns the secrets registered with AddSensitiveValue, in
+// the order they were registered
+func (c *Client) SensitiveValues() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.sensitive)
+}
+
+// featureMethods are the methods of each optional feature and the request
+// their not found errors name
+var featureMethods = map[string]struct {
+	feature      snitchdns.Feature
+	method, path string
+}{
+	"SearchLogs":                {snitchdns.FeatureQueryLogs, "GET", "/search"},
+	"ExportQueryLog":            {snitchdns.FeatureQueryLogs, "GET", "/search/export"},
+	"ListNotificationProviders": {snitchdns.FeatureNotifications, "GET", "/notifications/providers"},
+	"ListZoneNotifications":     {snitchdns.FeatureNotifications, "GET", "/zones/notifications"},
+	"GetZoneNotification":       {snitchdns.FeatureNotifications, "GET", "/zones/notifications"},
+	"UpdateZoneNotification":    {snitchdns.FeatureNotifications, "POST", "/zones/notifications"},
+}
+
+// RemoveFeature makes the fake act like a SnitchDNS build without an
+// optional feature: HasFeature reports it missing, and the methods of the
+// feature fail with a 404 snitchdns.APIError
+func (c *Client) RemoveFeature(feature snitchdns.Feature) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.missing[feature] = true
+}
+
+// call records a call of method and returns its injected failure, if any,
+// or a not found error for methods of removed features. The caller must
+// hold the lock.
+func (c *Client) call(method string) error {
+	c.calls[method]++
+	if err := c.failures[method]; err != nil {
+		return err
+	}
+	if m, ok := featureMethods[method]; ok && c.missing[m.feature] {
+		return notFound(m.method, m.path)
+	}
+	return nil
+}
+
+// AddZone stores a zone as if it had been created outside of the code under
+// test, assigning an ID when it has none, and returns the stored zone
+func (c *Client) AddZone(zone snitchdns.Zone) snitchdns.Zone {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if zone.ID == 0 {
+		zone.ID = c.newID()
+	}
+	if zone.UpdatedAt == "" {
+		zone.CreatedAt = c.stamp()
+		zone.UpdatedAt = zone.CreatedAt
+	}
+	zone.Tags = snitchdns.NewZoneTags(zone.Tags)
+	c.zones[zone.ID] = &zone
+	return zone
+}
+
+// AddRecord stores a record in a zone as if it had been created outside of
+// the code under test and returns the stored record
+func (c *Client) AddRecord(zoneID int, record snitchdns.Record) snitchdns.Record {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if record.ID == 0 {
+		record.ID = c.newID()
+	}
+	record.ZoneID = zoneID
+	record.DataRaw = encodeData(record.Data)
+	record.ConditionalDataRaw = encodeData(record.ConditionalData)
+	if c.records[zoneID] == nil {
+		c.records[zoneID] = map[int]*snitchdns.Record{}
+	}
+	c.records[zoneID][record.ID] = &record
+	return copyRecord(&record)
+}
+
+// AddLogs appends entries to the query log. Entries without an ID are
+// numbered in order, so later entries are newer.
+func (c *Client) AddLogs(logs ...snitchdns.QueryLog) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, log := range logs {
+		if log.ID == 0 {
+			log.ID = c.newID()
+		}
+		c.logs = append(c.logs, log)
+	}
+}
+
+// AddUser stores a user account returned by ListUsers
+func (c *Client) AddUser(user snitchdns.User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if user.ID == 0 {
+		user.ID = c.newID()
+	}
+	c.users = append(c.users, user)
+}
+
+// SetNotificationProviders replaces the notification providers available
+// on the fake server
+func (c *Client) SetNotificationProviders(providers ...snitchdns.NotificationProvider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.providers = slices.Clone(providers)
+}
+
+// Zones returns all stored zones ordered by ID
+func (c *Client) Zones() []snitchdns.Zone {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.listZones()
+}
+
+// Records returns the stored records of a zone ordered by ID
+func (c *Client) Records(zoneID int) []snitchdns.Record {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.listRecords(zoneID)
+}
+
+// GetServerInfo returns the configured version
+func (c *Client) GetServerInfo(_ context.Context) (*snitchdns.ServerInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetServerInfo"); err != nil {
+		return nil, err
+	}
+	return &snitchdns.ServerInfo{Version: c.Version}, nil
+}
+
+// Ping succeeds unless an error is injected
+func (c *Client) Ping(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("Ping"); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// HasFeature reports whether the feature was not removed with RemoveFeature
+func (c *Client) HasFeature(ctx context.Context, feature snitchdns.Feature) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("HasFeature"); err != nil {
+		return false, err
+	}
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	return !c.missing[feature], nil
+}
+
+// ListZones returns all zones
+func (c *Client) ListZones() ([]snitchdns.Zone, error) {
+	return c.ListZonesWithContext(context.Background())
+}
+
+// ListZonesWithContext returns all zones
+func (c *Client) ListZonesWithContext(ctx context.Context) ([]snitchdns.Zone, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ListZones"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.listZones(), nil
+}
+
+// SearchZonesWithContext returns the zones whose domain contains search,
+// ignoring case
+func (c *Client) SearchZonesWithContext(ctx context.Context, search string) ([]snitchdns.Zone, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("SearchZones"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	search = strings.ToLower(search)
+	return slices.DeleteFunc(c.listZones(), func(zone snitchdns.Zone) bool {
+		return !strings.Contains(strings.ToLower(zone.Domain), search)
+	}), nil
+}
+
+// CreateZone creates a zone
+func (c *Client) CreateZone(req snitchdns.CreateZoneRequest) (*snitchdns.Zone, error) {
+	return c.CreateZoneWithContext(context.Background(), req)
+}
+
+// CreateZoneWithContext creates a zone. Empty and duplicate domains are
+// rejected with the SnitchDNS error codes.
+func (c *Client) CreateZoneWithContext(ctx context.Context, req snitchdns.CreateZoneRequest) (*snitchdns.Zone, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("CreateZone"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := c.checkDomain("POST", "/zones", req.Domain, 0); err != nil {
+		return nil, err
+	}
+
+	stamp := c.stamp()
+	zone := &snitchdns.Zone{
+		ID:         c.newID(),
+		UserID:     req.UserID,
+		Domain:     req.Domain,
+		Active:     req.Active,
+		CatchAll:   req.CatchAll,
+		Forwarding: req.Forwarding,
+		Regex:      req.Regex,
+		Master:     req.Master,
+		Tags:       snitchdns.NewZoneTags(req.Tags),
+		CreatedAt:  stamp,
+		UpdatedAt:  stamp,
+	}
+	if zone.UserID == 0 {
+		zone.UserID = 1
+	}
+	c.zones[zone.ID] = zone
+
+	result := *zone
+	return &result, nil
+}
+
+// GetZone returns a zone
+func (c *Client) GetZone(id string) (*snitchdns.Zone, error) {
+	return c.GetZoneWithContext(context.Background(), id)
+}
+
+// GetZoneWithContext returns a zone
+func (c *Client) GetZoneWithContext(ctx context.Context, id string) (*snitchdns.Zone, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetZone"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	zone, err := c.zone("GET", id)
+	if err != nil {
+		return nil, err
+	}
+	result := *zone
+	return &result, nil
+}
+
+// GetZoneIfModified returns a zone unless its updated_at equals updatedAt
+func (c *Client) GetZoneIfModified(ctx context.Context, id, updatedAt string) (*snitchdns.Zone, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetZoneIfModified"); err != nil {
+		return nil, false, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	zone, err := c.zone("GET", id)
+	if err != nil {
+		return nil, false, err
+	}
+	if updatedAt != "" && zone.UpdatedAt == updatedAt {
+		return nil, false, nil
+	}
+	result := *zone
+	return &result, true, nil
+}
+
+// UpdateZone updates a zone
+func (c *Client) UpdateZone(id string, req snitchdns.UpdateZoneRequest) (*snitchdns.Zone, error) {
+	return c.UpdateZoneWithContext(context.Background(), id, req)
+}
+
+// UpdateZoneWithContext updates the fields of a zone set in req
+func (c *Client) UpdateZoneWithContext(ctx context.Context, id string, req snitchdns.UpdateZoneRequest) (*snitchdns.Zone, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("UpdateZone"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	zone, err := c.zone("POST", id)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpectedUpdatedAt != "" && req.ExpectedUpdatedAt != zone.UpdatedAt {
+		return nil, &snitchdns.ConflictError{Method: "POST", Path: "/zones/" + id, ExpectedUpdatedAt: req.ExpectedUpdatedAt, UpdatedAt: zone.UpdatedAt}
+	}
+	if req.Domain != nil {
+		if err := c.checkDomain("POST", "/zones/"+id, *req.Domain, zone.ID); err != nil {
+			return nil, err
+		}
+		zone.Domain = *req.Domain
+	}
+	setIf(&zone.Active, req.Active)
+	setIf(&zone.CatchAll, req.CatchAll)
+	setIf(&zone.Forwarding, req.Forwarding)
+	setIf(&zone.Regex, req.Regex)
+	if req.Tags != nil {
+		zone.Tags = snitchdns.NewZoneTags(*req.Tags)
+	}
+	zone.UpdatedAt = c.stamp()
+
+	result := *zone
+	return &result, nil
+}
+
+// DeleteZone deletes a zone
+func (c *Client) DeleteZone(id string) error {
+	return c.DeleteZoneWithContext(context.Background(), id)
+}
+
+// DeleteZoneWithContext deletes a zone along with its records,
+// restrictions and notification subscriptions
+func (c *Client) DeleteZoneWithContext(ctx context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("DeleteZone"); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	zone, err := c.zone("DELETE", id)
+	if err != nil {
+		return err
+	}
+	delete(c.zones, zone.ID)
+	delete(c.records, zone.ID)
+	delete(c.restrictions, zone.ID)
+	delete(c.notifications, zone.ID)
+	return nil
+}
+
+// GetZoneStats returns the number of records of a zone and its hits in the
+// query log
+func (c *Client) GetZoneStats(ctx context.Context, zoneID string) (*snitchdns.ZoneStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetZoneStats"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	zone, err := c.zone("GET", zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &snitchdns.ZoneStats{RecordCount: len(c.records[zone.ID])}
+	for _, log := range c.logs {
+		if log.ZoneID != zone.ID {
+			continue
+		}
+		stats.TotalHits++
+		if log.Date > stats.LastActivity {
+			stats.LastActivity = log.Date
+		}
+	}
+	return stats, nil
+}
+
+// GetZoneQueryStats counts the queries of a zone in the query log per type
+// and per day. Time bounds only apply to entries whose date is in the
+// "2006-01-02 15:04:05" format.
+func (c *Client) GetZoneQueryStats(ctx context.Context, zoneID string, params snitchdns.ZoneQueryStatsParams) (*snitchdns.ZoneQueryStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetZoneQueryStats"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	zone, err := c.zone("GET", zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &snitchdns.ZoneQueryStats{ByType: map[string]int{}, ByDay: map[string]int{}}
+	search := snitchdns.SearchParams{From: params.From, To: params.To}
+	for _, log := range c.logs {
+		if log.ZoneID != zone.ID || !searchMatch(log, search) {
+			continue
+		}
+		stats.Total++
+		stats.ByType[strings.ToUpper(log.Type)]++
+		if date, err := time.Parse(dateLayout, log.Date); err == nil {
+			stats.ByDay[date.Format(time.DateOnly)]++
+		}
+	}
+	return stats, nil
+}
+
+// ImportZonesCSV creates the zones of a CSV in the SnitchDNS format for
+// user 1. Like the server, it creates all of them or, when a row is invalid
+// or a domain exists, none.
+func (c *Client) ImportZonesCSV(ctx context.Context, r io.Reader) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ImportZonesCSV"); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	const path = "/zones/import"
+	requests, err := snitchdns.ReadZonesCSV(r)
+	if err != nil {
+		return apiError("POST", path, http.StatusBadRequest, 0, err.Error())
+	}
+
+	seen := make(map[string]bool, len(requests))
+	for _, req := range requests {
+		if err := c.checkDomain("POST", path, req.Domain, 0); err != nil {
+			return err
+		}
+		if seen[strings.ToLower(req.Domain)] {
+			return apiError("POST", path, http.StatusBadRequest, snitchdns.ErrCodeZoneExists, "Domain already exists")
+		}
+		seen[strings.ToLower(req.Domain)] = true
+	}
+
+	stamp := c.stamp()
+	for _, req := range requests {
+		zone := &snitchdns.Zone{
+			ID:         c.newID(),
+			UserID:     1,
+			Domain:     req.Domain,
+			Active:     req.Active,
+			CatchAll:   req.CatchAll,
+			Forwarding: req.Forwarding,
+			Regex:      req.Regex,
+			Master:     req.Master,
+			Tags:       snitchdns.NewZoneTags(req.Tags),
+			CreatedAt:  stamp,
+			UpdatedAt:  stamp,
+		}
+		c.zones[zone.ID] = zone
+	}
+	return nil
+}
+
+// CreateRecord creates a record
+func (c *Client) CreateRecord(zoneID string, req snitchdns.CreateRecordRequest) (*snitchdns.Record, error) {
+	return c.CreateRecordWithContext(context.Background(), zoneID, req)
+}
+
+// CreateRecordWithContext creates a record in a zone
+func (c *Client) CreateRecordWithContext(ctx context.Context, zoneID string, req snitchdns.CreateRecordRequest) (*snitchdns.Record, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("CreateRecord"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	zone, err := c.zone("POST", zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &snitchdns.Record{
+		ID:               c.newID(),
+		ZoneID:           zone.ID,
+		Active:           req.Active,
+		Class:            req.Class,
+		Type:             req.Type,
+		TTL:              req.TTL,
+		IsConditional:    req.IsConditional,
+		ConditionalCount: req.ConditionalCount,
+		ConditionalLimit: req.ConditionalLimit,
+		ConditionalReset: req.ConditionalReset,
+	}
+	setData(record, snitchdns.EncodeRecordData(req.Data), snitchdns.EncodeRecordData(req.ConditionalData))
+	if c.records[zone.ID] == nil {
+		c.records[zone.ID] = map[int]*snitchdns.Record{}
+	}
+	c.records[zone.ID][record.ID] = record
+
+	result := copyRecord(record)
+	return &result, nil
+}
+
+// GetRecord returns a record
+func (c *Client) GetRecord(zoneID, recordID string) (*snitchdns.Record, error) {
+	return c.GetRecordWithContext(context.Background(), zoneID, recordID)
+}
+
+// GetRecordWithContext returns a record of a zone
+func (c *Client) GetRecordWithContext(ctx context.Context, zoneID, recordID string) (*snitchdns.Record, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetRecord"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	record, err := c.record("GET", zoneID, recordID)
+	if err != nil {
+		return nil, err
+	}
+	result := copyRecord(record)
+	return &result, nil
+}
+
+// ListRecords returns the records of a zone
+func (c *Client) ListRecords(zoneID string) ([]snitchdns.Record, error) {
+	return c.ListRecordsWithContext(context.Background(), zoneID)
+}
+
+// ListRecordsWithContext returns the records of a zone ordered by ID
+func (c *Client) ListRecordsWithContext(ctx context.Context, zoneID string) ([]snitchdns.Record, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ListRecords"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	zone, err := c.zone("GET", zoneID)
+	if err != nil {
+		return nil, err
+	}
+	return c.listRecords(zone.ID), nil
+}
+
+// UpdateRecord updates a record
+func (c *Client) UpdateRecord(zoneID, recordID string, req snitchdns.UpdateRecordRequest) (*snitchdns.Record, error) {
+	return c.UpdateRecordWithContext(context.Background(), zoneID, recordID, req)
+}
+
+// UpdateRecordWithContext updates the fields of a record set in req
+func (c *Client) UpdateRecordWithContext(ctx context.Context, zoneID, recordID string, req snitchdns.UpdateRecordRequest) (*snitchdns.Record, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("UpdateRecord"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	record, err := c.record("POST", zoneID, recordID)
+	if err != nil {
+		return nil, err
+	}
+	setIf(&record.Active, req.Active)
+	setIf(&record.Class, req.Class)
+	setIf(&record.Type, req.Type)
+	setIf(&record.TTL, req.TTL)
+	setIf(&record.IsConditional, req.IsConditional)
+	setIf(&record.ConditionalCount, req.ConditionalCount)
+	setIf(&record.ConditionalLimit, req.ConditionalLimit)
+	setIf(&record.ConditionalReset, req.ConditionalReset)
+
+	data, conditionalData := record.Data, record.ConditionalData
+	if req.Data != nil {
+		data = snitchdns.EncodeRecordData(req.Data)
+	}
+	if req.ConditionalData != nil {
+		conditionalData = snitchdns.EncodeRecordData(req.ConditionalData)
+	}
+	setData(record, data, conditionalData)
+
+	result := copyRecord(record)
+	return &result, nil
+}
+
+// ResetConditionalCount sets the query count of a record back to zero
+func (c *Client) ResetConditionalCount(ctx context.Context, zoneID, recordID string) (*snitchdns.Record, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ResetConditionalCount"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	record, err := c.record("POST", zoneID, recordID)
+	if err != nil {
+		return nil, err
+	}
+	record.ConditionalCount = 0
+
+	result := copyRecord(record)
+	return &result, nil
+}
+
+// DeleteRecord deletes a record
+func (c *Client) DeleteRecord(zoneID, recordID string) error {
+	return c.DeleteRecordWithContext(context.Background(), zoneID, recordID)
+}
+
+// DeleteRecordWithContext deletes a record of a zone
+func (c *Client) DeleteRecordWithContext(ctx context.Context, zoneID, recordID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("DeleteRecord"); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	record, err := c.record("DELETE", zoneID, recordID)
+	if err != nil {
+		return err
+	}
+	delete(c.records[record.ZoneID], record.ID)
+	return nil
+}
+
+// ImportRecordsCSV fails with 404 Not Found like SnitchDNS releases without
+// the import endpoint, so callers take their record-by-record fallback
+func (c *Client) ImportRecordsCSV(ctx context.Context, zoneID string, _ []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ImportRecordsCSV"); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return notFound("POST", fmt.Sprintf("/zones/%s/records/import", zoneID))
+}
+
+// ListRestrictions returns the restrictions of a zone ordered by ID
+func (c *Client) ListRestrictions(ctx context.Context, zoneID string) ([]snitchdns.ZoneRestriction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ListRestrictions"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	zone, err := c.zone("GET", zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	restrictions := make([]snitchdns.ZoneRestriction, 0, len(c.restrictions[zone.ID]))
+	for _, restriction := range c.restrictions[zone.ID] {
+		restrictions = append(restrictions, *restriction)
+	}
+	slices.SortFunc(restrictions, func(a, b snitchdns.ZoneRestriction) int { return a.ID - b.ID })
+	return restrictions, nil
+}
+
+// CreateRestriction adds a restriction to a zone
+func (c *Client) CreateRestriction(ctx context.Context, zoneID string, req snitchdns.RestrictionRequest) (*snitchdns.ZoneRestriction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("CreateRestriction"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	zone, err := c.zone("POST", zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	restriction := &snitchdns.ZoneRestriction{
+		ID:      c.newID(),
+		ZoneID:  zone.ID,
+		IPRange: req.IPRange,
+		Type:    req.Type,
+		Enabled: true,
+	}
+	setIf(&restriction.Enabled, req.Enabled)
+	if c.restrictions[zone.ID] == nil {
+		c.restrictions[zone.ID] = map[int]*snitchdns.ZoneRestriction{}
+	}
+	c.restrictions[zone.ID][restriction.ID] = restriction
+
+	result := *restriction
+	return &result, nil
+}
+
+// GetRestriction returns a restriction of a zone
+func (c *Client) GetRestriction(ctx context.Context, zoneID, restrictionID string) (*snitchdns.ZoneRestriction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetRestriction"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	restriction, err := c.restriction("GET", zoneID, restrictionID)
+	if err != nil {
+		return nil, err
+	}
+	result := *restriction
+	return &result, nil
+}
+
+// UpdateRestriction updates the fields of a restriction set in req
+func (c *Client) UpdateRestriction(ctx context.Context, zoneID, restrictionID string, req snitchdns.RestrictionRequest) (*snitchdns.ZoneRestriction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("UpdateRestriction"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	restriction, err := c.restriction("POST", zoneID, restrictionID)
+	if err != nil {
+		return nil, err
+	}
+	if req.IPRange != "" {
+		restriction.IPRange = req.IPRange
+	}
+	if req.Type != "" {
+		restriction.Type = req.Type
+	}
+	setIf(&restriction.Enabled, req.Enabled)
+
+	result := *restriction
+	return &result, nil
+}
+
+// DeleteRestriction deletes a restriction of a zone
+func (c *Client) DeleteRestriction(ctx context.Context, zoneID, restrictionID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("DeleteRestriction"); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	restriction, err := c.restriction("DELETE", zoneID, restrictionID)
+	if err != nil {
+		return err
+	}
+	delete(c.restrictions[restriction.ZoneID], restriction.ID)
+	return nil
+}
+
+// ListNotificationProviders returns the notification providers
+func (c *Client) ListNotificationProviders(ctx context.Context) ([]snitchdns.NotificationProvider, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ListNotificationProviders"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return slices.Clone(c.providers), nil
+}
+
+// ListZoneNotifications returns a zone's subscriptions to every provider,
+// disabled ones included
+func (c *Client) ListZoneNotifications(ctx context.Context, zoneID string) ([]snitchdns.NotificationSubscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ListZoneNotifications"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	zone, err := c.zone("GET", zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]snitchdns.NotificationSubscription, 0, len(c.providers))
+	for _, provider := range c.providers {
+		subscriptions = append(subscriptions, *c.subscription(zone.ID, provider))
+	}
+	return subscriptions, nil
+}
+
+// GetZoneNotification returns a zone's subscription to a provider
+func (c *Client) GetZoneNotification(ctx context.Context, zoneID, provider string) (*snitchdns.NotificationSubscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetZoneNotification"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	subscription, err := c.zoneSubscription("GET", zoneID, provider)
+	if err != nil {
+		return nil, err
+	}
+	result := *subscription
+	return &result, nil
+}
+
+// UpdateZoneNotification updates a zone's subscription to a provider
+func (c *Client) UpdateZoneNotification(ctx context.Context, zoneID, provider string, req snitchdns.UpdateNotificationRequest) (*snitchdns.NotificationSubscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("UpdateZoneNotification"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	subscription, err := c.zoneSubscription("POST", zoneID, provider)
+	if err != nil {
+		return nil, err
+	}
+	setIf(&subscription.Enabled, req.Enabled)
+	if req.Data != nil {
+		data, err := json.Marshal(req.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		subscription.Data = data
+	}
+	c.notifications[subscription.ZoneID][provider] = subscription
+
+	result := *subscription
+	return &result, nil
+}
+
+// SearchLogs returns a page of the query log matching params, newest
+// entries first. The domain matches as a substring; time bounds only apply
+// to entries whose date is in the "2006-01-02 15:04:05" format.
+func (c *Client) SearchLogs(ctx context.Context, params snitchdns.SearchParams) (*snitchdns.SearchPage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("SearchLogs"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var matches []snitchdns.QueryLog
+	for _, log := range c.logs {
+		if searchMatch(log, params) {
+			matches = append(matches, log)
+		}
+	}
+	slices.SortFunc(matches, func(a, b snitchdns.QueryLog) int { return b.ID - a.ID })
+
+	page, perPage := max(params.Page, 1), params.PerPage
+	if perPage <= 0 {
+		perPage = 20
+	}
+	pages := (len(matches) + perPage - 1) / perPage
+	start := min((page-1)*perPage, len(matches))
+	end := min(start+perPage, len(matches))
+
+	return &snitchdns.SearchPage{
+		Page:    page,
+		Pages:   pages,
+		Count:   len(matches),
+		Results: slices.Clone(matches[start:end]),
+	}, nil
+}
+
+// ExportQueryLog returns the matching query log entries, newest first, as
+// CSV with a header row or as a JSON array
+func (c *Client) ExportQueryLog(ctx context.Context, params snitchdns.SearchParams, format snitchdns.QueryLogFormat) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ExportQueryLog"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !format.Valid() {
+		return nil, fmt.Errorf("unsupported query log export format %q", format)
+	}
+
+	matches := []snitchdns.QueryLog{}
+	for _, log := range c.logs {
+		if searchMatch(log, params) {
+			matches = append(matches, log)
+		}
+	}
+	slices.SortFunc(matches, func(a, b snitchdns.QueryLog) int { return b.ID - a.ID })
+
+	if format == snitchdns.QueryLogFormatJSON {
+		return json.Marshal(matches)
+	}
+
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+	w.Write([]string{"id", "date", "source_ip", "domain", "type", "matched", "forwarded", "blocked", "zone_id", "record_id"})
+	for _, log := range matches {
+		w.Write([]string{
+			strconv.Itoa(log.ID), log.Date, log.SourceIP, log.Domain, log.Type,
+			strconv.FormatBool(log.Matched), strconv.FormatBool(log.Forwarded), strconv.FormatBool(log.Blocked),
+			strconv.Itoa(log.ZoneID), strconv.Itoa(log.RecordID),
+		})
+	}
+	w.Flush()
+	return out.Bytes(), w.Error()
+}
+
+// ClearZoneLogs deletes the logged queries answered by a zone
+func (c *Client) ClearZoneLogs(ctx context.Context, zoneID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ClearZoneLogs"); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	zone, err := c.zone("DELETE", zoneID)
+	if err != nil {
+		return err
+	}
+	c.logs = slices.DeleteFunc(c.logs, func(log snitchdns.QueryLog) bool { return log.ZoneID == zone.ID })
+	return nil
+}
+
+// GetSettings returns the server settings
+func (c *Client) GetSettings(ctx context.Context) (snitchdns.Settings, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetSettings"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return copySettings(c.settings), nil
+}
+
+// UpdateSettings updates the given settings and returns all settings
+func (c *Client) UpdateSettings(ctx context.Context, settings snitchdns.Settings) (snitchdns.Settings, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("UpdateSettings"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for name, value := range settings {
+		c.settings[name] = value
+	}
+	return copySettings(c.settings), nil
+}
+
+// ListUsers returns the user accounts
+func (c *Client) ListUsers(ctx context.Context) ([]snitchdns.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ListUsers"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return slices.Clone(c.users), nil
+}
+
+// GetQuota returns ZoneLimit and the number of zones of the API key's user
+func (c *Client) GetQuota(ctx context.Context) (*snitchdns.Quota, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetQuota"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	quota := &snitchdns.Quota{ZoneLimit: c.ZoneLimit}
+	for _, zone := range c.zones {
+		// Zones added without a user ID belong to the API key's user too
+		if zone.UserID <= 1 {
+			quota.ZonesUsed++
+		}
+	}
+	return quota, nil
+}
+
+// ListAPIKeys returns the API keys ordered by ID, without their secrets
+func (c *Client) ListAPIKeys(ctx context.Context) ([]snitchdns.APIKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ListAPIKeys"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	keys := make([]snitchdns.APIKey, 0, len(c.apiKeys))
+	for _, key := range c.apiKeys {
+		listed := *key
+		listed.Key = ""
+		keys = append(keys, listed)
+	}
+	slices.SortFunc(keys, func(a, b snitchdns.APIKey) int { return a.ID - b.ID })
+	return keys, nil
+}
+
+// CreateAPIKey stores an API key with a generated secret. Keys belong to
+// user 1 unless the request names another user.
+func (c *Client) CreateAPIKey(ctx context.Context, req snitchdns.CreateAPIKeyRequest) (*snitchdns.APIKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("CreateAPIKey"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	id := c.newID()
+	key := &snitchdns.APIKey{
+		ID:        id,
+		UserID:    req.UserID,
+		Name:      req.Name,
+		Key:       fmt.Sprintf("snitchdnsmock-key-%d", id),
+		Enabled:   true,
+		CreatedAt: c.stamp(),
+	}
+	if key.UserID == 0 {
+		key.UserID = 1
+	}
+	setIf(&key.Enabled, req.Enabled)
+	c.apiKeys[id] = key
+
+	created := *key
+	return &created, nil
+}
+
+// GetAPIKey returns an API key without its secret
+func (c *Client) GetAPIKey(ctx context.Context, id string) (*snitchdns.APIKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetAPIKey"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key, err := c.apiKey("GET", id)
+	if err != nil {
+		return nil, err
+	}
+	result := *key
+	result.Key = ""
+	return &result, nil
+}
+
+// UpdateAPIKey updates the fields of an API key set in req
+func (c *Client) UpdateAPIKey(ctx context.Context, id string, req snitchdns.UpdateAPIKeyRequest) (*snitchdns.APIKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("UpdateAPIKey"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key, err := c.apiKey("POST", id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		key.Name = req.Name
+	}
+	setIf(&key.Enabled, req.Enabled)
+
+	result := *key
+	result.Key = ""
+	return &result, nil
+}
+
+// DeleteAPIKey deletes an API key
+func (c *Client) DeleteAPIKey(ctx context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("DeleteAPIKey"); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	key, err := c.apiKey("DELETE", id)
+	if err != nil {
+		return err
+	}
+	delete(c.apiKeys, key.ID)
+	return nil
+}
+
+// apiKey returns the stored API key. The caller must hold the lock.
+func (c *Client) apiKey(method, id string) (*snitchdns.APIKey, error) {
+	keyID, err := strconv.Atoi(id)
+	if err != nil || c.apiKeys[keyID] == nil {
+		return nil, notFound(method, "/apikeys/"+id)
+	}
+	return c.apiKeys[keyID], nil
+}
+
+// ListAliases returns the aliases ordered by ID
+func (c *Client) ListAliases(ctx context.Context) ([]snitchdns.Alias, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("ListAliases"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	aliases := make([]snitchdns.Alias, 0, len(c.aliases))
+	for _, alias := range c.aliases {
+		aliases = append(aliases, *alias)
+	}
+	slices.SortFunc(aliases, func(a, b snitchdns.Alias) int { return a.ID - b.ID })
+	return aliases, nil
+}
+
+// CreateAlias stores an alias
+func (c *Client) CreateAlias(ctx context.Context, req snitchdns.AliasRequest) (*snitchdns.Alias, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("CreateAlias"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	alias := &snitchdns.Alias{ID: c.newID(), IP: req.IP, Name: req.Name}
+	c.aliases[alias.ID] = alias
+
+	result := *alias
+	return &result, nil
+}
+
+// GetAlias returns an alias
+func (c *Client) GetAlias(ctx context.Context, id string) (*snitchdns.Alias, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("GetAlias"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	alias, err := c.alias("GET", id)
+	if err != nil {
+		return nil, err
+	}
+	result := *alias
+	return &result, nil
+}
+
+// UpdateAlias updates the fields of an alias set in req
+func (c *Client) UpdateAlias(ctx context.Context, id string, req snitchdns.AliasRequest) (*snitchdns.Alias, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("UpdateAlias"); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	alias, err := c.alias("POST", id)
+	if err != nil {
+		return nil, err
+	}
+	if req.IP != "" {
+		alias.IP = req.IP
+	}
+	if req.Name != "" {
+		alias.Name = req.Name
+	}
+
+	result := *alias
+	return &result, nil
+}
+
+// DeleteAlias deletes an alias
+func (c *Client) DeleteAlias(ctx context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.call("DeleteAlias"); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	alias, err := c.alias("DELETE", id)
+	if err != nil {
+		return err
+	}
+	delete(c.aliases, alias.ID)
+	return nil
+}
+
+// alias returns the stored alias. The caller must hold the lock.
+func (c *Client) alias(method, id string) (*snitchdns.Alias, error) {
+	aliasID, err := strconv.Atoi(id)
+	if err != nil || c.aliases[aliasID] == nil {
+		return nil, notFound(method, "/aliases/"+id)
+	}
+	return c.aliases[aliasID], nil
+}
+
+// newID returns the next object ID. IDs are unique across object kinds,
+// which catches code mixing up zone and record IDs. The caller must hold
+// the lock.
+func (c *Client) newID() int {
+	c.nextID++
+	return c.nextID
+}
+
+// stamp returns a timestamp later than every previous one. The caller must
+// hold the lock.
+func (c *Client) stamp() string {
+	now := c.Now().UTC().Truncate(time.Second)
+	if !now.After(c.lastStamp) {
+		now = c.lastStamp.Add(time.Second)
+	}
+	c.lastStamp = now
+	return now.Format(dateLayout)
+}
+
+// checkDomain rejects empty domains and domains of other zones than
+// zoneID. The caller must hold the lock.
+func (c *Client) checkDomain(method, path, domain string, zoneID int) error {
+	if strings.TrimSpace(domain) == "" {
+		return apiError(method, path, http.StatusBadRequest, snitchdns.ErrCodeEmptyDomain, "Domain is empty")
+	}
+	for _, zone := range c.zones {
+		if zone.ID != zoneID && strings.EqualFold(zone.Domain, domain) {
+			return apiError(method, path, http.StatusBadRequest, snitchdns.ErrCodeZoneExists, "Domain already exists")
+		}
+	}
+	return nil
+}
+
+// zone returns the stored zone with the given ID. The caller must hold the
+// lock.
+func (c *Client) zone(method, id string) (*snitchdns.Zone, error) {
+	path := "/zones/" + id
+	zoneID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, notFound(method, path)
+	}
+	zone, ok := c.zones[zoneID]
+	if !ok {
+		return nil, notFound(method, path)
+	}
+	return zone, nil
+}
+
+// record returns the stored record of a zone. The caller must hold the
+// lock.
+func (c *Client) record(method, zoneID, recordID string) (*snitchdns.Record, error) {
+	zone, err := c.zone(method, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/zones/%s/records/%s", zoneID, recordID)
+	id, err := strconv.Atoi(recordID)
+	if err != nil {
+		return nil, notFound(method, path)
+	}
+	record, ok := c.records[zone.ID][id]
+	if !ok {
+		return nil, notFound(method, path)
+	}
+	return record, nil
+}
+
+// restriction returns the stored restriction of a zone. The caller must
+// hold the lock.
+func (c *Client) restriction(method, zoneID, restrictionID string) (*snitchdns.ZoneRestriction, error) {
+	zone, err := c.zone(method, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/zones/%s/restrictions/%s", zoneID, restrictionID)
+	id, err := strconv.Atoi(restrictionID)
+	if err != nil {
+		return nil, notFound(method, path)
+	}
+	restriction, ok := c.restrictions[zone.ID][id]
+	if !ok {
+		return nil, notFound(method, path)
+	}
+	return restriction, nil
+}
+
+// zoneSubscription returns a copy of a zone's subscription to a provider,
+// which must exist. The caller must hold the lock.
+func (c *Client) zoneSubscription(method, zoneID, provider string) (*snitchdns.NotificationSubscription, error) {
+	zone, err := c.zone(method, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(c.providers, func(p snitchdns.NotificationProvider) bool { return p.Name == provider })
+	if index < 0 {
+		return nil, notFound(method, fmt.Sprintf("/zones/%s/notifications/%s", zoneID, provider))
+	}
+
+	subscription := *c.subscription(zone.ID, c.providers[index])
+	if c.notifications[zone.ID] == nil {
+		c.notifications[zone.ID] = map[string]*snitchdns.NotificationSubscription{}
+	}
+	return &subscription, nil
+}
+
+// subscription returns a zone's subscription to a provider, a disabled one
+// without data when the zone never subscribed. The caller must hold the
+// lock.
+func (c *Client) subscription(zoneID int, provider snitchdns.NotificationProvider) *snitchdns.NotificationSubscription {
+	if subscription, ok := c.notifications[zoneID][provider.Name]; ok {
+		return subscription
+	}
+	return &snitchdns.NotificationSubscription{
+		ZoneID: zoneID,
+		TypeID: provider.ID,
+		Type:   provider.Name,
+		Data:   json.RawMessage("null"),
+	}
+}
+
+// listZones returns copies of all zones ordered by ID. The caller must hold
+// the lock.
+func (c *Client) listZones() []snitchdns.Zone {
+	zones := make([]snitchdns.Zone, 0, len(c.zones))
+	for _, zone := range c.zones {
+		zones = append(zones, *zone)
+	}
+	slices.SortFunc(zones, func(a, b snitchdns.Zone) int { return a.ID - b.ID })
+	return zones
+}
+
+// listRecords returns copies of a zone's records ordered by ID. The caller
+// must hold the lock.
+func (c *Client) listRecords(zoneID int) []snitchdns.Record {
+	records := make([]snitchdns.Record, 0, len(c.records[zoneID]))
+	for _, record := range c.records[zoneID] {
+		records = append(records, copyRecord(record))
+	}
+	slices.SortFunc(records, func(a, b snitchdns.Record) int { return a.ID - b.ID })
+	return records
+}
+
+// searchMatch reports whether a query log entry matches the search
+func searchMatch(log snitchdns.QueryLog, params snitchdns.SearchParams) bool {
+	switch {
+	case params.Domain != "" && !strings.Contains(strings.ToLower(log.Domain), strings.ToLower(params.Domain)),
+		params.SourceIP != "" && log.SourceIP != params.SourceIP,
+		params.Type != "" && !strings.EqualFold(log.Type, params.Type),
+		params.Matched != nil && log.Matched != *params.Matched,
+		params.Forwarded != nil && log.Forwarded != *params.Forwarded,
+		params.Blocked != nil && log.Blocked != *params.Blocked:
+		return false
+	}
+
+	if params.From.IsZero() && params.To.IsZero() {
+		return true
+	}
+	date, err := time.Parse(dateLayout, log.Date)
+	if err != nil {
+		return true
+	}
+	return !date.Before(params.From.UTC().Truncate(time.Second)) &&
+		(params.To.IsZero() || !date.After(params.To.UTC()))
+}
+
+// setIf sets *field to *value when value is not nil
+func setIf[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+// setData stores data and conditional data in both their parsed and their
+// JSON-encoded form, like records decoded from API responses
+func setData(record *snitchdns.Record, data, conditionalData snitchdns.RawRecordData) {
+	record.DataRaw = encodeData(data)
+	record.ConditionalDataRaw = encodeData(conditionalData)
+	record.Data = decodeData(record.DataRaw)
+	record.ConditionalData = decodeData(record.ConditionalDataRaw)
+}
+
+// encodeData encodes record data the way the API returns it
+func encodeData(data snitchdns.RawRecordData) string {
+	if data.IsEmpty() {
+		return "{}"
+	}
+	return string(data)
+}
+
+// decodeData decodes record data like the client does, returning nil for
+// empty data
+func decodeData(raw string) snitchdns.RawRecordData {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil || len(fields) == 0 {
+		return nil
+	}
+	return snitchdns.RawRecordData(raw)
+}
+
+// copyRecord returns a copy of a record that does not share its data
+func copyRecord(record *snitchdns.Record) snitchdns.Record {
+	result := *record
+	result.Data = decodeData(record.DataRaw)
+	result.ConditionalData = decodeData(record.ConditionalDataRaw)
+	return result
+}
+
+// copySettings returns a copy of settings
+func copySettings(settings snitchdns.Settings) snitchdns.Settings {
+	result := make(snitchdns.Settings, len(settings))
+	for name, value := range settings {
+		result[name] = value
+	}
+	return result
+}
+
+// notFound returns the error SnitchDNS responds with for a missing object
+func notFound(method, path string) error {
+	return apiError(method, path, http.StatusNotFound, 0, "Not found")
+}
+
+// apiError returns an API error with a SnitchDNS error response body
+func apiError(method, path string, status, code int, message string) error {
+	body, _ := json.Marshal(map[string]interface{}{"code": code, "message": message})
+	return &snitchdns.APIError{
+		Method:     method,
+		Path:       path,
+		StatusCode: status,
+		RequestID:  "snitchdnsmock",
+		Body:       string(body),
+		Code:       code,
+		Message:    message,
+	}
+}
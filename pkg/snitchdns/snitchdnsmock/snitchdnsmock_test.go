@@ -0,0 +1,192 @@
+package snitchdnsmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+)
+
+// TestZones tests zone CRUD and the errors of missing and duplicate zones
+func TestZones(t *testing.T) {
+	mock := New()
+
+	zone, err := mock.CreateZone(snitchdns.CreateZoneRequest{Domain: "example.com", Active: true, Tags: snitchdns.ZoneTags{"b", "a"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if zone.ID == 0 || zone.UpdatedAt == "" || zone.Tags.String() != "a,b" {
+		t.Errorf("Expected an ID, a timestamp and normalized tags, got %+v", zone)
+	}
+
+	_, err = mock.CreateZone(snitchdns.CreateZoneRequest{Domain: "EXAMPLE.com"})
+	var apiErr *snitchdns.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != snitchdns.ErrCodeZoneExists {
+		t.Errorf("Expected a zone exists error, got %v", err)
+	}
+
+	id := fmt.Sprint(zone.ID)
+	catchAll := true
+	updated, err := mock.UpdateZone(id, snitchdns.UpdateZoneRequest{CatchAll: &catchAll})
+	if err != nil || !updated.CatchAll || !updated.Active {
+		t.Fatalf("Expected only catch_all to change, got %+v, %v", updated, err)
+	}
+	if updated.UpdatedAt <= zone.UpdatedAt {
+		t.Errorf("Expected updated_at to advance, got %s after %s", updated.UpdatedAt, zone.UpdatedAt)
+	}
+
+	if _, modified, err := mock.GetZoneIfModified(context.Background(), id, updated.UpdatedAt); err != nil || modified {
+		t.Errorf("Expected the zone to be unchanged, got modified=%t err=%v", modified, err)
+	}
+
+	// An update based on the first read conflicts with the one above
+	if _, err := mock.UpdateZone(id, snitchdns.UpdateZoneRequest{CatchAll: &catchAll, ExpectedUpdatedAt: zone.UpdatedAt}); !snitchdns.IsConflict(err) {
+		t.Errorf("Expected a conflict error, got %v", err)
+	}
+	if _, err := mock.UpdateZone(id, snitchdns.UpdateZoneRequest{CatchAll: &catchAll, ExpectedUpdatedAt: updated.UpdatedAt}); err != nil {
+		t.Errorf("Expected an update based on the latest read to succeed, got %v", err)
+	}
+
+	if err := mock.DeleteZone(id); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := mock.GetZone(id); !snitchdns.IsNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+	if _, err := mock.GetZone("not-a-number"); !snitchdns.IsNotFound(err) {
+		t.Errorf("Expected a not found error for an invalid ID, got %v", err)
+	}
+}
+
+// TestRecords tests that records are returned like decoded API responses
+func TestRecords(t *testing.T) {
+	mock := New()
+	zone := mock.AddZone(snitchdns.Zone{Domain: "example.com"})
+	zoneID := fmt.Sprint(zone.ID)
+
+	record, err := mock.CreateRecord(zoneID, snitchdns.CreateRecordRequest{
+		Type: "MX", Class: "IN", TTL: 300, Active: true,
+		Data: map[string]interface{}{"priority": 10, "hostname": "mail.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if record.ZoneID != zone.ID || record.Data.Get("priority") != "10" || record.DataRaw == "" {
+		t.Errorf("Expected decoded data like the API client returns, got %+v", record)
+	}
+
+	// Returned records do not share data with the stored ones
+	copy(record.Data, `{"priority":20`)
+	records, err := mock.ListRecords(zoneID)
+	if err != nil || len(records) != 1 || records[0].Data.Get("priority") != "10" {
+		t.Errorf("Expected the stored record to be unchanged, got %+v, %v", records, err)
+	}
+
+	ttl := 60
+	updated, err := mock.UpdateRecord(zoneID, fmt.Sprint(record.ID), snitchdns.UpdateRecordRequest{TTL: &ttl})
+	if err != nil || updated.TTL != 60 || updated.Data.Get("hostname") != "mail.example.com" {
+		t.Errorf("Expected only the TTL to change, got %+v, %v", updated, err)
+	}
+
+	if _, err := mock.GetRecord("999", fmt.Sprint(record.ID)); !snitchdns.IsNotFound(err) {
+		t.Errorf("Expected a not found error for another zone, got %v", err)
+	}
+	if err := mock.ImportRecordsCSV(context.Background(), zoneID, nil); !snitchdns.IsNotFound(err) {
+		t.Errorf("Expected the import endpoint to be missing, got %v", err)
+	}
+}
+
+// TestImportZonesCSV tests that a zone import creates all zones or none
+func TestImportZonesCSV(t *testing.T) {
+	ctx := context.Background()
+	mock := New()
+	mock.AddZone(snitchdns.Zone{Domain: "example.org"})
+
+	var apiErr *snitchdns.APIError
+	err := mock.ImportZonesCSV(ctx, strings.NewReader("domain,active\nexample.com,true\nexample.org,true\n"))
+	if !errors.As(err, &apiErr) || apiErr.Code != snitchdns.ErrCodeZoneExists || len(mock.Zones()) != 1 {
+		t.Fatalf("Expected the import to fail without creating zones, got %v and %+v", err, mock.Zones())
+	}
+
+	if err := mock.ImportZonesCSV(ctx, strings.NewReader("domain,active,tags\nexample.com,true,canary\n")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	zones := mock.Zones()
+	if len(zones) != 2 || zones[1].Domain != "example.com" || !zones[1].Active || zones[1].Tags.String() != "canary" {
+		t.Errorf("Expected the imported zone, got %+v", zones)
+	}
+}
+
+// TestFail tests injected failures and call counting
+func TestFail(t *testing.T) {
+	mock := New()
+	failure := errors.New("injected")
+
+	mock.Fail("ListZones", failure)
+	if _, err := mock.ListZonesWithContext(context.Background()); !errors.Is(err, failure) {
+		t.Errorf("Expected the injected error, got %v", err)
+	}
+
+	mock.Fail("ListZones", nil)
+	if _, err := mock.ListZones(); err != nil {
+		t.Errorf("Expected no error after clearing the failure, got %v", err)
+	}
+
+	if calls := mock.Calls("ListZones"); calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+// TestRemoveFeature tests that removed features are reported missing and
+// their methods fail with not found
+func TestRemoveFeature(t *testing.T) {
+	mock := New()
+	ctx := context.Background()
+
+	mock.RemoveFeature(snitchdns.FeatureQueryLogs)
+	if ok, err := mock.HasFeature(ctx, snitchdns.FeatureQueryLogs); err != nil || ok {
+		t.Errorf("Expected query logs to be missing, got %t, %v", ok, err)
+	}
+	if _, err := mock.SearchLogs(ctx, snitchdns.SearchParams{}); !snitchdns.IsNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+
+	if ok, err := mock.HasFeature(ctx, snitchdns.FeatureNotifications); err != nil || !ok {
+		t.Errorf("Expected notifications to be available, got %t, %v", ok, err)
+	}
+	if _, err := mock.ListNotificationProviders(ctx); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+// TestSearchLogs tests query log filters and paging
+func TestSearchLogs(t *testing.T) {
+	mock := New()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		mock.AddLogs(snitchdns.QueryLog{
+			Domain:  fmt.Sprintf("host%d.example.com", i),
+			Type:    "A",
+			Matched: i%2 == 0,
+			Date:    base.Add(time.Duration(i) * time.Minute).Format(dateLayout),
+		})
+	}
+
+	matched := true
+	page, err := mock.SearchLogs(context.Background(), snitchdns.SearchParams{Matched: &matched, PerPage: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if page.Count != 3 || page.Pages != 2 || len(page.Results) != 2 || page.Results[0].Domain != "host4.example.com" {
+		t.Errorf("Expected the newest 2 of 3 matched entries, got %+v", page)
+	}
+
+	page, _ = mock.SearchLogs(context.Background(), snitchdns.SearchParams{From: base.Add(3 * time.Minute)})
+	if page.Count != 2 {
+		t.Errorf("Expected 2 entries since the start time, got %+v", page)
+	}
+}
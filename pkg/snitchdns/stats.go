@@ -0,0 +1,67 @@
+package snitchdns
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ZoneStats is the activity summary of a zone
+type ZoneStats struct {
+	RecordCount int `json:"record_count"`
+	TotalHits   int `json:"total_hits"`
+	// LastActivity is the time of the most recent query, empty if the zone
+	// has never been queried
+	LastActivity string `json:"last_activity"`
+}
+
+// GetZoneStats retrieves the activity summary of a zone
+func (c *Client) GetZoneStats(ctx context.Context, zoneID string) (*ZoneStats, error) {
+	var stats ZoneStats
+	if err := c.doJSONRequest(ctx, "GET", fmt.Sprintf("/zones/%s/stats", zoneID), nil, &stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// ZoneQueryStats counts the queries a zone answered in a time range
+type ZoneQueryStats struct {
+	Total int `json:"total"`
+	// ByType counts queries per query type, such as "A"
+	ByType map[string]int `json:"by_type"`
+	// ByDay counts queries per UTC day, keyed by date in the
+	// "2006-01-02" format. Days without queries may be missing.
+	ByDay map[string]int `json:"by_day"`
+}
+
+// ZoneQueryStatsParams bounds the queries counted by GetZoneQueryStats.
+// Zero times do not bound the range.
+type ZoneQueryStatsParams struct {
+	// From and To are sent in UTC with a resolution of one second, like
+	// the time range of a search
+	From time.Time
+	To   time.Time
+}
+
+// query encodes the parameters as a query string
+func (p ZoneQueryStatsParams) query() url.Values {
+	return SearchParams{From: p.From, To: p.To}.query()
+}
+
+// GetZoneQueryStats retrieves the number of queries a zone answered per
+// query type and per day
+func (c *Client) GetZoneQueryStats(ctx context.Context, zoneID string, params ZoneQueryStatsParams) (*ZoneQueryStats, error) {
+	path := fmt.Sprintf("/zones/%s/stats/queries", zoneID)
+	if query := params.query(); len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var stats ZoneQueryStats
+	if err := c.doJSONRequest(ctx, "GET", path, nil, &stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
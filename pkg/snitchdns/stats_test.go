@@ -0,0 +1,58 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestGetZoneStats tests that zone activity is decoded
+func TestGetZoneStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/zones/5/stats" {
+			t.Errorf("Expected path /zones/5/stats, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"record_count": 3, "total_hits": 42, "last_activity": "2024-05-01T10:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	stats, err := client.GetZoneStats(context.Background(), "5")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.RecordCount != 3 || stats.TotalHits != 42 || stats.LastActivity != "2024-05-01T10:00:00Z" {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+// TestGetZoneQueryStats tests that the time range is sent and counts are decoded
+func TestGetZoneQueryStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/zones/5/stats/queries" {
+			t.Errorf("Expected path /zones/5/stats/queries, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("date_from") != "2024-05-01" || r.URL.Query().Get("time_from") != "00:00:00" || r.URL.Query().Has("date_to") {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"total": 3, "by_type": {"A": 2, "TXT": 1}, "by_day": {"2024-05-01": 1, "2024-05-03": 2}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	stats, err := client.GetZoneQueryStats(context.Background(), "5", ZoneQueryStatsParams{
+		From: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.Total != 3 || stats.ByType["A"] != 2 || stats.ByDay["2024-05-03"] != 2 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,202 @@
+package snitchdns
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// responseDecoder consumes the body of a successful response. It is called
+// once per attempt, so it must not keep results of an earlier attempt.
+type responseDecoder func(body io.Reader) error
+
+// invalidResponseError is returned by an attempt whose response body was
+// received in full but could not be decoded
+type invalidResponseError struct {
+	Err error
+}
+
+// Error implements the error interface
+func (e *invalidResponseError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the decoding failure
+func (e *invalidResponseError) Unwrap() error {
+	return e.Err
+}
+
+// responseBody returns the body of resp, decompressed when the server sent
+// it gzip-encoded. The transport only decompresses by itself when it set
+// Accept-Encoding, which the client does explicitly so that custom
+// transports get compressed responses too.
+func responseBody(resp *http.Response) (io.Reader, error) {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, nil
+	}
+	return gzip.NewReader(resp.Body)
+}
+
+// readRecorder remembers the first read error of the reader it wraps, to
+// tell a broken connection apart from a malformed body
+type readRecorder struct {
+	r   io.Reader
+	err error
+}
+
+// Read implements io.Reader
+func (r *readRecorder) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && err != io.EOF && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
+
+// streamResponse decodes a response body. Read errors are returned as
+// transport errors, so the attempt can be retried; anything else is an
+// invalidResponseError.
+func streamResponse(body io.Reader, decode responseDecoder) error {
+	recorder := &readRecorder{r: body}
+	err := decode(recorder)
+	if recorder.err != nil {
+		return fmt.Errorf("failed to read response body: %w", recorder.err)
+	}
+	if err != nil {
+		return &invalidResponseError{Err: err}
+	}
+	return nil
+}
+
+// decodeArray decodes a JSON array one element at a time, so only the
+// decoded elements are held in memory and never the whole body
+func decodeArray[T any](body io.Reader, fn func(T) error) error {
+	dec := json.NewDecoder(body)
+
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		// null lists nothing
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected a JSON array, got %v", token)
+	}
+
+	for dec.More() {
+		var element T
+		if err := dec.Decode(&element); err != nil {
+			return err
+		}
+		if err := fn(element); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// ErrSkipRemaining can be returned by the function passed to WalkZones or
+// WalkRecords to stop early. The walk then returns nil.
+var ErrSkipRemaining = errors.New("skip remaining")
+
+// errWalkStopped stops decoding a listing once the walk function returned
+// an error
+var errWalkStopped = errors.New("walk stopped")
+
+// WalkZones calls fn for every zone, requesting one page of zones at a time
+// and streaming each page into fn, so the listing of a large server is never
+// held in memory at once. Returning ErrSkipRemaining from fn stops the walk
+// without an error; any other error stops it and is returned.
+func (c *Client) WalkZones(ctx context.Context, fn func(Zone) error) error {
+	return c.walkZones(ctx, "", fn)
+}
+
+// walkZones walks the zone listing, narrowed by the server to zones
+// matching search unless it is empty
+func (c *Client) walkZones(ctx context.Context, search string, fn func(Zone) error) error {
+	for page := 1; ; page++ {
+		query := url.Values{
+			"page":     {strconv.Itoa(page)},
+			"per_page": {strconv.Itoa(zonePageSize)},
+		}
+		if search != "" {
+			query.Set("search", search)
+		}
+
+		var result zonePage
+		_, err := c.doRequest(ctx, "GET", "/zones?"+query.Encode(), "application/json", nil,
+			func(body io.Reader) error {
+				result = zonePage{}
+				return json.NewDecoder(body).Decode(&result)
+			})
+		if err != nil {
+			return err
+		}
+
+		for _, zone := range result.Results {
+			if err := fn(zone); err != nil {
+				if errors.Is(err, ErrSkipRemaining) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if page >= result.Pages || len(result.Results) == 0 {
+			return nil
+		}
+	}
+}
+
+// WalkRecords calls fn for every record of a zone. The listing is decoded
+// one record at a time as it arrives and each record is handed to fn right
+// away, so the records of a large zone are never held in memory at once.
+// When an attempt breaks off and is retried, the records fn already got are
+// skipped, so fn sees every record once. Returning ErrSkipRemaining from fn
+// stops the walk without an error; any other error stops it and is returned.
+func (c *Client) WalkRecords(ctx context.Context, zoneID string, fn func(Record) error) error {
+	var delivered int
+	var fnErr error
+	_, err := c.doRequest(ctx, "GET", fmt.Sprintf("/zones/%s/records", zoneID), "application/json", nil,
+		func(body io.Reader) error {
+			// A retried attempt starts over
+			var index int
+			err := decodeArray(body, func(record Record) error {
+				index++
+				if index <= delivered {
+					return nil
+				}
+				if err := record.parseData(); err != nil {
+					return err
+				}
+				delivered++
+				if fnErr = fn(record); fnErr != nil {
+					return errWalkStopped
+				}
+				return nil
+			})
+			if errors.Is(err, errWalkStopped) {
+				// The rest of the body is not needed
+				return nil
+			}
+			return err
+		})
+	if fnErr != nil {
+		if errors.Is(fnErr, ErrSkipRemaining) {
+			return nil
+		}
+		return fnErr
+	}
+	return err
+}
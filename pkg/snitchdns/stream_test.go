@@ -0,0 +1,185 @@
+package snitchdns
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestGzipResponses tests that compressed responses are requested and
+// decoded, and that compression can be turned off
+func TestGzipResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := `[{"id": 1, "type": "A", "data": "{\"address\": \"10.0.0.1\"}"}, {"id": 2, "type": "TXT", "data": "{\"data\": \"canary\"}"}]`
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			w.Write([]byte(body))
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(body))
+		gz.Close()
+	}))
+	defer server.Close()
+
+	for _, compression := range []bool{true, false} {
+		client := NewClient(server.URL, "test-key", WithCompression(compression))
+		records, err := client.ListRecordsWithContext(context.Background(), "1")
+		if err != nil {
+			t.Fatalf("Unexpected error with compression %t: %v", compression, err)
+		}
+		if len(records) != 2 || records[0].Data.Get("address") != "10.0.0.1" || records[1].Type != "TXT" {
+			t.Errorf("Unexpected records with compression %t: %+v", compression, records)
+		}
+	}
+}
+
+// TestWalkZones tests that zones are streamed page by page and that a walk
+// can stop before requesting further pages
+func TestWalkZones(t *testing.T) {
+	requests := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("page") == "1" {
+			w.Write([]byte(`{"page": 1, "pages": 2, "results": [{"id": 1, "domain": "a.example.com"}, {"id": 2, "domain": "b.example.com"}]}`))
+			return
+		}
+		w.Write([]byte(`{"page": 2, "pages": 2, "results": [{"id": 3, "domain": "c.example.com"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	var domains []string
+	if err := client.WalkZones(context.Background(), func(zone Zone) error {
+		domains = append(domains, zone.Domain)
+		return nil
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(domains, ",") != "a.example.com,b.example.com,c.example.com" || requests.Load() != 2 {
+		t.Errorf("Expected 3 zones in 2 requests, got %v in %d", domains, requests.Load())
+	}
+
+	requests.Store(0)
+	err := client.WalkZones(context.Background(), func(zone Zone) error {
+		return ErrSkipRemaining
+	})
+	if err != nil || requests.Load() != 1 {
+		t.Errorf("Expected the walk to stop after the first page, got %v after %d requests", err, requests.Load())
+	}
+
+	boom := errors.New("boom")
+	if err := client.WalkZones(context.Background(), func(zone Zone) error { return boom }); !errors.Is(err, boom) {
+		t.Errorf("Expected the walk function's error, got %v", err)
+	}
+}
+
+// TestWalkRecords tests that records are handed out before the listing is
+// complete, that a retried attempt does not repeat them and that a walk can
+// stop early
+func TestWalkRecords(t *testing.T) {
+	attempts := atomic.Int32{}
+	firstSeen := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		body := `[{"id": 1, "type": "A"}, {"id": 2, "type": "A"}, {"id": 3, "type": "A"}]`
+		if attempts.Add(1) == 1 {
+			// Send the first record, wait for the walk to get it, then
+			// break off the response
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.Write([]byte(body[:strings.Index(body, ", {")]))
+			w.(http.Flusher).Flush()
+			<-firstSeen
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(1, time.Millisecond, time.Millisecond))
+
+	var ids []int
+	err := client.WalkRecords(context.Background(), "1", func(record Record) error {
+		if len(ids) == 0 {
+			close(firstSeen)
+		}
+		ids = append(ids, record.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" || attempts.Load() != 2 {
+		t.Errorf("Expected records [1 2 3] in 2 attempts, got %v in %d", ids, attempts.Load())
+	}
+
+	ids = nil
+	err = client.WalkRecords(context.Background(), "1", func(record Record) error {
+		ids = append(ids, record.ID)
+		return ErrSkipRemaining
+	})
+	if err != nil || fmt.Sprint(ids) != "[1]" {
+		t.Errorf("Expected the walk to stop after the first record, got %v (err=%v)", ids, err)
+	}
+
+	boom := errors.New("boom")
+	if err := client.WalkRecords(context.Background(), "1", func(Record) error { return boom }); !errors.Is(err, boom) {
+		t.Errorf("Expected the walk function's error, got %v", err)
+	}
+}
+
+// TestStreamMalformedResponse tests that a malformed listing fails without
+// being retried
+func TestStreamMalformedResponse(t *testing.T) {
+	attempts := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.Write([]byte(`[{"id": 1}, {"id": `))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(3, time.Millisecond, time.Millisecond))
+
+	_, err := client.ListRecordsWithContext(context.Background(), "1")
+	if err == nil || !strings.Contains(err.Error(), "failed to parse response") {
+		t.Fatalf("Expected a parse error, got %v", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts.Load())
+	}
+}
+
+// TestDecodeArray tests decoding arrays element by element
+func TestDecodeArray(t *testing.T) {
+	tests := []struct {
+		body    string
+		want    int
+		wantErr bool
+	}{
+		{`[1, 2, 3]`, 3, false},
+		{`[]`, 0, false},
+		{`null`, 0, false},
+		{`{"results": []}`, 0, true},
+		{`[1, "two"]`, 1, true},
+	}
+
+	for _, tt := range tests {
+		var got int
+		err := decodeArray(strings.NewReader(tt.body), func(int) error {
+			got++
+			return nil
+		})
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("decodeArray(%s) = %d, %v; want %d, error %t", tt.body, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package snitchdns
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of the client's spans
+const tracerName = "github.com/EinDev/snitchdns-tf/pkg/snitchdns"
+
+// idSegment matches numeric path segments, which are replaced in span names
+// so that calls to the same route share a name
+var idSegment = regexp.MustCompile(`/\d+(/|$)`)
+
+// startSpan starts the span of an API call. Without a tracer the context is
+// returned unchanged with a span that records nothing.
+func (c *Client) startSpan(ctx context.Context, info RequestInfo) (context.Context, trace.Span) {
+	if c.tracer == nil {
+		return ctx, noop.Span{}
+	}
+
+	path, _, _ := strings.Cut(info.Path, "?")
+	return c.tracer.Start(ctx, info.Method+" "+spanRoute(path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", info.Method),
+			attribute.String("url.path", path),
+			attribute.String("snitchdns.request_id", info.RequestID),
+		),
+	)
+}
+
+// endSpan records the outcome of an API call and ends its span
+func endSpan(span trace.Span, info RequestInfo) {
+	if info.StatusCode != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", info.StatusCode))
+	}
+	if info.Attempts > 0 {
+		span.SetAttributes(attribute.Int("snitchdns.retry_count", info.Attempts-1))
+	}
+	if info.Err != nil {
+		span.RecordError(info.Err)
+		span.SetStatus(codes.Error, info.Err.Error())
+	}
+	span.End()
+}
+
+// spanRoute returns path with numeric IDs replaced by {id}
+func spanRoute(path string) string {
+	for idSegment.MatchString(path) {
+		path = idSegment.ReplaceAllString(path, "/{id}$1")
+	}
+	return path
+}
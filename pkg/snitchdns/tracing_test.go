@@ -0,0 +1,93 @@
+package snitchdns
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestTracing tests that every API call records one span with its outcome
+func TestTracing(t *testing.T) {
+	attempts := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/zones/1/records/2" && attempts.Add(1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path == "/zones/404" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 2, "type": "A", "data": "{}"}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	client := NewClient(server.URL, "test-key",
+		WithRetry(3, time.Millisecond, 5*time.Millisecond),
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+	)
+
+	if _, err := client.GetRecord("1", "2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.GetZone("404"); err == nil {
+		t.Fatal("Expected an error for the missing zone")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+
+	ok := spans[0]
+	if ok.Name() != "GET /zones/{id}/records/{id}" {
+		t.Errorf("Unexpected span name %q", ok.Name())
+	}
+	attrs := attribute.NewSet(ok.Attributes()...)
+	if v, _ := attrs.Value("url.path"); v.AsString() != "/zones/1/records/2" {
+		t.Errorf("Unexpected url.path %q", v.AsString())
+	}
+	if v, _ := attrs.Value("http.response.status_code"); v.AsInt64() != http.StatusOK {
+		t.Errorf("Unexpected status code %d", v.AsInt64())
+	}
+	if v, _ := attrs.Value("snitchdns.retry_count"); v.AsInt64() != 1 {
+		t.Errorf("Expected 1 retry, got %d", v.AsInt64())
+	}
+	if ok.Status().Code == codes.Error {
+		t.Errorf("Expected the successful call not to be an error, got %v", ok.Status())
+	}
+
+	failed := spans[1]
+	if failed.Status().Code != codes.Error || len(failed.Events()) == 0 {
+		t.Errorf("Expected the failed call to record its error, got %v", failed.Status())
+	}
+}
+
+// TestSpanRoute tests that numeric IDs are replaced in span names
+func TestSpanRoute(t *testing.T) {
+	tests := map[string]string{
+		"/zones":                "/zones",
+		"/zones/12":             "/zones/{id}",
+		"/zones/12/records/345": "/zones/{id}/records/{id}",
+		"/zones/12/34":          "/zones/{id}/{id}",
+		"/zones/example.com":    "/zones/example.com",
+		"/users/1a":             "/users/1a",
+	}
+
+	for path, expected := range tests {
+		if got := spanRoute(path); got != expected {
+			t.Errorf("spanRoute(%q) = %q, expected %q", path, got, expected)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package snitchdns
+
+import "context"
+
+// User is a SnitchDNS user account. Listing users requires an admin API key.
+type User struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	FullName string `json:"full_name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Admin    bool   `json:"admin"`
+	Active   bool   `json:"active"`
+}
+
+// ListUsers retrieves all user accounts
+func (c *Client) ListUsers(ctx context.Context) ([]User, error) {
+	var users []User
+	if err := c.doJSONRequest(ctx, "GET", "/users", nil, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
@@ -0,0 +1,30 @@
+package snitchdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestListUsers tests that user accounts are decoded
+func TestListUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users" {
+			t.Errorf("Expected path /users, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id": 1, "username": "admin", "admin": true, "active": true}, {"id": 7, "username": "alice", "email": "alice@example.com", "active": true}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	users, err := client.ListUsers(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(users) != 2 || users[1].ID != 7 || users[1].Username != "alice" || users[1].Admin {
+		t.Errorf("Unexpected users: %+v", users)
+	}
+}
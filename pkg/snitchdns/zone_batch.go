@@ -0,0 +1,103 @@
+package snitchdns
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultZoneBatchParallelism is the number of zones CreateZones creates and
+// DeleteZones deletes at the same time unless told otherwise
+const DefaultZoneBatchParallelism = DefaultParallelism
+
+// ZoneBatchResult is the outcome of creating one zone of a batch. Zone is
+// set when the zone was created, Err when it was not.
+type ZoneBatchResult struct {
+	Request CreateZoneRequest
+	Zone    *Zone
+	Err     error
+}
+
+// CreateZones creates many zones at once, one request each with at most
+// parallelism running at the same time, see RunParallel. Every zone is
+// attempted even when others fail, so callers can keep what succeeded.
+// Results are returned in the order of reqs, and zones not yet started when
+// ctx is cancelled fail with the context error. It works with any backend.
+func CreateZones(ctx context.Context, c ClientInterface, reqs []CreateZoneRequest, parallelism int) []ZoneBatchResult {
+	results := make([]ZoneBatchResult, len(reqs))
+	errs := RunParallel(ctx, len(reqs), parallelism, func(ctx context.Context, i int) error {
+		zone, err := c.CreateZoneWithContext(ctx, reqs[i])
+		results[i].Zone = zone
+		return err
+	})
+
+	for i, req := range reqs {
+		results[i].Request = req
+		results[i].Err = errs[i]
+	}
+	return results
+}
+
+// ZoneDeleteError is returned by DeleteZones when some zones could not be
+// deleted. It keeps the error of each of them, so callers can report and
+// keep track of the zones that are left.
+type ZoneDeleteError struct {
+	// Total is the number of zones DeleteZones was asked to delete
+	Total int
+
+	// Failed maps the IDs of the zones that were not deleted to their error
+	Failed map[string]error
+}
+
+// Error implements the error interface, listing the failed zones by ID
+func (e *ZoneDeleteError) Error() string {
+	ids := make([]string, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	failures := make([]string, len(ids))
+	for i, id := range ids {
+		failures[i] = fmt.Sprintf("zone %s: %s", id, e.Failed[id])
+	}
+	return fmt.Sprintf("failed to delete %d of %d zones: %s", len(e.Failed), e.Total, strings.Join(failures, "; "))
+}
+
+// Unwrap returns the errors of the failed zones, so StatusCode and
+// errors.Is see them
+func (e *ZoneDeleteError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// DeleteZones deletes many zones at once, such as every zone of an
+// engagement being torn down. Like CreateZones, the zones are deleted one
+// request each with RunParallel, and every zone is attempted even when
+// others fail. Zones that are already gone count as deleted, and
+// zones not yet started when ctx is cancelled fail with the context error.
+// The error is a *ZoneDeleteError naming every zone left, nil when all were
+// deleted. It works with any backend.
+func DeleteZones(ctx context.Context, c ClientInterface, ids []string, parallelism int) error {
+	errs := RunParallel(ctx, len(ids), parallelism, func(ctx context.Context, i int) error {
+		if err := c.DeleteZoneWithContext(ctx, ids[i]); err != nil && !IsNotFound(err) {
+			return err
+		}
+		return nil
+	})
+
+	failed := make(map[string]error)
+	for i, err := range errs {
+		if err != nil {
+			failed[ids[i]] = err
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return &ZoneDeleteError{Total: len(ids), Failed: failed}
+}
@@ -0,0 +1,149 @@
+package snitchdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCreateZones tests that zones are created in parallel up to the limit,
+// and that a failing zone does not stop the others
+func TestCreateZones(t *testing.T) {
+	var inFlight, maxInFlight, nextID int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		var req CreateZoneRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Domain == "taken.example.com" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"message": "Domain already exists"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Zone{ID: int(atomic.AddInt32(&nextID, 1)), Domain: req.Domain, Regex: req.Regex})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	domains := []string{"a.example.com", "b.example.com", "taken.example.com", "c.example.com", "d.example.com", "e.example.com"}
+	reqs := make([]CreateZoneRequest, len(domains))
+	for i, domain := range domains {
+		reqs[i] = CreateZoneRequest{Domain: domain, Active: true, Regex: true}
+	}
+
+	results := CreateZones(context.Background(), client, reqs, 2)
+	if len(results) != len(domains) {
+		t.Fatalf("Expected %d results, got %d", len(domains), len(results))
+	}
+	for i, result := range results {
+		if result.Request.Domain != domains[i] {
+			t.Errorf("Expected result %d for %s, got %s", i, domains[i], result.Request.Domain)
+		}
+		if domains[i] == "taken.example.com" {
+			if result.Err == nil || result.Zone != nil {
+				t.Errorf("Expected %s to fail, got %+v", domains[i], result)
+			}
+			continue
+		}
+		if result.Err != nil || result.Zone == nil || result.Zone.Domain != domains[i] {
+			t.Errorf("Expected %s to be created, got %+v", domains[i], result)
+		}
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+// TestCreateZonesCancelled tests that zones are not created after the
+// context is cancelled
+func TestCreateZonesCancelled(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := CreateZones(ctx, NewClient(server.URL, "test-key"), []CreateZoneRequest{{Domain: "a.example.com"}, {Domain: "b.example.com"}}, 0)
+	for _, result := range results {
+		if result.Err == nil {
+			t.Errorf("Expected %s to fail with the context error", result.Request.Domain)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("Expected no requests, got %d", requests)
+	}
+}
+
+// TestDeleteZones tests that zones are deleted in parallel up to the limit,
+// that zones already gone count as deleted, and that the zones left are
+// reported together
+func TestDeleteZones(t *testing.T) {
+	var inFlight, maxInFlight, requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		switch r.URL.Path {
+		case "/zones/3":
+			w.WriteHeader(http.StatusNotFound)
+		case "/zones/4", "/zones/6":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message": "Zone is locked"}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	err := DeleteZones(context.Background(), client, []string{"1", "2", "3", "4", "5", "6"}, 2)
+	var deleteErr *ZoneDeleteError
+	if !errors.As(err, &deleteErr) {
+		t.Fatalf("Expected a ZoneDeleteError, got %v", err)
+	}
+	if deleteErr.Total != 6 || len(deleteErr.Failed) != 2 || deleteErr.Failed["4"] == nil || deleteErr.Failed["6"] == nil {
+		t.Errorf("Expected zones 4 and 6 to fail, got %+v", deleteErr)
+	}
+	if !IsForbidden(err) || !strings.Contains(err.Error(), "failed to delete 2 of 6 zones: zone 4: ") {
+		t.Errorf("Expected the failures to be reported by zone, got %v", err)
+	}
+	if requests != 6 || maxInFlight > 2 {
+		t.Errorf("Expected 6 requests, at most 2 in flight, got %d and %d", requests, maxInFlight)
+	}
+
+	if err := DeleteZones(context.Background(), client, []string{"1", "3"}, 0); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
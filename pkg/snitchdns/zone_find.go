@@ -0,0 +1,70 @@
+package snitchdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrZoneNotFound is returned by FindZoneByDomain when no zone serves the domain
+var ErrZoneNotFound = errors.New("zone not found")
+
+// FindZoneByDomain returns the zone with the given domain from the zone
+// listing. Domains are compared case-insensitively and without a trailing
+// dot; regex zones are never matched. It works with any backend, since it
+// only needs the listing.
+func FindZoneByDomain(ctx context.Context, c ClientInterface, domain string) (*Zone, error) {
+	zones, err := c.ListZonesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	zone, ok := MatchZoneDomain(zones, domain)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrZoneNotFound, domain)
+	}
+	return zone, nil
+}
+
+// MatchZoneDomain finds the non-regex zone with the given domain in a listing
+func MatchZoneDomain(zones []Zone, domain string) (*Zone, bool) {
+	key := NormalizeZoneDomain(domain)
+	for i := range zones {
+		if !zones[i].Regex && NormalizeZoneDomain(zones[i].Domain) == key {
+			zone := zones[i]
+			return &zone, true
+		}
+	}
+	return nil, false
+}
+
+// NormalizeZoneDomain returns the form of a domain that equal domains share:
+// trimmed of surrounding space, lower-cased and without a trailing dot
+func NormalizeZoneDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// adoptRetriedZone returns the zone a retried creation of req failed with
+// err for, since the domain was taken, when an earlier attempt created it:
+// the request timed out or failed with a 5xx status after the server stored
+// the zone. Other errors, and duplicates after earlier attempts that cannot
+// have reached the server, such as a 429 response, are returned as they are.
+func adoptRetriedZone(ctx context.Context, c ClientInterface, req CreateZoneRequest, err error) (*Zone, error) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrCodeZoneExists || !apiErr.EarlierAttemptMayHaveApplied {
+		return nil, err
+	}
+
+	zones, listErr := c.ListZonesWithContext(ctx)
+	if listErr != nil {
+		return nil, err
+	}
+	domain := NormalizeZoneDomain(req.Domain)
+	for _, zone := range zones {
+		if zone.Regex == req.Regex && NormalizeZoneDomain(zone.Domain) == domain {
+			return &zone, nil
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,119 @@
+package snitchdns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFindZoneByDomain tests matching a domain against every page of the
+// zone listing
+func TestFindZoneByDomain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("page") == "1" {
+			w.Write([]byte(`{"page": 1, "pages": 2, "results": [
+				{"id": 1, "domain": ".*\\.example\\.com", "regex": true},
+				{"id": 2, "domain": "other.example.com"}
+			]}`))
+			return
+		}
+		w.Write([]byte(`{"page": 2, "pages": 2, "results": [{"id": 3, "domain": "Canary.Example.com."}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	zone, err := FindZoneByDomain(context.Background(), client, "canary.example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if zone.ID != 3 {
+		t.Errorf("Expected zone 3, got %d", zone.ID)
+	}
+
+	_, err = FindZoneByDomain(context.Background(), client, "missing.example.com")
+	if !errors.Is(err, ErrZoneNotFound) {
+		t.Errorf("Expected ErrZoneNotFound, got %v", err)
+	}
+}
+
+// TestCreateZoneAdoptsRetriedZone tests that a retried zone creation
+// finding the domain taken returns the zone an earlier attempt created,
+// while a duplicate on the first attempt is an error
+func TestCreateZoneAdoptsRetriedZone(t *testing.T) {
+	creates := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"page": 1, "pages": 1, "results": [{"id": 7, "domain": "Example.com."}]}`))
+			return
+		}
+		// The first attempt stores the zone but its response is lost
+		if creates.Add(1) == 1 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code": 5003, "message": "Domain already exists"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key",
+		WithRetry(2, time.Millisecond, 5*time.Millisecond),
+		WithIdempotentRetries(true),
+	)
+	zone, err := client.CreateZone(CreateZoneRequest{Domain: "example.com"})
+	if err != nil {
+		t.Fatalf("Expected the created zone to be adopted, got %v", err)
+	}
+	if zone.ID != 7 {
+		t.Errorf("Expected zone 7, got %d", zone.ID)
+	}
+
+	_, err = client.CreateZone(CreateZoneRequest{Domain: "example.com"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrCodeZoneExists {
+		t.Errorf("Expected a duplicate on the first attempt to fail, got %v", err)
+	}
+}
+
+// TestCreateZoneRateLimitedDuplicate tests that a duplicate after a 429
+// response is not adopted: the rate limited attempt was not handled, so the
+// zone belongs to someone else
+func TestCreateZoneRateLimitedDuplicate(t *testing.T) {
+	creates, listings := atomic.Int32{}, atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			listings.Add(1)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"page": 1, "pages": 1, "results": [{"id": 7, "domain": "example.com"}]}`))
+			return
+		}
+		if creates.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code": 5003, "message": "Domain already exists"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRetry(2, time.Millisecond, 5*time.Millisecond))
+	_, err := client.CreateZone(CreateZoneRequest{Domain: "example.com"})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrCodeZoneExists || apiErr.Attempt != 2 {
+		t.Fatalf("Expected the duplicate of the second attempt, got %v", err)
+	}
+	if apiErr.EarlierAttemptMayHaveApplied {
+		t.Error("Expected the rate limited attempt not to count as applied")
+	}
+	if listings.Load() != 0 {
+		t.Errorf("Expected no zone listing for adoption, got %d", listings.Load())
+	}
+}
@@ -0,0 +1,89 @@
+package snitchdns
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+)
+
+// Ways a zone answers a query name, as returned by MatchQueryZone
+const (
+	ZoneMatchExact    = "exact"
+	ZoneMatchRegex    = "regex"
+	ZoneMatchCatchAll = "catch_all"
+)
+
+// CompileZonePattern compiles the domain of a regex zone to match whole
+// names, case-insensitively. Errors are those of the pattern as written.
+func CompileZonePattern(domain string) (*regexp.Regexp, error) {
+	if _, err := syntax.Parse(domain, syntax.Perl); err != nil {
+		return nil, err
+	}
+	return regexp.Compile(`(?i)^(?:` + domain + `)$`)
+}
+
+// MatchQueryZone finds the zone of a listing that answers queries for name,
+// and how it matched. Inactive zones never answer. A zone with the name as
+// its domain answers first, then the regex zone with the lowest ID whose
+// pattern matches the name, then the catch-all zone of the closest parent
+// domain. Names are compared case-insensitively and without a trailing dot;
+// regex zones whose pattern does not compile are skipped.
+func MatchQueryZone(zones []Zone, name string) (*Zone, string, bool) {
+	name = NormalizeZoneDomain(name)
+
+	active := make([]Zone, 0, len(zones))
+	for _, zone := range zones {
+		if zone.Active {
+			active = append(active, zone)
+		}
+	}
+	slices.SortStableFunc(active, func(a, b Zone) int { return a.ID - b.ID })
+
+	if zone, ok := MatchZoneDomain(active, name); ok {
+		return zone, ZoneMatchExact, true
+	}
+
+	for i := range active {
+		if !active[i].Regex {
+			continue
+		}
+		pattern, err := CompileZonePattern(active[i].Domain)
+		if err == nil && pattern.MatchString(name) {
+			zone := active[i]
+			return &zone, ZoneMatchRegex, true
+		}
+	}
+
+	for parent := name; ; {
+		_, rest, ok := strings.Cut(parent, ".")
+		if !ok || rest == "" {
+			return nil, "", false
+		}
+		parent = rest
+		if zone, ok := MatchZoneDomain(active, parent); ok && zone.CatchAll {
+			return zone, ZoneMatchCatchAll, true
+		}
+	}
+}
+
+// MatchQueryRecord finds the active record of a zone's listing answering
+// queries of the given type and class, the one with the lowest ID when
+// several do. Types and classes are compared case-insensitively.
+func MatchQueryRecord(records []Record, recordType, class string) (*Record, bool) {
+	var match *Record
+	for i := range records {
+		record := &records[i]
+		if !record.Active || !strings.EqualFold(record.Type, recordType) || !strings.EqualFold(record.Class, class) {
+			continue
+		}
+		if match == nil || record.ID < match.ID {
+			match = record
+		}
+	}
+	if match == nil {
+		return nil, false
+	}
+	found := *match
+	return &found, true
+}
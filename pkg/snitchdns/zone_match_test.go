@@ -0,0 +1,61 @@
+package snitchdns
+
+import "testing"
+
+// TestMatchQueryZone tests the order in which exact, regex and catch-all
+// zones answer a name
+func TestMatchQueryZone(t *testing.T) {
+	zones := []Zone{
+		{ID: 7, Domain: "example.com", Active: true, CatchAll: true},
+		{ID: 6, Domain: `canary-[0-9]+\.example\.com`, Active: true, Regex: true},
+		{ID: 5, Domain: `canary-.*\.example\.com`, Active: true, Regex: true},
+		{ID: 4, Domain: "www.example.com", Active: true},
+		{ID: 3, Domain: "dev.example.com", Active: true},
+		{ID: 2, Domain: "old.example.com", CatchAll: true},
+		{ID: 1, Domain: "(broken", Active: true, Regex: true},
+	}
+
+	tests := []struct {
+		name  string
+		id    int
+		match string
+	}{
+		{"WWW.Example.com.", 4, ZoneMatchExact},
+		{"canary-1.example.com", 5, ZoneMatchRegex},
+		{"canary-a.example.com", 5, ZoneMatchRegex},
+		{"x.canary-1.example.com", 7, ZoneMatchCatchAll},
+		{"a.dev.example.com", 7, ZoneMatchCatchAll},
+		{"a.old.example.com", 7, ZoneMatchCatchAll},
+		{"example.org", 0, ""},
+		{"old.example.com", 7, ZoneMatchCatchAll},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone, match, ok := MatchQueryZone(zones, tt.name)
+			if ok != (tt.id != 0) {
+				t.Fatalf("Expected found %v, got %+v", tt.id != 0, zone)
+			}
+			if ok && (zone.ID != tt.id || match != tt.match) {
+				t.Errorf("Expected zone %d by %s, got zone %d by %s", tt.id, tt.match, zone.ID, match)
+			}
+		})
+	}
+}
+
+// TestMatchQueryRecord tests that the active record of the type and class
+// with the lowest ID answers
+func TestMatchQueryRecord(t *testing.T) {
+	records := []Record{
+		{ID: 4, Active: true, Class: "IN", Type: "A"},
+		{ID: 3, Active: true, Class: "IN", Type: "TXT"},
+		{ID: 2, Active: true, Class: "in", Type: "a"},
+		{ID: 1, Active: false, Class: "IN", Type: "A"},
+	}
+
+	if record, ok := MatchQueryRecord(records, "A", "IN"); !ok || record.ID != 2 {
+		t.Errorf("Expected record 2, got %+v", record)
+	}
+	if record, ok := MatchQueryRecord(records, "MX", "IN"); ok {
+		t.Errorf("Expected no MX record, got %+v", record)
+	}
+}
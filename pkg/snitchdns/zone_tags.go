@@ -0,0 +1,56 @@
+package snitchdns
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ZoneTags are the tags of a zone. The API takes them as a comma-separated
+// string and returns them as a list; ZoneTags encodes to the former and
+// decodes from either. Tags are trimmed, deduplicated and sorted, so their
+// order never differs between requests and responses.
+type ZoneTags []string
+
+// NewZoneTags returns the normalized tags
+func NewZoneTags(tags []string) ZoneTags {
+	seen := make(map[string]bool, len(tags))
+	normalized := ZoneTags{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// String returns the tags in the comma-separated form the API accepts
+func (t ZoneTags) String() string {
+	return strings.Join(NewZoneTags(t), ",")
+}
+
+// MarshalJSON encodes the tags as a comma-separated string
+func (t ZoneTags) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes tags from a list or a comma-separated string
+func (t *ZoneTags) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*t = NewZoneTags(list)
+		return nil
+	}
+
+	var joined string
+	if err := json.Unmarshal(data, &joined); err != nil {
+		return fmt.Errorf("decoding zone tags: expected a list or a string, got %s", data)
+	}
+	*t = NewZoneTags(strings.Split(joined, ","))
+	return nil
+}
@@ -0,0 +1,52 @@
+package snitchdns
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestZoneTagsUnmarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected ZoneTags
+	}{
+		{name: "list", input: `["web", "prod"]`, expected: ZoneTags{"prod", "web"}},
+		{name: "string", input: `"web, prod,web"`, expected: ZoneTags{"prod", "web"}},
+		{name: "empty string", input: `""`, expected: ZoneTags{}},
+		{name: "null", input: `null`, expected: ZoneTags{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tags ZoneTags
+			if err := json.Unmarshal([]byte(tt.input), &tags); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tags, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, tags)
+			}
+		})
+	}
+
+	var tags ZoneTags
+	if err := json.Unmarshal([]byte(`42`), &tags); err == nil {
+		t.Error("expected an error for a number")
+	}
+}
+
+func TestZoneTagsMarshal(t *testing.T) {
+	body, err := json.Marshal(CreateZoneRequest{Domain: "example.com", Tags: ZoneTags{"web", "prod", " web "}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded["tags"] != "prod,web" {
+		t.Errorf("expected tags %q, got %v", "prod,web", decoded["tags"])
+	}
+}
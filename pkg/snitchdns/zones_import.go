@@ -0,0 +1,139 @@
+package snitchdns
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// zonesCSVColumns are the columns of the SnitchDNS zone CSV format, in the
+// order WriteZonesCSV writes them
+var zonesCSVColumns = []string{"domain", "active", "catch_all", "forwarding", "regex", "master", "tags"}
+
+// ImportZonesCSV uploads zones in the SnitchDNS CSV format, as read by
+// ReadZonesCSV, to the zone import endpoint, which creates every zone in
+// the file for the API key's user. The server imports the whole file or,
+// on an invalid row or an existing domain, nothing.
+func (c *Client) ImportZonesCSV(ctx context.Context, csv io.Reader) error {
+	contentType, body, err := csvImportBody("zones.csv", csv)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRawRequestWithContext(ctx, "POST", "/zones/import", contentType, body)
+	return err
+}
+
+// ReadZonesCSV parses zones in the SnitchDNS CSV format. The header names
+// the columns, in any order: domain, which is required, and the optional
+// active, catch_all, forwarding, regex, master and tags, the latter
+// comma-separated. Unknown columns, such as the id of an export, are
+// ignored; missing booleans are false.
+func ReadZonesCSV(r io.Reader) ([]CreateZoneRequest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("zone CSV is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read zone CSV: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["domain"]; !ok {
+		return nil, errors.New("zone CSV has no domain column")
+	}
+
+	var zones []CreateZoneRequest
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return zones, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zone CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		zone, err := zoneFromCSVRow(columns, row)
+		if err != nil {
+			return nil, fmt.Errorf("zone CSV line %d: %w", line, err)
+		}
+		zones = append(zones, zone)
+	}
+}
+
+// WriteZonesCSV writes zones in the SnitchDNS CSV format, as accepted by
+// ImportZonesCSV
+func WriteZonesCSV(w io.Writer, zones []CreateZoneRequest) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(zonesCSVColumns); err != nil {
+		return err
+	}
+
+	for _, zone := range zones {
+		err := writer.Write([]string{
+			zone.Domain,
+			strconv.FormatBool(zone.Active),
+			strconv.FormatBool(zone.CatchAll),
+			strconv.FormatBool(zone.Forwarding),
+			strconv.FormatBool(zone.Regex),
+			strconv.FormatBool(zone.Master),
+			zone.Tags.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// zoneFromCSVRow converts a row of the zone CSV format
+func zoneFromCSVRow(columns map[string]int, row []string) (CreateZoneRequest, error) {
+	value := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	zone := CreateZoneRequest{Domain: value("domain")}
+	if zone.Domain == "" {
+		return zone, errors.New("domain is empty")
+	}
+
+	flags := map[string]*bool{
+		"active":     &zone.Active,
+		"catch_all":  &zone.CatchAll,
+		"forwarding": &zone.Forwarding,
+		"regex":      &zone.Regex,
+		"master":     &zone.Master,
+	}
+	for name, flag := range flags {
+		raw := value(name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return zone, fmt.Errorf("%s must be true or false, got %q", name, raw)
+		}
+		*flag = parsed
+	}
+
+	if tags := value("tags"); tags != "" {
+		zone.Tags = NewZoneTags(strings.Split(tags, ","))
+	}
+	return zone, nil
+}
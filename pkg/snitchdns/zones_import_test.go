@@ -0,0 +1,76 @@
+package snitchdns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestImportZonesCSV tests that the CSV is uploaded as a multipart file
+func TestImportZonesCSV(t *testing.T) {
+	csv := "domain,active,catch_all\nexample.com,true,false\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/zones/import" {
+			t.Errorf("Expected POST /zones/import, got %s %s", r.Method, r.URL.Path)
+		}
+
+		file, header, err := r.FormFile("csvfile")
+		if err != nil {
+			t.Fatalf("Expected a csvfile upload: %v", err)
+		}
+		content, _ := io.ReadAll(file)
+		if string(content) != csv || header.Filename != "zones.csv" {
+			t.Errorf("Unexpected upload %s: %q", header.Filename, content)
+		}
+
+		w.Write([]byte(`{"success": true, "message": "OK"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	if err := client.ImportZonesCSV(context.Background(), strings.NewReader(csv)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+// TestZonesCSV tests that zones round-trip through the CSV format and that
+// invalid documents are rejected
+func TestZonesCSV(t *testing.T) {
+	zones, err := ReadZonesCSV(strings.NewReader("id, Domain,active,catch_all,tags\n" +
+		"7,example.com,true,,\"b, a\"\n" +
+		"8,example.org,false,true,\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(zones) != 2 || zones[0].Domain != "example.com" || !zones[0].Active || zones[0].CatchAll ||
+		zones[0].Tags.String() != "a,b" || zones[1].Active || !zones[1].CatchAll {
+		t.Fatalf("Unexpected zones: %+v", zones)
+	}
+
+	var out strings.Builder
+	if err := WriteZonesCSV(&out, zones); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := "domain,active,catch_all,forwarding,regex,master,tags\n" +
+		"example.com,true,false,false,false,false,\"a,b\"\n" +
+		"example.org,false,true,false,false,false,\n"
+	if out.String() != want {
+		t.Errorf("Unexpected CSV:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"active\ntrue\n",
+		"domain,active\n,true\n",
+		"domain,active\nexample.com,yes please\n",
+	} {
+		if _, err := ReadZonesCSV(strings.NewReader(invalid)); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}